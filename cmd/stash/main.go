@@ -19,6 +19,11 @@ import (
 func main() {
 	defer recoverPanic()
 
+	// the scraper subcommand runs without initializing the server
+	if len(os.Args) > 1 && os.Args[1] == "scraper" {
+		os.Exit(runScraperCommand(os.Args[2:], os.Stdout))
+	}
+
 	_, err := manager.Initialize()
 	if err != nil {
 		panic(err)
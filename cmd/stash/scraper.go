@@ -0,0 +1,204 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+
+	"github.com/stashapp/stash/pkg/scraper"
+)
+
+const scraperTestUsage = `Usage: stash scraper test [--verbose] <scraper.yml> <tests.yml>
+
+Runs an xpath or json scraper against saved pages, without loading anything
+from the network. The tests file has the following format:
+
+tests:
+  - name: scene page
+    type: scene
+    # optional: mapped scraper name, or url used to select the byURL config
+    scraper: sceneScraper
+    url: https://example.com/scenes/1
+    # paths are relative to the tests file
+    fixture: fixtures/scene.html
+    expected: fixtures/scene.expected.yml
+`
+
+// scraperTests is the format of the scraper test file.
+type scraperTests struct {
+	Tests []scraperTest `yaml:"tests"`
+}
+
+type scraperTest struct {
+	Name     string `yaml:"name"`
+	Type     string `yaml:"type"`
+	Scraper  string `yaml:"scraper"`
+	URL      string `yaml:"url"`
+	Fixture  string `yaml:"fixture"`
+	Expected string `yaml:"expected"`
+}
+
+var errScraperTestFailed = errors.New("scraper tests failed")
+
+// runScraperCommand runs the scraper subcommand with the provided arguments.
+// Returns the exit code.
+func runScraperCommand(args []string, out io.Writer) int {
+	if len(args) == 0 || args[0] != "test" {
+		fmt.Fprint(out, scraperTestUsage)
+		return 2
+	}
+
+	flags := pflag.NewFlagSet("scraper test", pflag.ContinueOnError)
+	flags.SetOutput(out)
+	verbose := flags.BoolP("verbose", "v", false, "print the selector, post-process actions and values of each field")
+	flags.Usage = func() {
+		fmt.Fprint(out, scraperTestUsage)
+	}
+
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return 2
+	}
+
+	if err := runScraperTests(context.Background(), flags.Arg(0), flags.Arg(1), *verbose, out); err != nil {
+		fmt.Fprintf(out, "%v\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+func runScraperTests(ctx context.Context, configPath string, testsPath string, verbose bool, out io.Writer) error {
+	config, err := os.ReadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(testsPath)
+	if err != nil {
+		return err
+	}
+
+	var tests scraperTests
+	if err := yaml.Unmarshal(data, &tests); err != nil {
+		return fmt.Errorf("parsing %s: %w", testsPath, err)
+	}
+
+	if len(tests.Tests) == 0 {
+		return fmt.Errorf("no tests in %s", testsPath)
+	}
+
+	dir := filepath.Dir(testsPath)
+	failed := 0
+	for i, t := range tests.Tests {
+		name := t.Name
+		if name == "" {
+			name = fmt.Sprintf("test %d", i+1)
+		}
+
+		result, err := runScraperTest(ctx, config, dir, t)
+		if err != nil {
+			fmt.Fprintf(out, "FAIL %s: %v\n", name, err)
+			failed++
+			continue
+		}
+
+		if result.Valid {
+			fmt.Fprintf(out, "PASS %s\n", name)
+		} else {
+			fmt.Fprintf(out, "FAIL %s\n", name)
+			failed++
+		}
+
+		printScraperValidationResult(out, result, verbose)
+	}
+
+	fmt.Fprintf(out, "%d passed, %d failed\n", len(tests.Tests)-failed, failed)
+
+	if failed > 0 {
+		return errScraperTestFailed
+	}
+
+	return nil
+}
+
+func runScraperTest(ctx context.Context, config []byte, dir string, t scraperTest) (*scraper.ScraperValidationResult, error) {
+	resolve := func(p string) string {
+		if filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+
+	if t.Fixture == "" {
+		return nil, errors.New("fixture is required")
+	}
+
+	fixture, err := os.ReadFile(resolve(t.Fixture))
+	if err != nil {
+		return nil, err
+	}
+
+	var expected []byte
+	if t.Expected != "" {
+		expected, err = os.ReadFile(resolve(t.Expected))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return scraper.ValidateScraperConfig(ctx, bytes.NewReader(config), scraper.ValidationFixture{
+		Type:     scraper.ScrapeContentType(strings.ToUpper(t.Type)),
+		Scraper:  t.Scraper,
+		URL:      t.URL,
+		Document: string(fixture),
+		Expected: string(expected),
+	})
+}
+
+func printScraperValidationResult(out io.Writer, r *scraper.ScraperValidationResult, verbose bool) {
+	if verbose {
+		fmt.Fprintf(out, "  scraper: %s\n", r.Scraper)
+		for _, f := range r.Fields {
+			switch {
+			case f.Fixed != nil:
+				fmt.Fprintf(out, "  %s: fixed %q\n", f.Field, *f.Fixed)
+			case f.Selector != nil:
+				fmt.Fprintf(out, "  %s: %s\n", f.Field, *f.Selector)
+			}
+			for _, p := range f.PostProcess {
+				fmt.Fprintf(out, "      -> %s\n", p)
+			}
+			for _, v := range f.Values {
+				fmt.Fprintf(out, "      = %q\n", v)
+			}
+		}
+	}
+
+	for _, w := range r.Warnings {
+		fmt.Fprintf(out, "  warning: %s\n", w)
+	}
+
+	for _, d := range r.Differences {
+		fmt.Fprintf(out, "  %s: expected %s, got %s\n", d.Field, quoteOrMissing(d.Expected), quoteOrMissing(d.Actual))
+	}
+}
+
+func quoteOrMissing(s *string) string {
+	if s == nil {
+		return "<missing>"
+	}
+	return fmt.Sprintf("%q", *s)
+}
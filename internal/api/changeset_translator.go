@@ -276,3 +276,105 @@ func (t changesetTranslator) optionalBool(value *bool, field string) models.Opti
 
 	return models.NewOptionalBoolPtr(value)
 }
+
+// legacyURLs converts the value of a deprecated single url field into a list
+// of urls.
+func legacyURLs(value *string) []string {
+	if value == nil || *value == "" {
+		return []string{}
+	}
+
+	return []string{*value}
+}
+
+// inputURLs returns the urls of a create input. The deprecated url field is
+// used if the urls field is not set.
+func inputURLs(value []string, legacyValue *string) []string {
+	if value != nil {
+		return value
+	}
+
+	return legacyURLs(legacyValue)
+}
+
+// updateStrings returns an update setting the provided values, or nil if the
+// field is not set.
+func (t changesetTranslator) updateStrings(value []string, field string) *models.UpdateStrings {
+	if !t.hasField(field) {
+		return nil
+	}
+
+	if value == nil {
+		value = []string{}
+	}
+
+	return &models.UpdateStrings{
+		Values: value,
+		Mode:   models.RelationshipUpdateModeSet,
+	}
+}
+
+// updateStringsBulk returns the update from a bulk update input, or nil if
+// the field is not set.
+func (t changesetTranslator) updateStringsBulk(value *BulkUpdateStrings, field string) *models.UpdateStrings {
+	if !t.hasField(field) || value == nil {
+		return nil
+	}
+
+	values := value.Values
+	if values == nil {
+		values = []string{}
+	}
+
+	return &models.UpdateStrings{
+		Values: values,
+		Mode:   value.Mode,
+	}
+}
+
+// updateURLs returns the urls to set from an update input of the object with
+// the provided id. The deprecated url field replaces the first of the
+// existing urls if the urls field is not set. Returns nil if neither field is
+// set.
+func (t changesetTranslator) updateURLs(ctx context.Context, l models.URLLoader, id int, value []string, legacyValue *string) (*models.UpdateStrings, error) {
+	if ret := t.updateStrings(value, "urls"); ret != nil {
+		return ret, nil
+	}
+
+	return t.legacyURLUpdate(ctx, l, id, legacyValue)
+}
+
+// bulkUpdateURLs returns the urls to update from a bulk update input for the
+// object with the provided id. The deprecated url field replaces the first of
+// the existing urls if the urls field is not set. Returns nil if neither
+// field is set.
+func (t changesetTranslator) bulkUpdateURLs(ctx context.Context, l models.URLLoader, id int, value *BulkUpdateStrings, legacyValue *string) (*models.UpdateStrings, error) {
+	if ret := t.updateStringsBulk(value, "urls"); ret != nil {
+		return ret, nil
+	}
+
+	return t.legacyURLUpdate(ctx, l, id, legacyValue)
+}
+
+// legacyURLUpdate returns an update replacing the first of the existing urls
+// with the value of the deprecated url field, leaving the other urls
+// unchanged. The first url is removed if the value is empty. Returns nil if
+// the url field is not set.
+func (t changesetTranslator) legacyURLUpdate(ctx context.Context, l models.URLLoader, id int, value *string) (*models.UpdateStrings, error) {
+	if !t.hasField("url") {
+		return nil, nil
+	}
+
+	existing, err := l.GetURLs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var v string
+	if value != nil {
+		v = *value
+	}
+
+	first := func(string) bool { return true }
+	return t.updateStrings(replaceURL(existing, first, v), "url"), nil
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_changesetTranslator_updateURLs(t *testing.T) {
+	const sceneID = 1
+	existing := []string{"https://a.test", "https://b.test", "https://c.test"}
+
+	newURL := "https://new.test"
+	empty := ""
+
+	tests := []struct {
+		name     string
+		inputMap map[string]interface{}
+		urls     []string
+		url      *string
+		want     *models.UpdateStrings
+	}{
+		{
+			"neither set",
+			map[string]interface{}{},
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"urls set",
+			map[string]interface{}{"urls": nil, "url": nil},
+			[]string{newURL},
+			&empty,
+			&models.UpdateStrings{Values: []string{newURL}, Mode: models.RelationshipUpdateModeSet},
+		},
+		{
+			"url replaces first",
+			map[string]interface{}{"url": nil},
+			nil,
+			&newURL,
+			&models.UpdateStrings{Values: []string{newURL, "https://b.test", "https://c.test"}, Mode: models.RelationshipUpdateModeSet},
+		},
+		{
+			"empty url removes first",
+			map[string]interface{}{"url": nil},
+			nil,
+			&empty,
+			&models.UpdateStrings{Values: []string{"https://b.test", "https://c.test"}, Mode: models.RelationshipUpdateModeSet},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qb := &mocks.SceneReaderWriter{}
+			qb.On("GetURLs", testCtx, sceneID).Return(existing, nil)
+
+			translator := changesetTranslator{
+				inputMap: tt.inputMap,
+			}
+
+			got, err := translator.updateURLs(testCtx, qb, sceneID, tt.urls, tt.url)
+			if err != nil {
+				t.Errorf("changesetTranslator.updateURLs() error = %v", err)
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -53,6 +53,9 @@ func (r *Resolver) Mutation() MutationResolver {
 func (r *Resolver) Performer() PerformerResolver {
 	return &performerResolver{r}
 }
+func (r *Resolver) PerformerImage() PerformerImageResolver {
+	return &performerImageResolver{r}
+}
 func (r *Resolver) Query() QueryResolver {
 	return &queryResolver{r}
 }
@@ -65,6 +68,18 @@ func (r *Resolver) Image() ImageResolver {
 func (r *Resolver) SceneMarker() SceneMarkerResolver {
 	return &sceneMarkerResolver{r}
 }
+func (r *Resolver) JobRecord() JobRecordResolver {
+	return &jobRecordResolver{r}
+}
+func (r *Resolver) JobCheckpoint() JobCheckpointResolver {
+	return &jobCheckpointResolver{r}
+}
+func (r *Resolver) StashBoxSubmission() StashBoxSubmissionResolver {
+	return &stashBoxSubmissionResolver{r}
+}
+func (r *Resolver) ScenePendingChange() ScenePendingChangeResolver {
+	return &scenePendingChangeResolver{r}
+}
 func (r *Resolver) Studio() StudioResolver {
 	return &studioResolver{r}
 }
@@ -84,8 +99,13 @@ type subscriptionResolver struct{ *Resolver }
 
 type galleryResolver struct{ *Resolver }
 type performerResolver struct{ *Resolver }
+type performerImageResolver struct{ *Resolver }
 type sceneResolver struct{ *Resolver }
 type sceneMarkerResolver struct{ *Resolver }
+type scenePendingChangeResolver struct{ *Resolver }
+type jobRecordResolver struct{ *Resolver }
+type jobCheckpointResolver struct{ *Resolver }
+type stashBoxSubmissionResolver struct{ *Resolver }
 type imageResolver struct{ *Resolver }
 type studioResolver struct{ *Resolver }
 type movieResolver struct{ *Resolver }
@@ -177,6 +177,25 @@ func (r *galleryResolver) Date(ctx context.Context, obj *models.Gallery) (*strin
 	return nil, nil
 }
 
+func (r *galleryResolver) URL(ctx context.Context, obj *models.Gallery) (*string, error) {
+	urls, err := r.Urls(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return firstURL(urls), nil
+}
+
+func (r *galleryResolver) Urls(ctx context.Context, obj *models.Gallery) ([]string, error) {
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return obj.LoadURLs(ctx, r.repository.Gallery)
+	}); err != nil {
+		return nil, err
+	}
+
+	return obj.URLs.List(), nil
+}
+
 func (r *galleryResolver) Checksum(ctx context.Context, obj *models.Gallery) (string, error) {
 	if !obj.Files.PrimaryLoaded() {
 		if err := r.withTxn(ctx, func(ctx context.Context) error {
@@ -262,3 +281,16 @@ func (r *galleryResolver) ImageCount(ctx context.Context, obj *models.Gallery) (
 
 	return ret, nil
 }
+
+func (r *galleryResolver) CustomFields(ctx context.Context, obj *models.Gallery) (map[string]interface{}, error) {
+	var ret models.CustomFieldMap
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.repository.Gallery.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
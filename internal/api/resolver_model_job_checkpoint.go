@@ -0,0 +1,32 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *jobCheckpointResolver) Input(ctx context.Context, obj *models.JobCheckpoint) (*string, error) {
+	if obj.Input.Valid {
+		return &obj.Input.String, nil
+	}
+
+	return nil, nil
+}
+
+func (r *jobCheckpointResolver) Checkpoint(ctx context.Context, obj *models.JobCheckpoint) (*string, error) {
+	if obj.Checkpoint.Valid {
+		return &obj.Checkpoint.String, nil
+	}
+
+	return nil, nil
+}
+
+func (r *jobCheckpointResolver) AddTime(ctx context.Context, obj *models.JobCheckpoint) (*time.Time, error) {
+	return &obj.AddTime.Timestamp, nil
+}
+
+func (r *jobCheckpointResolver) UpdatedAt(ctx context.Context, obj *models.JobCheckpoint) (*time.Time, error) {
+	return &obj.UpdatedAt.Timestamp, nil
+}
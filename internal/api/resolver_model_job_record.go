@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *jobRecordResolver) Status(ctx context.Context, obj *models.JobRecord) (JobStatus, error) {
+	return JobStatus(obj.Status), nil
+}
+
+func (r *jobRecordResolver) Input(ctx context.Context, obj *models.JobRecord) (*string, error) {
+	if obj.Input.Valid {
+		return &obj.Input.String, nil
+	}
+
+	return nil, nil
+}
+
+func (r *jobRecordResolver) Error(ctx context.Context, obj *models.JobRecord) (*string, error) {
+	if obj.Error.Valid {
+		return &obj.Error.String, nil
+	}
+
+	return nil, nil
+}
+
+func (r *jobRecordResolver) Summary(ctx context.Context, obj *models.JobRecord) (*JobSummary, error) {
+	return &JobSummary{
+		Created: obj.Created,
+		Updated: obj.Updated,
+		Skipped: obj.Skipped,
+	}, nil
+}
+
+func (r *jobRecordResolver) StartTime(ctx context.Context, obj *models.JobRecord) (*time.Time, error) {
+	if obj.StartTime.Valid {
+		return &obj.StartTime.Timestamp, nil
+	}
+
+	return nil, nil
+}
+
+func (r *jobRecordResolver) EndTime(ctx context.Context, obj *models.JobRecord) (*time.Time, error) {
+	if obj.EndTime.Valid {
+		return &obj.EndTime.Timestamp, nil
+	}
+
+	return nil, nil
+}
+
+func (r *jobRecordResolver) AddTime(ctx context.Context, obj *models.JobRecord) (*time.Time, error) {
+	return &obj.AddTime.Timestamp, nil
+}
+
+func (r *jobRecordResolver) Logs(ctx context.Context, obj *models.JobRecord) ([]*LogEntry, error) {
+	var logs []models.JobRecordLog
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		var err error
+		logs, err = r.repository.JobRecord.GetLogs(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*LogEntry, len(logs))
+	for i, l := range logs {
+		ret[i] = &LogEntry{
+			Time:    l.Time.Timestamp,
+			Level:   getLogLevel(l.Level),
+			Message: l.Message,
+		}
+	}
+
+	return ret, nil
+}
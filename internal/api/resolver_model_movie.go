@@ -18,10 +18,23 @@ func (r *movieResolver) Name(ctx context.Context, obj *models.Movie) (string, er
 }
 
 func (r *movieResolver) URL(ctx context.Context, obj *models.Movie) (*string, error) {
-	if obj.URL.Valid {
-		return &obj.URL.String, nil
+	urls, err := r.Urls(ctx, obj)
+	if err != nil {
+		return nil, err
 	}
-	return nil, nil
+
+	return firstURL(urls), nil
+}
+
+func (r *movieResolver) Urls(ctx context.Context, obj *models.Movie) (ret []string, err error) {
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Movie.GetURLs(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
 }
 
 func (r *movieResolver) Aliases(ctx context.Context, obj *models.Movie) (*string, error) {
@@ -146,3 +159,16 @@ func (r *movieResolver) CreatedAt(ctx context.Context, obj *models.Movie) (*time
 func (r *movieResolver) UpdatedAt(ctx context.Context, obj *models.Movie) (*time.Time, error) {
 	return &obj.UpdatedAt.Timestamp, nil
 }
+
+func (r *movieResolver) CustomFields(ctx context.Context, obj *models.Movie) (map[string]interface{}, error) {
+	var ret models.CustomFieldMap
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.repository.Movie.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
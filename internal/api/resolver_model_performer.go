@@ -2,12 +2,15 @@ package api
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 
+	"github.com/stashapp/stash/internal/api/loaders"
 	"github.com/stashapp/stash/internal/api/urlbuilders"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 func (r *performerResolver) Height(ctx context.Context, obj *models.Performer) (*string, error) {
@@ -36,6 +39,31 @@ func (r *performerResolver) ImagePath(ctx context.Context, obj *models.Performer
 	return &imagePath, nil
 }
 
+func (r *performerResolver) Images(ctx context.Context, obj *models.Performer) (ret []*models.PerformerImage, err error) {
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Performer.GetImages(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *performerImageResolver) ImagePath(ctx context.Context, obj *models.PerformerImage) (string, error) {
+	p, err := loaders.From(ctx).PerformerByID.Load(obj.PerformerID)
+	if err != nil {
+		return "", err
+	}
+
+	if p == nil {
+		return "", fmt.Errorf("performer with id %d not found", obj.PerformerID)
+	}
+
+	baseURL, _ := ctx.Value(BaseURLCtxKey).(string)
+	return urlbuilders.NewPerformerURLBuilder(baseURL, p).GetPerformerImageURLByID(obj.ID), nil
+}
+
 func (r *performerResolver) Tags(ctx context.Context, obj *models.Performer) (ret []*models.Tag, err error) {
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.repository.Tag.FindByPerformerID(ctx, obj.ID)
@@ -94,6 +122,47 @@ func (r *performerResolver) Scenes(ctx context.Context, obj *models.Performer) (
 	return ret, nil
 }
 
+func (r *performerResolver) URL(ctx context.Context, obj *models.Performer) (*string, error) {
+	urls, err := r.Urls(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return firstURL(urls), nil
+}
+
+func (r *performerResolver) Urls(ctx context.Context, obj *models.Performer) (ret []string, err error) {
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Performer.GetURLs(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *performerResolver) Twitter(ctx context.Context, obj *models.Performer) (*string, error) {
+	return r.siteURL(ctx, obj, utils.TwitterURL)
+}
+
+func (r *performerResolver) Instagram(ctx context.Context, obj *models.Performer) (*string, error) {
+	return r.siteURL(ctx, obj, utils.InstagramURL)
+}
+
+func (r *performerResolver) siteURL(ctx context.Context, obj *models.Performer, siteURL string) (*string, error) {
+	urls, err := r.Urls(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	if ret := utils.URLForSite(urls, siteURL); ret != "" {
+		return &ret, nil
+	}
+
+	return nil, nil
+}
+
 func (r *performerResolver) StashIds(ctx context.Context, obj *models.Performer) ([]*models.StashID, error) {
 	var ret []models.StashID
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
@@ -149,3 +218,16 @@ func (r *performerResolver) MovieCount(ctx context.Context, obj *models.Performe
 
 	return &res, nil
 }
+
+func (r *performerResolver) CustomFields(ctx context.Context, obj *models.Performer) (map[string]interface{}, error) {
+	var ret models.CustomFieldMap
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.repository.Performer.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
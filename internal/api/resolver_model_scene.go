@@ -326,6 +326,35 @@ func stashIDsSliceToPtrSlice(v []models.StashID) []*models.StashID {
 	return ret
 }
 
+func (r *sceneResolver) URL(ctx context.Context, obj *models.Scene) (*string, error) {
+	urls, err := r.Urls(ctx, obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return firstURL(urls), nil
+}
+
+func (r *sceneResolver) Urls(ctx context.Context, obj *models.Scene) ([]string, error) {
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return obj.LoadURLs(ctx, r.repository.Scene)
+	}); err != nil {
+		return nil, err
+	}
+
+	return obj.URLs.List(), nil
+}
+
+// firstURL returns the first of the provided urls, for use by the deprecated
+// url fields. Returns nil if there are no urls.
+func firstURL(urls []string) *string {
+	if len(urls) == 0 {
+		return nil
+	}
+
+	return &urls[0]
+}
+
 func (r *sceneResolver) StashIds(ctx context.Context, obj *models.Scene) (ret []*models.StashID, err error) {
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		return obj.LoadStashIDs(ctx, r.repository.Scene)
@@ -336,6 +365,17 @@ func (r *sceneResolver) StashIds(ctx context.Context, obj *models.Scene) (ret []
 	return stashIDsSliceToPtrSlice(obj.StashIDs.List()), nil
 }
 
+func (r *sceneResolver) StashBoxChecks(ctx context.Context, obj *models.Scene) (ret []*models.SceneStashBoxCheck, err error) {
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.SceneStashBoxCheck.FindBySceneID(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (r *sceneResolver) Phash(ctx context.Context, obj *models.Scene) (*string, error) {
 	f, err := r.getPrimaryFile(ctx, obj)
 	if err != nil {
@@ -399,3 +439,16 @@ func (r *sceneResolver) InteractiveSpeed(ctx context.Context, obj *models.Scene)
 
 	return primaryFile.InteractiveSpeed, nil
 }
+
+func (r *sceneResolver) CustomFields(ctx context.Context, obj *models.Scene) (map[string]interface{}, error) {
+	var ret models.CustomFieldMap
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		var err error
+		ret, err = r.repository.Scene.GetCustomFields(ctx, obj.ID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
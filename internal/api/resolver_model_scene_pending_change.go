@@ -0,0 +1,31 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *scenePendingChangeResolver) Scene(ctx context.Context, obj *models.ScenePendingChange) (ret *models.Scene, err error) {
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.Scene.Find(ctx, obj.SceneID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *scenePendingChangeResolver) CurrentValue(ctx context.Context, obj *models.ScenePendingChange) (*string, error) {
+	if obj.CurrentValue.Valid {
+		return &obj.CurrentValue.String, nil
+	}
+
+	return nil, nil
+}
+
+func (r *scenePendingChangeResolver) CreatedAt(ctx context.Context, obj *models.ScenePendingChange) (*time.Time, error) {
+	return &obj.CreatedAt.Timestamp, nil
+}
@@ -0,0 +1,20 @@
+package api
+
+import (
+	"context"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *stashBoxSubmissionResolver) EntityType(ctx context.Context, obj *models.StashBoxSubmission) (StashBoxSubmissionEntityType, error) {
+	return StashBoxSubmissionEntityType(obj.EntityType), nil
+}
+
+func (r *stashBoxSubmissionResolver) Type(ctx context.Context, obj *models.StashBoxSubmission) (StashBoxSubmissionType, error) {
+	return StashBoxSubmissionType(obj.Type), nil
+}
+
+func (r *stashBoxSubmissionResolver) CreatedAt(ctx context.Context, obj *models.StashBoxSubmission) (*time.Time, error) {
+	return &obj.CreatedAt.Timestamp, nil
+}
@@ -158,6 +158,29 @@ func (r *mutationResolver) ConfigureGeneral(ctx context.Context, input ConfigGen
 		c.Set(config.ParallelTasks, *input.ParallelTasks)
 	}
 
+	if input.JobConcurrencyIo != nil {
+		c.Set(config.JobConcurrencyIO, *input.JobConcurrencyIo)
+	}
+
+	if input.JobConcurrencyCPU != nil {
+		c.Set(config.JobConcurrencyCPU, *input.JobConcurrencyCPU)
+	}
+
+	if input.JobConcurrencyNetwork != nil {
+		c.Set(config.JobConcurrencyNetwork, *input.JobConcurrencyNetwork)
+	}
+
+	if input.AutoResumeJobs != nil {
+		c.Set(config.AutoResumeJobs, *input.AutoResumeJobs)
+	}
+
+	if input.StashBoxFingerprintCheckInterval != nil {
+		if *input.StashBoxFingerprintCheckInterval < 0 {
+			return makeConfigGeneralResult(), fmt.Errorf("stash-box fingerprint check interval must not be negative")
+		}
+		c.Set(config.StashBoxFingerprintCheckInterval, *input.StashBoxFingerprintCheckInterval)
+	}
+
 	if input.PreviewAudio != nil {
 		c.Set(config.PreviewAudio, *input.PreviewAudio)
 	}
@@ -452,6 +475,44 @@ func (r *mutationResolver) ConfigureScraping(ctx context.Context, input ConfigSc
 		c.Set(config.ScraperCertCheck, input.ScraperCertCheck)
 	}
 
+	if input.CacheTTL != nil {
+		if *input.CacheTTL < 0 {
+			return makeConfigScrapingResult(), errors.New("cache TTL must not be negative")
+		}
+		c.Set(config.ScraperCacheTTL, *input.CacheTTL)
+	}
+
+	if input.CacheMaxSize != nil {
+		if *input.CacheMaxSize < 0 {
+			return makeConfigScrapingResult(), errors.New("cache max size must not be negative")
+		}
+		c.Set(config.ScraperCacheMaxSize, *input.CacheMaxSize)
+	}
+
+	// the rate limiter is recreated when the scraper cache is refreshed
+	if input.RateLimit != nil {
+		if *input.RateLimit < 0 {
+			return makeConfigScrapingResult(), errors.New("rate limit must not be negative")
+		}
+		c.Set(config.ScraperRateLimit, *input.RateLimit)
+		refreshScraperCache = true
+	}
+
+	if input.MaxConcurrency != nil {
+		if *input.MaxConcurrency < 0 {
+			return makeConfigScrapingResult(), errors.New("max concurrency must not be negative")
+		}
+		c.Set(config.ScraperMaxConcurrency, *input.MaxConcurrency)
+		refreshScraperCache = true
+	}
+
+	if input.MaxRetries != nil {
+		if *input.MaxRetries < 0 {
+			return makeConfigScrapingResult(), errors.New("max retries must not be negative")
+		}
+		c.Set(config.ScraperMaxRetries, *input.MaxRetries)
+	}
+
 	if refreshScraperCache {
 		manager.GetInstance().RefreshScraperCache()
 	}
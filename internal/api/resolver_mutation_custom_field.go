@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *mutationResolver) CustomFieldDefinitionCreate(ctx context.Context, input CustomFieldDefinitionCreateInput) (*models.CustomFieldDefinition, error) {
+	currentTime := time.Now()
+	newDefinition := models.CustomFieldDefinition{
+		EntityType: input.EntityType,
+		Name:       strings.TrimSpace(input.Name),
+		Type:       input.Type,
+		Options:    input.Options,
+		CreatedAt:  currentTime,
+		UpdatedAt:  currentTime,
+	}
+
+	if err := newDefinition.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.CustomFieldDefinition
+
+		existing, err := qb.FindByName(ctx, newDefinition.EntityType, newDefinition.Name)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return fmt.Errorf("custom field %q already exists for %s", newDefinition.Name, newDefinition.EntityType)
+		}
+
+		return qb.Create(ctx, &newDefinition)
+	}); err != nil {
+		return nil, err
+	}
+
+	return &newDefinition, nil
+}
+
+func (r *mutationResolver) CustomFieldDefinitionUpdate(ctx context.Context, input CustomFieldDefinitionUpdateInput) (*models.CustomFieldDefinition, error) {
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	translator := changesetTranslator{
+		inputMap: getUpdateInputMap(ctx),
+	}
+
+	var ret *models.CustomFieldDefinition
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.CustomFieldDefinition
+
+		ret, err = qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if ret == nil {
+			return fmt.Errorf("custom field definition with id %d not found", id)
+		}
+
+		if input.Name != nil {
+			name := strings.TrimSpace(*input.Name)
+			if name != ret.Name {
+				existing, err := qb.FindByName(ctx, ret.EntityType, name)
+				if err != nil {
+					return err
+				}
+				if existing != nil {
+					return fmt.Errorf("custom field %q already exists for %s", name, ret.EntityType)
+				}
+			}
+			ret.Name = name
+		}
+
+		if translator.hasField("options") {
+			ret.Options = input.Options
+		}
+
+		if err := ret.Validate(); err != nil {
+			return err
+		}
+
+		ret.UpdatedAt = time.Now()
+
+		return qb.Update(ctx, ret)
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) CustomFieldDefinitionDestroy(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		return r.repository.CustomFieldDefinition.Destroy(ctx, idInt)
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -54,12 +54,10 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 		PerformerIDs: models.NewRelatedIDs(performerIDs),
 		TagIDs:       models.NewRelatedIDs(tagIDs),
 		SceneIDs:     models.NewRelatedIDs(sceneIDs),
+		URLs:         models.NewRelatedStrings(inputURLs(input.Urls, input.URL)),
 		CreatedAt:    currentTime,
 		UpdatedAt:    currentTime,
 	}
-	if input.URL != nil {
-		newGallery.URL = *input.URL
-	}
 	if input.Details != nil {
 		newGallery.Details = *input.Details
 	}
@@ -88,6 +86,12 @@ func (r *mutationResolver) GalleryCreate(ctx context.Context, input GalleryCreat
 			return err
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newGallery.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -191,7 +195,10 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 	}
 
 	updatedGallery.Details = translator.optionalString(input.Details, "details")
-	updatedGallery.URL = translator.optionalString(input.URL, "url")
+	updatedGallery.URLs, err = translator.updateURLs(ctx, qb, galleryID, input.Urls, input.URL)
+	if err != nil {
+		return nil, err
+	}
 	updatedGallery.Date = translator.optionalDate(input.Date, "date")
 	updatedGallery.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
 	updatedGallery.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
@@ -254,6 +261,12 @@ func (r *mutationResolver) galleryUpdate(ctx context.Context, input models.Galle
 		return nil, err
 	}
 
+	if input.CustomFields != nil {
+		if err := qb.SetCustomFields(ctx, galleryID, *input.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
 	return gallery, nil
 }
 
@@ -266,7 +279,6 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 	updatedGallery := models.NewGalleryPartial()
 
 	updatedGallery.Details = translator.optionalString(input.Details, "details")
-	updatedGallery.URL = translator.optionalString(input.URL, "url")
 	updatedGallery.Date = translator.optionalDate(input.Date, "date")
 	updatedGallery.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
 	var err error
@@ -306,11 +318,22 @@ func (r *mutationResolver) BulkGalleryUpdate(ctx context.Context, input BulkGall
 		for _, galleryIDStr := range input.Ids {
 			galleryID, _ := strconv.Atoi(galleryIDStr)
 
+			updatedGallery.URLs, err = translator.bulkUpdateURLs(ctx, qb, galleryID, input.Urls, input.URL)
+			if err != nil {
+				return err
+			}
+
 			gallery, err := qb.UpdatePartial(ctx, galleryID, updatedGallery)
 			if err != nil {
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, galleryID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, gallery)
 		}
 
@@ -172,6 +172,12 @@ func (r *mutationResolver) imageUpdate(ctx context.Context, input ImageUpdateInp
 		return nil, err
 	}
 
+	if input.CustomFields != nil {
+		if err := qb.SetCustomFields(ctx, imageID, *input.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
 	return image, nil
 }
 
@@ -247,6 +253,12 @@ func (r *mutationResolver) BulkImageUpdate(ctx context.Context, input BulkImageU
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, imageID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, image)
 		}
 
@@ -21,3 +21,70 @@ func (r *mutationResolver) StopAllJobs(ctx context.Context) (bool, error) {
 	manager.GetInstance().JobManager.CancelAll()
 	return true, nil
 }
+
+func (r *mutationResolver) SetJobPriority(ctx context.Context, jobID string, priority int) (bool, error) {
+	idInt, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, err
+	}
+	manager.GetInstance().JobManager.SetPriority(idInt, priority)
+
+	return true, nil
+}
+
+func (r *mutationResolver) ReorderJob(ctx context.Context, jobID string, position int) (bool, error) {
+	idInt, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, err
+	}
+	manager.GetInstance().JobManager.ReorderJob(idInt, position)
+
+	return true, nil
+}
+
+func (r *mutationResolver) PauseJob(ctx context.Context, jobID string) (bool, error) {
+	idInt, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, err
+	}
+	manager.GetInstance().JobManager.PauseJob(idInt)
+
+	return true, nil
+}
+
+func (r *mutationResolver) ResumeJob(ctx context.Context, jobID string) (bool, error) {
+	idInt, err := strconv.Atoi(jobID)
+	if err != nil {
+		return false, err
+	}
+	manager.GetInstance().JobManager.ResumeJob(idInt)
+
+	return true, nil
+}
+
+func (r *mutationResolver) ResumeInterruptedJob(ctx context.Context, id string) (string, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return "", err
+	}
+
+	jobID, err := manager.GetInstance().ResumeInterruptedJob(ctx, idInt)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) DiscardInterruptedJob(ctx context.Context, id string) (bool, error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return false, err
+	}
+
+	if err := manager.GetInstance().DiscardInterruptedJob(ctx, idInt); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -96,6 +96,34 @@ func (r *mutationResolver) MetadataIdentify(ctx context.Context, input identify.
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) MetadataIdentifyGalleries(ctx context.Context, input identify.GalleryOptions) (string, error) {
+	t := manager.CreateIdentifyGalleriesJob(input)
+	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying galleries...", t)
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataIdentifyPerformers(ctx context.Context, input identify.PerformerOptions) (string, error) {
+	t := manager.CreateIdentifyPerformersJob(input)
+	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying performers...", t)
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataIdentifyStudios(ctx context.Context, input identify.StudioOptions) (string, error) {
+	t := manager.CreateIdentifyStudiosJob(input)
+	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying studios...", t)
+
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) MetadataIdentifyMovies(ctx context.Context, input identify.MovieOptions) (string, error) {
+	t := manager.CreateIdentifyMoviesJob(input)
+	jobID := manager.GetInstance().JobManager.Add(ctx, "Identifying movies...", t)
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) MetadataClean(ctx context.Context, input manager.CleanMetadataInput) (string, error) {
 	jobID := manager.GetInstance().Clean(ctx, input)
 	return strconv.Itoa(jobID), nil
@@ -133,7 +161,7 @@ func (r *mutationResolver) BackupDatabase(ctx context.Context, input BackupDatab
 		backupPath = database.DatabaseBackupPath(backupDirectoryPath)
 	}
 
-	err := database.Backup(backupPath)
+	err := mgr.BackupDatabase(ctx, backupPath)
 	if err != nil {
 		return nil, err
 	}
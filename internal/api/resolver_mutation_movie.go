@@ -96,10 +96,6 @@ func (r *mutationResolver) MovieCreate(ctx context.Context, input MovieCreateInp
 		newMovie.Synopsis = sql.NullString{String: *input.Synopsis, Valid: true}
 	}
 
-	if input.URL != nil {
-		newMovie.URL = sql.NullString{String: *input.URL, Valid: true}
-	}
-
 	// Start the transaction and save the movie
 	var movie *models.Movie
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
@@ -109,6 +105,10 @@ func (r *mutationResolver) MovieCreate(ctx context.Context, input MovieCreateInp
 			return err
 		}
 
+		if err := qb.UpdateURLs(ctx, movie.ID, inputURLs(input.Urls, input.URL)); err != nil {
+			return err
+		}
+
 		// update image table
 		if len(frontimageData) > 0 {
 			if err := qb.UpdateImages(ctx, movie.ID, frontimageData, backimageData); err != nil {
@@ -116,6 +116,12 @@ func (r *mutationResolver) MovieCreate(ctx context.Context, input MovieCreateInp
 			}
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, movie.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -172,17 +178,28 @@ func (r *mutationResolver) MovieUpdate(ctx context.Context, input MovieUpdateInp
 	updatedMovie.StudioID = translator.nullInt64FromString(input.StudioID, "studio_id")
 	updatedMovie.Director = translator.nullString(input.Director, "director")
 	updatedMovie.Synopsis = translator.nullString(input.Synopsis, "synopsis")
-	updatedMovie.URL = translator.nullString(input.URL, "url")
 
 	// Start the transaction and save the movie
 	var movie *models.Movie
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Movie
+
+		urls, err := translator.updateURLs(ctx, qb, updatedMovie.ID, input.Urls, input.URL)
+		if err != nil {
+			return err
+		}
+
 		movie, err = qb.Update(ctx, updatedMovie)
 		if err != nil {
 			return err
 		}
 
+		if urls != nil {
+			if err := qb.UpdateURLs(ctx, movie.ID, urls.Apply(nil)); err != nil {
+				return err
+			}
+		}
+
 		// update image table
 		if frontImageIncluded || backImageIncluded {
 			if !frontImageIncluded {
@@ -216,6 +233,12 @@ func (r *mutationResolver) MovieUpdate(ctx context.Context, input MovieUpdateInp
 			}
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, movie.ID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -244,6 +267,7 @@ func (r *mutationResolver) BulkMovieUpdate(ctx context.Context, input BulkMovieU
 	updatedMovie.Rating = translator.ratingConversion(input.Rating, input.Rating100)
 	updatedMovie.StudioID = translator.nullInt64FromString(input.StudioID, "studio_id")
 	updatedMovie.Director = translator.nullString(input.Director, "director")
+	urls := translator.updateStringsBulk(input.Urls, "urls")
 
 	ret := []*models.Movie{}
 
@@ -267,6 +291,23 @@ func (r *mutationResolver) BulkMovieUpdate(ctx context.Context, input BulkMovieU
 				return err
 			}
 
+			if urls != nil {
+				existingURLs, err := qb.GetURLs(ctx, movieID)
+				if err != nil {
+					return err
+				}
+
+				if err := qb.UpdateURLs(ctx, movieID, urls.Apply(existingURLs)); err != nil {
+					return err
+				}
+			}
+
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, movieID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, movie)
 		}
 
@@ -35,6 +35,121 @@ func stashIDPtrSliceToSlice(v []*models.StashID) []models.StashID {
 	return ret
 }
 
+// handleURL returns the url of a deprecated social media handle field.
+func handleURL(handle *string, siteURL string) string {
+	if handle == nil {
+		return ""
+	}
+
+	return utils.URLFromHandle(*handle, siteURL)
+}
+
+// performerInputURLs returns the urls of a performer create input. The
+// deprecated url, twitter and instagram fields are used if the urls field is
+// not set.
+func performerInputURLs(input PerformerCreateInput) []string {
+	if input.Urls != nil {
+		return input.Urls
+	}
+
+	ret := legacyURLs(input.URL)
+	for _, u := range []string{
+		handleURL(input.Twitter, utils.TwitterURL),
+		handleURL(input.Instagram, utils.InstagramURL),
+	} {
+		if u != "" {
+			ret = append(ret, u)
+		}
+	}
+
+	return ret
+}
+
+// replaceURL replaces the first of the urls that matches with the provided
+// value, appending the value if none match. The matching url is removed if
+// the value is empty.
+func replaceURL(urls []string, match func(string) bool, value string) []string {
+	var ret []string
+	replaced := false
+	for _, u := range urls {
+		if !replaced && match(u) {
+			replaced = true
+			if value != "" {
+				ret = append(ret, value)
+			}
+			continue
+		}
+
+		ret = append(ret, u)
+	}
+
+	if !replaced && value != "" {
+		ret = append(ret, value)
+	}
+
+	return ret
+}
+
+func isSiteURL(siteURL string) func(string) bool {
+	return func(u string) bool {
+		return utils.URLForSite([]string{u}, siteURL) != ""
+	}
+}
+
+// performerURLs applies the url fields of a performer update to the existing
+// urls of a performer. If the urls field is not set, the deprecated url,
+// twitter and instagram fields each replace the existing url for their site.
+// Returns false if none of the fields are set.
+func (t changesetTranslator) performerURLs(existing []string, urls *models.UpdateStrings, url, twitter, instagram *string) ([]string, bool) {
+	if urls != nil {
+		return urls.Apply(existing), true
+	}
+
+	isTwitter := isSiteURL(utils.TwitterURL)
+	isInstagram := isSiteURL(utils.InstagramURL)
+
+	ret := existing
+	set := false
+	if t.hasField("url") {
+		var v string
+		if url != nil {
+			v = *url
+		}
+		ret = replaceURL(ret, func(u string) bool {
+			return !isTwitter(u) && !isInstagram(u)
+		}, v)
+		set = true
+	}
+	if t.hasField("twitter") {
+		ret = replaceURL(ret, isTwitter, handleURL(twitter, utils.TwitterURL))
+		set = true
+	}
+	if t.hasField("instagram") {
+		ret = replaceURL(ret, isInstagram, handleURL(instagram, utils.InstagramURL))
+		set = true
+	}
+
+	return ret, set
+}
+
+// updatePerformerURLs applies the url fields of a performer update to the
+// stored urls of the performer.
+func (r *mutationResolver) updatePerformerURLs(ctx context.Context, translator changesetTranslator, performerID int, urls *models.UpdateStrings, url, twitter, instagram *string) error {
+	qb := r.repository.Performer
+
+	existing, err := qb.GetURLs(ctx, performerID)
+	if err != nil {
+		return err
+	}
+
+	newURLs, set := translator.performerURLs(existing, urls, url, twitter, instagram)
+	if !set {
+		return nil
+	}
+
+	return qb.UpdateURLs(ctx, performerID, newURLs)
+}
+
 func (r *mutationResolver) PerformerCreate(ctx context.Context, input PerformerCreateInput) (*models.Performer, error) {
 	// generate checksum from performer name rather than image
 	checksum := md5.FromString(input.Name)
@@ -58,8 +173,8 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input PerformerC
 		CreatedAt: currentTime,
 		UpdatedAt: currentTime,
 	}
-	if input.URL != nil {
-		newPerformer.URL = *input.URL
+	if input.Disambiguation != nil {
+		newPerformer.Disambiguation = *input.Disambiguation
 	}
 	if input.Gender != nil {
 		newPerformer.Gender = *input.Gender
@@ -105,12 +220,6 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input PerformerC
 	if input.Aliases != nil {
 		newPerformer.Aliases = *input.Aliases
 	}
-	if input.Twitter != nil {
-		newPerformer.Twitter = *input.Twitter
-	}
-	if input.Instagram != nil {
-		newPerformer.Instagram = *input.Instagram
-	}
 	if input.Favorite != nil {
 		newPerformer.Favorite = *input.Favorite
 	}
@@ -147,11 +256,20 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input PerformerC
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		qb := r.repository.Performer
 
+		const newPerformerID = 0
+		if err := performer.EnsureNameUnique(ctx, newPerformerID, newPerformer.Name, newPerformer.Disambiguation, qb); err != nil {
+			return err
+		}
+
 		err = qb.Create(ctx, &newPerformer)
 		if err != nil {
 			return err
 		}
 
+		if err := qb.UpdateURLs(ctx, newPerformer.ID, performerInputURLs(input)); err != nil {
+			return err
+		}
+
 		if len(input.TagIds) > 0 {
 			if err := r.updatePerformerTags(ctx, newPerformer.ID, input.TagIds); err != nil {
 				return err
@@ -173,6 +291,12 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input PerformerC
 			}
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, newPerformer.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -182,7 +306,7 @@ func (r *mutationResolver) PerformerCreate(ctx context.Context, input PerformerC
 	return r.getPerformer(ctx, newPerformer.ID)
 }
 
-func (r *mutationResolver) PerformerUpdate(ctx context.Context, input PerformerUpdateInput) (*models.Performer, error) {
+func (r *mutationResolver) PerformerUpdate(ctx context.Context, input models.PerformerUpdateInput) (*models.Performer, error) {
 	// Populate performer from the input
 	performerID, _ := strconv.Atoi(input.ID)
 	updatedPerformer := models.NewPerformerPartial()
@@ -209,7 +333,7 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input PerformerU
 		updatedPerformer.Checksum = models.NewOptionalString(checksum)
 	}
 
-	updatedPerformer.URL = translator.optionalString(input.URL, "url")
+	updatedPerformer.Disambiguation = translator.optionalString(input.Disambiguation, "disambiguation")
 
 	if translator.hasField("gender") {
 		if input.Gender != nil {
@@ -239,8 +363,6 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input PerformerU
 	updatedPerformer.Tattoos = translator.optionalString(input.Tattoos, "tattoos")
 	updatedPerformer.Piercings = translator.optionalString(input.Piercings, "piercings")
 	updatedPerformer.Aliases = translator.optionalString(input.Aliases, "aliases")
-	updatedPerformer.Twitter = translator.optionalString(input.Twitter, "twitter")
-	updatedPerformer.Instagram = translator.optionalString(input.Instagram, "instagram")
 	updatedPerformer.Favorite = translator.optionalBool(input.Favorite, "favorite")
 	updatedPerformer.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
 	updatedPerformer.Details = translator.optionalString(input.Details, "details")
@@ -269,11 +391,31 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input PerformerU
 			}
 		}
 
+		if updatedPerformer.Name.Set || updatedPerformer.Disambiguation.Set {
+			name := existing.Name
+			if updatedPerformer.Name.Set {
+				name = updatedPerformer.Name.Value
+			}
+			disambiguation := existing.Disambiguation
+			if updatedPerformer.Disambiguation.Set {
+				disambiguation = updatedPerformer.Disambiguation.Value
+			}
+
+			if err := performer.EnsureNameUnique(ctx, performerID, name, disambiguation, qb); err != nil {
+				return err
+			}
+		}
+
 		_, err = qb.UpdatePartial(ctx, performerID, updatedPerformer)
 		if err != nil {
 			return err
 		}
 
+		urls := translator.updateStrings(input.Urls, "urls")
+		if err := r.updatePerformerURLs(ctx, translator, performerID, urls, input.URL, input.Twitter, input.Instagram); err != nil {
+			return err
+		}
+
 		// Save the tags
 		if translator.hasField("tag_ids") {
 			if err := r.updatePerformerTags(ctx, performerID, input.TagIds); err != nil {
@@ -301,6 +443,12 @@ func (r *mutationResolver) PerformerUpdate(ctx context.Context, input PerformerU
 			}
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, performerID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -331,7 +479,6 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 
 	updatedPerformer := models.NewPerformerPartial()
 
-	updatedPerformer.URL = translator.optionalString(input.URL, "url")
 	updatedPerformer.Birthdate = translator.optionalDate(input.Birthdate, "birthdate")
 	updatedPerformer.Ethnicity = translator.optionalString(input.Ethnicity, "ethnicity")
 	updatedPerformer.Country = translator.optionalString(input.Country, "country")
@@ -352,8 +499,6 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 	updatedPerformer.Tattoos = translator.optionalString(input.Tattoos, "tattoos")
 	updatedPerformer.Piercings = translator.optionalString(input.Piercings, "piercings")
 	updatedPerformer.Aliases = translator.optionalString(input.Aliases, "aliases")
-	updatedPerformer.Twitter = translator.optionalString(input.Twitter, "twitter")
-	updatedPerformer.Instagram = translator.optionalString(input.Instagram, "instagram")
 	updatedPerformer.Favorite = translator.optionalBool(input.Favorite, "favorite")
 	updatedPerformer.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
 	updatedPerformer.Details = translator.optionalString(input.Details, "details")
@@ -398,6 +543,17 @@ func (r *mutationResolver) BulkPerformerUpdate(ctx context.Context, input BulkPe
 				return err
 			}
 
+			urls := translator.updateStringsBulk(input.Urls, "urls")
+			if err := r.updatePerformerURLs(ctx, translator, performerID, urls, input.URL, input.Twitter, input.Instagram); err != nil {
+				return err
+			}
+
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, performerID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, performer)
 
 			// Save the tags
@@ -476,3 +632,85 @@ func (r *mutationResolver) PerformersDestroy(ctx context.Context, performerIDs [
 
 	return true, nil
 }
+
+// modifyPerformerImages calls fn to modify the images of a performer and bumps
+// the performer's updated time, so that image paths change.
+func (r *mutationResolver) modifyPerformerImages(ctx context.Context, performerID string, input interface{}, fn func(ctx context.Context, qb models.PerformerReaderWriter, performerID int) error) (*models.Performer, error) {
+	id, err := strconv.Atoi(performerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.Performer
+
+		existing, err := qb.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if existing == nil {
+			return fmt.Errorf("performer with id %d not found", id)
+		}
+
+		if err := fn(ctx, qb, id); err != nil {
+			return err
+		}
+
+		updatedPerformer := models.NewPerformerPartial()
+		_, err = qb.UpdatePartial(ctx, id, updatedPerformer)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	r.hookExecutor.ExecutePostHooks(ctx, id, plugin.PerformerUpdatePost, input, []string{"images"})
+	return r.getPerformer(ctx, id)
+}
+
+func (r *mutationResolver) PerformerImageAdd(ctx context.Context, input PerformerImageAddInput) (*models.Performer, error) {
+	imageData, err := utils.ProcessImageInput(ctx, input.Image)
+	if err != nil {
+		return nil, err
+	}
+
+	primary := input.Primary != nil && *input.Primary
+
+	return r.modifyPerformerImages(ctx, input.PerformerID, input, func(ctx context.Context, qb models.PerformerReaderWriter, performerID int) error {
+		_, err := qb.AddImage(ctx, performerID, imageData, primary)
+		return err
+	})
+}
+
+func (r *mutationResolver) PerformerImageRemove(ctx context.Context, input PerformerImageRemoveInput) (*models.Performer, error) {
+	imageID, err := strconv.Atoi(input.ImageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.modifyPerformerImages(ctx, input.PerformerID, input, func(ctx context.Context, qb models.PerformerReaderWriter, performerID int) error {
+		return qb.DestroyImageByID(ctx, performerID, imageID)
+	})
+}
+
+func (r *mutationResolver) PerformerImageReorder(ctx context.Context, input PerformerImageReorderInput) (*models.Performer, error) {
+	imageIDs, err := stringslice.StringSliceToIntSlice(input.ImageIds)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.modifyPerformerImages(ctx, input.PerformerID, input, func(ctx context.Context, qb models.PerformerReaderWriter, performerID int) error {
+		return qb.ReorderImages(ctx, performerID, imageIDs)
+	})
+}
+
+func (r *mutationResolver) PerformerImageSetPrimary(ctx context.Context, input PerformerImageSetPrimaryInput) (*models.Performer, error) {
+	imageID, err := strconv.Atoi(input.ImageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.modifyPerformerImages(ctx, input.PerformerID, input, func(ctx context.Context, qb models.PerformerReaderWriter, performerID int) error {
+		return qb.SetPrimaryImage(ctx, performerID, imageID)
+	})
+}
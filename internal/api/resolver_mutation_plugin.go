@@ -2,23 +2,62 @@ package api
 
 import (
 	"context"
+	"strconv"
+	"strings"
 
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/plugin"
 )
 
 func (r *mutationResolver) RunPluginTask(ctx context.Context, pluginID string, taskName string, args []*plugin.PluginArgInput) (string, error) {
 	m := manager.GetInstance()
-	m.RunPluginTask(ctx, pluginID, taskName, args)
-	return "todo", nil
+	jobID := m.RunPluginTask(ctx, pluginID, taskName, args)
+	return strconv.Itoa(jobID), nil
 }
 
 func (r *mutationResolver) ReloadPlugins(ctx context.Context) (bool, error) {
-	err := manager.GetInstance().PluginCache.LoadPlugins()
+	m := manager.GetInstance()
+	err := m.PluginCache.LoadPlugins()
 	if err != nil {
 		logger.Errorf("Error reading plugin configs: %v", err)
 	}
 
+	// reload scrapers to pick up the scrapers provided by plugins
+	if err := m.ScraperCache.ReloadScrapers(); err != nil {
+		logger.Errorf("Error reading scraper configs: %v", err)
+	}
+
 	return true, nil
 }
+
+func (r *mutationResolver) ConfigurePlugin(ctx context.Context, pluginID string, input map[string]interface{}) (map[string]interface{}, error) {
+	pluginCache := manager.GetInstance().PluginCache
+
+	settings, err := pluginCache.ValidatePluginSettings(pluginID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	// merge with the existing values, so that omitted settings are unchanged
+	c := config.GetInstance()
+	existing := c.GetPluginConfiguration(pluginID)
+	for k := range input {
+		for ek := range existing {
+			if strings.EqualFold(k, ek) {
+				delete(existing, ek)
+			}
+		}
+	}
+	for k, v := range settings {
+		existing[k] = v
+	}
+
+	c.SetPluginConfiguration(pluginID, existing)
+	if err := c.Write(); err != nil {
+		return nil, err
+	}
+
+	return pluginCache.GetPluginSettings(pluginID)
+}
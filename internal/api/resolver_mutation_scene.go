@@ -69,7 +69,7 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input SceneCreateInp
 		Code:         translator.string(input.Code, "code"),
 		Details:      translator.string(input.Details, "details"),
 		Director:     translator.string(input.Director, "director"),
-		URL:          translator.string(input.URL, "url"),
+		URLs:         models.NewRelatedStrings(inputURLs(input.Urls, input.URL)),
 		Date:         translator.datePtr(input.Date, "date"),
 		Rating:       input.Rating,
 		Organized:    translator.bool(input.Organized, "organized"),
@@ -96,7 +96,17 @@ func (r *mutationResolver) SceneCreate(ctx context.Context, input SceneCreateInp
 
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
 		ret, err = r.Resolver.sceneService.Create(ctx, &newScene, fileIDs, coverImageData)
-		return err
+		if err != nil {
+			return err
+		}
+
+		if len(input.CustomFields) > 0 {
+			if err := r.repository.Scene.SetCustomFields(ctx, ret.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
+		return nil
 	}); err != nil {
 		return nil, err
 	}
@@ -170,7 +180,6 @@ func scenePartialFromInput(input models.SceneUpdateInput, translator changesetTr
 	updatedScene.Code = translator.optionalString(input.Code, "code")
 	updatedScene.Details = translator.optionalString(input.Details, "details")
 	updatedScene.Director = translator.optionalString(input.Director, "director")
-	updatedScene.URL = translator.optionalString(input.URL, "url")
 	updatedScene.Date = translator.optionalDate(input.Date, "date")
 	updatedScene.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
 	updatedScene.OCounter = translator.optionalInt(input.OCounter, "o_counter")
@@ -257,6 +266,11 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		return nil, err
 	}
 
+	updatedScene.URLs, err = translator.updateURLs(ctx, qb, sceneID, input.Urls, input.URL)
+	if err != nil {
+		return nil, err
+	}
+
 	// ensure that title is set where scene has no file
 	if updatedScene.Title.Set && updatedScene.Title.Value == "" {
 		if err := s.LoadFiles(ctx, r.repository.Scene); err != nil {
@@ -303,6 +317,12 @@ func (r *mutationResolver) sceneUpdate(ctx context.Context, input models.SceneUp
 		return nil, err
 	}
 
+	if input.CustomFields != nil {
+		if err := qb.SetCustomFields(ctx, sceneID, *input.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := r.sceneUpdateCoverImage(ctx, s, coverImageData); err != nil {
 		return nil, err
 	}
@@ -346,7 +366,6 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 	updatedScene.Code = translator.optionalString(input.Code, "code")
 	updatedScene.Details = translator.optionalString(input.Details, "details")
 	updatedScene.Director = translator.optionalString(input.Director, "director")
-	updatedScene.URL = translator.optionalString(input.URL, "url")
 	updatedScene.Date = translator.optionalDate(input.Date, "date")
 	updatedScene.Rating = translator.ratingConversionOptional(input.Rating, input.Rating100)
 	updatedScene.StudioID, err = translator.optionalIntFromString(input.StudioID, "studio_id")
@@ -392,11 +411,22 @@ func (r *mutationResolver) BulkSceneUpdate(ctx context.Context, input BulkSceneU
 		qb := r.repository.Scene
 
 		for _, sceneID := range sceneIDs {
+			updatedScene.URLs, err = translator.bulkUpdateURLs(ctx, qb, sceneID, input.Urls, input.URL)
+			if err != nil {
+				return err
+			}
+
 			scene, err := qb.UpdatePartial(ctx, sceneID, updatedScene)
 			if err != nil {
 				return err
 			}
 
+			if input.CustomFields != nil {
+				if err := qb.SetCustomFields(ctx, sceneID, *input.CustomFields); err != nil {
+					return err
+				}
+			}
+
 			ret = append(ret, scene)
 		}
 
@@ -614,12 +644,12 @@ func (r *mutationResolver) SceneMerge(ctx context.Context, input SceneMergeInput
 		return nil, fmt.Errorf("converting destination ID %s: %w", input.Destination, err)
 	}
 
+	translator := changesetTranslator{
+		inputMap: getNamedUpdateInputMap(ctx, "input.values"),
+	}
+
 	var values *models.ScenePartial
 	if input.Values != nil {
-		translator := changesetTranslator{
-			inputMap: getNamedUpdateInputMap(ctx, "input.values"),
-		}
-
 		values, err = scenePartialFromInput(*input.Values, translator)
 		if err != nil {
 			return nil, err
@@ -641,6 +671,13 @@ func (r *mutationResolver) SceneMerge(ctx context.Context, input SceneMergeInput
 
 	var ret *models.Scene
 	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		if input.Values != nil {
+			values.URLs, err = translator.updateURLs(ctx, r.repository.Scene, destID, input.Values.Urls, input.Values.URL)
+			if err != nil {
+				return err
+			}
+		}
+
 		if err := r.Resolver.sceneService.Merge(ctx, srcIDs, destID, *values); err != nil {
 			return err
 		}
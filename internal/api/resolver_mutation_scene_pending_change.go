@@ -0,0 +1,128 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// getScenePendingChanges returns the pending changes for the scene which
+// match the input fields. Returns all pending changes for the scene if no
+// fields are provided. Returns an error if any of the fields does not have a
+// pending change.
+func (r *mutationResolver) getScenePendingChanges(ctx context.Context, sceneID int, fields []string) ([]*models.ScenePendingChange, error) {
+	changes, err := r.repository.ScenePendingChange.FindBySceneID(ctx, sceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		return changes, nil
+	}
+
+	var ret []*models.ScenePendingChange
+	for _, f := range fields {
+		found := false
+		for _, c := range changes {
+			if c.Field == f {
+				ret = append(ret, c)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("no pending change for field %s of scene %d", f, sceneID)
+		}
+	}
+
+	return ret, nil
+}
+
+func (r *mutationResolver) ScenePendingChangesAccept(ctx context.Context, input ScenePendingChangesInput) (*models.Scene, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return nil, err
+	}
+
+	var updater *scene.UpdateSet
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		changes, err := r.getScenePendingChanges(ctx, sceneID, stringslice.StrUnique(input.Fields))
+		if err != nil {
+			return err
+		}
+
+		if len(changes) == 0 {
+			return fmt.Errorf("no pending changes for scene %d", sceneID)
+		}
+
+		updater, err = identify.PendingChangesUpdateSet(sceneID, changes)
+		if err != nil {
+			return err
+		}
+
+		if _, err := updater.Update(ctx, r.repository.Scene, &scene.PathsCoverSetter{
+			Paths:               manager.GetInstance().Paths,
+			FileNamingAlgorithm: config.GetInstance().GetVideoFileNamingAlgorithm(),
+		}); err != nil {
+			return err
+		}
+
+		for _, c := range changes {
+			if err := r.repository.ScenePendingChange.Destroy(ctx, c.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// fire post-update hooks
+	updateInput := updater.UpdateInput()
+	r.hookExecutor.ExecutePostHooks(ctx, sceneID, plugin.SceneUpdatePost, updateInput, utils.NotNilFields(updateInput, "json"))
+
+	return r.getScene(ctx, sceneID)
+}
+
+func (r *mutationResolver) ScenePendingChangesReject(ctx context.Context, input ScenePendingChangesInput) (bool, error) {
+	sceneID, err := strconv.Atoi(input.SceneID)
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.ScenePendingChange
+
+		if len(input.Fields) == 0 {
+			return qb.DestroyBySceneID(ctx, sceneID)
+		}
+
+		changes, err := r.getScenePendingChanges(ctx, sceneID, stringslice.StrUnique(input.Fields))
+		if err != nil {
+			return err
+		}
+
+		for _, c := range changes {
+			if err := qb.Destroy(ctx, c.ID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
@@ -2,8 +2,12 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/stashapp/stash/internal/manager"
+	"github.com/stashapp/stash/internal/manager/config"
 )
 
 func (r *mutationResolver) ReloadScrapers(ctx context.Context) (bool, error) {
@@ -15,3 +19,46 @@ func (r *mutationResolver) ReloadScrapers(ctx context.Context) (bool, error) {
 
 	return true, nil
 }
+
+func (r *mutationResolver) ClearScraperCache(ctx context.Context) (bool, error) {
+	if err := manager.GetInstance().ScraperCache.ResponseCache().Clear(); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *mutationResolver) ConfigureScraperSecrets(ctx context.Context, scraperID string, input map[string]interface{}) ([]string, error) {
+	c := config.GetInstance()
+	secrets := c.GetScraperSecrets(scraperID)
+	for k, v := range input {
+		// secret names are case-insensitive
+		name := strings.ToLower(k)
+		if v == nil {
+			delete(secrets, name)
+			continue
+		}
+
+		str, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: value of secret %s must be a string", ErrInput, k)
+		}
+		secrets[name] = str
+	}
+
+	c.SetScraperSecrets(scraperID, secrets)
+	if err := c.Write(); err != nil {
+		return nil, err
+	}
+
+	// log in again using the new secrets
+	manager.GetInstance().ScraperCache.ResetSession(scraperID)
+
+	ret := []string{}
+	for k := range secrets {
+		ret = append(ret, k)
+	}
+	sort.Strings(ret)
+
+	return ret, nil
+}
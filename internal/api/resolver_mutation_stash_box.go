@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/internal/manager/config"
+	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
 )
 
@@ -26,7 +28,7 @@ func (r *mutationResolver) SubmitStashBoxFingerprints(ctx context.Context, input
 		return false, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
 	}
 
-	client := stashbox.NewClient(*boxes[input.StashBoxIndex], r.txnManager, r.stashboxRepository())
+	client := stashbox.NewClient(*boxes[input.StashBoxIndex], r.txnManager, r.stashboxRepository(), r.scraperCache().ResponseCache())
 
 	return client.SubmitStashBoxFingerprints(ctx, input.SceneIds, boxes[input.StashBoxIndex].Endpoint)
 }
@@ -36,6 +38,20 @@ func (r *mutationResolver) StashBoxBatchPerformerTag(ctx context.Context, input
 	return strconv.Itoa(jobID), nil
 }
 
+func (r *mutationResolver) StashBoxBatchStudioTag(ctx context.Context, input manager.StashBoxBatchStudioTagInput) (string, error) {
+	jobID := manager.GetInstance().StashBoxBatchStudioTag(ctx, input)
+	return strconv.Itoa(jobID), nil
+}
+
+func (r *mutationResolver) StashBoxFingerprintCheck(ctx context.Context, input manager.StashBoxFingerprintCheckInput) (string, error) {
+	jobID, err := manager.GetInstance().StashBoxFingerprintCheck(ctx, input)
+	if err != nil {
+		return "", err
+	}
+
+	return strconv.Itoa(jobID), nil
+}
+
 func (r *mutationResolver) SubmitStashBoxSceneDraft(ctx context.Context, input StashBoxDraftSubmissionInput) (*string, error) {
 	boxes := config.GetInstance().GetStashBoxes()
 
@@ -43,7 +59,7 @@ func (r *mutationResolver) SubmitStashBoxSceneDraft(ctx context.Context, input S
 		return nil, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
 	}
 
-	client := stashbox.NewClient(*boxes[input.StashBoxIndex], r.txnManager, r.stashboxRepository())
+	client := stashbox.NewClient(*boxes[input.StashBoxIndex], r.txnManager, r.stashboxRepository(), r.scraperCache().ResponseCache())
 
 	id, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -61,7 +77,11 @@ func (r *mutationResolver) SubmitStashBoxSceneDraft(ctx context.Context, input S
 		filepath := manager.GetInstance().Paths.Scene.GetScreenshotPath(scene.GetHash(config.GetInstance().GetVideoFileNamingAlgorithm()))
 
 		res, err = client.SubmitSceneDraft(ctx, scene, boxes[input.StashBoxIndex].Endpoint, filepath)
-		return err
+		if err != nil {
+			return err
+		}
+
+		return r.recordStashBoxSubmission(ctx, boxes[input.StashBoxIndex].Endpoint, models.StashBoxSubmissionEntityScene, id, models.StashBoxSubmissionTypeDraft, res)
 	})
 
 	return res, err
@@ -74,7 +94,7 @@ func (r *mutationResolver) SubmitStashBoxPerformerDraft(ctx context.Context, inp
 		return nil, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
 	}
 
-	client := stashbox.NewClient(*boxes[input.StashBoxIndex], r.txnManager, r.stashboxRepository())
+	client := stashbox.NewClient(*boxes[input.StashBoxIndex], r.txnManager, r.stashboxRepository(), r.scraperCache().ResponseCache())
 
 	id, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -90,8 +110,142 @@ func (r *mutationResolver) SubmitStashBoxPerformerDraft(ctx context.Context, inp
 		}
 
 		res, err = client.SubmitPerformerDraft(ctx, performer, boxes[input.StashBoxIndex].Endpoint)
-		return err
+		if err != nil {
+			return err
+		}
+
+		return r.recordStashBoxSubmission(ctx, boxes[input.StashBoxIndex].Endpoint, models.StashBoxSubmissionEntityPerformer, id, models.StashBoxSubmissionTypeDraft, res)
+	})
+
+	return res, err
+}
+
+func (r *mutationResolver) SubmitStashBoxStudioDraft(ctx context.Context, input StashBoxEditSubmissionInput) (*string, error) {
+	boxes := config.GetInstance().GetStashBoxes()
+
+	if input.StashBoxIndex < 0 || input.StashBoxIndex >= len(boxes) {
+		return nil, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
+	}
+
+	client := r.stashBoxSubmissionClient(boxes[input.StashBoxIndex])
+
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *string
+	err = r.withTxn(ctx, func(ctx context.Context) error {
+		studio, err := r.repository.Studio.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if studio == nil {
+			return fmt.Errorf("studio with id %d not found", id)
+		}
+
+		res, err = client.SubmitStudioDraft(ctx, studio, boxes[input.StashBoxIndex].Endpoint, input.Comment)
+		if err != nil {
+			return err
+		}
+
+		return r.recordStashBoxSubmission(ctx, boxes[input.StashBoxIndex].Endpoint, models.StashBoxSubmissionEntityStudio, id, models.StashBoxSubmissionTypeEdit, res)
+	})
+
+	return res, err
+}
+
+func (r *mutationResolver) SubmitStashBoxTagDraft(ctx context.Context, input StashBoxEditSubmissionInput) (*string, error) {
+	boxes := config.GetInstance().GetStashBoxes()
+
+	if input.StashBoxIndex < 0 || input.StashBoxIndex >= len(boxes) {
+		return nil, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
+	}
+
+	client := r.stashBoxSubmissionClient(boxes[input.StashBoxIndex])
+
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *string
+	err = r.withTxn(ctx, func(ctx context.Context) error {
+		tag, err := r.repository.Tag.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if tag == nil {
+			return fmt.Errorf("tag with id %d not found", id)
+		}
+
+		res, err = client.SubmitTagDraft(ctx, tag, input.Comment)
+		if err != nil {
+			return err
+		}
+
+		return r.recordStashBoxSubmission(ctx, boxes[input.StashBoxIndex].Endpoint, models.StashBoxSubmissionEntityTag, id, models.StashBoxSubmissionTypeEdit, res)
+	})
+
+	return res, err
+}
+
+func (r *mutationResolver) SubmitStashBoxSceneEdit(ctx context.Context, input StashBoxEditSubmissionInput) (*string, error) {
+	boxes := config.GetInstance().GetStashBoxes()
+
+	if input.StashBoxIndex < 0 || input.StashBoxIndex >= len(boxes) {
+		return nil, fmt.Errorf("invalid stash_box_index %d", input.StashBoxIndex)
+	}
+
+	client := r.stashBoxSubmissionClient(boxes[input.StashBoxIndex])
+
+	id, err := strconv.Atoi(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *string
+	err = r.withTxn(ctx, func(ctx context.Context) error {
+		scene, err := r.repository.Scene.Find(ctx, id)
+		if err != nil {
+			return err
+		}
+		if scene == nil {
+			return fmt.Errorf("scene with id %d not found", id)
+		}
+
+		res, err = client.SubmitSceneEdit(ctx, scene, boxes[input.StashBoxIndex].Endpoint, input.Comment)
+		if err != nil {
+			return err
+		}
+
+		return r.recordStashBoxSubmission(ctx, boxes[input.StashBoxIndex].Endpoint, models.StashBoxSubmissionEntityScene, id, models.StashBoxSubmissionTypeEdit, res)
 	})
 
 	return res, err
 }
+
+// recordStashBoxSubmission adds the submission with the returned remote ID
+// to the submission history. Does nothing if no ID was returned.
+// stashBoxSubmissionClient returns a client for submitting edits. Responses
+// are not cached, since edits are compared against the current stash-box
+// objects.
+func (r *mutationResolver) stashBoxSubmissionClient(box *models.StashBox) *stashbox.Client {
+	return stashbox.NewClient(*box, r.txnManager, r.stashboxRepository(), nil)
+}
+
+func (r *mutationResolver) recordStashBoxSubmission(ctx context.Context, endpoint string, entityType string, entityID int, submissionType string, remoteID *string) error {
+	if remoteID == nil {
+		return nil
+	}
+
+	_, err := r.repository.StashBoxSubmission.Create(ctx, models.StashBoxSubmission{
+		Endpoint:   endpoint,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Type:       submissionType,
+		RemoteID:   *remoteID,
+		CreatedAt:  models.SQLiteTimestamp{Timestamp: time.Now()},
+	})
+	return err
+}
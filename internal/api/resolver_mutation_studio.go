@@ -113,6 +113,12 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input StudioCreateI
 			}
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, s.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
@@ -122,7 +128,7 @@ func (r *mutationResolver) StudioCreate(ctx context.Context, input StudioCreateI
 	return r.getStudio(ctx, s.ID)
 }
 
-func (r *mutationResolver) StudioUpdate(ctx context.Context, input StudioUpdateInput) (*models.Studio, error) {
+func (r *mutationResolver) StudioUpdate(ctx context.Context, input models.StudioUpdateInput) (*models.Studio, error) {
 	// Populate studio from the input
 	studioID, err := strconv.Atoi(input.ID)
 	if err != nil {
@@ -205,6 +211,12 @@ func (r *mutationResolver) StudioUpdate(ctx context.Context, input StudioUpdateI
 			}
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, studioID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	}); err != nil {
 		return nil, err
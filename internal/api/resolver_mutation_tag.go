@@ -103,6 +103,12 @@ func (r *mutationResolver) TagCreate(ctx context.Context, input TagCreateInput)
 			}
 		}
 
+		if len(input.CustomFields) > 0 {
+			if err := qb.SetCustomFields(ctx, t.ID, models.CustomFieldsInput{Full: input.CustomFields}); err != nil {
+				return err
+			}
+		}
+
 		if len(parentIDs) > 0 {
 			if err := qb.UpdateParentTags(ctx, t.ID, parentIDs); err != nil {
 				return err
@@ -229,6 +235,12 @@ func (r *mutationResolver) TagUpdate(ctx context.Context, input TagUpdateInput)
 			}
 		}
 
+		if input.CustomFields != nil {
+			if err := qb.SetCustomFields(ctx, tagID, *input.CustomFields); err != nil {
+				return err
+			}
+		}
+
 		if parentIDs != nil {
 			if err := qb.UpdateParentTags(ctx, tagID, parentIDs); err != nil {
 				return err
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/fsutil"
@@ -83,46 +84,51 @@ func makeConfigGeneralResult() *ConfigGeneralResult {
 	scraperCDPPath := config.GetScraperCDPPath()
 
 	return &ConfigGeneralResult{
-		Stashes:                      config.GetStashPaths(),
-		DatabasePath:                 config.GetDatabasePath(),
-		BackupDirectoryPath:          config.GetBackupDirectoryPath(),
-		GeneratedPath:                config.GetGeneratedPath(),
-		MetadataPath:                 config.GetMetadataPath(),
-		ConfigFilePath:               config.GetConfigFile(),
-		ScrapersPath:                 config.GetScrapersPath(),
-		CachePath:                    config.GetCachePath(),
-		CalculateMd5:                 config.IsCalculateMD5(),
-		VideoFileNamingAlgorithm:     config.GetVideoFileNamingAlgorithm(),
-		ParallelTasks:                config.GetParallelTasks(),
-		PreviewAudio:                 config.GetPreviewAudio(),
-		PreviewSegments:              config.GetPreviewSegments(),
-		PreviewSegmentDuration:       config.GetPreviewSegmentDuration(),
-		PreviewExcludeStart:          config.GetPreviewExcludeStart(),
-		PreviewExcludeEnd:            config.GetPreviewExcludeEnd(),
-		PreviewPreset:                config.GetPreviewPreset(),
-		MaxTranscodeSize:             &maxTranscodeSize,
-		MaxStreamingTranscodeSize:    &maxStreamingTranscodeSize,
-		WriteImageThumbnails:         config.IsWriteImageThumbnails(),
-		APIKey:                       config.GetAPIKey(),
-		Username:                     config.GetUsername(),
-		Password:                     config.GetPasswordHash(),
-		MaxSessionAge:                config.GetMaxSessionAge(),
-		LogFile:                      &logFile,
-		LogOut:                       config.GetLogOut(),
-		LogLevel:                     config.GetLogLevel(),
-		LogAccess:                    config.GetLogAccess(),
-		VideoExtensions:              config.GetVideoExtensions(),
-		ImageExtensions:              config.GetImageExtensions(),
-		GalleryExtensions:            config.GetGalleryExtensions(),
-		CreateGalleriesFromFolders:   config.GetCreateGalleriesFromFolders(),
-		Excludes:                     config.GetExcludes(),
-		ImageExcludes:                config.GetImageExcludes(),
-		CustomPerformerImageLocation: &customPerformerImageLocation,
-		ScraperUserAgent:             &scraperUserAgent,
-		ScraperCertCheck:             config.GetScraperCertCheck(),
-		ScraperCDPPath:               &scraperCDPPath,
-		StashBoxes:                   config.GetStashBoxes(),
-		PythonPath:                   config.GetPythonPath(),
+		Stashes:                          config.GetStashPaths(),
+		DatabasePath:                     config.GetDatabasePath(),
+		BackupDirectoryPath:              config.GetBackupDirectoryPath(),
+		GeneratedPath:                    config.GetGeneratedPath(),
+		MetadataPath:                     config.GetMetadataPath(),
+		ConfigFilePath:                   config.GetConfigFile(),
+		ScrapersPath:                     config.GetScrapersPath(),
+		CachePath:                        config.GetCachePath(),
+		CalculateMd5:                     config.IsCalculateMD5(),
+		VideoFileNamingAlgorithm:         config.GetVideoFileNamingAlgorithm(),
+		ParallelTasks:                    config.GetParallelTasks(),
+		JobConcurrencyIo:                 config.GetJobConcurrencyIO(),
+		JobConcurrencyCPU:                config.GetJobConcurrencyCPU(),
+		JobConcurrencyNetwork:            config.GetJobConcurrencyNetwork(),
+		AutoResumeJobs:                   config.GetAutoResumeJobs(),
+		StashBoxFingerprintCheckInterval: int(config.GetStashBoxFingerprintCheckInterval().Hours()),
+		PreviewAudio:                     config.GetPreviewAudio(),
+		PreviewSegments:                  config.GetPreviewSegments(),
+		PreviewSegmentDuration:           config.GetPreviewSegmentDuration(),
+		PreviewExcludeStart:              config.GetPreviewExcludeStart(),
+		PreviewExcludeEnd:                config.GetPreviewExcludeEnd(),
+		PreviewPreset:                    config.GetPreviewPreset(),
+		MaxTranscodeSize:                 &maxTranscodeSize,
+		MaxStreamingTranscodeSize:        &maxStreamingTranscodeSize,
+		WriteImageThumbnails:             config.IsWriteImageThumbnails(),
+		APIKey:                           config.GetAPIKey(),
+		Username:                         config.GetUsername(),
+		Password:                         config.GetPasswordHash(),
+		MaxSessionAge:                    config.GetMaxSessionAge(),
+		LogFile:                          &logFile,
+		LogOut:                           config.GetLogOut(),
+		LogLevel:                         config.GetLogLevel(),
+		LogAccess:                        config.GetLogAccess(),
+		VideoExtensions:                  config.GetVideoExtensions(),
+		ImageExtensions:                  config.GetImageExtensions(),
+		GalleryExtensions:                config.GetGalleryExtensions(),
+		CreateGalleriesFromFolders:       config.GetCreateGalleriesFromFolders(),
+		Excludes:                         config.GetExcludes(),
+		ImageExcludes:                    config.GetImageExcludes(),
+		CustomPerformerImageLocation:     &customPerformerImageLocation,
+		ScraperUserAgent:                 &scraperUserAgent,
+		ScraperCertCheck:                 config.GetScraperCertCheck(),
+		ScraperCDPPath:                   &scraperCDPPath,
+		StashBoxes:                       config.GetStashBoxes(),
+		PythonPath:                       config.GetPythonPath(),
 	}
 }
 
@@ -203,6 +209,11 @@ func makeConfigScrapingResult() *ConfigScrapingResult {
 		ScraperCertCheck:   config.GetScraperCertCheck(),
 		ScraperCDPPath:     &scraperCDPPath,
 		ExcludeTagPatterns: config.GetScraperExcludeTagPatterns(),
+		CacheTTL:           int(config.GetScraperCacheTTL() / time.Minute),
+		CacheMaxSize:       int(config.GetScraperCacheMaxSize() / (1024 * 1024)),
+		RateLimit:          config.GetScraperRateLimit(),
+		MaxConcurrency:     config.GetScraperMaxConcurrency(),
+		MaxRetries:         config.GetScraperMaxRetries(),
 	}
 }
 
@@ -226,7 +237,7 @@ func makeConfigUIResult() map[string]interface{} {
 }
 
 func (r *queryResolver) ValidateStashBoxCredentials(ctx context.Context, input config.StashBoxInput) (*StashBoxValidationResult, error) {
-	client := stashbox.NewClient(models.StashBox{Endpoint: input.Endpoint, APIKey: input.APIKey}, r.txnManager, r.stashboxRepository())
+	client := stashbox.NewClient(models.StashBox{Endpoint: input.Endpoint, APIKey: input.APIKey}, r.txnManager, r.stashboxRepository(), nil)
 	user, err := client.GetUser(ctx)
 
 	valid := user != nil && user.Me != nil
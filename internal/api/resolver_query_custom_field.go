@@ -0,0 +1,22 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) CustomFieldDefinitions(ctx context.Context, entityType *models.CustomFieldEntityType) (ret []*models.CustomFieldDefinition, err error) {
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		if entityType != nil {
+			ret, err = r.repository.CustomFieldDefinition.FindByEntityType(ctx, *entityType)
+		} else {
+			ret, err = r.repository.CustomFieldDefinition.All(ctx)
+		}
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
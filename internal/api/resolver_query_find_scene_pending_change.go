@@ -0,0 +1,39 @@
+package api
+
+import (
+	"context"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+)
+
+func (r *queryResolver) FindScenePendingChanges(ctx context.Context, sceneIds []string) (ret []*models.ScenePendingChange, err error) {
+	ids, err := stringslice.StringSliceToIntSlice(sceneIds)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		qb := r.repository.ScenePendingChange
+
+		if len(ids) == 0 {
+			ret, err = qb.All(ctx)
+			return err
+		}
+
+		for _, id := range ids {
+			changes, err := qb.FindBySceneID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			ret = append(ret, changes...)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stashapp/stash/internal/manager"
 	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
 )
 
 func (r *queryResolver) JobQueue(ctx context.Context) ([]*Job, error) {
@@ -32,15 +33,69 @@ func (r *queryResolver) FindJob(ctx context.Context, input FindJobInput) (*Job,
 	return jobToJobModel(*j), nil
 }
 
+func (r *queryResolver) JobHistory(ctx context.Context, status *JobStatus, filter *models.FindFilterType) (ret *FindJobHistoryResultType, err error) {
+	var statusStr *string
+	if status != nil {
+		s := status.String()
+		statusStr = &s
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		jobs, count, err := r.repository.JobRecord.Query(ctx, statusStr, filter)
+		if err != nil {
+			return err
+		}
+
+		ret = &FindJobHistoryResultType{
+			Count: count,
+			Jobs:  jobs,
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) FindJobRecord(ctx context.Context, id string) (ret *models.JobRecord, err error) {
+	idInt, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		ret, err = r.repository.JobRecord.Find(ctx, idInt)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *queryResolver) InterruptedJobs(ctx context.Context) ([]*models.JobCheckpoint, error) {
+	return manager.GetInstance().InterruptedJobs(ctx)
+}
+
 func jobToJobModel(j job.Job) *Job {
 	ret := &Job{
-		ID:          strconv.Itoa(j.ID),
-		Status:      JobStatus(j.Status),
-		Description: j.Description,
-		SubTasks:    j.Details,
-		StartTime:   j.StartTime,
-		EndTime:     j.EndTime,
-		AddTime:     j.AddTime,
+		ID:            strconv.Itoa(j.ID),
+		Status:        JobStatus(j.Status),
+		Description:   j.Description,
+		SubTasks:      j.Details,
+		StartTime:     j.StartTime,
+		EndTime:       j.EndTime,
+		AddTime:       j.AddTime,
+		Priority:      j.Priority,
+		ResourceClass: JobResourceClass(j.ResourceClass),
+		Result:        j.Result,
+		Error:         j.Error,
+		Summary: &JobSummary{
+			Created: j.Summary.Created,
+			Updated: j.Summary.Updated,
+			Skipped: j.Summary.Skipped,
+		},
 	}
 
 	if j.Progress != -1 {
@@ -21,6 +21,10 @@ func (r *queryResolver) ScrapeURL(ctx context.Context, url string, ty scraper.Sc
 	return r.scraperCache().ScrapeURL(ctx, url, ty)
 }
 
+func (r *queryResolver) ScrapeURLs(ctx context.Context, urls []string, ty scraper.ScrapeContentType) ([]scraper.ScrapedContent, error) {
+	return r.scraperCache().ScrapeURLs(ctx, urls, ty)
+}
+
 // deprecated
 func (r *queryResolver) ScrapeFreeonesPerformerList(ctx context.Context, query string) ([]string, error) {
 	content, err := r.scraperCache().ScrapeName(ctx, scraper.FreeonesScraperID, query, scraper.ScrapeContentTypePerformer)
@@ -218,6 +222,15 @@ func (r *queryResolver) ScrapeGalleryURL(ctx context.Context, url string) (*scra
 	return marshalScrapedGallery(content)
 }
 
+func (r *queryResolver) ScrapeImageURL(ctx context.Context, url string) (*scraper.ScrapedImage, error) {
+	content, err := r.scraperCache().ScrapeURL(ctx, url, scraper.ScrapeContentTypeImage)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalScrapedImage(content)
+}
+
 func (r *queryResolver) ScrapeMovieURL(ctx context.Context, url string) (*models.ScrapedMovie, error) {
 	content, err := r.scraperCache().ScrapeURL(ctx, url, scraper.ScrapeContentTypeMovie)
 	if err != nil {
@@ -227,6 +240,33 @@ func (r *queryResolver) ScrapeMovieURL(ctx context.Context, url string) (*models
 	return marshalScrapedMovie(content)
 }
 
+func (r *queryResolver) ValidateScraper(ctx context.Context, input ValidateScraperInput) (*scraper.ScraperValidationResult, error) {
+	fixture := scraper.ValidationFixture{
+		Type:     input.Type,
+		Document: input.Fixture,
+	}
+	if input.Scraper != nil {
+		fixture.Scraper = *input.Scraper
+	}
+	if input.URL != nil {
+		fixture.URL = *input.URL
+	}
+	if input.Expected != nil {
+		fixture.Expected = *input.Expected
+	}
+
+	switch {
+	case input.ScraperID != nil && input.Config != nil:
+		return nil, fmt.Errorf("%w: scraper_id and config must not both be set", ErrInput)
+	case input.ScraperID != nil:
+		return r.scraperCache().ValidateScraper(ctx, *input.ScraperID, fixture)
+	case input.Config != nil:
+		return scraper.ValidateScraperConfig(ctx, strings.NewReader(*input.Config), fixture)
+	}
+
+	return nil, fmt.Errorf("%w: scraper_id or config must be set", ErrInput)
+}
+
 func (r *queryResolver) getStashBoxClient(index int) (*stashbox.Client, error) {
 	boxes := config.GetInstance().GetStashBoxes()
 
@@ -234,7 +274,7 @@ func (r *queryResolver) getStashBoxClient(index int) (*stashbox.Client, error) {
 		return nil, fmt.Errorf("%w: invalid stash_box_index %d", ErrInput, index)
 	}
 
-	return stashbox.NewClient(*boxes[index], r.txnManager, r.stashboxRepository()), nil
+	return stashbox.NewClient(*boxes[index], r.txnManager, r.stashboxRepository(), r.scraperCache().ResponseCache()), nil
 }
 
 func (r *queryResolver) ScrapeSingleScene(ctx context.Context, source scraper.Source, input ScrapeSingleSceneInput) ([]*scraper.ScrapedScene, error) {
@@ -426,6 +466,131 @@ func (r *queryResolver) ScrapeSingleGallery(ctx context.Context, source scraper.
 	}
 }
 
+func (r *queryResolver) ScrapeSingleImage(ctx context.Context, source scraper.Source, input ScrapeSingleImageInput) ([]*scraper.ScrapedImage, error) {
+	if source.StashBoxIndex != nil {
+		return nil, ErrNotSupported
+	}
+
+	if source.ScraperID == nil {
+		return nil, fmt.Errorf("%w: scraper_id must be set", ErrInput)
+	}
+
+	content, err := r.scrapeSingle(ctx, *source.ScraperID, scraper.ScrapeContentTypeImage, input.ImageID, scraper.Input{Image: input.ImageInput}, input.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalScrapedImages(content)
+}
+
 func (r *queryResolver) ScrapeSingleMovie(ctx context.Context, source scraper.Source, input ScrapeSingleMovieInput) ([]*models.ScrapedMovie, error) {
-	return nil, ErrNotSupported
+	if source.StashBoxIndex != nil {
+		return nil, ErrNotSupported
+	}
+
+	if source.ScraperID == nil {
+		return nil, fmt.Errorf("%w: scraper_id must be set", ErrInput)
+	}
+
+	content, err := r.scrapeSingle(ctx, *source.ScraperID, scraper.ScrapeContentTypeMovie, input.MovieID, scraper.Input{Movie: input.MovieInput}, input.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalScrapedMovies(content)
+}
+
+// scrapeSingle scrapes content of the provided type using the scraper with
+// the provided ID, by id, fragment or query.
+func (r *queryResolver) scrapeSingle(ctx context.Context, scraperID string, ty scraper.ScrapeContentType, id *string, input scraper.Input, query *string) ([]scraper.ScrapedContent, error) {
+	var c scraper.ScrapedContent
+	var err error
+
+	switch {
+	case id != nil:
+		var idInt int
+		idInt, err = strconv.Atoi(*id)
+		if err != nil {
+			return nil, fmt.Errorf("%w: id is not an integer: '%s'", ErrInput, *id)
+		}
+		c, err = r.scraperCache().ScrapeID(ctx, scraperID, idInt, ty)
+	case input.Image != nil || input.Movie != nil || input.Studio != nil || input.Tag != nil:
+		c, err = r.scraperCache().ScrapeFragment(ctx, scraperID, input)
+	case query != nil:
+		return r.scraperCache().ScrapeName(ctx, scraperID, *query, ty)
+	default:
+		return nil, ErrNotImplemented
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if c == nil {
+		return nil, nil
+	}
+
+	return []scraper.ScrapedContent{c}, nil
+}
+
+func (r *queryResolver) ScrapeSingleStudio(ctx context.Context, source scraper.Source, input ScrapeSingleStudioInput) ([]*models.ScrapedStudio, error) {
+	switch {
+	case source.ScraperID != nil:
+		content, err := r.scrapeSingle(ctx, *source.ScraperID, scraper.ScrapeContentTypeStudio, input.StudioID, scraper.Input{Studio: input.StudioInput}, input.Query)
+		if err != nil {
+			return nil, err
+		}
+
+		return marshalScrapedStudios(content)
+	case source.StashBoxIndex != nil:
+		client, err := r.getStashBoxClient(*source.StashBoxIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		var ret *models.ScrapedStudio
+		switch {
+		case input.StudioID != nil:
+			studioID, err := strconv.Atoi(*input.StudioID)
+			if err != nil {
+				return nil, fmt.Errorf("%w: studio id is not an integer: '%s'", ErrInput, *input.StudioID)
+			}
+			ret, err = client.FindStashBoxStudio(ctx, studioID)
+			if err != nil {
+				return nil, err
+			}
+		case input.Query != nil:
+			ret, err = client.FindStashBoxStudioByName(ctx, *input.Query)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%w: studio_id or query must be set", ErrInput)
+		}
+
+		if ret == nil {
+			return nil, nil
+		}
+
+		return []*models.ScrapedStudio{ret}, nil
+	}
+
+	return nil, fmt.Errorf("%w: scraper_id or stash_box_index must be set", ErrInput)
+}
+
+func (r *queryResolver) ScrapeSingleTag(ctx context.Context, source scraper.Source, input ScrapeSingleTagInput) ([]*models.ScrapedTag, error) {
+	if source.StashBoxIndex != nil {
+		return nil, ErrNotSupported
+	}
+
+	if source.ScraperID == nil {
+		return nil, fmt.Errorf("%w: scraper_id must be set", ErrInput)
+	}
+
+	content, err := r.scrapeSingle(ctx, *source.ScraperID, scraper.ScrapeContentTypeTag, input.TagID, scraper.Input{Tag: input.TagInput}, input.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	return marshalScrapedTags(content)
 }
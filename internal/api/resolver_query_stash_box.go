@@ -0,0 +1,43 @@
+package api
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+func (r *queryResolver) StashBoxSubmissions(ctx context.Context, submissionFilter *StashBoxSubmissionFilterType, filter *models.FindFilterType) (ret *FindStashBoxSubmissionsResultType, err error) {
+	var f models.StashBoxSubmissionFilter
+	if submissionFilter != nil {
+		f.Endpoint = submissionFilter.Endpoint
+		if submissionFilter.EntityType != nil {
+			v := submissionFilter.EntityType.String()
+			f.EntityType = &v
+		}
+		if submissionFilter.EntityID != nil {
+			id, err := strconv.Atoi(*submissionFilter.EntityID)
+			if err != nil {
+				return nil, err
+			}
+			f.EntityID = &id
+		}
+	}
+
+	if err := r.withTxn(ctx, func(ctx context.Context) error {
+		submissions, count, err := r.repository.StashBoxSubmission.Query(ctx, f, filter)
+		if err != nil {
+			return err
+		}
+
+		ret = &FindStashBoxSubmissionsResultType{
+			Count:       count,
+			Submissions: submissions,
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
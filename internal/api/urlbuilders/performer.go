@@ -23,3 +23,7 @@ func NewPerformerURLBuilder(baseURL string, performer *models.Performer) Perform
 func (b PerformerURLBuilder) GetPerformerImageURL() string {
 	return b.BaseURL + "/performer/" + b.PerformerID + "/image?" + b.UpdatedAt
 }
+
+func (b PerformerURLBuilder) GetPerformerImageURLByID(imageID int) string {
+	return b.BaseURL + "/performer/" + b.PerformerID + "/image/" + strconv.Itoa(imageID) + "?" + b.UpdatedAt
+}
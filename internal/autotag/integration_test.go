@@ -173,7 +173,7 @@ func createScenes(ctx context.Context, sqb models.SceneReaderWriter, folderStore
 
 	s := &models.Scene{
 		Title:    expectedMatchTitle,
-		URL:      existingStudioSceneName,
+		URLs:     models.NewRelatedStrings([]string{existingStudioSceneName}),
 		StudioID: &existingStudioID,
 	}
 	if err := createScene(ctx, sqb, s, f); err != nil {
@@ -548,7 +548,7 @@ func TestParsePerformerScenes(t *testing.T) {
 
 	for _, p := range performers {
 		if err := withDB(func(ctx context.Context) error {
-			return tagger.PerformerScenes(ctx, p, nil, r.Scene)
+			return tagger.PerformerScenes(ctx, p, nil, false, r.Scene)
 		}); err != nil {
 			t.Errorf("Error auto-tagging performers: %s", err)
 		}
@@ -618,8 +618,13 @@ func TestParseStudioScenes(t *testing.T) {
 		}
 
 		for _, scene := range scenes {
+			if err := scene.LoadURLs(ctx, r.Scene); err != nil {
+				t.Error(err.Error())
+				continue
+			}
+
 			// check for existing studio id scene first
-			if scene.URL == existingStudioSceneName {
+			if scene.URLs.First() == existingStudioSceneName {
 				if scene.StudioID == nil || *scene.StudioID != existingStudioID {
 					t.Error("Incorrectly overwrote studio ID for scene with existing studio ID")
 				}
@@ -715,7 +720,7 @@ func TestParsePerformerImages(t *testing.T) {
 
 	for _, p := range performers {
 		if err := withDB(func(ctx context.Context) error {
-			return tagger.PerformerImages(ctx, p, nil, r.Image)
+			return tagger.PerformerImages(ctx, p, nil, false, r.Image)
 		}); err != nil {
 			t.Errorf("Error auto-tagging performers: %s", err)
 		}
@@ -884,7 +889,7 @@ func TestParsePerformerGalleries(t *testing.T) {
 
 	for _, p := range performers {
 		if err := withDB(func(ctx context.Context) error {
-			return tagger.PerformerGalleries(ctx, p, nil, r.Gallery)
+			return tagger.PerformerGalleries(ctx, p, nil, false, r.Gallery)
 		}); err != nil {
 			t.Errorf("Error auto-tagging performers: %s", err)
 		}
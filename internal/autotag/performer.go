@@ -40,10 +40,15 @@ func getPerformerTagger(p *models.Performer, cache *match.Cache) tagger {
 }
 
 // PerformerScenes searches for scenes whose path matches the provided performer name and tags the scene with the performer.
-func (tagger *Tagger) PerformerScenes(ctx context.Context, p *models.Performer, paths []string, rw SceneQueryPerformerUpdater) error {
+// If requireDisambiguation is true, the path must also match the performer's disambiguation.
+func (tagger *Tagger) PerformerScenes(ctx context.Context, p *models.Performer, paths []string, requireDisambiguation bool, rw SceneQueryPerformerUpdater) error {
 	t := getPerformerTagger(p, tagger.Cache)
 
 	return t.tagScenes(ctx, paths, rw, func(o *models.Scene) (bool, error) {
+		if requireDisambiguation && !match.DisambiguationMatchesPath(p, o.Path) {
+			return false, nil
+		}
+
 		if err := o.LoadPerformerIDs(ctx, rw); err != nil {
 			return false, err
 		}
@@ -64,10 +69,15 @@ func (tagger *Tagger) PerformerScenes(ctx context.Context, p *models.Performer,
 }
 
 // PerformerImages searches for images whose path matches the provided performer name and tags the image with the performer.
-func (tagger *Tagger) PerformerImages(ctx context.Context, p *models.Performer, paths []string, rw ImageQueryPerformerUpdater) error {
+// If requireDisambiguation is true, the path must also match the performer's disambiguation.
+func (tagger *Tagger) PerformerImages(ctx context.Context, p *models.Performer, paths []string, requireDisambiguation bool, rw ImageQueryPerformerUpdater) error {
 	t := getPerformerTagger(p, tagger.Cache)
 
 	return t.tagImages(ctx, paths, rw, func(o *models.Image) (bool, error) {
+		if requireDisambiguation && !match.DisambiguationMatchesPath(p, o.Path) {
+			return false, nil
+		}
+
 		if err := o.LoadPerformerIDs(ctx, rw); err != nil {
 			return false, err
 		}
@@ -88,10 +98,15 @@ func (tagger *Tagger) PerformerImages(ctx context.Context, p *models.Performer,
 }
 
 // PerformerGalleries searches for galleries whose path matches the provided performer name and tags the gallery with the performer.
-func (tagger *Tagger) PerformerGalleries(ctx context.Context, p *models.Performer, paths []string, rw GalleryQueryPerformerUpdater) error {
+// If requireDisambiguation is true, the path must also match the performer's disambiguation.
+func (tagger *Tagger) PerformerGalleries(ctx context.Context, p *models.Performer, paths []string, requireDisambiguation bool, rw GalleryQueryPerformerUpdater) error {
 	t := getPerformerTagger(p, tagger.Cache)
 
 	return t.tagGalleries(ctx, paths, rw, func(o *models.Gallery) (bool, error) {
+		if requireDisambiguation && !match.DisambiguationMatchesPath(p, o.Path) {
+			return false, nil
+		}
+
 		if err := o.LoadPerformerIDs(ctx, rw); err != nil {
 			return false, err
 		}
@@ -100,7 +100,7 @@ func testPerformerScenes(t *testing.T, performerName, expectedRegex string) {
 		TxnManager: &mocks.TxnManager{},
 	}
 
-	err := tagger.PerformerScenes(testCtx, &performer, nil, mockSceneReader)
+	err := tagger.PerformerScenes(testCtx, &performer, nil, false, mockSceneReader)
 
 	assert := assert.New(t)
 
@@ -188,7 +188,7 @@ func testPerformerImages(t *testing.T, performerName, expectedRegex string) {
 		TxnManager: &mocks.TxnManager{},
 	}
 
-	err := tagger.PerformerImages(testCtx, &performer, nil, mockImageReader)
+	err := tagger.PerformerImages(testCtx, &performer, nil, false, mockImageReader)
 
 	assert := assert.New(t)
 
@@ -276,7 +276,7 @@ func testPerformerGalleries(t *testing.T, performerName, expectedRegex string) {
 		TxnManager: &mocks.TxnManager{},
 	}
 
-	err := tagger.PerformerGalleries(testCtx, &performer, nil, mockGalleryReader)
+	err := tagger.PerformerGalleries(testCtx, &performer, nil, false, mockGalleryReader)
 
 	assert := assert.New(t)
 
@@ -0,0 +1,239 @@
+package identify
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type GalleryScraper interface {
+	ScrapeGallery(ctx context.Context, galleryID int) (*scraper.ScrapedGallery, error)
+}
+
+type GalleryReaderUpdater interface {
+	UpdatePartial(ctx context.Context, id int, updatedGallery models.GalleryPartial) (*models.Gallery, error)
+	models.PerformerIDLoader
+	models.TagIDLoader
+	models.URLLoader
+}
+
+type GalleryScraperSource struct {
+	Name    string
+	Options *MetadataOptions
+	Scraper GalleryScraper
+}
+
+type GalleryIdentifier struct {
+	GalleryReaderUpdater GalleryReaderUpdater
+	StudioCreator        StudioCreator
+	PerformerCreator     PerformerCreator
+	TagCreator           TagCreator
+
+	DefaultOptions   *MetadataOptions
+	Sources          []GalleryScraperSource
+	PostHookExecutor PostHookExecutor
+}
+
+type galleryScrapeResult struct {
+	result *scraper.ScrapedGallery
+	source GalleryScraperSource
+}
+
+func (t *GalleryIdentifier) Identify(ctx context.Context, txnManager txn.Manager, gallery *models.Gallery) (bool, error) {
+	result, err := t.scrapeGallery(ctx, gallery)
+	if err != nil {
+		return false, err
+	}
+
+	if result == nil {
+		logger.Debugf("Unable to identify gallery %s", gallery.DisplayName())
+		return false, nil
+	}
+
+	// results were found, modify the gallery
+	modified, err := t.modifyGallery(ctx, txnManager, gallery, result)
+	if err != nil {
+		return false, fmt.Errorf("error modifying gallery: %v", err)
+	}
+
+	return modified, nil
+}
+
+func (t *GalleryIdentifier) scrapeGallery(ctx context.Context, gallery *models.Gallery) (*galleryScrapeResult, error) {
+	// iterate through the input sources
+	for _, source := range t.Sources {
+		// scrape using the source
+		scraped, err := source.Scraper.ScrapeGallery(ctx, gallery.ID)
+		if err != nil {
+			logger.Errorf("error scraping from %v: %v", source.Scraper, err)
+			continue
+		}
+
+		// if results were found then return
+		if scraped != nil {
+			return &galleryScrapeResult{
+				result: scraped,
+				source: source,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t *GalleryIdentifier) getGalleryPartial(ctx context.Context, g *models.Gallery, result *galleryScrapeResult) (*models.GalleryPartial, error) {
+	options := []MetadataOptions{}
+	if result.source.Options != nil {
+		options = append(options, *result.source.Options)
+	}
+	if t.DefaultOptions != nil {
+		options = append(options, *t.DefaultOptions)
+	}
+
+	fieldOptions := getFieldOptions(options)
+
+	setOrganized := false
+	for _, o := range options {
+		if o.SetOrganized != nil {
+			setOrganized = *o.SetOrganized
+			break
+		}
+	}
+
+	ignoreMale := false
+	for _, o := range options {
+		if o.IncludeMalePerformers != nil {
+			ignoreMale = !*o.IncludeMalePerformers
+			break
+		}
+	}
+
+	scraped := result.result
+	ret := getGalleryPartial(g, scraped, fieldOptions, setOrganized)
+
+	studioID, err := getStudioID(ctx, "", t.StudioCreator, scraped.Studio, g.StudioID, fieldOptions["studio"])
+	if err != nil {
+		return nil, fmt.Errorf("error getting studio: %w", err)
+	}
+
+	if studioID != nil {
+		ret.StudioID = models.NewOptionalInt(*studioID)
+	}
+
+	performerIDs, err := getPerformerIDs(ctx, "", t.PerformerCreator, scraped.Performers, g.PerformerIDs, fieldOptions["performers"], ignoreMale)
+	if err != nil {
+		return nil, err
+	}
+	if performerIDs != nil {
+		ret.PerformerIDs = &models.UpdateIDs{
+			IDs:  performerIDs,
+			Mode: models.RelationshipUpdateModeSet,
+		}
+	}
+
+	tagIDs, err := getTagIDs(ctx, t.TagCreator, scraped.Tags, g.TagIDs, fieldOptions["tags"])
+	if err != nil {
+		return nil, err
+	}
+	if tagIDs != nil {
+		ret.TagIDs = &models.UpdateIDs{
+			IDs:  tagIDs,
+			Mode: models.RelationshipUpdateModeSet,
+		}
+	}
+
+	return &ret, nil
+}
+
+func (t *GalleryIdentifier) modifyGallery(ctx context.Context, txnManager txn.Manager, g *models.Gallery, result *galleryScrapeResult) (bool, error) {
+	var updateInput models.GalleryUpdateInput
+	var fields []string
+	if err := txn.WithTxn(ctx, txnManager, func(ctx context.Context) error {
+		// load gallery relationships
+		if err := g.LoadURLs(ctx, t.GalleryReaderUpdater); err != nil {
+			return err
+		}
+		if err := g.LoadPerformerIDs(ctx, t.GalleryReaderUpdater); err != nil {
+			return err
+		}
+		if err := g.LoadTagIDs(ctx, t.GalleryReaderUpdater); err != nil {
+			return err
+		}
+
+		partial, err := t.getGalleryPartial(ctx, g, result)
+		if err != nil {
+			return err
+		}
+
+		// don't update anything if nothing was set
+		updateInput = partial.UpdateInput(g.ID)
+		fields = utils.NotNilFields(updateInput, "json")
+		if len(fields) == 0 {
+			logger.Debugf("Nothing to set for gallery %s", g.DisplayName())
+			return nil
+		}
+
+		partial.UpdatedAt = models.NewOptionalTime(time.Now())
+		if _, err := t.GalleryReaderUpdater.UpdatePartial(ctx, g.ID, *partial); err != nil {
+			return fmt.Errorf("error updating gallery: %w", err)
+		}
+
+		as := ""
+		if partial.Title.Set {
+			as = fmt.Sprintf(" as %s", partial.Title.Value)
+		}
+		logger.Infof("Successfully identified gallery %s%s using %s", g.DisplayName(), as, result.source.Name)
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	// fire post-update hooks
+	if len(fields) > 0 && t.PostHookExecutor != nil {
+		t.PostHookExecutor.ExecutePostHooks(ctx, g.ID, plugin.GalleryUpdatePost, updateInput, fields)
+	}
+
+	return len(fields) > 0, nil
+}
+
+func getGalleryPartial(gallery *models.Gallery, scraped *scraper.ScrapedGallery, fieldOptions map[string]*FieldOptions, setOrganized bool) models.GalleryPartial {
+	partial := models.GalleryPartial{}
+
+	if scraped.Title != nil && (gallery.Title != *scraped.Title) {
+		if shouldSetSingleValueField(fieldOptions["title"], gallery.Title != "") {
+			partial.Title = models.NewOptionalString(*scraped.Title)
+		}
+	}
+	if scraped.Date != nil && (gallery.Date == nil || gallery.Date.String() != *scraped.Date) {
+		if shouldSetSingleValueField(fieldOptions["date"], gallery.Date != nil) {
+			d := models.NewDate(*scraped.Date)
+			partial.Date = models.NewOptionalDate(d)
+		}
+	}
+	if scraped.Details != nil && (gallery.Details != *scraped.Details) {
+		if shouldSetSingleValueField(fieldOptions["details"], gallery.Details != "") {
+			partial.Details = models.NewOptionalString(*scraped.Details)
+		}
+	}
+	if urls := getURLs(scraped.URLs, gallery.URLs.List(), fieldOptions["urls"]); urls != nil {
+		partial.URLs = &models.UpdateStrings{
+			Values: urls,
+			Mode:   models.RelationshipUpdateModeSet,
+		}
+	}
+
+	if setOrganized && !gallery.Organized {
+		// just reuse the boolean since we know it's true
+		partial.Organized = models.NewOptionalBool(setOrganized)
+	}
+
+	return partial
+}
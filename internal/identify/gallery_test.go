@@ -0,0 +1,120 @@
+package identify
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+)
+
+func Test_getGalleryPartial(t *testing.T) {
+	var (
+		originalTitle = "originalTitle"
+		originalDate  = "2001-01-01"
+		originalURL   = "originalURL"
+
+		scrapedTitle   = "scrapedTitle"
+		scrapedDate    = "2002-02-02"
+		scrapedDetails = "scrapedDetails"
+		scrapedURL     = "scrapedURL"
+	)
+
+	originalDateObj := models.NewDate(originalDate)
+	scrapedDateObj := models.NewDate(scrapedDate)
+
+	original := models.Gallery{
+		Title: originalTitle,
+		Date:  &originalDateObj,
+		URLs:  models.NewRelatedStrings([]string{originalURL}),
+	}
+
+	organisedGallery := original
+	organisedGallery.Organized = true
+
+	scraped := scraper.ScrapedGallery{
+		Title:   &scrapedTitle,
+		Date:    &scrapedDate,
+		Details: &scrapedDetails,
+		URLs:    []string{scrapedURL},
+	}
+
+	overwrite := func(fields ...string) map[string]*FieldOptions {
+		ret := make(map[string]*FieldOptions)
+		for _, f := range fields {
+			ret[f] = &FieldOptions{Field: f, Strategy: FieldStrategyOverwrite}
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name         string
+		gallery      *models.Gallery
+		fieldOptions map[string]*FieldOptions
+		setOrganized bool
+		want         models.GalleryPartial
+	}{
+		{
+			"merge only sets missing fields",
+			&original,
+			nil,
+			false,
+			models.GalleryPartial{
+				Details: models.NewOptionalString(scrapedDetails),
+				URLs: &models.UpdateStrings{
+					Values: []string{originalURL, scrapedURL},
+					Mode:   models.RelationshipUpdateModeSet,
+				},
+			},
+		},
+		{
+			"overwrite",
+			&original,
+			overwrite("title", "date", "urls"),
+			false,
+			models.GalleryPartial{
+				Title:   models.NewOptionalString(scrapedTitle),
+				Date:    models.NewOptionalDate(scrapedDateObj),
+				Details: models.NewOptionalString(scrapedDetails),
+				URLs: &models.UpdateStrings{
+					Values: []string{scrapedURL},
+					Mode:   models.RelationshipUpdateModeSet,
+				},
+			},
+		},
+		{
+			"set organized",
+			&original,
+			nil,
+			true,
+			models.GalleryPartial{
+				Details:   models.NewOptionalString(scrapedDetails),
+				Organized: models.NewOptionalBool(true),
+				URLs: &models.UpdateStrings{
+					Values: []string{originalURL, scrapedURL},
+					Mode:   models.RelationshipUpdateModeSet,
+				},
+			},
+		},
+		{
+			"set organized on organized gallery",
+			&organisedGallery,
+			nil,
+			true,
+			models.GalleryPartial{
+				Details: models.NewOptionalString(scrapedDetails),
+				URLs: &models.UpdateStrings{
+					Values: []string{originalURL, scrapedURL},
+					Mode:   models.RelationshipUpdateModeSet,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getGalleryPartial(tt.gallery, &scraped, tt.fieldOptions, tt.setOrganized); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getGalleryPartial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
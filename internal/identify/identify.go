@@ -6,6 +6,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/txn"
@@ -20,6 +21,12 @@ type SceneUpdatePostHookExecutor interface {
 	ExecuteSceneUpdatePostHooks(ctx context.Context, input models.SceneUpdateInput, inputFields []string)
 }
 
+// PostHookExecutor executes the post hooks for identified galleries,
+// performers, studios and movies.
+type PostHookExecutor interface {
+	ExecutePostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string)
+}
+
 type ScraperSource struct {
 	Name       string
 	Options    *MetadataOptions
@@ -37,25 +44,34 @@ type SceneIdentifier struct {
 	Sources                     []ScraperSource
 	ScreenshotSetter            scene.ScreenshotSetter
 	SceneUpdatePostHookExecutor SceneUpdatePostHookExecutor
+
+	// if true, changes are stored using PendingChangeWriter for review
+	// instead of being applied to the scene
+	ReviewChanges       bool
+	PendingChangeWriter PendingChangeWriter
 }
 
-func (t *SceneIdentifier) Identify(ctx context.Context, txnManager txn.Manager, scene *models.Scene) error {
+// Identify scrapes the scene using the configured sources and updates it
+// with the first result found. Returns true if the scene was modified, or if
+// changes to it were stored for review.
+func (t *SceneIdentifier) Identify(ctx context.Context, txnManager txn.Manager, scene *models.Scene) (bool, error) {
 	result, err := t.scrapeScene(ctx, scene)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	if result == nil {
 		logger.Debugf("Unable to identify %s", scene.Path)
-		return nil
+		return false, nil
 	}
 
 	// results were found, modify the scene
-	if err := t.modifyScene(ctx, txnManager, scene, result); err != nil {
-		return fmt.Errorf("error modifying scene: %v", err)
+	modified, err := t.modifyScene(ctx, txnManager, scene, result)
+	if err != nil {
+		return false, fmt.Errorf("error modifying scene: %v", err)
 	}
 
-	return nil
+	return modified, nil
 }
 
 type scrapeResult struct {
@@ -99,6 +115,11 @@ func (t *SceneIdentifier) getSceneUpdater(ctx context.Context, s *models.Scene,
 	}
 
 	fieldOptions := getFieldOptions(options)
+	if t.ReviewChanges {
+		// missing objects would otherwise be created before the changes are
+		// accepted
+		fieldOptions = withoutCreateMissing(fieldOptions)
+	}
 
 	setOrganized := false
 	for _, o := range options {
@@ -190,10 +211,13 @@ func (t *SceneIdentifier) getSceneUpdater(ctx context.Context, s *models.Scene,
 	return ret, nil
 }
 
-func (t *SceneIdentifier) modifyScene(ctx context.Context, txnManager txn.Manager, s *models.Scene, result *scrapeResult) error {
+func (t *SceneIdentifier) modifyScene(ctx context.Context, txnManager txn.Manager, s *models.Scene, result *scrapeResult) (bool, error) {
 	var updater *scene.UpdateSet
 	if err := txn.WithTxn(ctx, txnManager, func(ctx context.Context) error {
 		// load scene relationships
+		if err := s.LoadURLs(ctx, t.SceneReaderUpdater); err != nil {
+			return err
+		}
 		if err := s.LoadPerformerIDs(ctx, t.SceneReaderUpdater); err != nil {
 			return err
 		}
@@ -216,6 +240,16 @@ func (t *SceneIdentifier) modifyScene(ctx context.Context, txnManager txn.Manage
 			return nil
 		}
 
+		if t.ReviewChanges {
+			n, err := storePendingChanges(ctx, t.PendingChangeWriter, s, updater, result.source.Name)
+			if err != nil {
+				return err
+			}
+
+			logger.Infof("Stored %d pending changes for %s using %s", n, s.Path, result.source.Name)
+			return nil
+		}
+
 		if _, err := updater.Update(ctx, t.SceneReaderUpdater, t.ScreenshotSetter); err != nil {
 			return fmt.Errorf("error updating scene: %w", err)
 		}
@@ -229,17 +263,18 @@ func (t *SceneIdentifier) modifyScene(ctx context.Context, txnManager txn.Manage
 
 		return nil
 	}); err != nil {
-		return err
+		return false, err
 	}
 
 	// fire post-update hooks
-	if !updater.IsEmpty() {
+	// pending changes fire the hooks once accepted
+	if !t.ReviewChanges && !updater.IsEmpty() {
 		updateInput := updater.UpdateInput()
 		fields := utils.NotNilFields(updateInput, "json")
 		t.SceneUpdatePostHookExecutor.ExecuteSceneUpdatePostHooks(ctx, updateInput, fields)
 	}
 
-	return nil
+	return !updater.IsEmpty(), nil
 }
 
 func getFieldOptions(options []MetadataOptions) map[string]*FieldOptions {
@@ -253,6 +288,25 @@ func getFieldOptions(options []MetadataOptions) map[string]*FieldOptions {
 		}
 	}
 
+	// options saved before multiple urls were supported use the url field
+	if _, found := ret["urls"]; !found && ret["url"] != nil {
+		ret["urls"] = ret["url"]
+	}
+
+	return ret
+}
+
+// withoutCreateMissing returns a copy of the field options which does not
+// create missing objects.
+func withoutCreateMissing(fieldOptions map[string]*FieldOptions) map[string]*FieldOptions {
+	createMissing := false
+	ret := make(map[string]*FieldOptions, len(fieldOptions))
+	for k, v := range fieldOptions {
+		o := *v
+		o.CreateMissing = &createMissing
+		ret[k] = &o
+	}
+
 	return ret
 }
 
@@ -275,9 +329,10 @@ func getScenePartial(scene *models.Scene, scraped *scraper.ScrapedScene, fieldOp
 			partial.Details = models.NewOptionalString(*scraped.Details)
 		}
 	}
-	if scraped.URL != nil && (scene.URL != *scraped.URL) {
-		if shouldSetSingleValueField(fieldOptions["url"], scene.URL != "") {
-			partial.URL = models.NewOptionalString(*scraped.URL)
+	if urls := getURLs(scraped.URLs, scene.URLs.List(), fieldOptions["urls"]); urls != nil {
+		partial.URLs = &models.UpdateStrings{
+			Values: urls,
+			Mode:   models.RelationshipUpdateModeSet,
 		}
 	}
 	if scraped.Director != nil && (scene.Director != *scraped.Director) {
@@ -132,8 +132,9 @@ func TestSceneIdentifier_Identify(t *testing.T) {
 				PerformerIDs: models.NewRelatedIDs([]int{}),
 				TagIDs:       models.NewRelatedIDs([]int{}),
 				StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+				URLs:         models.NewRelatedStrings([]string{}),
 			}
-			if err := identifier.Identify(testCtx, &mocks.TxnManager{}, scene); (err != nil) != tt.wantErr {
+			if _, err := identifier.Identify(testCtx, &mocks.TxnManager{}, scene); (err != nil) != tt.wantErr {
 				t.Errorf("SceneIdentifier.Identify() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -162,6 +163,7 @@ func TestSceneIdentifier_modifyScene(t *testing.T) {
 					PerformerIDs: models.NewRelatedIDs([]int{}),
 					TagIDs:       models.NewRelatedIDs([]int{}),
 					StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+					URLs:         models.NewRelatedStrings([]string{}),
 				},
 				&scrapeResult{
 					result: &scraper.ScrapedScene{},
@@ -172,7 +174,7 @@ func TestSceneIdentifier_modifyScene(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if err := tr.modifyScene(testCtx, repo, tt.args.scene, tt.args.result); (err != nil) != tt.wantErr {
+			if _, err := tr.modifyScene(testCtx, repo, tt.args.scene, tt.args.result); (err != nil) != tt.wantErr {
 				t.Errorf("SceneIdentifier.modifyScene() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -239,6 +241,31 @@ func Test_getFieldOptions(t *testing.T) {
 				},
 			},
 		},
+		{
+			"legacy url",
+			args{
+				[]MetadataOptions{
+					{
+						FieldOptions: []*FieldOptions{
+							{
+								Field:    "url",
+								Strategy: FieldStrategyOverwrite,
+							},
+						},
+					},
+				},
+			},
+			map[string]*FieldOptions{
+				"url": {
+					Field:    "url",
+					Strategy: FieldStrategyOverwrite,
+				},
+				"urls": {
+					Field:    "url",
+					Strategy: FieldStrategyOverwrite,
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -271,33 +298,45 @@ func Test_getScenePartial(t *testing.T) {
 		Title:   originalTitle,
 		Date:    &originalDateObj,
 		Details: originalDetails,
-		URL:     originalURL,
+		URLs:    models.NewRelatedStrings([]string{originalURL}),
 	}
 
 	organisedScene := *originalScene
 	organisedScene.Organized = true
 
-	emptyScene := &models.Scene{}
+	emptyScene := &models.Scene{
+		URLs: models.NewRelatedStrings([]string{}),
+	}
 
 	postPartial := models.ScenePartial{
 		Title:   models.NewOptionalString(scrapedTitle),
 		Date:    models.NewOptionalDate(scrapedDateObj),
 		Details: models.NewOptionalString(scrapedDetails),
-		URL:     models.NewOptionalString(scrapedURL),
+		URLs: &models.UpdateStrings{
+			Values: []string{scrapedURL},
+			Mode:   models.RelationshipUpdateModeSet,
+		},
+	}
+
+	mergedPartial := models.ScenePartial{
+		URLs: &models.UpdateStrings{
+			Values: []string{originalURL, scrapedURL},
+			Mode:   models.RelationshipUpdateModeSet,
+		},
 	}
 
 	scrapedScene := &scraper.ScrapedScene{
 		Title:   &scrapedTitle,
 		Date:    &scrapedDate,
 		Details: &scrapedDetails,
-		URL:     &scrapedURL,
+		URLs:    []string{scrapedURL},
 	}
 
 	scrapedUnchangedScene := &scraper.ScrapedScene{
 		Title:   &originalTitle,
 		Date:    &originalDate,
 		Details: &originalDetails,
-		URL:     &originalURL,
+		URLs:    []string{originalURL},
 	}
 
 	makeFieldOptions := func(input *FieldOptions) map[string]*FieldOptions {
@@ -305,7 +344,7 @@ func Test_getScenePartial(t *testing.T) {
 			"title":   input,
 			"date":    input,
 			"details": input,
-			"url":     input,
+			"urls":    input,
 		}
 	}
 
@@ -360,7 +399,7 @@ func Test_getScenePartial(t *testing.T) {
 				mergeAll,
 				false,
 			},
-			models.ScenePartial{},
+			mergedPartial,
 		},
 		{
 			"merge (empty values)",
@@ -0,0 +1,313 @@
+package identify
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+type MovieScraper interface {
+	ScrapeMovie(ctx context.Context, movieID int) (*models.ScrapedMovie, error)
+}
+
+type MovieReaderUpdater interface {
+	Update(ctx context.Context, updatedMovie models.MoviePartial) (*models.Movie, error)
+	GetFrontImage(ctx context.Context, movieID int) ([]byte, error)
+	GetBackImage(ctx context.Context, movieID int) ([]byte, error)
+	UpdateImages(ctx context.Context, movieID int, frontImage []byte, backImage []byte) error
+	models.URLLoader
+	UpdateURLs(ctx context.Context, movieID int, urls []string) error
+}
+
+type MovieScraperSource struct {
+	Name    string
+	Options *MetadataOptions
+	Scraper MovieScraper
+}
+
+type MovieIdentifier struct {
+	MovieReaderUpdater MovieReaderUpdater
+	StudioCreator      StudioCreator
+
+	DefaultOptions   *MetadataOptions
+	Sources          []MovieScraperSource
+	PostHookExecutor PostHookExecutor
+}
+
+type movieScrapeResult struct {
+	result *models.ScrapedMovie
+	source MovieScraperSource
+}
+
+// movieUpdateSet contains the changes to apply to an identified movie.
+type movieUpdateSet struct {
+	Partial    models.MoviePartial
+	URLs       []string
+	FrontImage []byte
+	BackImage  []byte
+}
+
+func (u movieUpdateSet) hasFields() bool {
+	return len(utils.NotNilFields(u.Partial, "json")) > 0
+}
+
+func (u movieUpdateSet) IsEmpty() bool {
+	return !u.hasFields() && u.URLs == nil && u.FrontImage == nil && u.BackImage == nil
+}
+
+func (t *MovieIdentifier) Identify(ctx context.Context, txnManager txn.Manager, movie *models.Movie) (bool, error) {
+	result, err := t.scrapeMovie(ctx, movie)
+	if err != nil {
+		return false, err
+	}
+
+	if result == nil {
+		logger.Debugf("Unable to identify movie %s", movie.Name.String)
+		return false, nil
+	}
+
+	// results were found, modify the movie
+	modified, err := t.modifyMovie(ctx, txnManager, movie, result)
+	if err != nil {
+		return false, fmt.Errorf("error modifying movie: %v", err)
+	}
+
+	return modified, nil
+}
+
+func (t *MovieIdentifier) scrapeMovie(ctx context.Context, movie *models.Movie) (*movieScrapeResult, error) {
+	// iterate through the input sources
+	for _, source := range t.Sources {
+		// scrape using the source
+		scraped, err := source.Scraper.ScrapeMovie(ctx, movie.ID)
+		if err != nil {
+			logger.Errorf("error scraping from %v: %v", source.Scraper, err)
+			continue
+		}
+
+		// if results were found then return
+		if scraped != nil {
+			return &movieScrapeResult{
+				result: scraped,
+				source: source,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t *MovieIdentifier) getMovieUpdater(ctx context.Context, m *models.Movie, result *movieScrapeResult) (*movieUpdateSet, error) {
+	options := []MetadataOptions{}
+	if result.source.Options != nil {
+		options = append(options, *result.source.Options)
+	}
+	if t.DefaultOptions != nil {
+		options = append(options, *t.DefaultOptions)
+	}
+
+	fieldOptions := getFieldOptions(options)
+	scraped := result.result
+
+	ret := &movieUpdateSet{
+		Partial: getMoviePartial(m, scraped, fieldOptions),
+	}
+
+	if len(scraped.URLs) > 0 {
+		originalURLs, err := t.MovieReaderUpdater.GetURLs(ctx, m.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.URLs = getURLs(scraped.URLs, originalURLs, fieldOptions["urls"])
+	}
+
+	studioID, err := t.movieStudio(ctx, m, scraped, fieldOptions["studio"])
+	if err != nil {
+		return nil, fmt.Errorf("error getting studio: %w", err)
+	}
+
+	if studioID != nil {
+		ret.Partial.StudioID = &sql.NullInt64{Int64: int64(*studioID), Valid: true}
+	}
+
+	ret.FrontImage, err = t.movieImage(ctx, m, scraped.FrontImage, fieldOptions["front_image"], t.MovieReaderUpdater.GetFrontImage)
+	if err != nil {
+		return nil, fmt.Errorf("error getting front image: %w", err)
+	}
+
+	ret.BackImage, err = t.movieImage(ctx, m, scraped.BackImage, fieldOptions["back_image"], t.MovieReaderUpdater.GetBackImage)
+	if err != nil {
+		return nil, fmt.Errorf("error getting back image: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (t *MovieIdentifier) movieStudio(ctx context.Context, m *models.Movie, scraped *models.ScrapedMovie, fieldStrategy *FieldOptions) (*int, error) {
+	var existingID *int
+	if m.StudioID.Valid {
+		v := int(m.StudioID.Int64)
+		existingID = &v
+	}
+
+	return getStudioID(ctx, "", t.StudioCreator, scraped.Studio, existingID, fieldStrategy)
+}
+
+func (t *MovieIdentifier) movieImage(ctx context.Context, m *models.Movie, scraped *string, fieldStrategy *FieldOptions, getExisting func(ctx context.Context, movieID int) ([]byte, error)) ([]byte, error) {
+	if scraped == nil || *scraped == "" {
+		return nil, nil
+	}
+
+	existing, err := getExisting(ctx, m.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !shouldSetSingleValueField(fieldStrategy, len(existing) > 0) {
+		return nil, nil
+	}
+
+	return utils.ProcessImageInput(ctx, *scraped)
+}
+
+func (t *MovieIdentifier) modifyMovie(ctx context.Context, txnManager txn.Manager, m *models.Movie, result *movieScrapeResult) (bool, error) {
+	var updater *movieUpdateSet
+	if err := txn.WithTxn(ctx, txnManager, func(ctx context.Context) error {
+		var err error
+		updater, err = t.getMovieUpdater(ctx, m, result)
+		if err != nil {
+			return err
+		}
+
+		// don't update anything if nothing was set
+		if updater.IsEmpty() {
+			logger.Debugf("Nothing to set for movie %s", m.Name.String)
+			return nil
+		}
+
+		if updater.hasFields() {
+			updater.Partial.UpdatedAt = &models.SQLiteTimestamp{Timestamp: time.Now()}
+			if _, err := t.MovieReaderUpdater.Update(ctx, updater.Partial); err != nil {
+				return fmt.Errorf("error updating movie: %w", err)
+			}
+		}
+
+		if updater.URLs != nil {
+			if err := t.MovieReaderUpdater.UpdateURLs(ctx, m.ID, updater.URLs); err != nil {
+				return fmt.Errorf("error updating movie urls: %w", err)
+			}
+		}
+
+		if updater.FrontImage != nil || updater.BackImage != nil {
+			if err := t.updateImages(ctx, m.ID, updater); err != nil {
+				return fmt.Errorf("error updating movie images: %w", err)
+			}
+		}
+
+		as := ""
+		if updater.Partial.Name != nil {
+			as = fmt.Sprintf(" as %s", updater.Partial.Name.String)
+		}
+		logger.Infof("Successfully identified movie %s%s using %s", m.Name.String, as, result.source.Name)
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	// fire post-update hooks
+	if !updater.IsEmpty() && t.PostHookExecutor != nil {
+		fields := utils.NotNilFields(updater.Partial, "json")
+		if updater.URLs != nil {
+			fields = append(fields, "urls")
+		}
+		if updater.FrontImage != nil {
+			fields = append(fields, "front_image")
+		}
+		if updater.BackImage != nil {
+			fields = append(fields, "back_image")
+		}
+		t.PostHookExecutor.ExecutePostHooks(ctx, m.ID, plugin.MovieUpdatePost, updater.Partial, fields)
+	}
+
+	return !updater.IsEmpty(), nil
+}
+
+// updateImages sets the scraped images, keeping the existing image for any
+// image that was not scraped.
+func (t *MovieIdentifier) updateImages(ctx context.Context, movieID int, updater *movieUpdateSet) error {
+	var err error
+	front := updater.FrontImage
+	back := updater.BackImage
+
+	if front == nil {
+		front, err = t.MovieReaderUpdater.GetFrontImage(ctx, movieID)
+		if err != nil {
+			return err
+		}
+	}
+	if back == nil {
+		back, err = t.MovieReaderUpdater.GetBackImage(ctx, movieID)
+		if err != nil {
+			return err
+		}
+	}
+
+	// a back image cannot be set without a front image
+	if len(front) == 0 {
+		front, err = utils.ProcessImageInput(ctx, models.DefaultMovieImage)
+		if err != nil {
+			return err
+		}
+	}
+
+	return t.MovieReaderUpdater.UpdateImages(ctx, movieID, front, back)
+}
+
+func getMoviePartial(movie *models.Movie, scraped *models.ScrapedMovie, fieldOptions map[string]*FieldOptions) models.MoviePartial {
+	partial := models.MoviePartial{
+		ID: movie.ID,
+	}
+
+	setString := func(field string, existing sql.NullString, value *string) *sql.NullString {
+		if value == nil || *value == "" || existing.String == *value {
+			return nil
+		}
+		if !shouldSetSingleValueField(fieldOptions[field], existing.Valid && existing.String != "") {
+			return nil
+		}
+		return &sql.NullString{String: *value, Valid: true}
+	}
+
+	partial.Name = setString("name", movie.Name, scraped.Name)
+	partial.Aliases = setString("aliases", movie.Aliases, scraped.Aliases)
+	partial.Director = setString("director", movie.Director, scraped.Director)
+	partial.Synopsis = setString("synopsis", movie.Synopsis, scraped.Synopsis)
+
+	if scraped.Date != nil && (!movie.Date.Valid || movie.Date.String != *scraped.Date) {
+		if shouldSetSingleValueField(fieldOptions["date"], movie.Date.Valid) {
+			partial.Date = &models.SQLiteDate{String: *scraped.Date, Valid: true}
+		}
+	}
+
+	if scraped.Duration != nil {
+		duration, err := utils.ParseDuration(*scraped.Duration)
+		if err != nil {
+			logger.Warnf("Ignoring scraped duration: %v", err)
+		} else if !movie.Duration.Valid || movie.Duration.Int64 != int64(duration) {
+			if shouldSetSingleValueField(fieldOptions["duration"], movie.Duration.Valid) {
+				partial.Duration = &sql.NullInt64{Int64: int64(duration), Valid: true}
+			}
+		}
+	}
+
+	return partial
+}
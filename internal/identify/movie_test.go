@@ -0,0 +1,212 @@
+package identify
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockMovieScraper struct {
+	errIDs  []int
+	results map[int]*models.ScrapedMovie
+}
+
+func (s mockMovieScraper) ScrapeMovie(ctx context.Context, movieID int) (*models.ScrapedMovie, error) {
+	if intslice.IntInclude(s.errIDs, movieID) {
+		return nil, errors.New("scrape movie error")
+	}
+	return s.results[movieID], nil
+}
+
+type mockPostHookExecutor struct {
+}
+
+func (s mockPostHookExecutor) ExecutePostHooks(ctx context.Context, id int, hookType plugin.HookTriggerEnum, input interface{}, inputFields []string) {
+}
+
+func TestMovieIdentifier_Identify(t *testing.T) {
+	const (
+		errID1 = iota
+		errID2
+		missingID
+		found1ID
+		found2ID
+		errUpdateID
+	)
+
+	var scrapedName = "scrapedName"
+
+	sources := []MovieScraperSource{
+		{
+			Scraper: mockMovieScraper{
+				errIDs: []int{errID1},
+				results: map[int]*models.ScrapedMovie{
+					found1ID: {
+						Name: &scrapedName,
+					},
+				},
+			},
+		},
+		{
+			Scraper: mockMovieScraper{
+				errIDs: []int{errID2},
+				results: map[int]*models.ScrapedMovie{
+					found2ID: {
+						Name: &scrapedName,
+					},
+					errUpdateID: {
+						Name: &scrapedName,
+					},
+				},
+			},
+		},
+	}
+
+	mockMovieReaderWriter := &mocks.MovieReaderWriter{}
+	mockMovieReaderWriter.On("Update", mock.Anything, mock.MatchedBy(func(p models.MoviePartial) bool {
+		return p.ID == errUpdateID
+	})).Return(nil, errors.New("update error"))
+	mockMovieReaderWriter.On("Update", mock.Anything, mock.MatchedBy(func(p models.MoviePartial) bool {
+		return p.ID != errUpdateID
+	})).Return(nil, nil)
+
+	tests := []struct {
+		name    string
+		movieID int
+		wantErr bool
+	}{
+		{
+			"error scraping",
+			errID1,
+			false,
+		},
+		{
+			"error scraping from second",
+			errID2,
+			false,
+		},
+		{
+			"found in first scraper",
+			found1ID,
+			false,
+		},
+		{
+			"found in second scraper",
+			found2ID,
+			false,
+		},
+		{
+			"not found",
+			missingID,
+			false,
+		},
+		{
+			"error modifying",
+			errUpdateID,
+			true,
+		},
+	}
+
+	identifier := MovieIdentifier{
+		MovieReaderUpdater: mockMovieReaderWriter,
+		DefaultOptions:     &MetadataOptions{},
+		Sources:            sources,
+		PostHookExecutor:   mockPostHookExecutor{},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			movie := &models.Movie{
+				ID: tt.movieID,
+			}
+			if _, err := identifier.Identify(testCtx, &mocks.TxnManager{}, movie); (err != nil) != tt.wantErr {
+				t.Errorf("MovieIdentifier.Identify() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_getMoviePartial(t *testing.T) {
+	var (
+		originalName     = "originalName"
+		originalDate     = "2001-01-01"
+		originalDirector = "originalDirector"
+
+		scrapedName     = "scrapedName"
+		scrapedDate     = "2002-02-02"
+		scrapedDirector = "scrapedDirector"
+		scrapedDuration = "1:30:00"
+	)
+
+	original := models.Movie{
+		ID:       1,
+		Name:     sql.NullString{String: originalName, Valid: true},
+		Date:     models.SQLiteDate{String: originalDate, Valid: true},
+		Director: sql.NullString{String: originalDirector, Valid: true},
+	}
+
+	scraped := models.ScrapedMovie{
+		Name:     &scrapedName,
+		Date:     &scrapedDate,
+		Director: &scrapedDirector,
+		Duration: &scrapedDuration,
+	}
+
+	overwrite := func(fields ...string) map[string]*FieldOptions {
+		ret := make(map[string]*FieldOptions)
+		for _, f := range fields {
+			ret[f] = &FieldOptions{Field: f, Strategy: FieldStrategyOverwrite}
+		}
+		return ret
+	}
+
+	tests := []struct {
+		name         string
+		fieldOptions map[string]*FieldOptions
+		want         models.MoviePartial
+	}{
+		{
+			"merge only sets missing fields",
+			nil,
+			models.MoviePartial{
+				ID:       1,
+				Duration: &sql.NullInt64{Int64: 5400, Valid: true},
+			},
+		},
+		{
+			"overwrite",
+			overwrite("name", "date", "director"),
+			models.MoviePartial{
+				ID:       1,
+				Name:     &sql.NullString{String: scrapedName, Valid: true},
+				Date:     &models.SQLiteDate{String: scrapedDate, Valid: true},
+				Director: &sql.NullString{String: scrapedDirector, Valid: true},
+				Duration: &sql.NullInt64{Int64: 5400, Valid: true},
+			},
+		},
+		{
+			"ignore",
+			map[string]*FieldOptions{
+				"duration": {Field: "duration", Strategy: FieldStrategyIgnore},
+			},
+			models.MoviePartial{
+				ID: 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getMoviePartial(&original, &scraped, tt.fieldOptions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getMoviePartial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -23,6 +23,44 @@ type Options struct {
 	SceneIDs []string `json:"sceneIDs"`
 	// paths of scenes to identify - ignored if scene ids are set
 	Paths []string `json:"paths"`
+	// if true, changes are stored for review instead of being applied
+	ReviewChanges *bool `json:"reviewChanges"`
+}
+
+type MovieOptions struct {
+	// An ordered list of sources to identify movies with. Only the first source that finds a match is used.
+	Sources []*Source `json:"sources"`
+	// Options defined here override the configured defaults
+	Options *MetadataOptions `json:"options"`
+	// movie ids to identify - all movies are identified if not set
+	MovieIDs []string `json:"movieIDs"`
+}
+
+type GalleryOptions struct {
+	// An ordered list of sources to identify galleries with. Only the first source that finds a match is used.
+	Sources []*Source `json:"sources"`
+	// Options defined here override the configured defaults
+	Options *MetadataOptions `json:"options"`
+	// gallery ids to identify - all unorganized galleries are identified if not set
+	GalleryIDs []string `json:"galleryIDs"`
+}
+
+type PerformerOptions struct {
+	// An ordered list of sources to identify performers with. Only the first source that finds a match is used.
+	Sources []*Source `json:"sources"`
+	// Options defined here override the configured defaults
+	Options *MetadataOptions `json:"options"`
+	// performer ids to identify - all performers are identified if not set
+	PerformerIDs []string `json:"performerIDs"`
+}
+
+type StudioOptions struct {
+	// An ordered list of sources to identify studios with. Only the first source that finds a match is used.
+	Sources []*Source `json:"sources"`
+	// Options defined here override the configured defaults
+	Options *MetadataOptions `json:"options"`
+	// studio ids to identify - all studios are identified if not set
+	StudioIDs []string `json:"studioIDs"`
 }
 
 type MetadataOptions struct {
@@ -7,12 +7,18 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/pkg/hash/md5"
+	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 type PerformerCreator interface {
 	Create(ctx context.Context, newPerformer *models.Performer) error
 	UpdateStashIDs(ctx context.Context, performerID int, stashIDs []models.StashID) error
+	UpdateURLs(ctx context.Context, performerID int, urls []string) error
 }
 
 func getPerformerID(ctx context.Context, endpoint string, w PerformerCreator, p *models.ScrapedPerformer, createMissing bool) (*int, error) {
@@ -38,6 +44,12 @@ func createMissingPerformer(ctx context.Context, endpoint string, w PerformerCre
 		return nil, fmt.Errorf("error creating performer: %w", err)
 	}
 
+	if len(p.URLs) > 0 {
+		if err := w.UpdateURLs(ctx, performerInput.ID, p.URLs); err != nil {
+			return nil, fmt.Errorf("error setting performer urls: %w", err)
+		}
+	}
+
 	if endpoint != "" && p.RemoteSiteID != nil {
 		if err := w.UpdateStashIDs(ctx, performerInput.ID, []models.StashID{
 			{
@@ -60,6 +72,9 @@ func scrapedToPerformerInput(performer *models.ScrapedPerformer) models.Performe
 		CreatedAt: currentTime,
 		UpdatedAt: currentTime,
 	}
+	if performer.Disambiguation != nil {
+		ret.Disambiguation = *performer.Disambiguation
+	}
 	if performer.Birthdate != nil {
 		d := models.NewDate(*performer.Birthdate)
 		ret.Birthdate = &d
@@ -113,12 +128,425 @@ func scrapedToPerformerInput(performer *models.ScrapedPerformer) models.Performe
 	if performer.Aliases != nil {
 		ret.Aliases = *performer.Aliases
 	}
-	if performer.Twitter != nil {
-		ret.Twitter = *performer.Twitter
+
+	return ret
+}
+
+type PerformerScraper interface {
+	ScrapePerformer(ctx context.Context, performerID int) (*models.ScrapedPerformer, error)
+}
+
+type PerformerReaderUpdater interface {
+	UpdatePartial(ctx context.Context, id int, updatedPerformer models.PerformerPartial) (*models.Performer, error)
+	GetImage(ctx context.Context, performerID int) ([]byte, error)
+	UpdateImage(ctx context.Context, performerID int, image []byte) error
+	GetImages(ctx context.Context, performerID int) ([]*models.PerformerImage, error)
+	AddImage(ctx context.Context, performerID int, image []byte, primary bool) (int, error)
+	DestroyImageByID(ctx context.Context, performerID int, imageID int) error
+	GetTagIDs(ctx context.Context, performerID int) ([]int, error)
+	UpdateTags(ctx context.Context, performerID int, tagIDs []int) error
+	models.StashIDLoader
+	UpdateStashIDs(ctx context.Context, performerID int, stashIDs []models.StashID) error
+	models.URLLoader
+	UpdateURLs(ctx context.Context, performerID int, urls []string) error
+}
+
+type PerformerScraperSource struct {
+	Name       string
+	Options    *MetadataOptions
+	Scraper    PerformerScraper
+	RemoteSite string
+}
+
+type PerformerIdentifier struct {
+	PerformerReaderUpdater PerformerReaderUpdater
+	TagCreator             TagCreator
+
+	DefaultOptions   *MetadataOptions
+	Sources          []PerformerScraperSource
+	PostHookExecutor PostHookExecutor
+}
+
+type performerScrapeResult struct {
+	result *models.ScrapedPerformer
+	source PerformerScraperSource
+}
+
+// performerUpdateSet contains the changes to apply to an identified
+// performer.
+type performerUpdateSet struct {
+	Partial  models.PerformerPartial
+	URLs     []string
+	Image    []byte
+	TagIDs   []int
+	StashIDs []models.StashID
+
+	// Images are added to the performer's images. If ReplaceImages is true,
+	// the existing non-primary images are removed first.
+	Images        [][]byte
+	ReplaceImages bool
+}
+
+// isEmpty returns true if there is nothing to update.
+func (u performerUpdateSet) isEmpty() bool {
+	return u.Partial == models.PerformerPartial{} &&
+		u.URLs == nil &&
+		u.Image == nil &&
+		u.Images == nil &&
+		u.TagIDs == nil &&
+		u.StashIDs == nil
+}
+
+// UpdateInput returns a PerformerUpdateInput for the performer with the
+// provided id, using the populated fields of the update set. Added images are
+// not included.
+func (u performerUpdateSet) UpdateInput(id int) models.PerformerUpdateInput {
+	p := u.Partial
+
+	var gender *models.GenderEnum
+	if v := p.Gender.Ptr(); v != nil {
+		g := models.GenderEnum(*v)
+		gender = &g
+	}
+
+	dateString := func(d models.OptionalDate) *string {
+		if v := d.Ptr(); v != nil {
+			s := v.String()
+			return &s
+		}
+		return nil
 	}
-	if performer.Instagram != nil {
-		ret.Instagram = *performer.Instagram
+
+	ret := models.PerformerUpdateInput{
+		ID:             strconv.Itoa(id),
+		Name:           p.Name.Ptr(),
+		Disambiguation: p.Disambiguation.Ptr(),
+		Urls:           u.URLs,
+		Gender:         gender,
+		Birthdate:      dateString(p.Birthdate),
+		Ethnicity:      p.Ethnicity.Ptr(),
+		Country:        p.Country.Ptr(),
+		EyeColor:       p.EyeColor.Ptr(),
+		HeightCm:       p.Height.Ptr(),
+		Measurements:   p.Measurements.Ptr(),
+		FakeTits:       p.FakeTits.Ptr(),
+		CareerLength:   p.CareerLength.Ptr(),
+		Tattoos:        p.Tattoos.Ptr(),
+		Piercings:      p.Piercings.Ptr(),
+		Aliases:        p.Aliases.Ptr(),
+		Favorite:       p.Favorite.Ptr(),
+		Rating100:      p.Rating.Ptr(),
+		Details:        p.Details.Ptr(),
+		DeathDate:      dateString(p.DeathDate),
+		HairColor:      p.HairColor.Ptr(),
+		Weight:         p.Weight.Ptr(),
+		IgnoreAutoTag:  p.IgnoreAutoTag.Ptr(),
+	}
+
+	if u.Image != nil {
+		// convert back to base64
+		data := utils.GetBase64StringFromData(u.Image)
+		ret.Image = &data
+	}
+
+	if u.TagIDs != nil {
+		ret.TagIds = intslice.IntSliceToStringSlice(u.TagIDs)
+	}
+
+	if u.StashIDs != nil {
+		ret.StashIds = make([]*models.StashID, len(u.StashIDs))
+		for i := range u.StashIDs {
+			ret.StashIds[i] = &u.StashIDs[i]
+		}
 	}
 
 	return ret
 }
+
+func (t *PerformerIdentifier) Identify(ctx context.Context, txnManager txn.Manager, performer *models.Performer) (bool, error) {
+	result, err := t.scrapePerformer(ctx, performer)
+	if err != nil {
+		return false, err
+	}
+
+	if result == nil {
+		logger.Debugf("Unable to identify performer %s", performer.Name)
+		return false, nil
+	}
+
+	// results were found, modify the performer
+	modified, err := t.modifyPerformer(ctx, txnManager, performer, result)
+	if err != nil {
+		return false, fmt.Errorf("error modifying performer: %v", err)
+	}
+
+	return modified, nil
+}
+
+func (t *PerformerIdentifier) scrapePerformer(ctx context.Context, performer *models.Performer) (*performerScrapeResult, error) {
+	// iterate through the input sources
+	for _, source := range t.Sources {
+		// scrape using the source
+		scraped, err := source.Scraper.ScrapePerformer(ctx, performer.ID)
+		if err != nil {
+			logger.Errorf("error scraping from %v: %v", source.Scraper, err)
+			continue
+		}
+
+		// if results were found then return
+		if scraped != nil {
+			return &performerScrapeResult{
+				result: scraped,
+				source: source,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t *PerformerIdentifier) getPerformerUpdater(ctx context.Context, p *models.Performer, result *performerScrapeResult) (*performerUpdateSet, error) {
+	options := []MetadataOptions{}
+	if result.source.Options != nil {
+		options = append(options, *result.source.Options)
+	}
+	if t.DefaultOptions != nil {
+		options = append(options, *t.DefaultOptions)
+	}
+
+	fieldOptions := getFieldOptions(options)
+	scraped := result.result
+	r := t.PerformerReaderUpdater
+
+	ret := &performerUpdateSet{
+		Partial: getPerformerPartial(p, scraped, fieldOptions),
+	}
+
+	if len(scraped.URLs) > 0 {
+		originalURLs, err := r.GetURLs(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.URLs = getURLs(scraped.URLs, originalURLs, fieldOptions["urls"])
+	}
+
+	if scraped.Image != nil && *scraped.Image != "" {
+		existing, err := r.GetImage(ctx, p.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting performer image: %w", err)
+		}
+
+		if shouldSetSingleValueField(fieldOptions["image"], len(existing) > 0) {
+			ret.Image, err = utils.ProcessImageInput(ctx, *scraped.Image)
+			if err != nil {
+				return nil, fmt.Errorf("error processing image input: %w", err)
+			}
+		}
+	}
+
+	// sources may return many images, so they are only added to the
+	// performer's images if the field is configured explicitly
+	if o := fieldOptions["images"]; o != nil && o.Strategy.IsValid() && o.Strategy != FieldStrategyIgnore {
+		for _, image := range scraped.Images {
+			data, err := utils.ProcessImageInput(ctx, image)
+			if err != nil {
+				logger.Warnf("error processing performer image: %v", err)
+				continue
+			}
+			ret.Images = append(ret.Images, data)
+		}
+		ret.ReplaceImages = ret.Images != nil && o.Strategy == FieldStrategyOverwrite
+	}
+
+	if len(scraped.Tags) > 0 {
+		originalTagIDs, err := r.GetTagIDs(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.TagIDs, err = getTagIDs(ctx, t.TagCreator, scraped.Tags, models.NewRelatedIDs(originalTagIDs), fieldOptions["tags"])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if scraped.RemoteSiteID != nil && result.source.RemoteSite != "" {
+		originalStashIDs, err := r.GetStashIDs(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.StashIDs = getStashIDs(result.source.RemoteSite, scraped.RemoteSiteID, models.NewRelatedStashIDs(originalStashIDs), fieldOptions["stash_ids"])
+	}
+
+	return ret, nil
+}
+
+func (t *PerformerIdentifier) modifyPerformer(ctx context.Context, txnManager txn.Manager, p *models.Performer, result *performerScrapeResult) (bool, error) {
+	var updateInput *models.PerformerUpdateInput
+	if err := txn.WithTxn(ctx, txnManager, func(ctx context.Context) error {
+		updater, err := t.getPerformerUpdater(ctx, p, result)
+		if err != nil {
+			return err
+		}
+
+		// don't update anything if nothing was set
+		if updater.isEmpty() {
+			logger.Debugf("Nothing to set for performer %s", p.Name)
+			return nil
+		}
+
+		r := t.PerformerReaderUpdater
+		updater.Partial.UpdatedAt = models.NewOptionalTime(time.Now())
+		if _, err := r.UpdatePartial(ctx, p.ID, updater.Partial); err != nil {
+			return fmt.Errorf("error updating performer: %w", err)
+		}
+
+		if updater.URLs != nil {
+			if err := r.UpdateURLs(ctx, p.ID, updater.URLs); err != nil {
+				return fmt.Errorf("error updating performer urls: %w", err)
+			}
+		}
+
+		if updater.Image != nil {
+			if err := r.UpdateImage(ctx, p.ID, updater.Image); err != nil {
+				return fmt.Errorf("error updating performer image: %w", err)
+			}
+		}
+
+		if updater.Images != nil {
+			if err := t.addImages(ctx, p.ID, updater.Images, updater.ReplaceImages); err != nil {
+				return err
+			}
+		}
+
+		if updater.TagIDs != nil {
+			if err := r.UpdateTags(ctx, p.ID, updater.TagIDs); err != nil {
+				return fmt.Errorf("error updating performer tags: %w", err)
+			}
+		}
+
+		if updater.StashIDs != nil {
+			if err := r.UpdateStashIDs(ctx, p.ID, updater.StashIDs); err != nil {
+				return fmt.Errorf("error updating performer stash ids: %w", err)
+			}
+		}
+
+		input := updater.UpdateInput(p.ID)
+		updateInput = &input
+
+		logger.Infof("Successfully identified performer %s using %s", p.Name, result.source.Name)
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	if updateInput == nil {
+		return false, nil
+	}
+
+	// fire post-update hooks
+	if t.PostHookExecutor != nil {
+		fields := utils.NotNilFields(*updateInput, "json")
+		t.PostHookExecutor.ExecutePostHooks(ctx, p.ID, plugin.PerformerUpdatePost, *updateInput, fields)
+	}
+
+	return true, nil
+}
+
+func (t *PerformerIdentifier) addImages(ctx context.Context, performerID int, images [][]byte, replace bool) error {
+	r := t.PerformerReaderUpdater
+
+	if replace {
+		existing, err := r.GetImages(ctx, performerID)
+		if err != nil {
+			return fmt.Errorf("error getting performer images: %w", err)
+		}
+
+		for _, i := range existing {
+			if i.Primary {
+				continue
+			}
+			if err := r.DestroyImageByID(ctx, performerID, i.ID); err != nil {
+				return fmt.Errorf("error removing performer image: %w", err)
+			}
+		}
+	}
+
+	for _, image := range images {
+		const primary = false
+		if _, err := r.AddImage(ctx, performerID, image, primary); err != nil {
+			return fmt.Errorf("error adding performer image: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func getPerformerPartial(performer *models.Performer, scraped *models.ScrapedPerformer, fieldOptions map[string]*FieldOptions) models.PerformerPartial {
+	partial := models.PerformerPartial{}
+
+	setString := func(field string, existing string, value *string) models.OptionalString {
+		if value == nil || *value == "" || existing == *value {
+			return models.OptionalString{}
+		}
+		if !shouldSetSingleValueField(fieldOptions[field], existing != "") {
+			return models.OptionalString{}
+		}
+		return models.NewOptionalString(*value)
+	}
+
+	setDate := func(field string, existing *models.Date, value *string) models.OptionalDate {
+		if value == nil || *value == "" || (existing != nil && existing.String() == *value) {
+			return models.OptionalDate{}
+		}
+		if !shouldSetSingleValueField(fieldOptions[field], existing != nil) {
+			return models.OptionalDate{}
+		}
+		return models.NewOptionalDate(models.NewDate(*value))
+	}
+
+	setInt := func(field string, existing *int, value *string) models.OptionalInt {
+		if value == nil {
+			return models.OptionalInt{}
+		}
+		v, err := strconv.Atoi(*value)
+		if err != nil || (existing != nil && *existing == v) {
+			return models.OptionalInt{}
+		}
+		if !shouldSetSingleValueField(fieldOptions[field], existing != nil) {
+			return models.OptionalInt{}
+		}
+		return models.NewOptionalInt(v)
+	}
+
+	partial.Name = setString("name", performer.Name, scraped.Name)
+	if partial.Name.Set {
+		partial.Checksum = models.NewOptionalString(md5.FromString(partial.Name.Value))
+	}
+	partial.Disambiguation = setString("disambiguation", performer.Disambiguation, scraped.Disambiguation)
+
+	if scraped.Gender != nil && models.GenderEnum(*scraped.Gender).IsValid() {
+		partial.Gender = setString("gender", performer.Gender.String(), scraped.Gender)
+	}
+
+	partial.Birthdate = setDate("birthdate", performer.Birthdate, scraped.Birthdate)
+	partial.Ethnicity = setString("ethnicity", performer.Ethnicity, scraped.Ethnicity)
+	partial.Country = setString("country", performer.Country, scraped.Country)
+	partial.EyeColor = setString("eye_color", performer.EyeColor, scraped.EyeColor)
+	partial.Height = setInt("height", performer.Height, scraped.Height)
+	partial.Measurements = setString("measurements", performer.Measurements, scraped.Measurements)
+	partial.FakeTits = setString("fake_tits", performer.FakeTits, scraped.FakeTits)
+	partial.CareerLength = setString("career_length", performer.CareerLength, scraped.CareerLength)
+	partial.Tattoos = setString("tattoos", performer.Tattoos, scraped.Tattoos)
+	partial.Piercings = setString("piercings", performer.Piercings, scraped.Piercings)
+	partial.Aliases = setString("aliases", performer.Aliases, scraped.Aliases)
+	partial.Details = setString("details", performer.Details, scraped.Details)
+	partial.DeathDate = setDate("death_date", performer.DeathDate, scraped.DeathDate)
+	partial.HairColor = setString("hair_color", performer.HairColor, scraped.HairColor)
+	partial.Weight = setInt("weight", performer.Weight, scraped.Weight)
+
+	return partial
+}
@@ -7,8 +7,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stashapp/stash/pkg/hash/md5"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/utils"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -234,7 +236,7 @@ func Test_scrapedToPerformerInput(t *testing.T) {
 	md5 := "b068931cc450442b63f5b3d276ea4297"
 
 	var stringValues []string
-	for i := 0; i < 17; i++ {
+	for i := 0; i < 16; i++ {
 		stringValues = append(stringValues, strconv.Itoa(i))
 	}
 
@@ -263,45 +265,43 @@ func Test_scrapedToPerformerInput(t *testing.T) {
 		{
 			"set all",
 			&models.ScrapedPerformer{
-				Name:         &name,
-				Birthdate:    nextVal(),
-				DeathDate:    nextVal(),
-				Gender:       nextVal(),
-				Ethnicity:    nextVal(),
-				Country:      nextVal(),
-				EyeColor:     nextVal(),
-				HairColor:    nextVal(),
-				Height:       nextVal(),
-				Weight:       nextVal(),
-				Measurements: nextVal(),
-				FakeTits:     nextVal(),
-				CareerLength: nextVal(),
-				Tattoos:      nextVal(),
-				Piercings:    nextVal(),
-				Aliases:      nextVal(),
-				Twitter:      nextVal(),
-				Instagram:    nextVal(),
+				Name:           &name,
+				Disambiguation: nextVal(),
+				Birthdate:      nextVal(),
+				DeathDate:      nextVal(),
+				Gender:         nextVal(),
+				Ethnicity:      nextVal(),
+				Country:        nextVal(),
+				EyeColor:       nextVal(),
+				HairColor:      nextVal(),
+				Height:         nextVal(),
+				Weight:         nextVal(),
+				Measurements:   nextVal(),
+				FakeTits:       nextVal(),
+				CareerLength:   nextVal(),
+				Tattoos:        nextVal(),
+				Piercings:      nextVal(),
+				Aliases:        nextVal(),
 			},
 			models.Performer{
-				Name:         name,
-				Checksum:     md5,
-				Birthdate:    dateToDatePtr(models.NewDate(*nextVal())),
-				DeathDate:    dateToDatePtr(models.NewDate(*nextVal())),
-				Gender:       models.GenderEnum(*nextVal()),
-				Ethnicity:    *nextVal(),
-				Country:      *nextVal(),
-				EyeColor:     *nextVal(),
-				HairColor:    *nextVal(),
-				Height:       nextIntVal(),
-				Weight:       nextIntVal(),
-				Measurements: *nextVal(),
-				FakeTits:     *nextVal(),
-				CareerLength: *nextVal(),
-				Tattoos:      *nextVal(),
-				Piercings:    *nextVal(),
-				Aliases:      *nextVal(),
-				Twitter:      *nextVal(),
-				Instagram:    *nextVal(),
+				Name:           name,
+				Checksum:       md5,
+				Disambiguation: *nextVal(),
+				Birthdate:      dateToDatePtr(models.NewDate(*nextVal())),
+				DeathDate:      dateToDatePtr(models.NewDate(*nextVal())),
+				Gender:         models.GenderEnum(*nextVal()),
+				Ethnicity:      *nextVal(),
+				Country:        *nextVal(),
+				EyeColor:       *nextVal(),
+				HairColor:      *nextVal(),
+				Height:         nextIntVal(),
+				Weight:         nextIntVal(),
+				Measurements:   *nextVal(),
+				FakeTits:       *nextVal(),
+				CareerLength:   *nextVal(),
+				Tattoos:        *nextVal(),
+				Piercings:      *nextVal(),
+				Aliases:        *nextVal(),
 			},
 		},
 		{
@@ -327,3 +327,184 @@ func Test_scrapedToPerformerInput(t *testing.T) {
 		})
 	}
 }
+
+func Test_getPerformerPartial(t *testing.T) {
+	var (
+		originalName    = "originalName"
+		originalCountry = "originalCountry"
+
+		scrapedName     = "scrapedName"
+		scrapedCountry  = "scrapedCountry"
+		scrapedHeight   = "170"
+		scrapedGender   = models.GenderEnumFemale.String()
+		invalidGender   = "invalid"
+		scrapedBirthday = "2001-01-01"
+	)
+
+	original := models.Performer{
+		Name:    originalName,
+		Country: originalCountry,
+	}
+
+	scraped := models.ScrapedPerformer{
+		Name:      &scrapedName,
+		Country:   &scrapedCountry,
+		Height:    &scrapedHeight,
+		Gender:    &scrapedGender,
+		Birthdate: &scrapedBirthday,
+	}
+
+	tests := []struct {
+		name         string
+		scraped      models.ScrapedPerformer
+		fieldOptions map[string]*FieldOptions
+		want         models.PerformerPartial
+	}{
+		{
+			"merge only sets missing fields",
+			scraped,
+			nil,
+			models.PerformerPartial{
+				Gender:    models.NewOptionalString(scrapedGender),
+				Height:    models.NewOptionalInt(170),
+				Birthdate: models.NewOptionalDate(models.NewDate(scrapedBirthday)),
+			},
+		},
+		{
+			"overwrite",
+			scraped,
+			map[string]*FieldOptions{
+				"name":    {Field: "name", Strategy: FieldStrategyOverwrite},
+				"country": {Field: "country", Strategy: FieldStrategyOverwrite},
+			},
+			models.PerformerPartial{
+				Name:      models.NewOptionalString(scrapedName),
+				Checksum:  models.NewOptionalString(md5.FromString(scrapedName)),
+				Country:   models.NewOptionalString(scrapedCountry),
+				Gender:    models.NewOptionalString(scrapedGender),
+				Height:    models.NewOptionalInt(170),
+				Birthdate: models.NewOptionalDate(models.NewDate(scrapedBirthday)),
+			},
+		},
+		{
+			"ignore",
+			scraped,
+			map[string]*FieldOptions{
+				"gender":    {Field: "gender", Strategy: FieldStrategyIgnore},
+				"height":    {Field: "height", Strategy: FieldStrategyIgnore},
+				"birthdate": {Field: "birthdate", Strategy: FieldStrategyIgnore},
+			},
+			models.PerformerPartial{},
+		},
+		{
+			"invalid gender",
+			models.ScrapedPerformer{
+				Gender: &invalidGender,
+			},
+			nil,
+			models.PerformerPartial{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getPerformerPartial(&original, &tt.scraped, tt.fieldOptions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getPerformerPartial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPerformerIdentifier_addImages(t *testing.T) {
+	const (
+		performerID    = 1
+		errPerformerID = 2
+		primaryID      = 10
+		otherID        = 11
+	)
+
+	image := []byte("image")
+
+	mockPerformerReaderWriter := &mocks.PerformerReaderWriter{}
+	mockPerformerReaderWriter.On("GetImages", testCtx, performerID).Return([]*models.PerformerImage{
+		{ID: primaryID, PerformerID: performerID, Primary: true},
+		{ID: otherID, PerformerID: performerID, Position: 1},
+	}, nil)
+	mockPerformerReaderWriter.On("DestroyImageByID", testCtx, performerID, otherID).Return(nil).Once()
+	mockPerformerReaderWriter.On("AddImage", testCtx, performerID, image, false).Return(12, nil).Twice()
+	mockPerformerReaderWriter.On("AddImage", testCtx, errPerformerID, image, false).Return(0, errors.New("add error")).Once()
+
+	identifier := PerformerIdentifier{
+		PerformerReaderUpdater: mockPerformerReaderWriter,
+	}
+
+	// merge only adds the images
+	assert.NoError(t, identifier.addImages(testCtx, performerID, [][]byte{image}, false))
+
+	// overwrite removes the non-primary images first
+	assert.NoError(t, identifier.addImages(testCtx, performerID, [][]byte{image}, true))
+
+	assert.Error(t, identifier.addImages(testCtx, errPerformerID, [][]byte{image}, false))
+
+	mockPerformerReaderWriter.AssertExpectations(t)
+}
+
+func Test_performerUpdateSet_UpdateInput(t *testing.T) {
+	const performerID = 1
+	name := "name"
+	gender := models.GenderEnumFemale
+	birthdate := "2001-02-03"
+	image := []byte("image")
+	imageData := "aW1hZ2U="
+	stashID := models.StashID{StashID: "stashID", Endpoint: "endpoint"}
+
+	tests := []struct {
+		name       string
+		u          performerUpdateSet
+		want       models.PerformerUpdateInput
+		wantFields []string
+	}{
+		{
+			"partial fields",
+			performerUpdateSet{
+				Partial: models.PerformerPartial{
+					Name:      models.NewOptionalString(name),
+					Gender:    models.NewOptionalString(gender.String()),
+					Birthdate: models.NewOptionalDate(models.NewDate(birthdate)),
+					UpdatedAt: models.NewOptionalTime(time.Now()),
+				},
+			},
+			models.PerformerUpdateInput{
+				ID:        strconv.Itoa(performerID),
+				Name:      &name,
+				Gender:    &gender,
+				Birthdate: &birthdate,
+			},
+			[]string{"name", "gender", "birthdate"},
+		},
+		{
+			"relationships",
+			performerUpdateSet{
+				URLs:     []string{"url"},
+				Image:    image,
+				Images:   [][]byte{image},
+				TagIDs:   []int{2},
+				StashIDs: []models.StashID{stashID},
+			},
+			models.PerformerUpdateInput{
+				ID:       strconv.Itoa(performerID),
+				Urls:     []string{"url"},
+				Image:    &imageData,
+				TagIds:   []string{"2"},
+				StashIds: []*models.StashID{&stashID},
+			},
+			[]string{"urls", "tag_ids", "image", "stash_ids"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.u.UpdateInput(performerID)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantFields, utils.NotNilFields(got, "json"))
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package identify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil"
+	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// getStudioID returns the id of the scraped studio, creating it if necessary.
+// Returns nil if the studio should not be set or is unchanged.
+func getStudioID(ctx context.Context, endpoint string, w StudioCreator, scraped *models.ScrapedStudio, existingID *int, fieldStrategy *FieldOptions) (*int, error) {
+	createMissing := fieldStrategy != nil && utils.IsTrue(fieldStrategy.CreateMissing)
+
+	if scraped == nil || !shouldSetSingleValueField(fieldStrategy, existingID != nil) {
+		return nil, nil
+	}
+
+	if scraped.StoredID != nil {
+		// existing studio, just set it
+		studioID, err := strconv.Atoi(*scraped.StoredID)
+		if err != nil {
+			return nil, fmt.Errorf("error converting studio ID %s: %w", *scraped.StoredID, err)
+		}
+
+		// only return value if different to current
+		if existingID == nil || *existingID != studioID {
+			return &studioID, nil
+		}
+	} else if createMissing {
+		return createMissingStudio(ctx, endpoint, w, scraped)
+	}
+
+	return nil, nil
+}
+
+// getPerformerIDs returns the performer ids to set from the scraped
+// performers, creating missing performers if necessary. Returns nil if the
+// performers should not be set or are unchanged.
+func getPerformerIDs(ctx context.Context, endpoint string, w PerformerCreator, scraped []*models.ScrapedPerformer, original models.RelatedIDs, fieldStrategy *FieldOptions, ignoreMale bool) ([]int, error) {
+	// just check if ignored
+	if len(scraped) == 0 || !shouldSetSingleValueField(fieldStrategy, false) {
+		return nil, nil
+	}
+
+	createMissing := fieldStrategy != nil && utils.IsTrue(fieldStrategy.CreateMissing)
+	strategy := FieldStrategyMerge
+	if fieldStrategy != nil {
+		strategy = fieldStrategy.Strategy
+	}
+
+	var performerIDs []int
+	originalPerformerIDs := original.List()
+
+	if strategy == FieldStrategyMerge {
+		// add to existing
+		performerIDs = originalPerformerIDs
+	}
+
+	for _, p := range scraped {
+		if ignoreMale && p.Gender != nil && strings.EqualFold(*p.Gender, models.GenderEnumMale.String()) {
+			continue
+		}
+
+		performerID, err := getPerformerID(ctx, endpoint, w, p, createMissing)
+		if err != nil {
+			return nil, err
+		}
+
+		if performerID != nil {
+			performerIDs = intslice.IntAppendUnique(performerIDs, *performerID)
+		}
+	}
+
+	// don't return if nothing was added
+	if sliceutil.SliceSame(originalPerformerIDs, performerIDs) {
+		return nil, nil
+	}
+
+	return performerIDs, nil
+}
+
+// getTagIDs returns the tag ids to set from the scraped tags, creating
+// missing tags if necessary. Returns nil if the tags should not be set or are
+// unchanged.
+func getTagIDs(ctx context.Context, w TagCreator, scraped []*models.ScrapedTag, original models.RelatedIDs, fieldStrategy *FieldOptions) ([]int, error) {
+	// just check if ignored
+	if len(scraped) == 0 || !shouldSetSingleValueField(fieldStrategy, false) {
+		return nil, nil
+	}
+
+	createMissing := fieldStrategy != nil && utils.IsTrue(fieldStrategy.CreateMissing)
+	strategy := FieldStrategyMerge
+	if fieldStrategy != nil {
+		strategy = fieldStrategy.Strategy
+	}
+
+	var tagIDs []int
+	originalTagIDs := original.List()
+
+	if strategy == FieldStrategyMerge {
+		// add to existing
+		tagIDs = originalTagIDs
+	}
+
+	for _, t := range scraped {
+		if t.StoredID != nil {
+			// existing tag, just add it
+			tagID, err := strconv.ParseInt(*t.StoredID, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("error converting tag ID %s: %w", *t.StoredID, err)
+			}
+
+			tagIDs = intslice.IntAppendUnique(tagIDs, int(tagID))
+		} else if createMissing {
+			now := time.Now()
+			created, err := w.Create(ctx, models.Tag{
+				Name:      t.Name,
+				CreatedAt: models.SQLiteTimestamp{Timestamp: now},
+				UpdatedAt: models.SQLiteTimestamp{Timestamp: now},
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error creating tag: %w", err)
+			}
+
+			tagIDs = append(tagIDs, created.ID)
+		}
+	}
+
+	// don't return if nothing was added
+	if sliceutil.SliceSame(originalTagIDs, tagIDs) {
+		return nil, nil
+	}
+
+	return tagIDs, nil
+}
+
+// getStashIDs returns the stash ids to set for the remote site id from the
+// provided endpoint. Returns nil if the stash ids should not be set or are
+// unchanged.
+func getStashIDs(endpoint string, remoteSiteID *string, original models.RelatedStashIDs, fieldStrategy *FieldOptions) []models.StashID {
+	// just check if ignored
+	if remoteSiteID == nil || endpoint == "" || !shouldSetSingleValueField(fieldStrategy, false) {
+		return nil
+	}
+
+	strategy := FieldStrategyMerge
+	if fieldStrategy != nil {
+		strategy = fieldStrategy.Strategy
+	}
+
+	var stashIDs []models.StashID
+	originalStashIDs := original.List()
+
+	if strategy == FieldStrategyMerge {
+		// add to existing
+		// make a copy so we don't modify the original
+		stashIDs = append(stashIDs, originalStashIDs...)
+	}
+
+	for i, stashID := range stashIDs {
+		if endpoint == stashID.Endpoint {
+			// if stashID is the same, then don't set
+			if stashID.StashID == *remoteSiteID {
+				return nil
+			}
+
+			// replace the stash id and return
+			stashID.StashID = *remoteSiteID
+			stashIDs[i] = stashID
+			return stashIDs
+		}
+	}
+
+	// not found, create new entry
+	stashIDs = append(stashIDs, models.StashID{
+		StashID:  *remoteSiteID,
+		Endpoint: endpoint,
+	})
+
+	if sliceutil.SliceSame(originalStashIDs, stashIDs) {
+		return nil
+	}
+
+	return stashIDs
+}
+
+// getURLs returns the urls to set from the scraped urls. The scraped urls are
+// appended to the original urls unless the field strategy is overwrite.
+// Returns nil if the urls should not be set or are unchanged.
+func getURLs(scraped []string, original []string, fieldStrategy *FieldOptions) []string {
+	// just check if ignored
+	if len(scraped) == 0 || !shouldSetSingleValueField(fieldStrategy, false) {
+		return nil
+	}
+
+	strategy := FieldStrategyMerge
+	if fieldStrategy != nil {
+		strategy = fieldStrategy.Strategy
+	}
+
+	var urls []string
+	if strategy == FieldStrategyMerge {
+		// add to existing
+		urls = append(urls, original...)
+	}
+
+	urls = stringslice.StrAppendUniques(urls, scraped)
+
+	// don't return if nothing was added
+	if sliceutil.SliceSame(original, urls) {
+		return nil
+	}
+
+	return urls
+}
@@ -0,0 +1,258 @@
+package identify
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+)
+
+// PendingChangeWriter stores the changes proposed by the identify task for
+// later review.
+type PendingChangeWriter interface {
+	Create(ctx context.Context, obj models.ScenePendingChange) (*models.ScenePendingChange, error)
+	DestroyBySceneID(ctx context.Context, sceneID int) error
+}
+
+const coverImageField = "cover_image"
+
+// storePendingChanges replaces any pending changes for the scene with the
+// changes in the provided updater.
+func storePendingChanges(ctx context.Context, w PendingChangeWriter, s *models.Scene, updater *scene.UpdateSet, source string) (int, error) {
+	changes, err := getPendingChanges(s, updater, source)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := w.DestroyBySceneID(ctx, s.ID); err != nil {
+		return 0, fmt.Errorf("error removing existing pending changes: %w", err)
+	}
+
+	for _, c := range changes {
+		if _, err := w.Create(ctx, c); err != nil {
+			return 0, fmt.Errorf("error creating pending change for field %s: %w", c.Field, err)
+		}
+	}
+
+	return len(changes), nil
+}
+
+// getPendingChanges returns a pending change for each field set in the
+// updater, recording the current value of the field alongside the proposed
+// value. The current value of the cover image is not recorded.
+func getPendingChanges(s *models.Scene, updater *scene.UpdateSet, source string) ([]models.ScenePendingChange, error) {
+	var ret []models.ScenePendingChange
+	now := models.SQLiteTimestamp{Timestamp: time.Now()}
+
+	add := func(field string, current interface{}, hasCurrent bool, proposed interface{}) error {
+		c := models.ScenePendingChange{
+			SceneID:   s.ID,
+			Source:    source,
+			Field:     field,
+			CreatedAt: now,
+		}
+
+		if hasCurrent {
+			v, err := json.Marshal(current)
+			if err != nil {
+				return fmt.Errorf("error encoding current value of %s: %w", field, err)
+			}
+			c.CurrentValue = sql.NullString{String: string(v), Valid: true}
+		}
+
+		v, err := json.Marshal(proposed)
+		if err != nil {
+			return fmt.Errorf("error encoding proposed value of %s: %w", field, err)
+		}
+		c.ProposedValue = string(v)
+
+		ret = append(ret, c)
+		return nil
+	}
+
+	p := updater.Partial
+	type stringField struct {
+		field    string
+		current  string
+		proposed models.OptionalString
+	}
+
+	for _, f := range []stringField{
+		{"title", s.Title, p.Title},
+		{"code", s.Code, p.Code},
+		{"details", s.Details, p.Details},
+		{"director", s.Director, p.Director},
+	} {
+		if f.proposed.Set {
+			if err := add(f.field, f.current, f.current != "", f.proposed.Value); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if p.URLs != nil {
+		current := s.URLs.List()
+		if err := add("urls", current, len(current) > 0, p.URLs.Values); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Date.Set {
+		var current string
+		if s.Date != nil {
+			current = s.Date.String()
+		}
+		if err := add("date", current, s.Date != nil, p.Date.Value.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Organized.Set {
+		if err := add("organized", s.Organized, true, p.Organized.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.StudioID.Set {
+		var current int
+		if s.StudioID != nil {
+			current = *s.StudioID
+		}
+		if err := add("studio_id", current, s.StudioID != nil, p.StudioID.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.PerformerIDs != nil {
+		if err := add("performer_ids", s.PerformerIDs.List(), true, p.PerformerIDs.IDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.TagIDs != nil {
+		if err := add("tag_ids", s.TagIDs.List(), true, p.TagIDs.IDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.StashIDs != nil {
+		if err := add("stash_ids", s.StashIDs.List(), true, p.StashIDs.StashIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	if updater.CoverImage != nil {
+		if err := add(coverImageField, nil, false, updater.CoverImage); err != nil {
+			return nil, err
+		}
+	}
+
+	return ret, nil
+}
+
+// PendingChangesUpdateSet returns an UpdateSet which applies the provided
+// pending changes to the scene.
+func PendingChangesUpdateSet(sceneID int, changes []*models.ScenePendingChange) (*scene.UpdateSet, error) {
+	ret := &scene.UpdateSet{
+		ID: sceneID,
+	}
+
+	for _, c := range changes {
+		if c.SceneID != sceneID {
+			return nil, fmt.Errorf("pending change %d is not for scene %d", c.ID, sceneID)
+		}
+
+		if err := applyPendingChange(ret, c); err != nil {
+			return nil, fmt.Errorf("error applying pending change to field %s: %w", c.Field, err)
+		}
+	}
+
+	return ret, nil
+}
+
+func applyPendingChange(u *scene.UpdateSet, c *models.ScenePendingChange) error {
+	decodeString := func() (models.OptionalString, error) {
+		var v string
+		err := json.Unmarshal([]byte(c.ProposedValue), &v)
+		return models.NewOptionalString(v), err
+	}
+
+	decodeIDs := func() (*models.UpdateIDs, error) {
+		var v []int
+		if err := json.Unmarshal([]byte(c.ProposedValue), &v); err != nil {
+			return nil, err
+		}
+		return &models.UpdateIDs{
+			IDs:  v,
+			Mode: models.RelationshipUpdateModeSet,
+		}, nil
+	}
+
+	var err error
+	p := &u.Partial
+	switch c.Field {
+	case "title":
+		p.Title, err = decodeString()
+	case "code":
+		p.Code, err = decodeString()
+	case "details":
+		p.Details, err = decodeString()
+	case "director":
+		p.Director, err = decodeString()
+	case "url":
+		// stored before scenes supported multiple urls
+		var v string
+		if err = json.Unmarshal([]byte(c.ProposedValue), &v); err == nil {
+			p.URLs = &models.UpdateStrings{
+				Values: []string{v},
+				Mode:   models.RelationshipUpdateModeAdd,
+			}
+		}
+	case "urls":
+		var v []string
+		if err = json.Unmarshal([]byte(c.ProposedValue), &v); err == nil {
+			p.URLs = &models.UpdateStrings{
+				Values: v,
+				Mode:   models.RelationshipUpdateModeSet,
+			}
+		}
+	case "date":
+		var v string
+		if err = json.Unmarshal([]byte(c.ProposedValue), &v); err == nil {
+			p.Date = models.NewOptionalDate(models.NewDate(v))
+		}
+	case "organized":
+		var v bool
+		if err = json.Unmarshal([]byte(c.ProposedValue), &v); err == nil {
+			p.Organized = models.NewOptionalBool(v)
+		}
+	case "studio_id":
+		var v int
+		if err = json.Unmarshal([]byte(c.ProposedValue), &v); err == nil {
+			p.StudioID = models.NewOptionalInt(v)
+		}
+	case "performer_ids":
+		p.PerformerIDs, err = decodeIDs()
+	case "tag_ids":
+		p.TagIDs, err = decodeIDs()
+	case "stash_ids":
+		var v []models.StashID
+		if err = json.Unmarshal([]byte(c.ProposedValue), &v); err == nil {
+			p.StashIDs = &models.UpdateStashIDs{
+				StashIDs: v,
+				Mode:     models.RelationshipUpdateModeSet,
+			}
+		}
+	case coverImageField:
+		err = json.Unmarshal([]byte(c.ProposedValue), &u.CoverImage)
+	default:
+		err = errors.New("unsupported field")
+	}
+
+	return err
+}
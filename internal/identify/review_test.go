@@ -0,0 +1,249 @@
+package identify
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stretchr/testify/mock"
+)
+
+const testSource = "source"
+
+func Test_getPendingChanges(t *testing.T) {
+	const (
+		sceneID   = 1
+		studioID  = 2
+		tagID     = 3
+		newTagID  = 4
+		origTitle = "origTitle"
+		newTitle  = "newTitle"
+		newDate   = "2001-01-01"
+		origURL   = "origURL"
+		newURL    = "newURL"
+	)
+
+	s := &models.Scene{
+		ID:           sceneID,
+		Title:        origTitle,
+		PerformerIDs: models.NewRelatedIDs([]int{}),
+		TagIDs:       models.NewRelatedIDs([]int{tagID}),
+		StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+		URLs:         models.NewRelatedStrings([]string{origURL}),
+	}
+
+	tests := []struct {
+		name    string
+		updater *scene.UpdateSet
+		want    []models.ScenePendingChange
+	}{
+		{
+			"empty",
+			&scene.UpdateSet{ID: sceneID},
+			nil,
+		},
+		{
+			"fields",
+			&scene.UpdateSet{
+				ID: sceneID,
+				Partial: models.ScenePartial{
+					Title: models.NewOptionalString(newTitle),
+					URLs: &models.UpdateStrings{
+						Values: []string{origURL, newURL},
+						Mode:   models.RelationshipUpdateModeSet,
+					},
+					Date:     models.NewOptionalDate(models.NewDate(newDate)),
+					StudioID: models.NewOptionalInt(studioID),
+					TagIDs: &models.UpdateIDs{
+						IDs:  []int{tagID, newTagID},
+						Mode: models.RelationshipUpdateModeSet,
+					},
+				},
+				CoverImage: []byte("image"),
+			},
+			[]models.ScenePendingChange{
+				{
+					SceneID:       sceneID,
+					Source:        testSource,
+					Field:         "title",
+					CurrentValue:  sql.NullString{String: `"origTitle"`, Valid: true},
+					ProposedValue: `"newTitle"`,
+				},
+				{
+					SceneID:       sceneID,
+					Source:        testSource,
+					Field:         "urls",
+					CurrentValue:  sql.NullString{String: `["origURL"]`, Valid: true},
+					ProposedValue: `["origURL","newURL"]`,
+				},
+				{
+					SceneID:       sceneID,
+					Source:        testSource,
+					Field:         "date",
+					ProposedValue: `"2001-01-01"`,
+				},
+				{
+					SceneID:       sceneID,
+					Source:        testSource,
+					Field:         "studio_id",
+					ProposedValue: "2",
+				},
+				{
+					SceneID:       sceneID,
+					Source:        testSource,
+					Field:         "tag_ids",
+					CurrentValue:  sql.NullString{String: "[3]", Valid: true},
+					ProposedValue: "[3,4]",
+				},
+				{
+					SceneID:       sceneID,
+					Source:        testSource,
+					Field:         "cover_image",
+					ProposedValue: `"aW1hZ2U="`,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getPendingChanges(s, tt.updater, testSource)
+			if err != nil {
+				t.Errorf("getPendingChanges() error = %v", err)
+				return
+			}
+
+			// ignore created at
+			for i := range got {
+				got[i].CreatedAt = models.SQLiteTimestamp{}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getPendingChanges() = %v, want %v", got, tt.want)
+			}
+
+			// ensure the changes can be converted back into the updater
+			var changes []*models.ScenePendingChange
+			for i := range got {
+				changes = append(changes, &got[i])
+			}
+
+			updater, err := PendingChangesUpdateSet(s.ID, changes)
+			if err != nil {
+				t.Errorf("PendingChangesUpdateSet() error = %v", err)
+				return
+			}
+
+			if !reflect.DeepEqual(updater, tt.updater) {
+				t.Errorf("PendingChangesUpdateSet() = %v, want %v", updater, tt.updater)
+			}
+		})
+	}
+}
+
+func TestPendingChangesUpdateSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []*models.ScenePendingChange
+		wantErr bool
+	}{
+		{
+			"wrong scene",
+			[]*models.ScenePendingChange{
+				{SceneID: 2, Field: "title", ProposedValue: `"title"`},
+			},
+			true,
+		},
+		{
+			"unsupported field",
+			[]*models.ScenePendingChange{
+				{SceneID: 1, Field: "rating", ProposedValue: "1"},
+			},
+			true,
+		},
+		{
+			"invalid value",
+			[]*models.ScenePendingChange{
+				{SceneID: 1, Field: "studio_id", ProposedValue: `"foo"`},
+			},
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := PendingChangesUpdateSet(1, tt.changes); (err != nil) != tt.wantErr {
+				t.Errorf("PendingChangesUpdateSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSceneIdentifier_modifyScene_review(t *testing.T) {
+	const sceneID = 1
+	title := "title"
+
+	mockPendingChangeWriter := &mocks.ScenePendingChangeReaderWriter{}
+	mockPendingChangeWriter.On("DestroyBySceneID", mock.Anything, sceneID).Return(nil).Once()
+	mockPendingChangeWriter.On("Create", mock.Anything, mock.MatchedBy(func(c models.ScenePendingChange) bool {
+		return c.SceneID == sceneID && c.Field == "title" && c.ProposedValue == `"title"`
+	})).Return(&models.ScenePendingChange{}, nil).Once()
+
+	repo := models.Repository{
+		TxnManager: &mocks.TxnManager{},
+	}
+
+	createMissing := true
+	fieldOptions := []*FieldOptions{
+		{Field: "studio", Strategy: FieldStrategyMerge, CreateMissing: &createMissing},
+		{Field: "performers", Strategy: FieldStrategyMerge, CreateMissing: &createMissing},
+		{Field: "tags", Strategy: FieldStrategyMerge, CreateMissing: &createMissing},
+	}
+
+	// scene reader updater and creators are not set - the scene must not be
+	// updated and missing objects must not be created
+	tr := &SceneIdentifier{
+		DefaultOptions: &MetadataOptions{
+			FieldOptions: fieldOptions,
+		},
+		ReviewChanges:       true,
+		PendingChangeWriter: mockPendingChangeWriter,
+	}
+
+	s := &models.Scene{
+		ID:           sceneID,
+		PerformerIDs: models.NewRelatedIDs([]int{}),
+		TagIDs:       models.NewRelatedIDs([]int{}),
+		StashIDs:     models.NewRelatedStashIDs([]models.StashID{}),
+		URLs:         models.NewRelatedStrings([]string{}),
+	}
+
+	result := &scrapeResult{
+		result: &scraper.ScrapedScene{
+			Title: &title,
+			Studio: &models.ScrapedStudio{
+				Name: "missing studio",
+			},
+			Performers: []*models.ScrapedPerformer{
+				{Name: &title},
+			},
+			Tags: []*models.ScrapedTag{
+				{Name: "missing tag"},
+			},
+		},
+		source: ScraperSource{
+			Name: testSource,
+		},
+	}
+
+	if _, err := tr.modifyScene(testCtx, repo, s, result); err != nil {
+		t.Errorf("SceneIdentifier.modifyScene() error = %v", err)
+	}
+
+	mockPendingChangeWriter.AssertExpectations(t)
+	if !*fieldOptions[0].CreateMissing {
+		t.Error("SceneIdentifier.modifyScene() modified the field options")
+	}
+}
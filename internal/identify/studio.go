@@ -4,10 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/stashapp/stash/pkg/hash/md5"
+	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin"
+	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 type StudioCreator interface {
@@ -50,3 +55,276 @@ func scrapedToStudioInput(studio *models.ScrapedStudio) models.Studio {
 
 	return ret
 }
+
+type StudioScraper interface {
+	ScrapeStudio(ctx context.Context, studioID int) (*models.ScrapedStudio, error)
+}
+
+type StudioReaderUpdater interface {
+	StudioCreator
+	Update(ctx context.Context, updatedStudio models.StudioPartial) (*models.Studio, error)
+	GetImage(ctx context.Context, studioID int) ([]byte, error)
+	UpdateImage(ctx context.Context, studioID int, image []byte) error
+	models.StashIDLoader
+}
+
+type StudioScraperSource struct {
+	Name       string
+	Options    *MetadataOptions
+	Scraper    StudioScraper
+	RemoteSite string
+}
+
+type StudioIdentifier struct {
+	StudioReaderUpdater StudioReaderUpdater
+
+	DefaultOptions   *MetadataOptions
+	Sources          []StudioScraperSource
+	PostHookExecutor PostHookExecutor
+}
+
+type studioScrapeResult struct {
+	result *models.ScrapedStudio
+	source StudioScraperSource
+}
+
+// studioUpdateSet contains the changes to apply to an identified studio.
+type studioUpdateSet struct {
+	Partial  models.StudioPartial
+	Image    []byte
+	StashIDs []models.StashID
+}
+
+// isEmpty returns true if there is nothing to update.
+func (u studioUpdateSet) isEmpty() bool {
+	return u.Partial == models.StudioPartial{} &&
+		u.Image == nil &&
+		u.StashIDs == nil
+}
+
+// UpdateInput returns a StudioUpdateInput for the studio with the provided
+// id, using the populated fields of the update set.
+func (u studioUpdateSet) UpdateInput(id int) models.StudioUpdateInput {
+	p := u.Partial
+
+	nullString := func(v *sql.NullString) *string {
+		if v == nil || !v.Valid {
+			return nil
+		}
+		return &v.String
+	}
+
+	ret := models.StudioUpdateInput{
+		ID:            strconv.Itoa(id),
+		Name:          nullString(p.Name),
+		URL:           nullString(p.URL),
+		Details:       nullString(p.Details),
+		IgnoreAutoTag: p.IgnoreAutoTag,
+	}
+
+	if p.ParentID != nil && p.ParentID.Valid {
+		parentID := strconv.FormatInt(p.ParentID.Int64, 10)
+		ret.ParentID = &parentID
+	}
+
+	if p.Rating != nil && p.Rating.Valid {
+		rating := int(p.Rating.Int64)
+		ret.Rating100 = &rating
+	}
+
+	if u.Image != nil {
+		// convert back to base64
+		data := utils.GetBase64StringFromData(u.Image)
+		ret.Image = &data
+	}
+
+	if u.StashIDs != nil {
+		ret.StashIds = make([]*models.StashID, len(u.StashIDs))
+		for i := range u.StashIDs {
+			ret.StashIds[i] = &u.StashIDs[i]
+		}
+	}
+
+	return ret
+}
+
+func (t *StudioIdentifier) Identify(ctx context.Context, txnManager txn.Manager, studio *models.Studio) (bool, error) {
+	result, err := t.scrapeStudio(ctx, studio)
+	if err != nil {
+		return false, err
+	}
+
+	if result == nil {
+		logger.Debugf("Unable to identify studio %s", studio.Name.String)
+		return false, nil
+	}
+
+	// results were found, modify the studio
+	modified, err := t.modifyStudio(ctx, txnManager, studio, result)
+	if err != nil {
+		return false, fmt.Errorf("error modifying studio: %v", err)
+	}
+
+	return modified, nil
+}
+
+func (t *StudioIdentifier) scrapeStudio(ctx context.Context, studio *models.Studio) (*studioScrapeResult, error) {
+	// iterate through the input sources
+	for _, source := range t.Sources {
+		// scrape using the source
+		scraped, err := source.Scraper.ScrapeStudio(ctx, studio.ID)
+		if err != nil {
+			logger.Errorf("error scraping from %v: %v", source.Scraper, err)
+			continue
+		}
+
+		// if results were found then return
+		if scraped != nil {
+			return &studioScrapeResult{
+				result: scraped,
+				source: source,
+			}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (t *StudioIdentifier) getStudioUpdater(ctx context.Context, s *models.Studio, result *studioScrapeResult) (*studioUpdateSet, error) {
+	options := []MetadataOptions{}
+	if result.source.Options != nil {
+		options = append(options, *result.source.Options)
+	}
+	if t.DefaultOptions != nil {
+		options = append(options, *t.DefaultOptions)
+	}
+
+	fieldOptions := getFieldOptions(options)
+	scraped := result.result
+	r := t.StudioReaderUpdater
+
+	ret := &studioUpdateSet{
+		Partial: getStudioPartial(s, scraped, fieldOptions),
+	}
+
+	var existingParentID *int
+	if s.ParentID.Valid {
+		v := int(s.ParentID.Int64)
+		existingParentID = &v
+	}
+
+	parentID, err := getStudioID(ctx, result.source.RemoteSite, r, scraped.Parent, existingParentID, fieldOptions["parent_studio"])
+	if err != nil {
+		return nil, fmt.Errorf("error getting parent studio: %w", err)
+	}
+
+	// a studio cannot be its own parent
+	if parentID != nil && *parentID != s.ID {
+		ret.Partial.ParentID = &sql.NullInt64{Int64: int64(*parentID), Valid: true}
+	}
+
+	if scraped.Image != nil && *scraped.Image != "" {
+		existing, err := r.GetImage(ctx, s.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting studio image: %w", err)
+		}
+
+		if shouldSetSingleValueField(fieldOptions["image"], len(existing) > 0) {
+			ret.Image, err = utils.ProcessImageInput(ctx, *scraped.Image)
+			if err != nil {
+				return nil, fmt.Errorf("error processing image input: %w", err)
+			}
+		}
+	}
+
+	if scraped.RemoteSiteID != nil && result.source.RemoteSite != "" {
+		originalStashIDs, err := r.GetStashIDs(ctx, s.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.StashIDs = getStashIDs(result.source.RemoteSite, scraped.RemoteSiteID, models.NewRelatedStashIDs(originalStashIDs), fieldOptions["stash_ids"])
+	}
+
+	return ret, nil
+}
+
+func (t *StudioIdentifier) modifyStudio(ctx context.Context, txnManager txn.Manager, s *models.Studio, result *studioScrapeResult) (bool, error) {
+	var updateInput *models.StudioUpdateInput
+	if err := txn.WithTxn(ctx, txnManager, func(ctx context.Context) error {
+		updater, err := t.getStudioUpdater(ctx, s, result)
+		if err != nil {
+			return err
+		}
+
+		// don't update anything if nothing was set
+		if updater.isEmpty() {
+			logger.Debugf("Nothing to set for studio %s", s.Name.String)
+			return nil
+		}
+
+		r := t.StudioReaderUpdater
+		updater.Partial.ID = s.ID
+		updater.Partial.UpdatedAt = &models.SQLiteTimestamp{Timestamp: time.Now()}
+		if _, err := r.Update(ctx, updater.Partial); err != nil {
+			return fmt.Errorf("error updating studio: %w", err)
+		}
+
+		if updater.Image != nil {
+			if err := r.UpdateImage(ctx, s.ID, updater.Image); err != nil {
+				return fmt.Errorf("error updating studio image: %w", err)
+			}
+		}
+
+		if updater.StashIDs != nil {
+			if err := r.UpdateStashIDs(ctx, s.ID, updater.StashIDs); err != nil {
+				return fmt.Errorf("error updating studio stash ids: %w", err)
+			}
+		}
+
+		input := updater.UpdateInput(s.ID)
+		updateInput = &input
+
+		logger.Infof("Successfully identified studio %s using %s", s.Name.String, result.source.Name)
+
+		return nil
+	}); err != nil {
+		return false, err
+	}
+
+	if updateInput == nil {
+		return false, nil
+	}
+
+	// fire post-update hooks
+	if t.PostHookExecutor != nil {
+		fields := utils.NotNilFields(*updateInput, "json")
+		t.PostHookExecutor.ExecutePostHooks(ctx, s.ID, plugin.StudioUpdatePost, *updateInput, fields)
+	}
+
+	return true, nil
+}
+
+func getStudioPartial(studio *models.Studio, scraped *models.ScrapedStudio, fieldOptions map[string]*FieldOptions) models.StudioPartial {
+	partial := models.StudioPartial{}
+
+	if scraped.Name != "" && studio.Name.String != scraped.Name {
+		if shouldSetSingleValueField(fieldOptions["name"], studio.Name.String != "") {
+			partial.Name = &sql.NullString{String: scraped.Name, Valid: true}
+			checksum := md5.FromString(scraped.Name)
+			partial.Checksum = &checksum
+		}
+	}
+	if scraped.URL != nil && *scraped.URL != "" && studio.URL.String != *scraped.URL {
+		if shouldSetSingleValueField(fieldOptions["url"], studio.URL.String != "") {
+			partial.URL = &sql.NullString{String: *scraped.URL, Valid: true}
+		}
+	}
+	if scraped.Details != nil && *scraped.Details != "" && studio.Details.String != *scraped.Details {
+		if shouldSetSingleValueField(fieldOptions["details"], studio.Details.String != "") {
+			partial.Details = &sql.NullString{String: *scraped.Details, Valid: true}
+		}
+	}
+
+	return partial
+}
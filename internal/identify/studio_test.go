@@ -1,12 +1,16 @@
 package identify
 
 import (
+	"database/sql"
 	"errors"
 	"reflect"
+	"strconv"
 	"testing"
 
+	"github.com/stashapp/stash/pkg/hash/md5"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/utils"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -161,3 +165,216 @@ func Test_scrapedToStudioInput(t *testing.T) {
 		})
 	}
 }
+
+func Test_getStudioPartial(t *testing.T) {
+	var (
+		originalName = "originalName"
+		originalURL  = "originalURL"
+
+		scrapedName    = "scrapedName"
+		scrapedURL     = "scrapedURL"
+		scrapedDetails = "scrapedDetails"
+	)
+
+	original := models.Studio{
+		Name: sql.NullString{String: originalName, Valid: true},
+		URL:  sql.NullString{String: originalURL, Valid: true},
+	}
+
+	scraped := models.ScrapedStudio{
+		Name:    scrapedName,
+		URL:     &scrapedURL,
+		Details: &scrapedDetails,
+	}
+
+	scrapedChecksum := md5.FromString(scrapedName)
+
+	tests := []struct {
+		name         string
+		fieldOptions map[string]*FieldOptions
+		want         models.StudioPartial
+	}{
+		{
+			"merge only sets missing fields",
+			nil,
+			models.StudioPartial{
+				Details: &sql.NullString{String: scrapedDetails, Valid: true},
+			},
+		},
+		{
+			"overwrite",
+			map[string]*FieldOptions{
+				"name": {Field: "name", Strategy: FieldStrategyOverwrite},
+				"url":  {Field: "url", Strategy: FieldStrategyOverwrite},
+			},
+			models.StudioPartial{
+				Name:     &sql.NullString{String: scrapedName, Valid: true},
+				Checksum: &scrapedChecksum,
+				URL:      &sql.NullString{String: scrapedURL, Valid: true},
+				Details:  &sql.NullString{String: scrapedDetails, Valid: true},
+			},
+		},
+		{
+			"ignore",
+			map[string]*FieldOptions{
+				"details": {Field: "details", Strategy: FieldStrategyIgnore},
+			},
+			models.StudioPartial{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getStudioPartial(&original, &scraped, tt.fieldOptions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getStudioPartial() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_studioUpdateSet_UpdateInput(t *testing.T) {
+	const studioID = 1
+	name := "name"
+	details := "details"
+	stashID := models.StashID{StashID: "stashID", Endpoint: "endpoint"}
+
+	u := studioUpdateSet{
+		Partial: models.StudioPartial{
+			Name:     &sql.NullString{String: name, Valid: true},
+			Checksum: &name,
+			Details:  &sql.NullString{String: details, Valid: true},
+		},
+		StashIDs: []models.StashID{stashID},
+	}
+
+	want := models.StudioUpdateInput{
+		ID:       strconv.Itoa(studioID),
+		Name:     &name,
+		Details:  &details,
+		StashIds: []*models.StashID{&stashID},
+	}
+	wantFields := []string{"name", "stash_ids", "details"}
+
+	got := u.UpdateInput(studioID)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("studioUpdateSet.UpdateInput() = %v, want %v", got, want)
+	}
+	if fields := utils.NotNilFields(got, "json"); !reflect.DeepEqual(fields, wantFields) {
+		t.Errorf("studioUpdateSet.UpdateInput() fields = %v, want %v", fields, wantFields)
+	}
+}
+
+func TestStudioIdentifier_getStudioUpdater_parent(t *testing.T) {
+	const (
+		studioID         = 1
+		existingParentID = 2
+		newParentID      = 3
+		createdParentID  = 4
+		endpoint         = "endpoint"
+		remoteParentID   = "remoteParentID"
+		parentName       = "parent"
+	)
+
+	newParentStoredID := strconv.Itoa(newParentID)
+	ownStoredID := strconv.Itoa(studioID)
+	remoteParentSiteID := remoteParentID
+	createMissing := true
+
+	mockStudioReaderWriter := &mocks.StudioReaderWriter{}
+	mockStudioReaderWriter.On("Create", testCtx, mock.MatchedBy(func(s models.Studio) bool {
+		return s.Name.String == parentName
+	})).Return(&models.Studio{ID: createdParentID}, nil)
+	mockStudioReaderWriter.On("UpdateStashIDs", testCtx, createdParentID, []models.StashID{
+		{Endpoint: endpoint, StashID: remoteParentID},
+	}).Return(nil)
+
+	mergeCreate := &FieldOptions{Field: "parent_studio", Strategy: FieldStrategyMerge, CreateMissing: &createMissing}
+	overwrite := &FieldOptions{Field: "parent_studio", Strategy: FieldStrategyOverwrite}
+
+	tests := []struct {
+		name         string
+		parentID     *int
+		scraped      *models.ScrapedStudio
+		fieldOptions *FieldOptions
+		want         *sql.NullInt64
+	}{
+		{
+			"create missing parent",
+			nil,
+			&models.ScrapedStudio{Name: parentName, RemoteSiteID: &remoteParentSiteID},
+			mergeCreate,
+			&sql.NullInt64{Int64: createdParentID, Valid: true},
+		},
+		{
+			"missing parent not created",
+			nil,
+			&models.ScrapedStudio{Name: parentName, RemoteSiteID: &remoteParentSiteID},
+			nil,
+			nil,
+		},
+		{
+			"sync parent",
+			nil,
+			&models.ScrapedStudio{Name: parentName, StoredID: &newParentStoredID},
+			nil,
+			&sql.NullInt64{Int64: newParentID, Valid: true},
+		},
+		{
+			"existing parent kept on merge",
+			func() *int { v := existingParentID; return &v }(),
+			&models.ScrapedStudio{Name: parentName, StoredID: &newParentStoredID},
+			nil,
+			nil,
+		},
+		{
+			"existing parent overwritten",
+			func() *int { v := existingParentID; return &v }(),
+			&models.ScrapedStudio{Name: parentName, StoredID: &newParentStoredID},
+			overwrite,
+			&sql.NullInt64{Int64: newParentID, Valid: true},
+		},
+		{
+			"own parent ignored",
+			nil,
+			&models.ScrapedStudio{Name: parentName, StoredID: &ownStoredID},
+			nil,
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &models.Studio{ID: studioID}
+			if tt.parentID != nil {
+				s.ParentID = sql.NullInt64{Int64: int64(*tt.parentID), Valid: true}
+			}
+
+			var fieldOptions []*FieldOptions
+			if tt.fieldOptions != nil {
+				fieldOptions = append(fieldOptions, tt.fieldOptions)
+			}
+
+			identifier := StudioIdentifier{
+				StudioReaderUpdater: mockStudioReaderWriter,
+				DefaultOptions: &MetadataOptions{
+					FieldOptions: fieldOptions,
+				},
+			}
+
+			got, err := identifier.getStudioUpdater(testCtx, s, &studioScrapeResult{
+				result: &models.ScrapedStudio{
+					Parent: tt.scraped,
+				},
+				source: StudioScraperSource{
+					RemoteSite: endpoint,
+				},
+			})
+			if err != nil {
+				t.Errorf("StudioIdentifier.getStudioUpdater() error = %v", err)
+				return
+			}
+
+			if !reflect.DeepEqual(got.Partial.ParentID, tt.want) {
+				t.Errorf("StudioIdentifier.getStudioUpdater() ParentID = %v, want %v", got.Partial.ParentID, tt.want)
+			}
+		})
+	}
+}
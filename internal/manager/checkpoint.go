@@ -0,0 +1,76 @@
+package manager
+
+import "sync"
+
+// idCheckpoint is the checkpoint of a job that processes objects in
+// ascending ID order.
+type idCheckpoint struct {
+	// LastID is the ID of the last processed object. All objects with a
+	// lower ID have also been processed.
+	LastID int `json:"last_id"`
+}
+
+// processed returns true if the object with the provided ID was processed
+// before the checkpoint was set.
+func (c idCheckpoint) processed(id int) bool {
+	return id <= c.LastID
+}
+
+// idCheckpointTracker tracks the checkpoint of a job that queues objects in
+// ascending ID order and processes them concurrently. The checkpoint only
+// advances past an object once all of its tasks, and those of all objects
+// queued before it, are done.
+type idCheckpointTracker struct {
+	mutex sync.Mutex
+	// ids of the objects with unfinished tasks, in queue order
+	queued []int
+	// number of unfinished tasks, keyed by object id
+	remaining map[int]int
+
+	checkpoint    idCheckpoint
+	setCheckpoint func(checkpoint interface{})
+}
+
+func newIDCheckpointTracker(checkpoint idCheckpoint, setCheckpoint func(checkpoint interface{})) *idCheckpointTracker {
+	return &idCheckpointTracker{
+		remaining:     make(map[int]int),
+		checkpoint:    checkpoint,
+		setCheckpoint: setCheckpoint,
+	}
+}
+
+// add adds an unfinished task for the object with the provided id. Objects
+// must be added in ascending ID order. To prevent the checkpoint advancing
+// past an object before all of its tasks are queued, the caller should add a
+// task for the object before queuing its tasks, and mark it done afterwards.
+func (t *idCheckpointTracker) add(id int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if _, found := t.remaining[id]; !found {
+		t.queued = append(t.queued, id)
+	}
+
+	t.remaining[id]++
+}
+
+// done marks a task of the object with the provided id as done, advancing
+// the checkpoint if possible.
+func (t *idCheckpointTracker) done(id int) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.remaining[id]--
+
+	advanced := false
+	for len(t.queued) > 0 && t.remaining[t.queued[0]] <= 0 {
+		t.checkpoint.LastID = t.queued[0]
+		delete(t.remaining, t.queued[0])
+		t.queued = t.queued[1:]
+		advanced = true
+	}
+
+	if advanced {
+		t.setCheckpoint(t.checkpoint)
+	}
+}
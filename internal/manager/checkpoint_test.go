@@ -0,0 +1,48 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDCheckpointTracker(t *testing.T) {
+	var checkpoints []int
+	tracker := newIDCheckpointTracker(idCheckpoint{}, func(checkpoint interface{}) {
+		checkpoints = append(checkpoints, checkpoint.(idCheckpoint).LastID)
+	})
+
+	// object 1 has two tasks, object 2 has none, object 3 has one
+	tracker.add(1)
+	tracker.add(1)
+	tracker.add(1)
+	tracker.done(1)
+
+	tracker.add(2)
+	tracker.done(2)
+
+	tracker.add(3)
+	tracker.add(3)
+	tracker.done(3)
+
+	// expect no checkpoint until the tasks of object 1 are done
+	assert.Len(t, checkpoints, 0)
+
+	// finishing the task of object 3 should not advance past object 1
+	tracker.done(3)
+	assert.Len(t, checkpoints, 0)
+
+	tracker.done(1)
+	assert.Len(t, checkpoints, 0)
+
+	tracker.done(1)
+	assert.Equal(t, []int{3}, checkpoints)
+}
+
+func TestIDCheckpointProcessed(t *testing.T) {
+	c := idCheckpoint{LastID: 2}
+
+	assert.True(t, c.processed(1))
+	assert.True(t, c.processed(2))
+	assert.False(t, c.processed(3))
+}
@@ -7,12 +7,14 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"sync"
 	// "github.com/sasha-s/go-deadlock" // if you have deadlock issues
 
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/spf13/cast"
 	"github.com/spf13/viper"
 
 	"github.com/stashapp/stash/internal/identify"
@@ -63,6 +65,23 @@ const (
 	ParallelTasks        = "parallel_tasks"
 	parallelTasksDefault = 1
 
+	// JobConcurrencyIO, JobConcurrencyCPU and JobConcurrencyNetwork are the
+	// config keys for the number of jobs of each resource class that are
+	// run concurrently.
+	JobConcurrencyIO      = "job_concurrency_io"
+	JobConcurrencyCPU     = "job_concurrency_cpu"
+	JobConcurrencyNetwork = "job_concurrency_network"
+	jobConcurrencyDefault = 1
+
+	// AutoResumeJobs is the config key for whether jobs interrupted by a
+	// restart are resumed automatically at startup.
+	AutoResumeJobs = "auto_resume_jobs"
+
+	// StashBoxFingerprintCheckInterval is the config key for the number of
+	// hours between automatic stash-box fingerprint checks. Zero disables
+	// automatic checks.
+	StashBoxFingerprintCheckInterval = "stash_box_fingerprint_check_interval"
+
 	PreviewPreset = "preview_preset"
 
 	PreviewAudio        = "preview_audio"
@@ -104,13 +123,31 @@ const (
 	ScraperCDPPath            = "scraper_cdp_path"
 	ScraperExcludeTagPatterns = "scraper_exclude_tag_patterns"
 
+	// scraper response cache options. The TTL is in minutes, and the
+	// maximum size is in megabytes.
+	ScraperCacheTTL            = "scraper_cache_ttl"
+	scraperCacheTTLDefault     = 60 * 24
+	ScraperCacheMaxSize        = "scraper_cache_max_size"
+	scraperCacheMaxSizeDefault = 100
+
+	// scraper rate limiting options. The rate limit is in requests per second
+	// per host. Requests are not limited if zero.
+	ScraperRateLimit         = "scraper_rate_limit"
+	ScraperMaxConcurrency    = "scraper_max_concurrency"
+	ScraperMaxRetries        = "scraper_max_retries"
+	scraperMaxRetriesDefault = 3
+
+	// secrets used by scraper login flows, keyed by scraper ID
+	ScrapersSecrets = "scrapers.secrets"
+
 	// stash-box options
 	StashBoxes = "stash_boxes"
 
 	PythonPath = "python_path"
 
 	// plugin options
-	PluginsPath = "plugins_path"
+	PluginsPath    = "plugins_path"
+	PluginsSetting = "plugins.settings"
 
 	// i18n
 	Language = "language"
@@ -447,6 +484,18 @@ func (i *Instance) getBoolDefault(key string, def bool) bool {
 	return ret
 }
 
+func (i *Instance) getIntDefault(key string, def int) int {
+	i.RLock()
+	defer i.RUnlock()
+
+	ret := def
+	v := i.viper(key)
+	if v.IsSet(key) {
+		ret = v.GetInt(key)
+	}
+	return ret
+}
+
 func (i *Instance) getInt(key string) int {
 	i.RLock()
 	defer i.RUnlock()
@@ -640,6 +689,58 @@ func (i *Instance) GetScraperCertCheck() bool {
 	return i.getBoolDefault(ScraperCertCheck, true)
 }
 
+// GetScraperCacheTTL returns the duration that scraper responses are cached
+// for. Caching is disabled if zero.
+func (i *Instance) GetScraperCacheTTL() time.Duration {
+	return time.Duration(i.getIntDefault(ScraperCacheTTL, scraperCacheTTLDefault)) * time.Minute
+}
+
+// GetScraperCacheMaxSize returns the maximum total size of the cached scraper
+// responses, in bytes. The size is not limited if zero.
+func (i *Instance) GetScraperCacheMaxSize() int64 {
+	return int64(i.getIntDefault(ScraperCacheMaxSize, scraperCacheMaxSizeDefault)) * 1024 * 1024
+}
+
+// GetScraperRateLimit returns the maximum number of scraper requests per
+// second made to a single host. Requests are not limited if zero.
+func (i *Instance) GetScraperRateLimit() float64 {
+	return i.getFloat64(ScraperRateLimit)
+}
+
+// GetScraperMaxConcurrency returns the maximum number of concurrent scraper
+// requests made to a single host. Requests are not limited if zero.
+func (i *Instance) GetScraperMaxConcurrency() int {
+	return i.getInt(ScraperMaxConcurrency)
+}
+
+// GetScraperMaxRetries returns the number of times that scraper requests
+// which fail with a transient error are retried.
+func (i *Instance) GetScraperMaxRetries() int {
+	return i.getIntDefault(ScraperMaxRetries, scraperMaxRetriesDefault)
+}
+
+// GetScraperSecrets returns the stored secrets of the scraper with the
+// provided ID. Secret names are returned in lower case.
+func (i *Instance) GetScraperSecrets(scraperID string) map[string]string {
+	i.RLock()
+	defer i.RUnlock()
+
+	// viper keys are case-insensitive, so scraper IDs are stored in lower case
+	v := i.viper(ScrapersSecrets).GetStringMap(ScrapersSecrets)
+	return cast.ToStringMapString(v[strings.ToLower(scraperID)])
+}
+
+// SetScraperSecrets sets the stored secrets of the scraper with the provided
+// ID, replacing any existing values.
+func (i *Instance) SetScraperSecrets(scraperID string, v map[string]string) {
+	i.Lock()
+	defer i.Unlock()
+
+	secrets := i.main.GetStringMap(ScrapersSecrets)
+	secrets[strings.ToLower(scraperID)] = v
+	i.main.Set(ScrapersSecrets, secrets)
+}
+
 func (i *Instance) GetScraperExcludeTagPatterns() []string {
 	return i.getStringSlice(ScraperExcludeTagPatterns)
 }
@@ -664,6 +765,28 @@ func (i *Instance) GetPluginsPath() string {
 	return i.getString(PluginsPath)
 }
 
+// GetPluginConfiguration returns the stored setting values of the plugin
+// with the provided ID. Setting names are returned in lower case.
+func (i *Instance) GetPluginConfiguration(pluginID string) map[string]interface{} {
+	i.RLock()
+	defer i.RUnlock()
+
+	// viper keys are case-insensitive, so plugin IDs are stored in lower case
+	v := i.viper(PluginsSetting).GetStringMap(PluginsSetting)
+	return cast.ToStringMap(v[strings.ToLower(pluginID)])
+}
+
+// SetPluginConfiguration sets the stored setting values of the plugin with
+// the provided ID, replacing any existing values.
+func (i *Instance) SetPluginConfiguration(pluginID string, v map[string]interface{}) {
+	i.Lock()
+	defer i.Unlock()
+
+	settings := i.main.GetStringMap(PluginsSetting)
+	settings[strings.ToLower(pluginID)] = v
+	i.main.Set(PluginsSetting, settings)
+}
+
 func (i *Instance) GetPythonPath() string {
 	return i.getString(PythonPath)
 }
@@ -714,6 +837,42 @@ func (i *Instance) GetParallelTasksWithAutoDetection() int {
 	return parallelTasks
 }
 
+// GetJobConcurrencyIO returns the number of IO-heavy jobs that are run
+// concurrently.
+func (i *Instance) GetJobConcurrencyIO() int {
+	return i.getIntDefault(JobConcurrencyIO, jobConcurrencyDefault)
+}
+
+// GetJobConcurrencyCPU returns the number of CPU-heavy jobs, such as generate
+// jobs, that are run concurrently.
+func (i *Instance) GetJobConcurrencyCPU() int {
+	return i.getIntDefault(JobConcurrencyCPU, jobConcurrencyDefault)
+}
+
+// GetJobConcurrencyNetwork returns the number of network-bound jobs, such as
+// identify jobs, that are run concurrently.
+func (i *Instance) GetJobConcurrencyNetwork() int {
+	return i.getIntDefault(JobConcurrencyNetwork, jobConcurrencyDefault)
+}
+
+// GetAutoResumeJobs returns true if jobs that were interrupted by a restart
+// should be resumed automatically at startup.
+func (i *Instance) GetAutoResumeJobs() bool {
+	return i.getBool(AutoResumeJobs)
+}
+
+// GetStashBoxFingerprintCheckInterval returns the interval between
+// automatic stash-box fingerprint checks. Returns zero if automatic checks
+// are disabled.
+func (i *Instance) GetStashBoxFingerprintCheckInterval() time.Duration {
+	hours := i.getInt(StashBoxFingerprintCheckInterval)
+	if hours <= 0 {
+		return 0
+	}
+
+	return time.Duration(hours) * time.Hour
+}
+
 func (i *Instance) GetPreviewAudio() bool {
 	return i.getBool(PreviewAudio)
 }
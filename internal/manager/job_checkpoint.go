@@ -0,0 +1,306 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// ErrJobResumed is returned when resuming or discarding an interrupted job
+// that has already been resumed.
+var ErrJobResumed = errors.New("job has already been resumed")
+
+// resumeJobFunc recreates a resumable job from its JSON-encoded input and
+// checkpoint. The checkpoint is nil if the job did not set a checkpoint.
+type resumeJobFunc func(s *Manager, input []byte, checkpoint []byte) (job.JobExec, error)
+
+// resumeJobFuncs contains the functions used to recreate resumable jobs,
+// keyed by job type.
+var resumeJobFuncs = map[string]resumeJobFunc{
+	generateJobType: resumeGenerateJob,
+	identifyJobType: resumeIdentifyJob,
+	autoTagJobType:  resumeAutoTagJob,
+	scanJobType:     resumeScanJob,
+	exportJobType:   resumeExportJob,
+}
+
+// unmarshalCheckpoint decodes a JSON-encoded checkpoint into v. Has no effect
+// if the checkpoint is nil.
+func unmarshalCheckpoint(checkpoint []byte, v interface{}) error {
+	if checkpoint == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(checkpoint, v); err != nil {
+		return fmt.Errorf("decoding checkpoint: %w", err)
+	}
+
+	return nil
+}
+
+// jobCheckpointWriter persists the checkpoints of resumable jobs to the
+// database, so that the jobs can be resumed after a restart.
+type jobCheckpointWriter struct {
+	txnManager models.TxnManager
+	repository models.JobCheckpointReaderWriter
+
+	// held while writing, and while queuing a resumed job so that its
+	// checkpoint is associated with the job before the job can write it
+	mutex sync.Mutex
+	// ids of the stored checkpoints of queued jobs, keyed by job id
+	checkpoints map[int]int
+}
+
+func newJobCheckpointWriter(repo Repository) *jobCheckpointWriter {
+	return &jobCheckpointWriter{
+		txnManager:  repo,
+		repository:  repo.JobCheckpoint,
+		checkpoints: make(map[int]int),
+	}
+}
+
+func (w *jobCheckpointWriter) WriteCheckpoint(j job.Job) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	// the checkpoint is not set when the job starts
+	var checkpoint sql.NullString
+	if j.Checkpoint != nil {
+		encoded, err := json.Marshal(j.Checkpoint)
+		if err != nil {
+			logger.Warnf("error encoding checkpoint of job %q: %v", j.Description, err)
+			return
+		}
+		checkpoint = sql.NullString{String: string(encoded), Valid: true}
+	}
+
+	now := models.SQLiteTimestamp{Timestamp: time.Now()}
+
+	ctx := context.Background()
+	if err := txn.WithTxn(ctx, w.txnManager, func(ctx context.Context) error {
+		id, found := w.checkpoints[j.ID]
+		if found {
+			existing, err := w.repository.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			if existing != nil {
+				// keep the stored checkpoint of a resumed job until the job
+				// sets a new one
+				if !checkpoint.Valid {
+					return nil
+				}
+
+				existing.Checkpoint = checkpoint
+				existing.UpdatedAt = now
+				_, err := w.repository.Update(ctx, *existing)
+				return err
+			}
+		}
+
+		c := models.JobCheckpoint{
+			Type:        j.Type,
+			Description: j.Description,
+			Checkpoint:  checkpoint,
+			AddTime:     models.SQLiteTimestamp{Timestamp: j.AddTime},
+			UpdatedAt:   now,
+		}
+
+		if j.Input != nil {
+			input, err := json.Marshal(j.Input)
+			if err != nil {
+				return fmt.Errorf("encoding input: %w", err)
+			}
+			c.Input = sql.NullString{String: string(input), Valid: true}
+		}
+
+		created, err := w.repository.Create(ctx, c)
+		if err != nil {
+			return err
+		}
+
+		w.checkpoints[j.ID] = created.ID
+		return nil
+	}); err != nil {
+		logger.Errorf("error writing checkpoint of job %q: %v", j.Description, err)
+	}
+}
+
+func (w *jobCheckpointWriter) RemoveCheckpoint(j job.Job) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	id, found := w.checkpoints[j.ID]
+	if !found {
+		return
+	}
+
+	delete(w.checkpoints, j.ID)
+
+	if err := txn.WithTxn(context.Background(), w.txnManager, func(ctx context.Context) error {
+		return w.repository.Destroy(ctx, id)
+	}); err != nil {
+		logger.Errorf("error removing checkpoint of job %q: %v", j.Description, err)
+	}
+}
+
+func (w *jobCheckpointWriter) isQueued(checkpointID int) bool {
+	// assumes lock held
+	for _, id := range w.checkpoints {
+		if id == checkpointID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// interruptedJobs returns the stored checkpoints of jobs that were not
+// finished when stash was stopped, and which have not since been resumed.
+func (w *jobCheckpointWriter) interruptedJobs(ctx context.Context) ([]*models.JobCheckpoint, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var all []*models.JobCheckpoint
+	if err := txn.WithDatabase(ctx, w.txnManager, func(ctx context.Context) error {
+		var err error
+		all, err = w.repository.All(ctx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	var ret []*models.JobCheckpoint
+	for _, c := range all {
+		if !w.isQueued(c.ID) {
+			ret = append(ret, c)
+		}
+	}
+
+	return ret, nil
+}
+
+// resume recreates the interrupted job with the provided checkpoint id and
+// adds it to the job manager. Returns the id of the queued job.
+func (w *jobCheckpointWriter) resume(ctx context.Context, s *Manager, checkpointID int) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.isQueued(checkpointID) {
+		return 0, ErrJobResumed
+	}
+
+	var c *models.JobCheckpoint
+	if err := txn.WithDatabase(ctx, w.txnManager, func(ctx context.Context) error {
+		var err error
+		c, err = w.repository.Find(ctx, checkpointID)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+
+	if c == nil {
+		return 0, fmt.Errorf("%w: interrupted job with id %d", models.ErrNotFound, checkpointID)
+	}
+
+	fn := resumeJobFuncs[c.Type]
+	if fn == nil {
+		return 0, fmt.Errorf("cannot resume job of unknown type %q", c.Type)
+	}
+
+	var input, checkpoint []byte
+	if c.Input.Valid {
+		input = []byte(c.Input.String)
+	}
+	if c.Checkpoint.Valid {
+		checkpoint = []byte(c.Checkpoint.String)
+	}
+
+	e, err := fn(s, input, checkpoint)
+	if err != nil {
+		return 0, fmt.Errorf("resuming job %q: %w", c.Description, err)
+	}
+
+	// the job cannot write its checkpoint until the lock is released
+	jobID := s.JobManager.Add(ctx, c.Description, e)
+	w.checkpoints[jobID] = c.ID
+
+	return jobID, nil
+}
+
+// discard removes the stored checkpoint of an interrupted job, so that it is
+// no longer offered for resumption.
+func (w *jobCheckpointWriter) discard(ctx context.Context, checkpointID int) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.isQueued(checkpointID) {
+		return ErrJobResumed
+	}
+
+	return txn.WithTxn(ctx, w.txnManager, func(ctx context.Context) error {
+		return w.repository.Destroy(ctx, checkpointID)
+	})
+}
+
+// InterruptedJobs returns the jobs that were not finished when stash was
+// stopped, and which may be resumed using ResumeInterruptedJob.
+func (s *Manager) InterruptedJobs(ctx context.Context) ([]*models.JobCheckpoint, error) {
+	return s.checkpointWriter.interruptedJobs(ctx)
+}
+
+// ResumeInterruptedJob queues the interrupted job with the provided id,
+// resuming it from its last checkpoint. Returns the id of the queued job.
+func (s *Manager) ResumeInterruptedJob(ctx context.Context, id int) (int, error) {
+	return s.checkpointWriter.resume(ctx, s, id)
+}
+
+// DiscardInterruptedJob discards the interrupted job with the provided id.
+func (s *Manager) DiscardInterruptedJob(ctx context.Context, id int) error {
+	return s.checkpointWriter.discard(ctx, id)
+}
+
+// resumeInterruptedJobs queues all interrupted jobs if automatic resumption
+// is enabled. Otherwise, the interrupted jobs are left to be resumed by the
+// user.
+func (s *Manager) resumeInterruptedJobs(ctx context.Context) {
+	jobs, err := s.InterruptedJobs(ctx)
+	if err != nil {
+		logger.Errorf("error getting interrupted jobs: %v", err)
+		return
+	}
+
+	if len(jobs) == 0 {
+		return
+	}
+
+	if !s.Config.GetAutoResumeJobs() {
+		logger.Infof("%d interrupted jobs can be resumed", len(jobs))
+		return
+	}
+
+	for _, c := range jobs {
+		if _, err := s.ResumeInterruptedJob(ctx, c.ID); err != nil {
+			logger.Errorf("error resuming interrupted job %q: %v", c.Description, err)
+			continue
+		}
+
+		logger.Infof("Resumed interrupted job %q", c.Description)
+	}
+}
+
+func initJobCheckpoints(m *job.Manager, repo Repository) *jobCheckpointWriter {
+	ret := newJobCheckpointWriter(repo)
+	m.SetCheckpointWriter(ret)
+	return ret
+}
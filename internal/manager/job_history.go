@@ -0,0 +1,87 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// maxJobHistory is the number of job records kept in the database.
+const maxJobHistory = 1000
+
+// jobHistoryWriter persists jobs removed from the job queue to the database.
+type jobHistoryWriter struct {
+	txnManager txn.Manager
+	repository models.JobRecordReaderWriter
+}
+
+func (w *jobHistoryWriter) RecordJob(j job.Job) {
+	// jobs that were never started are not recorded
+	if j.StartTime == nil {
+		return
+	}
+
+	r := models.JobRecord{
+		Description: j.Description,
+		Status:      string(j.Status),
+		Created:     j.Summary.Created,
+		Updated:     j.Summary.Updated,
+		Skipped:     j.Summary.Skipped,
+		AddTime:     models.SQLiteTimestamp{Timestamp: j.AddTime},
+		StartTime:   models.NullSQLiteTimestamp{Timestamp: *j.StartTime, Valid: true},
+	}
+
+	if j.EndTime != nil {
+		r.EndTime = models.NullSQLiteTimestamp{Timestamp: *j.EndTime, Valid: true}
+	}
+
+	if j.Error != nil {
+		r.Error = sql.NullString{String: *j.Error, Valid: true}
+	}
+
+	if j.Input != nil {
+		input, err := json.Marshal(j.Input)
+		if err != nil {
+			logger.Warnf("error encoding input of job %q: %v", j.Description, err)
+		} else {
+			r.Input = sql.NullString{String: string(input), Valid: true}
+		}
+	}
+
+	logs := make([]models.JobRecordLog, len(j.Logs))
+	for i, l := range j.Logs {
+		logs[i] = models.JobRecordLog{
+			Time:    models.SQLiteTimestamp{Timestamp: l.Time},
+			Level:   l.Level,
+			Message: l.Message,
+		}
+	}
+
+	ctx := context.Background()
+	if err := txn.WithTxn(ctx, w.txnManager, func(ctx context.Context) error {
+		created, err := w.repository.Create(ctx, r)
+		if err != nil {
+			return err
+		}
+
+		if err := w.repository.UpdateLogs(ctx, created.ID, logs); err != nil {
+			return err
+		}
+
+		return w.repository.Prune(ctx, maxJobHistory)
+	}); err != nil {
+		logger.Errorf("error recording history of job %q: %v", j.Description, err)
+	}
+}
+
+func initJobHistory(m *job.Manager, repo Repository) {
+	m.SetHistoryWriter(&jobHistoryWriter{
+		txnManager: repo,
+		repository: repo.JobRecord,
+	})
+}
@@ -19,6 +19,14 @@ func (jp *jsonUtils) saveScaped(scraped []jsonschema.ScrapedItem) error {
 	return jsonschema.SaveScrapedFile(jp.json.ScrapedFile, scraped)
 }
 
+func (jp *jsonUtils) getCustomFields() ([]jsonschema.CustomFieldDefinition, error) {
+	return jsonschema.LoadCustomFieldsFile(jp.json.CustomFieldsFile)
+}
+
+func (jp *jsonUtils) saveCustomFields(definitions []jsonschema.CustomFieldDefinition) error {
+	return jsonschema.SaveCustomFieldsFile(jp.json.CustomFieldsFile, definitions)
+}
+
 func (jp *jsonUtils) savePerformer(fn string, performer *jsonschema.Performer) error {
 	return jsonschema.SavePerformerFile(filepath.Join(jp.json.Performers, fn), performer)
 }
@@ -117,6 +117,8 @@ type Manager struct {
 
 	JobManager *job.Manager
 
+	checkpointWriter *jobCheckpointWriter
+
 	PluginCache  *plugin.Cache
 	ScraperCache *scraper.Cache
 
@@ -209,6 +211,8 @@ func initialize() error {
 	}
 
 	instance.JobManager = initJobManager()
+	initJobHistory(instance.JobManager, instance.Repository)
+	instance.checkpointWriter = initJobCheckpoints(instance.JobManager, instance.Repository)
 
 	sceneServer := SceneServer{
 		TxnManager:       instance.Repository,
@@ -491,6 +495,9 @@ func (s *Manager) PostInit(ctx context.Context) error {
 		return err
 	}
 
+	s.resumeInterruptedJobs(ctx)
+	s.startStashBoxFingerprintCheckScheduler()
+
 	return nil
 }
 
@@ -511,11 +518,12 @@ func (s *Manager) initScraperCache() *scraper.Cache {
 	ret, err := scraper.NewCache(config.GetInstance(), s.Repository, scraper.Repository{
 		SceneFinder:     s.Repository.Scene,
 		GalleryFinder:   s.Repository.Gallery,
+		ImageFinder:     s.Repository.Image,
 		TagFinder:       s.Repository.Tag,
 		PerformerFinder: s.Repository.Performer,
 		MovieFinder:     s.Repository.Movie,
 		StudioFinder:    s.Repository.Studio,
-	})
+	}, s.PluginCache)
 
 	if err != nil {
 		logger.Errorf("Error reading scraper configs: %s", err.Error())
@@ -547,6 +555,10 @@ func (s *Manager) RefreshConfig() {
 			logger.Warnf("could not create directory for Interactive Heatmaps: %v", err)
 		}
 	}
+
+	s.JobManager.SetConcurrency(job.ResourceIO, config.GetJobConcurrencyIO())
+	s.JobManager.SetConcurrency(job.ResourceCPU, config.GetJobConcurrencyCPU())
+	s.JobManager.SetConcurrency(job.ResourceNetwork, config.GetJobConcurrencyNetwork())
 }
 
 // RefreshScraperCache refreshes the scraper cache. Call this when scraper
@@ -720,7 +732,9 @@ func (s *Manager) Shutdown(code int) {
 	pprof.StopCPUProfile()
 
 	// TODO: Each part of the manager needs to gracefully stop at some point
-	// for now, we just close the database.
+	// for now, we just stop plugin services and close the database.
+	s.PluginCache.StopServices()
+
 	err := s.Database.Close()
 	if err != nil {
 		logger.Errorf("Error closing database: %s", err)
@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/stashapp/stash/internal/identify"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/job"
@@ -96,7 +97,7 @@ func (s *Manager) Import(ctx context.Context) (int, error) {
 		return 0, errors.New("metadata path must be set in config")
 	}
 
-	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+	j := job.MakeJobExecWithClass(job.ResourceExclusive, func(ctx context.Context, progress *job.Progress) {
 		task := ImportTask{
 			txnManager:          s.Repository,
 			BaseDir:             metadataPath,
@@ -118,16 +119,10 @@ func (s *Manager) Export(ctx context.Context) (int, error) {
 		return 0, errors.New("metadata path must be set in config")
 	}
 
-	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
-		var wg sync.WaitGroup
-		wg.Add(1)
-		task := ExportTask{
-			txnManager:          s.Repository,
-			full:                true,
-			fileNamingAlgorithm: config.GetVideoFileNamingAlgorithm(),
-		}
-		task.Start(ctx, &wg)
-	})
+	j := &exportJob{
+		txnManager:          s.Repository,
+		fileNamingAlgorithm: config.GetVideoFileNamingAlgorithm(),
+	}
 
 	return s.JobManager.Add(ctx, "Exporting...", j), nil
 }
@@ -136,7 +131,12 @@ func (s *Manager) RunSingleTask(ctx context.Context, t Task) int {
 	var wg sync.WaitGroup
 	wg.Add(1)
 
-	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+	class := job.ResourceIO
+	if r, ok := t.(job.ResourceClassReporter); ok {
+		class = r.ResourceClass()
+	}
+
+	j := job.MakeJobExecWithClass(class, func(ctx context.Context, progress *job.Progress) {
 		t.Start(ctx)
 		wg.Done()
 	})
@@ -149,7 +149,7 @@ func (s *Manager) Generate(ctx context.Context, input GenerateMetadataInput) (in
 		return 0, err
 	}
 	if err := instance.Paths.Generated.EnsureTmpDir(); err != nil {
-		logger.Warnf("could not generate temporary directory: %v", err)
+		job.Logger(ctx).Warnf("could not generate temporary directory: %v", err)
 	}
 
 	j := &GenerateJob{
@@ -171,13 +171,13 @@ func (s *Manager) GenerateScreenshot(ctx context.Context, sceneId string, at flo
 // generate default screenshot if at is nil
 func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, at *float64) int {
 	if err := instance.Paths.Generated.EnsureTmpDir(); err != nil {
-		logger.Warnf("failure generating screenshot: %v", err)
+		job.Logger(ctx).Warnf("failure generating screenshot: %v", err)
 	}
 
-	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+	j := job.MakeJobExecWithClass(job.ResourceCPU, func(ctx context.Context, progress *job.Progress) {
 		sceneIdInt, err := strconv.Atoi(sceneId)
 		if err != nil {
-			logger.Errorf("Error parsing scene id %s: %s", sceneId, err.Error())
+			job.Logger(ctx).Errorf("Error parsing scene id %s: %s", sceneId, err.Error())
 			return
 		}
 
@@ -190,7 +190,7 @@ func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, at *fl
 			}
 			return err
 		}); err != nil || scene == nil {
-			logger.Errorf("failed to get scene for generate: %s", err.Error())
+			job.Logger(ctx).Errorf("failed to get scene for generate: %s", err.Error())
 			return
 		}
 
@@ -203,7 +203,7 @@ func (s *Manager) generateScreenshot(ctx context.Context, sceneId string, at *fl
 
 		task.Start(ctx)
 
-		logger.Infof("Generate screenshot finished")
+		job.Logger(ctx).Infof("Generate screenshot finished")
 	})
 
 	return s.JobManager.Add(ctx, fmt.Sprintf("Generating screenshot for scene id %s", sceneId), j)
@@ -248,10 +248,51 @@ func (s *Manager) Clean(ctx context.Context, input CleanMetadataInput) int {
 	return s.JobManager.Add(ctx, "Cleaning...", &j)
 }
 
+// BackupDatabase backs up the database to the provided path. The backup runs
+// as an exclusive job, so it waits for running jobs to finish and no other
+// jobs are started until it is complete.
+func (s *Manager) BackupDatabase(ctx context.Context, backupPath string) error {
+	done := make(chan error, 1)
+	j := job.MakeJobExecWithClass(job.ResourceExclusive, func(ctx context.Context, progress *job.Progress) {
+		done <- s.Database.Backup(backupPath)
+	})
+
+	sub := s.JobManager.Subscribe(ctx)
+	jobID := s.JobManager.Add(ctx, "Backing up database...", j)
+
+	for {
+		select {
+		case err := <-done:
+			return err
+		case removed, ok := <-sub.RemovedJob:
+			if !ok {
+				// the subscription is closed when the context is done
+				s.JobManager.CancelJob(jobID)
+				return ctx.Err()
+			}
+
+			if removed.ID != jobID {
+				continue
+			}
+
+			// the job may have finished before being removed
+			select {
+			case err := <-done:
+				return err
+			default:
+				return errors.New("database backup was cancelled")
+			}
+		case <-ctx.Done():
+			s.JobManager.CancelJob(jobID)
+			return ctx.Err()
+		}
+	}
+}
+
 func (s *Manager) MigrateHash(ctx context.Context) int {
-	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
+	j := job.MakeJobExecWithClass(job.ResourceExclusive, func(ctx context.Context, progress *job.Progress) {
 		fileNamingAlgo := config.GetInstance().GetVideoFileNamingAlgorithm()
-		logger.Infof("Migrating generated files for %s naming hash", fileNamingAlgo.String())
+		job.Logger(ctx).Infof("Migrating generated files for %s naming hash", fileNamingAlgo.String())
 
 		var scenes []*models.Scene
 		if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
@@ -259,7 +300,7 @@ func (s *Manager) MigrateHash(ctx context.Context) int {
 			scenes, err = s.Repository.Scene.All(ctx)
 			return err
 		}); err != nil {
-			logger.Errorf("failed to fetch list of scenes for migration: %s", err.Error())
+			job.Logger(ctx).Errorf("failed to fetch list of scenes for migration: %s", err.Error())
 			return
 		}
 
@@ -270,12 +311,12 @@ func (s *Manager) MigrateHash(ctx context.Context) int {
 		for _, scene := range scenes {
 			progress.Increment()
 			if job.IsCancelled(ctx) {
-				logger.Info("Stopping due to user request")
+				job.Logger(ctx).Info("Stopping due to user request")
 				return
 			}
 
 			if scene == nil {
-				logger.Errorf("nil scene, skipping migrate")
+				job.Logger(ctx).Errorf("nil scene, skipping migrate")
 				continue
 			}
 
@@ -290,7 +331,7 @@ func (s *Manager) MigrateHash(ctx context.Context) int {
 			wg.Wait()
 		}
 
-		logger.Info("Finished migrating")
+		job.Logger(ctx).Info("Finished migrating")
 	})
 
 	return s.JobManager.Add(ctx, "Migrating scene hashes...", j)
@@ -308,18 +349,21 @@ type StashBoxBatchPerformerTagInput struct {
 	PerformerIds []string `json:"performer_ids"`
 	// If set, only tag these performer names
 	PerformerNames []string `json:"performer_names"`
+	// Add stash-box images to the performer's images instead of replacing the primary image if true
+	AppendImages *bool `json:"append_images"`
 }
 
 func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, input StashBoxBatchPerformerTagInput) int {
-	j := job.MakeJobExec(func(ctx context.Context, progress *job.Progress) {
-		logger.Infof("Initiating stash-box batch performer tag")
+	j := job.MakeJobExecWithClass(job.ResourceNetwork, func(ctx context.Context, progress *job.Progress) {
+		job.Logger(ctx).Infof("Initiating stash-box batch performer tag")
 
 		boxes := config.GetInstance().GetStashBoxes()
 		if input.Endpoint < 0 || input.Endpoint >= len(boxes) {
-			logger.Error(fmt.Errorf("invalid stash_box_index %d", input.Endpoint))
+			job.Logger(ctx).Error(fmt.Errorf("invalid stash_box_index %d", input.Endpoint))
 			return
 		}
 		box := boxes[input.Endpoint]
+		appendImages := input.AppendImages != nil && *input.AppendImages
 
 		var tasks []StashBoxPerformerTagTask
 
@@ -342,6 +386,7 @@ func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, input StashBoxB
 								refresh:         input.Refresh,
 								box:             box,
 								excluded_fields: input.ExcludeFields,
+								appendImages:    appendImages,
 							})
 						} else {
 							return err
@@ -350,7 +395,7 @@ func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, input StashBoxB
 				}
 				return nil
 			}); err != nil {
-				logger.Error(err.Error())
+				job.Logger(ctx).Error(err.Error())
 			}
 		} else if len(input.PerformerNames) > 0 {
 			for i := range input.PerformerNames {
@@ -360,6 +405,7 @@ func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, input StashBoxB
 						refresh:         input.Refresh,
 						box:             box,
 						excluded_fields: input.ExcludeFields,
+						appendImages:    appendImages,
 					})
 				}
 			}
@@ -387,11 +433,12 @@ func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, input StashBoxB
 						refresh:         input.Refresh,
 						box:             box,
 						excluded_fields: input.ExcludeFields,
+						appendImages:    appendImages,
 					})
 				}
 				return nil
 			}); err != nil {
-				logger.Error(err.Error())
+				job.Logger(ctx).Error(err.Error())
 				return
 			}
 		}
@@ -402,7 +449,7 @@ func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, input StashBoxB
 
 		progress.SetTotal(len(tasks))
 
-		logger.Infof("Starting stash-box batch operation for %d performers", len(tasks))
+		job.Logger(ctx).Infof("Starting stash-box batch operation for %d performers", len(tasks))
 
 		var wg sync.WaitGroup
 		for _, task := range tasks {
@@ -418,3 +465,174 @@ func (s *Manager) StashBoxBatchPerformerTag(ctx context.Context, input StashBoxB
 
 	return s.JobManager.Add(ctx, "Batch stash-box performer tag...", j)
 }
+
+type StashBoxBatchStudioTagInput struct {
+	// Stash endpoint to use for the studio tagging
+	Endpoint int `json:"endpoint"`
+	// Refresh studios already tagged by StashBox if true. Only tag studios with no StashBox tagging if false
+	Refresh bool `json:"refresh"`
+	// If set, only tag these studio ids
+	StudioIds []string `json:"studio_ids"`
+	// If set, only tag these studio names. Studios that do not exist are created
+	StudioNames []string `json:"studio_names"`
+	// Strategies of the fields to set. Fields missing from here are defaulted
+	// to MERGE, and missing parent studios are created unless a strategy for
+	// parent_studio is provided
+	FieldOptions []*identify.FieldOptions `json:"field_options"`
+}
+
+// getStashBoxStudioTagOptions returns the metadata options for the studio
+// tag tasks, creating missing parent studios by default.
+func getStashBoxStudioTagOptions(input StashBoxBatchStudioTagInput) *identify.MetadataOptions {
+	ret := &identify.MetadataOptions{
+		FieldOptions: input.FieldOptions,
+	}
+
+	for _, f := range input.FieldOptions {
+		if f.Field == "parent_studio" {
+			return ret
+		}
+	}
+
+	createMissing := true
+	ret.FieldOptions = append(ret.FieldOptions, &identify.FieldOptions{
+		Field:         "parent_studio",
+		Strategy:      identify.FieldStrategyMerge,
+		CreateMissing: &createMissing,
+	})
+
+	return ret
+}
+
+func (s *Manager) getStashBoxStudioTagTasks(ctx context.Context, input StashBoxBatchStudioTagInput, box *models.StashBox) ([]StashBoxStudioTagTask, error) {
+	options := getStashBoxStudioTagOptions(input)
+
+	if len(input.StudioNames) > 0 && len(input.StudioIds) == 0 {
+		var tasks []StashBoxStudioTagTask
+		for i := range input.StudioNames {
+			if len(input.StudioNames[i]) > 0 {
+				tasks = append(tasks, StashBoxStudioTagTask{
+					name:    &input.StudioNames[i],
+					box:     box,
+					options: options,
+				})
+			}
+		}
+		return tasks, nil
+	}
+
+	var studios []*models.Studio
+	if err := s.Repository.WithTxn(ctx, func(ctx context.Context) error {
+		qb := s.Repository.Studio
+
+		if len(input.StudioIds) == 0 {
+			var err error
+			studios, err = qb.FindByStashIDStatus(ctx, input.Refresh, box.Endpoint)
+			if err != nil {
+				return fmt.Errorf("error querying studios: %w", err)
+			}
+			return nil
+		}
+
+		for _, studioID := range input.StudioIds {
+			id, err := strconv.Atoi(studioID)
+			if err != nil {
+				return fmt.Errorf("invalid studio id %s: %w", studioID, err)
+			}
+
+			studio, err := qb.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if studio == nil {
+				return fmt.Errorf("%w: studio with id %d", models.ErrNotFound, id)
+			}
+
+			studios = append(studios, studio)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	tasks := make([]StashBoxStudioTagTask, len(studios))
+	for i, studio := range studios {
+		tasks[i] = StashBoxStudioTagTask{
+			studio:  studio,
+			box:     box,
+			options: options,
+		}
+	}
+
+	return tasks, nil
+}
+
+func (s *Manager) StashBoxBatchStudioTag(ctx context.Context, input StashBoxBatchStudioTagInput) int {
+	j := job.MakeJobExecWithClass(job.ResourceNetwork, func(ctx context.Context, progress *job.Progress) {
+		job.Logger(ctx).Infof("Initiating stash-box batch studio tag")
+
+		boxes := config.GetInstance().GetStashBoxes()
+		if input.Endpoint < 0 || input.Endpoint >= len(boxes) {
+			err := fmt.Errorf("invalid stash_box_index %d", input.Endpoint)
+			job.Logger(ctx).Error(err)
+			progress.SetError(err)
+			return
+		}
+		box := boxes[input.Endpoint]
+
+		tasks, err := s.getStashBoxStudioTagTasks(ctx, input, box)
+		if err != nil {
+			job.Logger(ctx).Error(err.Error())
+			progress.SetError(err)
+			return
+		}
+
+		if len(tasks) == 0 {
+			return
+		}
+
+		progress.SetTotal(len(tasks))
+
+		job.Logger(ctx).Infof("Starting stash-box batch operation for %d studios", len(tasks))
+
+		for _, task := range tasks {
+			if job.IsCancelled(ctx) {
+				job.Logger(ctx).Info("Stopping due to user request")
+				return
+			}
+
+			progress.ExecuteTask(task.Description(), func() {
+				if err := task.Start(ctx, progress); err != nil {
+					job.Logger(ctx).Errorf("Error tagging studio: %v", err)
+				}
+			})
+
+			progress.Increment()
+		}
+	})
+
+	return s.JobManager.Add(ctx, "Batch stash-box studio tag...", j)
+}
+
+func (s *Manager) StashBoxFingerprintCheck(ctx context.Context, input StashBoxFingerprintCheckInput) (int, error) {
+	boxes := config.GetInstance().GetStashBoxes()
+	if input.Endpoint < 0 || input.Endpoint >= len(boxes) {
+		return 0, fmt.Errorf("invalid stash_box_index %d", input.Endpoint)
+	}
+
+	task := &StashBoxFingerprintCheckTask{
+		box:   boxes[input.Endpoint],
+		input: input,
+	}
+
+	j := job.MakeJobExecWithClass(job.ResourceNetwork, func(ctx context.Context, progress *job.Progress) {
+		job.Logger(ctx).Infof("Initiating stash-box fingerprint check")
+
+		if err := task.Start(ctx, progress); err != nil {
+			job.Logger(ctx).Errorf("Error checking stash-box fingerprints: %v", err)
+			progress.SetError(err)
+		}
+	})
+
+	return s.JobManager.Add(ctx, "Checking stash-box fingerprints...", j), nil
+}
@@ -61,6 +61,14 @@ type Repository struct {
 	Studio      models.StudioReaderWriter
 	Tag         models.TagReaderWriter
 	SavedFilter models.SavedFilterReaderWriter
+
+	ScenePendingChange models.ScenePendingChangeReaderWriter
+	JobRecord          models.JobRecordReaderWriter
+	JobCheckpoint      models.JobCheckpointReaderWriter
+	StashBoxSubmission models.StashBoxSubmissionReaderWriter
+	SceneStashBoxCheck models.SceneStashBoxCheckReaderWriter
+
+	CustomFieldDefinition models.CustomFieldDefinitionReaderWriter
 }
 
 func (r *Repository) WithTxn(ctx context.Context, fn txn.TxnFunc) error {
@@ -88,6 +96,14 @@ func sqliteRepository(d *sqlite.Database) Repository {
 		Studio:      txnRepo.Studio,
 		Tag:         txnRepo.Tag,
 		SavedFilter: txnRepo.SavedFilter,
+
+		ScenePendingChange: txnRepo.ScenePendingChange,
+		JobRecord:          txnRepo.JobRecord,
+		JobCheckpoint:      txnRepo.JobCheckpoint,
+		StashBoxSubmission: txnRepo.StashBoxSubmission,
+		SceneStashBoxCheck: txnRepo.SceneStashBoxCheck,
+
+		CustomFieldDefinition: txnRepo.CustomFieldDefinition,
 	}
 }
 
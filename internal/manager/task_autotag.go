@@ -2,8 +2,10 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -12,17 +14,98 @@ import (
 	"github.com/stashapp/stash/internal/autotag"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
-	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
+const autoTagJobType = "autotag"
+
+// the stages of an auto-tag job, in the order that they are processed
+const (
+	autoTagStagePerformers = "performers"
+	autoTagStageStudios    = "studios"
+	autoTagStageTags       = "tags"
+	autoTagStageScenes     = "scenes"
+	autoTagStageImages     = "images"
+	autoTagStageGalleries  = "galleries"
+)
+
+var autoTagStages = []string{
+	autoTagStagePerformers,
+	autoTagStageStudios,
+	autoTagStageTags,
+	autoTagStageScenes,
+	autoTagStageImages,
+	autoTagStageGalleries,
+}
+
+// autoTagCheckpoint is the checkpoint of an auto-tag job. Objects of each
+// stage are processed in ascending ID order.
+type autoTagCheckpoint struct {
+	// Stage being processed when the checkpoint was set
+	Stage string `json:"stage"`
+	idCheckpoint
+}
+
+// processed returns true if the object with the provided id was processed
+// in the provided stage before the checkpoint was set.
+func (c autoTagCheckpoint) processed(stage string, id int) bool {
+	if c.Stage == "" {
+		return false
+	}
+
+	stageIndex := stringslice.StrIndex(autoTagStages, stage)
+	checkpointIndex := stringslice.StrIndex(autoTagStages, c.Stage)
+	if stageIndex != checkpointIndex {
+		return stageIndex < checkpointIndex
+	}
+
+	return c.idCheckpoint.processed(id)
+}
+
+func setAutoTagCheckpoint(progress *job.Progress, stage string, id int) {
+	progress.SetCheckpoint(autoTagCheckpoint{
+		Stage:        stage,
+		idCheckpoint: idCheckpoint{LastID: id},
+	})
+}
+
 type autoTagJob struct {
 	txnManager Repository
 	input      AutoTagMetadataInput
 
 	cache match.Cache
+
+	// objects up to the checkpoint are skipped when resuming
+	checkpoint autoTagCheckpoint
+}
+
+// JobInput returns the input of the job to be recorded in the job history.
+func (j *autoTagJob) JobInput() interface{} {
+	return j.input
+}
+
+// JobType returns the type used to resume the job.
+func (j *autoTagJob) JobType() string {
+	return autoTagJobType
+}
+
+func resumeAutoTagJob(s *Manager, input []byte, checkpoint []byte) (job.JobExec, error) {
+	ret := &autoTagJob{
+		txnManager: s.Repository,
+	}
+
+	if err := json.Unmarshal(input, &ret.input); err != nil {
+		return nil, fmt.Errorf("decoding input: %w", err)
+	}
+
+	if err := unmarshalCheckpoint(checkpoint, &ret.checkpoint); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
 }
 
 func (j *autoTagJob) Execute(ctx context.Context, progress *job.Progress) {
@@ -37,7 +120,7 @@ func (j *autoTagJob) Execute(ctx context.Context, progress *job.Progress) {
 		j.autoTagSpecific(ctx, progress)
 	}
 
-	logger.Infof("Finished autotag after %s", time.Since(begin).String())
+	job.Logger(ctx).Infof("Finished autotag after %s", time.Since(begin).String())
 }
 
 func (j *autoTagJob) isFileBasedAutoTag(input AutoTagMetadataInput) bool {
@@ -58,6 +141,7 @@ func (j *autoTagJob) autoTagFiles(ctx context.Context, progress *job.Progress, p
 		progress:   progress,
 		txnManager: j.txnManager,
 		cache:      &j.cache,
+		checkpoint: j.checkpoint,
 	}
 
 	t.process(ctx)
@@ -102,20 +186,35 @@ func (j *autoTagJob) autoTagSpecific(ctx context.Context, progress *job.Progress
 
 		return nil
 	}); err != nil {
-		logger.Error(err.Error())
+		job.Logger(ctx).Error(err.Error())
 		return
 	}
 
 	total := performerCount + studioCount + tagCount
 	progress.SetTotal(total)
 
-	logger.Infof("Starting autotag of %d performers, %d studios, %d tags", performerCount, studioCount, tagCount)
+	job.Logger(ctx).Infof("Starting autotag of %d performers, %d studios, %d tags", performerCount, studioCount, tagCount)
+
+	// process in id order so that the job can be resumed
+	sortIDStrings(performerIds)
+	sortIDStrings(studioIds)
+	sortIDStrings(tagIds)
 
 	j.autoTagPerformers(ctx, progress, input.Paths, performerIds)
 	j.autoTagStudios(ctx, progress, input.Paths, studioIds)
 	j.autoTagTags(ctx, progress, input.Paths, tagIds)
 }
 
+// sortIDStrings sorts a slice of numeric ID strings in ascending order.
+// Values that are not numeric, such as the "*" wildcard, are sorted first.
+func sortIDStrings(ids []string) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+}
+
 func (j *autoTagJob) autoTagPerformers(ctx context.Context, progress *job.Progress, paths []string, performerIds []string) {
 	if job.IsCancelled(ctx) {
 		return
@@ -133,6 +232,7 @@ func (j *autoTagJob) autoTagPerformers(ctx context.Context, progress *job.Progre
 			performerQuery := j.txnManager.Performer
 			ignoreAutoTag := false
 			perPage := -1
+			sortBy := "id"
 
 			if performerId == "*" {
 				var err error
@@ -140,6 +240,7 @@ func (j *autoTagJob) autoTagPerformers(ctx context.Context, progress *job.Progre
 					IgnoreAutoTag: &ignoreAutoTag,
 				}, &models.FindFilterType{
 					PerPage: &perPage,
+					Sort:    &sortBy,
 				})
 				if err != nil {
 					return fmt.Errorf("error querying performers: %v", err)
@@ -163,19 +264,36 @@ func (j *autoTagJob) autoTagPerformers(ctx context.Context, progress *job.Progre
 
 			for _, performer := range performers {
 				if job.IsCancelled(ctx) {
-					logger.Info("Stopping due to user request")
+					job.Logger(ctx).Info("Stopping due to user request")
 					return nil
 				}
 
+				if j.checkpoint.processed(autoTagStagePerformers, performer.ID) {
+					progress.Increment()
+					continue
+				}
+
 				if err := func() error {
 					r := j.txnManager
-					if err := tagger.PerformerScenes(ctx, performer, paths, r.Scene); err != nil {
+
+					// performers sharing a name can only be told apart by their disambiguation
+					sameName, err := r.Performer.FindByNames(ctx, []string{performer.Name}, true)
+					if err != nil {
+						return fmt.Errorf("finding performers with the same name: %w", err)
+					}
+					shared := len(sameName) > 1
+					if shared && performer.Disambiguation == "" {
+						job.Logger(ctx).Infof("Skipping performer '%s': name is shared with other performers", performer.Name)
+						return nil
+					}
+
+					if err := tagger.PerformerScenes(ctx, performer, paths, shared, r.Scene); err != nil {
 						return fmt.Errorf("processing scenes: %w", err)
 					}
-					if err := tagger.PerformerImages(ctx, performer, paths, r.Image); err != nil {
+					if err := tagger.PerformerImages(ctx, performer, paths, shared, r.Image); err != nil {
 						return fmt.Errorf("processing images: %w", err)
 					}
-					if err := tagger.PerformerGalleries(ctx, performer, paths, r.Gallery); err != nil {
+					if err := tagger.PerformerGalleries(ctx, performer, paths, shared, r.Gallery); err != nil {
 						return fmt.Errorf("processing galleries: %w", err)
 					}
 
@@ -185,11 +303,12 @@ func (j *autoTagJob) autoTagPerformers(ctx context.Context, progress *job.Progre
 				}
 
 				progress.Increment()
+				setAutoTagCheckpoint(progress, autoTagStagePerformers, performer.ID)
 			}
 
 			return nil
 		}); err != nil {
-			logger.Error(err.Error())
+			job.Logger(ctx).Error(err.Error())
 			continue
 		}
 	}
@@ -213,12 +332,14 @@ func (j *autoTagJob) autoTagStudios(ctx context.Context, progress *job.Progress,
 			studioQuery := r.Studio
 			ignoreAutoTag := false
 			perPage := -1
+			sortBy := "id"
 			if studioId == "*" {
 				var err error
 				studios, _, err = studioQuery.Query(ctx, &models.StudioFilterType{
 					IgnoreAutoTag: &ignoreAutoTag,
 				}, &models.FindFilterType{
 					PerPage: &perPage,
+					Sort:    &sortBy,
 				})
 				if err != nil {
 					return fmt.Errorf("error querying studios: %v", err)
@@ -243,10 +364,15 @@ func (j *autoTagJob) autoTagStudios(ctx context.Context, progress *job.Progress,
 
 			for _, studio := range studios {
 				if job.IsCancelled(ctx) {
-					logger.Info("Stopping due to user request")
+					job.Logger(ctx).Info("Stopping due to user request")
 					return nil
 				}
 
+				if j.checkpoint.processed(autoTagStageStudios, studio.ID) {
+					progress.Increment()
+					continue
+				}
+
 				if err := func() error {
 					aliases, err := r.Studio.GetAliases(ctx, studio.ID)
 					if err != nil {
@@ -269,11 +395,12 @@ func (j *autoTagJob) autoTagStudios(ctx context.Context, progress *job.Progress,
 				}
 
 				progress.Increment()
+				setAutoTagCheckpoint(progress, autoTagStageStudios, studio.ID)
 			}
 
 			return nil
 		}); err != nil {
-			logger.Error(err.Error())
+			job.Logger(ctx).Error(err.Error())
 			continue
 		}
 	}
@@ -296,12 +423,14 @@ func (j *autoTagJob) autoTagTags(ctx context.Context, progress *job.Progress, pa
 			tagQuery := r.Tag
 			ignoreAutoTag := false
 			perPage := -1
+			sortBy := "id"
 			if tagId == "*" {
 				var err error
 				tags, _, err = tagQuery.Query(ctx, &models.TagFilterType{
 					IgnoreAutoTag: &ignoreAutoTag,
 				}, &models.FindFilterType{
 					PerPage: &perPage,
+					Sort:    &sortBy,
 				})
 				if err != nil {
 					return fmt.Errorf("error querying tags: %v", err)
@@ -321,10 +450,15 @@ func (j *autoTagJob) autoTagTags(ctx context.Context, progress *job.Progress, pa
 
 			for _, tag := range tags {
 				if job.IsCancelled(ctx) {
-					logger.Info("Stopping due to user request")
+					job.Logger(ctx).Info("Stopping due to user request")
 					return nil
 				}
 
+				if j.checkpoint.processed(autoTagStageTags, tag.ID) {
+					progress.Increment()
+					continue
+				}
+
 				if err := func() error {
 					aliases, err := r.Tag.GetAliases(ctx, tag.ID)
 					if err != nil {
@@ -347,11 +481,12 @@ func (j *autoTagJob) autoTagTags(ctx context.Context, progress *job.Progress, pa
 				}
 
 				progress.Increment()
+				setAutoTagCheckpoint(progress, autoTagStageTags, tag.ID)
 			}
 
 			return nil
 		}); err != nil {
-			logger.Error(err.Error())
+			job.Logger(ctx).Error(err.Error())
 			continue
 		}
 	}
@@ -366,6 +501,7 @@ type autoTagFilesTask struct {
 	progress   *job.Progress
 	txnManager Repository
 	cache      *match.Cache
+	checkpoint autoTagCheckpoint
 }
 
 func (t *autoTagFilesTask) makeSceneFilter() *models.SceneFilterType {
@@ -492,6 +628,8 @@ func (t *autoTagFilesTask) processScenes(ctx context.Context, r Repository) erro
 	batchSize := 1000
 
 	findFilter := models.BatchFindFilter(batchSize)
+	sortBy := "id"
+	findFilter.Sort = &sortBy
 	sceneFilter := t.makeSceneFilter()
 
 	more := true
@@ -510,6 +648,11 @@ func (t *autoTagFilesTask) processScenes(ctx context.Context, r Repository) erro
 				return nil
 			}
 
+			if t.checkpoint.processed(autoTagStageScenes, ss.ID) {
+				t.progress.Increment()
+				continue
+			}
+
 			tt := autoTagSceneTask{
 				txnManager: t.txnManager,
 				scene:      ss,
@@ -525,6 +668,7 @@ func (t *autoTagFilesTask) processScenes(ctx context.Context, r Repository) erro
 			wg.Wait()
 
 			t.progress.Increment()
+			setAutoTagCheckpoint(t.progress, autoTagStageScenes, ss.ID)
 		}
 
 		if len(scenes) != batchSize {
@@ -533,7 +677,7 @@ func (t *autoTagFilesTask) processScenes(ctx context.Context, r Repository) erro
 			*findFilter.Page++
 
 			if *findFilter.Page%10 == 1 {
-				logger.Infof("Processed %d scenes...", (*findFilter.Page-1)*batchSize)
+				job.Logger(ctx).Infof("Processed %d scenes...", (*findFilter.Page-1)*batchSize)
 			}
 		}
 	}
@@ -549,6 +693,8 @@ func (t *autoTagFilesTask) processImages(ctx context.Context, r Repository) erro
 	batchSize := 1000
 
 	findFilter := models.BatchFindFilter(batchSize)
+	sortBy := "id"
+	findFilter.Sort = &sortBy
 	imageFilter := t.makeImageFilter()
 
 	more := true
@@ -567,6 +713,11 @@ func (t *autoTagFilesTask) processImages(ctx context.Context, r Repository) erro
 				return nil
 			}
 
+			if t.checkpoint.processed(autoTagStageImages, ss.ID) {
+				t.progress.Increment()
+				continue
+			}
+
 			tt := autoTagImageTask{
 				txnManager: t.txnManager,
 				image:      ss,
@@ -582,6 +733,7 @@ func (t *autoTagFilesTask) processImages(ctx context.Context, r Repository) erro
 			wg.Wait()
 
 			t.progress.Increment()
+			setAutoTagCheckpoint(t.progress, autoTagStageImages, ss.ID)
 		}
 
 		if len(images) != batchSize {
@@ -590,7 +742,7 @@ func (t *autoTagFilesTask) processImages(ctx context.Context, r Repository) erro
 			*findFilter.Page++
 
 			if *findFilter.Page%10 == 1 {
-				logger.Infof("Processed %d images...", (*findFilter.Page-1)*batchSize)
+				job.Logger(ctx).Infof("Processed %d images...", (*findFilter.Page-1)*batchSize)
 			}
 		}
 	}
@@ -606,6 +758,8 @@ func (t *autoTagFilesTask) processGalleries(ctx context.Context, r Repository) e
 	batchSize := 1000
 
 	findFilter := models.BatchFindFilter(batchSize)
+	sortBy := "id"
+	findFilter.Sort = &sortBy
 	galleryFilter := t.makeGalleryFilter()
 
 	more := true
@@ -624,6 +778,11 @@ func (t *autoTagFilesTask) processGalleries(ctx context.Context, r Repository) e
 				return nil
 			}
 
+			if t.checkpoint.processed(autoTagStageGalleries, ss.ID) {
+				t.progress.Increment()
+				continue
+			}
+
 			tt := autoTagGalleryTask{
 				txnManager: t.txnManager,
 				gallery:    ss,
@@ -639,6 +798,7 @@ func (t *autoTagFilesTask) processGalleries(ctx context.Context, r Repository) e
 			wg.Wait()
 
 			t.progress.Increment()
+			setAutoTagCheckpoint(t.progress, autoTagStageGalleries, ss.ID)
 		}
 
 		if len(galleries) != batchSize {
@@ -647,7 +807,7 @@ func (t *autoTagFilesTask) processGalleries(ctx context.Context, r Repository) e
 			*findFilter.Page++
 
 			if *findFilter.Page%10 == 1 {
-				logger.Infof("Processed %d galleries...", (*findFilter.Page-1)*batchSize)
+				job.Logger(ctx).Infof("Processed %d galleries...", (*findFilter.Page-1)*batchSize)
 			}
 		}
 	}
@@ -664,34 +824,34 @@ func (t *autoTagFilesTask) process(ctx context.Context) {
 		}
 
 		t.progress.SetTotal(total)
-		logger.Infof("Starting autotag of %d files", total)
+		job.Logger(ctx).Infof("Starting autotag of %d files", total)
 
 		return nil
 	}); err != nil {
-		logger.Errorf("error getting count for autotag task: %v", err)
+		job.Logger(ctx).Errorf("error getting count for autotag task: %v", err)
 		return
 	}
 
-	logger.Info("Autotagging scenes...")
+	job.Logger(ctx).Info("Autotagging scenes...")
 	if err := t.processScenes(ctx, r); err != nil {
-		logger.Errorf("error processing scenes: %w", err)
+		job.Logger(ctx).Errorf("error processing scenes: %w", err)
 		return
 	}
 
-	logger.Info("Autotagging images...")
+	job.Logger(ctx).Info("Autotagging images...")
 	if err := t.processImages(ctx, r); err != nil {
-		logger.Errorf("error processing images: %w", err)
+		job.Logger(ctx).Errorf("error processing images: %w", err)
 		return
 	}
 
-	logger.Info("Autotagging galleries...")
+	job.Logger(ctx).Info("Autotagging galleries...")
 	if err := t.processGalleries(ctx, r); err != nil {
-		logger.Errorf("error processing galleries: %w", err)
+		job.Logger(ctx).Errorf("error processing galleries: %w", err)
 		return
 	}
 
 	if job.IsCancelled(ctx) {
-		logger.Info("Stopping due to user request")
+		job.Logger(ctx).Info("Stopping due to user request")
 	}
 }
 
@@ -733,7 +893,7 @@ func (t *autoTagSceneTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 
 		return nil
 	}); err != nil {
-		logger.Error(err.Error())
+		job.Logger(ctx).Error(err.Error())
 	}
 }
 
@@ -770,7 +930,7 @@ func (t *autoTagImageTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 
 		return nil
 	}); err != nil {
-		logger.Error(err.Error())
+		job.Logger(ctx).Error(err.Error())
 	}
 }
 
@@ -807,6 +967,6 @@ func (t *autoTagGalleryTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 
 		return nil
 	}); err != nil {
-		logger.Error(err.Error())
+		job.Logger(ctx).Error(err.Error())
 	}
 }
@@ -32,11 +32,22 @@ type cleanJob struct {
 	scanSubs     *subscriptionManager
 }
 
+// JobInput returns the input of the job to be recorded in the job history.
+func (j *cleanJob) JobInput() interface{} {
+	return j.input
+}
+
+// ResourceClass returns ResourceExclusive, since cleaning removes objects
+// that other jobs may be using.
+func (j *cleanJob) ResourceClass() job.ResourceClass {
+	return job.ResourceExclusive
+}
+
 func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) {
-	logger.Infof("Starting cleaning of tracked files")
+	job.Logger(ctx).Infof("Starting cleaning of tracked files")
 	start := time.Now()
 	if j.input.DryRun {
-		logger.Infof("Running in Dry Mode")
+		job.Logger(ctx).Infof("Running in Dry Mode")
 	}
 
 	j.cleaner.Clean(ctx, file.CleanOptions{
@@ -46,7 +57,7 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) {
 	}, progress)
 
 	if job.IsCancelled(ctx) {
-		logger.Info("Stopping due to user request")
+		job.Logger(ctx).Info("Stopping due to user request")
 		return
 	}
 
@@ -54,7 +65,7 @@ func (j *cleanJob) Execute(ctx context.Context, progress *job.Progress) {
 
 	j.scanSubs.notify()
 	elapsed := time.Since(start)
-	logger.Info(fmt.Sprintf("Finished Cleaning (%s)", elapsed))
+	job.Logger(ctx).Info(fmt.Sprintf("Finished Cleaning (%s)", elapsed))
 }
 
 func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
@@ -86,7 +97,7 @@ func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
 					continue
 				}
 
-				logger.Infof("Gallery has 0 images. Marking to clean: %s", g.DisplayName())
+				job.Logger(ctx).Infof("Gallery has 0 images. Marking to clean: %s", g.DisplayName())
 				toClean = append(toClean, g.ID)
 			}
 
@@ -95,7 +106,7 @@ func (j *cleanJob) cleanEmptyGalleries(ctx context.Context) {
 
 		return nil
 	}); err != nil {
-		logger.Errorf("Error finding empty galleries: %v", err)
+		job.Logger(ctx).Errorf("Error finding empty galleries: %v", err)
 		return
 	}
 
@@ -135,7 +146,7 @@ func (j *cleanJob) deleteGallery(ctx context.Context, id int) {
 
 		return nil
 	}); err != nil {
-		logger.Errorf("Error deleting gallery from database: %s", err.Error())
+		job.Logger(ctx).Errorf("Error deleting gallery from database: %s", err.Error())
 	}
 }
 
@@ -170,12 +181,12 @@ func (f *cleanFilter) Accept(ctx context.Context, path string, info fs.FileInfo)
 	}
 
 	if stash == nil {
-		logger.Infof("%s not in any stash library directories. Marking to clean: \"%s\"", fileOrFolder, path)
+		job.Logger(ctx).Infof("%s not in any stash library directories. Marking to clean: \"%s\"", fileOrFolder, path)
 		return false
 	}
 
 	if fsutil.IsPathInDir(generatedPath, path) {
-		logger.Infof("%s is in generated path. Marking to clean: \"%s\"", fileOrFolder, path)
+		job.Logger(ctx).Infof("%s is in generated path. Marking to clean: \"%s\"", fileOrFolder, path)
 		return false
 	}
 
@@ -298,7 +309,7 @@ func (h *cleanHandler) handleRelatedScenes(ctx context.Context, fileDeleter *fil
 
 		// only delete if the scene has no other files
 		if len(scene.Files.List()) <= 1 {
-			logger.Infof("Deleting scene %q since it has no other related files", scene.DisplayName())
+			job.Logger(ctx).Infof("Deleting scene %q since it has no other related files", scene.DisplayName())
 			if err := mgr.SceneService.Destroy(ctx, scene, sceneFileDeleter, true, false); err != nil {
 				return err
 			}
@@ -347,7 +358,7 @@ func (h *cleanHandler) handleRelatedGalleries(ctx context.Context, fileID file.I
 
 		// only delete if the gallery has no other files
 		if len(g.Files.List()) <= 1 {
-			logger.Infof("Deleting gallery %q since it has no other related files", g.DisplayName())
+			job.Logger(ctx).Infof("Deleting gallery %q since it has no other related files", g.DisplayName())
 			if err := qb.Destroy(ctx, g.ID); err != nil {
 				return err
 			}
@@ -386,7 +397,7 @@ func (h *cleanHandler) deleteRelatedFolderGalleries(ctx context.Context, folderI
 	}
 
 	for _, g := range galleries {
-		logger.Infof("Deleting folder-based gallery %q since the folder no longer exists", g.DisplayName())
+		job.Logger(ctx).Infof("Deleting folder-based gallery %q since the folder no longer exists", g.DisplayName())
 		if err := qb.Destroy(ctx, g.ID); err != nil {
 			return err
 		}
@@ -420,7 +431,7 @@ func (h *cleanHandler) handleRelatedImages(ctx context.Context, fileDeleter *fil
 		}
 
 		if len(i.Files.List()) <= 1 {
-			logger.Infof("Deleting image %q since it has no other related files", i.DisplayName())
+			job.Logger(ctx).Infof("Deleting image %q since it has no other related files", i.DisplayName())
 			if err := mgr.ImageService.Destroy(ctx, i, imageFileDeleter, true, false); err != nil {
 				return err
 			}
@@ -17,6 +17,7 @@ import (
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/json"
@@ -51,9 +52,63 @@ type ExportTask struct {
 
 	includeDependencies bool
 
+	// checkpoint of a resumed full export. Object types in the checkpoint
+	// are not exported again.
+	checkpoint exportCheckpoint
+	// called after each object type is exported, if set
+	setCheckpoint func(checkpoint interface{})
+
 	DownloadHash string
 }
 
+const exportJobType = "export"
+
+// exportCheckpoint is the checkpoint of a full export job.
+type exportCheckpoint struct {
+	// ObjectTypes that have been completely exported
+	ObjectTypes []string `json:"object_types"`
+}
+
+// exportJob performs a full export to the metadata directory. Unlike partial
+// exports, which are written to a temporary directory, a full export can be
+// resumed.
+type exportJob struct {
+	txnManager          Repository
+	fileNamingAlgorithm models.HashAlgorithm
+	checkpoint          exportCheckpoint
+}
+
+func (j *exportJob) Execute(ctx context.Context, progress *job.Progress) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	task := ExportTask{
+		txnManager:          j.txnManager,
+		full:                true,
+		fileNamingAlgorithm: j.fileNamingAlgorithm,
+		checkpoint:          j.checkpoint,
+		setCheckpoint:       progress.SetCheckpoint,
+	}
+	task.Start(ctx, &wg)
+}
+
+// JobType returns the type used to resume the job.
+func (j *exportJob) JobType() string {
+	return exportJobType
+}
+
+func resumeExportJob(s *Manager, input []byte, checkpoint []byte) (job.JobExec, error) {
+	ret := &exportJob{
+		txnManager:          s.Repository,
+		fileNamingAlgorithm: s.Config.GetVideoFileNamingAlgorithm(),
+	}
+
+	if err := unmarshalCheckpoint(checkpoint, &ret.checkpoint); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 type ExportObjectTypeInput struct {
 	Ids []string `json:"ids"`
 	All *bool    `json:"all"`
@@ -126,20 +181,20 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 		var err error
 		t.baseDir, err = instance.Paths.Generated.TempDir("export")
 		if err != nil {
-			logger.Errorf("error creating temporary directory for export: %s", err.Error())
+			job.Logger(ctx).Errorf("error creating temporary directory for export: %s", err.Error())
 			return
 		}
 
 		defer func() {
 			err := fsutil.RemoveDir(t.baseDir)
 			if err != nil {
-				logger.Errorf("error removing directory %s: %s", t.baseDir, err.Error())
+				job.Logger(ctx).Errorf("error removing directory %s: %s", t.baseDir, err.Error())
 			}
 		}()
 	}
 
 	if t.baseDir == "" {
-		logger.Errorf("baseDir must not be empty")
+		job.Logger(ctx).Errorf("baseDir must not be empty")
 		return
 	}
 
@@ -147,7 +202,10 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 		json: *paths.GetJSONPaths(t.baseDir),
 	}
 
-	paths.EmptyJSONDirs(t.baseDir)
+	// keep the files of the object types exported before resuming
+	if len(t.checkpoint.ObjectTypes) == 0 {
+		paths.EmptyJSONDirs(t.baseDir)
+	}
 	paths.EnsureJSONDirs(t.baseDir)
 
 	txnErr := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
@@ -166,32 +224,58 @@ func (t *ExportTask) Start(ctx context.Context, wg *sync.WaitGroup) {
 			}
 		}
 
-		t.ExportScenes(ctx, workerCount, r)
-		t.ExportImages(ctx, workerCount, r)
-		t.ExportGalleries(ctx, workerCount, r)
-		t.ExportMovies(ctx, workerCount, r)
-		t.ExportPerformers(ctx, workerCount, r)
-		t.ExportStudios(ctx, workerCount, r)
-		t.ExportTags(ctx, workerCount, r)
+		type exporter struct {
+			objectType string
+			export     func()
+		}
+
+		exporters := []exporter{
+			{"custom fields", func() { t.ExportCustomFields(ctx, r) }},
+			{"scenes", func() { t.ExportScenes(ctx, workerCount, r) }},
+			{"images", func() { t.ExportImages(ctx, workerCount, r) }},
+			{"galleries", func() { t.ExportGalleries(ctx, workerCount, r) }},
+			{"movies", func() { t.ExportMovies(ctx, workerCount, r) }},
+			{"performers", func() { t.ExportPerformers(ctx, workerCount, r) }},
+			{"studios", func() { t.ExportStudios(ctx, workerCount, r) }},
+			{"tags", func() { t.ExportTags(ctx, workerCount, r) }},
+		}
 
 		if t.full {
-			t.ExportScrapedItems(ctx, r)
+			exporters = append(exporters, exporter{"scraped items", func() { t.ExportScrapedItems(ctx, r) }})
+		}
+
+		for _, e := range exporters {
+			if stringslice.StrInclude(t.checkpoint.ObjectTypes, e.objectType) {
+				job.Logger(ctx).Infof("Skipping %s - already exported before resuming", e.objectType)
+				continue
+			}
+
+			e.export()
+
+			if job.IsCancelled(ctx) {
+				return nil
+			}
+
+			if t.setCheckpoint != nil {
+				t.checkpoint.ObjectTypes = append(t.checkpoint.ObjectTypes, e.objectType)
+				t.setCheckpoint(t.checkpoint)
+			}
 		}
 
 		return nil
 	})
 	if txnErr != nil {
-		logger.Warnf("error while running export transaction: %v", txnErr)
+		job.Logger(ctx).Warnf("error while running export transaction: %v", txnErr)
 	}
 
 	if !t.full {
 		err := t.generateDownload()
 		if err != nil {
-			logger.Errorf("error generating download link: %s", err.Error())
+			job.Logger(ctx).Errorf("error generating download link: %s", err.Error())
 			return
 		}
 	}
-	logger.Infof("Export complete in %s.", time.Since(startTime))
+	job.Logger(ctx).Infof("Export complete in %s.", time.Since(startTime))
 }
 
 func (t *ExportTask) generateDownload() error {
@@ -226,6 +310,10 @@ func (t *ExportTask) zipFiles(w io.Writer) error {
 		json: *paths.GetJSONPaths(""),
 	}
 
+	if err := t.zipFile(t.json.json.CustomFieldsFile, u.json.Metadata, z); err != nil {
+		logger.Warnf("error adding custom fields to zip: %v", err)
+	}
+
 	walkWarn(t.json.json.Tags, t.zipWalkFunc(u.json.Tags, z))
 	walkWarn(t.json.json.Galleries, t.zipWalkFunc(u.json.Galleries, z))
 	walkWarn(t.json.json.Performers, t.zipWalkFunc(u.json.Performers, z))
@@ -297,13 +385,13 @@ func (t *ExportTask) populateMovieScenes(ctx context.Context, repo Repository) {
 	}
 
 	if err != nil {
-		logger.Errorf("[movies] failed to fetch movies: %s", err.Error())
+		job.Logger(ctx).Errorf("[movies] failed to fetch movies: %s", err.Error())
 	}
 
 	for _, m := range movies {
 		scenes, err := sceneReader.FindByMovieID(ctx, m.ID)
 		if err != nil {
-			logger.Errorf("[movies] <%s> failed to fetch scenes for movie: %s", m.Checksum, err.Error())
+			job.Logger(ctx).Errorf("[movies] <%s> failed to fetch scenes for movie: %s", m.Checksum, err.Error())
 			continue
 		}
 
@@ -327,18 +415,18 @@ func (t *ExportTask) populateGalleryImages(ctx context.Context, repo Repository)
 	}
 
 	if err != nil {
-		logger.Errorf("[galleries] failed to fetch galleries: %s", err.Error())
+		job.Logger(ctx).Errorf("[galleries] failed to fetch galleries: %s", err.Error())
 	}
 
 	for _, g := range galleries {
 		if err := g.LoadFiles(ctx, reader); err != nil {
-			logger.Errorf("[galleries] <%s> failed to fetch files for gallery: %s", g.DisplayName(), err.Error())
+			job.Logger(ctx).Errorf("[galleries] <%s> failed to fetch files for gallery: %s", g.DisplayName(), err.Error())
 			continue
 		}
 
 		images, err := imageReader.FindByGalleryID(ctx, g.ID)
 		if err != nil {
-			logger.Errorf("[galleries] <%s> failed to fetch images for gallery: %s", g.PrimaryChecksum(), err.Error())
+			job.Logger(ctx).Errorf("[galleries] <%s> failed to fetch images for gallery: %s", g.PrimaryChecksum(), err.Error())
 			continue
 		}
 
@@ -363,12 +451,12 @@ func (t *ExportTask) ExportScenes(ctx context.Context, workers int, repo Reposit
 	}
 
 	if err != nil {
-		logger.Errorf("[scenes] failed to fetch scenes: %s", err.Error())
+		job.Logger(ctx).Errorf("[scenes] failed to fetch scenes: %s", err.Error())
 	}
 
 	jobCh := make(chan *models.Scene, workers*2) // make a buffered channel to feed workers
 
-	logger.Info("[scenes] exporting")
+	job.Logger(ctx).Info("[scenes] exporting")
 	startTime := time.Now()
 
 	for w := 0; w < workers; w++ { // create export Scene workers
@@ -380,7 +468,7 @@ func (t *ExportTask) ExportScenes(ctx context.Context, workers int, repo Reposit
 		index := i + 1
 
 		if (i % 100) == 0 { // make progress easier to read
-			logger.Progressf("[scenes] %d of %d", index, len(scenes))
+			job.Logger(ctx).Progressf("[scenes] %d of %d", index, len(scenes))
 		}
 		jobCh <- scene // feed workers
 	}
@@ -388,7 +476,7 @@ func (t *ExportTask) ExportScenes(ctx context.Context, workers int, repo Reposit
 	close(jobCh) // close channel so that workers will know no more jobs are available
 	scenesWg.Wait()
 
-	logger.Infof("[scenes] export complete in %s. %d workers used.", time.Since(startTime), workers)
+	job.Logger(ctx).Infof("[scenes] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
 
 func exportFile(f file.File, t *ExportTask) {
@@ -495,12 +583,18 @@ func exportScene(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 		sceneHash := s.GetHash(t.fileNamingAlgorithm)
 
 		if err := s.LoadRelationships(ctx, sceneReader); err != nil {
-			logger.Errorf("[scenes] <%s> error loading scene relationships: %v", sceneHash, err)
+			job.Logger(ctx).Errorf("[scenes] <%s> error loading scene relationships: %v", sceneHash, err)
 		}
 
 		newSceneJSON, err := scene.ToBasicJSON(ctx, sceneReader, s)
 		if err != nil {
-			logger.Errorf("[scenes] <%s> error getting scene JSON: %s", sceneHash, err.Error())
+			job.Logger(ctx).Errorf("[scenes] <%s> error getting scene JSON: %s", sceneHash, err.Error())
+			continue
+		}
+
+		newSceneJSON.CustomFields, err = sceneReader.GetCustomFields(ctx, s.ID)
+		if err != nil {
+			job.Logger(ctx).Errorf("[scenes] <%s> error getting scene custom fields: %s", sceneHash, err.Error())
 			continue
 		}
 
@@ -511,19 +605,19 @@ func exportScene(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 
 		newSceneJSON.Studio, err = scene.GetStudioName(ctx, studioReader, s)
 		if err != nil {
-			logger.Errorf("[scenes] <%s> error getting scene studio name: %s", sceneHash, err.Error())
+			job.Logger(ctx).Errorf("[scenes] <%s> error getting scene studio name: %s", sceneHash, err.Error())
 			continue
 		}
 
 		galleries, err := galleryReader.FindBySceneID(ctx, s.ID)
 		if err != nil {
-			logger.Errorf("[scenes] <%s> error getting scene gallery checksums: %s", sceneHash, err.Error())
+			job.Logger(ctx).Errorf("[scenes] <%s> error getting scene gallery checksums: %s", sceneHash, err.Error())
 			continue
 		}
 
 		for _, g := range galleries {
 			if err := g.LoadFiles(ctx, galleryReader); err != nil {
-				logger.Errorf("[scenes] <%s> error getting scene gallery files: %s", sceneHash, err.Error())
+				job.Logger(ctx).Errorf("[scenes] <%s> error getting scene gallery files: %s", sceneHash, err.Error())
 				continue
 			}
 		}
@@ -532,7 +626,7 @@ func exportScene(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 
 		performers, err := performerReader.FindBySceneID(ctx, s.ID)
 		if err != nil {
-			logger.Errorf("[scenes] <%s> error getting scene performer names: %s", sceneHash, err.Error())
+			job.Logger(ctx).Errorf("[scenes] <%s> error getting scene performer names: %s", sceneHash, err.Error())
 			continue
 		}
 
@@ -540,19 +634,19 @@ func exportScene(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 
 		newSceneJSON.Tags, err = scene.GetTagNames(ctx, tagReader, s)
 		if err != nil {
-			logger.Errorf("[scenes] <%s> error getting scene tag names: %s", sceneHash, err.Error())
+			job.Logger(ctx).Errorf("[scenes] <%s> error getting scene tag names: %s", sceneHash, err.Error())
 			continue
 		}
 
 		newSceneJSON.Markers, err = scene.GetSceneMarkersJSON(ctx, sceneMarkerReader, tagReader, s)
 		if err != nil {
-			logger.Errorf("[scenes] <%s> error getting scene markers JSON: %s", sceneHash, err.Error())
+			job.Logger(ctx).Errorf("[scenes] <%s> error getting scene markers JSON: %s", sceneHash, err.Error())
 			continue
 		}
 
 		newSceneJSON.Movies, err = scene.GetSceneMoviesJSON(ctx, movieReader, s)
 		if err != nil {
-			logger.Errorf("[scenes] <%s> error getting scene movies JSON: %s", sceneHash, err.Error())
+			job.Logger(ctx).Errorf("[scenes] <%s> error getting scene movies JSON: %s", sceneHash, err.Error())
 			continue
 		}
 
@@ -565,14 +659,14 @@ func exportScene(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 
 			tagIDs, err := scene.GetDependentTagIDs(ctx, tagReader, sceneMarkerReader, s)
 			if err != nil {
-				logger.Errorf("[scenes] <%s> error getting scene tags: %s", sceneHash, err.Error())
+				job.Logger(ctx).Errorf("[scenes] <%s> error getting scene tags: %s", sceneHash, err.Error())
 				continue
 			}
 			t.tags.IDs = intslice.IntAppendUniques(t.tags.IDs, tagIDs)
 
 			movieIDs, err := scene.GetDependentMovieIDs(ctx, s)
 			if err != nil {
-				logger.Errorf("[scenes] <%s> error getting scene movies: %s", sceneHash, err.Error())
+				job.Logger(ctx).Errorf("[scenes] <%s> error getting scene movies: %s", sceneHash, err.Error())
 				continue
 			}
 			t.movies.IDs = intslice.IntAppendUniques(t.movies.IDs, movieIDs)
@@ -586,7 +680,7 @@ func exportScene(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 		fn := newSceneJSON.Filename(s.ID, basename, hash)
 
 		if err := t.json.saveScene(fn, newSceneJSON); err != nil {
-			logger.Errorf("[scenes] <%s> failed to save json: %s", sceneHash, err.Error())
+			job.Logger(ctx).Errorf("[scenes] <%s> failed to save json: %s", sceneHash, err.Error())
 		}
 	}
 }
@@ -606,12 +700,12 @@ func (t *ExportTask) ExportImages(ctx context.Context, workers int, repo Reposit
 	}
 
 	if err != nil {
-		logger.Errorf("[images] failed to fetch images: %s", err.Error())
+		job.Logger(ctx).Errorf("[images] failed to fetch images: %s", err.Error())
 	}
 
 	jobCh := make(chan *models.Image, workers*2) // make a buffered channel to feed workers
 
-	logger.Info("[images] exporting")
+	job.Logger(ctx).Info("[images] exporting")
 	startTime := time.Now()
 
 	for w := 0; w < workers; w++ { // create export Image workers
@@ -623,7 +717,7 @@ func (t *ExportTask) ExportImages(ctx context.Context, workers int, repo Reposit
 		index := i + 1
 
 		if (i % 100) == 0 { // make progress easier to read
-			logger.Progressf("[images] %d of %d", index, len(images))
+			job.Logger(ctx).Progressf("[images] %d of %d", index, len(images))
 		}
 		jobCh <- image // feed workers
 	}
@@ -631,7 +725,7 @@ func (t *ExportTask) ExportImages(ctx context.Context, workers int, repo Reposit
 	close(jobCh) // close channel so that workers will know no more jobs are available
 	imagesWg.Wait()
 
-	logger.Infof("[images] export complete in %s. %d workers used.", time.Since(startTime), workers)
+	job.Logger(ctx).Infof("[images] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
 
 func exportImage(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models.Image, repo Repository, t *ExportTask) {
@@ -645,7 +739,7 @@ func exportImage(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 		imageHash := s.Checksum
 
 		if err := s.LoadFiles(ctx, repo.Image); err != nil {
-			logger.Errorf("[images] <%s> error getting image files: %s", imageHash, err.Error())
+			job.Logger(ctx).Errorf("[images] <%s> error getting image files: %s", imageHash, err.Error())
 			continue
 		}
 
@@ -659,19 +753,25 @@ func exportImage(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 		var err error
 		newImageJSON.Studio, err = image.GetStudioName(ctx, studioReader, s)
 		if err != nil {
-			logger.Errorf("[images] <%s> error getting image studio name: %s", imageHash, err.Error())
+			job.Logger(ctx).Errorf("[images] <%s> error getting image studio name: %s", imageHash, err.Error())
+			continue
+		}
+
+		newImageJSON.CustomFields, err = repo.Image.GetCustomFields(ctx, s.ID)
+		if err != nil {
+			job.Logger(ctx).Errorf("[images] <%s> error getting image custom fields: %s", imageHash, err.Error())
 			continue
 		}
 
 		imageGalleries, err := galleryReader.FindByImageID(ctx, s.ID)
 		if err != nil {
-			logger.Errorf("[images] <%s> error getting image galleries: %s", imageHash, err.Error())
+			job.Logger(ctx).Errorf("[images] <%s> error getting image galleries: %s", imageHash, err.Error())
 			continue
 		}
 
 		for _, g := range imageGalleries {
 			if err := g.LoadFiles(ctx, galleryReader); err != nil {
-				logger.Errorf("[images] <%s> error getting image gallery files: %s", imageHash, err.Error())
+				job.Logger(ctx).Errorf("[images] <%s> error getting image gallery files: %s", imageHash, err.Error())
 				continue
 			}
 		}
@@ -680,7 +780,7 @@ func exportImage(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 
 		performers, err := performerReader.FindByImageID(ctx, s.ID)
 		if err != nil {
-			logger.Errorf("[images] <%s> error getting image performer names: %s", imageHash, err.Error())
+			job.Logger(ctx).Errorf("[images] <%s> error getting image performer names: %s", imageHash, err.Error())
 			continue
 		}
 
@@ -688,7 +788,7 @@ func exportImage(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 
 		tags, err := tagReader.FindByImageID(ctx, s.ID)
 		if err != nil {
-			logger.Errorf("[images] <%s> error getting image tag names: %s", imageHash, err.Error())
+			job.Logger(ctx).Errorf("[images] <%s> error getting image tag names: %s", imageHash, err.Error())
 			continue
 		}
 
@@ -707,7 +807,7 @@ func exportImage(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models
 		fn := newImageJSON.Filename(filepath.Base(s.Path), s.Checksum)
 
 		if err := t.json.saveImage(fn, newImageJSON); err != nil {
-			logger.Errorf("[images] <%s> failed to save json: %s", imageHash, err.Error())
+			job.Logger(ctx).Errorf("[images] <%s> failed to save json: %s", imageHash, err.Error())
 		}
 	}
 }
@@ -727,12 +827,12 @@ func (t *ExportTask) ExportGalleries(ctx context.Context, workers int, repo Repo
 	}
 
 	if err != nil {
-		logger.Errorf("[galleries] failed to fetch galleries: %s", err.Error())
+		job.Logger(ctx).Errorf("[galleries] failed to fetch galleries: %s", err.Error())
 	}
 
 	jobCh := make(chan *models.Gallery, workers*2) // make a buffered channel to feed workers
 
-	logger.Info("[galleries] exporting")
+	job.Logger(ctx).Info("[galleries] exporting")
 	startTime := time.Now()
 
 	for w := 0; w < workers; w++ { // create export Scene workers
@@ -744,7 +844,7 @@ func (t *ExportTask) ExportGalleries(ctx context.Context, workers int, repo Repo
 		index := i + 1
 
 		if (i % 100) == 0 { // make progress easier to read
-			logger.Progressf("[galleries] %d of %d", index, len(galleries))
+			job.Logger(ctx).Progressf("[galleries] %d of %d", index, len(galleries))
 		}
 
 		jobCh <- gallery
@@ -753,7 +853,7 @@ func (t *ExportTask) ExportGalleries(ctx context.Context, workers int, repo Repo
 	close(jobCh) // close channel so that workers will know no more jobs are available
 	galleriesWg.Wait()
 
-	logger.Infof("[galleries] export complete in %s. %d workers used.", time.Since(startTime), workers)
+	job.Logger(ctx).Infof("[galleries] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
 
 func exportGallery(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models.Gallery, repo Repository, t *ExportTask) {
@@ -764,7 +864,12 @@ func exportGallery(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *mode
 
 	for g := range jobChan {
 		if err := g.LoadFiles(ctx, repo.Gallery); err != nil {
-			logger.Errorf("[galleries] <%s> failed to fetch files for gallery: %s", g.DisplayName(), err.Error())
+			job.Logger(ctx).Errorf("[galleries] <%s> failed to fetch files for gallery: %s", g.DisplayName(), err.Error())
+			continue
+		}
+
+		if err := g.LoadURLs(ctx, repo.Gallery); err != nil {
+			job.Logger(ctx).Errorf("[galleries] <%s> failed to fetch urls for gallery: %s", g.DisplayName(), err.Error())
 			continue
 		}
 
@@ -772,7 +877,13 @@ func exportGallery(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *mode
 
 		newGalleryJSON, err := gallery.ToBasicJSON(g)
 		if err != nil {
-			logger.Errorf("[galleries] <%s> error getting gallery JSON: %s", galleryHash, err.Error())
+			job.Logger(ctx).Errorf("[galleries] <%s> error getting gallery JSON: %s", galleryHash, err.Error())
+			continue
+		}
+
+		newGalleryJSON.CustomFields, err = repo.Gallery.GetCustomFields(ctx, g.ID)
+		if err != nil {
+			job.Logger(ctx).Errorf("[galleries] <%s> error getting gallery custom fields: %s", galleryHash, err.Error())
 			continue
 		}
 
@@ -785,12 +896,12 @@ func exportGallery(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *mode
 		if g.FolderID != nil {
 			folder, err := repo.Folder.Find(ctx, *g.FolderID)
 			if err != nil {
-				logger.Errorf("[galleries] <%s> error getting gallery folder: %v", galleryHash, err)
+				job.Logger(ctx).Errorf("[galleries] <%s> error getting gallery folder: %v", galleryHash, err)
 				continue
 			}
 
 			if folder == nil {
-				logger.Errorf("[galleries] <%s> unable to find gallery folder", galleryHash)
+				job.Logger(ctx).Errorf("[galleries] <%s> unable to find gallery folder", galleryHash)
 				continue
 			}
 
@@ -799,13 +910,13 @@ func exportGallery(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *mode
 
 		newGalleryJSON.Studio, err = gallery.GetStudioName(ctx, studioReader, g)
 		if err != nil {
-			logger.Errorf("[galleries] <%s> error getting gallery studio name: %s", galleryHash, err.Error())
+			job.Logger(ctx).Errorf("[galleries] <%s> error getting gallery studio name: %s", galleryHash, err.Error())
 			continue
 		}
 
 		performers, err := performerReader.FindByGalleryID(ctx, g.ID)
 		if err != nil {
-			logger.Errorf("[galleries] <%s> error getting gallery performer names: %s", galleryHash, err.Error())
+			job.Logger(ctx).Errorf("[galleries] <%s> error getting gallery performer names: %s", galleryHash, err.Error())
 			continue
 		}
 
@@ -813,7 +924,7 @@ func exportGallery(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *mode
 
 		tags, err := tagReader.FindByGalleryID(ctx, g.ID)
 		if err != nil {
-			logger.Errorf("[galleries] <%s> error getting gallery tag names: %s", galleryHash, err.Error())
+			job.Logger(ctx).Errorf("[galleries] <%s> error getting gallery tag names: %s", galleryHash, err.Error())
 			continue
 		}
 
@@ -842,7 +953,7 @@ func exportGallery(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *mode
 		fn := newGalleryJSON.Filename(basename, hash)
 
 		if err := t.json.saveGallery(fn, newGalleryJSON); err != nil {
-			logger.Errorf("[galleries] <%s> failed to save json: %s", galleryHash, err.Error())
+			job.Logger(ctx).Errorf("[galleries] <%s> failed to save json: %s", galleryHash, err.Error())
 		}
 	}
 }
@@ -861,11 +972,11 @@ func (t *ExportTask) ExportPerformers(ctx context.Context, workers int, repo Rep
 	}
 
 	if err != nil {
-		logger.Errorf("[performers] failed to fetch performers: %s", err.Error())
+		job.Logger(ctx).Errorf("[performers] failed to fetch performers: %s", err.Error())
 	}
 	jobCh := make(chan *models.Performer, workers*2) // make a buffered channel to feed workers
 
-	logger.Info("[performers] exporting")
+	job.Logger(ctx).Info("[performers] exporting")
 	startTime := time.Now()
 
 	for w := 0; w < workers; w++ { // create export Performer workers
@@ -875,7 +986,7 @@ func (t *ExportTask) ExportPerformers(ctx context.Context, workers int, repo Rep
 
 	for i, performer := range performers {
 		index := i + 1
-		logger.Progressf("[performers] %d of %d", index, len(performers))
+		job.Logger(ctx).Progressf("[performers] %d of %d", index, len(performers))
 
 		jobCh <- performer // feed workers
 	}
@@ -883,7 +994,7 @@ func (t *ExportTask) ExportPerformers(ctx context.Context, workers int, repo Rep
 	close(jobCh) // close channel so workers will know that no more jobs are available
 	performersWg.Wait()
 
-	logger.Infof("[performers] export complete in %s. %d workers used.", time.Since(startTime), workers)
+	job.Logger(ctx).Infof("[performers] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
 
 func (t *ExportTask) exportPerformer(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models.Performer, repo Repository) {
@@ -895,13 +1006,19 @@ func (t *ExportTask) exportPerformer(ctx context.Context, wg *sync.WaitGroup, jo
 		newPerformerJSON, err := performer.ToJSON(ctx, performerReader, p)
 
 		if err != nil {
-			logger.Errorf("[performers] <%s> error getting performer JSON: %s", p.Checksum, err.Error())
+			job.Logger(ctx).Errorf("[performers] <%s> error getting performer JSON: %s", p.Checksum, err.Error())
+			continue
+		}
+
+		newPerformerJSON.CustomFields, err = performerReader.GetCustomFields(ctx, p.ID)
+		if err != nil {
+			job.Logger(ctx).Errorf("[performers] <%s> error getting performer custom fields: %s", p.Checksum, err.Error())
 			continue
 		}
 
 		tags, err := repo.Tag.FindByPerformerID(ctx, p.ID)
 		if err != nil {
-			logger.Errorf("[performers] <%s> error getting performer tags: %s", p.Checksum, err.Error())
+			job.Logger(ctx).Errorf("[performers] <%s> error getting performer tags: %s", p.Checksum, err.Error())
 			continue
 		}
 
@@ -914,7 +1031,7 @@ func (t *ExportTask) exportPerformer(ctx context.Context, wg *sync.WaitGroup, jo
 		fn := newPerformerJSON.Filename()
 
 		if err := t.json.savePerformer(fn, newPerformerJSON); err != nil {
-			logger.Errorf("[performers] <%s> failed to save json: %s", p.Checksum, err.Error())
+			job.Logger(ctx).Errorf("[performers] <%s> failed to save json: %s", p.Checksum, err.Error())
 		}
 	}
 }
@@ -933,10 +1050,10 @@ func (t *ExportTask) ExportStudios(ctx context.Context, workers int, repo Reposi
 	}
 
 	if err != nil {
-		logger.Errorf("[studios] failed to fetch studios: %s", err.Error())
+		job.Logger(ctx).Errorf("[studios] failed to fetch studios: %s", err.Error())
 	}
 
-	logger.Info("[studios] exporting")
+	job.Logger(ctx).Info("[studios] exporting")
 	startTime := time.Now()
 
 	jobCh := make(chan *models.Studio, workers*2) // make a buffered channel to feed workers
@@ -948,7 +1065,7 @@ func (t *ExportTask) ExportStudios(ctx context.Context, workers int, repo Reposi
 
 	for i, studio := range studios {
 		index := i + 1
-		logger.Progressf("[studios] %d of %d", index, len(studios))
+		job.Logger(ctx).Progressf("[studios] %d of %d", index, len(studios))
 
 		jobCh <- studio // feed workers
 	}
@@ -956,7 +1073,7 @@ func (t *ExportTask) ExportStudios(ctx context.Context, workers int, repo Reposi
 	close(jobCh)
 	studiosWg.Wait()
 
-	logger.Infof("[studios] export complete in %s. %d workers used.", time.Since(startTime), workers)
+	job.Logger(ctx).Infof("[studios] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
 
 func (t *ExportTask) exportStudio(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models.Studio, repo Repository) {
@@ -968,14 +1085,20 @@ func (t *ExportTask) exportStudio(ctx context.Context, wg *sync.WaitGroup, jobCh
 		newStudioJSON, err := studio.ToJSON(ctx, studioReader, s)
 
 		if err != nil {
-			logger.Errorf("[studios] <%s> error getting studio JSON: %s", s.Checksum, err.Error())
+			job.Logger(ctx).Errorf("[studios] <%s> error getting studio JSON: %s", s.Checksum, err.Error())
+			continue
+		}
+
+		newStudioJSON.CustomFields, err = studioReader.GetCustomFields(ctx, s.ID)
+		if err != nil {
+			job.Logger(ctx).Errorf("[studios] <%s> error getting studio custom fields: %s", s.Checksum, err.Error())
 			continue
 		}
 
 		fn := newStudioJSON.Filename()
 
 		if err := t.json.saveStudio(fn, newStudioJSON); err != nil {
-			logger.Errorf("[studios] <%s> failed to save json: %s", s.Checksum, err.Error())
+			job.Logger(ctx).Errorf("[studios] <%s> failed to save json: %s", s.Checksum, err.Error())
 		}
 	}
 }
@@ -994,10 +1117,10 @@ func (t *ExportTask) ExportTags(ctx context.Context, workers int, repo Repositor
 	}
 
 	if err != nil {
-		logger.Errorf("[tags] failed to fetch tags: %s", err.Error())
+		job.Logger(ctx).Errorf("[tags] failed to fetch tags: %s", err.Error())
 	}
 
-	logger.Info("[tags] exporting")
+	job.Logger(ctx).Info("[tags] exporting")
 	startTime := time.Now()
 
 	jobCh := make(chan *models.Tag, workers*2) // make a buffered channel to feed workers
@@ -1009,7 +1132,7 @@ func (t *ExportTask) ExportTags(ctx context.Context, workers int, repo Repositor
 
 	for i, tag := range tags {
 		index := i + 1
-		logger.Progressf("[tags] %d of %d", index, len(tags))
+		job.Logger(ctx).Progressf("[tags] %d of %d", index, len(tags))
 
 		jobCh <- tag // feed workers
 	}
@@ -1017,7 +1140,7 @@ func (t *ExportTask) ExportTags(ctx context.Context, workers int, repo Repositor
 	close(jobCh)
 	tagsWg.Wait()
 
-	logger.Infof("[tags] export complete in %s. %d workers used.", time.Since(startTime), workers)
+	job.Logger(ctx).Infof("[tags] export complete in %s. %d workers used.", time.Since(startTime), workers)
 }
 
 func (t *ExportTask) exportTag(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models.Tag, repo Repository) {
@@ -1029,14 +1152,20 @@ func (t *ExportTask) exportTag(ctx context.Context, wg *sync.WaitGroup, jobChan
 		newTagJSON, err := tag.ToJSON(ctx, tagReader, thisTag)
 
 		if err != nil {
-			logger.Errorf("[tags] <%s> error getting tag JSON: %s", thisTag.Name, err.Error())
+			job.Logger(ctx).Errorf("[tags] <%s> error getting tag JSON: %s", thisTag.Name, err.Error())
+			continue
+		}
+
+		newTagJSON.CustomFields, err = tagReader.GetCustomFields(ctx, thisTag.ID)
+		if err != nil {
+			job.Logger(ctx).Errorf("[tags] <%s> error getting tag custom fields: %s", thisTag.Name, err.Error())
 			continue
 		}
 
 		fn := newTagJSON.Filename()
 
 		if err := t.json.saveTag(fn, newTagJSON); err != nil {
-			logger.Errorf("[tags] <%s> failed to save json: %s", fn, err.Error())
+			job.Logger(ctx).Errorf("[tags] <%s> failed to save json: %s", fn, err.Error())
 		}
 	}
 }
@@ -1055,10 +1184,10 @@ func (t *ExportTask) ExportMovies(ctx context.Context, workers int, repo Reposit
 	}
 
 	if err != nil {
-		logger.Errorf("[movies] failed to fetch movies: %s", err.Error())
+		job.Logger(ctx).Errorf("[movies] failed to fetch movies: %s", err.Error())
 	}
 
-	logger.Info("[movies] exporting")
+	job.Logger(ctx).Info("[movies] exporting")
 	startTime := time.Now()
 
 	jobCh := make(chan *models.Movie, workers*2) // make a buffered channel to feed workers
@@ -1070,7 +1199,7 @@ func (t *ExportTask) ExportMovies(ctx context.Context, workers int, repo Reposit
 
 	for i, movie := range movies {
 		index := i + 1
-		logger.Progressf("[movies] %d of %d", index, len(movies))
+		job.Logger(ctx).Progressf("[movies] %d of %d", index, len(movies))
 
 		jobCh <- movie // feed workers
 	}
@@ -1078,7 +1207,7 @@ func (t *ExportTask) ExportMovies(ctx context.Context, workers int, repo Reposit
 	close(jobCh)
 	moviesWg.Wait()
 
-	logger.Infof("[movies] export complete in %s. %d workers used.", time.Since(startTime), workers)
+	job.Logger(ctx).Infof("[movies] export complete in %s. %d workers used.", time.Since(startTime), workers)
 
 }
 func (t *ExportTask) exportMovie(ctx context.Context, wg *sync.WaitGroup, jobChan <-chan *models.Movie, repo Repository) {
@@ -1091,7 +1220,13 @@ func (t *ExportTask) exportMovie(ctx context.Context, wg *sync.WaitGroup, jobCha
 		newMovieJSON, err := movie.ToJSON(ctx, movieReader, studioReader, m)
 
 		if err != nil {
-			logger.Errorf("[movies] <%s> error getting tag JSON: %s", m.Checksum, err.Error())
+			job.Logger(ctx).Errorf("[movies] <%s> error getting tag JSON: %s", m.Checksum, err.Error())
+			continue
+		}
+
+		newMovieJSON.CustomFields, err = movieReader.GetCustomFields(ctx, m.ID)
+		if err != nil {
+			job.Logger(ctx).Errorf("[movies] <%s> error getting movie custom fields: %s", m.Checksum, err.Error())
 			continue
 		}
 
@@ -1104,26 +1239,54 @@ func (t *ExportTask) exportMovie(ctx context.Context, wg *sync.WaitGroup, jobCha
 		fn := newMovieJSON.Filename()
 
 		if err := t.json.saveMovie(fn, newMovieJSON); err != nil {
-			logger.Errorf("[movies] <%s> failed to save json: %s", fn, err.Error())
+			job.Logger(ctx).Errorf("[movies] <%s> failed to save json: %s", fn, err.Error())
 		}
 	}
 }
 
+func (t *ExportTask) ExportCustomFields(ctx context.Context, repo Repository) {
+	definitions, err := repo.CustomFieldDefinition.All(ctx)
+	if err != nil {
+		job.Logger(ctx).Errorf("[custom fields] failed to fetch custom field definitions: %s", err.Error())
+		return
+	}
+
+	job.Logger(ctx).Info("[custom fields] exporting")
+
+	ret := []jsonschema.CustomFieldDefinition{}
+	for _, d := range definitions {
+		ret = append(ret, jsonschema.CustomFieldDefinition{
+			EntityType: d.EntityType.String(),
+			Name:       d.Name,
+			Type:       d.Type.String(),
+			Options:    d.Options,
+			CreatedAt:  json.JSONTime{Time: d.CreatedAt},
+			UpdatedAt:  json.JSONTime{Time: d.UpdatedAt},
+		})
+	}
+
+	if err := t.json.saveCustomFields(ret); err != nil {
+		job.Logger(ctx).Errorf("[custom fields] failed to save json: %s", err.Error())
+	}
+
+	job.Logger(ctx).Infof("[custom fields] export complete")
+}
+
 func (t *ExportTask) ExportScrapedItems(ctx context.Context, repo Repository) {
 	qb := repo.ScrapedItem
 	sqb := repo.Studio
 	scrapedItems, err := qb.All(ctx)
 	if err != nil {
-		logger.Errorf("[scraped sites] failed to fetch all items: %s", err.Error())
+		job.Logger(ctx).Errorf("[scraped sites] failed to fetch all items: %s", err.Error())
 	}
 
-	logger.Info("[scraped sites] exporting")
+	job.Logger(ctx).Info("[scraped sites] exporting")
 
 	scraped := []jsonschema.ScrapedItem{}
 
 	for i, scrapedItem := range scrapedItems {
 		index := i + 1
-		logger.Progressf("[scraped sites] %d of %d", index, len(scrapedItems))
+		job.Logger(ctx).Progressf("[scraped sites] %d of %d", index, len(scrapedItems))
 
 		var studioName string
 		if scrapedItem.StudioID.Valid {
@@ -1181,13 +1344,13 @@ func (t *ExportTask) ExportScrapedItems(ctx context.Context, repo Repository) {
 
 	scrapedJSON, err := t.json.getScraped()
 	if err != nil {
-		logger.Debugf("[scraped sites] error reading json: %s", err.Error())
+		job.Logger(ctx).Debugf("[scraped sites] error reading json: %s", err.Error())
 	}
 	if !jsonschema.CompareJSON(scrapedJSON, scraped) {
 		if err := t.json.saveScaped(scraped); err != nil {
-			logger.Errorf("[scraped sites] failed to save json: %s", err.Error())
+			job.Logger(ctx).Errorf("[scraped sites] failed to save json: %s", err.Error())
 		}
 	}
 
-	logger.Infof("[scraped sites] export complete")
+	job.Logger(ctx).Infof("[scraped sites] export complete")
 }
@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/jsonschema"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/models/paths"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// exportGalleryReader implements the gallery methods used by exportGallery.
+type exportGalleryReader struct {
+	GalleryReaderWriter
+	urls []string
+}
+
+func (r *exportGalleryReader) GetFiles(ctx context.Context, relatedID int) ([]file.File, error) {
+	return []file.File{}, nil
+}
+
+func (r *exportGalleryReader) GetURLs(ctx context.Context, relatedID int) ([]string, error) {
+	return r.urls, nil
+}
+
+func (r *exportGalleryReader) GetCustomFields(ctx context.Context, id int) (models.CustomFieldMap, error) {
+	return nil, nil
+}
+
+func TestExportGallery(t *testing.T) {
+	const (
+		galleryID = 1
+		title     = "title"
+	)
+	urls := []string{"https://a.test", "https://b.test"}
+
+	performerReader := &mocks.PerformerReaderWriter{}
+	performerReader.On("FindByGalleryID", mock.Anything, galleryID).Return(nil, nil)
+	tagReader := &mocks.TagReaderWriter{}
+	tagReader.On("FindByGalleryID", mock.Anything, galleryID).Return(nil, nil)
+
+	repo := Repository{
+		Gallery:   &exportGalleryReader{urls: urls},
+		Performer: performerReader,
+		Tag:       tagReader,
+	}
+
+	jsonPaths := paths.GetJSONPaths(t.TempDir())
+	paths.EnsureJSONDirs(jsonPaths.Metadata)
+	task := &ExportTask{
+		json: jsonUtils{json: *jsonPaths},
+	}
+
+	// the urls of the gallery have not been loaded
+	g := &models.Gallery{
+		ID:    galleryID,
+		Title: title,
+	}
+
+	var wg sync.WaitGroup
+	jobCh := make(chan *models.Gallery, 1)
+	jobCh <- g
+	close(jobCh)
+
+	wg.Add(1)
+	exportGallery(context.Background(), &wg, jobCh, repo, task)
+
+	fn := (&jsonschema.Gallery{}).Filename(title, "1")
+	got, err := jsonschema.LoadGalleryFile(filepath.Join(jsonPaths.Galleries, fn))
+	if err != nil {
+		t.Fatalf("error loading exported gallery: %v", err)
+	}
+
+	assert.Equal(t, urls, got.URLs)
+}
@@ -2,13 +2,14 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/remeh/sizedwaitgroup"
 	"github.com/stashapp/stash/internal/manager/config"
 	"github.com/stashapp/stash/pkg/job"
-	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scene/generate"
@@ -52,12 +53,24 @@ type GeneratePreviewOptionsInput struct {
 
 const generateQueueSize = 200000
 
+const generateJobType = "generate"
+
 type GenerateJob struct {
 	txnManager Repository
 	input      GenerateMetadataInput
 
 	overwrite      bool
 	fileNamingAlgo models.HashAlgorithm
+
+	// scenes up to the checkpoint are skipped when resuming
+	checkpoint idCheckpoint
+	tracker    *idCheckpointTracker
+}
+
+// generateSceneTask is a generate task for a scene.
+type generateSceneTask struct {
+	Task
+	sceneID int
 }
 
 type totalsGenerate struct {
@@ -72,6 +85,37 @@ type totalsGenerate struct {
 	tasks int
 }
 
+// JobInput returns the input of the job to be recorded in the job history.
+func (j *GenerateJob) JobInput() interface{} {
+	return j.input
+}
+
+// ResourceClass returns ResourceCPU, since generating runs ffmpeg.
+func (j *GenerateJob) ResourceClass() job.ResourceClass {
+	return job.ResourceCPU
+}
+
+// JobType returns the type used to resume the job.
+func (j *GenerateJob) JobType() string {
+	return generateJobType
+}
+
+func resumeGenerateJob(s *Manager, input []byte, checkpoint []byte) (job.JobExec, error) {
+	ret := &GenerateJob{
+		txnManager: s.Repository,
+	}
+
+	if err := json.Unmarshal(input, &ret.input); err != nil {
+		return nil, fmt.Errorf("decoding input: %w", err)
+	}
+
+	if err := unmarshalCheckpoint(checkpoint, &ret.checkpoint); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 	var scenes []*models.Scene
 	var err error
@@ -85,7 +129,12 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 	config := config.GetInstance()
 	parallelTasks := config.GetParallelTasksWithAutoDetection()
 
-	logger.Infof("Generate started with %d parallel tasks", parallelTasks)
+	j.tracker = newIDCheckpointTracker(j.checkpoint, progress.SetCheckpoint)
+	if j.checkpoint.LastID > 0 {
+		job.Logger(ctx).Infof("Resuming generate after scene id %d", j.checkpoint.LastID)
+	}
+
+	job.Logger(ctx).Infof("Generate started with %d parallel tasks", parallelTasks)
 
 	queue := make(chan Task, generateQueueSize)
 	go func() {
@@ -94,11 +143,11 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 		var totals totalsGenerate
 		sceneIDs, err := stringslice.StringSliceToIntSlice(j.input.SceneIDs)
 		if err != nil {
-			logger.Error(err.Error())
+			job.Logger(ctx).Error(err.Error())
 		}
 		markerIDs, err := stringslice.StringSliceToIntSlice(j.input.MarkerIDs)
 		if err != nil {
-			logger.Error(err.Error())
+			job.Logger(ctx).Error(err.Error())
 		}
 
 		g := &generate.Generator{
@@ -115,13 +164,19 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 				totals = j.queueTasks(ctx, g, queue)
 			} else {
 				if len(j.input.SceneIDs) > 0 {
+					// queue in id order so that the job can be resumed
+					sort.Ints(sceneIDs)
 					scenes, err = qb.FindMany(ctx, sceneIDs)
 					for _, s := range scenes {
+						if j.checkpoint.processed(s.ID) {
+							continue
+						}
+
 						if err := s.LoadFiles(ctx, qb); err != nil {
 							return err
 						}
 
-						j.queueSceneJobs(ctx, g, s, queue, &totals)
+						j.queueScene(ctx, g, s, queue, &totals)
 					}
 				}
 
@@ -138,11 +193,12 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 
 			return nil
 		}); err != nil {
-			logger.Error(err.Error())
+			job.Logger(ctx).Error(err.Error())
+			progress.SetError(err)
 			return
 		}
 
-		logger.Infof("Generating %d sprites %d previews %d image previews %d markers %d transcodes %d phashes %d heatmaps & speeds", totals.sprites, totals.previews, totals.imagePreviews, totals.markers, totals.transcodes, totals.phashes, totals.interactiveHeatmapSpeeds)
+		job.Logger(ctx).Infof("Generating %d sprites %d previews %d image previews %d markers %d transcodes %d phashes %d heatmaps & speeds", totals.sprites, totals.previews, totals.imagePreviews, totals.markers, totals.transcodes, totals.phashes, totals.interactiveHeatmapSpeeds)
 
 		progress.SetTotal(int(totals.tasks))
 	}()
@@ -152,12 +208,12 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 	// Start measuring how long the generate has taken. (consider moving this up)
 	start := time.Now()
 	if err = instance.Paths.Generated.EnsureTmpDir(); err != nil {
-		logger.Warnf("could not create temporary directory: %v", err)
+		job.Logger(ctx).Warnf("could not create temporary directory: %v", err)
 	}
 
 	defer func() {
 		if err := instance.Paths.Generated.EmptyTmpDir(); err != nil {
-			logger.Warnf("failure emptying temporary directory: %v", err)
+			job.Logger(ctx).Warnf("failure emptying temporary directory: %v", err)
 		}
 	}()
 
@@ -172,6 +228,9 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 		localTask := f
 		go progress.ExecuteTask(localTask.GetDescription(), func() {
 			localTask.Start(ctx)
+			if t, ok := localTask.(*generateSceneTask); ok {
+				j.tracker.done(t.sceneID)
+			}
 			wg.Done()
 			progress.Increment()
 		})
@@ -180,12 +239,12 @@ func (j *GenerateJob) Execute(ctx context.Context, progress *job.Progress) {
 	wg.Wait()
 
 	if job.IsCancelled(ctx) {
-		logger.Info("Stopping due to user request")
+		job.Logger(ctx).Info("Stopping due to user request")
 		return
 	}
 
 	elapsed := time.Since(start)
-	logger.Info(fmt.Sprintf("Generate finished (%s)", elapsed))
+	job.Logger(ctx).Info(fmt.Sprintf("Generate finished (%s)", elapsed))
 }
 
 func (j *GenerateJob) queueTasks(ctx context.Context, g *generate.Generator, queue chan<- Task) totalsGenerate {
@@ -193,7 +252,10 @@ func (j *GenerateJob) queueTasks(ctx context.Context, g *generate.Generator, que
 
 	const batchSize = 1000
 
+	// query in id order so that the job can be resumed
 	findFilter := models.BatchFindFilter(batchSize)
+	sortBy := "id"
+	findFilter.Sort = &sortBy
 
 	for more := true; more; {
 		if job.IsCancelled(ctx) {
@@ -202,7 +264,7 @@ func (j *GenerateJob) queueTasks(ctx context.Context, g *generate.Generator, que
 
 		scenes, err := scene.Query(ctx, j.txnManager.Scene, nil, findFilter)
 		if err != nil {
-			logger.Errorf("Error encountered queuing files to scan: %s", err.Error())
+			job.Logger(ctx).Errorf("Error encountered queuing files to scan: %s", err.Error())
 			return totals
 		}
 
@@ -211,12 +273,16 @@ func (j *GenerateJob) queueTasks(ctx context.Context, g *generate.Generator, que
 				return totals
 			}
 
+			if j.checkpoint.processed(ss.ID) {
+				continue
+			}
+
 			if err := ss.LoadFiles(ctx, j.txnManager.Scene); err != nil {
-				logger.Errorf("Error encountered queuing files to scan: %s", err.Error())
+				job.Logger(ctx).Errorf("Error encountered queuing files to scan: %s", err.Error())
 				return totals
 			}
 
-			j.queueSceneJobs(ctx, g, ss, queue, &totals)
+			j.queueScene(ctx, g, ss, queue, &totals)
 		}
 
 		if len(scenes) != batchSize {
@@ -264,6 +330,23 @@ func getGeneratePreviewOptions(optionsInput GeneratePreviewOptionsInput) generat
 	return ret
 }
 
+// queueScene queues the tasks for a scene. The scene is tracked so that the
+// checkpoint only advances past the scene once all of its tasks are done.
+func (j *GenerateJob) queueScene(ctx context.Context, g *generate.Generator, scene *models.Scene, queue chan<- Task, totals *totalsGenerate) {
+	j.tracker.add(scene.ID)
+	j.queueSceneJobs(ctx, g, scene, queue, totals)
+	j.tracker.done(scene.ID)
+}
+
+// sceneTask tracks a task for the scene.
+func (j *GenerateJob) sceneTask(scene *models.Scene, t Task) Task {
+	j.tracker.add(scene.ID)
+	return &generateSceneTask{
+		Task:    t,
+		sceneID: scene.ID,
+	}
+}
+
 func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator, scene *models.Scene, queue chan<- Task, totals *totalsGenerate) {
 	if utils.IsTrue(j.input.Sprites) {
 		task := &GenerateSpriteTask{
@@ -275,7 +358,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		if j.overwrite || task.required() {
 			totals.sprites++
 			totals.tasks++
-			queue <- task
+			queue <- j.sceneTask(scene, task)
 		}
 	}
 
@@ -310,7 +393,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 
 			if addTask {
 				totals.tasks++
-				queue <- task
+				queue <- j.sceneTask(scene, task)
 			}
 		}
 	}
@@ -332,7 +415,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			totals.markers += int64(markers)
 			totals.tasks++
 
-			queue <- task
+			queue <- j.sceneTask(scene, task)
 		}
 	}
 
@@ -348,7 +431,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		if task.isTranscodeNeeded() {
 			totals.transcodes++
 			totals.tasks++
-			queue <- task
+			queue <- j.sceneTask(scene, task)
 		}
 	}
 
@@ -366,7 +449,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 			if task.shouldGenerate() {
 				totals.phashes++
 				totals.tasks++
-				queue <- task
+				queue <- j.sceneTask(scene, task)
 			}
 		}
 	}
@@ -382,7 +465,7 @@ func (j *GenerateJob) queueSceneJobs(ctx context.Context, g *generate.Generator,
 		if task.shouldGenerate() {
 			totals.interactiveHeatmapSpeeds++
 			totals.tasks++
-			queue <- task
+			queue <- j.sceneTask(scene, task)
 		}
 	}
 }
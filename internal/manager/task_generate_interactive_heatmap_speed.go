@@ -6,7 +6,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/file/video"
 	"github.com/stashapp/stash/pkg/fsutil"
-	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -35,7 +35,7 @@ func (t *GenerateInteractiveHeatmapSpeedTask) Start(ctx context.Context) {
 	err := generator.Generate()
 
 	if err != nil {
-		logger.Errorf("error generating heatmap: %s", err.Error())
+		job.Logger(ctx).Errorf("error generating heatmap: %s", err.Error())
 		return
 	}
 
@@ -47,7 +47,7 @@ func (t *GenerateInteractiveHeatmapSpeedTask) Start(ctx context.Context) {
 		qb := t.TxnManager.File
 		return qb.Update(ctx, primaryFile)
 	}); err != nil {
-		logger.Error(err.Error())
+		job.Logger(ctx).Error(err.Error())
 	}
 
 }
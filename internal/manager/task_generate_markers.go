@@ -7,6 +7,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene/generate"
@@ -51,12 +52,12 @@ func (t *GenerateMarkersTask) Start(ctx context.Context) {
 
 			return err
 		}); err != nil {
-			logger.Errorf("error finding scene for marker: %s", err.Error())
+			job.Logger(ctx).Errorf("error finding scene for marker: %s", err.Error())
 			return
 		}
 
 		if scene == nil {
-			logger.Errorf("scene not found for id %d", t.Marker.SceneID.Int64)
+			job.Logger(ctx).Errorf("scene not found for id %d", t.Marker.SceneID.Int64)
 			return
 		}
 
@@ -78,7 +79,7 @@ func (t *GenerateMarkersTask) generateSceneMarkers(ctx context.Context) {
 		sceneMarkers, err = t.TxnManager.SceneMarker.FindBySceneID(ctx, t.Scene.ID)
 		return err
 	}); err != nil {
-		logger.Errorf("error getting scene markers: %s", err.Error())
+		job.Logger(ctx).Errorf("error getting scene markers: %s", err.Error())
 		return
 	}
 
@@ -93,12 +94,12 @@ func (t *GenerateMarkersTask) generateSceneMarkers(ctx context.Context) {
 	// Make the folder for the scenes markers
 	markersFolder := filepath.Join(instance.Paths.Generated.Markers, sceneHash)
 	if err := fsutil.EnsureDir(markersFolder); err != nil {
-		logger.Warnf("could not create the markers folder (%v): %v", markersFolder, err)
+		job.Logger(ctx).Warnf("could not create the markers folder (%v): %v", markersFolder, err)
 	}
 
 	for i, sceneMarker := range sceneMarkers {
 		index := i + 1
-		logger.Progressf("[generator] <%s> scene marker %d of %d", sceneHash, index, len(sceneMarkers))
+		job.Logger(ctx).Progressf("[generator] <%s> scene marker %d of %d", sceneHash, index, len(sceneMarkers))
 
 		t.generateMarker(videoFile, t.Scene, sceneMarker)
 	}
@@ -134,7 +135,7 @@ func (t *GenerateMarkersTask) markersNeeded(ctx context.Context) int {
 	markers := 0
 	sceneMarkers, err := t.TxnManager.SceneMarker.FindBySceneID(ctx, t.Scene.ID)
 	if err != nil {
-		logger.Errorf("error finding scene markers: %s", err.Error())
+		job.Logger(ctx).Errorf("error finding scene markers: %s", err.Error())
 		return 0
 	}
 
@@ -6,7 +6,7 @@ import (
 
 	"github.com/stashapp/stash/pkg/file"
 	"github.com/stashapp/stash/pkg/hash/videophash"
-	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/txn"
 )
@@ -30,7 +30,7 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 
 	hash, err := videophash.Generate(instance.FFMPEG, t.File)
 	if err != nil {
-		logger.Errorf("error generating phash: %s", err.Error())
+		job.Logger(ctx).Errorf("error generating phash: %s", err.Error())
 		logErrorOutput(err)
 		return
 	}
@@ -45,7 +45,7 @@ func (t *GeneratePhashTask) Start(ctx context.Context) {
 
 		return qb.Update(ctx, t.File)
 	}); err != nil {
-		logger.Error(err.Error())
+		job.Logger(ctx).Error(err.Error())
 	}
 }
 
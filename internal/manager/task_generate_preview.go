@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene/generate"
@@ -34,21 +35,21 @@ func (t *GeneratePreviewTask) Start(ctx context.Context) {
 	ffprobe := instance.FFProbe
 	videoFile, err := ffprobe.NewVideoFile(t.Scene.Path)
 	if err != nil {
-		logger.Errorf("error reading video file: %v", err)
+		job.Logger(ctx).Errorf("error reading video file: %v", err)
 		return
 	}
 
 	videoChecksum := t.Scene.GetHash(t.fileNamingAlgorithm)
 
 	if err := t.generateVideo(videoChecksum, videoFile.Duration); err != nil {
-		logger.Errorf("error generating preview: %v", err)
+		job.Logger(ctx).Errorf("error generating preview: %v", err)
 		logErrorOutput(err)
 		return
 	}
 
 	if t.ImagePreview {
 		if err := t.generateWebp(videoChecksum); err != nil {
-			logger.Errorf("error generating preview webp: %v", err)
+			job.Logger(ctx).Errorf("error generating preview webp: %v", err)
 			logErrorOutput(err)
 		}
 	}
@@ -6,7 +6,7 @@ import (
 	"io"
 	"os"
 
-	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scene/generate"
@@ -41,7 +41,7 @@ func (t *GenerateScreenshotTask) Start(ctx context.Context) {
 	// in the database. We'll use SetSceneScreenshot to set the data
 	// which also generates the thumbnail
 
-	logger.Debugf("Creating screenshot for %s", scenePath)
+	job.Logger(ctx).Debugf("Creating screenshot for %s", scenePath)
 
 	g := generate.Generator{
 		Encoder:     instance.FFMPEG,
@@ -53,21 +53,21 @@ func (t *GenerateScreenshotTask) Start(ctx context.Context) {
 	if err := g.Screenshot(context.TODO(), videoFile.Path, checksum, videoFile.Width, videoFile.Duration, generate.ScreenshotOptions{
 		At: &at,
 	}); err != nil {
-		logger.Errorf("Error generating screenshot: %v", err)
+		job.Logger(ctx).Errorf("Error generating screenshot: %v", err)
 		logErrorOutput(err)
 		return
 	}
 
 	f, err := os.Open(normalPath)
 	if err != nil {
-		logger.Errorf("Error reading screenshot: %s", err.Error())
+		job.Logger(ctx).Errorf("Error reading screenshot: %s", err.Error())
 		return
 	}
 	defer f.Close()
 
 	coverImageData, err := io.ReadAll(f)
 	if err != nil {
-		logger.Errorf("Error reading screenshot: %s", err.Error())
+		job.Logger(ctx).Errorf("Error reading screenshot: %s", err.Error())
 		return
 	}
 
@@ -92,6 +92,6 @@ func (t *GenerateScreenshotTask) Start(ctx context.Context) {
 
 		return nil
 	}); err != nil {
-		logger.Error(err.Error())
+		job.Logger(ctx).Error(err.Error())
 	}
 }
@@ -5,7 +5,7 @@ import (
 	"fmt"
 
 	"github.com/stashapp/stash/pkg/fsutil"
-	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/models"
 )
 
@@ -27,7 +27,7 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 	ffprobe := instance.FFProbe
 	videoFile, err := ffprobe.NewVideoFile(t.Scene.Path)
 	if err != nil {
-		logger.Errorf("error reading video file: %s", err.Error())
+		job.Logger(ctx).Errorf("error reading video file: %s", err.Error())
 		return
 	}
 
@@ -37,13 +37,13 @@ func (t *GenerateSpriteTask) Start(ctx context.Context) {
 	generator, err := NewSpriteGenerator(*videoFile, sceneHash, imagePath, vttPath, 9, 9)
 
 	if err != nil {
-		logger.Errorf("error creating sprite generator: %s", err.Error())
+		job.Logger(ctx).Errorf("error creating sprite generator: %s", err.Error())
 		return
 	}
 	generator.Overwrite = t.Overwrite
 
 	if err := generator.Generate(); err != nil {
-		logger.Errorf("error generating sprite: %s", err.Error())
+		job.Logger(ctx).Errorf("error generating sprite: %s", err.Error())
 		logErrorOutput(err)
 		return
 	}
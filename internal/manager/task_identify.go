@@ -2,29 +2,36 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/stashapp/stash/internal/identify"
 	"github.com/stashapp/stash/pkg/job"
-	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scraper"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 var ErrInput = errors.New("invalid request input")
 
+const identifyJobType = "identify"
+
 type IdentifyJob struct {
 	postHookExecutor identify.SceneUpdatePostHookExecutor
 	input            identify.Options
 
 	stashBoxes []*models.StashBox
 	progress   *job.Progress
+
+	// scenes up to the checkpoint are skipped when resuming
+	checkpoint idCheckpoint
 }
 
 func CreateIdentifyJob(input identify.Options) *IdentifyJob {
@@ -35,6 +42,36 @@ func CreateIdentifyJob(input identify.Options) *IdentifyJob {
 	}
 }
 
+// JobInput returns the input of the job to be recorded in the job history.
+func (j *IdentifyJob) JobInput() interface{} {
+	return j.input
+}
+
+// ResourceClass returns ResourceNetwork, since identifying mostly waits on
+// scrapers and stash-box.
+func (j *IdentifyJob) ResourceClass() job.ResourceClass {
+	return job.ResourceNetwork
+}
+
+// JobType returns the type used to resume the job.
+func (j *IdentifyJob) JobType() string {
+	return identifyJobType
+}
+
+func resumeIdentifyJob(s *Manager, input []byte, checkpoint []byte) (job.JobExec, error) {
+	var options identify.Options
+	if err := json.Unmarshal(input, &options); err != nil {
+		return nil, fmt.Errorf("decoding input: %w", err)
+	}
+
+	ret := CreateIdentifyJob(options)
+	if err := unmarshalCheckpoint(checkpoint, &ret.checkpoint); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) {
 	j.progress = progress
 
@@ -45,12 +82,14 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) {
 
 	sources, err := j.getSources()
 	if err != nil {
-		logger.Error(err)
+		job.Logger(ctx).Error(err)
+		progress.SetError(err)
 		return
 	}
 
 	// if scene ids provided, use those
-	// otherwise, batch query for all scenes - ordering by path
+	// otherwise, batch query for all scenes - ordering by id so that the job
+	// can be resumed
 	// don't use a transaction to query scenes
 	if err := txn.WithDatabase(ctx, instance.Repository, func(ctx context.Context) error {
 		if len(j.input.SceneIDs) == 0 {
@@ -63,11 +102,17 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) {
 		}
 
 		progress.SetTotal(len(sceneIDs))
+		sort.Ints(sceneIDs)
 		for _, id := range sceneIDs {
 			if job.IsCancelled(ctx) {
 				break
 			}
 
+			if j.checkpoint.processed(id) {
+				progress.Increment()
+				continue
+			}
+
 			// find the scene
 			var err error
 			scene, err := instance.Repository.Scene.Find(ctx, id)
@@ -84,7 +129,8 @@ func (j *IdentifyJob) Execute(ctx context.Context, progress *job.Progress) {
 
 		return nil
 	}); err != nil {
-		logger.Errorf("Error encountered while identifying scenes: %v", err)
+		job.Logger(ctx).Errorf("Error encountered while identifying scenes: %v", err)
+		progress.SetError(err)
 	}
 }
 
@@ -94,9 +140,9 @@ func (j *IdentifyJob) identifyAllScenes(ctx context.Context, sources []identify.
 	sceneFilter := scene.FilterFromPaths(j.input.Paths)
 	sceneFilter.Organized = &organised
 
-	sort := "path"
+	sortBy := "id"
 	findFilter := &models.FindFilterType{
-		Sort: &sort,
+		Sort: &sortBy,
 	}
 
 	// get the count
@@ -120,6 +166,11 @@ func (j *IdentifyJob) identifyAllScenes(ctx context.Context, sources []identify.
 			return nil
 		}
 
+		if j.checkpoint.processed(scene.ID) {
+			j.progress.Increment()
+			return nil
+		}
+
 		j.identifyScene(ctx, scene, sources)
 		return nil
 	})
@@ -130,7 +181,10 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 		return
 	}
 
-	var taskError error
+	var (
+		modified  bool
+		taskError error
+	)
 	j.progress.ExecuteTask("Identifying "+s.Path, func() {
 		task := identify.SceneIdentifier{
 			SceneReaderUpdater: instance.Repository.Scene,
@@ -145,16 +199,24 @@ func (j *IdentifyJob) identifyScene(ctx context.Context, s *models.Scene, source
 				FileNamingAlgorithm: instance.Config.GetVideoFileNamingAlgorithm(),
 			},
 			SceneUpdatePostHookExecutor: j.postHookExecutor,
+
+			ReviewChanges:       utils.IsTrue(j.input.ReviewChanges),
+			PendingChangeWriter: instance.Repository.ScenePendingChange,
 		}
 
-		taskError = task.Identify(ctx, instance.Repository, s)
+		modified, taskError = task.Identify(ctx, instance.Repository, s)
 	})
 
 	if taskError != nil {
-		logger.Errorf("Error encountered identifying %s: %v", s.Path, taskError)
+		job.Logger(ctx).Errorf("Error encountered identifying %s: %v", s.Path, taskError)
+	} else if modified {
+		j.progress.AddUpdated(1)
+	} else {
+		j.progress.AddSkipped(1)
 	}
 
 	j.progress.Increment()
+	j.progress.SetCheckpoint(idCheckpoint{LastID: s.ID})
 }
 
 func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
@@ -176,7 +238,7 @@ func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 						Performer: instance.Repository.Performer,
 						Tag:       instance.Repository.Tag,
 						Studio:    instance.Repository.Studio,
-					}),
+					}, instance.ScraperCache.ResponseCache()),
 					stashBox.Endpoint,
 				},
 				RemoteSite: stashBox.Endpoint,
@@ -203,6 +265,73 @@ func (j *IdentifyJob) getSources() ([]identify.ScraperSource, error) {
 	return ret, nil
 }
 
+// identifySource is an identify source resolved to either a scraper or a
+// stash-box instance.
+type identifySource struct {
+	name     string
+	options  *identify.MetadataOptions
+	scraper  *scraperSource
+	stashBox *stashboxSource
+}
+
+// resolveIdentifySources resolves the provided identify sources, returning
+// an error if a source does not exist, or if it is a stash-box source and
+// allowStashBox is false.
+func resolveIdentifySources(sources []*identify.Source, stashBoxes []*models.StashBox, allowStashBox bool) ([]identifySource, error) {
+	var ret []identifySource
+	for _, source := range sources {
+		if source.Source == nil {
+			return nil, fmt.Errorf("%w: source must be set", ErrInput)
+		}
+
+		src := identifySource{
+			options: source.Options,
+		}
+
+		if source.Source.ScraperID != nil {
+			scraperID := *source.Source.ScraperID
+			s := instance.ScraperCache.GetScraper(scraperID)
+			if s == nil {
+				return nil, fmt.Errorf("%w: scraper with id %q", models.ErrNotFound, scraperID)
+			}
+
+			src.name = s.Name
+			src.scraper = &scraperSource{
+				cache:     instance.ScraperCache,
+				scraperID: scraperID,
+			}
+		} else {
+			if !allowStashBox {
+				return nil, fmt.Errorf("%w: scraper_id must be set - stash-box sources are not supported", ErrInput)
+			}
+
+			if source.Source.StashBoxIndex == nil && source.Source.StashBoxEndpoint == nil {
+				return nil, fmt.Errorf("%w: stash_box_index or stash_box_endpoint or scraper_id must be set", ErrInput)
+			}
+
+			stashBox, err := resolveStashBox(stashBoxes, *source.Source)
+			if err != nil {
+				return nil, err
+			}
+
+			src.name = "stash-box: " + stashBox.Endpoint
+			src.stashBox = &stashboxSource{
+				stashbox.NewClient(*stashBox, instance.Repository, stashbox.Repository{
+					Scene:     instance.Repository.Scene,
+					Performer: instance.Repository.Performer,
+					Tag:       instance.Repository.Tag,
+					Studio:    instance.Repository.Studio,
+				}, instance.ScraperCache.ResponseCache()),
+				stashBox.Endpoint,
+			}
+		}
+
+		ret = append(ret, src)
+	}
+
+	return ret, nil
+}
+
 func (j *IdentifyJob) getStashBox(src *scraper.Source) (*models.StashBox, error) {
 	if src.ScraperID != nil {
 		return nil, nil
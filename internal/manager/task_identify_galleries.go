@@ -0,0 +1,181 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type IdentifyGalleriesJob struct {
+	postHookExecutor identify.PostHookExecutor
+	input            identify.GalleryOptions
+
+	progress *job.Progress
+}
+
+func CreateIdentifyGalleriesJob(input identify.GalleryOptions) *IdentifyGalleriesJob {
+	return &IdentifyGalleriesJob{
+		postHookExecutor: instance.PluginCache,
+		input:            input,
+	}
+}
+
+// JobInput returns the input of the job to be recorded in the job history.
+func (j *IdentifyGalleriesJob) JobInput() interface{} {
+	return j.input
+}
+
+// ResourceClass returns ResourceNetwork, as for IdentifyJob.
+func (j *IdentifyGalleriesJob) ResourceClass() job.ResourceClass {
+	return job.ResourceNetwork
+}
+
+func (j *IdentifyGalleriesJob) Execute(ctx context.Context, progress *job.Progress) {
+	j.progress = progress
+
+	// if no sources provided - just return
+	if len(j.input.Sources) == 0 {
+		return
+	}
+
+	sources, err := j.getSources()
+	if err != nil {
+		job.Logger(ctx).Error(err)
+		progress.SetError(err)
+		return
+	}
+
+	var galleries []*models.Gallery
+	if err := txn.WithTxn(ctx, instance.Repository, func(ctx context.Context) error {
+		galleries, err = j.getGalleries(ctx)
+		return err
+	}); err != nil {
+		job.Logger(ctx).Errorf("Error encountered while identifying galleries: %v", err)
+		progress.SetError(err)
+		return
+	}
+
+	progress.SetTotal(len(galleries))
+	for _, g := range galleries {
+		if job.IsCancelled(ctx) {
+			job.Logger(ctx).Info("Stopping due to user request")
+			return
+		}
+
+		j.identifyGallery(ctx, g, sources)
+	}
+}
+
+// getGalleries returns the galleries with the ids provided in the input, or
+// all unorganized galleries if no ids were provided.
+func (j *IdentifyGalleriesJob) getGalleries(ctx context.Context) ([]*models.Gallery, error) {
+	qb := instance.Repository.Gallery
+
+	if len(j.input.GalleryIDs) == 0 {
+		organized := false
+		perPage := models.PerPageAll
+		sort := "path"
+		galleries, _, err := qb.Query(ctx, &models.GalleryFilterType{
+			Organized: &organized,
+		}, &models.FindFilterType{
+			PerPage: &perPage,
+			Sort:    &sort,
+		})
+		return galleries, err
+	}
+
+	galleryIDs, err := stringslice.StringSliceToIntSlice(j.input.GalleryIDs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid gallery IDs: %w", err)
+	}
+
+	var ret []*models.Gallery
+	for _, id := range galleryIDs {
+		g, err := qb.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error finding gallery with id %d: %w", id, err)
+		}
+
+		if g == nil {
+			return nil, fmt.Errorf("%w: gallery with id %d", models.ErrNotFound, id)
+		}
+
+		ret = append(ret, g)
+	}
+
+	return ret, nil
+}
+
+func (j *IdentifyGalleriesJob) identifyGallery(ctx context.Context, g *models.Gallery, sources []identify.GalleryScraperSource) {
+	var (
+		modified  bool
+		taskError error
+	)
+	j.progress.ExecuteTask("Identifying gallery "+g.DisplayName(), func() {
+		task := identify.GalleryIdentifier{
+			GalleryReaderUpdater: instance.Repository.Gallery,
+			StudioCreator:        instance.Repository.Studio,
+			PerformerCreator:     instance.Repository.Performer,
+			TagCreator:           instance.Repository.Tag,
+
+			DefaultOptions:   j.input.Options,
+			Sources:          sources,
+			PostHookExecutor: j.postHookExecutor,
+		}
+
+		modified, taskError = task.Identify(ctx, instance.Repository, g)
+	})
+
+	if taskError != nil {
+		job.Logger(ctx).Errorf("Error encountered identifying gallery %s: %v", g.DisplayName(), taskError)
+	} else if modified {
+		j.progress.AddUpdated(1)
+	} else {
+		j.progress.AddSkipped(1)
+	}
+
+	j.progress.Increment()
+}
+
+func (j *IdentifyGalleriesJob) getSources() ([]identify.GalleryScraperSource, error) {
+	sources, err := resolveIdentifySources(j.input.Sources, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []identify.GalleryScraperSource
+	for _, s := range sources {
+		ret = append(ret, identify.GalleryScraperSource{
+			Name:    s.name,
+			Options: s.options,
+			Scraper: *s.scraper,
+		})
+	}
+
+	return ret, nil
+}
+
+func (s scraperSource) ScrapeGallery(ctx context.Context, galleryID int) (*scraper.ScrapedGallery, error) {
+	content, err := s.cache.ScrapeID(ctx, s.scraperID, galleryID, scraper.ScrapeContentTypeGallery)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't try to convert nil return value
+	if content == nil {
+		return nil, nil
+	}
+
+	if gallery, ok := content.(scraper.ScrapedGallery); ok {
+		return &gallery, nil
+	}
+
+	return nil, errors.New("could not convert content to gallery")
+}
@@ -0,0 +1,170 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type IdentifyMoviesJob struct {
+	postHookExecutor identify.PostHookExecutor
+	input            identify.MovieOptions
+
+	progress *job.Progress
+}
+
+func CreateIdentifyMoviesJob(input identify.MovieOptions) *IdentifyMoviesJob {
+	return &IdentifyMoviesJob{
+		postHookExecutor: instance.PluginCache,
+		input:            input,
+	}
+}
+
+// JobInput returns the input of the job to be recorded in the job history.
+func (j *IdentifyMoviesJob) JobInput() interface{} {
+	return j.input
+}
+
+// ResourceClass returns ResourceNetwork, as for IdentifyJob.
+func (j *IdentifyMoviesJob) ResourceClass() job.ResourceClass {
+	return job.ResourceNetwork
+}
+
+func (j *IdentifyMoviesJob) Execute(ctx context.Context, progress *job.Progress) {
+	j.progress = progress
+
+	// if no sources provided - just return
+	if len(j.input.Sources) == 0 {
+		return
+	}
+
+	sources, err := j.getSources()
+	if err != nil {
+		job.Logger(ctx).Error(err)
+		progress.SetError(err)
+		return
+	}
+
+	var movies []*models.Movie
+	if err := txn.WithTxn(ctx, instance.Repository, func(ctx context.Context) error {
+		movies, err = j.getMovies(ctx)
+		return err
+	}); err != nil {
+		job.Logger(ctx).Errorf("Error encountered while identifying movies: %v", err)
+		progress.SetError(err)
+		return
+	}
+
+	progress.SetTotal(len(movies))
+	for _, m := range movies {
+		if job.IsCancelled(ctx) {
+			job.Logger(ctx).Info("Stopping due to user request")
+			return
+		}
+
+		j.identifyMovie(ctx, m, sources)
+	}
+}
+
+// getMovies returns the movies with the ids provided in the input, or all
+// movies if no ids were provided.
+func (j *IdentifyMoviesJob) getMovies(ctx context.Context) ([]*models.Movie, error) {
+	qb := instance.Repository.Movie
+
+	if len(j.input.MovieIDs) == 0 {
+		return qb.All(ctx)
+	}
+
+	movieIDs, err := stringslice.StringSliceToIntSlice(j.input.MovieIDs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid movie IDs: %w", err)
+	}
+
+	var ret []*models.Movie
+	for _, id := range movieIDs {
+		m, err := qb.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error finding movie with id %d: %w", id, err)
+		}
+
+		if m == nil {
+			return nil, fmt.Errorf("%w: movie with id %d", models.ErrNotFound, id)
+		}
+
+		ret = append(ret, m)
+	}
+
+	return ret, nil
+}
+
+func (j *IdentifyMoviesJob) identifyMovie(ctx context.Context, m *models.Movie, sources []identify.MovieScraperSource) {
+	var (
+		modified  bool
+		taskError error
+	)
+	j.progress.ExecuteTask("Identifying movie "+m.Name.String, func() {
+		task := identify.MovieIdentifier{
+			MovieReaderUpdater: instance.Repository.Movie,
+			StudioCreator:      instance.Repository.Studio,
+
+			DefaultOptions:   j.input.Options,
+			Sources:          sources,
+			PostHookExecutor: j.postHookExecutor,
+		}
+
+		modified, taskError = task.Identify(ctx, instance.Repository, m)
+	})
+
+	if taskError != nil {
+		job.Logger(ctx).Errorf("Error encountered identifying movie %s: %v", m.Name.String, taskError)
+	} else if modified {
+		j.progress.AddUpdated(1)
+	} else {
+		j.progress.AddSkipped(1)
+	}
+
+	j.progress.Increment()
+}
+
+func (j *IdentifyMoviesJob) getSources() ([]identify.MovieScraperSource, error) {
+	sources, err := resolveIdentifySources(j.input.Sources, nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []identify.MovieScraperSource
+	for _, s := range sources {
+		ret = append(ret, identify.MovieScraperSource{
+			Name:    s.name,
+			Options: s.options,
+			Scraper: *s.scraper,
+		})
+	}
+
+	return ret, nil
+}
+
+func (s scraperSource) ScrapeMovie(ctx context.Context, movieID int) (*models.ScrapedMovie, error) {
+	content, err := s.cache.ScrapeID(ctx, s.scraperID, movieID, scraper.ScrapeContentTypeMovie)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't try to convert nil return value
+	if content == nil {
+		return nil, nil
+	}
+
+	if movie, ok := content.(models.ScrapedMovie); ok {
+		return &movie, nil
+	}
+
+	return nil, errors.New("could not convert content to movie")
+}
@@ -0,0 +1,189 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type IdentifyPerformersJob struct {
+	postHookExecutor identify.PostHookExecutor
+	input            identify.PerformerOptions
+
+	stashBoxes []*models.StashBox
+	progress   *job.Progress
+}
+
+func CreateIdentifyPerformersJob(input identify.PerformerOptions) *IdentifyPerformersJob {
+	return &IdentifyPerformersJob{
+		postHookExecutor: instance.PluginCache,
+		input:            input,
+		stashBoxes:       instance.Config.GetStashBoxes(),
+	}
+}
+
+// JobInput returns the input of the job to be recorded in the job history.
+func (j *IdentifyPerformersJob) JobInput() interface{} {
+	return j.input
+}
+
+// ResourceClass returns ResourceNetwork, as for IdentifyJob.
+func (j *IdentifyPerformersJob) ResourceClass() job.ResourceClass {
+	return job.ResourceNetwork
+}
+
+func (j *IdentifyPerformersJob) Execute(ctx context.Context, progress *job.Progress) {
+	j.progress = progress
+
+	// if no sources provided - just return
+	if len(j.input.Sources) == 0 {
+		return
+	}
+
+	sources, err := j.getSources()
+	if err != nil {
+		job.Logger(ctx).Error(err)
+		progress.SetError(err)
+		return
+	}
+
+	var performers []*models.Performer
+	if err := txn.WithTxn(ctx, instance.Repository, func(ctx context.Context) error {
+		performers, err = j.getPerformers(ctx)
+		return err
+	}); err != nil {
+		job.Logger(ctx).Errorf("Error encountered while identifying performers: %v", err)
+		progress.SetError(err)
+		return
+	}
+
+	progress.SetTotal(len(performers))
+	for _, p := range performers {
+		if job.IsCancelled(ctx) {
+			job.Logger(ctx).Info("Stopping due to user request")
+			return
+		}
+
+		j.identifyPerformer(ctx, p, sources)
+	}
+}
+
+// getPerformers returns the performers with the ids provided in the input, or
+// all performers if no ids were provided.
+func (j *IdentifyPerformersJob) getPerformers(ctx context.Context) ([]*models.Performer, error) {
+	qb := instance.Repository.Performer
+
+	if len(j.input.PerformerIDs) == 0 {
+		return qb.All(ctx)
+	}
+
+	performerIDs, err := stringslice.StringSliceToIntSlice(j.input.PerformerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid performer IDs: %w", err)
+	}
+
+	var ret []*models.Performer
+	for _, id := range performerIDs {
+		p, err := qb.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error finding performer with id %d: %w", id, err)
+		}
+
+		if p == nil {
+			return nil, fmt.Errorf("%w: performer with id %d", models.ErrNotFound, id)
+		}
+
+		ret = append(ret, p)
+	}
+
+	return ret, nil
+}
+
+func (j *IdentifyPerformersJob) identifyPerformer(ctx context.Context, p *models.Performer, sources []identify.PerformerScraperSource) {
+	var (
+		modified  bool
+		taskError error
+	)
+	j.progress.ExecuteTask("Identifying performer "+p.Name, func() {
+		task := identify.PerformerIdentifier{
+			PerformerReaderUpdater: instance.Repository.Performer,
+			TagCreator:             instance.Repository.Tag,
+
+			DefaultOptions:   j.input.Options,
+			Sources:          sources,
+			PostHookExecutor: j.postHookExecutor,
+		}
+
+		modified, taskError = task.Identify(ctx, instance.Repository, p)
+	})
+
+	if taskError != nil {
+		job.Logger(ctx).Errorf("Error encountered identifying performer %s: %v", p.Name, taskError)
+	} else if modified {
+		j.progress.AddUpdated(1)
+	} else {
+		j.progress.AddSkipped(1)
+	}
+
+	j.progress.Increment()
+}
+
+func (j *IdentifyPerformersJob) getSources() ([]identify.PerformerScraperSource, error) {
+	sources, err := resolveIdentifySources(j.input.Sources, j.stashBoxes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []identify.PerformerScraperSource
+	for _, s := range sources {
+		src := identify.PerformerScraperSource{
+			Name:    s.name,
+			Options: s.options,
+		}
+
+		if s.stashBox != nil {
+			src.Scraper = *s.stashBox
+			src.RemoteSite = s.stashBox.endpoint
+		} else {
+			src.Scraper = *s.scraper
+		}
+
+		ret = append(ret, src)
+	}
+
+	return ret, nil
+}
+
+func (s stashboxSource) ScrapePerformer(ctx context.Context, performerID int) (*models.ScrapedPerformer, error) {
+	ret, err := s.FindStashBoxPerformer(ctx, performerID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying stash-box using performer ID %d: %w", performerID, err)
+	}
+
+	return ret, nil
+}
+
+func (s scraperSource) ScrapePerformer(ctx context.Context, performerID int) (*models.ScrapedPerformer, error) {
+	content, err := s.cache.ScrapeID(ctx, s.scraperID, performerID, scraper.ScrapeContentTypePerformer)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't try to convert nil return value
+	if content == nil {
+		return nil, nil
+	}
+
+	if performer, ok := content.(models.ScrapedPerformer); ok {
+		return &performer, nil
+	}
+
+	return nil, errors.New("could not convert content to performer")
+}
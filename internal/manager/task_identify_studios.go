@@ -0,0 +1,188 @@
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type IdentifyStudiosJob struct {
+	postHookExecutor identify.PostHookExecutor
+	input            identify.StudioOptions
+
+	stashBoxes []*models.StashBox
+	progress   *job.Progress
+}
+
+func CreateIdentifyStudiosJob(input identify.StudioOptions) *IdentifyStudiosJob {
+	return &IdentifyStudiosJob{
+		postHookExecutor: instance.PluginCache,
+		input:            input,
+		stashBoxes:       instance.Config.GetStashBoxes(),
+	}
+}
+
+// JobInput returns the input of the job to be recorded in the job history.
+func (j *IdentifyStudiosJob) JobInput() interface{} {
+	return j.input
+}
+
+// ResourceClass returns ResourceNetwork, as for IdentifyJob.
+func (j *IdentifyStudiosJob) ResourceClass() job.ResourceClass {
+	return job.ResourceNetwork
+}
+
+func (j *IdentifyStudiosJob) Execute(ctx context.Context, progress *job.Progress) {
+	j.progress = progress
+
+	// if no sources provided - just return
+	if len(j.input.Sources) == 0 {
+		return
+	}
+
+	sources, err := j.getSources()
+	if err != nil {
+		job.Logger(ctx).Error(err)
+		progress.SetError(err)
+		return
+	}
+
+	var studios []*models.Studio
+	if err := txn.WithTxn(ctx, instance.Repository, func(ctx context.Context) error {
+		studios, err = j.getStudios(ctx)
+		return err
+	}); err != nil {
+		job.Logger(ctx).Errorf("Error encountered while identifying studios: %v", err)
+		progress.SetError(err)
+		return
+	}
+
+	progress.SetTotal(len(studios))
+	for _, studio := range studios {
+		if job.IsCancelled(ctx) {
+			job.Logger(ctx).Info("Stopping due to user request")
+			return
+		}
+
+		j.identifyStudio(ctx, studio, sources)
+	}
+}
+
+// getStudios returns the studios with the ids provided in the input, or
+// all studios if no ids were provided.
+func (j *IdentifyStudiosJob) getStudios(ctx context.Context) ([]*models.Studio, error) {
+	qb := instance.Repository.Studio
+
+	if len(j.input.StudioIDs) == 0 {
+		return qb.All(ctx)
+	}
+
+	studioIDs, err := stringslice.StringSliceToIntSlice(j.input.StudioIDs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid studio IDs: %w", err)
+	}
+
+	var ret []*models.Studio
+	for _, id := range studioIDs {
+		studio, err := qb.Find(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("error finding studio with id %d: %w", id, err)
+		}
+
+		if studio == nil {
+			return nil, fmt.Errorf("%w: studio with id %d", models.ErrNotFound, id)
+		}
+
+		ret = append(ret, studio)
+	}
+
+	return ret, nil
+}
+
+func (j *IdentifyStudiosJob) identifyStudio(ctx context.Context, studio *models.Studio, sources []identify.StudioScraperSource) {
+	var (
+		modified  bool
+		taskError error
+	)
+	j.progress.ExecuteTask("Identifying studio "+studio.Name.String, func() {
+		task := identify.StudioIdentifier{
+			StudioReaderUpdater: instance.Repository.Studio,
+
+			DefaultOptions:   j.input.Options,
+			Sources:          sources,
+			PostHookExecutor: j.postHookExecutor,
+		}
+
+		modified, taskError = task.Identify(ctx, instance.Repository, studio)
+	})
+
+	if taskError != nil {
+		job.Logger(ctx).Errorf("Error encountered identifying studio %s: %v", studio.Name.String, taskError)
+	} else if modified {
+		j.progress.AddUpdated(1)
+	} else {
+		j.progress.AddSkipped(1)
+	}
+
+	j.progress.Increment()
+}
+
+func (j *IdentifyStudiosJob) getSources() ([]identify.StudioScraperSource, error) {
+	sources, err := resolveIdentifySources(j.input.Sources, j.stashBoxes, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret []identify.StudioScraperSource
+	for _, s := range sources {
+		src := identify.StudioScraperSource{
+			Name:    s.name,
+			Options: s.options,
+		}
+
+		if s.stashBox != nil {
+			src.Scraper = *s.stashBox
+			src.RemoteSite = s.stashBox.endpoint
+		} else {
+			src.Scraper = *s.scraper
+		}
+
+		ret = append(ret, src)
+	}
+
+	return ret, nil
+}
+
+func (s stashboxSource) ScrapeStudio(ctx context.Context, studioID int) (*models.ScrapedStudio, error) {
+	ret, err := s.FindStashBoxStudio(ctx, studioID)
+	if err != nil {
+		return nil, fmt.Errorf("error querying stash-box using studio ID %d: %w", studioID, err)
+	}
+
+	return ret, nil
+}
+
+func (s scraperSource) ScrapeStudio(ctx context.Context, studioID int) (*models.ScrapedStudio, error) {
+	content, err := s.cache.ScrapeID(ctx, s.scraperID, studioID, scraper.ScrapeContentTypeStudio)
+	if err != nil {
+		return nil, err
+	}
+
+	// don't try to convert nil return value
+	if content == nil {
+		return nil, nil
+	}
+
+	if studio, ok := content.(models.ScrapedStudio); ok {
+		return &studio, nil
+	}
+
+	return nil, errors.New("could not convert content to studio")
+}
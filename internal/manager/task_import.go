@@ -15,6 +15,7 @@ import (
 	"github.com/stashapp/stash/pkg/fsutil"
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/models/json"
@@ -84,17 +85,23 @@ func (t *ImportTask) GetDescription() string {
 	return "Importing..."
 }
 
+// ResourceClass returns ResourceExclusive, since importing may reset the
+// database.
+func (t *ImportTask) ResourceClass() job.ResourceClass {
+	return job.ResourceExclusive
+}
+
 func (t *ImportTask) Start(ctx context.Context) {
 	if t.TmpZip != "" {
 		defer func() {
 			err := fsutil.RemoveDir(t.BaseDir)
 			if err != nil {
-				logger.Errorf("error removing directory %s: %s", t.BaseDir, err.Error())
+				job.Logger(ctx).Errorf("error removing directory %s: %s", t.BaseDir, err.Error())
 			}
 		}()
 
 		if err := t.unzipFile(); err != nil {
-			logger.Errorf("error unzipping provided file for import: %s", err.Error())
+			job.Logger(ctx).Errorf("error unzipping provided file for import: %s", err.Error())
 			return
 		}
 	}
@@ -113,7 +120,7 @@ func (t *ImportTask) Start(ctx context.Context) {
 
 	scraped, _ := t.json.getScraped()
 	if scraped == nil {
-		logger.Warn("missing scraped json")
+		job.Logger(ctx).Warn("missing scraped json")
 	}
 	t.scraped = scraped
 
@@ -121,11 +128,12 @@ func (t *ImportTask) Start(ctx context.Context) {
 		err := t.txnManager.Reset()
 
 		if err != nil {
-			logger.Errorf("Error resetting database: %s", err.Error())
+			job.Logger(ctx).Errorf("Error resetting database: %s", err.Error())
 			return
 		}
 	}
 
+	t.ImportCustomFields(ctx)
 	t.ImportTags(ctx)
 	t.ImportPerformers(ctx)
 	t.ImportStudios(ctx)
@@ -191,14 +199,83 @@ func (t *ImportTask) unzipFile() error {
 	return nil
 }
 
+// ImportCustomFields imports the custom field definitions. Definitions must
+// be imported before any objects, so that their custom field values can be
+// set.
+func (t *ImportTask) ImportCustomFields(ctx context.Context) {
+	definitions, err := t.json.getCustomFields()
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			job.Logger(ctx).Errorf("[custom fields] failed to read custom fields file: %v", err)
+		}
+		return
+	}
+
+	job.Logger(ctx).Info("[custom fields] importing")
+
+	if err := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
+		qb := t.txnManager.CustomFieldDefinition
+
+		for i, definitionJSON := range definitions {
+			index := i + 1
+			job.Logger(ctx).Progressf("[custom fields] %d of %d", index, len(definitions))
+
+			d := models.CustomFieldDefinition{
+				EntityType: models.CustomFieldEntityType(definitionJSON.EntityType),
+				Name:       definitionJSON.Name,
+				Type:       models.CustomFieldType(definitionJSON.Type),
+				Options:    definitionJSON.Options,
+				CreatedAt:  t.getTimeFromJSONTime(definitionJSON.CreatedAt),
+				UpdatedAt:  t.getTimeFromJSONTime(definitionJSON.UpdatedAt),
+			}
+
+			if err := d.Validate(); err != nil {
+				job.Logger(ctx).Errorf("[custom fields] <%s> invalid definition: %v", d.Name, err)
+				continue
+			}
+
+			existing, err := qb.FindByName(ctx, d.EntityType, d.Name)
+			if err != nil {
+				return err
+			}
+
+			if existing == nil {
+				if err := qb.Create(ctx, &d); err != nil {
+					return fmt.Errorf("creating custom field %q: %w", d.Name, err)
+				}
+				continue
+			}
+
+			if existing.Type != d.Type {
+				job.Logger(ctx).Errorf("[custom fields] <%s> type %s does not match existing type %s", d.Name, d.Type, existing.Type)
+				continue
+			}
+
+			if t.DuplicateBehaviour == ImportDuplicateEnumOverwrite {
+				existing.Options = d.Options
+				existing.UpdatedAt = d.UpdatedAt
+				if err := qb.Update(ctx, existing); err != nil {
+					return fmt.Errorf("updating custom field %q: %w", d.Name, err)
+				}
+			}
+		}
+
+		return nil
+	}); err != nil {
+		job.Logger(ctx).Errorf("[custom fields] import failed to commit: %v", err)
+	}
+
+	job.Logger(ctx).Info("[custom fields] import complete")
+}
+
 func (t *ImportTask) ImportPerformers(ctx context.Context) {
-	logger.Info("[performers] importing")
+	job.Logger(ctx).Info("[performers] importing")
 
 	path := t.json.json.Performers
 	files, err := os.ReadDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
-			logger.Errorf("[performers] failed to read performers directory: %v", err)
+			job.Logger(ctx).Errorf("[performers] failed to read performers directory: %v", err)
 		}
 
 		return
@@ -208,11 +285,11 @@ func (t *ImportTask) ImportPerformers(ctx context.Context) {
 		index := i + 1
 		performerJSON, err := jsonschema.LoadPerformerFile(filepath.Join(path, fi.Name()))
 		if err != nil {
-			logger.Errorf("[performers] failed to read json: %s", err.Error())
+			job.Logger(ctx).Errorf("[performers] failed to read json: %s", err.Error())
 			continue
 		}
 
-		logger.Progressf("[performers] %d of %d", index, len(files))
+		job.Logger(ctx).Progressf("[performers] %d of %d", index, len(files))
 
 		if err := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
 			r := t.txnManager
@@ -225,23 +302,23 @@ func (t *ImportTask) ImportPerformers(ctx context.Context) {
 
 			return performImport(ctx, importer, t.DuplicateBehaviour)
 		}); err != nil {
-			logger.Errorf("[performers] <%s> import failed: %s", fi.Name(), err.Error())
+			job.Logger(ctx).Errorf("[performers] <%s> import failed: %s", fi.Name(), err.Error())
 		}
 	}
 
-	logger.Info("[performers] import complete")
+	job.Logger(ctx).Info("[performers] import complete")
 }
 
 func (t *ImportTask) ImportStudios(ctx context.Context) {
 	pendingParent := make(map[string][]*jsonschema.Studio)
 
-	logger.Info("[studios] importing")
+	job.Logger(ctx).Info("[studios] importing")
 
 	path := t.json.json.Studios
 	files, err := os.ReadDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
-			logger.Errorf("[studios] failed to read studios directory: %v", err)
+			job.Logger(ctx).Errorf("[studios] failed to read studios directory: %v", err)
 		}
 
 		return
@@ -251,11 +328,11 @@ func (t *ImportTask) ImportStudios(ctx context.Context) {
 		index := i + 1
 		studioJSON, err := jsonschema.LoadStudioFile(filepath.Join(path, fi.Name()))
 		if err != nil {
-			logger.Errorf("[studios] failed to read json: %s", err.Error())
+			job.Logger(ctx).Errorf("[studios] failed to read json: %s", err.Error())
 			continue
 		}
 
-		logger.Progressf("[studios] %d of %d", index, len(files))
+		job.Logger(ctx).Progressf("[studios] %d of %d", index, len(files))
 
 		if err := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
 			return t.ImportStudio(ctx, studioJSON, pendingParent, t.txnManager.Studio)
@@ -268,28 +345,28 @@ func (t *ImportTask) ImportStudios(ctx context.Context) {
 				continue
 			}
 
-			logger.Errorf("[studios] <%s> failed to create: %s", fi.Name(), err.Error())
+			job.Logger(ctx).Errorf("[studios] <%s> failed to create: %s", fi.Name(), err.Error())
 			continue
 		}
 	}
 
 	// create the leftover studios, warning for missing parents
 	if len(pendingParent) > 0 {
-		logger.Warnf("[studios] importing studios with missing parents")
+		job.Logger(ctx).Warnf("[studios] importing studios with missing parents")
 
 		for _, s := range pendingParent {
 			for _, orphanStudioJSON := range s {
 				if err := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
 					return t.ImportStudio(ctx, orphanStudioJSON, nil, t.txnManager.Studio)
 				}); err != nil {
-					logger.Errorf("[studios] <%s> failed to create: %s", orphanStudioJSON.Name, err.Error())
+					job.Logger(ctx).Errorf("[studios] <%s> failed to create: %s", orphanStudioJSON.Name, err.Error())
 					continue
 				}
 			}
 		}
 	}
 
-	logger.Info("[studios] import complete")
+	job.Logger(ctx).Info("[studios] import complete")
 }
 
 func (t *ImportTask) ImportStudio(ctx context.Context, studioJSON *jsonschema.Studio, pendingParent map[string][]*jsonschema.Studio, readerWriter studio.NameFinderCreatorUpdater) error {
@@ -324,13 +401,13 @@ func (t *ImportTask) ImportStudio(ctx context.Context, studioJSON *jsonschema.St
 }
 
 func (t *ImportTask) ImportMovies(ctx context.Context) {
-	logger.Info("[movies] importing")
+	job.Logger(ctx).Info("[movies] importing")
 
 	path := t.json.json.Movies
 	files, err := os.ReadDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
-			logger.Errorf("[movies] failed to read movies directory: %v", err)
+			job.Logger(ctx).Errorf("[movies] failed to read movies directory: %v", err)
 		}
 
 		return
@@ -340,11 +417,11 @@ func (t *ImportTask) ImportMovies(ctx context.Context) {
 		index := i + 1
 		movieJSON, err := jsonschema.LoadMovieFile(filepath.Join(path, fi.Name()))
 		if err != nil {
-			logger.Errorf("[movies] failed to read json: %s", err.Error())
+			job.Logger(ctx).Errorf("[movies] failed to read json: %s", err.Error())
 			continue
 		}
 
-		logger.Progressf("[movies] %d of %d", index, len(files))
+		job.Logger(ctx).Progressf("[movies] %d of %d", index, len(files))
 
 		if err := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
 			r := t.txnManager
@@ -360,22 +437,22 @@ func (t *ImportTask) ImportMovies(ctx context.Context) {
 
 			return performImport(ctx, movieImporter, t.DuplicateBehaviour)
 		}); err != nil {
-			logger.Errorf("[movies] <%s> import failed: %s", fi.Name(), err.Error())
+			job.Logger(ctx).Errorf("[movies] <%s> import failed: %s", fi.Name(), err.Error())
 			continue
 		}
 	}
 
-	logger.Info("[movies] import complete")
+	job.Logger(ctx).Info("[movies] import complete")
 }
 
 func (t *ImportTask) ImportFiles(ctx context.Context) {
-	logger.Info("[files] importing")
+	job.Logger(ctx).Info("[files] importing")
 
 	path := t.json.json.Files
 	files, err := os.ReadDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
-			logger.Errorf("[files] failed to read files directory: %v", err)
+			job.Logger(ctx).Errorf("[files] failed to read files directory: %v", err)
 		}
 
 		return
@@ -387,11 +464,11 @@ func (t *ImportTask) ImportFiles(ctx context.Context) {
 		index := i + 1
 		fileJSON, err := jsonschema.LoadFileFile(filepath.Join(path, fi.Name()))
 		if err != nil {
-			logger.Errorf("[files] failed to read json: %s", err.Error())
+			job.Logger(ctx).Errorf("[files] failed to read json: %s", err.Error())
 			continue
 		}
 
-		logger.Progressf("[files] %d of %d", index, len(files))
+		job.Logger(ctx).Progressf("[files] %d of %d", index, len(files))
 
 		if err := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
 			return t.ImportFile(ctx, fileJSON, pendingParent)
@@ -404,28 +481,28 @@ func (t *ImportTask) ImportFiles(ctx context.Context) {
 				continue
 			}
 
-			logger.Errorf("[files] <%s> failed to create: %s", fi.Name(), err.Error())
+			job.Logger(ctx).Errorf("[files] <%s> failed to create: %s", fi.Name(), err.Error())
 			continue
 		}
 	}
 
 	// create the leftover studios, warning for missing parents
 	if len(pendingParent) > 0 {
-		logger.Warnf("[files] importing files with missing zip files")
+		job.Logger(ctx).Warnf("[files] importing files with missing zip files")
 
 		for _, s := range pendingParent {
 			for _, orphanFileJSON := range s {
 				if err := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
 					return t.ImportFile(ctx, orphanFileJSON, nil)
 				}); err != nil {
-					logger.Errorf("[files] <%s> failed to create: %s", orphanFileJSON.DirEntry().Path, err.Error())
+					job.Logger(ctx).Errorf("[files] <%s> failed to create: %s", orphanFileJSON.DirEntry().Path, err.Error())
 					continue
 				}
 			}
 		}
 	}
 
-	logger.Info("[files] import complete")
+	job.Logger(ctx).Info("[files] import complete")
 }
 
 func (t *ImportTask) ImportFile(ctx context.Context, fileJSON jsonschema.DirEntry, pendingParent map[string][]jsonschema.DirEntry) error {
@@ -459,13 +536,13 @@ func (t *ImportTask) ImportFile(ctx context.Context, fileJSON jsonschema.DirEntr
 }
 
 func (t *ImportTask) ImportGalleries(ctx context.Context) {
-	logger.Info("[galleries] importing")
+	job.Logger(ctx).Info("[galleries] importing")
 
 	path := t.json.json.Galleries
 	files, err := os.ReadDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
-			logger.Errorf("[galleries] failed to read galleries directory: %v", err)
+			job.Logger(ctx).Errorf("[galleries] failed to read galleries directory: %v", err)
 		}
 
 		return
@@ -475,11 +552,11 @@ func (t *ImportTask) ImportGalleries(ctx context.Context) {
 		index := i + 1
 		galleryJSON, err := jsonschema.LoadGalleryFile(filepath.Join(path, fi.Name()))
 		if err != nil {
-			logger.Errorf("[galleries] failed to read json: %s", err.Error())
+			job.Logger(ctx).Errorf("[galleries] failed to read json: %s", err.Error())
 			continue
 		}
 
-		logger.Progressf("[galleries] %d of %d", index, len(files))
+		job.Logger(ctx).Progressf("[galleries] %d of %d", index, len(files))
 
 		if err := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
 			r := t.txnManager
@@ -501,23 +578,23 @@ func (t *ImportTask) ImportGalleries(ctx context.Context) {
 
 			return performImport(ctx, galleryImporter, t.DuplicateBehaviour)
 		}); err != nil {
-			logger.Errorf("[galleries] <%s> import failed to commit: %s", fi.Name(), err.Error())
+			job.Logger(ctx).Errorf("[galleries] <%s> import failed to commit: %s", fi.Name(), err.Error())
 			continue
 		}
 	}
 
-	logger.Info("[galleries] import complete")
+	job.Logger(ctx).Info("[galleries] import complete")
 }
 
 func (t *ImportTask) ImportTags(ctx context.Context) {
 	pendingParent := make(map[string][]*jsonschema.Tag)
-	logger.Info("[tags] importing")
+	job.Logger(ctx).Info("[tags] importing")
 
 	path := t.json.json.Tags
 	files, err := os.ReadDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
-			logger.Errorf("[tags] failed to read tags directory: %v", err)
+			job.Logger(ctx).Errorf("[tags] failed to read tags directory: %v", err)
 		}
 
 		return
@@ -527,11 +604,11 @@ func (t *ImportTask) ImportTags(ctx context.Context) {
 		index := i + 1
 		tagJSON, err := jsonschema.LoadTagFile(filepath.Join(path, fi.Name()))
 		if err != nil {
-			logger.Errorf("[tags] failed to read json: %s", err.Error())
+			job.Logger(ctx).Errorf("[tags] failed to read json: %s", err.Error())
 			continue
 		}
 
-		logger.Progressf("[tags] %d of %d", index, len(files))
+		job.Logger(ctx).Progressf("[tags] %d of %d", index, len(files))
 
 		if err := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
 			return t.ImportTag(ctx, tagJSON, pendingParent, false, t.txnManager.Tag)
@@ -542,7 +619,7 @@ func (t *ImportTask) ImportTags(ctx context.Context) {
 				continue
 			}
 
-			logger.Errorf("[tags] <%s> failed to import: %s", fi.Name(), err.Error())
+			job.Logger(ctx).Errorf("[tags] <%s> failed to import: %s", fi.Name(), err.Error())
 			continue
 		}
 	}
@@ -552,13 +629,13 @@ func (t *ImportTask) ImportTags(ctx context.Context) {
 			if err := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
 				return t.ImportTag(ctx, orphanTagJSON, nil, true, t.txnManager.Tag)
 			}); err != nil {
-				logger.Errorf("[tags] <%s> failed to create: %s", orphanTagJSON.Name, err.Error())
+				job.Logger(ctx).Errorf("[tags] <%s> failed to create: %s", orphanTagJSON.Name, err.Error())
 				continue
 			}
 		}
 	}
 
-	logger.Info("[tags] import complete")
+	job.Logger(ctx).Info("[tags] import complete")
 }
 
 func (t *ImportTask) ImportTag(ctx context.Context, tagJSON *jsonschema.Tag, pendingParent map[string][]*jsonschema.Tag, fail bool, readerWriter tag.NameFinderCreatorUpdater) error {
@@ -596,7 +673,7 @@ func (t *ImportTask) ImportTag(ctx context.Context, tagJSON *jsonschema.Tag, pen
 
 func (t *ImportTask) ImportScrapedItems(ctx context.Context) {
 	if err := t.txnManager.WithTxn(ctx, func(ctx context.Context) error {
-		logger.Info("[scraped sites] importing")
+		job.Logger(ctx).Info("[scraped sites] importing")
 		r := t.txnManager
 		qb := r.ScrapedItem
 		sqb := r.Studio
@@ -604,7 +681,7 @@ func (t *ImportTask) ImportScrapedItems(ctx context.Context) {
 
 		for i, mappingJSON := range t.scraped {
 			index := i + 1
-			logger.Progressf("[scraped sites] %d of %d", index, len(t.scraped))
+			job.Logger(ctx).Progressf("[scraped sites] %d of %d", index, len(t.scraped))
 
 			newScrapedItem := models.ScrapedItem{
 				Title:           sql.NullString{String: mappingJSON.Title, Valid: true},
@@ -625,7 +702,7 @@ func (t *ImportTask) ImportScrapedItems(ctx context.Context) {
 
 			studio, err := sqb.FindByName(ctx, mappingJSON.Studio, false)
 			if err != nil {
-				logger.Errorf("[scraped sites] failed to fetch studio: %s", err.Error())
+				job.Logger(ctx).Errorf("[scraped sites] failed to fetch studio: %s", err.Error())
 			}
 			if studio != nil {
 				newScrapedItem.StudioID = sql.NullInt64{Int64: int64(studio.ID), Valid: true}
@@ -633,26 +710,26 @@ func (t *ImportTask) ImportScrapedItems(ctx context.Context) {
 
 			_, err = qb.Create(ctx, newScrapedItem)
 			if err != nil {
-				logger.Errorf("[scraped sites] <%s> failed to create: %s", newScrapedItem.Title.String, err.Error())
+				job.Logger(ctx).Errorf("[scraped sites] <%s> failed to create: %s", newScrapedItem.Title.String, err.Error())
 			}
 		}
 
 		return nil
 	}); err != nil {
-		logger.Errorf("[scraped sites] import failed to commit: %s", err.Error())
+		job.Logger(ctx).Errorf("[scraped sites] import failed to commit: %s", err.Error())
 	}
 
-	logger.Info("[scraped sites] import complete")
+	job.Logger(ctx).Info("[scraped sites] import complete")
 }
 
 func (t *ImportTask) ImportScenes(ctx context.Context) {
-	logger.Info("[scenes] importing")
+	job.Logger(ctx).Info("[scenes] importing")
 
 	path := t.json.json.Scenes
 	files, err := os.ReadDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
-			logger.Errorf("[scenes] failed to read scenes directory: %v", err)
+			job.Logger(ctx).Errorf("[scenes] failed to read scenes directory: %v", err)
 		}
 
 		return
@@ -661,11 +738,11 @@ func (t *ImportTask) ImportScenes(ctx context.Context) {
 	for i, fi := range files {
 		index := i + 1
 
-		logger.Progressf("[scenes] %d of %d", index, len(files))
+		job.Logger(ctx).Progressf("[scenes] %d of %d", index, len(files))
 
 		sceneJSON, err := jsonschema.LoadSceneFile(filepath.Join(path, fi.Name()))
 		if err != nil {
-			logger.Infof("[scenes] <%s> json parse failure: %s", fi.Name(), err.Error())
+			job.Logger(ctx).Infof("[scenes] <%s> json parse failure: %s", fi.Name(), err.Error())
 			continue
 		}
 
@@ -715,21 +792,21 @@ func (t *ImportTask) ImportScenes(ctx context.Context) {
 
 			return nil
 		}); err != nil {
-			logger.Errorf("[scenes] <%s> import failed: %s", fi.Name(), err.Error())
+			job.Logger(ctx).Errorf("[scenes] <%s> import failed: %s", fi.Name(), err.Error())
 		}
 	}
 
-	logger.Info("[scenes] import complete")
+	job.Logger(ctx).Info("[scenes] import complete")
 }
 
 func (t *ImportTask) ImportImages(ctx context.Context) {
-	logger.Info("[images] importing")
+	job.Logger(ctx).Info("[images] importing")
 
 	path := t.json.json.Images
 	files, err := os.ReadDir(path)
 	if err != nil {
 		if !errors.Is(err, os.ErrNotExist) {
-			logger.Errorf("[images] failed to read images directory: %v", err)
+			job.Logger(ctx).Errorf("[images] failed to read images directory: %v", err)
 		}
 
 		return
@@ -738,11 +815,11 @@ func (t *ImportTask) ImportImages(ctx context.Context) {
 	for i, fi := range files {
 		index := i + 1
 
-		logger.Progressf("[images] %d of %d", index, len(files))
+		job.Logger(ctx).Progressf("[images] %d of %d", index, len(files))
 
 		imageJSON, err := jsonschema.LoadImageFile(filepath.Join(path, fi.Name()))
 		if err != nil {
-			logger.Infof("[images] <%s> json parse failure: %s", fi.Name(), err.Error())
+			job.Logger(ctx).Infof("[images] <%s> json parse failure: %s", fi.Name(), err.Error())
 			continue
 		}
 
@@ -769,11 +846,11 @@ func (t *ImportTask) ImportImages(ctx context.Context) {
 
 			return performImport(ctx, imageImporter, t.DuplicateBehaviour)
 		}); err != nil {
-			logger.Errorf("[images] <%s> import failed: %s", fi.Name(), err.Error())
+			job.Logger(ctx).Errorf("[images] <%s> import failed: %s", fi.Name(), err.Error())
 		}
 	}
 
-	logger.Info("[images] import complete")
+	job.Logger(ctx).Info("[images] import complete")
 }
 
 var currentLocation = time.Now().Location()
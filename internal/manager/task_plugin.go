@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/stashapp/stash/pkg/job"
-	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/plugin"
 )
 
@@ -14,13 +13,13 @@ func (s *Manager) RunPluginTask(ctx context.Context, pluginID string, taskName s
 		pluginProgress := make(chan float64)
 		task, err := s.PluginCache.CreateTask(ctx, pluginID, taskName, args, pluginProgress)
 		if err != nil {
-			logger.Errorf("Error creating plugin task: %s", err.Error())
+			job.Logger(ctx).Errorf("Error creating plugin task: %s", err.Error())
 			return
 		}
 
 		err = task.Start()
 		if err != nil {
-			logger.Errorf("Error running plugin task: %s", err.Error())
+			job.Logger(ctx).Errorf("Error running plugin task: %s", err.Error())
 			return
 		}
 
@@ -31,12 +30,16 @@ func (s *Manager) RunPluginTask(ctx context.Context, pluginID string, taskName s
 
 			output := task.GetResult()
 			if output == nil {
-				logger.Debug("Plugin returned no result")
+				job.Logger(ctx).Debug("Plugin returned no result")
 			} else {
 				if output.Error != nil {
-					logger.Errorf("Plugin returned error: %s", *output.Error)
+					job.Logger(ctx).Errorf("Plugin returned error: %s", *output.Error)
 				} else if output.Output != nil {
-					logger.Debugf("Plugin returned: %v", output.Output)
+					job.Logger(ctx).Debugf("Plugin returned: %v", output.Output)
+
+					if s.PluginCache.TaskHasResult(pluginID, taskName) {
+						progress.SetResult(output.Output)
+					}
 				}
 			}
 		}()
@@ -49,7 +52,7 @@ func (s *Manager) RunPluginTask(ctx context.Context, pluginID string, taskName s
 				progress.SetPercent(p)
 			case <-jobCtx.Done():
 				if err := task.Stop(); err != nil {
-					logger.Errorf("Error stopping plugin operation: %s", err.Error())
+					job.Logger(ctx).Errorf("Error stopping plugin operation: %s", err.Error())
 				}
 				return
 			}
@@ -2,6 +2,7 @@ package manager
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -17,27 +18,67 @@ import (
 	"github.com/stashapp/stash/pkg/gallery"
 	"github.com/stashapp/stash/pkg/image"
 	"github.com/stashapp/stash/pkg/job"
-	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
 	"github.com/stashapp/stash/pkg/scene/generate"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
 type scanner interface {
 	Scan(ctx context.Context, handlers []file.Handler, options file.ScanOptions, progressReporter file.ProgressReporter)
 }
 
+const scanJobType = "scan"
+
+// scanCheckpoint is the checkpoint of a scan job.
+type scanCheckpoint struct {
+	// Paths that have been completely scanned
+	Paths []string `json:"paths"`
+	// Last folder of the path being scanned that has been completely scanned
+	Folder string `json:"folder,omitempty"`
+}
+
 type ScanJob struct {
 	scanner       scanner
 	input         ScanMetadataInput
 	subscriptions *subscriptionManager
+
+	// paths in the checkpoint are skipped when resuming
+	checkpoint scanCheckpoint
+}
+
+// JobInput returns the input of the job to be recorded in the job history.
+func (j *ScanJob) JobInput() interface{} {
+	return j.input
+}
+
+// JobType returns the type used to resume the job.
+func (j *ScanJob) JobType() string {
+	return scanJobType
+}
+
+func resumeScanJob(s *Manager, input []byte, checkpoint []byte) (job.JobExec, error) {
+	ret := &ScanJob{
+		scanner:       s.Scanner,
+		subscriptions: s.scanSubs,
+	}
+
+	if err := json.Unmarshal(input, &ret.input); err != nil {
+		return nil, fmt.Errorf("decoding input: %w", err)
+	}
+
+	if err := unmarshalCheckpoint(checkpoint, &ret.checkpoint); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
 }
 
 func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) {
 	input := j.input
 
 	if job.IsCancelled(ctx) {
-		logger.Info("Stopping due to user request")
+		job.Logger(ctx).Info("Stopping due to user request")
 		return
 	}
 
@@ -50,36 +91,149 @@ func (j *ScanJob) Execute(ctx context.Context, progress *job.Progress) {
 	start := time.Now()
 
 	const taskQueueSize = 200000
-	taskQueue := job.NewTaskQueue(ctx, progress, taskQueueSize, instance.Config.GetParallelTasksWithAutoDetection())
+	parallelTasks := instance.Config.GetParallelTasksWithAutoDetection()
 
 	var minModTime time.Time
 	if j.input.Filter != nil && j.input.Filter.MinModTime != nil {
 		minModTime = *j.input.Filter.MinModTime
 	}
 
-	j.scanner.Scan(ctx, getScanHandlers(j.input, taskQueue, progress), file.ScanOptions{
-		Paths:             paths,
-		ScanFilters:       []file.PathFilter{newScanFilter(instance.Config, minModTime)},
-		ZipFileExtensions: instance.Config.GetGalleryExtensions(),
-		ParallelTasks:     instance.Config.GetParallelTasksWithAutoDetection(),
-		HandlerRequiredFilters: []file.Filter{
-			newHandlerRequiredFilter(instance.Config),
-		},
-	}, progress)
+	scanFilters := []file.PathFilter{newScanFilter(instance.Config, minModTime)}
+	handlerRequiredFilters := []file.Filter{
+		newHandlerRequiredFilter(instance.Config),
+	}
 
-	taskQueue.Close()
+	// scan each folder separately, so that a resumed job can skip the
+	// folders that were completely scanned
+	for _, p := range paths {
+		if stringslice.StrInclude(j.checkpoint.Paths, p) {
+			job.Logger(ctx).Infof("Skipping %s - already scanned before resuming", p)
+			continue
+		}
 
-	if job.IsCancelled(ctx) {
-		logger.Info("Stopping due to user request")
-		return
+		folders, err := getScanFolders(ctx, p, scanFilters)
+		if err != nil {
+			if job.IsCancelled(ctx) {
+				job.Logger(ctx).Info("Stopping due to user request")
+			} else {
+				job.Logger(ctx).Errorf("error walking %s: %v", p, err)
+			}
+			return
+		}
+
+		folders = foldersAfter(folders, j.checkpoint.Folder)
+		if j.checkpoint.Folder != "" {
+			job.Logger(ctx).Infof("Resuming scan of %s after %s", p, j.checkpoint.Folder)
+		}
+
+		for _, folder := range folders {
+			taskQueue := job.NewTaskQueue(ctx, progress, taskQueueSize, parallelTasks)
+
+			j.scanner.Scan(ctx, getScanHandlers(j.input, taskQueue, progress), file.ScanOptions{
+				Paths:                  []string{folder},
+				ScanFilters:            []file.PathFilter{folderScanFilter{folder: folder, filters: scanFilters}},
+				ZipFileExtensions:      instance.Config.GetGalleryExtensions(),
+				ParallelTasks:          parallelTasks,
+				HandlerRequiredFilters: handlerRequiredFilters,
+			}, progress)
+
+			// wait for the tasks of the folder to finish before considering
+			// it scanned
+			taskQueue.Close()
+
+			if job.IsCancelled(ctx) {
+				job.Logger(ctx).Info("Stopping due to user request")
+				return
+			}
+
+			j.checkpoint.Folder = folder
+			progress.SetCheckpoint(j.checkpoint)
+		}
+
+		j.checkpoint.Paths = append(j.checkpoint.Paths, p)
+		j.checkpoint.Folder = ""
+		progress.SetCheckpoint(j.checkpoint)
 	}
 
 	elapsed := time.Since(start)
-	logger.Info(fmt.Sprintf("Scan finished (%s)", elapsed))
+	job.Logger(ctx).Info(fmt.Sprintf("Scan finished (%s)", elapsed))
 
 	j.subscriptions.notify()
 }
 
+// getScanFolders returns the folders under path that are accepted by the
+// filters, in the order that they are walked.
+func getScanFolders(ctx context.Context, path string, filters []file.PathFilter) ([]string, error) {
+	var ret []string
+	err := fsutil.SymWalk(path, func(p string, info fs.FileInfo, err error) error {
+		if err != nil {
+			// don't let errors prevent scanning
+			return nil
+		}
+
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			return nil
+		}
+
+		if !acceptScanPath(ctx, filters, p, info) {
+			return filepath.SkipDir
+		}
+
+		ret = append(ret, p)
+		return nil
+	})
+
+	return ret, err
+}
+
+// foldersAfter returns the folders after the provided folder. Returns all
+// folders if the folder is empty or no longer present.
+func foldersAfter(folders []string, folder string) []string {
+	if folder == "" {
+		return folders
+	}
+
+	for i, f := range folders {
+		if f == folder {
+			return folders[i+1:]
+		}
+	}
+
+	return folders
+}
+
+// acceptScanPath returns true if any of the filters accepts the path, in the
+// same way as the scanner.
+func acceptScanPath(ctx context.Context, filters []file.PathFilter, path string, info fs.FileInfo) bool {
+	for _, f := range filters {
+		if f.Accept(ctx, path, info) {
+			return true
+		}
+	}
+
+	return len(filters) == 0
+}
+
+// folderScanFilter restricts the scan of a folder to the files directly in
+// the folder. Its subfolders are scanned separately.
+type folderScanFilter struct {
+	folder  string
+	filters []file.PathFilter
+}
+
+func (f folderScanFilter) Accept(ctx context.Context, path string, info fs.FileInfo) bool {
+	// folders inside zip files are not subfolders of the folder
+	if info.IsDir() && path != f.folder && filepath.Dir(path) == f.folder {
+		return false
+	}
+
+	return acceptScanPath(ctx, f.filters, path, info)
+}
+
 type extensionConfig struct {
 	vidExt []string
 	imgExt []string
@@ -259,7 +413,7 @@ func (f *scanFilter) Accept(ctx context.Context, path string, info fs.FileInfo)
 
 	// #1756 - skip zero length files
 	if !info.IsDir() && info.Size() == 0 {
-		logger.Infof("Skipping zero-length file: %s", path)
+		job.Logger(ctx).Infof("Skipping zero-length file: %s", path)
 		return false
 	}
 
@@ -355,7 +509,7 @@ func (g *imageThumbnailGenerator) GenerateThumbnail(ctx context.Context, i *mode
 		return nil
 	}
 
-	logger.Debugf("Generating thumbnail for %s", f.Path)
+	job.Logger(ctx).Debugf("Generating thumbnail for %s", f.Path)
 
 	encoder := image.NewThumbnailEncoder(instance.FFMPEG)
 	data, err := encoder.GetThumbnail(f, models.DefaultGthumbWidth)
@@ -0,0 +1,91 @@
+package manager
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stretchr/testify/assert"
+)
+
+type testPathFilter func(path string, info fs.FileInfo) bool
+
+func (f testPathFilter) Accept(ctx context.Context, path string, info fs.FileInfo) bool {
+	return f(path, info)
+}
+
+func TestScanFolders(t *testing.T) {
+	root := t.TempDir()
+	for _, d := range []string{"a/b", "a/excluded/c", "d"} {
+		if err := os.MkdirAll(filepath.Join(root, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	filters := []file.PathFilter{testPathFilter(func(path string, info fs.FileInfo) bool {
+		return filepath.Base(path) != "excluded"
+	})}
+
+	folders, err := getScanFolders(context.Background(), root, filters)
+	if err != nil {
+		t.Fatalf("getScanFolders() error = %v", err)
+	}
+
+	want := []string{
+		root,
+		filepath.Join(root, "a"),
+		filepath.Join(root, "a", "b"),
+		filepath.Join(root, "d"),
+	}
+	assert.Equal(t, want, folders)
+
+	assert.Equal(t, want[2:], foldersAfter(folders, want[1]))
+	assert.Equal(t, want, foldersAfter(folders, ""))
+	// rescan all folders if the checkpoint folder no longer exists
+	assert.Equal(t, want, foldersAfter(folders, filepath.Join(root, "missing")))
+}
+
+func TestFolderScanFilter(t *testing.T) {
+	root := t.TempDir()
+	for _, d := range []string{"sub"} {
+		if err := os.MkdirAll(filepath.Join(root, d), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "file.mp4"), []byte("test"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	acceptAll := testPathFilter(func(path string, info fs.FileInfo) bool {
+		return true
+	})
+	filter := folderScanFilter{
+		folder:  root,
+		filters: []file.PathFilter{acceptAll},
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want bool
+	}{
+		{"folder", root, true},
+		{"file", filepath.Join(root, "file.mp4"), true},
+		{"subfolder", filepath.Join(root, "sub"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := os.Stat(tt.path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := filter.Accept(context.Background(), tt.path, info); got != tt.want {
+				t.Errorf("folderScanFilter.Accept() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package manager
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/scraper/stashbox"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+type StashBoxFingerprintCheckInput struct {
+	// Stash endpoint to check against
+	Endpoint int `json:"endpoint"`
+	// If set, only check these scene ids. Otherwise all scenes with a stash
+	// id for the endpoint are checked
+	SceneIds []string `json:"scene_ids"`
+	// Remove the current user's votes for fingerprints that no longer match
+	// any local file
+	DownvoteStaleFingerprints *bool `json:"downvote_stale_fingerprints"`
+	// Remove the stash id of scenes whose stash-box scene was deleted, and
+	// replace the stash id of scenes whose stash-box scene was merged
+	UnlinkStaleScenes *bool `json:"unlink_stale_scenes"`
+}
+
+type stashBoxFingerprintCheckTarget struct {
+	scene        *models.Scene
+	stashID      string
+	fingerprints []*models.StashBoxFingerprint
+}
+
+// StashBoxFingerprintCheckTask checks the fingerprints of scenes against the
+// stash-box scenes linked by their stash ids, recording the results.
+type StashBoxFingerprintCheckTask struct {
+	box   *models.StashBox
+	input StashBoxFingerprintCheckInput
+}
+
+func (t *StashBoxFingerprintCheckTask) Start(ctx context.Context, progress *job.Progress) error {
+	targets, err := t.getTargets(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		job.Logger(ctx).Infof("No scenes linked to %s to check", t.box.Endpoint)
+		return nil
+	}
+
+	client := stashbox.NewClient(*t.box, instance.Repository, stashbox.Repository{
+		Scene:     instance.Repository.Scene,
+		Performer: instance.Repository.Performer,
+		Tag:       instance.Repository.Tag,
+		Studio:    instance.Repository.Studio,
+	}, instance.ScraperCache.ResponseCache())
+
+	progress.SetTotal(len(targets))
+
+	job.Logger(ctx).Infof("Checking fingerprints of %d scenes against %s", len(targets), t.box.Endpoint)
+
+	for _, target := range targets {
+		if job.IsCancelled(ctx) {
+			job.Logger(ctx).Info("Stopping due to user request")
+			return nil
+		}
+
+		progress.ExecuteTask("Checking fingerprints of "+target.scene.GetTitle(), func() {
+			if err := t.checkScene(ctx, client, target, progress); err != nil {
+				job.Logger(ctx).Errorf("Error checking fingerprints of scene %d: %v", target.scene.ID, err)
+			}
+		})
+
+		progress.Increment()
+	}
+
+	return nil
+}
+
+func (t *StashBoxFingerprintCheckTask) getTargets(ctx context.Context) ([]stashBoxFingerprintCheckTarget, error) {
+	var ret []stashBoxFingerprintCheckTarget
+
+	r := instance.Repository
+	if err := txn.WithDatabase(ctx, r, func(ctx context.Context) error {
+		qb := r.Scene
+
+		addTarget := func(s *models.Scene) error {
+			if err := s.LoadStashIDs(ctx, qb); err != nil {
+				return err
+			}
+
+			for _, stashID := range s.StashIDs.List() {
+				if stashID.Endpoint != t.box.Endpoint {
+					continue
+				}
+
+				if err := s.LoadFiles(ctx, qb); err != nil {
+					return err
+				}
+
+				ret = append(ret, stashBoxFingerprintCheckTarget{
+					scene:        s,
+					stashID:      stashID.StashID,
+					fingerprints: stashbox.SceneFingerprints(s.Files.List()),
+				})
+				break
+			}
+
+			return nil
+		}
+
+		if len(t.input.SceneIds) > 0 {
+			for _, sceneID := range t.input.SceneIds {
+				id, err := strconv.Atoi(sceneID)
+				if err != nil {
+					return fmt.Errorf("invalid scene id %s: %w", sceneID, err)
+				}
+
+				s, err := qb.Find(ctx, id)
+				if err != nil {
+					return err
+				}
+				if s == nil {
+					return fmt.Errorf("%w: scene with id %d", models.ErrNotFound, id)
+				}
+
+				if err := addTarget(s); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		sortBy := "id"
+		sceneFilter := &models.SceneFilterType{
+			StashID: &models.StringCriterionInput{
+				Modifier: models.CriterionModifierNotNull,
+			},
+		}
+		return scene.BatchProcess(ctx, qb, sceneFilter, &models.FindFilterType{Sort: &sortBy}, addTarget)
+	}); err != nil {
+		return nil, fmt.Errorf("error querying scenes: %w", err)
+	}
+
+	return ret, nil
+}
+
+func (t *StashBoxFingerprintCheckTask) checkScene(ctx context.Context, client *stashbox.Client, target stashBoxFingerprintCheckTarget, progress *job.Progress) error {
+	result, err := client.CheckSceneFingerprints(ctx, target.stashID, target.fingerprints)
+	if err != nil {
+		return err
+	}
+
+	unlink := t.input.UnlinkStaleScenes != nil && *t.input.UnlinkStaleScenes
+	downvote := t.input.DownvoteStaleFingerprints != nil && *t.input.DownvoteStaleFingerprints
+
+	switch {
+	case unlink && result.Status == models.StashBoxFingerprintStatusDeleted:
+		job.Logger(ctx).Infof("Removing stash id %s of scene %d: stash-box scene was deleted", target.stashID, target.scene.ID)
+		if err := t.replaceStashID(ctx, target, nil); err != nil {
+			return err
+		}
+		progress.AddUpdated(1)
+		return nil
+	case unlink && result.Status == models.StashBoxFingerprintStatusMerged:
+		job.Logger(ctx).Infof("Replacing stash id %s of scene %d with %s: stash-box scene was merged", target.stashID, target.scene.ID, *result.MergedInto)
+		if err := t.replaceStashID(ctx, target, result.MergedInto); err != nil {
+			return err
+		}
+		progress.AddUpdated(1)
+
+		// check against the scene it was merged into
+		target.stashID = *result.MergedInto
+		result, err = client.CheckSceneFingerprints(ctx, target.stashID, target.fingerprints)
+		if err != nil {
+			return err
+		}
+	}
+
+	if downvote && len(result.Stale) > 0 {
+		job.Logger(ctx).Infof("Removing %d stale fingerprints from stash-box scene %s", len(result.Stale), target.stashID)
+		if err := client.DownvoteFingerprints(ctx, target.stashID, result.Stale); err != nil {
+			return fmt.Errorf("removing stale fingerprints: %w", err)
+		}
+	}
+
+	r := instance.Repository
+	return txn.WithTxn(ctx, r, func(ctx context.Context) error {
+		return r.SceneStashBoxCheck.Set(ctx, models.SceneStashBoxCheck{
+			SceneID:              target.scene.ID,
+			Endpoint:             t.box.Endpoint,
+			StashID:              target.stashID,
+			Status:               result.Status,
+			UnlinkedFingerprints: result.Unlinked,
+			MergedInto:           result.MergedInto,
+			CheckedAt:            time.Now(),
+		})
+	})
+}
+
+// replaceStashID replaces the stash id of the target scene for the
+// endpoint. The stash id is removed if newStashID is nil.
+func (t *StashBoxFingerprintCheckTask) replaceStashID(ctx context.Context, target stashBoxFingerprintCheckTarget, newStashID *string) error {
+	var stashIDs []models.StashID
+	for _, stashID := range target.scene.StashIDs.List() {
+		if stashID.Endpoint != t.box.Endpoint {
+			stashIDs = append(stashIDs, stashID)
+		}
+	}
+
+	if newStashID != nil {
+		stashIDs = append(stashIDs, models.StashID{
+			StashID:  *newStashID,
+			Endpoint: t.box.Endpoint,
+		})
+	}
+
+	r := instance.Repository
+	return txn.WithTxn(ctx, r, func(ctx context.Context) error {
+		partial := models.NewScenePartial()
+		partial.StashIDs = &models.UpdateStashIDs{
+			StashIDs: stashIDs,
+			Mode:     models.RelationshipUpdateModeSet,
+		}
+
+		if _, err := r.Scene.UpdatePartial(ctx, target.scene.ID, partial); err != nil {
+			return err
+		}
+
+		return r.SceneStashBoxCheck.Destroy(ctx, target.scene.ID, t.box.Endpoint)
+	})
+}
+
+const stashBoxFingerprintCheckPollInterval = time.Minute
+
+var stashBoxFingerprintCheckSchedulerOnce sync.Once
+
+// startStashBoxFingerprintCheckScheduler starts a goroutine that queues a
+// fingerprint check for each stash-box endpoint once the configured check
+// interval has elapsed since its last check.
+func (s *Manager) startStashBoxFingerprintCheckScheduler() {
+	stashBoxFingerprintCheckSchedulerOnce.Do(func() {
+		go func() {
+			// endpoints with a queued check, to avoid queuing checks for
+			// endpoints without linked scenes on every poll
+			lastQueued := make(map[string]time.Time)
+
+			ticker := time.NewTicker(stashBoxFingerprintCheckPollInterval)
+			defer ticker.Stop()
+
+			for range ticker.C {
+				s.queueDueStashBoxFingerprintChecks(context.Background(), lastQueued)
+			}
+		}()
+	})
+}
+
+func (s *Manager) queueDueStashBoxFingerprintChecks(ctx context.Context, lastQueued map[string]time.Time) {
+	interval := s.Config.GetStashBoxFingerprintCheckInterval()
+	if interval == 0 {
+		return
+	}
+
+	now := time.Now()
+	for i, box := range s.Config.GetStashBoxes() {
+		if t, found := lastQueued[box.Endpoint]; found && now.Sub(t) < interval {
+			continue
+		}
+
+		var lastChecked *time.Time
+		if err := txn.WithDatabase(ctx, s.Repository, func(ctx context.Context) error {
+			var err error
+			lastChecked, err = s.Repository.SceneStashBoxCheck.LastCheckedAt(ctx, box.Endpoint)
+			return err
+		}); err != nil {
+			job.Logger(ctx).Errorf("error getting last stash-box fingerprint check: %v", err)
+			continue
+		}
+
+		if lastChecked != nil && now.Sub(*lastChecked) < interval {
+			continue
+		}
+
+		job.Logger(ctx).Infof("Queuing scheduled stash-box fingerprint check for %s", box.Endpoint)
+		if _, err := s.StashBoxFingerprintCheck(ctx, StashBoxFingerprintCheckInput{Endpoint: i}); err != nil {
+			job.Logger(ctx).Errorf("error queuing stash-box fingerprint check: %v", err)
+			continue
+		}
+		lastQueued[box.Endpoint] = now
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"time"
 
 	"github.com/stashapp/stash/pkg/hash/md5"
-	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/job"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scraper/stashbox"
 	"github.com/stashapp/stash/pkg/txn"
@@ -20,6 +20,7 @@ type StashBoxPerformerTagTask struct {
 	performer       *models.Performer
 	refresh         bool
 	excluded_fields []string
+	appendImages    bool
 }
 
 func (t *StashBoxPerformerTagTask) Start(ctx context.Context) {
@@ -46,7 +47,7 @@ func (t *StashBoxPerformerTagTask) stashBoxPerformerTag(ctx context.Context) {
 		Performer: instance.Repository.Performer,
 		Tag:       instance.Repository.Tag,
 		Studio:    instance.Repository.Studio,
-	})
+	}, instance.ScraperCache.ResponseCache())
 
 	if t.refresh {
 		var performerID string
@@ -60,7 +61,7 @@ func (t *StashBoxPerformerTagTask) stashBoxPerformerTag(ctx context.Context) {
 			return nil
 		})
 		if txnErr != nil {
-			logger.Warnf("error while executing read transaction: %v", err)
+			job.Logger(ctx).Warnf("error while executing read transaction: %v", err)
 		}
 		if performerID != "" {
 			performer, err = client.FindStashBoxPerformerByID(ctx, performerID)
@@ -76,7 +77,7 @@ func (t *StashBoxPerformerTagTask) stashBoxPerformerTag(ctx context.Context) {
 	}
 
 	if err != nil {
-		logger.Errorf("Error fetching performer data from stash-box: %s", err.Error())
+		job.Logger(ctx).Errorf("Error fetching performer data from stash-box: %s", err.Error())
 		return
 	}
 
@@ -126,9 +127,6 @@ func (t *StashBoxPerformerTagTask) stashBoxPerformerTag(ctx context.Context) {
 					partial.Weight = models.NewOptionalInt(w)
 				}
 			}
-			if performer.Instagram != nil && !excluded["instagram"] {
-				partial.Instagram = models.NewOptionalString(*performer.Instagram)
-			}
 			if performer.Measurements != nil && !excluded["measurements"] {
 				partial.Measurements = models.NewOptionalString(*performer.Measurements)
 			}
@@ -137,22 +135,29 @@ func (t *StashBoxPerformerTagTask) stashBoxPerformerTag(ctx context.Context) {
 				checksum := md5.FromString(*performer.Name)
 				partial.Checksum = models.NewOptionalString(checksum)
 			}
+			if performer.Disambiguation != nil && !excluded["disambiguation"] {
+				partial.Disambiguation = models.NewOptionalString(*performer.Disambiguation)
+			}
 			if performer.Piercings != nil && !excluded["piercings"] {
 				partial.Piercings = models.NewOptionalString(*performer.Piercings)
 			}
 			if performer.Tattoos != nil && !excluded["tattoos"] {
 				partial.Tattoos = models.NewOptionalString(*performer.Tattoos)
 			}
-			if performer.Twitter != nil && !excluded["twitter"] {
-				partial.Twitter = models.NewOptionalString(*performer.Twitter)
-			}
-			if performer.URL != nil && !excluded["url"] {
-				partial.URL = models.NewOptionalString(*performer.URL)
-			}
 
 			txnErr := txn.WithTxn(ctx, instance.Repository, func(ctx context.Context) error {
 				r := instance.Repository
 				_, err := r.Performer.UpdatePartial(ctx, t.performer.ID, partial)
+				if err != nil {
+					return err
+				}
+
+				if len(performer.URLs) > 0 && !excluded["urls"] {
+					err = r.Performer.UpdateURLs(ctx, t.performer.ID, performer.URLs)
+					if err != nil {
+						return err
+					}
+				}
 
 				if !t.refresh {
 					err = r.Performer.UpdateStashIDs(ctx, t.performer.ID, []models.StashID{
@@ -167,11 +172,7 @@ func (t *StashBoxPerformerTagTask) stashBoxPerformerTag(ctx context.Context) {
 				}
 
 				if len(performer.Images) > 0 && !excluded["image"] {
-					image, err := utils.ReadImageFromURL(ctx, performer.Images[0])
-					if err != nil {
-						return err
-					}
-					err = r.Performer.UpdateImage(ctx, t.performer.ID, image)
+					err = t.saveImages(ctx, r.Performer, t.performer.ID, performer.Images)
 					if err != nil {
 						return err
 					}
@@ -182,36 +183,34 @@ func (t *StashBoxPerformerTagTask) stashBoxPerformerTag(ctx context.Context) {
 					if performer.Name != nil {
 						name = *performer.Name
 					}
-					logger.Infof("Updated performer %s", name)
+					job.Logger(ctx).Infof("Updated performer %s", name)
 				}
 				return err
 			})
 			if txnErr != nil {
-				logger.Warnf("failure to execute partial update of performer: %v", err)
+				job.Logger(ctx).Warnf("failure to execute partial update of performer: %v", err)
 			}
 		} else if t.name != nil && performer.Name != nil {
 			currentTime := time.Now()
 			newPerformer := models.Performer{
-				Aliases:      getString(performer.Aliases),
-				Birthdate:    getDate(performer.Birthdate),
-				CareerLength: getString(performer.CareerLength),
-				Checksum:     md5.FromString(*performer.Name),
-				Country:      getString(performer.Country),
-				CreatedAt:    currentTime,
-				Ethnicity:    getString(performer.Ethnicity),
-				EyeColor:     getString(performer.EyeColor),
-				FakeTits:     getString(performer.FakeTits),
-				Gender:       models.GenderEnum(getString(performer.Gender)),
-				Height:       getIntPtr(performer.Height),
-				Weight:       getIntPtr(performer.Weight),
-				Instagram:    getString(performer.Instagram),
-				Measurements: getString(performer.Measurements),
-				Name:         *performer.Name,
-				Piercings:    getString(performer.Piercings),
-				Tattoos:      getString(performer.Tattoos),
-				Twitter:      getString(performer.Twitter),
-				URL:          getString(performer.URL),
-				UpdatedAt:    currentTime,
+				Aliases:        getString(performer.Aliases),
+				Birthdate:      getDate(performer.Birthdate),
+				CareerLength:   getString(performer.CareerLength),
+				Checksum:       md5.FromString(*performer.Name),
+				Country:        getString(performer.Country),
+				CreatedAt:      currentTime,
+				Disambiguation: getString(performer.Disambiguation),
+				Ethnicity:      getString(performer.Ethnicity),
+				EyeColor:       getString(performer.EyeColor),
+				FakeTits:       getString(performer.FakeTits),
+				Gender:         models.GenderEnum(getString(performer.Gender)),
+				Height:         getIntPtr(performer.Height),
+				Weight:         getIntPtr(performer.Weight),
+				Measurements:   getString(performer.Measurements),
+				Name:           *performer.Name,
+				Piercings:      getString(performer.Piercings),
+				Tattoos:        getString(performer.Tattoos),
+				UpdatedAt:      currentTime,
 			}
 			err := txn.WithTxn(ctx, instance.Repository, func(ctx context.Context) error {
 				r := instance.Repository
@@ -220,6 +219,11 @@ func (t *StashBoxPerformerTagTask) stashBoxPerformerTag(ctx context.Context) {
 					return err
 				}
 
+				err = r.Performer.UpdateURLs(ctx, newPerformer.ID, performer.URLs)
+				if err != nil {
+					return err
+				}
+
 				err = r.Performer.UpdateStashIDs(ctx, newPerformer.ID, []models.StashID{
 					{
 						Endpoint: t.box.Endpoint,
@@ -231,18 +235,14 @@ func (t *StashBoxPerformerTagTask) stashBoxPerformerTag(ctx context.Context) {
 				}
 
 				if len(performer.Images) > 0 {
-					image, imageErr := utils.ReadImageFromURL(ctx, performer.Images[0])
-					if imageErr != nil {
-						return imageErr
-					}
-					err = r.Performer.UpdateImage(ctx, newPerformer.ID, image)
+					err = t.saveImages(ctx, r.Performer, newPerformer.ID, performer.Images)
 				}
 				return err
 			})
 			if err != nil {
-				logger.Errorf("Failed to save performer %s: %s", *t.name, err.Error())
+				job.Logger(ctx).Errorf("Failed to save performer %s: %s", *t.name, err.Error())
 			} else {
-				logger.Infof("Saved performer %s", *t.name)
+				job.Logger(ctx).Infof("Saved performer %s", *t.name)
 			}
 		}
 	} else {
@@ -252,8 +252,36 @@ func (t *StashBoxPerformerTagTask) stashBoxPerformerTag(ctx context.Context) {
 		} else if t.performer != nil {
 			name = t.performer.Name
 		}
-		logger.Infof("No match found for %s", name)
+		job.Logger(ctx).Infof("No match found for %s", name)
+	}
+}
+
+// saveImages saves the images of a stash-box performer. If appendImages is
+// set, all of the images are added to the performer's images. Otherwise, the
+// first image replaces the primary image.
+func (t *StashBoxPerformerTagTask) saveImages(ctx context.Context, qb models.PerformerWriter, performerID int, images []string) error {
+	if !t.appendImages {
+		image, err := utils.ReadImageFromURL(ctx, images[0])
+		if err != nil {
+			return err
+		}
+		return qb.UpdateImage(ctx, performerID, image)
+	}
+
+	for _, url := range images {
+		image, err := utils.ReadImageFromURL(ctx, url)
+		if err != nil {
+			job.Logger(ctx).Warnf("error reading performer image %s: %v", url, err)
+			continue
+		}
+
+		const primary = false
+		if _, err := qb.AddImage(ctx, performerID, image, primary); err != nil {
+			return err
+		}
 	}
+
+	return nil
 }
 
 func getDate(val *string) *models.Date {
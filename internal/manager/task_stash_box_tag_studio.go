@@ -0,0 +1,176 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/hash/md5"
+	"github.com/stashapp/stash/pkg/job"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper/stashbox"
+	"github.com/stashapp/stash/pkg/txn"
+)
+
+// StashBoxStudioTagTask tags a single studio from stash-box. If studio is
+// set, the studio is matched using its stash ID, name or aliases. Otherwise
+// the studio with the provided name is found on stash-box, and created
+// locally if it does not already exist.
+type StashBoxStudioTagTask struct {
+	box     *models.StashBox
+	name    *string
+	studio  *models.Studio
+	options *identify.MetadataOptions
+}
+
+func (t *StashBoxStudioTagTask) Description() string {
+	var name string
+	if t.name != nil {
+		name = *t.name
+	} else if t.studio != nil {
+		name = t.studio.Name.String
+	}
+
+	return fmt.Sprintf("Tagging studio %s from stash-box", name)
+}
+
+// Start tags the studio, recording whether it was created, updated or
+// skipped in the job progress.
+func (t *StashBoxStudioTagTask) Start(ctx context.Context, progress *job.Progress) error {
+	client := stashbox.NewClient(*t.box, instance.Repository, stashbox.Repository{
+		Scene:     instance.Repository.Scene,
+		Performer: instance.Repository.Performer,
+		Tag:       instance.Repository.Tag,
+		Studio:    instance.Repository.Studio,
+	}, instance.ScraperCache.ResponseCache())
+
+	studio := t.studio
+	created := false
+	if studio == nil {
+		var err error
+		studio, created, err = t.findOrCreateStudio(ctx, client)
+		if err != nil {
+			return err
+		}
+		if studio == nil {
+			job.Logger(ctx).Infof("No match found for %s", *t.name)
+			progress.AddSkipped(1)
+			return nil
+		}
+		if created {
+			progress.AddCreated(1)
+		}
+	}
+
+	identifier := identify.StudioIdentifier{
+		StudioReaderUpdater: instance.Repository.Studio,
+		DefaultOptions:      t.options,
+		Sources: []identify.StudioScraperSource{
+			{
+				Name: "stash-box: " + t.box.Endpoint,
+				Scraper: stashboxSource{
+					Client:   client,
+					endpoint: t.box.Endpoint,
+				},
+				RemoteSite: t.box.Endpoint,
+			},
+		},
+		PostHookExecutor: instance.PluginCache,
+	}
+
+	modified, err := identifier.Identify(ctx, instance.Repository, studio)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case created:
+		// created studios are not also counted as updated
+	case modified:
+		progress.AddUpdated(1)
+	case t.studio != nil:
+		job.Logger(ctx).Infof("No changes for studio %s", studio.Name.String)
+		progress.AddSkipped(1)
+	}
+
+	return nil
+}
+
+// findOrCreateStudio finds the studio with the task name on stash-box. If a
+// local studio matches the stash-box studio, it is returned. Otherwise a new
+// studio is created with the stash-box name and stash ID. Returns nil if the
+// studio is not found on stash-box.
+func (t *StashBoxStudioTagTask) findOrCreateStudio(ctx context.Context, client *stashbox.Client) (*models.Studio, bool, error) {
+	scraped, err := client.FindStashBoxStudioByName(ctx, *t.name)
+	if err != nil {
+		return nil, false, fmt.Errorf("error fetching studio data from stash-box: %w", err)
+	}
+
+	if scraped == nil {
+		return nil, false, nil
+	}
+
+	var ret *models.Studio
+	created := false
+	if err := txn.WithTxn(ctx, instance.Repository, func(ctx context.Context) error {
+		var err error
+		ret, created, err = getOrCreateStashBoxStudio(ctx, instance.Repository.Studio, t.box.Endpoint, scraped)
+		return err
+	}); err != nil {
+		return nil, false, fmt.Errorf("error saving studio %s: %w", scraped.Name, err)
+	}
+
+	if created {
+		job.Logger(ctx).Infof("Created studio %s", scraped.Name)
+	}
+
+	return ret, created, nil
+}
+
+type stashBoxStudioFinderCreator interface {
+	Find(ctx context.Context, id int) (*models.Studio, error)
+	Create(ctx context.Context, newStudio models.Studio) (*models.Studio, error)
+	UpdateStashIDs(ctx context.Context, studioID int, stashIDs []models.StashID) error
+}
+
+// getOrCreateStashBoxStudio returns the local studio matched to the scraped
+// stash-box studio, or creates it with the stash-box name and stash ID.
+// Returns true if the studio was created.
+func getOrCreateStashBoxStudio(ctx context.Context, qb stashBoxStudioFinderCreator, endpoint string, scraped *models.ScrapedStudio) (*models.Studio, bool, error) {
+	if scraped.StoredID != nil {
+		id, err := strconv.Atoi(*scraped.StoredID)
+		if err != nil {
+			return nil, false, err
+		}
+
+		ret, err := qb.Find(ctx, id)
+		return ret, false, err
+	}
+
+	now := time.Now()
+	ret, err := qb.Create(ctx, models.Studio{
+		Name:      sql.NullString{String: scraped.Name, Valid: true},
+		Checksum:  md5.FromString(scraped.Name),
+		CreatedAt: models.SQLiteTimestamp{Timestamp: now},
+		UpdatedAt: models.SQLiteTimestamp{Timestamp: now},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if scraped.RemoteSiteID != nil {
+		if err := qb.UpdateStashIDs(ctx, ret.ID, []models.StashID{
+			{
+				Endpoint: endpoint,
+				StashID:  *scraped.RemoteSiteID,
+			},
+		}); err != nil {
+			return nil, false, err
+		}
+	}
+
+	return ret, true, nil
+}
@@ -0,0 +1,129 @@
+package manager
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stashapp/stash/internal/identify"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_getStashBoxStudioTagOptions(t *testing.T) {
+	createMissing := true
+	defaultParent := &identify.FieldOptions{
+		Field:         "parent_studio",
+		Strategy:      identify.FieldStrategyMerge,
+		CreateMissing: &createMissing,
+	}
+	image := &identify.FieldOptions{
+		Field:    "image",
+		Strategy: identify.FieldStrategyOverwrite,
+	}
+	ignoreParent := &identify.FieldOptions{
+		Field:    "parent_studio",
+		Strategy: identify.FieldStrategyIgnore,
+	}
+
+	tests := []struct {
+		name         string
+		fieldOptions []*identify.FieldOptions
+		want         []*identify.FieldOptions
+	}{
+		{
+			"no options",
+			nil,
+			[]*identify.FieldOptions{defaultParent},
+		},
+		{
+			"other field",
+			[]*identify.FieldOptions{image},
+			[]*identify.FieldOptions{image, defaultParent},
+		},
+		{
+			"parent provided",
+			[]*identify.FieldOptions{ignoreParent},
+			[]*identify.FieldOptions{ignoreParent},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getStashBoxStudioTagOptions(StashBoxBatchStudioTagInput{
+				FieldOptions: tt.fieldOptions,
+			})
+			assert.Equal(t, tt.want, got.FieldOptions)
+		})
+	}
+}
+
+func Test_getOrCreateStashBoxStudio(t *testing.T) {
+	const (
+		endpoint  = "endpoint"
+		existing  = 1
+		createdID = 2
+		name      = "name"
+		remoteID  = "remoteID"
+	)
+
+	existingID := "1"
+	remoteSiteID := remoteID
+	existingStudio := &models.Studio{ID: existing}
+	createdStudio := &models.Studio{ID: createdID}
+
+	tests := []struct {
+		name         string
+		scraped      *models.ScrapedStudio
+		wantStashIDs []models.StashID
+		want         *models.Studio
+		wantCreated  bool
+	}{
+		{
+			"matched",
+			&models.ScrapedStudio{Name: name, StoredID: &existingID, RemoteSiteID: &remoteSiteID},
+			nil,
+			existingStudio,
+			false,
+		},
+		{
+			"created",
+			&models.ScrapedStudio{Name: name, RemoteSiteID: &remoteSiteID},
+			[]models.StashID{{Endpoint: endpoint, StashID: remoteID}},
+			createdStudio,
+			true,
+		},
+		{
+			"created without remote id",
+			&models.ScrapedStudio{Name: name},
+			nil,
+			createdStudio,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			qb := &mocks.StudioReaderWriter{}
+			qb.On("Find", ctx, existing).Return(existingStudio, nil).Maybe()
+			qb.On("Create", ctx, mock.MatchedBy(func(s models.Studio) bool {
+				return s.Name == sql.NullString{String: name, Valid: true}
+			})).Return(createdStudio, nil).Maybe()
+			if tt.wantStashIDs != nil {
+				qb.On("UpdateStashIDs", ctx, createdID, tt.wantStashIDs).Return(nil).Once()
+			}
+
+			got, created, err := getOrCreateStashBoxStudio(ctx, qb, endpoint, tt.scraped)
+			if err != nil {
+				t.Errorf("getOrCreateStashBoxStudio() error = %v", err)
+				return
+			}
+
+			assert.Same(t, tt.want, got)
+			assert.Equal(t, tt.wantCreated, created)
+			qb.AssertExpectations(t)
+		})
+	}
+}
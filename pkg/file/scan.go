@@ -144,7 +144,7 @@ func (s *scanJob) withDB(ctx context.Context, fn func(ctx context.Context) error
 
 func (s *scanJob) execute(ctx context.Context) {
 	paths := s.options.Paths
-	logger.Infof("scanning %d paths", len(paths))
+	logger.Debugf("scanning %d paths", len(paths))
 	s.startTime = time.Now()
 
 	s.fileQueue = make(chan scanFile, scanQueueSize)
@@ -160,7 +160,7 @@ func (s *scanJob) execute(ctx context.Context) {
 			return
 		}
 
-		logger.Infof("Finished adding files to queue. %d files queued", s.count)
+		logger.Debugf("Finished adding files to queue. %d files queued", s.count)
 	}()
 
 	if err := s.processQueue(ctx); err != nil {
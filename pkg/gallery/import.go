@@ -29,6 +29,7 @@ type Importer struct {
 
 type FullCreatorUpdater interface {
 	FinderCreatorUpdater
+	models.CustomFieldsWriter
 	Update(ctx context.Context, updatedGallery *models.Gallery) error
 }
 
@@ -56,6 +57,7 @@ func (i *Importer) PreImport(ctx context.Context) error {
 
 func (i *Importer) galleryJSONToGallery(galleryJSON jsonschema.Gallery) models.Gallery {
 	newGallery := models.Gallery{
+		URLs:         models.NewRelatedStrings([]string{}),
 		PerformerIDs: models.NewRelatedIDs([]int{}),
 		TagIDs:       models.NewRelatedIDs([]int{}),
 	}
@@ -66,8 +68,11 @@ func (i *Importer) galleryJSONToGallery(galleryJSON jsonschema.Gallery) models.G
 	if galleryJSON.Details != "" {
 		newGallery.Details = galleryJSON.Details
 	}
-	if galleryJSON.URL != "" {
-		newGallery.URL = galleryJSON.URL
+	if len(galleryJSON.URLs) > 0 {
+		newGallery.URLs = models.NewRelatedStrings(galleryJSON.URLs)
+	} else if galleryJSON.URL != "" {
+		// exported before galleries supported multiple urls
+		newGallery.URLs = models.NewRelatedStrings([]string{galleryJSON.URL})
 	}
 	if galleryJSON.Date != "" {
 		d := models.NewDate(galleryJSON.Date)
@@ -282,6 +287,12 @@ func (i *Importer) populateFilesFolder(ctx context.Context) error {
 }
 
 func (i *Importer) PostImport(ctx context.Context, id int) error {
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{Full: i.Input.CustomFields}); err != nil {
+			return fmt.Errorf("error setting custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
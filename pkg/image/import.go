@@ -21,6 +21,7 @@ type GalleryFinder interface {
 
 type FullCreatorUpdater interface {
 	FinderCreatorUpdater
+	models.CustomFieldsWriter
 	Update(ctx context.Context, updatedImage *models.Image) error
 }
 
@@ -284,6 +285,12 @@ func (i *Importer) populateTags(ctx context.Context) error {
 }
 
 func (i *Importer) PostImport(ctx context.Context, id int) error {
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{Full: i.Input.CustomFields}); err != nil {
+			return fmt.Errorf("error setting custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
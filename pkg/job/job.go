@@ -11,21 +11,83 @@ type JobExec interface {
 }
 
 type jobExecImpl struct {
-	fn func(ctx context.Context, progress *Progress)
+	fn    func(ctx context.Context, progress *Progress)
+	class ResourceClass
 }
 
 func (j *jobExecImpl) Execute(ctx context.Context, progress *Progress) {
 	j.fn(ctx, progress)
 }
 
+func (j *jobExecImpl) ResourceClass() ResourceClass {
+	return j.class
+}
+
 // MakeJobExec returns a simple JobExec implementation using the provided
-// function.
+// function. The job is a ResourceIO job.
 func MakeJobExec(fn func(ctx context.Context, progress *Progress)) JobExec {
+	return MakeJobExecWithClass(ResourceIO, fn)
+}
+
+// MakeJobExecWithClass returns a simple JobExec implementation using the
+// provided function and resource class.
+func MakeJobExecWithClass(class ResourceClass, fn func(ctx context.Context, progress *Progress)) JobExec {
 	return &jobExecImpl{
-		fn: fn,
+		fn:    fn,
+		class: class,
 	}
 }
 
+// InputReporter is optionally implemented by a JobExec to report the input
+// that the job was created with. The input is recorded in the job history.
+type InputReporter interface {
+	JobInput() interface{}
+}
+
+// ResourceClass is the class of resource that a job mostly uses. The number
+// of jobs of each class that run concurrently is limited separately.
+type ResourceClass string
+
+const (
+	// ResourceIO is the class of jobs that mostly read and write files and
+	// the database. This is the default class.
+	ResourceIO ResourceClass = "IO"
+	// ResourceCPU is the class of jobs that are mostly CPU-bound, such as
+	// jobs that run ffmpeg.
+	ResourceCPU ResourceClass = "CPU"
+	// ResourceNetwork is the class of jobs that mostly wait on remote
+	// services.
+	ResourceNetwork ResourceClass = "NETWORK"
+	// ResourceExclusive is the class of jobs that must not run concurrently
+	// with any other job, such as jobs that replace or remove database
+	// contents. An exclusive job waits for all running jobs to finish, and no
+	// other jobs are started while it runs.
+	ResourceExclusive ResourceClass = "EXCLUSIVE"
+)
+
+// ResourceClasses contains all of the resource classes.
+var ResourceClasses = []ResourceClass{
+	ResourceIO,
+	ResourceCPU,
+	ResourceNetwork,
+	ResourceExclusive,
+}
+
+// ResourceClassReporter is optionally implemented by a JobExec to report the
+// class of resource that the job uses. Jobs that do not implement it are
+// treated as ResourceIO jobs.
+type ResourceClassReporter interface {
+	ResourceClass() ResourceClass
+}
+
+// Resumable is optionally implemented by a JobExec that can be resumed from
+// its last checkpoint if stash is stopped while the job is running. The job
+// sets its checkpoint using Progress.SetCheckpoint.
+type Resumable interface {
+	// JobType returns the name used to recreate the job when resuming it.
+	JobType() string
+}
+
 // Status is the status of a Job
 type Status string
 
@@ -42,6 +104,10 @@ const (
 	StatusCancelled Status = "CANCELLED"
 	// StatusFailed means that the job failed.
 	StatusFailed Status = "FAILED"
+	// StatusPaused means that the job is paused. A paused job that has not
+	// yet started is not started until resumed. A paused job that is running
+	// waits before starting its next task until resumed.
+	StatusPaused Status = "PAUSED"
 )
 
 // Job represents the status of a queued or running job.
@@ -56,12 +122,44 @@ type Job struct {
 	StartTime *time.Time
 	EndTime   *time.Time
 	AddTime   time.Time
+	// Jobs with a higher priority are started before jobs with a lower
+	// priority. Jobs with the same priority are started in queue order.
+	Priority      int
+	ResourceClass ResourceClass
+	// Result returned by the job, if any
+	Result interface{}
+	// Input that the job was created with, if reported by the JobExec
+	Input interface{}
+	// Error message set if the job failed
+	Error *string
+	// Summary of the objects affected by the job
+	Summary Summary
+	// Log lines emitted while the job was running
+	Logs []LogLine
+	// Type of the job, if the JobExec is Resumable
+	Type string
+	// Checkpoint last set by a resumable job
+	Checkpoint interface{}
 
 	outerCtx   context.Context
 	exec       JobExec
 	cancelFunc context.CancelFunc
 }
 
+// Summary counts the objects affected by a job.
+type Summary struct {
+	Created int
+	Updated int
+	Skipped int
+}
+
+// LogLine is a log line emitted while a job was running.
+type LogLine struct {
+	Time    time.Time
+	Level   string
+	Message string
+}
+
 // TimeElapsed returns the total time elapsed for the job.
 // If the EndTime is set, then it uses this to calculate the elapsed time, otherwise it uses time.Now.
 func (j *Job) TimeElapsed() time.Duration {
@@ -76,9 +174,10 @@ func (j *Job) TimeElapsed() time.Duration {
 }
 
 func (j *Job) cancel() {
-	if j.Status == StatusReady {
+	switch {
+	case j.Status == StatusReady, j.Status == StatusPaused && !j.isStarted():
 		j.Status = StatusCancelled
-	} else if j.Status == StatusRunning {
+	case j.Status == StatusRunning, j.Status == StatusPaused:
 		j.Status = StatusStopping
 	}
 
@@ -87,6 +186,10 @@ func (j *Job) cancel() {
 	}
 }
 
+func (j *Job) isStarted() bool {
+	return j.StartTime != nil
+}
+
 // IsCancelled returns true if cancel has been called on the context.
 func IsCancelled(ctx context.Context) bool {
 	select {
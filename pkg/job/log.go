@@ -0,0 +1,110 @@
+package job
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+type jobContextKey struct{}
+
+type jobContext struct {
+	manager *Manager
+	id      int
+}
+
+// withJob returns a context identifying the job executing under it.
+func withJob(ctx context.Context, m *Manager, id int) context.Context {
+	return context.WithValue(ctx, jobContextKey{}, jobContext{
+		manager: m,
+		id:      id,
+	})
+}
+
+// IDFromContext returns the ID of the job executing under the context.
+// Returns false if the context is not the context of a job.
+func IDFromContext(ctx context.Context) (int, bool) {
+	jc, ok := ctx.Value(jobContextKey{}).(jobContext)
+	return jc.id, ok
+}
+
+// Logger returns a logger that logs using the global logger, and adds info,
+// warning and error lines to the job executing under the context. Returns
+// the global logger if the context is not the context of a job.
+func Logger(ctx context.Context) logger.LoggerImpl {
+	jc, ok := ctx.Value(jobContextKey{}).(jobContext)
+	if !ok {
+		return globalLogger{}
+	}
+
+	return &jobLogger{
+		manager: jc.manager,
+		id:      jc.id,
+	}
+}
+
+// globalLogger forwards to the global logger functions, which do nothing if
+// no logger is registered.
+type globalLogger struct{}
+
+func (globalLogger) Progressf(format string, args ...interface{}) {
+	logger.Progressf(format, args...)
+}
+func (globalLogger) Trace(args ...interface{})                 { logger.Trace(args...) }
+func (globalLogger) Tracef(format string, args ...interface{}) { logger.Tracef(format, args...) }
+func (globalLogger) Debug(args ...interface{})                 { logger.Debug(args...) }
+func (globalLogger) Debugf(format string, args ...interface{}) { logger.Debugf(format, args...) }
+func (globalLogger) Info(args ...interface{})                  { logger.Info(args...) }
+func (globalLogger) Infof(format string, args ...interface{})  { logger.Infof(format, args...) }
+func (globalLogger) Warn(args ...interface{})                  { logger.Warn(args...) }
+func (globalLogger) Warnf(format string, args ...interface{})  { logger.Warnf(format, args...) }
+func (globalLogger) Error(args ...interface{})                 { logger.Error(args...) }
+func (globalLogger) Errorf(format string, args ...interface{}) { logger.Errorf(format, args...) }
+func (globalLogger) Fatal(args ...interface{})                 { logger.Fatal(args...) }
+func (globalLogger) Fatalf(format string, args ...interface{}) { logger.Fatalf(format, args...) }
+
+type jobLogger struct {
+	globalLogger
+	manager *Manager
+	id      int
+}
+
+func (l *jobLogger) add(level string, message string) {
+	l.manager.AddLogLine(l.id, LogLine{
+		Time:    time.Now(),
+		Level:   level,
+		Message: message,
+	})
+}
+
+func (l *jobLogger) Info(args ...interface{}) {
+	l.globalLogger.Info(args...)
+	l.add("info", fmt.Sprint(args...))
+}
+
+func (l *jobLogger) Infof(format string, args ...interface{}) {
+	l.globalLogger.Infof(format, args...)
+	l.add("info", fmt.Sprintf(format, args...))
+}
+
+func (l *jobLogger) Warn(args ...interface{}) {
+	l.globalLogger.Warn(args...)
+	l.add("warn", fmt.Sprint(args...))
+}
+
+func (l *jobLogger) Warnf(format string, args ...interface{}) {
+	l.globalLogger.Warnf(format, args...)
+	l.add("warn", fmt.Sprintf(format, args...))
+}
+
+func (l *jobLogger) Error(args ...interface{}) {
+	l.globalLogger.Error(args...)
+	l.add("error", fmt.Sprint(args...))
+}
+
+func (l *jobLogger) Errorf(format string, args ...interface{}) {
+	l.globalLogger.Errorf(format, args...)
+	l.add("error", fmt.Sprintf(format, args...))
+}
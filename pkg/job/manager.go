@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"fmt"
 	"runtime/debug"
 	"sync"
 	"time"
@@ -12,19 +13,60 @@ import (
 const maxGraveyardSize = 10
 const defaultThrottleLimit = 100 * time.Millisecond
 
-// Manager maintains a queue of jobs. Jobs are executed one at a time.
+// maxJobLogLines is the maximum number of log lines kept for each job. The
+// oldest lines are discarded once the limit is reached.
+const maxJobLogLines = 1000
+
+// defaultCheckpointInterval is the minimum time between writes of the
+// checkpoint of a job.
+const defaultCheckpointInterval = 5 * time.Second
+
+// HistoryWriter records jobs once they have been removed from the queue.
+type HistoryWriter interface {
+	RecordJob(j Job)
+}
+
+// CheckpointWriter persists the checkpoints of resumable jobs, so that the
+// jobs can be resumed if stash is stopped while they are running.
+type CheckpointWriter interface {
+	// WriteCheckpoint persists the checkpoint of a running job. It is called
+	// when the job starts, with the checkpoint of the job nil, and whenever
+	// the job sets a checkpoint.
+	WriteCheckpoint(j Job)
+	// RemoveCheckpoint removes the persisted checkpoint of a job that has
+	// been removed from the queue.
+	RemoveCheckpoint(j Job)
+}
+
+// defaultConcurrency is the number of jobs of a resource class that are run
+// concurrently, unless set otherwise.
+const defaultConcurrency = 1
+
+// Manager maintains a queue of jobs. Jobs of different resource classes are
+// executed concurrently, up to the concurrency limit of each class.
 type Manager struct {
 	queue     []*Job
 	graveyard []*Job
 
-	mutex    sync.Mutex
-	notEmpty *sync.Cond
-	stop     chan struct{}
+	mutex sync.Mutex
+	// signalled when the queue changes such that a job may be startable
+	queueUpdated *sync.Cond
+	// signalled when a paused job is resumed or cancelled
+	resumed *sync.Cond
+	stop    chan struct{}
+
+	concurrency map[ResourceClass]int
+	running     map[ResourceClass]int
 
 	lastID int
 
 	subscriptions       []*ManagerSubscription
 	updateThrottleLimit time.Duration
+
+	historyWriter HistoryWriter
+
+	checkpointWriter   CheckpointWriter
+	checkpointInterval time.Duration
 }
 
 // NewManager initialises and returns a new Manager.
@@ -32,20 +74,73 @@ func NewManager() *Manager {
 	ret := &Manager{
 		stop:                make(chan struct{}),
 		updateThrottleLimit: defaultThrottleLimit,
+		checkpointInterval:  defaultCheckpointInterval,
+		concurrency:         make(map[ResourceClass]int),
+		running:             make(map[ResourceClass]int),
 	}
 
-	ret.notEmpty = sync.NewCond(&ret.mutex)
+	ret.queueUpdated = sync.NewCond(&ret.mutex)
+	ret.resumed = sync.NewCond(&ret.mutex)
 
 	go ret.dispatcher()
 
 	return ret
 }
 
+// SetHistoryWriter sets the HistoryWriter used to record jobs once they are
+// removed from the queue.
+func (m *Manager) SetHistoryWriter(w HistoryWriter) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.historyWriter = w
+}
+
+// SetCheckpointWriter sets the writer used to persist the checkpoints of
+// resumable jobs.
+func (m *Manager) SetCheckpointWriter(w CheckpointWriter) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.checkpointWriter = w
+}
+
+// SetConcurrency sets the maximum number of jobs of the provided resource
+// class that are run concurrently. Values less than 1 are treated as 1.
+func (m *Manager) SetConcurrency(c ResourceClass, n int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if n < 1 {
+		n = 1
+	}
+
+	m.concurrency[c] = n
+	m.queueUpdated.Broadcast()
+}
+
+func (m *Manager) getConcurrency(c ResourceClass) int {
+	// assumes lock held
+	if n, ok := m.concurrency[c]; ok {
+		return n
+	}
+
+	return defaultConcurrency
+}
+
 // Stop is used to stop the dispatcher thread. Once Stop is called, no
-// more Jobs will be processed.
+// more Jobs will be processed. The checkpoints of jobs that are cancelled by
+// Stop are kept, so that the jobs may be resumed.
 func (m *Manager) Stop() {
-	m.CancelAll()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// mark as stopped before cancelling, so that jobs removed from here on
+	// keep their checkpoints
 	close(m.stop)
+	m.cancelAll()
+
+	m.queueUpdated.Broadcast()
 }
 
 // Add queues a job.
@@ -56,20 +151,21 @@ func (m *Manager) Add(ctx context.Context, description string, e JobExec) int {
 	t := time.Now()
 
 	j := Job{
-		ID:          m.nextID(),
-		Status:      StatusReady,
-		Description: description,
-		AddTime:     t,
-		exec:        e,
-		outerCtx:    ctx,
+		ID:            m.nextID(),
+		Status:        StatusReady,
+		Description:   description,
+		AddTime:       t,
+		ResourceClass: jobResourceClass(e),
+		Type:          jobType(e),
+		Input:         jobInput(e),
+		exec:          e,
+		outerCtx:      ctx,
 	}
 
 	m.queue = append(m.queue, &j)
 
-	if len(m.queue) == 1 {
-		// notify that there is now a job in the queue
-		m.notEmpty.Broadcast()
-	}
+	// notify the dispatcher that there is a new job in the queue
+	m.queueUpdated.Broadcast()
 
 	m.notifyNewJob(&j)
 
@@ -85,12 +181,15 @@ func (m *Manager) Start(ctx context.Context, description string, e JobExec) int
 	t := time.Now()
 
 	j := Job{
-		ID:          m.nextID(),
-		Status:      StatusReady,
-		Description: description,
-		AddTime:     t,
-		exec:        e,
-		outerCtx:    ctx,
+		ID:            m.nextID(),
+		Status:        StatusReady,
+		Description:   description,
+		AddTime:       t,
+		ResourceClass: jobResourceClass(e),
+		Type:          jobType(e),
+		Input:         jobInput(e),
+		exec:          e,
+		outerCtx:      ctx,
 	}
 
 	m.queue = append(m.queue, &j)
@@ -100,6 +199,30 @@ func (m *Manager) Start(ctx context.Context, description string, e JobExec) int
 	return j.ID
 }
 
+func jobResourceClass(e JobExec) ResourceClass {
+	if r, ok := e.(ResourceClassReporter); ok {
+		return r.ResourceClass()
+	}
+
+	return ResourceIO
+}
+
+func jobType(e JobExec) string {
+	if r, ok := e.(Resumable); ok {
+		return r.JobType()
+	}
+
+	return ""
+}
+
+func jobInput(e JobExec) interface{} {
+	if r, ok := e.(InputReporter); ok {
+		return r.JobInput()
+	}
+
+	return nil
+}
+
 func (m *Manager) notifyNewJob(j *Job) {
 	// assumes lock held
 	for _, s := range m.subscriptions {
@@ -116,49 +239,91 @@ func (m *Manager) nextID() int {
 	return m.lastID
 }
 
+// getReadyJob returns the ready job with the highest priority whose resource
+// class is below its concurrency limit. Jobs with the same priority are
+// returned in queue order. Returns nil if no job can be started.
 func (m *Manager) getReadyJob() *Job {
 	// assumes lock held
+
+	// no other jobs are started while an exclusive job is running
+	if m.running[ResourceExclusive] > 0 {
+		return nil
+	}
+
+	// jobs without a higher priority are not started while an exclusive job
+	// is waiting, so that it is not starved
+	exclusive := m.getReadyExclusiveJob()
+
+	var ret *Job
 	for _, j := range m.queue {
-		if j.Status == StatusReady {
-			return j
+		if j.Status != StatusReady || j.ResourceClass == ResourceExclusive || m.running[j.ResourceClass] >= m.getConcurrency(j.ResourceClass) {
+			continue
+		}
+
+		if exclusive != nil && j.Priority <= exclusive.Priority {
+			continue
+		}
+
+		if ret == nil || j.Priority > ret.Priority {
+			ret = j
 		}
 	}
 
-	return nil
+	if ret == nil && exclusive != nil && m.runningCount() == 0 {
+		return exclusive
+	}
+
+	return ret
+}
+
+// getReadyExclusiveJob returns the ready exclusive job with the highest
+// priority, or nil if there is none.
+func (m *Manager) getReadyExclusiveJob() *Job {
+	// assumes lock held
+	var ret *Job
+	for _, j := range m.queue {
+		if j.Status != StatusReady || j.ResourceClass != ResourceExclusive {
+			continue
+		}
+
+		if ret == nil || j.Priority > ret.Priority {
+			ret = j
+		}
+	}
+
+	return ret
+}
+
+func (m *Manager) runningCount() int {
+	// assumes lock held
+	ret := 0
+	for _, n := range m.running {
+		ret += n
+	}
+
+	return ret
 }
 
 func (m *Manager) dispatcher() {
 	m.mutex.Lock()
+	defer m.mutex.Unlock()
 
 	for {
-		// wait until we have something to process
-		j := m.getReadyJob()
-
-		for j == nil {
-			m.notEmpty.Wait()
-
-			// it's possible that we have been stopped - check here
-			select {
-			case <-m.stop:
-				m.mutex.Unlock()
-				return
-			default:
-				// keep going
-				j = m.getReadyJob()
-			}
+		// start as many jobs as the concurrency limits allow
+		for j := m.getReadyJob(); j != nil; j = m.getReadyJob() {
+			m.dispatch(j.outerCtx, j)
 		}
 
-		done := m.dispatch(j.outerCtx, j)
-
-		// unlock the mutex and wait for the job to finish
-		m.mutex.Unlock()
-		<-done
-		m.mutex.Lock()
-
-		// remove the job from the queue
-		m.removeJob(j)
+		// wait until a job may be startable
+		m.queueUpdated.Wait()
 
-		// process next job
+		// it's possible that we have been stopped - check here
+		select {
+		case <-m.stop:
+			return
+		default:
+			// keep going
+		}
 	}
 }
 
@@ -172,25 +337,22 @@ func (m *Manager) newProgress(j *Job) *Progress {
 	}
 }
 
-func (m *Manager) dispatch(ctx context.Context, j *Job) (done chan struct{}) {
+func (m *Manager) dispatch(ctx context.Context, j *Job) {
 	// assumes lock held
 	t := time.Now()
 	j.StartTime = &t
 	j.Status = StatusRunning
+	m.running[j.ResourceClass]++
 
-	ctx, cancelFunc := context.WithCancel(valueOnlyContext{ctx})
+	ctx, cancelFunc := context.WithCancel(withJob(valueOnlyContext{ctx}, m, j.ID))
 	j.cancelFunc = cancelFunc
 
-	done = make(chan struct{})
-	go m.executeJob(ctx, j, done)
+	go m.executeJob(ctx, j)
 
 	m.notifyJobUpdate(j)
-
-	return
 }
 
-func (m *Manager) executeJob(ctx context.Context, j *Job, done chan struct{}) {
-	defer close(done)
+func (m *Manager) executeJob(ctx context.Context, j *Job) {
 	defer m.onJobFinish(j)
 	defer func() {
 		if p := recover(); p != nil {
@@ -201,10 +363,13 @@ func (m *Manager) executeJob(ctx context.Context, j *Job, done chan struct{}) {
 			m.mutex.Lock()
 			defer m.mutex.Unlock()
 			j.Status = StatusFailed
+			errStr := fmt.Sprintf("panic: %v", p)
+			j.Error = &errStr
 		}
 	}()
 
 	progress := m.newProgress(j)
+	progress.updater.writeInitialCheckpoint()
 	j.exec.Execute(ctx, progress)
 }
 
@@ -212,13 +377,22 @@ func (m *Manager) onJobFinish(job *Job) {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	if job.Status == StatusStopping {
+	switch {
+	case job.Status == StatusStopping:
 		job.Status = StatusCancelled
-	} else if job.Status != StatusFailed {
+	case job.Error != nil:
+		job.Status = StatusFailed
+	default:
 		job.Status = StatusFinished
 	}
 	t := time.Now()
 	job.EndTime = &t
+
+	m.running[job.ResourceClass]--
+	m.removeJob(job)
+
+	// the resource used by the job is now free
+	m.queueUpdated.Broadcast()
 }
 
 func (m *Manager) removeJob(job *Job) {
@@ -238,6 +412,15 @@ func (m *Manager) removeJob(job *Job) {
 		m.graveyard = m.graveyard[1:]
 	}
 
+	if m.historyWriter != nil {
+		// record outside of the lock
+		go m.historyWriter.RecordJob(*job)
+	}
+
+	if m.checkpointWriter != nil && job.Type != "" && !m.isStopped() {
+		go m.checkpointWriter.RemoveCheckpoint(*job)
+	}
+
 	// notify job removed
 	for _, s := range m.subscriptions {
 		// don't block if channel is full
@@ -248,6 +431,16 @@ func (m *Manager) removeJob(job *Job) {
 	}
 }
 
+func (m *Manager) isStopped() bool {
+	// assumes lock held
+	select {
+	case <-m.stop:
+		return true
+	default:
+		return false
+	}
+}
+
 func (m *Manager) getJob(list []*Job, id int) (index int, job *Job) {
 	// assumes lock held
 	for i, j := range list {
@@ -277,6 +470,9 @@ func (m *Manager) CancelJob(id int) {
 			// remove from the queue
 			m.removeJob(j)
 		}
+
+		// wake the job if it is waiting while paused
+		m.resumed.Broadcast()
 	}
 }
 
@@ -286,6 +482,12 @@ func (m *Manager) CancelAll() {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	m.cancelAll()
+}
+
+func (m *Manager) cancelAll() {
+	// assumes lock held
+
 	// call cancel on all
 	for _, j := range m.queue {
 		j.cancel()
@@ -295,6 +497,87 @@ func (m *Manager) CancelAll() {
 			m.removeJob(j)
 		}
 	}
+
+	// wake any jobs waiting while paused
+	m.resumed.Broadcast()
+}
+
+// SetPriority sets the priority of the job with the provided id. Jobs with a
+// higher priority are started before jobs with a lower priority. If no job
+// exists in the queue with the provided id, then there is no effect.
+func (m *Manager) SetPriority(id int, priority int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j != nil {
+		j.Priority = priority
+		m.notifyJobUpdate(j)
+	}
+}
+
+// ReorderJob moves the job with the provided id to the provided position in
+// the queue. The position is clamped to the bounds of the queue. Queue order
+// determines the order in which jobs with the same priority are started. If
+// no job exists in the queue with the provided id, then there is no effect.
+func (m *Manager) ReorderJob(id int, position int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	index, j := m.getJob(m.queue, id)
+	if j == nil {
+		return
+	}
+
+	m.queue = append(m.queue[:index], m.queue[index+1:]...)
+
+	if position < 0 {
+		position = 0
+	}
+	if position > len(m.queue) {
+		position = len(m.queue)
+	}
+
+	m.queue = append(m.queue[:position], append([]*Job{j}, m.queue[position:]...)...)
+	m.notifyJobUpdate(j)
+}
+
+// PauseJob pauses the job with the provided id. A paused job that has not yet
+// started is not started until it is resumed. A paused job that is running
+// waits before starting its next task until it is resumed, and continues to
+// count towards the concurrency limit of its resource class. If no ready or
+// running job exists with the provided id, then there is no effect.
+func (m *Manager) PauseJob(id int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j != nil && (j.Status == StatusReady || j.Status == StatusRunning) {
+		j.Status = StatusPaused
+		m.notifyJobUpdate(j)
+	}
+}
+
+// ResumeJob resumes the paused job with the provided id. If no paused job
+// exists with the provided id, then there is no effect.
+func (m *Manager) ResumeJob(id int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, id)
+	if j == nil || j.Status != StatusPaused {
+		return
+	}
+
+	if j.isStarted() {
+		j.Status = StatusRunning
+		m.resumed.Broadcast()
+	} else {
+		j.Status = StatusReady
+		m.queueUpdated.Broadcast()
+	}
+
+	m.notifyJobUpdate(j)
 }
 
 // GetJob returns a copy of the Job for the provided id. Returns nil if the job
@@ -314,6 +597,23 @@ func (m *Manager) GetJob(id int) *Job {
 	return nil
 }
 
+// AddLogLine adds the log line to the running job with the provided id. Log
+// lines are usually added using the Logger of the job's context.
+func (m *Manager) AddLogLine(jobID int, l LogLine) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	_, j := m.getJob(m.queue, jobID)
+	if j == nil || !j.isStarted() {
+		return
+	}
+
+	j.Logs = append(j.Logs, l)
+	if len(j.Logs) > maxJobLogLines {
+		j.Logs = j.Logs[len(j.Logs)-maxJobLogLines:]
+	}
+}
+
 // GetQueue returns a copy of the current job queue.
 func (m *Manager) GetQueue() []Job {
 	m.mutex.Lock()
@@ -379,6 +679,11 @@ type updater struct {
 	job         *Job
 	lastUpdate  time.Time
 	updateTimer *time.Timer
+
+	// held while writing a checkpoint, so that checkpoints are written in
+	// order
+	checkpointMutex sync.Mutex
+	lastCheckpoint  time.Time
 }
 
 func (u *updater) notifyUpdate() {
@@ -408,3 +713,78 @@ func (u *updater) updateProgress(progress float64, details []string) {
 		u.notifyUpdate()
 	}
 }
+
+// waitIfPaused blocks while the job is paused.
+func (u *updater) waitIfPaused() {
+	u.m.mutex.Lock()
+	defer u.m.mutex.Unlock()
+
+	for u.job.Status == StatusPaused {
+		u.m.resumed.Wait()
+	}
+}
+
+func (u *updater) setResult(result interface{}) {
+	u.m.mutex.Lock()
+	defer u.m.mutex.Unlock()
+
+	u.job.Result = result
+	u.notifyUpdate()
+}
+
+func (u *updater) setCheckpoint(checkpoint interface{}) {
+	u.checkpointMutex.Lock()
+	defer u.checkpointMutex.Unlock()
+
+	u.m.mutex.Lock()
+	u.job.Checkpoint = checkpoint
+	w := u.m.checkpointWriter
+	if w == nil || u.job.Type == "" || time.Since(u.lastCheckpoint) < u.m.checkpointInterval {
+		u.m.mutex.Unlock()
+		return
+	}
+
+	u.lastCheckpoint = time.Now()
+	j := *u.job
+	u.m.mutex.Unlock()
+
+	// write outside of the lock
+	w.WriteCheckpoint(j)
+}
+
+// writeInitialCheckpoint persists the checkpoint of a resumable job when it
+// starts, so that the job can be resumed if stash is stopped before the job
+// sets a checkpoint.
+func (u *updater) writeInitialCheckpoint() {
+	u.checkpointMutex.Lock()
+	defer u.checkpointMutex.Unlock()
+
+	u.m.mutex.Lock()
+	w := u.m.checkpointWriter
+	if w == nil || u.job.Type == "" {
+		u.m.mutex.Unlock()
+		return
+	}
+
+	j := *u.job
+	u.m.mutex.Unlock()
+
+	// write outside of the lock
+	w.WriteCheckpoint(j)
+}
+
+func (u *updater) setError(err error) {
+	u.m.mutex.Lock()
+	defer u.m.mutex.Unlock()
+
+	errStr := err.Error()
+	u.job.Error = &errStr
+	u.notifyUpdate()
+}
+
+func (u *updater) updateSummary(fn func(s *Summary)) {
+	u.m.mutex.Lock()
+	defer u.m.mutex.Unlock()
+
+	fn(&u.job.Summary)
+}
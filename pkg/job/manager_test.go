@@ -2,6 +2,8 @@ package job
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,6 +13,7 @@ import (
 const sleepTime time.Duration = 10 * time.Millisecond
 
 type testExec struct {
+	ctx       context.Context
 	started   chan struct{}
 	finish    chan struct{}
 	cancelled bool
@@ -25,6 +28,7 @@ func newTestExec(finish chan struct{}) *testExec {
 }
 
 func (e *testExec) Execute(ctx context.Context, p *Progress) {
+	e.ctx = ctx
 	e.progress = p
 	close(e.started)
 
@@ -341,3 +345,675 @@ func TestSubscribe(t *testing.T) {
 
 	cancel()
 }
+
+type testHistoryWriter struct {
+	recorded chan Job
+}
+
+func (w *testHistoryWriter) RecordJob(j Job) {
+	w.recorded <- j
+}
+
+type testInputExec struct {
+	*testExec
+	input string
+}
+
+func (e *testInputExec) JobInput() interface{} {
+	return e.input
+}
+
+func TestHistory(t *testing.T) {
+	m := NewManager()
+	w := &testHistoryWriter{
+		recorded: make(chan Job, 1),
+	}
+	m.SetHistoryWriter(w)
+
+	const input = "input"
+	const logMessage = "log message"
+	exec1 := &testInputExec{
+		testExec: newTestExec(make(chan struct{})),
+		input:    input,
+	}
+	jobID := m.Add(context.Background(), "test job", exec1)
+
+	// wait for the job to start
+	<-exec1.started
+
+	Logger(exec1.ctx).Info(logMessage)
+
+	exec1.progress.AddCreated(1)
+	exec1.progress.AddUpdated(2)
+	exec1.progress.AddSkipped(3)
+
+	close(exec1.finish)
+
+	select {
+	case j := <-w.recorded:
+		assert := assert.New(t)
+		assert.Equal(jobID, j.ID)
+		assert.Equal(StatusFinished, j.Status)
+		assert.Equal(input, j.Input)
+		assert.Nil(j.Error)
+		assert.Equal(Summary{Created: 1, Updated: 2, Skipped: 3}, j.Summary)
+		if assert.Len(j.Logs, 1) {
+			assert.Equal(logMessage, j.Logs[0].Message)
+		}
+	case <-time.After(time.Second):
+		t.Error("job was not recorded")
+	}
+}
+
+func TestSetError(t *testing.T) {
+	m := NewManager()
+
+	exec1 := newTestExec(make(chan struct{}))
+	jobID := m.Add(context.Background(), "test job", exec1)
+
+	// wait for the job to start
+	<-exec1.started
+
+	exec2 := newTestExec(make(chan struct{}))
+	job2ID := m.Add(context.Background(), "other job", exec2)
+
+	Logger(exec1.ctx).Error("error")
+
+	exec1.progress.SetError(errors.New("test error"))
+	close(exec1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+	j := m.GetJob(jobID)
+	assert.Equal(StatusFailed, j.Status)
+	if assert.NotNil(j.Error) {
+		assert.Equal("test error", *j.Error)
+	}
+	assert.Len(j.Logs, 1)
+
+	j2 := m.GetJob(job2ID)
+	assert.Len(j2.Logs, 0)
+
+	close(exec2.finish)
+}
+
+func TestPanic(t *testing.T) {
+	m := NewManager()
+
+	jobID := m.Add(context.Background(), "test job", MakeJobExec(func(ctx context.Context, progress *Progress) {
+		panic("test panic")
+	}))
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+	j := m.GetJob(jobID)
+	assert.Equal(StatusFailed, j.Status)
+	if assert.NotNil(j.Error) {
+		assert.Equal("panic: test panic", *j.Error)
+	}
+}
+
+type testClassExec struct {
+	*testExec
+	class ResourceClass
+}
+
+func (e *testClassExec) ResourceClass() ResourceClass {
+	return e.class
+}
+
+func newTestClassExec(class ResourceClass) *testClassExec {
+	return &testClassExec{
+		testExec: newTestExec(make(chan struct{})),
+		class:    class,
+	}
+}
+
+func isStarted(e *testExec) bool {
+	select {
+	case <-e.started:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestResourceClasses(t *testing.T) {
+	m := NewManager()
+
+	cpu1 := newTestClassExec(ResourceCPU)
+	cpu2 := newTestClassExec(ResourceCPU)
+	network := newTestClassExec(ResourceNetwork)
+	ioExec := newTestExec(make(chan struct{}))
+
+	m.Add(context.Background(), "cpu job", cpu1)
+	cpu2ID := m.Add(context.Background(), "other cpu job", cpu2)
+	m.Add(context.Background(), "network job", network)
+	ioID := m.Add(context.Background(), "io job", ioExec)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect one job of each class to have started
+	assert := assert.New(t)
+	assert.True(isStarted(cpu1.testExec))
+	assert.False(isStarted(cpu2.testExec))
+	assert.True(isStarted(network.testExec))
+	assert.True(isStarted(ioExec))
+
+	assert.Equal(ResourceCPU, m.GetJob(cpu2ID).ResourceClass)
+	assert.Equal(ResourceIO, m.GetJob(ioID).ResourceClass)
+
+	// allow the first cpu job to finish
+	close(cpu1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect the second cpu job to have started
+	assert.True(isStarted(cpu2.testExec))
+
+	close(cpu2.finish)
+	close(network.finish)
+	close(ioExec.finish)
+}
+
+func TestExclusiveResourceClass(t *testing.T) {
+	m := NewManager()
+
+	cpu1 := newTestClassExec(ResourceCPU)
+	network1 := newTestClassExec(ResourceNetwork)
+	exclusive := newTestClassExec(ResourceExclusive)
+	cpu2 := newTestClassExec(ResourceCPU)
+	network2 := newTestClassExec(ResourceNetwork)
+	ioExec := newTestExec(make(chan struct{}))
+
+	m.Add(context.Background(), "cpu job", cpu1)
+	m.Add(context.Background(), "network job", network1)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	m.Add(context.Background(), "exclusive job", exclusive)
+	m.Add(context.Background(), "other cpu job", cpu2)
+	m.Add(context.Background(), "other network job", network2)
+	m.Add(context.Background(), "io job", ioExec)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect the exclusive job to wait for the running jobs, and to block
+	// the jobs queued after it
+	assert := assert.New(t)
+	assert.True(isStarted(cpu1.testExec))
+	assert.True(isStarted(network1.testExec))
+	assert.False(isStarted(exclusive.testExec))
+	assert.False(isStarted(ioExec))
+
+	close(cpu1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert.False(isStarted(exclusive.testExec))
+	assert.False(isStarted(cpu2.testExec))
+
+	close(network1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect only the exclusive job to be running
+	assert.True(isStarted(exclusive.testExec))
+	assert.False(isStarted(cpu2.testExec))
+	assert.False(isStarted(network2.testExec))
+	assert.False(isStarted(ioExec))
+
+	close(exclusive.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert.True(isStarted(cpu2.testExec))
+	assert.True(isStarted(network2.testExec))
+	assert.True(isStarted(ioExec))
+
+	close(cpu2.finish)
+	close(network2.finish)
+	close(ioExec.finish)
+}
+
+func TestSetConcurrency(t *testing.T) {
+	m := NewManager()
+
+	exec1 := newTestExec(make(chan struct{}))
+	exec2 := newTestExec(make(chan struct{}))
+	exec3 := newTestExec(make(chan struct{}))
+
+	m.Add(context.Background(), "test job", exec1)
+	m.Add(context.Background(), "other job", exec2)
+	m.Add(context.Background(), "third job", exec3)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert := assert.New(t)
+	assert.True(isStarted(exec1))
+	assert.False(isStarted(exec2))
+
+	// increasing the concurrency should start the next job
+	m.SetConcurrency(ResourceIO, 2)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert.True(isStarted(exec2))
+	assert.False(isStarted(exec3))
+
+	close(exec1.finish)
+	close(exec2.finish)
+	close(exec3.finish)
+}
+
+func TestPriority(t *testing.T) {
+	m := NewManager()
+
+	exec1 := newTestExec(make(chan struct{}))
+	exec2 := newTestExec(make(chan struct{}))
+	exec3 := newTestExec(make(chan struct{}))
+
+	m.Add(context.Background(), "test job", exec1)
+
+	// wait for the first job to start
+	<-exec1.started
+
+	m.Add(context.Background(), "other job", exec2)
+	job3ID := m.Add(context.Background(), "third job", exec3)
+
+	m.SetPriority(job3ID, 1)
+
+	assert := assert.New(t)
+	assert.Equal(1, m.GetJob(job3ID).Priority)
+
+	close(exec1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect the higher priority job to have started first
+	assert.True(isStarted(exec3))
+	assert.False(isStarted(exec2))
+
+	close(exec3.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert.True(isStarted(exec2))
+
+	close(exec2.finish)
+}
+
+func TestReorderJob(t *testing.T) {
+	m := NewManager()
+
+	exec1 := newTestExec(make(chan struct{}))
+	exec2 := newTestExec(make(chan struct{}))
+	exec3 := newTestExec(make(chan struct{}))
+
+	jobID := m.Add(context.Background(), "test job", exec1)
+
+	// wait for the first job to start
+	<-exec1.started
+
+	job2ID := m.Add(context.Background(), "other job", exec2)
+	job3ID := m.Add(context.Background(), "third job", exec3)
+
+	// move the third job before the second
+	m.ReorderJob(job3ID, 1)
+
+	assert := assert.New(t)
+	queue := m.GetQueue()
+	if assert.Len(queue, 3) {
+		assert.Equal(jobID, queue[0].ID)
+		assert.Equal(job3ID, queue[1].ID)
+		assert.Equal(job2ID, queue[2].ID)
+	}
+
+	// out of bounds positions are clamped
+	m.ReorderJob(job2ID, -1)
+
+	queue = m.GetQueue()
+	if assert.Len(queue, 3) {
+		assert.Equal(job2ID, queue[0].ID)
+	}
+
+	m.ReorderJob(job2ID, 10)
+
+	queue = m.GetQueue()
+	if assert.Len(queue, 3) {
+		assert.Equal(job2ID, queue[2].ID)
+	}
+
+	close(exec1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect the third job to have started first
+	assert.True(isStarted(exec3))
+	assert.False(isStarted(exec2))
+
+	close(exec3.finish)
+	close(exec2.finish)
+}
+
+func TestPauseReadyJob(t *testing.T) {
+	m := NewManager()
+
+	exec1 := newTestExec(make(chan struct{}))
+	exec2 := newTestExec(make(chan struct{}))
+	exec3 := newTestExec(make(chan struct{}))
+
+	m.Add(context.Background(), "test job", exec1)
+
+	// wait for the first job to start
+	<-exec1.started
+
+	job2ID := m.Add(context.Background(), "other job", exec2)
+	m.Add(context.Background(), "third job", exec3)
+
+	m.PauseJob(job2ID)
+
+	assert := assert.New(t)
+	assert.Equal(StatusPaused, m.GetJob(job2ID).Status)
+
+	close(exec1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect the paused job to be skipped
+	assert.False(isStarted(exec2))
+	assert.True(isStarted(exec3))
+
+	close(exec3.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert.False(isStarted(exec2))
+
+	m.ResumeJob(job2ID)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert.True(isStarted(exec2))
+	assert.Equal(StatusRunning, m.GetJob(job2ID).Status)
+
+	close(exec2.finish)
+}
+
+func TestPauseRunningJob(t *testing.T) {
+	m := NewManager()
+
+	pause := make(chan struct{})
+	executed := make(chan struct{})
+	finished := make(chan struct{})
+
+	jobID := m.Add(context.Background(), "test job", MakeJobExec(func(ctx context.Context, progress *Progress) {
+		defer close(finished)
+		<-pause
+		progress.ExecuteTask("task", func() {
+			close(executed)
+		})
+	}))
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	m.PauseJob(jobID)
+	close(pause)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect the task to not have been executed while paused
+	assert := assert.New(t)
+	assert.Equal(StatusPaused, m.GetJob(jobID).Status)
+
+	select {
+	case <-executed:
+		t.Error("task was executed while paused")
+	default:
+	}
+
+	m.ResumeJob(jobID)
+
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Error("job was not resumed")
+	}
+
+	assert.Equal(StatusFinished, m.GetJob(jobID).Status)
+}
+
+func TestCancelPausedJob(t *testing.T) {
+	m := NewManager()
+
+	pause := make(chan struct{})
+	finished := make(chan struct{})
+
+	jobID := m.Add(context.Background(), "test job", MakeJobExec(func(ctx context.Context, progress *Progress) {
+		defer close(finished)
+		<-pause
+		progress.ExecuteTask("task", func() {})
+	}))
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	m.PauseJob(jobID)
+	close(pause)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	m.CancelJob(jobID)
+
+	// expect cancelling to wake the paused job
+	select {
+	case <-finished:
+	case <-time.After(time.Second):
+		t.Error("paused job was not woken by cancel")
+	}
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	assert.Equal(t, StatusCancelled, m.GetJob(jobID).Status)
+}
+
+type testCheckpointWriter struct {
+	written chan Job
+	removed chan Job
+}
+
+func (w *testCheckpointWriter) WriteCheckpoint(j Job) {
+	w.written <- j
+}
+
+func (w *testCheckpointWriter) RemoveCheckpoint(j Job) {
+	w.removed <- j
+}
+
+type testResumableExec struct {
+	*testExec
+}
+
+func (e *testResumableExec) JobType() string {
+	return "test"
+}
+
+func TestCheckpoint(t *testing.T) {
+	m := NewManager()
+	m.checkpointInterval = 0
+	w := &testCheckpointWriter{
+		written: make(chan Job, 1),
+		removed: make(chan Job, 1),
+	}
+	m.SetCheckpointWriter(w)
+
+	exec1 := &testResumableExec{
+		testExec: newTestExec(make(chan struct{})),
+	}
+	jobID := m.Add(context.Background(), "test job", exec1)
+
+	// wait for the job to start
+	<-exec1.started
+
+	assert := assert.New(t)
+
+	// expect the checkpoint to be written when the job starts
+	select {
+	case j := <-w.written:
+		assert.Equal(jobID, j.ID)
+		assert.Nil(j.Checkpoint)
+	case <-time.After(time.Second):
+		t.Error("checkpoint was not written on start")
+	}
+
+	const checkpoint = 10
+	exec1.progress.SetCheckpoint(checkpoint)
+
+	select {
+	case j := <-w.written:
+		assert.Equal(jobID, j.ID)
+		assert.Equal("test", j.Type)
+		assert.Equal(checkpoint, j.Checkpoint)
+	case <-time.After(time.Second):
+		t.Error("checkpoint was not written")
+	}
+
+	close(exec1.finish)
+
+	// expect the checkpoint to be removed once the job is finished
+	select {
+	case j := <-w.removed:
+		assert.Equal(jobID, j.ID)
+	case <-time.After(time.Second):
+		t.Error("checkpoint was not removed")
+	}
+}
+
+func TestCheckpointNotResumable(t *testing.T) {
+	m := NewManager()
+	m.checkpointInterval = 0
+	w := &testCheckpointWriter{
+		written: make(chan Job, 1),
+		removed: make(chan Job, 1),
+	}
+	m.SetCheckpointWriter(w)
+
+	exec1 := newTestExec(make(chan struct{}))
+	jobID := m.Add(context.Background(), "test job", exec1)
+
+	// wait for the job to start
+	<-exec1.started
+
+	exec1.progress.SetCheckpoint(10)
+	close(exec1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect the checkpoint to be set but not written
+	assert.Equal(t, 10, m.GetJob(jobID).Checkpoint)
+
+	select {
+	case <-w.written:
+		t.Error("checkpoint of job that is not resumable was written")
+	case <-w.removed:
+		t.Error("checkpoint of job that is not resumable was removed")
+	default:
+	}
+}
+
+func TestStopKeepsCheckpoint(t *testing.T) {
+	m := NewManager()
+	m.checkpointInterval = 0
+	w := &testCheckpointWriter{
+		written: make(chan Job, 1),
+		removed: make(chan Job, 1),
+	}
+	m.SetCheckpointWriter(w)
+
+	exec1 := &testResumableExec{
+		testExec: newTestExec(make(chan struct{})),
+	}
+	m.Add(context.Background(), "test job", exec1)
+
+	// wait for the job to start
+	<-exec1.started
+
+	m.Stop()
+	close(exec1.finish)
+
+	// wait a tiny bit
+	time.Sleep(sleepTime)
+
+	// expect the checkpoint of the job stopped by Stop to be kept
+	select {
+	case <-w.removed:
+		t.Error("checkpoint was removed on stop")
+	default:
+	}
+}
+
+func TestJobLogger(t *testing.T) {
+	m := NewManager()
+
+	// run both jobs concurrently
+	exec1 := newTestClassExec(ResourceCPU)
+	exec2 := newTestClassExec(ResourceNetwork)
+
+	job1ID := m.Add(context.Background(), "cpu job", exec1)
+	job2ID := m.Add(context.Background(), "network job", exec2)
+
+	<-exec1.started
+	<-exec2.started
+
+	Logger(exec1.ctx).Infof("job %d", job1ID)
+	Logger(exec2.ctx).Warnf("job %d", job2ID)
+	// debug lines are not added to jobs
+	Logger(exec2.ctx).Debug("debug")
+	// lines logged outside of a job are not added to any job
+	Logger(context.Background()).Info("no job")
+
+	assert := assert.New(t)
+
+	id, ok := IDFromContext(exec1.ctx)
+	assert.True(ok)
+	assert.Equal(job1ID, id)
+
+	j1 := m.GetJob(job1ID)
+	if assert.Len(j1.Logs, 1) {
+		assert.Equal("info", j1.Logs[0].Level)
+		assert.Equal(fmt.Sprintf("job %d", job1ID), j1.Logs[0].Message)
+	}
+
+	j2 := m.GetJob(job2ID)
+	if assert.Len(j2.Logs, 1) {
+		assert.Equal("warn", j2.Logs[0].Level)
+		assert.Equal(fmt.Sprintf("job %d", job2ID), j2.Logs[0].Message)
+	}
+
+	close(exec1.finish)
+	close(exec2.finish)
+}
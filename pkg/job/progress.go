@@ -142,6 +142,41 @@ func (p *Progress) AddProcessed(v int) {
 	p.calculatePercent()
 }
 
+// SetResult sets the result of the job.
+func (p *Progress) SetResult(result interface{}) {
+	p.updater.setResult(result)
+}
+
+// SetCheckpoint sets the checkpoint of a resumable job. The checkpoint should
+// contain everything needed to resume the job from this point, and must be
+// JSON-encodable. The checkpoint is persisted at most once every few seconds,
+// so a resumed job may repeat some work. The checkpoints of jobs that are not
+// Resumable are not persisted.
+func (p *Progress) SetCheckpoint(checkpoint interface{}) {
+	p.updater.setCheckpoint(checkpoint)
+}
+
+// SetError sets the error of the job. The job is marked as failed once it
+// finishes, unless it was cancelled.
+func (p *Progress) SetError(err error) {
+	p.updater.setError(err)
+}
+
+// AddCreated adds to the number of objects created by the job.
+func (p *Progress) AddCreated(n int) {
+	p.updater.updateSummary(func(s *Summary) { s.Created += n })
+}
+
+// AddUpdated adds to the number of objects updated by the job.
+func (p *Progress) AddUpdated(n int) {
+	p.updater.updateSummary(func(s *Summary) { s.Updated += n })
+}
+
+// AddSkipped adds to the number of objects skipped by the job.
+func (p *Progress) AddSkipped(n int) {
+	p.updater.updateSummary(func(s *Summary) { s.Skipped += n })
+}
+
 func (p *Progress) addTask(t *task) {
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
@@ -164,8 +199,12 @@ func (p *Progress) removeTask(t *task) {
 }
 
 // ExecuteTask executes a task as part of a job. The description is used to
-// populate the Details slice in the parent Job.
+// populate the Details slice in the parent Job. If the job is paused, then
+// ExecuteTask waits until the job is resumed or cancelled before executing
+// the task.
 func (p *Progress) ExecuteTask(description string, fn func()) {
+	p.updater.waitIfPaused()
+
 	t := &task{
 		description: description,
 	}
@@ -174,7 +174,35 @@ func PathToPerformers(ctx context.Context, path string, reader PerformerAutoTagQ
 		}
 	}
 
-	return ret, nil
+	return disambiguatePerformers(ret, path), nil
+}
+
+// disambiguatePerformers removes performers that share a name with another
+// matched performer, unless the performer's disambiguation also matches the
+// path.
+func disambiguatePerformers(performers []*models.Performer, path string) []*models.Performer {
+	byName := make(map[string][]*models.Performer)
+	for _, p := range performers {
+		name := strings.ToLower(p.Name)
+		byName[name] = append(byName[name], p)
+	}
+
+	var ret []*models.Performer
+	for _, p := range performers {
+		if len(byName[strings.ToLower(p.Name)]) > 1 && !DisambiguationMatchesPath(p, path) {
+			continue
+		}
+
+		ret = append(ret, p)
+	}
+
+	return ret
+}
+
+// DisambiguationMatchesPath returns true if the performer has a
+// disambiguation and it matches the given path.
+func DisambiguationMatchesPath(p *models.Performer, path string) bool {
+	return p.Disambiguation != "" && nameMatchesPath(p.Disambiguation, path) != -1
 }
 
 func getStudios(ctx context.Context, words []string, reader StudioAutoTagQueryer, cache *Cache) ([]*models.Studio, error) {
@@ -1,6 +1,11 @@
 package match
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+)
 
 func Test_nameMatchesPath(t *testing.T) {
 	const name = "first last"
@@ -99,3 +104,36 @@ func Test_nameMatchesPath(t *testing.T) {
 		})
 	}
 }
+
+func Test_disambiguatePerformers(t *testing.T) {
+	unique := &models.Performer{ID: 1, Name: "unique"}
+	shared := &models.Performer{ID: 2, Name: "shared"}
+	sharedDisambiguated := &models.Performer{ID: 3, Name: "Shared", Disambiguation: "blonde"}
+
+	performers := []*models.Performer{unique, shared, sharedDisambiguated}
+
+	tests := []struct {
+		testName string
+		path     string
+		want     []*models.Performer
+	}{
+		{
+			"without disambiguation",
+			"unique shared.mp4",
+			[]*models.Performer{unique},
+		},
+		{
+			"with disambiguation",
+			"unique shared blonde.mp4",
+			[]*models.Performer{unique, sharedDisambiguated},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			got := disambiguatePerformers(performers, tt.path)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("disambiguatePerformers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
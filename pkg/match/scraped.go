@@ -3,6 +3,7 @@ package match
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/studio"
@@ -47,6 +48,24 @@ func ScrapedPerformer(ctx context.Context, qb PerformerFinder, p *models.Scraped
 		return err
 	}
 
+	var disambiguation string
+	if p.Disambiguation != nil {
+		disambiguation = *p.Disambiguation
+	}
+
+	// performers sharing a name are distinguished by their disambiguation
+	if disambiguation != "" || len(performers) > 1 {
+		matched := performersWithDisambiguation(performers, disambiguation)
+
+		// a single performer without a disambiguation may still be the
+		// same performer
+		if len(matched) == 0 && len(performers) == 1 && performers[0].Disambiguation == "" {
+			matched = performers
+		}
+
+		performers = matched
+	}
+
 	if len(performers) != 1 {
 		// ignore - cannot match
 		return nil
@@ -57,6 +76,17 @@ func ScrapedPerformer(ctx context.Context, qb PerformerFinder, p *models.Scraped
 	return nil
 }
 
+func performersWithDisambiguation(performers []*models.Performer, disambiguation string) []*models.Performer {
+	var ret []*models.Performer
+	for _, p := range performers {
+		if strings.EqualFold(p.Disambiguation, disambiguation) {
+			ret = append(ret, p)
+		}
+	}
+
+	return ret
+}
+
 type StudioFinder interface {
 	studio.Queryer
 	FindByStashID(ctx context.Context, stashID models.StashID) ([]*models.Studio, error)
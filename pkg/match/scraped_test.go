@@ -0,0 +1,71 @@
+package match
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestScrapedPerformer(t *testing.T) {
+	const (
+		name           = "name"
+		disambiguation = "disambiguation"
+		otherName      = "other name"
+		sharedName     = "shared name"
+	)
+
+	var (
+		noDisambiguation = ""
+		disambiguated    = disambiguation
+		otherDisambig    = "other"
+	)
+
+	plain := &models.Performer{ID: 1, Name: name}
+	shared := &models.Performer{ID: 2, Name: sharedName}
+	sharedDisambiguated := &models.Performer{ID: 3, Name: sharedName, Disambiguation: disambiguation}
+
+	qb := &mocks.PerformerReaderWriter{}
+	qb.On("FindByNames", mock.Anything, []string{name}, true).Return([]*models.Performer{plain}, nil)
+	qb.On("FindByNames", mock.Anything, []string{sharedName}, true).Return([]*models.Performer{shared, sharedDisambiguated}, nil)
+	qb.On("FindByNames", mock.Anything, []string{otherName}, true).Return(nil, nil)
+
+	tests := []struct {
+		name           string
+		performerName  string
+		disambiguation *string
+		want           *int
+	}{
+		{"no match", otherName, nil, nil},
+		{"single", name, nil, &plain.ID},
+		{"single with blank disambiguation", name, &noDisambiguation, &plain.ID},
+		{"single without local disambiguation", name, &disambiguated, &plain.ID},
+		{"shared without disambiguation", sharedName, nil, &shared.ID},
+		{"shared with disambiguation", sharedName, &disambiguated, &sharedDisambiguated.ID},
+		{"shared with unknown disambiguation", sharedName, &otherDisambig, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			performerName := tt.performerName
+			p := &models.ScrapedPerformer{
+				Name:           &performerName,
+				Disambiguation: tt.disambiguation,
+			}
+
+			err := ScrapedPerformer(context.Background(), qb, p, nil)
+			assert.Nil(t, err)
+
+			if tt.want == nil {
+				assert.Nil(t, p.StoredID)
+				return
+			}
+
+			want := strconv.Itoa(*tt.want)
+			assert.Equal(t, &want, p.StoredID)
+		})
+	}
+}
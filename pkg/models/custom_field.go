@@ -0,0 +1,157 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type CustomFieldType string
+
+const (
+	CustomFieldTypeString CustomFieldType = "STRING"
+	CustomFieldTypeInt    CustomFieldType = "INT"
+	CustomFieldTypeFloat  CustomFieldType = "FLOAT"
+	CustomFieldTypeDate   CustomFieldType = "DATE"
+	CustomFieldTypeBool   CustomFieldType = "BOOL"
+	CustomFieldTypeEnum   CustomFieldType = "ENUM"
+)
+
+var AllCustomFieldType = []CustomFieldType{
+	CustomFieldTypeString,
+	CustomFieldTypeInt,
+	CustomFieldTypeFloat,
+	CustomFieldTypeDate,
+	CustomFieldTypeBool,
+	CustomFieldTypeEnum,
+}
+
+func (e CustomFieldType) IsValid() bool {
+	switch e {
+	case CustomFieldTypeString, CustomFieldTypeInt, CustomFieldTypeFloat, CustomFieldTypeDate, CustomFieldTypeBool, CustomFieldTypeEnum:
+		return true
+	}
+	return false
+}
+
+func (e CustomFieldType) String() string {
+	return string(e)
+}
+
+func (e *CustomFieldType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CustomFieldType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CustomFieldType", str)
+	}
+	return nil
+}
+
+func (e CustomFieldType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+type CustomFieldEntityType string
+
+const (
+	CustomFieldEntityTypeScene     CustomFieldEntityType = "SCENE"
+	CustomFieldEntityTypeImage     CustomFieldEntityType = "IMAGE"
+	CustomFieldEntityTypeGallery   CustomFieldEntityType = "GALLERY"
+	CustomFieldEntityTypePerformer CustomFieldEntityType = "PERFORMER"
+	CustomFieldEntityTypeStudio    CustomFieldEntityType = "STUDIO"
+	CustomFieldEntityTypeTag       CustomFieldEntityType = "TAG"
+	CustomFieldEntityTypeMovie     CustomFieldEntityType = "MOVIE"
+)
+
+var AllCustomFieldEntityType = []CustomFieldEntityType{
+	CustomFieldEntityTypeScene,
+	CustomFieldEntityTypeImage,
+	CustomFieldEntityTypeGallery,
+	CustomFieldEntityTypePerformer,
+	CustomFieldEntityTypeStudio,
+	CustomFieldEntityTypeTag,
+	CustomFieldEntityTypeMovie,
+}
+
+func (e CustomFieldEntityType) IsValid() bool {
+	switch e {
+	case CustomFieldEntityTypeScene, CustomFieldEntityTypeImage, CustomFieldEntityTypeGallery, CustomFieldEntityTypePerformer, CustomFieldEntityTypeStudio, CustomFieldEntityTypeTag, CustomFieldEntityTypeMovie:
+		return true
+	}
+	return false
+}
+
+func (e CustomFieldEntityType) String() string {
+	return string(e)
+}
+
+func (e *CustomFieldEntityType) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CustomFieldEntityType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CustomFieldEntityType", str)
+	}
+	return nil
+}
+
+func (e CustomFieldEntityType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// CustomFieldMap maps custom field names to their values.
+type CustomFieldMap map[string]interface{}
+
+// CustomFieldsInput describes changes to the custom field values of an object.
+type CustomFieldsInput struct {
+	// Full replaces all existing values
+	Full map[string]interface{} `json:"full"`
+	// Partial sets the provided values, leaving other values unchanged.
+	// Values set to nil are removed.
+	Partial map[string]interface{} `json:"partial"`
+}
+
+type CustomFieldCriterionInput struct {
+	Field    string            `json:"field"`
+	Modifier CriterionModifier `json:"modifier"`
+	Value    []interface{}     `json:"value"`
+}
+
+type CustomFieldDefinitionReader interface {
+	Find(ctx context.Context, id int) (*CustomFieldDefinition, error)
+	FindByName(ctx context.Context, entityType CustomFieldEntityType, name string) (*CustomFieldDefinition, error)
+	// FindByEntityType returns the definitions of the provided entity type,
+	// ordered by name.
+	FindByEntityType(ctx context.Context, entityType CustomFieldEntityType) ([]*CustomFieldDefinition, error)
+	All(ctx context.Context) ([]*CustomFieldDefinition, error)
+}
+
+type CustomFieldDefinitionWriter interface {
+	Create(ctx context.Context, newObject *CustomFieldDefinition) error
+	Update(ctx context.Context, updatedObject *CustomFieldDefinition) error
+	Destroy(ctx context.Context, id int) error
+}
+
+type CustomFieldDefinitionReaderWriter interface {
+	CustomFieldDefinitionReader
+	CustomFieldDefinitionWriter
+}
+
+type CustomFieldsReader interface {
+	GetCustomFields(ctx context.Context, id int) (CustomFieldMap, error)
+}
+
+type CustomFieldsWriter interface {
+	// SetCustomFields applies the provided changes to the custom field values
+	// of the object. Returns an error if a value does not match the definition
+	// of its field, or if no field with the provided name is defined.
+	SetCustomFields(ctx context.Context, id int, input CustomFieldsInput) error
+}
@@ -57,23 +57,29 @@ type GalleryFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom field values
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type GalleryUpdateInput struct {
-	ClientMutationID *string  `json:"clientMutationId"`
-	ID               string   `json:"id"`
-	Title            *string  `json:"title"`
-	URL              *string  `json:"url"`
-	Date             *string  `json:"date"`
-	Details          *string  `json:"details"`
-	Rating           *int     `json:"rating"`
-	Rating100        *int     `json:"rating100"`
-	Organized        *bool    `json:"organized"`
-	SceneIds         []string `json:"scene_ids"`
-	StudioID         *string  `json:"studio_id"`
-	TagIds           []string `json:"tag_ids"`
-	PerformerIds     []string `json:"performer_ids"`
-	PrimaryFileID    *string  `json:"primary_file_id"`
+	ClientMutationID *string `json:"clientMutationId"`
+	ID               string  `json:"id"`
+	Title            *string `json:"title"`
+	// deprecated - use Urls
+	URL           *string  `json:"url"`
+	Urls          []string `json:"urls"`
+	Date          *string  `json:"date"`
+	Details       *string  `json:"details"`
+	Rating        *int     `json:"rating"`
+	Rating100     *int     `json:"rating100"`
+	Organized     *bool    `json:"organized"`
+	SceneIds      []string `json:"scene_ids"`
+	StudioID      *string  `json:"studio_id"`
+	TagIds        []string `json:"tag_ids"`
+	PerformerIds  []string `json:"performer_ids"`
+	PrimaryFileID *string  `json:"primary_file_id"`
+
+	CustomFields *CustomFieldsInput `json:"custom_fields"`
 }
 
 type GalleryDestroyInput struct {
@@ -98,6 +104,7 @@ type GalleryReader interface {
 	FindBySceneID(ctx context.Context, sceneID int) ([]*Gallery, error)
 	FindByImageID(ctx context.Context, imageID int) ([]*Gallery, error)
 
+	URLLoader
 	SceneIDLoader
 	PerformerIDLoader
 	TagIDLoader
@@ -107,6 +114,7 @@ type GalleryReader interface {
 	Query(ctx context.Context, galleryFilter *GalleryFilterType, findFilter *FindFilterType) ([]*Gallery, int, error)
 	QueryCount(ctx context.Context, galleryFilter *GalleryFilterType, findFilter *FindFilterType) (int, error)
 	GetImageIDs(ctx context.Context, galleryID int) ([]int, error)
+	CustomFieldsReader
 }
 
 type GalleryWriter interface {
@@ -115,6 +123,7 @@ type GalleryWriter interface {
 	UpdatePartial(ctx context.Context, id int, updatedGallery GalleryPartial) (*Gallery, error)
 	Destroy(ctx context.Context, id int) error
 	UpdateImages(ctx context.Context, galleryID int, imageIDs []int) error
+	CustomFieldsWriter
 }
 
 type GalleryReaderWriter interface {
@@ -46,6 +46,8 @@ type ImageFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom field values
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type ImageDestroyInput struct {
@@ -113,6 +115,7 @@ type ImageReader interface {
 	GalleryIDLoader
 	PerformerIDLoader
 	TagIDLoader
+	CustomFieldsReader
 }
 
 type ImageWriter interface {
@@ -123,6 +126,7 @@ type ImageWriter interface {
 	DecrementOCounter(ctx context.Context, id int) (int, error)
 	ResetOCounter(ctx context.Context, id int) (int, error)
 	Destroy(ctx context.Context, id int) error
+	CustomFieldsWriter
 }
 
 type ImageReaderWriter interface {
@@ -0,0 +1,20 @@
+package models
+
+import "context"
+
+type JobCheckpointReader interface {
+	Find(ctx context.Context, id int) (*JobCheckpoint, error)
+	// All returns all job checkpoints, in the order that the jobs were added.
+	All(ctx context.Context) ([]*JobCheckpoint, error)
+}
+
+type JobCheckpointWriter interface {
+	Create(ctx context.Context, obj JobCheckpoint) (*JobCheckpoint, error)
+	Update(ctx context.Context, obj JobCheckpoint) (*JobCheckpoint, error)
+	Destroy(ctx context.Context, id int) error
+}
+
+type JobCheckpointReaderWriter interface {
+	JobCheckpointReader
+	JobCheckpointWriter
+}
@@ -0,0 +1,24 @@
+package models
+
+import "context"
+
+type JobRecordReader interface {
+	Find(ctx context.Context, id int) (*JobRecord, error)
+	// Query returns the job records with the provided status, or all job
+	// records if status is nil. Records are sorted by add time, most recent
+	// first, unless a sort is provided.
+	Query(ctx context.Context, status *string, findFilter *FindFilterType) ([]*JobRecord, int, error)
+	GetLogs(ctx context.Context, id int) ([]JobRecordLog, error)
+}
+
+type JobRecordWriter interface {
+	Create(ctx context.Context, obj JobRecord) (*JobRecord, error)
+	UpdateLogs(ctx context.Context, id int, logs []JobRecordLog) error
+	// Prune removes all but the most recent keep job records.
+	Prune(ctx context.Context, keep int) error
+}
+
+type JobRecordReaderWriter interface {
+	JobRecordReader
+	JobRecordWriter
+}
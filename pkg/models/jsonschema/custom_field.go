@@ -0,0 +1,41 @@
+package jsonschema
+
+import (
+	"fmt"
+	"os"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/stashapp/stash/pkg/models/json"
+)
+
+type CustomFieldDefinition struct {
+	EntityType string        `json:"entity_type,omitempty"`
+	Name       string        `json:"name,omitempty"`
+	Type       string        `json:"type,omitempty"`
+	Options    []string      `json:"options,omitempty"`
+	CreatedAt  json.JSONTime `json:"created_at,omitempty"`
+	UpdatedAt  json.JSONTime `json:"updated_at,omitempty"`
+}
+
+func LoadCustomFieldsFile(filePath string) ([]CustomFieldDefinition, error) {
+	var definitions []CustomFieldDefinition
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	var json = jsoniter.ConfigCompatibleWithStandardLibrary
+	jsonParser := json.NewDecoder(file)
+	err = jsonParser.Decode(&definitions)
+	if err != nil {
+		return nil, err
+	}
+	return definitions, nil
+}
+
+func SaveCustomFieldsFile(filePath string, definitions []CustomFieldDefinition) error {
+	if definitions == nil {
+		return fmt.Errorf("custom field definitions must not be nil")
+	}
+	return marshalToFile(filePath, definitions)
+}
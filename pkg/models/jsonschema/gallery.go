@@ -11,19 +11,23 @@ import (
 )
 
 type Gallery struct {
-	ZipFiles   []string      `json:"zip_files,omitempty"`
-	FolderPath string        `json:"folder_path,omitempty"`
-	Title      string        `json:"title,omitempty"`
-	URL        string        `json:"url,omitempty"`
-	Date       string        `json:"date,omitempty"`
-	Details    string        `json:"details,omitempty"`
-	Rating     int           `json:"rating,omitempty"`
-	Organized  bool          `json:"organized,omitempty"`
-	Studio     string        `json:"studio,omitempty"`
-	Performers []string      `json:"performers,omitempty"`
-	Tags       []string      `json:"tags,omitempty"`
-	CreatedAt  json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt  json.JSONTime `json:"updated_at,omitempty"`
+	ZipFiles     []string               `json:"zip_files,omitempty"`
+	FolderPath   string                 `json:"folder_path,omitempty"`
+	Title        string                 `json:"title,omitempty"`
+	URLs         []string               `json:"urls,omitempty"`
+	Date         string                 `json:"date,omitempty"`
+	Details      string                 `json:"details,omitempty"`
+	Rating       int                    `json:"rating,omitempty"`
+	Organized    bool                   `json:"organized,omitempty"`
+	Studio       string                 `json:"studio,omitempty"`
+	Performers   []string               `json:"performers,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	CreatedAt    json.JSONTime          `json:"created_at,omitempty"`
+	UpdatedAt    json.JSONTime          `json:"updated_at,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
+
+	// deprecated - for import only
+	URL string `json:"url,omitempty"`
 }
 
 func (s Gallery) Filename(basename string, hash string) string {
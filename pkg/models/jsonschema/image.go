@@ -10,17 +10,18 @@ import (
 )
 
 type Image struct {
-	Title      string        `json:"title,omitempty"`
-	Studio     string        `json:"studio,omitempty"`
-	Rating     int           `json:"rating,omitempty"`
-	Organized  bool          `json:"organized,omitempty"`
-	OCounter   int           `json:"o_counter,omitempty"`
-	Galleries  []GalleryRef  `json:"galleries,omitempty"`
-	Performers []string      `json:"performers,omitempty"`
-	Tags       []string      `json:"tags,omitempty"`
-	Files      []string      `json:"files,omitempty"`
-	CreatedAt  json.JSONTime `json:"created_at,omitempty"`
-	UpdatedAt  json.JSONTime `json:"updated_at,omitempty"`
+	Title        string                 `json:"title,omitempty"`
+	Studio       string                 `json:"studio,omitempty"`
+	Rating       int                    `json:"rating,omitempty"`
+	Organized    bool                   `json:"organized,omitempty"`
+	OCounter     int                    `json:"o_counter,omitempty"`
+	Galleries    []GalleryRef           `json:"galleries,omitempty"`
+	Performers   []string               `json:"performers,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	Files        []string               `json:"files,omitempty"`
+	CreatedAt    json.JSONTime          `json:"created_at,omitempty"`
+	UpdatedAt    json.JSONTime          `json:"updated_at,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"`
 }
 
 func (s Image) Filename(basename string, hash string) string {
@@ -249,6 +249,29 @@ func (_m *GalleryReaderWriter) FindMany(ctx context.Context, ids []int) ([]*mode
 	return r0, r1
 }
 
+// GetCustomFields provides a mock function with given fields: ctx, id
+func (_m *GalleryReaderWriter) GetCustomFields(ctx context.Context, id int) (models.CustomFieldMap, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 models.CustomFieldMap
+	if rf, ok := ret.Get(0).(func(context.Context, int) models.CustomFieldMap); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.CustomFieldMap)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImageIDs provides a mock function with given fields: ctx, galleryID
 func (_m *GalleryReaderWriter) GetImageIDs(ctx context.Context, galleryID int) ([]int, error) {
 	ret := _m.Called(ctx, galleryID)
@@ -341,6 +364,29 @@ func (_m *GalleryReaderWriter) GetTagIDs(ctx context.Context, relatedID int) ([]
 	return r0, r1
 }
 
+// GetURLs provides a mock function with given fields: ctx, relatedID
+func (_m *GalleryReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]string, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Query provides a mock function with given fields: ctx, galleryFilter, findFilter
 func (_m *GalleryReaderWriter) Query(ctx context.Context, galleryFilter *models.GalleryFilterType, findFilter *models.FindFilterType) ([]*models.Gallery, int, error) {
 	ret := _m.Called(ctx, galleryFilter, findFilter)
@@ -392,6 +438,20 @@ func (_m *GalleryReaderWriter) QueryCount(ctx context.Context, galleryFilter *mo
 	return r0, r1
 }
 
+// SetCustomFields provides a mock function with given fields: ctx, id, input
+func (_m *GalleryReaderWriter) SetCustomFields(ctx context.Context, id int, input models.CustomFieldsInput) error {
+	ret := _m.Called(ctx, id, input)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.CustomFieldsInput) error); ok {
+		r0 = rf(ctx, id, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, updatedGallery
 func (_m *GalleryReaderWriter) Update(ctx context.Context, updatedGallery *models.Gallery) error {
 	ret := _m.Called(ctx, updatedGallery)
@@ -312,6 +312,29 @@ func (_m *MovieReaderWriter) GetBackImage(ctx context.Context, movieID int) ([]b
 	return r0, r1
 }
 
+// GetCustomFields provides a mock function with given fields: ctx, id
+func (_m *MovieReaderWriter) GetCustomFields(ctx context.Context, id int) (models.CustomFieldMap, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 models.CustomFieldMap
+	if rf, ok := ret.Get(0).(func(context.Context, int) models.CustomFieldMap); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.CustomFieldMap)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetFrontImage provides a mock function with given fields: ctx, movieID
 func (_m *MovieReaderWriter) GetFrontImage(ctx context.Context, movieID int) ([]byte, error) {
 	ret := _m.Called(ctx, movieID)
@@ -335,6 +358,29 @@ func (_m *MovieReaderWriter) GetFrontImage(ctx context.Context, movieID int) ([]
 	return r0, r1
 }
 
+// GetURLs provides a mock function with given fields: ctx, relatedID
+func (_m *MovieReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]string, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Query provides a mock function with given fields: ctx, movieFilter, findFilter
 func (_m *MovieReaderWriter) Query(ctx context.Context, movieFilter *models.MovieFilterType, findFilter *models.FindFilterType) ([]*models.Movie, int, error) {
 	ret := _m.Called(ctx, movieFilter, findFilter)
@@ -365,6 +411,20 @@ func (_m *MovieReaderWriter) Query(ctx context.Context, movieFilter *models.Movi
 	return r0, r1, r2
 }
 
+// SetCustomFields provides a mock function with given fields: ctx, id, input
+func (_m *MovieReaderWriter) SetCustomFields(ctx context.Context, id int, input models.CustomFieldsInput) error {
+	ret := _m.Called(ctx, id, input)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.CustomFieldsInput) error); ok {
+		r0 = rf(ctx, id, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, updatedMovie
 func (_m *MovieReaderWriter) Update(ctx context.Context, updatedMovie models.MoviePartial) (*models.Movie, error) {
 	ret := _m.Called(ctx, updatedMovie)
@@ -424,3 +484,17 @@ func (_m *MovieReaderWriter) UpdateImages(ctx context.Context, movieID int, fron
 
 	return r0
 }
+
+// UpdateURLs provides a mock function with given fields: ctx, movieID, urls
+func (_m *MovieReaderWriter) UpdateURLs(ctx context.Context, movieID int, urls []string) error {
+	ret := _m.Called(ctx, movieID, urls)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) error); ok {
+		r0 = rf(ctx, movieID, urls)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -14,6 +14,27 @@ type PerformerReaderWriter struct {
 	mock.Mock
 }
 
+// AddImage provides a mock function with given fields: ctx, performerID, image, primary
+func (_m *PerformerReaderWriter) AddImage(ctx context.Context, performerID int, image []byte, primary bool) (int, error) {
+	ret := _m.Called(ctx, performerID, image, primary)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(context.Context, int, []byte, bool) int); ok {
+		r0 = rf(ctx, performerID, image, primary)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, []byte, bool) error); ok {
+		r1 = rf(ctx, performerID, image, primary)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // All provides a mock function with given fields: ctx
 func (_m *PerformerReaderWriter) All(ctx context.Context) ([]*models.Performer, error) {
 	ret := _m.Called(ctx)
@@ -121,6 +142,20 @@ func (_m *PerformerReaderWriter) DestroyImage(ctx context.Context, performerID i
 	return r0
 }
 
+// DestroyImageByID provides a mock function with given fields: ctx, performerID, imageID
+func (_m *PerformerReaderWriter) DestroyImageByID(ctx context.Context, performerID int, imageID int) error {
+	ret := _m.Called(ctx, performerID, imageID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, performerID, imageID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Find provides a mock function with given fields: ctx, id
 func (_m *PerformerReaderWriter) Find(ctx context.Context, id int) (*models.Performer, error) {
 	ret := _m.Called(ctx, id)
@@ -305,6 +340,29 @@ func (_m *PerformerReaderWriter) FindMany(ctx context.Context, ids []int) ([]*mo
 	return r0, r1
 }
 
+// GetCustomFields provides a mock function with given fields: ctx, id
+func (_m *PerformerReaderWriter) GetCustomFields(ctx context.Context, id int) (models.CustomFieldMap, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 models.CustomFieldMap
+	if rf, ok := ret.Get(0).(func(context.Context, int) models.CustomFieldMap); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(models.CustomFieldMap)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImage provides a mock function with given fields: ctx, performerID
 func (_m *PerformerReaderWriter) GetImage(ctx context.Context, performerID int) ([]byte, error) {
 	ret := _m.Called(ctx, performerID)
@@ -328,6 +386,52 @@ func (_m *PerformerReaderWriter) GetImage(ctx context.Context, performerID int)
 	return r0, r1
 }
 
+// GetImageByID provides a mock function with given fields: ctx, performerID, imageID
+func (_m *PerformerReaderWriter) GetImageByID(ctx context.Context, performerID int, imageID int) ([]byte, error) {
+	ret := _m.Called(ctx, performerID, imageID)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []byte); ok {
+		r0 = rf(ctx, performerID, imageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, performerID, imageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetImages provides a mock function with given fields: ctx, performerID
+func (_m *PerformerReaderWriter) GetImages(ctx context.Context, performerID int) ([]*models.PerformerImage, error) {
+	ret := _m.Called(ctx, performerID)
+
+	var r0 []*models.PerformerImage
+	if rf, ok := ret.Get(0).(func(context.Context, int) []*models.PerformerImage); ok {
+		r0 = rf(ctx, performerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PerformerImage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, performerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetStashIDs provides a mock function with given fields: ctx, relatedID
 func (_m *PerformerReaderWriter) GetStashIDs(ctx context.Context, relatedID int) ([]models.StashID, error) {
 	ret := _m.Called(ctx, relatedID)
@@ -374,6 +478,29 @@ func (_m *PerformerReaderWriter) GetTagIDs(ctx context.Context, performerID int)
 	return r0, r1
 }
 
+// GetURLs provides a mock function with given fields: ctx, relatedID
+func (_m *PerformerReaderWriter) GetURLs(ctx context.Context, relatedID int) ([]string, error) {
+	ret := _m.Called(ctx, relatedID)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, int) []string); ok {
+		r0 = rf(ctx, relatedID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, relatedID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Query provides a mock function with given fields: ctx, performerFilter, findFilter
 func (_m *PerformerReaderWriter) Query(ctx context.Context, performerFilter *models.PerformerFilterType, findFilter *models.FindFilterType) ([]*models.Performer, int, error) {
 	ret := _m.Called(ctx, performerFilter, findFilter)
@@ -427,6 +554,48 @@ func (_m *PerformerReaderWriter) QueryForAutoTag(ctx context.Context, words []st
 	return r0, r1
 }
 
+// ReorderImages provides a mock function with given fields: ctx, performerID, imageIDs
+func (_m *PerformerReaderWriter) ReorderImages(ctx context.Context, performerID int, imageIDs []int) error {
+	ret := _m.Called(ctx, performerID, imageIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = rf(ctx, performerID, imageIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetCustomFields provides a mock function with given fields: ctx, id, input
+func (_m *PerformerReaderWriter) SetCustomFields(ctx context.Context, id int, input models.CustomFieldsInput) error {
+	ret := _m.Called(ctx, id, input)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, models.CustomFieldsInput) error); ok {
+		r0 = rf(ctx, id, input)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPrimaryImage provides a mock function with given fields: ctx, performerID, imageID
+func (_m *PerformerReaderWriter) SetPrimaryImage(ctx context.Context, performerID int, imageID int) error {
+	ret := _m.Called(ctx, performerID, imageID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, performerID, imageID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: ctx, updatedPerformer
 func (_m *PerformerReaderWriter) Update(ctx context.Context, updatedPerformer *models.Performer) error {
 	ret := _m.Called(ctx, updatedPerformer)
@@ -505,3 +674,17 @@ func (_m *PerformerReaderWriter) UpdateTags(ctx context.Context, performerID int
 
 	return r0
 }
+
+// UpdateURLs provides a mock function with given fields: ctx, performerID, urls
+func (_m *PerformerReaderWriter) UpdateURLs(ctx context.Context, performerID int, urls []string) error {
+	ret := _m.Called(ctx, performerID, urls)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, []string) error); ok {
+		r0 = rf(ctx, performerID, urls)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
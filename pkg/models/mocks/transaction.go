@@ -52,5 +52,13 @@ func NewTxnRepository() models.Repository {
 		Studio:      &StudioReaderWriter{},
 		Tag:         &TagReaderWriter{},
 		SavedFilter: &SavedFilterReaderWriter{},
+
+		ScenePendingChange: &ScenePendingChangeReaderWriter{},
+		JobRecord:          &JobRecordReaderWriter{},
+		JobCheckpoint:      &JobCheckpointReaderWriter{},
+		StashBoxSubmission: &StashBoxSubmissionReaderWriter{},
+		SceneStashBoxCheck: &SceneStashBoxCheckReaderWriter{},
+
+		CustomFieldDefinition: &CustomFieldDefinitionReaderWriter{},
 	}
 }
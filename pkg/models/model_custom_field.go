@@ -0,0 +1,170 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CustomFieldDefinition defines a typed custom field that may be set on
+// objects of a single entity type.
+type CustomFieldDefinition struct {
+	ID         int                   `json:"id"`
+	EntityType CustomFieldEntityType `json:"entity_type"`
+	Name       string                `json:"name"`
+	Type       CustomFieldType       `json:"type"`
+	// Options are the permitted values of enum fields
+	Options   []string  `json:"options"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Validate returns an error if the definition is not valid.
+func (d *CustomFieldDefinition) Validate() error {
+	if strings.TrimSpace(d.Name) == "" {
+		return errors.New("name must be non-empty")
+	}
+
+	if !d.EntityType.IsValid() {
+		return fmt.Errorf("invalid entity type %q", d.EntityType)
+	}
+
+	if !d.Type.IsValid() {
+		return fmt.Errorf("invalid type %q", d.Type)
+	}
+
+	if d.Type == CustomFieldTypeEnum {
+		if len(d.Options) == 0 {
+			return fmt.Errorf("enum field %q must have options", d.Name)
+		}
+	} else if len(d.Options) > 0 {
+		return fmt.Errorf("options are only permitted for enum fields")
+	}
+
+	return nil
+}
+
+// ConvertValue converts the provided value into the representation used for
+// values of this field. Returns an error if the value is not valid for the
+// field type.
+func (d *CustomFieldDefinition) ConvertValue(v interface{}) (interface{}, error) {
+	if n, ok := v.(json.Number); ok {
+		v = string(n)
+	}
+
+	var ret interface{}
+	var err error
+
+	switch d.Type {
+	case CustomFieldTypeString:
+		ret, err = convertCustomFieldString(v)
+	case CustomFieldTypeInt:
+		ret, err = convertCustomFieldInt(v)
+	case CustomFieldTypeFloat:
+		ret, err = convertCustomFieldFloat(v)
+	case CustomFieldTypeDate:
+		ret, err = convertCustomFieldDate(v)
+	case CustomFieldTypeBool:
+		ret, err = convertCustomFieldBool(v)
+	case CustomFieldTypeEnum:
+		ret, err = d.convertEnum(v)
+	default:
+		err = fmt.Errorf("unsupported type %s", d.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %v for custom field %q: %w", v, d.Name, err)
+	}
+
+	return ret, nil
+}
+
+func (d *CustomFieldDefinition) convertEnum(v interface{}) (interface{}, error) {
+	s, err := convertCustomFieldString(v)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, o := range d.Options {
+		if o == s {
+			return s, nil
+		}
+	}
+
+	return nil, fmt.Errorf("must be one of %s", strings.Join(d.Options, ", "))
+}
+
+func convertCustomFieldString(v interface{}) (string, error) {
+	switch vv := v.(type) {
+	case string:
+		return vv, nil
+	case []byte:
+		return string(vv), nil
+	}
+
+	return "", fmt.Errorf("expected string")
+}
+
+func convertCustomFieldInt(v interface{}) (int64, error) {
+	switch vv := v.(type) {
+	case int:
+		return int64(vv), nil
+	case int64:
+		return vv, nil
+	case float64:
+		if vv != math.Trunc(vv) {
+			return 0, fmt.Errorf("expected integer")
+		}
+		return int64(vv), nil
+	case string:
+		return strconv.ParseInt(vv, 10, 64)
+	}
+
+	return 0, fmt.Errorf("expected integer")
+}
+
+func convertCustomFieldFloat(v interface{}) (float64, error) {
+	switch vv := v.(type) {
+	case int:
+		return float64(vv), nil
+	case int64:
+		return float64(vv), nil
+	case float64:
+		return vv, nil
+	case string:
+		return strconv.ParseFloat(vv, 64)
+	}
+
+	return 0, fmt.Errorf("expected number")
+}
+
+func convertCustomFieldDate(v interface{}) (string, error) {
+	s, err := convertCustomFieldString(v)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := time.Parse(dateFormat, s); err != nil {
+		return "", fmt.Errorf("expected date in format YYYY-MM-DD")
+	}
+
+	return s, nil
+}
+
+func convertCustomFieldBool(v interface{}) (bool, error) {
+	switch vv := v.(type) {
+	case bool:
+		return vv, nil
+	case int64:
+		// booleans are stored as integers
+		return vv != 0, nil
+	case string:
+		return strconv.ParseBool(vv)
+	}
+
+	return false, fmt.Errorf("expected boolean")
+}
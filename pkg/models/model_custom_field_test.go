@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestCustomFieldDefinition_ConvertValue(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldType CustomFieldType
+		v         interface{}
+		want      interface{}
+		wantErr   bool
+	}{
+		{"string", CustomFieldTypeString, "value", "value", false},
+		{"string from int", CustomFieldTypeString, 1, nil, true},
+		{"int", CustomFieldTypeInt, 1, int64(1), false},
+		{"int from float", CustomFieldTypeInt, float64(2), int64(2), false},
+		{"int from fractional float", CustomFieldTypeInt, 2.5, nil, true},
+		{"int from string", CustomFieldTypeInt, "3", int64(3), false},
+		{"int from json number", CustomFieldTypeInt, json.Number("4"), int64(4), false},
+		{"int from invalid string", CustomFieldTypeInt, "a", nil, true},
+		{"float", CustomFieldTypeFloat, 1.5, 1.5, false},
+		{"float from int", CustomFieldTypeFloat, 1, float64(1), false},
+		{"float from string", CustomFieldTypeFloat, "2.5", 2.5, false},
+		{"date", CustomFieldTypeDate, "2022-01-02", "2022-01-02", false},
+		{"invalid date", CustomFieldTypeDate, "02/01/2022", nil, true},
+		{"bool", CustomFieldTypeBool, true, true, false},
+		{"bool from stored int", CustomFieldTypeBool, int64(0), false, false},
+		{"bool from string", CustomFieldTypeBool, "true", true, false},
+		{"enum", CustomFieldTypeEnum, "b", "b", false},
+		{"enum not in options", CustomFieldTypeEnum, "c", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &CustomFieldDefinition{
+				Name:    "field",
+				Type:    tt.fieldType,
+				Options: []string{"a", "b"},
+			}
+			got, err := d.ConvertValue(tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CustomFieldDefinition.ConvertValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CustomFieldDefinition.ConvertValue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCustomFieldDefinition_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition CustomFieldDefinition
+		wantErr    bool
+	}{
+		{"valid", CustomFieldDefinition{EntityType: CustomFieldEntityTypeScene, Name: "name", Type: CustomFieldTypeString}, false},
+		{"valid enum", CustomFieldDefinition{EntityType: CustomFieldEntityTypeScene, Name: "name", Type: CustomFieldTypeEnum, Options: []string{"a"}}, false},
+		{"empty name", CustomFieldDefinition{EntityType: CustomFieldEntityTypeScene, Name: " ", Type: CustomFieldTypeString}, true},
+		{"invalid entity type", CustomFieldDefinition{EntityType: "invalid", Name: "name", Type: CustomFieldTypeString}, true},
+		{"invalid type", CustomFieldDefinition{EntityType: CustomFieldEntityTypeScene, Name: "name", Type: "invalid"}, true},
+		{"enum without options", CustomFieldDefinition{EntityType: CustomFieldEntityTypeScene, Name: "name", Type: CustomFieldTypeEnum}, true},
+		{"options on non-enum", CustomFieldDefinition{EntityType: CustomFieldEntityTypeScene, Name: "name", Type: CustomFieldTypeInt, Options: []string{"a"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.definition.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("CustomFieldDefinition.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
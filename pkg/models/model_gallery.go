@@ -13,7 +13,6 @@ type Gallery struct {
 	ID int `json:"id"`
 
 	Title   string `json:"title"`
-	URL     string `json:"url"`
 	Date    *Date  `json:"date"`
 	Details string `json:"details"`
 	// Rating expressed in 1-100 scale
@@ -33,9 +32,10 @@ type Gallery struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
-	SceneIDs     RelatedIDs `json:"scene_ids"`
-	TagIDs       RelatedIDs `json:"tag_ids"`
-	PerformerIDs RelatedIDs `json:"performer_ids"`
+	URLs         RelatedStrings `json:"urls"`
+	SceneIDs     RelatedIDs     `json:"scene_ids"`
+	TagIDs       RelatedIDs     `json:"tag_ids"`
+	PerformerIDs RelatedIDs     `json:"performer_ids"`
 }
 
 // IsUserCreated returns true if the gallery was created by the user.
@@ -68,6 +68,12 @@ func (g *Gallery) LoadPrimaryFile(ctx context.Context, l file.Finder) error {
 	})
 }
 
+func (g *Gallery) LoadURLs(ctx context.Context, l URLLoader) error {
+	return g.URLs.load(func() ([]string, error) {
+		return l.GetURLs(ctx, g.ID)
+	})
+}
+
 func (g *Gallery) LoadSceneIDs(ctx context.Context, l SceneIDLoader) error {
 	return g.SceneIDs.load(func() ([]int, error) {
 		return l.GetSceneIDs(ctx, g.ID)
@@ -106,7 +112,6 @@ type GalleryPartial struct {
 	// Checksum    OptionalString
 	// Zip         OptionalBool
 	Title   OptionalString
-	URL     OptionalString
 	Date    OptionalDate
 	Details OptionalString
 	// Rating expressed in 1-100 scale
@@ -117,6 +122,7 @@ type GalleryPartial struct {
 	CreatedAt OptionalTime
 	UpdatedAt OptionalTime
 
+	URLs          *UpdateStrings
 	SceneIDs      *UpdateIDs
 	TagIDs        *UpdateIDs
 	PerformerIDs  *UpdateIDs
@@ -130,6 +136,43 @@ func NewGalleryPartial() GalleryPartial {
 	}
 }
 
+// UpdateInput constructs a GalleryUpdateInput using the populated fields in the GalleryPartial object.
+func (g GalleryPartial) UpdateInput(id int) GalleryUpdateInput {
+	var dateStr *string
+	if g.Date.Set {
+		d := g.Date.Value
+		v := d.String()
+		dateStr = &v
+	}
+
+	var urls []string
+	if g.URLs != nil {
+		urls = g.URLs.Values
+	}
+
+	ret := GalleryUpdateInput{
+		ID:           strconv.Itoa(id),
+		Title:        g.Title.Ptr(),
+		Urls:         urls,
+		Date:         dateStr,
+		Details:      g.Details.Ptr(),
+		Rating100:    g.Rating.Ptr(),
+		Organized:    g.Organized.Ptr(),
+		StudioID:     g.StudioID.StringPtr(),
+		SceneIds:     g.SceneIDs.IDStrings(),
+		TagIds:       g.TagIDs.IDStrings(),
+		PerformerIds: g.PerformerIDs.IDStrings(),
+	}
+
+	if g.Rating.Set && !g.Rating.Null {
+		// convert to 1-100 scale
+		rating := Rating100To5(g.Rating.Value)
+		ret.Rating = &rating
+	}
+
+	return ret
+}
+
 // GetTitle returns the title of the scene. If the Title field is empty,
 // then the base filename is returned.
 func (g Gallery) GetTitle() string {
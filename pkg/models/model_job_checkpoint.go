@@ -0,0 +1,29 @@
+package models
+
+import "database/sql"
+
+// JobCheckpoint is the persisted checkpoint of a resumable job that has not
+// yet finished.
+type JobCheckpoint struct {
+	ID int `db:"id" json:"id"`
+	// Type of the job, used to recreate the job when resuming it
+	Type        string `db:"type" json:"type"`
+	Description string `db:"description" json:"description"`
+	// JSON-encoded input of the job
+	Input sql.NullString `db:"input" json:"input"`
+	// JSON-encoded checkpoint of the job. Not set if the job has not yet set
+	// a checkpoint.
+	Checkpoint sql.NullString  `db:"checkpoint" json:"checkpoint"`
+	AddTime    SQLiteTimestamp `db:"add_time" json:"add_time"`
+	UpdatedAt  SQLiteTimestamp `db:"updated_at" json:"updated_at"`
+}
+
+type JobCheckpoints []*JobCheckpoint
+
+func (m *JobCheckpoints) Append(o interface{}) {
+	*m = append(*m, o.(*JobCheckpoint))
+}
+
+func (m *JobCheckpoints) New() interface{} {
+	return &JobCheckpoint{}
+}
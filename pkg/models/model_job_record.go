@@ -0,0 +1,37 @@
+package models
+
+import "database/sql"
+
+// JobRecord is the persisted record of a job that is no longer queued.
+type JobRecord struct {
+	ID          int    `db:"id" json:"id"`
+	Description string `db:"description" json:"description"`
+	// JSON-encoded input of the job
+	Input  sql.NullString `db:"input" json:"input"`
+	Status string         `db:"status" json:"status"`
+	Error  sql.NullString `db:"error" json:"error"`
+	// number of objects created, updated and skipped by the job
+	Created   int                 `db:"created" json:"created"`
+	Updated   int                 `db:"updated" json:"updated"`
+	Skipped   int                 `db:"skipped" json:"skipped"`
+	AddTime   SQLiteTimestamp     `db:"add_time" json:"add_time"`
+	StartTime NullSQLiteTimestamp `db:"start_time" json:"start_time"`
+	EndTime   NullSQLiteTimestamp `db:"end_time" json:"end_time"`
+}
+
+type JobRecords []*JobRecord
+
+func (m *JobRecords) Append(o interface{}) {
+	*m = append(*m, o.(*JobRecord))
+}
+
+func (m *JobRecords) New() interface{} {
+	return &JobRecord{}
+}
+
+// JobRecordLog is a log line emitted while a recorded job was running.
+type JobRecordLog struct {
+	Time    SQLiteTimestamp `db:"time" json:"time"`
+	Level   string          `db:"level" json:"level"`
+	Message string          `db:"message" json:"message"`
+}
@@ -7,27 +7,26 @@ import (
 )
 
 type Performer struct {
-	ID           int        `json:"id"`
-	Checksum     string     `json:"checksum"`
-	Name         string     `json:"name"`
-	Gender       GenderEnum `json:"gender"`
-	URL          string     `json:"url"`
-	Twitter      string     `json:"twitter"`
-	Instagram    string     `json:"instagram"`
-	Birthdate    *Date      `json:"birthdate"`
-	Ethnicity    string     `json:"ethnicity"`
-	Country      string     `json:"country"`
-	EyeColor     string     `json:"eye_color"`
-	Height       *int       `json:"height"`
-	Measurements string     `json:"measurements"`
-	FakeTits     string     `json:"fake_tits"`
-	CareerLength string     `json:"career_length"`
-	Tattoos      string     `json:"tattoos"`
-	Piercings    string     `json:"piercings"`
-	Aliases      string     `json:"aliases"`
-	Favorite     bool       `json:"favorite"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	ID       int    `json:"id"`
+	Checksum string `json:"checksum"`
+	Name     string `json:"name"`
+	// Disambiguation distinguishes performers sharing the same name
+	Disambiguation string     `json:"disambiguation"`
+	Gender         GenderEnum `json:"gender"`
+	Birthdate      *Date      `json:"birthdate"`
+	Ethnicity      string     `json:"ethnicity"`
+	Country        string     `json:"country"`
+	EyeColor       string     `json:"eye_color"`
+	Height         *int       `json:"height"`
+	Measurements   string     `json:"measurements"`
+	FakeTits       string     `json:"fake_tits"`
+	CareerLength   string     `json:"career_length"`
+	Tattoos        string     `json:"tattoos"`
+	Piercings      string     `json:"piercings"`
+	Aliases        string     `json:"aliases"`
+	Favorite       bool       `json:"favorite"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
 	// Rating expressed in 1-100 scale
 	Rating        *int   `json:"rating"`
 	Details       string `json:"details"`
@@ -40,27 +39,25 @@ type Performer struct {
 // PerformerPartial represents part of a Performer object. It is used to update
 // the database entry.
 type PerformerPartial struct {
-	ID           int
-	Checksum     OptionalString
-	Name         OptionalString
-	Gender       OptionalString
-	URL          OptionalString
-	Twitter      OptionalString
-	Instagram    OptionalString
-	Birthdate    OptionalDate
-	Ethnicity    OptionalString
-	Country      OptionalString
-	EyeColor     OptionalString
-	Height       OptionalInt
-	Measurements OptionalString
-	FakeTits     OptionalString
-	CareerLength OptionalString
-	Tattoos      OptionalString
-	Piercings    OptionalString
-	Aliases      OptionalString
-	Favorite     OptionalBool
-	CreatedAt    OptionalTime
-	UpdatedAt    OptionalTime
+	ID             int
+	Checksum       OptionalString
+	Name           OptionalString
+	Disambiguation OptionalString
+	Gender         OptionalString
+	Birthdate      OptionalDate
+	Ethnicity      OptionalString
+	Country        OptionalString
+	EyeColor       OptionalString
+	Height         OptionalInt
+	Measurements   OptionalString
+	FakeTits       OptionalString
+	CareerLength   OptionalString
+	Tattoos        OptionalString
+	Piercings      OptionalString
+	Aliases        OptionalString
+	Favorite       OptionalBool
+	CreatedAt      OptionalTime
+	UpdatedAt      OptionalTime
 	// Rating expressed in 1-100 scale
 	Rating        OptionalInt
 	Details       OptionalString
@@ -96,3 +93,12 @@ func (p *Performers) Append(o interface{}) {
 func (p *Performers) New() interface{} {
 	return &Performer{}
 }
+
+// PerformerImage is an entry in a performer's image gallery. The image data
+// is not included and must be retrieved separately.
+type PerformerImage struct {
+	ID          int  `db:"id" json:"id"`
+	PerformerID int  `db:"performer_id" json:"performer_id"`
+	Position    int  `db:"position" json:"position"`
+	Primary     bool `db:"primary" json:"primary"`
+}
@@ -17,7 +17,6 @@ type Scene struct {
 	Code     string `json:"code"`
 	Details  string `json:"details"`
 	Director string `json:"director"`
-	URL      string `json:"url"`
 	Date     *Date  `json:"date"`
 	// Rating expressed in 1-100 scale
 	Rating    *int `json:"rating"`
@@ -38,6 +37,7 @@ type Scene struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
+	URLs         RelatedStrings  `json:"urls"`
 	GalleryIDs   RelatedIDs      `json:"gallery_ids"`
 	TagIDs       RelatedIDs      `json:"tag_ids"`
 	PerformerIDs RelatedIDs      `json:"performer_ids"`
@@ -74,6 +74,12 @@ func (s *Scene) LoadPrimaryFile(ctx context.Context, l file.Finder) error {
 	})
 }
 
+func (s *Scene) LoadURLs(ctx context.Context, l URLLoader) error {
+	return s.URLs.load(func() ([]string, error) {
+		return l.GetURLs(ctx, s.ID)
+	})
+}
+
 func (s *Scene) LoadGalleryIDs(ctx context.Context, l GalleryIDLoader) error {
 	return s.GalleryIDs.load(func() ([]int, error) {
 		return l.GetGalleryIDs(ctx, s.ID)
@@ -105,6 +111,10 @@ func (s *Scene) LoadStashIDs(ctx context.Context, l StashIDLoader) error {
 }
 
 func (s *Scene) LoadRelationships(ctx context.Context, l SceneReader) error {
+	if err := s.LoadURLs(ctx, l); err != nil {
+		return err
+	}
+
 	if err := s.LoadGalleryIDs(ctx, l); err != nil {
 		return err
 	}
@@ -139,7 +149,6 @@ type ScenePartial struct {
 	Code     OptionalString
 	Details  OptionalString
 	Director OptionalString
-	URL      OptionalString
 	Date     OptionalDate
 	// Rating expressed in 1-100 scale
 	Rating    OptionalInt
@@ -149,6 +158,7 @@ type ScenePartial struct {
 	CreatedAt OptionalTime
 	UpdatedAt OptionalTime
 
+	URLs          *UpdateStrings
 	GalleryIDs    *UpdateIDs
 	TagIDs        *UpdateIDs
 	PerformerIDs  *UpdateIDs
@@ -176,8 +186,10 @@ type SceneUpdateInput struct {
 	Code             *string `json:"code"`
 	Details          *string `json:"details"`
 	Director         *string `json:"director"`
-	URL              *string `json:"url"`
-	Date             *string `json:"date"`
+	// deprecated - use Urls
+	URL  *string  `json:"url"`
+	Urls []string `json:"urls"`
+	Date *string  `json:"date"`
 	// Rating expressed in 1-5 scale
 	Rating *int `json:"rating"`
 	// Rating expressed in 1-100 scale
@@ -193,6 +205,8 @@ type SceneUpdateInput struct {
 	CoverImage    *string   `json:"cover_image"`
 	StashIds      []StashID `json:"stash_ids"`
 	PrimaryFileID *string   `json:"primary_file_id"`
+
+	CustomFields *CustomFieldsInput `json:"custom_fields"`
 }
 
 // UpdateInput constructs a SceneUpdateInput using the populated fields in the ScenePartial object.
@@ -204,6 +218,11 @@ func (s ScenePartial) UpdateInput(id int) SceneUpdateInput {
 		dateStr = &v
 	}
 
+	var urls []string
+	if s.URLs != nil {
+		urls = s.URLs.Values
+	}
+
 	var stashIDs []StashID
 	if s.StashIDs != nil {
 		stashIDs = s.StashIDs.StashIDs
@@ -215,7 +234,7 @@ func (s ScenePartial) UpdateInput(id int) SceneUpdateInput {
 		Code:         s.Code.Ptr(),
 		Details:      s.Details.Ptr(),
 		Director:     s.Director.Ptr(),
-		URL:          s.URL.Ptr(),
+		Urls:         urls,
 		Date:         dateStr,
 		Rating100:    s.Rating.Ptr(),
 		Organized:    s.Organized.Ptr(),
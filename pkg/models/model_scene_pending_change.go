@@ -0,0 +1,27 @@
+package models
+
+import "database/sql"
+
+// ScenePendingChange is a change to a single scene field proposed by the
+// identify task, awaiting review.
+type ScenePendingChange struct {
+	ID      int    `db:"id" json:"id"`
+	SceneID int    `db:"scene_id" json:"scene_id"`
+	Source  string `db:"source" json:"source"`
+	Field   string `db:"field" json:"field"`
+	// JSON-encoded value of the field at the time the change was proposed
+	CurrentValue sql.NullString `db:"current_value" json:"current_value"`
+	// JSON-encoded proposed value of the field
+	ProposedValue string          `db:"proposed_value" json:"proposed_value"`
+	CreatedAt     SQLiteTimestamp `db:"created_at" json:"created_at"`
+}
+
+type ScenePendingChanges []*ScenePendingChange
+
+func (m *ScenePendingChanges) Append(o interface{}) {
+	*m = append(*m, o.(*ScenePendingChange))
+}
+
+func (m *ScenePendingChanges) New() interface{} {
+	return &ScenePendingChange{}
+}
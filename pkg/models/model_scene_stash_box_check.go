@@ -0,0 +1,82 @@
+package models
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+type StashBoxFingerprintStatus string
+
+const (
+	// StashBoxFingerprintStatusMatched indicates that all of the local
+	// fingerprints are linked to the stash-box scene.
+	StashBoxFingerprintStatusMatched StashBoxFingerprintStatus = "MATCHED"
+	// StashBoxFingerprintStatusUnlinked indicates that some of the local
+	// fingerprints are not linked to the stash-box scene.
+	StashBoxFingerprintStatusUnlinked StashBoxFingerprintStatus = "UNLINKED"
+	// StashBoxFingerprintStatusMerged indicates that the stash-box scene was
+	// merged into another scene.
+	StashBoxFingerprintStatusMerged StashBoxFingerprintStatus = "MERGED"
+	// StashBoxFingerprintStatusDeleted indicates that the stash-box scene
+	// was deleted.
+	StashBoxFingerprintStatusDeleted StashBoxFingerprintStatus = "DELETED"
+)
+
+var AllStashBoxFingerprintStatus = []StashBoxFingerprintStatus{
+	StashBoxFingerprintStatusMatched,
+	StashBoxFingerprintStatusUnlinked,
+	StashBoxFingerprintStatusMerged,
+	StashBoxFingerprintStatusDeleted,
+}
+
+func (e StashBoxFingerprintStatus) IsValid() bool {
+	switch e {
+	case StashBoxFingerprintStatusMatched, StashBoxFingerprintStatusUnlinked, StashBoxFingerprintStatusMerged, StashBoxFingerprintStatusDeleted:
+		return true
+	}
+	return false
+}
+
+func (e StashBoxFingerprintStatus) String() string {
+	return string(e)
+}
+
+func (e *StashBoxFingerprintStatus) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = StashBoxFingerprintStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid StashBoxFingerprintStatus", str)
+	}
+	return nil
+}
+
+func (e StashBoxFingerprintStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// SceneStashBoxCheck is the result of checking the fingerprints of a scene
+// against the stash-box scene linked by its stash ID.
+type SceneStashBoxCheck struct {
+	SceneID  int                       `json:"scene_id"`
+	Endpoint string                    `json:"endpoint"`
+	StashID  string                    `json:"stash_id"`
+	Status   StashBoxFingerprintStatus `json:"status"`
+	// local fingerprints that are not linked to the stash-box scene
+	UnlinkedFingerprints []*StashBoxFingerprint `json:"unlinked_fingerprints"`
+	// ID of the stash-box scene that the linked scene was merged into
+	MergedInto *string   `json:"merged_into"`
+	CheckedAt  time.Time `json:"checked_at"`
+}
+
+type StashBoxFingerprintStatusCriterionInput struct {
+	// If set, only checks against this endpoint are considered
+	Endpoint *string                     `json:"endpoint"`
+	Value    []StashBoxFingerprintStatus `json:"value"`
+	Modifier CriterionModifier           `json:"modifier"`
+}
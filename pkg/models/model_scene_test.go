@@ -41,11 +41,14 @@ func TestScenePartial_UpdateInput(t *testing.T) {
 				Code:      NewOptionalString(code),
 				Details:   NewOptionalString(details),
 				Director:  NewOptionalString(director),
-				URL:       NewOptionalString(url),
 				Date:      NewOptionalDate(dateObj),
 				Rating:    NewOptionalInt(rating100),
 				Organized: NewOptionalBool(organized),
 				StudioID:  NewOptionalInt(studioID),
+				URLs: &UpdateStrings{
+					Values: []string{url},
+					Mode:   RelationshipUpdateModeSet,
+				},
 			},
 			SceneUpdateInput{
 				ID:        idStr,
@@ -53,7 +56,7 @@ func TestScenePartial_UpdateInput(t *testing.T) {
 				Code:      &code,
 				Details:   &details,
 				Director:  &director,
-				URL:       &url,
+				Urls:      []string{url},
 				Date:      &date,
 				Rating:    &ratingLegacy,
 				Rating100: &rating100,
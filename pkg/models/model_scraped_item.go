@@ -6,11 +6,14 @@ import (
 
 type ScrapedStudio struct {
 	// Set if studio matched
-	StoredID     *string `json:"stored_id"`
-	Name         string  `json:"name"`
-	URL          *string `json:"url"`
-	Image        *string `json:"image"`
-	RemoteSiteID *string `json:"remote_site_id"`
+	StoredID *string `json:"stored_id"`
+	Name     string  `json:"name"`
+	URL      *string `json:"url"`
+	// This should be a base64 encoded data URL
+	Image        *string        `json:"image"`
+	Details      *string        `json:"details"`
+	Parent       *ScrapedStudio `json:"parent"`
+	RemoteSiteID *string        `json:"remote_site_id"`
 }
 
 func (ScrapedStudio) IsScrapedContent() {}
@@ -18,24 +21,26 @@ func (ScrapedStudio) IsScrapedContent() {}
 // A performer from a scraping operation...
 type ScrapedPerformer struct {
 	// Set if performer matched
-	StoredID     *string       `json:"stored_id"`
-	Name         *string       `json:"name"`
-	Gender       *string       `json:"gender"`
-	URL          *string       `json:"url"`
-	Twitter      *string       `json:"twitter"`
-	Instagram    *string       `json:"instagram"`
-	Birthdate    *string       `json:"birthdate"`
-	Ethnicity    *string       `json:"ethnicity"`
-	Country      *string       `json:"country"`
-	EyeColor     *string       `json:"eye_color"`
-	Height       *string       `json:"height"`
-	Measurements *string       `json:"measurements"`
-	FakeTits     *string       `json:"fake_tits"`
-	CareerLength *string       `json:"career_length"`
-	Tattoos      *string       `json:"tattoos"`
-	Piercings    *string       `json:"piercings"`
-	Aliases      *string       `json:"aliases"`
-	Tags         []*ScrapedTag `json:"tags"`
+	StoredID       *string       `json:"stored_id"`
+	Name           *string       `json:"name"`
+	Disambiguation *string       `json:"disambiguation"`
+	Gender         *string       `json:"gender"`
+	URL            *string       `json:"url"`
+	Twitter        *string       `json:"twitter"`
+	Instagram      *string       `json:"instagram"`
+	URLs           []string      `json:"urls"`
+	Birthdate      *string       `json:"birthdate"`
+	Ethnicity      *string       `json:"ethnicity"`
+	Country        *string       `json:"country"`
+	EyeColor       *string       `json:"eye_color"`
+	Height         *string       `json:"height"`
+	Measurements   *string       `json:"measurements"`
+	FakeTits       *string       `json:"fake_tits"`
+	CareerLength   *string       `json:"career_length"`
+	Tattoos        *string       `json:"tattoos"`
+	Piercings      *string       `json:"piercings"`
+	Aliases        *string       `json:"aliases"`
+	Tags           []*ScrapedTag `json:"tags"`
 	// This should be a base64 encoded data URL
 	Image        *string  `json:"image"`
 	Images       []string `json:"images"`
@@ -50,8 +55,9 @@ func (ScrapedPerformer) IsScrapedContent() {}
 
 type ScrapedTag struct {
 	// Set if tag matched
-	StoredID *string `json:"stored_id"`
-	Name     string  `json:"name"`
+	StoredID    *string `json:"stored_id"`
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
 }
 
 func (ScrapedTag) IsScrapedContent() {}
@@ -66,6 +72,7 @@ type ScrapedMovie struct {
 	Rating   *string        `json:"rating"`
 	Director *string        `json:"director"`
 	URL      *string        `json:"url"`
+	URLs     []string       `json:"urls"`
 	Synopsis *string        `json:"synopsis"`
 	Studio   *ScrapedStudio `json:"studio"`
 	// This should be a base64 encoded data URL
@@ -0,0 +1,38 @@
+package models
+
+const (
+	StashBoxSubmissionEntityScene     = "SCENE"
+	StashBoxSubmissionEntityPerformer = "PERFORMER"
+	StashBoxSubmissionEntityStudio    = "STUDIO"
+	StashBoxSubmissionEntityTag       = "TAG"
+
+	// StashBoxSubmissionTypeDraft is a draft of a new or updated object,
+	// which must be completed on the stash-box instance before it becomes
+	// an edit.
+	StashBoxSubmissionTypeDraft = "DRAFT"
+	// StashBoxSubmissionTypeEdit is an edit submitted directly for voting.
+	StashBoxSubmissionTypeEdit = "EDIT"
+)
+
+// StashBoxSubmission records a draft or edit submitted to a stash-box
+// instance.
+type StashBoxSubmission struct {
+	ID         int    `db:"id" json:"id"`
+	Endpoint   string `db:"endpoint" json:"endpoint"`
+	EntityType string `db:"entity_type" json:"entity_type"`
+	EntityID   int    `db:"entity_id" json:"entity_id"`
+	Type       string `db:"type" json:"type"`
+	// ID of the draft or edit on the stash-box instance
+	RemoteID  string          `db:"remote_id" json:"remote_id"`
+	CreatedAt SQLiteTimestamp `db:"created_at" json:"created_at"`
+}
+
+type StashBoxSubmissions []*StashBoxSubmission
+
+func (m *StashBoxSubmissions) Append(o interface{}) {
+	*m = append(*m, o.(*StashBoxSubmission))
+}
+
+func (m *StashBoxSubmissions) New() interface{} {
+	return &StashBoxSubmission{}
+}
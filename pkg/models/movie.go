@@ -26,6 +26,8 @@ type MovieFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom field values
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type MovieReader interface {
@@ -39,10 +41,12 @@ type MovieReader interface {
 	Query(ctx context.Context, movieFilter *MovieFilterType, findFilter *FindFilterType) ([]*Movie, int, error)
 	GetFrontImage(ctx context.Context, movieID int) ([]byte, error)
 	GetBackImage(ctx context.Context, movieID int) ([]byte, error)
+	URLLoader
 	FindByPerformerID(ctx context.Context, performerID int) ([]*Movie, error)
 	CountByPerformerID(ctx context.Context, performerID int) (int, error)
 	FindByStudioID(ctx context.Context, studioID int) ([]*Movie, error)
 	CountByStudioID(ctx context.Context, studioID int) (int, error)
+	CustomFieldsReader
 }
 
 type MovieWriter interface {
@@ -52,6 +56,8 @@ type MovieWriter interface {
 	Destroy(ctx context.Context, id int) error
 	UpdateImages(ctx context.Context, movieID int, frontImage []byte, backImage []byte) error
 	DestroyImages(ctx context.Context, movieID int) error
+	UpdateURLs(ctx context.Context, movieID int, urls []string) error
+	CustomFieldsWriter
 }
 
 type MovieReaderWriter interface {
@@ -10,7 +10,8 @@ import (
 type JSONPaths struct {
 	Metadata string
 
-	ScrapedFile string
+	ScrapedFile      string
+	CustomFieldsFile string
 
 	Performers string
 	Scenes     string
@@ -26,6 +27,7 @@ func newJSONPaths(baseDir string) *JSONPaths {
 	jp := JSONPaths{}
 	jp.Metadata = baseDir
 	jp.ScrapedFile = filepath.Join(baseDir, "scraped.json")
+	jp.CustomFieldsFile = filepath.Join(baseDir, "custom_fields.json")
 	jp.Performers = filepath.Join(baseDir, "performers")
 	jp.Scenes = filepath.Join(baseDir, "scenes")
 	jp.Images = filepath.Join(baseDir, "images")
@@ -61,12 +61,49 @@ type GenderCriterionInput struct {
 	Modifier CriterionModifier `json:"modifier"`
 }
 
+type PerformerUpdateInput struct {
+	ID             string      `json:"id"`
+	Name           *string     `json:"name"`
+	Disambiguation *string     `json:"disambiguation"`
+	URL            *string     `json:"url"`
+	Urls           []string    `json:"urls"`
+	Gender         *GenderEnum `json:"gender"`
+	Birthdate      *string     `json:"birthdate"`
+	Ethnicity      *string     `json:"ethnicity"`
+	Country        *string     `json:"country"`
+	EyeColor       *string     `json:"eye_color"`
+	Height         *string     `json:"height"`
+	HeightCm       *int        `json:"height_cm"`
+	Measurements   *string     `json:"measurements"`
+	FakeTits       *string     `json:"fake_tits"`
+	CareerLength   *string     `json:"career_length"`
+	Tattoos        *string     `json:"tattoos"`
+	Piercings      *string     `json:"piercings"`
+	Aliases        *string     `json:"aliases"`
+	Twitter        *string     `json:"twitter"`
+	Instagram      *string     `json:"instagram"`
+	Favorite       *bool       `json:"favorite"`
+	TagIds         []string    `json:"tag_ids"`
+	// This should be a URL or a base64 encoded data URL
+	Image         *string            `json:"image"`
+	StashIds      []*StashID         `json:"stash_ids"`
+	Rating        *int               `json:"rating"`
+	Rating100     *int               `json:"rating100"`
+	Details       *string            `json:"details"`
+	DeathDate     *string            `json:"death_date"`
+	HairColor     *string            `json:"hair_color"`
+	Weight        *int               `json:"weight"`
+	IgnoreAutoTag *bool              `json:"ignore_auto_tag"`
+	CustomFields  *CustomFieldsInput `json:"custom_fields"`
+}
+
 type PerformerFilterType struct {
-	And     *PerformerFilterType  `json:"AND"`
-	Or      *PerformerFilterType  `json:"OR"`
-	Not     *PerformerFilterType  `json:"NOT"`
-	Name    *StringCriterionInput `json:"name"`
-	Details *StringCriterionInput `json:"details"`
+	And            *PerformerFilterType  `json:"AND"`
+	Or             *PerformerFilterType  `json:"OR"`
+	Not            *PerformerFilterType  `json:"NOT"`
+	Name           *StringCriterionInput `json:"name"`
+	Disambiguation *StringCriterionInput `json:"disambiguation"`
+	Details        *StringCriterionInput `json:"details"`
 	// Filter by favorite
 	FilterFavorites *bool `json:"filter_favorites"`
 	// Filter by birth year
@@ -135,6 +172,8 @@ type PerformerFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom field values
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type PerformerFinder interface {
@@ -157,9 +196,14 @@ type PerformerReader interface {
 	// support the query needed
 	QueryForAutoTag(ctx context.Context, words []string) ([]*Performer, error)
 	Query(ctx context.Context, performerFilter *PerformerFilterType, findFilter *FindFilterType) ([]*Performer, int, error)
+	// GetImage returns the primary image of the performer.
 	GetImage(ctx context.Context, performerID int) ([]byte, error)
+	GetImages(ctx context.Context, performerID int) ([]*PerformerImage, error)
+	GetImageByID(ctx context.Context, performerID int, imageID int) ([]byte, error)
 	StashIDLoader
+	URLLoader
 	GetTagIDs(ctx context.Context, performerID int) ([]int, error)
+	CustomFieldsReader
 }
 
 type PerformerWriter interface {
@@ -167,10 +211,18 @@ type PerformerWriter interface {
 	UpdatePartial(ctx context.Context, id int, updatedPerformer PerformerPartial) (*Performer, error)
 	Update(ctx context.Context, updatedPerformer *Performer) error
 	Destroy(ctx context.Context, id int) error
+	// UpdateImage replaces the primary image of the performer.
 	UpdateImage(ctx context.Context, performerID int, image []byte) error
+	// DestroyImage removes the primary image of the performer.
 	DestroyImage(ctx context.Context, performerID int) error
+	AddImage(ctx context.Context, performerID int, image []byte, primary bool) (int, error)
+	DestroyImageByID(ctx context.Context, performerID int, imageID int) error
+	ReorderImages(ctx context.Context, performerID int, imageIDs []int) error
+	SetPrimaryImage(ctx context.Context, performerID int, imageID int) error
 	UpdateStashIDs(ctx context.Context, performerID int, stashIDs []StashID) error
+	UpdateURLs(ctx context.Context, performerID int, urls []string) error
 	UpdateTags(ctx context.Context, performerID int, tagIDs []int) error
+	CustomFieldsWriter
 }
 
 type PerformerReaderWriter interface {
@@ -30,6 +30,10 @@ type StashIDLoader interface {
 	GetStashIDs(ctx context.Context, relatedID int) ([]StashID, error)
 }
 
+type URLLoader interface {
+	GetURLs(ctx context.Context, relatedID int) ([]string, error)
+}
+
 type VideoFileLoader interface {
 	GetFiles(ctx context.Context, relatedID int) ([]*file.VideoFile, error)
 }
@@ -219,6 +223,75 @@ func (r *RelatedStashIDs) load(fn func() ([]StashID, error)) error {
 	return nil
 }
 
+// RelatedStrings represents an ordered list of related strings, such as URLs.
+type RelatedStrings struct {
+	list []string
+}
+
+// NewRelatedStrings returns a loaded RelatedStrings object with the provided values.
+// Loaded will return true when called on the returned object if the provided slice is not nil.
+func NewRelatedStrings(values []string) RelatedStrings {
+	return RelatedStrings{
+		list: values,
+	}
+}
+
+// Loaded returns true if the relationship has been loaded.
+func (r RelatedStrings) Loaded() bool {
+	return r.list != nil
+}
+
+func (r RelatedStrings) mustLoaded() {
+	if !r.Loaded() {
+		panic("list has not been loaded")
+	}
+}
+
+// List returns the related values. Panics if the relationship has not been loaded.
+func (r RelatedStrings) List() []string {
+	r.mustLoaded()
+
+	return r.list
+}
+
+// First returns the first value, or an empty string if there are no values.
+// Panics if the relationship has not been loaded.
+func (r RelatedStrings) First() string {
+	r.mustLoaded()
+
+	if len(r.list) == 0 {
+		return ""
+	}
+
+	return r.list[0]
+}
+
+// Add adds the provided values to the list. Panics if the relationship has not been loaded.
+func (r *RelatedStrings) Add(values ...string) {
+	r.mustLoaded()
+
+	r.list = append(r.list, values...)
+}
+
+func (r *RelatedStrings) load(fn func() ([]string, error)) error {
+	if r.Loaded() {
+		return nil
+	}
+
+	values, err := fn()
+	if err != nil {
+		return err
+	}
+
+	if values == nil {
+		values = []string{}
+	}
+
+	r.list = values
+
+	return nil
+}
+
 type RelatedVideoFiles struct {
 	primaryFile   *file.VideoFile
 	files         []*file.VideoFile
@@ -28,6 +28,14 @@ type Repository struct {
 	Studio      StudioReaderWriter
 	Tag         TagReaderWriter
 	SavedFilter SavedFilterReaderWriter
+
+	ScenePendingChange ScenePendingChangeReaderWriter
+	JobRecord          JobRecordReaderWriter
+	JobCheckpoint      JobCheckpointReaderWriter
+	StashBoxSubmission StashBoxSubmissionReaderWriter
+	SceneStashBoxCheck SceneStashBoxCheckReaderWriter
+
+	CustomFieldDefinition CustomFieldDefinitionReaderWriter
 }
 
 func (r *Repository) WithTxn(ctx context.Context, fn txn.TxnFunc) error {
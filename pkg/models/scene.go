@@ -69,6 +69,8 @@ type SceneFilterType struct {
 	PerformerCount *IntCriterionInput `json:"performer_count"`
 	// Filter by StashID
 	StashID *StringCriterionInput `json:"stash_id"`
+	// Filter by the result of the last stash-box fingerprint check
+	StashBoxFingerprintStatus *StashBoxFingerprintStatusCriterionInput `json:"stash_box_fingerprint_status"`
 	// Filter by url
 	URL *StringCriterionInput `json:"url"`
 	// Filter by interactive
@@ -83,6 +85,8 @@ type SceneFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom field values
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type SceneQueryOptions struct {
@@ -145,6 +149,7 @@ type SceneReader interface {
 	FindByGalleryID(ctx context.Context, performerID int) ([]*Scene, error)
 	FindDuplicates(ctx context.Context, distance int) ([][]*Scene, error)
 
+	URLLoader
 	GalleryIDLoader
 	PerformerIDLoader
 	TagIDLoader
@@ -168,6 +173,7 @@ type SceneReader interface {
 	All(ctx context.Context) ([]*Scene, error)
 	Query(ctx context.Context, options SceneQueryOptions) (*SceneQueryResult, error)
 	GetCover(ctx context.Context, sceneID int) ([]byte, error)
+	CustomFieldsReader
 }
 
 type SceneWriter interface {
@@ -180,6 +186,7 @@ type SceneWriter interface {
 	Destroy(ctx context.Context, id int) error
 	UpdateCover(ctx context.Context, sceneID int, cover []byte) error
 	DestroyCover(ctx context.Context, sceneID int) error
+	CustomFieldsWriter
 }
 
 type SceneReaderWriter interface {
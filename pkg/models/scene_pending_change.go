@@ -0,0 +1,20 @@
+package models
+
+import "context"
+
+type ScenePendingChangeReader interface {
+	All(ctx context.Context) ([]*ScenePendingChange, error)
+	Find(ctx context.Context, id int) (*ScenePendingChange, error)
+	FindBySceneID(ctx context.Context, sceneID int) ([]*ScenePendingChange, error)
+}
+
+type ScenePendingChangeWriter interface {
+	Create(ctx context.Context, obj ScenePendingChange) (*ScenePendingChange, error)
+	Destroy(ctx context.Context, id int) error
+	DestroyBySceneID(ctx context.Context, sceneID int) error
+}
+
+type ScenePendingChangeReaderWriter interface {
+	ScenePendingChangeReader
+	ScenePendingChangeWriter
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type SceneStashBoxCheckReader interface {
+	// FindBySceneID returns the checks of the scene against the stash-box
+	// scenes that it is currently linked to.
+	FindBySceneID(ctx context.Context, sceneID int) ([]*SceneStashBoxCheck, error)
+	// LastCheckedAt returns the time of the most recent check against the
+	// endpoint, or nil if no scenes have been checked.
+	LastCheckedAt(ctx context.Context, endpoint string) (*time.Time, error)
+}
+
+type SceneStashBoxCheckWriter interface {
+	// Set replaces the check of the scene against the check endpoint.
+	Set(ctx context.Context, check SceneStashBoxCheck) error
+	Destroy(ctx context.Context, sceneID int, endpoint string) error
+}
+
+type SceneStashBoxCheckReaderWriter interface {
+	SceneStashBoxCheckReader
+	SceneStashBoxCheckWriter
+}
@@ -0,0 +1,26 @@
+package models
+
+import "context"
+
+// StashBoxSubmissionFilter filters the submission history. Nil fields are
+// not filtered on.
+type StashBoxSubmissionFilter struct {
+	Endpoint   *string
+	EntityType *string
+	EntityID   *int
+}
+
+type StashBoxSubmissionReader interface {
+	// Query returns the submissions matching the provided filter, most
+	// recent first.
+	Query(ctx context.Context, filter StashBoxSubmissionFilter, findFilter *FindFilterType) ([]*StashBoxSubmission, int, error)
+}
+
+type StashBoxSubmissionWriter interface {
+	Create(ctx context.Context, obj StashBoxSubmission) (*StashBoxSubmission, error)
+}
+
+type StashBoxSubmissionReaderWriter interface {
+	StashBoxSubmissionReader
+	StashBoxSubmissionWriter
+}
@@ -34,6 +34,24 @@ type StudioFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom field values
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
+}
+
+type StudioUpdateInput struct {
+	ID       string  `json:"id"`
+	Name     *string `json:"name"`
+	URL      *string `json:"url"`
+	ParentID *string `json:"parent_id"`
+	// This should be a URL or a base64 encoded data URL
+	Image         *string            `json:"image"`
+	StashIds      []*StashID         `json:"stash_ids"`
+	Rating        *int               `json:"rating"`
+	Rating100     *int               `json:"rating100"`
+	Details       *string            `json:"details"`
+	Aliases       []string           `json:"aliases"`
+	IgnoreAutoTag *bool              `json:"ignore_auto_tag"`
+	CustomFields  *CustomFieldsInput `json:"custom_fields"`
 }
 
 type StudioFinder interface {
@@ -46,6 +64,9 @@ type StudioReader interface {
 	FindChildren(ctx context.Context, id int) ([]*Studio, error)
 	FindByName(ctx context.Context, name string, nocase bool) (*Studio, error)
 	FindByStashID(ctx context.Context, stashID StashID) ([]*Studio, error)
+	// FindByStashIDStatus returns the studios that have, or do not have, a
+	// stash ID for the provided endpoint.
+	FindByStashIDStatus(ctx context.Context, hasStashID bool, stashboxEndpoint string) ([]*Studio, error)
 	Count(ctx context.Context) (int, error)
 	All(ctx context.Context) ([]*Studio, error)
 	// TODO - this interface is temporary until the filter schema can fully
@@ -56,6 +77,7 @@ type StudioReader interface {
 	HasImage(ctx context.Context, studioID int) (bool, error)
 	StashIDLoader
 	GetAliases(ctx context.Context, studioID int) ([]string, error)
+	CustomFieldsReader
 }
 
 type StudioWriter interface {
@@ -67,6 +89,7 @@ type StudioWriter interface {
 	DestroyImage(ctx context.Context, studioID int) error
 	UpdateStashIDs(ctx context.Context, studioID int, stashIDs []StashID) error
 	UpdateAliases(ctx context.Context, studioID int, aliases []string) error
+	CustomFieldsWriter
 }
 
 type StudioReaderWriter interface {
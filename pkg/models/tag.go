@@ -38,6 +38,8 @@ type TagFilterType struct {
 	CreatedAt *TimestampCriterionInput `json:"created_at"`
 	// Filter by updated at
 	UpdatedAt *TimestampCriterionInput `json:"updated_at"`
+	// Filter by custom field values
+	CustomFields []CustomFieldCriterionInput `json:"custom_fields"`
 }
 
 type TagFinder interface {
@@ -66,6 +68,7 @@ type TagReader interface {
 	GetAliases(ctx context.Context, tagID int) ([]string, error)
 	FindAllAncestors(ctx context.Context, tagID int, excludeIDs []int) ([]*TagPath, error)
 	FindAllDescendants(ctx context.Context, tagID int, excludeIDs []int) ([]*TagPath, error)
+	CustomFieldsReader
 }
 
 type TagWriter interface {
@@ -79,6 +82,7 @@ type TagWriter interface {
 	Merge(ctx context.Context, source []int, destination int) error
 	UpdateParentTags(ctx context.Context, tagID int, parentIDs []int) error
 	UpdateChildTags(ctx context.Context, tagID int, parentIDs []int) error
+	CustomFieldsWriter
 }
 
 type TagReaderWriter interface {
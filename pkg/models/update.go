@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
 type RelationshipUpdateMode string
@@ -63,3 +64,32 @@ func (u *UpdateIDs) IDStrings() []string {
 
 	return intslice.IntSliceToStringSlice(u.IDs)
 }
+
+// UpdateStrings represents an update to an ordered list of strings, such as
+// URLs. Added values are appended to the end of the list.
+type UpdateStrings struct {
+	Values []string               `json:"values"`
+	Mode   RelationshipUpdateMode `json:"mode"`
+}
+
+// Apply returns the result of applying the update to the existing values.
+func (u *UpdateStrings) Apply(existing []string) []string {
+	if u == nil {
+		return existing
+	}
+
+	switch u.Mode {
+	case RelationshipUpdateModeAdd:
+		return stringslice.StrAppendUniques(append([]string(nil), existing...), u.Values)
+	case RelationshipUpdateModeRemove:
+		var ret []string
+		for _, v := range existing {
+			if !stringslice.StrInclude(u.Values, v) {
+				ret = append(ret, v)
+			}
+		}
+		return ret
+	}
+
+	return stringslice.StrAppendUniques(nil, u.Values)
+}
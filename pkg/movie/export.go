@@ -11,13 +11,14 @@ import (
 	"github.com/stashapp/stash/pkg/utils"
 )
 
-type ImageGetter interface {
+type ImageURLGetter interface {
 	GetFrontImage(ctx context.Context, movieID int) ([]byte, error)
 	GetBackImage(ctx context.Context, movieID int) ([]byte, error)
+	models.URLLoader
 }
 
 // ToJSON converts a Movie into its JSON equivalent.
-func ToJSON(ctx context.Context, reader ImageGetter, studioReader studio.Finder, movie *models.Movie) (*jsonschema.Movie, error) {
+func ToJSON(ctx context.Context, reader ImageURLGetter, studioReader studio.Finder, movie *models.Movie) (*jsonschema.Movie, error) {
 	newMovieJSON := jsonschema.Movie{
 		CreatedAt: json.JSONTime{Time: movie.CreatedAt.Timestamp},
 		UpdatedAt: json.JSONTime{Time: movie.UpdatedAt.Timestamp},
@@ -47,9 +48,11 @@ func ToJSON(ctx context.Context, reader ImageGetter, studioReader studio.Finder,
 		newMovieJSON.Synopsis = movie.Synopsis.String
 	}
 
-	if movie.URL.Valid {
-		newMovieJSON.URL = movie.URL.String
+	urls, err := reader.GetURLs(ctx, movie.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting movie urls: %v", err)
 	}
+	newMovieJSON.URLs = urls
 
 	if movie.StudioID.Valid {
 		studio, err := studioReader.Find(ctx, int(movie.StudioID.Int64))
@@ -80,7 +80,6 @@ func createFullMovie(id int, studioID int) models.Movie {
 		},
 		Director: models.NullString(director),
 		Synopsis: models.NullString(synopsis),
-		URL:      models.NullString(url),
 		StudioID: sql.NullInt64{
 			Int64: int64(studioID),
 			Valid: true,
@@ -115,7 +114,7 @@ func createFullJSONMovie(studio, frontImage, backImage string) *jsonschema.Movie
 		Duration:   duration,
 		Director:   director,
 		Synopsis:   synopsis,
-		URL:        url,
+		URLs:       []string{url},
 		Studio:     studio,
 		FrontImage: frontImage,
 		BackImage:  backImage,
@@ -202,6 +201,11 @@ func TestToJSON(t *testing.T) {
 	mockMovieReader.On("GetBackImage", testCtx, errFrontImageID).Return(backImageBytes, nil).Maybe()
 	mockMovieReader.On("GetBackImage", testCtx, errStudioMovieID).Return(backImageBytes, nil).Maybe()
 
+	mockMovieReader.On("GetURLs", testCtx, emptyID).Return(nil, nil).Once()
+	for _, id := range []int{movieID, missingStudioMovieID, errFrontImageID, errBackImageID, errStudioMovieID} {
+		mockMovieReader.On("GetURLs", testCtx, id).Return([]string{url}, nil).Once()
+	}
+
 	mockStudioReader := &mocks.StudioReaderWriter{}
 
 	studioErr := errors.New("error getting studio")
@@ -16,6 +16,8 @@ type NameFinderCreatorUpdater interface {
 	NameFinderCreator
 	UpdateFull(ctx context.Context, updatedMovie models.Movie) (*models.Movie, error)
 	UpdateImages(ctx context.Context, movieID int, frontImage []byte, backImage []byte) error
+	UpdateURLs(ctx context.Context, movieID int, urls []string) error
+	models.CustomFieldsWriter
 }
 
 type Importer struct {
@@ -25,6 +27,7 @@ type Importer struct {
 	MissingRefBehaviour models.ImportMissingRefEnum
 
 	movie          models.Movie
+	urls           []string
 	frontImageData []byte
 	backImageData  []byte
 }
@@ -32,6 +35,12 @@ type Importer struct {
 func (i *Importer) PreImport(ctx context.Context) error {
 	i.movie = i.movieJSONToMovie(i.Input)
 
+	i.urls = i.Input.URLs
+	if len(i.urls) == 0 && i.Input.URL != "" {
+		// exported before movies supported multiple urls
+		i.urls = []string{i.Input.URL}
+	}
+
 	if err := i.populateStudio(ctx); err != nil {
 		return err
 	}
@@ -63,7 +72,6 @@ func (i *Importer) movieJSONToMovie(movieJSON jsonschema.Movie) models.Movie {
 		Date:      models.SQLiteDate{String: movieJSON.Date, Valid: true},
 		Director:  sql.NullString{String: movieJSON.Director, Valid: true},
 		Synopsis:  sql.NullString{String: movieJSON.Synopsis, Valid: true},
-		URL:       sql.NullString{String: movieJSON.URL, Valid: true},
 		CreatedAt: models.SQLiteTimestamp{Timestamp: movieJSON.CreatedAt.GetTime()},
 		UpdatedAt: models.SQLiteTimestamp{Timestamp: movieJSON.UpdatedAt.GetTime()},
 	}
@@ -125,12 +133,24 @@ func (i *Importer) createStudio(ctx context.Context, name string) (int, error) {
 }
 
 func (i *Importer) PostImport(ctx context.Context, id int) error {
+	if len(i.urls) > 0 {
+		if err := i.ReaderWriter.UpdateURLs(ctx, id, i.urls); err != nil {
+			return fmt.Errorf("error setting movie urls: %v", err)
+		}
+	}
+
 	if len(i.frontImageData) > 0 {
 		if err := i.ReaderWriter.UpdateImages(ctx, id, i.frontImageData, i.backImageData); err != nil {
 			return fmt.Errorf("error setting movie images: %v", err)
 		}
 	}
 
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{Full: i.Input.CustomFields}); err != nil {
+			return fmt.Errorf("error setting custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
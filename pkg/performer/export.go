@@ -13,32 +13,33 @@ import (
 
 type ImageStashIDGetter interface {
 	GetImage(ctx context.Context, performerID int) ([]byte, error)
+	GetImages(ctx context.Context, performerID int) ([]*models.PerformerImage, error)
+	GetImageByID(ctx context.Context, performerID int, imageID int) ([]byte, error)
 	models.StashIDLoader
+	models.URLLoader
 }
 
 // ToJSON converts a Performer object into its JSON equivalent.
 func ToJSON(ctx context.Context, reader ImageStashIDGetter, performer *models.Performer) (*jsonschema.Performer, error) {
 	newPerformerJSON := jsonschema.Performer{
-		Name:          performer.Name,
-		Gender:        performer.Gender.String(),
-		URL:           performer.URL,
-		Ethnicity:     performer.Ethnicity,
-		Country:       performer.Country,
-		EyeColor:      performer.EyeColor,
-		Measurements:  performer.Measurements,
-		FakeTits:      performer.FakeTits,
-		CareerLength:  performer.CareerLength,
-		Tattoos:       performer.Tattoos,
-		Piercings:     performer.Piercings,
-		Aliases:       performer.Aliases,
-		Twitter:       performer.Twitter,
-		Instagram:     performer.Instagram,
-		Favorite:      performer.Favorite,
-		Details:       performer.Details,
-		HairColor:     performer.HairColor,
-		IgnoreAutoTag: performer.IgnoreAutoTag,
-		CreatedAt:     json.JSONTime{Time: performer.CreatedAt},
-		UpdatedAt:     json.JSONTime{Time: performer.UpdatedAt},
+		Name:           performer.Name,
+		Disambiguation: performer.Disambiguation,
+		Gender:         performer.Gender.String(),
+		Ethnicity:      performer.Ethnicity,
+		Country:        performer.Country,
+		EyeColor:       performer.EyeColor,
+		Measurements:   performer.Measurements,
+		FakeTits:       performer.FakeTits,
+		CareerLength:   performer.CareerLength,
+		Tattoos:        performer.Tattoos,
+		Piercings:      performer.Piercings,
+		Aliases:        performer.Aliases,
+		Favorite:       performer.Favorite,
+		Details:        performer.Details,
+		HairColor:      performer.HairColor,
+		IgnoreAutoTag:  performer.IgnoreAutoTag,
+		CreatedAt:      json.JSONTime{Time: performer.CreatedAt},
+		UpdatedAt:      json.JSONTime{Time: performer.UpdatedAt},
 	}
 
 	if performer.Birthdate != nil {
@@ -68,6 +69,30 @@ func ToJSON(ctx context.Context, reader ImageStashIDGetter, performer *models.Pe
 		newPerformerJSON.Image = utils.GetBase64StringFromData(image)
 	}
 
+	images, err := reader.GetImages(ctx, performer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting performer images: %v", err)
+	}
+
+	for _, i := range images {
+		// the primary image is exported as the image field
+		if i.Primary {
+			continue
+		}
+
+		data, err := reader.GetImageByID(ctx, performer.ID, i.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error getting performer image %d: %v", i.ID, err)
+		}
+		newPerformerJSON.Images = append(newPerformerJSON.Images, utils.GetBase64StringFromData(data))
+	}
+
+	urls, err := reader.GetURLs(ctx, performer.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error getting performer urls: %v", err)
+	}
+	newPerformerJSON.URLs = urls
+
 	stashIDs, _ := reader.GetStashIDs(ctx, performer.ID)
 	var ret []models.StashID
 	for _, stashID := range stashIDs {
@@ -31,11 +31,9 @@ const (
 	eyeColor      = "eyeColor"
 	fakeTits      = "fakeTits"
 	gender        = "gender"
-	instagram     = "instagram"
 	measurements  = "measurements"
 	piercings     = "piercings"
 	tattoos       = "tattoos"
-	twitter       = "twitter"
 	details       = "details"
 	hairColor     = "hairColor"
 
@@ -49,6 +47,12 @@ var (
 )
 
 var imageBytes = []byte("imageBytes")
+var galleryImageBytes = []byte("galleryImageBytes")
+
+const (
+	primaryImageID = 10
+	galleryImageID = 11
+)
 
 var stashID = models.StashID{
 	StashID:  "StashID",
@@ -59,6 +63,7 @@ var stashIDs = []models.StashID{
 }
 
 const image = "aW1hZ2VCeXRlcw=="
+const galleryImage = "Z2FsbGVyeUltYWdlQnl0ZXM="
 
 var birthDate = models.NewDate("2001-01-01")
 var deathDate = models.NewDate("2021-02-02")
@@ -73,7 +78,6 @@ func createFullPerformer(id int, name string) *models.Performer {
 		ID:            id,
 		Name:          name,
 		Checksum:      md5.FromString(name),
-		URL:           url,
 		Aliases:       aliases,
 		Birthdate:     &birthDate,
 		CareerLength:  careerLength,
@@ -84,11 +88,9 @@ func createFullPerformer(id int, name string) *models.Performer {
 		Favorite:      true,
 		Gender:        gender,
 		Height:        &height,
-		Instagram:     instagram,
 		Measurements:  measurements,
 		Piercings:     piercings,
 		Tattoos:       tattoos,
-		Twitter:       twitter,
 		CreatedAt:     createTime,
 		UpdatedAt:     updateTime,
 		Rating:        &rating,
@@ -111,7 +113,7 @@ func createEmptyPerformer(id int) models.Performer {
 func createFullJSONPerformer(name string, image string) *jsonschema.Performer {
 	return &jsonschema.Performer{
 		Name:         name,
-		URL:          url,
+		URLs:         []string{url},
 		Aliases:      aliases,
 		Birthdate:    birthDate.String(),
 		CareerLength: careerLength,
@@ -122,11 +124,9 @@ func createFullJSONPerformer(name string, image string) *jsonschema.Performer {
 		Favorite:     true,
 		Gender:       gender,
 		Height:       strconv.Itoa(height),
-		Instagram:    instagram,
 		Measurements: measurements,
 		Piercings:    piercings,
 		Tattoos:      tattoos,
-		Twitter:      twitter,
 		CreatedAt: json.JSONTime{
 			Time: createTime,
 		},
@@ -135,6 +135,7 @@ func createFullJSONPerformer(name string, image string) *jsonschema.Performer {
 		},
 		Rating:    rating,
 		Image:     image,
+		Images:    []string{galleryImage},
 		Details:   details,
 		DeathDate: deathDate.String(),
 		HairColor: hairColor,
@@ -196,6 +197,16 @@ func TestToJSON(t *testing.T) {
 	mockPerformerReader.On("GetImage", testCtx, noImageID).Return(nil, nil).Once()
 	mockPerformerReader.On("GetImage", testCtx, errImageID).Return(nil, imageErr).Once()
 
+	mockPerformerReader.On("GetImages", testCtx, performerID).Return([]*models.PerformerImage{
+		{ID: primaryImageID, PerformerID: performerID, Primary: true},
+		{ID: galleryImageID, PerformerID: performerID, Position: 1},
+	}, nil).Once()
+	mockPerformerReader.On("GetImages", testCtx, noImageID).Return(nil, nil).Once()
+	mockPerformerReader.On("GetImageByID", testCtx, performerID, galleryImageID).Return(galleryImageBytes, nil).Once()
+
+	mockPerformerReader.On("GetURLs", testCtx, performerID).Return([]string{url}, nil).Once()
+	mockPerformerReader.On("GetURLs", testCtx, noImageID).Return(nil, nil).Once()
+
 	mockPerformerReader.On("GetStashIDs", testCtx, performerID).Return(stashIDs, nil).Once()
 	mockPerformerReader.On("GetStashIDs", testCtx, noImageID).Return(nil, nil).Once()
 
@@ -20,7 +20,10 @@ type NameFinderCreatorUpdater interface {
 	Update(ctx context.Context, updatedPerformer *models.Performer) error
 	UpdateTags(ctx context.Context, performerID int, tagIDs []int) error
 	UpdateImage(ctx context.Context, performerID int, image []byte) error
+	AddImage(ctx context.Context, performerID int, image []byte, primary bool) (int, error)
 	UpdateStashIDs(ctx context.Context, performerID int, stashIDs []models.StashID) error
+	UpdateURLs(ctx context.Context, performerID int, urls []string) error
+	models.CustomFieldsWriter
 }
 
 type Importer struct {
@@ -31,13 +34,16 @@ type Importer struct {
 
 	ID        int
 	performer models.Performer
+	urls      []string
 	imageData []byte
+	images    [][]byte
 
 	tags []*models.Tag
 }
 
 func (i *Importer) PreImport(ctx context.Context) error {
 	i.performer = performerJSONToPerformer(i.Input)
+	i.urls = performerJSONURLs(i.Input)
 
 	if err := i.populateTags(ctx); err != nil {
 		return err
@@ -51,6 +57,14 @@ func (i *Importer) PreImport(ctx context.Context) error {
 		}
 	}
 
+	for _, image := range i.Input.Images {
+		data, err := utils.ProcessBase64Image(image)
+		if err != nil {
+			return fmt.Errorf("invalid image: %v", err)
+		}
+		i.images = append(i.images, data)
+	}
+
 	return nil
 }
 
@@ -130,18 +144,37 @@ func (i *Importer) PostImport(ctx context.Context, id int) error {
 		}
 	}
 
+	if len(i.urls) > 0 {
+		if err := i.ReaderWriter.UpdateURLs(ctx, id, i.urls); err != nil {
+			return fmt.Errorf("error setting performer urls: %v", err)
+		}
+	}
+
 	if len(i.imageData) > 0 {
 		if err := i.ReaderWriter.UpdateImage(ctx, id, i.imageData); err != nil {
 			return fmt.Errorf("error setting performer image: %v", err)
 		}
 	}
 
+	for _, image := range i.images {
+		const primary = false
+		if _, err := i.ReaderWriter.AddImage(ctx, id, image, primary); err != nil {
+			return fmt.Errorf("error adding performer image: %v", err)
+		}
+	}
+
 	if len(i.Input.StashIDs) > 0 {
 		if err := i.ReaderWriter.UpdateStashIDs(ctx, id, i.Input.StashIDs); err != nil {
 			return fmt.Errorf("error setting stash id: %v", err)
 		}
 	}
 
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{Full: i.Input.CustomFields}); err != nil {
+			return fmt.Errorf("error setting custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -150,14 +183,14 @@ func (i *Importer) Name() string {
 }
 
 func (i *Importer) FindExistingID(ctx context.Context) (*int, error) {
-	const nocase = false
-	existing, err := i.ReaderWriter.FindByNames(ctx, []string{i.Name()}, nocase)
+	// performers sharing a name are distinguished by their disambiguation
+	existing, err := ByNameAndDisambiguation(ctx, i.ReaderWriter, i.Name(), i.Input.Disambiguation)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(existing) > 0 {
-		id := existing[0].ID
+	if existing != nil {
+		id := existing.ID
 		return &id, nil
 	}
 
@@ -189,27 +222,25 @@ func performerJSONToPerformer(performerJSON jsonschema.Performer) models.Perform
 	checksum := md5.FromString(performerJSON.Name)
 
 	newPerformer := models.Performer{
-		Name:          performerJSON.Name,
-		Checksum:      checksum,
-		Gender:        models.GenderEnum(performerJSON.Gender),
-		URL:           performerJSON.URL,
-		Ethnicity:     performerJSON.Ethnicity,
-		Country:       performerJSON.Country,
-		EyeColor:      performerJSON.EyeColor,
-		Measurements:  performerJSON.Measurements,
-		FakeTits:      performerJSON.FakeTits,
-		CareerLength:  performerJSON.CareerLength,
-		Tattoos:       performerJSON.Tattoos,
-		Piercings:     performerJSON.Piercings,
-		Aliases:       performerJSON.Aliases,
-		Twitter:       performerJSON.Twitter,
-		Instagram:     performerJSON.Instagram,
-		Details:       performerJSON.Details,
-		HairColor:     performerJSON.HairColor,
-		Favorite:      performerJSON.Favorite,
-		IgnoreAutoTag: performerJSON.IgnoreAutoTag,
-		CreatedAt:     performerJSON.CreatedAt.GetTime(),
-		UpdatedAt:     performerJSON.UpdatedAt.GetTime(),
+		Name:           performerJSON.Name,
+		Disambiguation: performerJSON.Disambiguation,
+		Checksum:       checksum,
+		Gender:         models.GenderEnum(performerJSON.Gender),
+		Ethnicity:      performerJSON.Ethnicity,
+		Country:        performerJSON.Country,
+		EyeColor:       performerJSON.EyeColor,
+		Measurements:   performerJSON.Measurements,
+		FakeTits:       performerJSON.FakeTits,
+		CareerLength:   performerJSON.CareerLength,
+		Tattoos:        performerJSON.Tattoos,
+		Piercings:      performerJSON.Piercings,
+		Aliases:        performerJSON.Aliases,
+		Details:        performerJSON.Details,
+		HairColor:      performerJSON.HairColor,
+		Favorite:       performerJSON.Favorite,
+		IgnoreAutoTag:  performerJSON.IgnoreAutoTag,
+		CreatedAt:      performerJSON.CreatedAt.GetTime(),
+		UpdatedAt:      performerJSON.UpdatedAt.GetTime(),
 	}
 
 	if performerJSON.Birthdate != "" {
@@ -247,3 +278,25 @@ func performerJSONToPerformer(performerJSON jsonschema.Performer) models.Perform
 
 	return newPerformer
 }
+
+// performerJSONURLs returns the urls of the performer, including the url,
+// twitter and instagram fields of files exported before performers supported
+// multiple urls.
+func performerJSONURLs(performerJSON jsonschema.Performer) []string {
+	if len(performerJSON.URLs) > 0 {
+		return performerJSON.URLs
+	}
+
+	var ret []string
+	if performerJSON.URL != "" {
+		ret = append(ret, performerJSON.URL)
+	}
+	if performerJSON.Twitter != "" {
+		ret = append(ret, utils.URLFromHandle(performerJSON.Twitter, utils.TwitterURL))
+	}
+	if performerJSON.Instagram != "" {
+		ret = append(ret, utils.URLFromHandle(performerJSON.Instagram, utils.InstagramURL))
+	}
+
+	return ret
+}
@@ -18,12 +18,14 @@ import (
 const invalidImage = "aW1hZ2VCeXRlcw&&"
 
 const (
-	existingPerformerID = 100
-	existingTagID       = 105
-	errTagsID           = 106
+	existingPerformerID      = 100
+	disambiguatedPerformerID = 101
+	existingTagID            = 105
+	errTagsID                = 106
 
 	existingPerformerName = "existingPerformerName"
 	performerNameErr      = "performerNameErr"
+	disambiguation        = "disambiguation"
 
 	existingTagName = "existingTagName"
 	existingTagErr  = "existingTagErr"
@@ -62,6 +64,8 @@ func TestImporterPreImport(t *testing.T) {
 	expectedPerformer := *createFullPerformer(0, performerName)
 	expectedPerformer.Checksum = md5.FromString(performerName)
 	assert.Equal(t, expectedPerformer, i.performer)
+	assert.Equal(t, imageBytes, i.imageData)
+	assert.Equal(t, [][]byte{galleryImageBytes}, i.images)
 }
 
 func TestImporterPreImportWithTag(t *testing.T) {
@@ -149,6 +153,49 @@ func TestImporterPreImportWithMissingTagCreateErr(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestImporterPreImportLegacyURLs(t *testing.T) {
+	tests := []struct {
+		name  string
+		input jsonschema.Performer
+		want  []string
+	}{
+		{
+			"urls",
+			jsonschema.Performer{
+				URLs: []string{url},
+				URL:  "ignored",
+			},
+			[]string{url},
+		},
+		{
+			"legacy fields",
+			jsonschema.Performer{
+				URL:       url,
+				Twitter:   "handle",
+				Instagram: "https://www.instagram.com/handle",
+			},
+			[]string{url, "https://twitter.com/handle", "https://www.instagram.com/handle"},
+		},
+		{
+			"none",
+			jsonschema.Performer{},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := Importer{
+				Input: tt.input,
+			}
+			i.Input.Name = performerName
+
+			err := i.PreImport(testCtx)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, i.urls)
+		})
+	}
+}
+
 func TestImporterPostImport(t *testing.T) {
 	readerWriter := &mocks.PerformerReaderWriter{}
 
@@ -171,6 +218,31 @@ func TestImporterPostImport(t *testing.T) {
 	readerWriter.AssertExpectations(t)
 }
 
+func TestImporterPostImportImages(t *testing.T) {
+	readerWriter := &mocks.PerformerReaderWriter{}
+
+	i := Importer{
+		ReaderWriter: readerWriter,
+		imageData:    imageBytes,
+		images:       [][]byte{galleryImageBytes},
+	}
+
+	addImageErr := errors.New("AddImage error")
+
+	readerWriter.On("UpdateImage", testCtx, performerID, imageBytes).Return(nil).Once()
+	readerWriter.On("AddImage", testCtx, performerID, galleryImageBytes, false).Return(galleryImageID, nil).Once()
+	readerWriter.On("UpdateImage", testCtx, errImageID, imageBytes).Return(nil).Once()
+	readerWriter.On("AddImage", testCtx, errImageID, galleryImageBytes, false).Return(0, addImageErr).Once()
+
+	err := i.PostImport(testCtx, performerID)
+	assert.Nil(t, err)
+
+	err = i.PostImport(testCtx, errImageID)
+	assert.NotNil(t, err)
+
+	readerWriter.AssertExpectations(t)
+}
+
 func TestImporterFindExistingID(t *testing.T) {
 	readerWriter := &mocks.PerformerReaderWriter{}
 
@@ -187,7 +259,11 @@ func TestImporterFindExistingID(t *testing.T) {
 		{
 			ID: existingPerformerID,
 		},
-	}, nil).Once()
+		{
+			ID:             disambiguatedPerformerID,
+			Disambiguation: disambiguation,
+		},
+	}, nil).Times(3)
 	readerWriter.On("FindByNames", testCtx, []string{performerNameErr}, false).Return(nil, errFindByNames).Once()
 
 	id, err := i.FindExistingID(testCtx)
@@ -199,6 +275,19 @@ func TestImporterFindExistingID(t *testing.T) {
 	assert.Equal(t, existingPerformerID, *id)
 	assert.Nil(t, err)
 
+	// performers sharing a name are matched by disambiguation
+	i.Input.Disambiguation = disambiguation
+	id, err = i.FindExistingID(testCtx)
+	assert.Equal(t, disambiguatedPerformerID, *id)
+	assert.Nil(t, err)
+
+	i.Input.Disambiguation = "other"
+	id, err = i.FindExistingID(testCtx)
+	assert.Nil(t, id)
+	assert.Nil(t, err)
+
+	i.Input.Disambiguation = ""
+
 	i.Input.Name = performerNameErr
 	id, err = i.FindExistingID(testCtx)
 	assert.Nil(t, id)
@@ -6,7 +6,30 @@ import (
 	"github.com/stashapp/stash/pkg/models"
 )
 
-type NameFinderCreator interface {
+type NameFinder interface {
 	FindByNames(ctx context.Context, names []string, nocase bool) ([]*models.Performer, error)
+}
+
+type NameFinderCreator interface {
+	NameFinder
 	Create(ctx context.Context, newPerformer *models.Performer) error
 }
+
+// ByNameAndDisambiguation returns the performer with the provided name and
+// disambiguation, or nil if no such performer exists. Names are matched case
+// sensitively.
+func ByNameAndDisambiguation(ctx context.Context, qb NameFinder, name string, disambiguation string) (*models.Performer, error) {
+	const nocase = false
+	performers, err := qb.FindByNames(ctx, []string{name}, nocase)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range performers {
+		if p.Disambiguation == disambiguation {
+			return p, nil
+		}
+	}
+
+	return nil, nil
+}
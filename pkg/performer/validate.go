@@ -1,12 +1,44 @@
 package performer
 
 import (
+	"context"
 	"errors"
+	"fmt"
 
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/utils"
 )
 
+type NameExistsError struct {
+	Name           string
+	Disambiguation string
+}
+
+func (e *NameExistsError) Error() string {
+	if e.Disambiguation != "" {
+		return fmt.Sprintf("performer with name '%s' and disambiguation '%s' already exists", e.Name, e.Disambiguation)
+	}
+	return fmt.Sprintf("performer with name '%s' already exists", e.Name)
+}
+
+// EnsureNameUnique returns a NameExistsError if a performer other than the
+// performer with the provided id has the same name and disambiguation.
+func EnsureNameUnique(ctx context.Context, id int, name string, disambiguation string, qb NameFinder) error {
+	existing, err := ByNameAndDisambiguation(ctx, qb, name, disambiguation)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil && existing.ID != id {
+		return &NameExistsError{
+			Name:           name,
+			Disambiguation: disambiguation,
+		}
+	}
+
+	return nil
+}
+
 func ValidateDeathDate(performer *models.Performer, birthdate *string, deathDate *string) error {
 	// don't validate existing values
 	if birthdate == nil && deathDate == nil {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -59,3 +60,44 @@ func TestValidateDeathDate(t *testing.T) {
 	assert.Nil(ValidateDeathDate(&validPerformer, nil, &date4))
 	assert.Nil(ValidateDeathDate(&validPerformer, &date1, nil))
 }
+
+func TestEnsureNameUnique(t *testing.T) {
+	const (
+		name                = "name"
+		id                  = 1
+		disambiguatedID     = 2
+		otherDisambiguation = "other"
+		newPerformerID      = 0
+	)
+
+	qb := &mocks.PerformerReaderWriter{}
+	qb.On("FindByNames", testCtx, []string{name}, false).Return([]*models.Performer{
+		{ID: id, Name: name},
+		{ID: disambiguatedID, Name: name, Disambiguation: disambiguation},
+	}, nil)
+
+	tests := []struct {
+		name           string
+		id             int
+		disambiguation string
+		wantErr        bool
+	}{
+		{"same name", newPerformerID, "", true},
+		{"same name and disambiguation", newPerformerID, disambiguation, true},
+		{"different disambiguation", newPerformerID, otherDisambiguation, false},
+		{"same performer", id, "", false},
+		{"same disambiguated performer", disambiguatedID, disambiguation, false},
+		{"removing disambiguation", disambiguatedID, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := EnsureNameUnique(testCtx, tt.id, name, tt.disambiguation, qb)
+			if tt.wantErr {
+				var nameExistsErr *NameExistsError
+				assert.ErrorAs(t, err, &nameExistsErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
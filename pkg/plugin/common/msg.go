@@ -3,7 +3,8 @@ package common
 import "net/http"
 
 const (
-	HookContextKey = "hookContext"
+	HookContextKey   = "hookContext"
+	ScrapeContextKey = "scrapeContext"
 )
 
 // StashServerConnection represents the connection details needed for a
@@ -85,6 +86,10 @@ type PluginInput struct {
 
 	// Arguments to the plugin operation.
 	Args ArgsMap `json:"args"`
+
+	// Values of the settings declared in the plugin configuration. Settings
+	// that have not been configured are set to their default values.
+	Settings map[string]interface{} `json:"settings"`
 }
 
 // PluginOutput is the data structure that is expected to be output by plugin
@@ -110,3 +115,16 @@ type HookContext struct {
 	Input       interface{} `json:"input"`
 	InputFields []string    `json:"inputFields,omitempty"`
 }
+
+// ScrapeContext is passed as a PluginArgValue when a plugin is used to
+// perform a scrape.
+type ScrapeContext struct {
+	// The type of content to scrape. One of PERFORMER, SCENE, GALLERY or
+	// MOVIE.
+	Type string `json:"type"`
+	// The type of scrape. One of NAME, FRAGMENT or URL.
+	ScrapeType string `json:"scrapeType"`
+	// The scrape input. This is the same input that is sent to script
+	// scrapers.
+	Input interface{} `json:"input"`
+}
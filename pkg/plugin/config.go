@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -51,11 +52,26 @@ type Config struct {
 	// If left unset, defaults to log.ErrorLevel.
 	PluginErrLogLevel string `yaml:"errLog"`
 
+	// Resource limits applied to each task and hook operation of this
+	// plugin. May be overridden for individual operations. Currently only
+	// applicable to goja plugins.
+	Limits *LimitsConfig `yaml:"limits"`
+
+	// Restrictions on the fetch API available to goja plugins.
+	Fetch *FetchConfig `yaml:"fetch"`
+
 	// The task configurations for tasks provided by this plugin.
 	Tasks []*OperationConfig `yaml:"tasks"`
 
 	// The hooks configurations for hooks registered by this plugin.
 	Hooks []*HookConfig `yaml:"hooks"`
+
+	// User-configurable settings of this plugin, keyed by setting name.
+	Settings map[string]SettingConfig `yaml:"settings"`
+
+	// An optional scraper configuration, in the same format as scraper
+	// configuration files. The scraper is registered using the plugin ID.
+	Scraper map[string]interface{} `yaml:"scraper"`
 }
 
 func (c Config) getPluginTasks(includePlugin bool) []*PluginTask {
@@ -67,6 +83,12 @@ func (c Config) getPluginTasks(includePlugin bool) []*PluginTask {
 			Description: &o.Description,
 		}
 
+		if o.Result != nil {
+			task.Result = &PluginTaskResult{
+				Description: o.Result.Description,
+			}
+		}
+
 		if includePlugin {
 			task.Plugin = c.toPlugin()
 		}
@@ -147,12 +169,33 @@ func (c Config) getHooks(hookType HookTriggerEnum) []*HookConfig {
 	return ret
 }
 
+// getLimits returns the resource limits for the provided operation. Limits set
+// on the operation take precedence over those set on the plugin.
+func (c Config) getLimits(operation *OperationConfig) LimitsConfig {
+	var ret LimitsConfig
+	if c.Limits != nil {
+		ret = *c.Limits
+	}
+
+	if operation.Limits != nil {
+		if operation.Limits.CPU != 0 {
+			ret.CPU = operation.Limits.CPU
+		}
+		if operation.Limits.Timeout != 0 {
+			ret.Timeout = operation.Limits.Timeout
+		}
+	}
+
+	return ret
+}
+
 func (c Config) getConfigPath() string {
 	return filepath.Dir(c.path)
 }
 
 func (c Config) getExecCommand(task *OperationConfig) []string {
-	ret := c.Exec
+	// copy to avoid modifying the configured exec value
+	ret := append([]string{}, c.Exec...)
 
 	ret = append(ret, task.ExecArgs...)
 
@@ -193,10 +236,25 @@ const (
 	InterfaceEnumRaw interfaceEnum = "raw"
 
 	InterfaceEnumJS interfaceEnum = "js"
+
+	// InterfaceEnumGoja interfaces are embedded Javascript plugins executed
+	// using goja. The script is loaded as a CommonJS module, and the
+	// function it exports is called with the common.PluginInput.
+	InterfaceEnumGoja interfaceEnum = "goja"
+
+	// InterfaceEnumService indicates that the plugin is a long-running
+	// process that is started with stash. Operations are sent to the process
+	// over a persistent connection using the RPCRunner interface declared in
+	// common/rpc.go.
+	InterfaceEnumService interfaceEnum = "service"
 )
 
 func (i interfaceEnum) Valid() bool {
-	return i == InterfaceEnumRPC || i == InterfaceEnumRaw || i == InterfaceEnumJS
+	switch i {
+	case InterfaceEnumRPC, InterfaceEnumRaw, InterfaceEnumJS, InterfaceEnumGoja, InterfaceEnumService:
+		return true
+	}
+	return false
 }
 
 func (i *interfaceEnum) getTaskBuilder() taskBuilder {
@@ -212,6 +270,14 @@ func (i *interfaceEnum) getTaskBuilder() taskBuilder {
 		return &jsTaskBuilder{}
 	}
 
+	if *i == InterfaceEnumGoja {
+		return &gojaTaskBuilder{}
+	}
+
+	if *i == InterfaceEnumService {
+		return &serviceTaskBuilder{}
+	}
+
 	// shouldn't happen
 	return nil
 }
@@ -236,6 +302,43 @@ type OperationConfig struct {
 	// used if the applicable argument is not provided during the operation
 	// call.
 	DefaultArgs map[string]string `yaml:"defaultArgs"`
+
+	// Resource limits for this operation. Overrides the limits set for the
+	// plugin.
+	Limits *LimitsConfig `yaml:"limits"`
+
+	// Declares that the task returns a result. The output of tasks that
+	// declare a result is set as the result of the job running the task.
+	// Not applicable to hooks.
+	Result *ResultConfig `yaml:"result"`
+}
+
+// ResultConfig describes the result returned by a plugin task.
+type ResultConfig struct {
+	// A description of the returned result.
+	Description *string `yaml:"description"`
+}
+
+// LimitsConfig describes the resource limits applied to a plugin operation.
+// Zero values indicate that no limit is applied.
+type LimitsConfig struct {
+	// The maximum amount of time spent executing the operation, excluding
+	// time spent waiting on asynchronous operations. For example: 30s.
+	CPU time.Duration `yaml:"cpu"`
+
+	// The maximum amount of wall-clock time that the operation may run for.
+	// For example: 10m.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// FetchConfig describes the restrictions on the fetch API.
+type FetchConfig struct {
+	// The hosts that may be accessed using fetch. Entries may begin with
+	// "*." to match all subdomains. If empty, all hosts may be accessed.
+	AllowedHosts []string `yaml:"allowedHosts"`
+
+	// The maximum size of a response body in bytes. Defaults to 10MB.
+	MaxResponseSize int64 `yaml:"maxResponseSize"`
 }
 
 type HookConfig struct {
@@ -263,6 +366,10 @@ func loadPluginFromYAML(reader io.Reader) (*Config, error) {
 		return nil, fmt.Errorf("invalid interface type %s", ret.Interface)
 	}
 
+	if err := ret.validateSettings(); err != nil {
+		return nil, err
+	}
+
 	return ret, nil
 }
 
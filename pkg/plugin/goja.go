@@ -0,0 +1,126 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/stashapp/stash/pkg/plugin/common"
+	"github.com/stashapp/stash/pkg/plugin/js"
+)
+
+type gojaTaskBuilder struct{}
+
+func (*gojaTaskBuilder) build(task pluginTask) Task {
+	return &gojaPluginTask{
+		pluginTask: task,
+	}
+}
+
+type gojaPluginTask struct {
+	pluginTask
+
+	started   bool
+	waitGroup sync.WaitGroup
+	cancel    context.CancelFunc
+}
+
+func (t *gojaPluginTask) onError(err error) {
+	errString := err.Error()
+	t.result = &common.PluginOutput{
+		Error: &errString,
+	}
+}
+
+func (t *gojaPluginTask) newVM() (*js.VM, error) {
+	limits := t.plugin.getLimits(t.operation)
+	vm := js.NewVM(js.Limits{
+		CPUTime: limits.CPU,
+		Timeout: limits.Timeout,
+	})
+
+	if err := vm.Set("input", t.input); err != nil {
+		return nil, fmt.Errorf("error setting input: %w", err)
+	}
+
+	if err := vm.AddRequireAPI(t.plugin.getConfigPath()); err != nil {
+		return nil, fmt.Errorf("error adding require API: %w", err)
+	}
+
+	if err := vm.AddLogAPI(t.progress); err != nil {
+		return nil, fmt.Errorf("error adding log API: %w", err)
+	}
+
+	if err := vm.AddUtilAPI(); err != nil {
+		return nil, fmt.Errorf("error adding util API: %w", err)
+	}
+
+	if err := vm.AddGQLAPI(t.input.ServerConnection.SessionCookie, t.gqlHandler); err != nil {
+		return nil, fmt.Errorf("error adding GraphQL API: %w", err)
+	}
+
+	var fetchOptions js.FetchOptions
+	if t.plugin.Fetch != nil {
+		fetchOptions.AllowedHosts = t.plugin.Fetch.AllowedHosts
+		fetchOptions.MaxResponseSize = t.plugin.Fetch.MaxResponseSize
+	}
+
+	if err := vm.AddFetchAPI(fetchOptions); err != nil {
+		return nil, fmt.Errorf("error adding fetch API: %w", err)
+	}
+
+	return vm, nil
+}
+
+func (t *gojaPluginTask) Start() error {
+	if t.started {
+		return errors.New("task already started")
+	}
+
+	t.started = true
+
+	if len(t.plugin.Exec) == 0 {
+		return errors.New("no script specified in exec")
+	}
+
+	scriptFile := t.plugin.Exec[0]
+
+	vm, err := t.newVM()
+	if err != nil {
+		return err
+	}
+
+	var ctx context.Context
+	ctx, t.cancel = context.WithCancel(context.Background())
+
+	t.waitGroup.Add(1)
+
+	go func() {
+		defer t.waitGroup.Done()
+		defer t.cancel()
+
+		output, err := vm.RunModule(ctx, scriptFile, t.input)
+		if err != nil {
+			t.onError(err)
+			return
+		}
+
+		t.result = &common.PluginOutput{
+			Output: output.Export(),
+		}
+	}()
+
+	return nil
+}
+
+func (t *gojaPluginTask) Wait() {
+	t.waitGroup.Wait()
+}
+
+func (t *gojaPluginTask) Stop() error {
+	if t.cancel != nil {
+		t.cancel()
+	}
+	return nil
+}
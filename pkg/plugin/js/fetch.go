@@ -0,0 +1,225 @@
+package js
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/dop251/goja"
+)
+
+// defaultMaxResponseSize is the maximum size of a fetch response body when
+// not otherwise configured.
+const defaultMaxResponseSize = 10 * 1024 * 1024
+
+// FetchOptions configures the sandboxing of the fetch API.
+type FetchOptions struct {
+	// AllowedHosts is the list of hosts that may be accessed. Entries may
+	// begin with "*." to match all subdomains. If empty, all hosts may be
+	// accessed.
+	AllowedHosts []string
+
+	// MaxResponseSize is the maximum number of bytes read from a response
+	// body. Defaults to 10MB if zero.
+	MaxResponseSize int64
+}
+
+func (o FetchOptions) hostAllowed(host string) bool {
+	if len(o.AllowedHosts) == 0 {
+		return true
+	}
+
+	host = strings.ToLower(host)
+	for _, h := range o.AllowedHosts {
+		h = strings.ToLower(h)
+		if h == host {
+			return true
+		}
+
+		if strings.HasPrefix(h, "*.") && strings.HasSuffix(host, h[1:]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type fetchInit struct {
+	Method  string
+	Headers map[string]string
+	Body    *string
+}
+
+func toFetchInit(v map[string]interface{}) fetchInit {
+	var ret fetchInit
+	ret.Method, _ = v["method"].(string)
+
+	if headers, ok := v["headers"].(map[string]interface{}); ok {
+		ret.Headers = make(map[string]string)
+		for k, hv := range headers {
+			ret.Headers[k] = fmt.Sprint(hv)
+		}
+	}
+
+	if body, ok := v["body"].(string); ok {
+		ret.Body = &body
+	}
+
+	return ret
+}
+
+type fetchResponse struct {
+	url        string
+	status     int
+	statusText string
+	header     http.Header
+	body       []byte
+}
+
+func (r *fetchResponse) jsValue(vm *VM) goja.Value {
+	o := vm.NewObject()
+
+	headers := make(map[string]string)
+	for k := range r.header {
+		headers[strings.ToLower(k)] = r.header.Get(k)
+	}
+
+	_ = o.Set("url", r.url)
+	_ = o.Set("status", r.status)
+	_ = o.Set("statusText", r.statusText)
+	_ = o.Set("ok", r.status >= 200 && r.status < 300)
+	_ = o.Set("headers", headers)
+	_ = o.Set("text", func() goja.Value {
+		return vm.resolved(string(r.body), nil)
+	})
+	_ = o.Set("json", func() goja.Value {
+		var v interface{}
+		err := json.Unmarshal(r.body, &v)
+		return vm.resolved(v, err)
+	})
+
+	return o
+}
+
+// resolved returns a promise that is already settled with v or err.
+func (vm *VM) resolved(v interface{}, err error) goja.Value {
+	promise, resolve, reject := vm.NewPromise()
+	if err != nil {
+		reject(vm.NewGoError(err))
+	} else {
+		resolve(v)
+	}
+
+	return vm.ToValue(promise)
+}
+
+func doFetch(ctx context.Context, client *http.Client, options FetchOptions, rawURL string, init fetchInit) (*fetchResponse, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url %q: %w", rawURL, err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("unsupported url scheme %q", u.Scheme)
+	}
+
+	if !options.hostAllowed(u.Hostname()) {
+		return nil, fmt.Errorf("access to host %q is not permitted", u.Hostname())
+	}
+
+	method := http.MethodGet
+	if init.Method != "" {
+		method = strings.ToUpper(init.Method)
+	}
+
+	var body io.Reader
+	if init.Body != nil {
+		body = strings.NewReader(*init.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range init.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	maxSize := options.MaxResponseSize
+	if maxSize <= 0 {
+		maxSize = defaultMaxResponseSize
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading response body: %w", err)
+	}
+
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("response body exceeds maximum size of %d bytes", maxSize)
+	}
+
+	return &fetchResponse{
+		url:        resp.Request.URL.String(),
+		status:     resp.StatusCode,
+		statusText: http.StatusText(resp.StatusCode),
+		header:     resp.Header,
+		body:       data,
+	}, nil
+}
+
+// AddFetchAPI adds a fetch function to the VM, modelled on the fetch API
+// provided by browsers. The function returns a promise which resolves to a
+// response object with url, status, statusText, ok and headers fields, and
+// text and json functions which return promises of the response body.
+//
+// Only http and https requests are permitted. Requests are restricted to the
+// hosts allowed by options, and redirects to other hosts are rejected.
+func (vm *VM) AddFetchAPI(options FetchOptions) error {
+	client := &http.Client{
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			if !options.hostAllowed(req.URL.Hostname()) {
+				return fmt.Errorf("redirect to host %q is not permitted", req.URL.Hostname())
+			}
+			return nil
+		},
+	}
+
+	fetch := func(call goja.FunctionCall) goja.Value {
+		rawURL := call.Argument(0).String()
+
+		var init fetchInit
+		if arg := call.Argument(1); !goja.IsUndefined(arg) && !goja.IsNull(arg) {
+			m, ok := arg.Export().(map[string]interface{})
+			if !ok {
+				panic(vm.NewTypeError("fetch options must be an object"))
+			}
+			init = toFetchInit(m)
+		}
+
+		return vm.async(func(ctx context.Context) (interface{}, error) {
+			return doFetch(ctx, client, options, rawURL, init)
+		})
+	}
+
+	if err := vm.Set("fetch", fetch); err != nil {
+		return fmt.Errorf("unable to set fetch: %w", err)
+	}
+
+	return nil
+}
@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/dop251/goja"
 	"github.com/robertkrimen/otto"
 )
 
@@ -34,6 +36,60 @@ func throw(vm *otto.Otto, str string) {
 	panic(value)
 }
 
+// doGQLRequest executes the graphql query against gqlHandler and returns the
+// data field of the response.
+func doGQLRequest(ctx context.Context, cookie *http.Cookie, gqlHandler http.Handler, query string, variables map[string]interface{}) (interface{}, error) {
+	in := struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables,omitempty"`
+	}{
+		Query:     query,
+		Variables: variables,
+	}
+
+	var body bytes.Buffer
+	err := json.NewEncoder(&body).Encode(in)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := http.NewRequestWithContext(ctx, "POST", "/graphql", &body)
+	if err != nil {
+		return nil, errors.New("could not make request")
+	}
+	r.Header.Set("Content-Type", "application/json")
+
+	if cookie != nil {
+		r.AddCookie(cookie)
+	}
+
+	w := &responseWriter{
+		header: make(http.Header),
+	}
+
+	gqlHandler.ServeHTTP(w, r)
+
+	if w.statusCode != http.StatusOK && w.statusCode != 0 {
+		return nil, fmt.Errorf("graphQL query failed: %d - %s. Query: %s. Variables: %v", w.statusCode, w.r.String(), in.Query, in.Variables)
+	}
+
+	output := w.r.String()
+	// convert to JSON
+	var obj map[string]interface{}
+	if err = json.Unmarshal([]byte(output), &obj); err != nil {
+		return nil, fmt.Errorf("could not unmarshal object %s: %s", output, err.Error())
+	}
+
+	retErr, hasErr := obj["errors"]
+
+	if hasErr {
+		errOut, _ := json.Marshal(retErr)
+		return nil, fmt.Errorf("graphql error: %s", string(errOut))
+	}
+
+	return obj["data"], nil
+}
+
 func gqlRequestFunc(ctx context.Context, vm *otto.Otto, cookie *http.Cookie, gqlHandler http.Handler) func(call otto.FunctionCall) otto.Value {
 	return func(call otto.FunctionCall) otto.Value {
 		if len(call.ArgumentList) == 0 {
@@ -48,66 +104,54 @@ func gqlRequestFunc(ctx context.Context, vm *otto.Otto, cookie *http.Cookie, gql
 			variables, _ = exported.(map[string]interface{})
 		}
 
-		in := struct {
-			Query     string                 `json:"query"`
-			Variables map[string]interface{} `json:"variables,omitempty"`
-		}{
-			Query:     query.String(),
-			Variables: variables,
-		}
-
-		var body bytes.Buffer
-		err := json.NewEncoder(&body).Encode(in)
+		data, err := doGQLRequest(ctx, cookie, gqlHandler, query.String(), variables)
 		if err != nil {
 			throw(vm, err.Error())
 		}
 
-		r, err := http.NewRequestWithContext(ctx, "POST", "/graphql", &body)
+		v, err := vm.ToValue(data)
 		if err != nil {
-			throw(vm, "could not make request")
-		}
-		r.Header.Set("Content-Type", "application/json")
-
-		if cookie != nil {
-			r.AddCookie(cookie)
-		}
-
-		w := &responseWriter{
-			header: make(http.Header),
+			throw(vm, fmt.Sprintf("could not create return value: %s", err.Error()))
 		}
 
-		gqlHandler.ServeHTTP(w, r)
+		return v
+	}
+}
 
-		if w.statusCode != http.StatusOK && w.statusCode != 0 {
-			throw(vm, fmt.Sprintf("graphQL query failed: %d - %s. Query: %s. Variables: %v", w.statusCode, w.r.String(), in.Query, in.Variables))
-		}
+func AddGQLAPI(ctx context.Context, vm *otto.Otto, cookie *http.Cookie, gqlHandler http.Handler) error {
+	gql, _ := vm.Object("({})")
+	if err := gql.Set("Do", gqlRequestFunc(ctx, vm, cookie, gqlHandler)); err != nil {
+		return fmt.Errorf("unable to set GraphQL Do function: %w", err)
+	}
 
-		output := w.r.String()
-		// convert to JSON
-		var obj map[string]interface{}
-		if err = json.Unmarshal([]byte(output), &obj); err != nil {
-			throw(vm, fmt.Sprintf("could not unmarshal object %s: %s", output, err.Error()))
-		}
+	if err := vm.Set("gql", gql); err != nil {
+		return fmt.Errorf("unable to set gql: %w", err)
+	}
 
-		retErr, hasErr := obj["errors"]
+	return nil
+}
 
-		if hasErr {
-			errOut, _ := json.Marshal(retErr)
-			throw(vm, fmt.Sprintf("graphql error: %s", string(errOut)))
+// AddGQLAPI adds the gql object to the VM. Unlike the otto API, gql.Do
+// returns a promise that resolves to the data field of the graphql response.
+func (vm *VM) AddGQLAPI(cookie *http.Cookie, gqlHandler http.Handler) error {
+	do := func(call goja.FunctionCall) goja.Value {
+		if len(call.Arguments) == 0 {
+			panic(vm.NewTypeError("missing argument"))
 		}
 
-		v, err := vm.ToValue(obj["data"])
-		if err != nil {
-			throw(vm, fmt.Sprintf("could not create return value: %s", err.Error()))
+		query := call.Argument(0).String()
+		var variables map[string]interface{}
+		if vars := call.Argument(1); !goja.IsUndefined(vars) && !goja.IsNull(vars) {
+			variables, _ = vars.Export().(map[string]interface{})
 		}
 
-		return v
+		return vm.async(func(ctx context.Context) (interface{}, error) {
+			return doGQLRequest(ctx, cookie, gqlHandler, query, variables)
+		})
 	}
-}
 
-func AddGQLAPI(ctx context.Context, vm *otto.Otto, cookie *http.Cookie, gqlHandler http.Handler) error {
-	gql, _ := vm.Object("({})")
-	if err := gql.Set("Do", gqlRequestFunc(ctx, vm, cookie, gqlHandler)); err != nil {
+	gql := vm.NewObject()
+	if err := gql.Set("Do", do); err != nil {
 		return fmt.Errorf("unable to set GraphQL Do function: %w", err)
 	}
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/dop251/goja"
 	"github.com/robertkrimen/otto"
 	"github.com/stashapp/stash/pkg/logger"
 )
@@ -94,3 +95,67 @@ func AddLogAPI(vm *otto.Otto, progress chan float64) error {
 
 	return nil
 }
+
+func gojaArgToString(call goja.FunctionCall) string {
+	arg := call.Argument(0)
+	if o, ok := arg.(*goja.Object); ok {
+		data, err := json.Marshal(o.Export())
+		if err != nil {
+			logger.Warnf("Couldn't json encode object")
+		}
+		return string(data)
+	}
+
+	return arg.String()
+}
+
+func gojaLogFunc(logFn func(args ...interface{})) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		logFn(pluginPrefix + gojaArgToString(call))
+		return goja.Undefined()
+	}
+}
+
+// AddLogAPI adds the log object to the VM. The API is the same as that
+// provided to otto scripts.
+func (vm *VM) AddLogAPI(progress chan float64) error {
+	log := vm.NewObject()
+	if err := log.Set("Trace", gojaLogFunc(logger.Trace)); err != nil {
+		return fmt.Errorf("error setting Trace: %w", err)
+	}
+	if err := log.Set("Debug", gojaLogFunc(logger.Debug)); err != nil {
+		return fmt.Errorf("error setting Debug: %w", err)
+	}
+	if err := log.Set("Info", gojaLogFunc(logger.Info)); err != nil {
+		return fmt.Errorf("error setting Info: %w", err)
+	}
+	if err := log.Set("Warn", gojaLogFunc(logger.Warn)); err != nil {
+		return fmt.Errorf("error setting Warn: %w", err)
+	}
+	if err := log.Set("Error", gojaLogFunc(logger.Error)); err != nil {
+		return fmt.Errorf("error setting Error: %w", err)
+	}
+	if err := log.Set("Progress", func(call goja.FunctionCall) goja.Value {
+		p, ok := call.Argument(0).Export().(float64)
+		if !ok {
+			i, isInt := call.Argument(0).Export().(int64)
+			if !isInt {
+				return goja.Undefined()
+			}
+			p = float64(i)
+		}
+
+		// hooks do not report progress
+		if progress != nil {
+			progress <- math.Min(math.Max(0, p), 1)
+		}
+		return goja.Undefined()
+	}); err != nil {
+		return fmt.Errorf("error setting Progress: %v", err)
+	}
+	if err := vm.Set("log", log); err != nil {
+		return fmt.Errorf("unable to set log: %w", err)
+	}
+
+	return nil
+}
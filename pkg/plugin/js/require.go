@@ -0,0 +1,234 @@
+package js
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dop251/goja"
+)
+
+// moduleLoader loads CommonJS modules from within a root directory.
+type moduleLoader struct {
+	vm   *VM
+	root string
+
+	// cache of loaded modules, keyed by absolute path
+	cache map[string]*goja.Object
+}
+
+// AddRequireAPI adds a CommonJS require function to the VM. Modules may only
+// be loaded from within the root directory.
+//
+// Relative module paths are resolved relative to the requiring module. Other
+// module names are resolved from node_modules directories between the
+// requiring module and the root directory.
+func (vm *VM) AddRequireAPI(root string) error {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return fmt.Errorf("resolving module root: %w", err)
+	}
+
+	vm.modules = &moduleLoader{
+		vm:    vm,
+		root:  root,
+		cache: make(map[string]*goja.Object),
+	}
+
+	if err := vm.Set("require", vm.modules.requireFunc(root)); err != nil {
+		return fmt.Errorf("unable to set require: %w", err)
+	}
+
+	return nil
+}
+
+// RunModule loads the module at path, relative to the root directory, and
+// calls the function it exports with args. The exported function may be the
+// module exports itself, or the main property of the exports. If the module
+// does not export a function, then the module exports are returned.
+//
+// If the function returns a promise, then RunModule returns the settled
+// value of the promise.
+func (vm *VM) RunModule(ctx context.Context, path string, args ...interface{}) (goja.Value, error) {
+	if vm.modules == nil {
+		return nil, errors.New("require API not added")
+	}
+
+	return vm.run(ctx, func() (goja.Value, error) {
+		exports, err := vm.modules.require(vm.modules.root, "./"+filepath.ToSlash(path))
+		if err != nil {
+			return nil, err
+		}
+
+		mainFn, ok := goja.AssertFunction(exports)
+		if !ok {
+			if o, isObj := exports.(*goja.Object); isObj {
+				mainFn, ok = goja.AssertFunction(o.Get("main"))
+			}
+		}
+
+		if !ok {
+			return exports, nil
+		}
+
+		jsArgs := make([]goja.Value, len(args))
+		for i, a := range args {
+			jsArgs[i] = vm.ToValue(a)
+		}
+
+		return mainFn(goja.Undefined(), jsArgs...)
+	})
+}
+
+func (l *moduleLoader) requireFunc(dir string) func(call goja.FunctionCall) goja.Value {
+	return func(call goja.FunctionCall) goja.Value {
+		name := call.Argument(0).String()
+		exports, err := l.require(dir, name)
+		if err != nil {
+			panic(l.vm.NewGoError(err))
+		}
+
+		return exports
+	}
+}
+
+func (l *moduleLoader) require(dir string, name string) (goja.Value, error) {
+	path, err := l.resolve(dir, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if module, found := l.cache[path]; found {
+		return module.Get("exports"), nil
+	}
+
+	module := l.vm.NewObject()
+	if err := module.Set("exports", l.vm.NewObject()); err != nil {
+		return nil, err
+	}
+
+	// cache before loading to handle cyclic dependencies
+	l.cache[path] = module
+
+	if err := l.load(path, module); err != nil {
+		delete(l.cache, path)
+		return nil, err
+	}
+
+	return module.Get("exports"), nil
+}
+
+func (l *moduleLoader) load(path string, module *goja.Object) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("loading module %s: %w", path, err)
+	}
+
+	if filepath.Ext(path) == ".json" {
+		var v interface{}
+		if err := json.Unmarshal(src, &v); err != nil {
+			return fmt.Errorf("parsing module %s: %w", path, err)
+		}
+
+		return module.Set("exports", l.vm.ToValue(v))
+	}
+
+	wrapped := "(function(exports, require, module, __filename, __dirname) {" + string(src) + "\n})"
+	prg, err := goja.Compile(path, wrapped, false)
+	if err != nil {
+		return fmt.Errorf("compiling module %s: %w", path, err)
+	}
+
+	v, err := l.vm.RunProgram(prg)
+	if err != nil {
+		return err
+	}
+
+	fn, ok := goja.AssertFunction(v)
+	if !ok {
+		return fmt.Errorf("module %s did not compile to a function", path)
+	}
+
+	dir := filepath.Dir(path)
+	_, err = fn(goja.Undefined(), module.Get("exports"), l.vm.ToValue(l.requireFunc(dir)), module, l.vm.ToValue(path), l.vm.ToValue(dir))
+	return err
+}
+
+func (l *moduleLoader) resolve(dir string, name string) (string, error) {
+	if name == "" {
+		return "", errors.New("module name is empty")
+	}
+
+	var candidates []string
+	if strings.HasPrefix(name, "./") || strings.HasPrefix(name, "../") {
+		candidates = append(candidates, filepath.Join(dir, filepath.FromSlash(name)))
+	} else if !filepath.IsAbs(name) {
+		// search node_modules from the current directory up to the root
+		for d := dir; l.contains(d); d = filepath.Dir(d) {
+			candidates = append(candidates, filepath.Join(d, "node_modules", filepath.FromSlash(name)))
+			if d == l.root {
+				break
+			}
+		}
+	}
+
+	for _, c := range candidates {
+		if !l.contains(c) {
+			return "", fmt.Errorf("module %s is outside of the plugin directory", name)
+		}
+
+		if path := l.resolveFile(c); path != "" {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("module %s not found", name)
+}
+
+// resolveFile returns the file to load for the module path p, trying
+// extensions and directory entry points as node does. Returns an empty string
+// if no file could be found.
+func (l *moduleLoader) resolveFile(p string) string {
+	for _, f := range []string{p, p + ".js", p + ".json"} {
+		if isFile(f) {
+			return f
+		}
+	}
+
+	// check for package.json main entry
+	if data, err := os.ReadFile(filepath.Join(p, "package.json")); err == nil {
+		var pkg struct {
+			Main string `json:"main"`
+		}
+		if json.Unmarshal(data, &pkg) == nil && pkg.Main != "" {
+			main := filepath.Join(p, filepath.FromSlash(pkg.Main))
+			if l.contains(main) {
+				for _, f := range []string{main, main + ".js", filepath.Join(main, "index.js")} {
+					if isFile(f) {
+						return f
+					}
+				}
+			}
+		}
+	}
+
+	if f := filepath.Join(p, "index.js"); isFile(f) {
+		return f
+	}
+
+	return ""
+}
+
+func (l *moduleLoader) contains(p string) bool {
+	rel, err := filepath.Rel(l.root, p)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func isFile(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && !info.IsDir()
+}
@@ -1,9 +1,11 @@
 package js
 
 import (
+	"context"
 	"fmt"
 	"time"
 
+	"github.com/dop251/goja"
 	"github.com/robertkrimen/otto"
 )
 
@@ -27,3 +29,95 @@ func AddUtilAPI(vm *otto.Otto) error {
 
 	return nil
 }
+
+type timer struct {
+	t         *time.Timer
+	cancelled bool
+}
+
+func (vm *VM) setTimeout(call goja.FunctionCall) goja.Value {
+	fn, ok := goja.AssertFunction(call.Argument(0))
+	if !ok {
+		panic(vm.NewTypeError("callback must be a function"))
+	}
+
+	delay := time.Duration(call.Argument(1).ToInteger()) * time.Millisecond
+	var args []goja.Value
+	if len(call.Arguments) > 2 {
+		args = call.Arguments[2:]
+	}
+
+	vm.nextTimerID++
+	id := vm.nextTimerID
+	t := &timer{}
+	vm.timers[id] = t
+
+	vm.pending++
+	t.t = time.AfterFunc(delay, func() {
+		vm.enqueue(func() error {
+			vm.pending--
+			delete(vm.timers, id)
+			if t.cancelled {
+				return nil
+			}
+
+			_, err := fn(goja.Undefined(), args...)
+			return err
+		})
+	})
+
+	return vm.ToValue(id)
+}
+
+func (vm *VM) clearTimeout(call goja.FunctionCall) goja.Value {
+	id := call.Argument(0).ToInteger()
+	t, found := vm.timers[id]
+	if !found || t.cancelled {
+		return goja.Undefined()
+	}
+
+	t.cancelled = true
+	if t.t.Stop() {
+		// the callback will never be queued
+		vm.pending--
+		delete(vm.timers, id)
+	}
+
+	return goja.Undefined()
+}
+
+// AddUtilAPI adds the util object and the setTimeout and clearTimeout
+// functions to the VM. Unlike the otto API, util.Sleep returns a promise that
+// resolves after the provided number of milliseconds.
+func (vm *VM) AddUtilAPI() error {
+	sleep := func(call goja.FunctionCall) goja.Value {
+		d := time.Duration(call.Argument(0).ToInteger()) * time.Millisecond
+		return vm.async(func(ctx context.Context) (interface{}, error) {
+			select {
+			case <-time.After(d):
+				return nil, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		})
+	}
+
+	util := vm.NewObject()
+	if err := util.Set("Sleep", sleep); err != nil {
+		return fmt.Errorf("unable to set sleep func: %w", err)
+	}
+
+	if err := vm.Set("util", util); err != nil {
+		return fmt.Errorf("unable to set util: %w", err)
+	}
+
+	if err := vm.Set("setTimeout", vm.setTimeout); err != nil {
+		return fmt.Errorf("unable to set setTimeout: %w", err)
+	}
+
+	if err := vm.Set("clearTimeout", vm.clearTimeout); err != nil {
+		return fmt.Errorf("unable to set clearTimeout: %w", err)
+	}
+
+	return nil
+}
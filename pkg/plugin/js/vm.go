@@ -0,0 +1,226 @@
+package js
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dop251/goja"
+)
+
+// ErrCPULimit is returned when a script exceeds its CPU time limit.
+var ErrCPULimit = errors.New("script exceeded CPU time limit")
+
+// Limits describes the resource limits applied to scripts run in a VM.
+// Zero values indicate that no limit is applied.
+type Limits struct {
+	// CPUTime is the maximum amount of time that may be spent executing
+	// Javascript. Time spent waiting on asynchronous operations such as
+	// timers, fetch requests and graphql requests is not counted.
+	CPUTime time.Duration
+
+	// Timeout is the maximum wall-clock time that a script may run for.
+	Timeout time.Duration
+}
+
+// VM is a goja Javascript runtime with an event loop. The event loop is used
+// to settle the promises returned by asynchronous API functions and to run
+// timer callbacks.
+//
+// A VM is not goroutine-safe. API functions must be added before the VM is
+// run, and the VM may only be run once.
+type VM struct {
+	*goja.Runtime
+
+	limits Limits
+
+	// ctx is the context of the current run. It is cancelled when the
+	// run is interrupted.
+	ctx context.Context
+
+	// jobs are functions to be run on the event loop
+	jobs chan func() error
+	// done is closed when the event loop exits
+	done chan struct{}
+
+	// pending is the number of asynchronous operations that will post a job
+	// to the event loop. Only accessed from the event loop.
+	pending int
+	cpuUsed time.Duration
+
+	timers      map[int64]*timer
+	nextTimerID int64
+
+	modules *moduleLoader
+}
+
+// NewVM returns a new VM which runs scripts subject to the provided limits.
+func NewVM(limits Limits) *VM {
+	return &VM{
+		Runtime: goja.New(),
+		limits:  limits,
+		ctx:     context.Background(),
+		jobs:    make(chan func() error),
+		done:    make(chan struct{}),
+		timers:  make(map[int64]*timer),
+	}
+}
+
+// jsValuer is implemented by the results of asynchronous operations that
+// need to be converted into Javascript values on the event loop.
+type jsValuer interface {
+	jsValue(vm *VM) goja.Value
+}
+
+// async runs fn in a separate goroutine and returns a promise that is
+// settled on the event loop with the result of fn.
+func (vm *VM) async(fn func(ctx context.Context) (interface{}, error)) goja.Value {
+	promise, resolve, reject := vm.NewPromise()
+	ctx := vm.ctx
+
+	vm.pending++
+	go func() {
+		v, err := fn(ctx)
+		vm.enqueue(func() error {
+			vm.pending--
+			switch {
+			case err != nil:
+				reject(vm.NewGoError(err))
+			case v == nil:
+				resolve(goja.Undefined())
+			default:
+				if valuer, ok := v.(jsValuer); ok {
+					resolve(valuer.jsValue(vm))
+				} else {
+					resolve(v)
+				}
+			}
+			return nil
+		})
+	}()
+
+	return vm.ToValue(promise)
+}
+
+// enqueue posts a job to the event loop. The job is discarded if the event
+// loop has exited.
+func (vm *VM) enqueue(job func() error) {
+	select {
+	case vm.jobs <- job:
+	case <-vm.done:
+	}
+}
+
+// runJob runs job, accounting for the time spent against the CPU time
+// limit.
+func (vm *VM) runJob(job func() error) error {
+	var cpuTimer *time.Timer
+	if vm.limits.CPUTime > 0 {
+		remaining := vm.limits.CPUTime - vm.cpuUsed
+		if remaining <= 0 {
+			return ErrCPULimit
+		}
+
+		cpuTimer = time.AfterFunc(remaining, func() {
+			vm.Interrupt(ErrCPULimit)
+		})
+	}
+
+	start := time.Now()
+	err := job()
+	vm.cpuUsed += time.Since(start)
+
+	// the interrupt may have fired after the job completed, or been
+	// swallowed by a promise reaction
+	if cpuTimer != nil && !cpuTimer.Stop() {
+		vm.ClearInterrupt()
+		return ErrCPULimit
+	}
+
+	var interrupted *goja.InterruptedError
+	if errors.As(err, &interrupted) {
+		if e, ok := interrupted.Value().(error); ok {
+			return e
+		}
+	}
+
+	return err
+}
+
+// run calls fn and then runs the event loop until the value returned by fn
+// is resolved. If fn returns a promise, then the event loop is run until the
+// promise is settled and its result is returned. Otherwise, the event loop is
+// run until there are no outstanding asynchronous operations.
+//
+// run returns early if ctx is cancelled or a limit is exceeded.
+func (vm *VM) run(ctx context.Context, fn func() (goja.Value, error)) (goja.Value, error) {
+	if vm.limits.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, vm.limits.Timeout)
+		defer cancel()
+	}
+
+	vm.ctx = ctx
+	defer close(vm.done)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			vm.Interrupt(ctx.Err())
+		case <-vm.done:
+		}
+	}()
+
+	var ret goja.Value
+	if err := vm.runJob(func() error {
+		var err error
+		ret, err = fn()
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if ret == nil {
+		ret = goja.Undefined()
+	}
+
+	promise, _ := ret.Export().(*goja.Promise)
+	for vm.pending > 0 && (promise == nil || promise.State() == goja.PromiseStatePending) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case job := <-vm.jobs:
+			if err := vm.runJob(job); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if promise == nil {
+		return ret, nil
+	}
+
+	switch promise.State() {
+	case goja.PromiseStateRejected:
+		return nil, promiseError(promise.Result())
+	case goja.PromiseStatePending:
+		return nil, errors.New("returned promise was never settled")
+	}
+
+	return promise.Result(), nil
+}
+
+func promiseError(v goja.Value) error {
+	if o, ok := v.(*goja.Object); ok {
+		if err, ok := o.Export().(error); ok {
+			return err
+		}
+
+		if stack := o.Get("stack"); stack != nil && !goja.IsUndefined(stack) {
+			return errors.New(stack.String())
+		}
+	}
+
+	return fmt.Errorf("promise rejected: %v", v)
+}
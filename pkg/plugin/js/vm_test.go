@@ -0,0 +1,116 @@
+package js
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testModule = `
+const { double } = require('./lib/helper');
+const data = require('./data.json');
+
+module.exports = async function(input) {
+	const mode = input.mode;
+	const v = await new Promise((resolve) => setTimeout(() => resolve(data.v), 1));
+
+	switch (mode) {
+	case 'loop':
+		while (true) {}
+	case 'sleep':
+		await util.Sleep(60000);
+		break;
+	case 'throw':
+		throw new Error('boom');
+	case 'fetch':
+		const r = await fetch(input.url);
+		return await r.json();
+	case 'escape':
+		require('../outside');
+	}
+
+	return double(v);
+};
+`
+
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, contents := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(contents), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestVM_RunModule(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		"main.js":       testModule,
+		"lib/helper.js": "exports.double = (x) => x * 2;",
+		"data.json":     `{"v": 21}`,
+	})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"fetched": true}`))
+	}))
+	defer srv.Close()
+
+	limits := Limits{
+		CPUTime: 100 * time.Millisecond,
+		Timeout: 500 * time.Millisecond,
+	}
+
+	tests := []struct {
+		name         string
+		input        map[string]interface{}
+		allowedHosts []string
+		want         interface{}
+		wantErr      error
+		wantAnyErr   bool
+	}{
+		{"async result", map[string]interface{}{}, nil, int64(42), nil, false},
+		{"cpu limit", map[string]interface{}{"mode": "loop"}, nil, nil, ErrCPULimit, false},
+		{"timeout", map[string]interface{}{"mode": "sleep"}, nil, nil, context.DeadlineExceeded, false},
+		{"throw", map[string]interface{}{"mode": "throw"}, nil, nil, nil, true},
+		{"fetch", map[string]interface{}{"mode": "fetch", "url": srv.URL}, nil, map[string]interface{}{"fetched": true}, nil, false},
+		{"fetch disallowed host", map[string]interface{}{"mode": "fetch", "url": srv.URL}, []string{"example.com"}, nil, nil, true},
+		{"require outside root", map[string]interface{}{"mode": "escape"}, nil, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vm := NewVM(limits)
+			if err := vm.AddRequireAPI(dir); err != nil {
+				t.Fatal(err)
+			}
+			if err := vm.AddUtilAPI(); err != nil {
+				t.Fatal(err)
+			}
+			if err := vm.AddFetchAPI(FetchOptions{AllowedHosts: tt.allowedHosts}); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := vm.RunModule(context.Background(), "main.js", tt.input)
+			switch {
+			case tt.wantErr != nil:
+				assert.ErrorIs(t, err, tt.wantErr)
+			case tt.wantAnyErr:
+				assert.Error(t, err)
+			default:
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.want, got.Export())
+				}
+			}
+		})
+	}
+}
@@ -31,6 +31,11 @@ type Plugin struct {
 	Version     *string       `json:"version"`
 	Tasks       []*PluginTask `json:"tasks"`
 	Hooks       []*PluginHook `json:"hooks"`
+
+	// Service is the status of the service process for service plugins.
+	Service *PluginServiceStatus `json:"service"`
+
+	Settings []*PluginSetting `json:"settings"`
 }
 
 type ServerConfig interface {
@@ -40,6 +45,11 @@ type ServerConfig interface {
 	HasTLSConfig() bool
 	GetPluginsPath() string
 	GetPythonPath() string
+
+	// GetPluginConfiguration returns the stored setting values for the
+	// plugin with the provided ID. Setting names may not match the case of
+	// the configured names.
+	GetPluginConfiguration(pluginID string) map[string]interface{}
 }
 
 // Cache stores plugin details.
@@ -48,6 +58,9 @@ type Cache struct {
 	plugins      []Config
 	sessionStore *session.Store
 	gqlHandler   http.Handler
+
+	// running services, keyed by plugin ID
+	services map[string]*service
 }
 
 // NewCache returns a new Cache.
@@ -73,7 +86,12 @@ func (c *Cache) RegisterSessionStore(sessionStore *session.Store) {
 
 // LoadPlugins clears the plugin cache and loads from the plugin path.
 // In the event of an error during loading, the cache will be left empty.
+//
+// Running services are stopped, and the services of the loaded plugins are
+// started.
 func (c *Cache) LoadPlugins() error {
+	c.StopServices()
+
 	c.plugins = nil
 	plugins, err := loadPlugins(c.config.GetPluginsPath())
 	if err != nil {
@@ -81,9 +99,33 @@ func (c *Cache) LoadPlugins() error {
 	}
 
 	c.plugins = plugins
+	c.startServices()
 	return nil
 }
 
+func (c *Cache) startServices() {
+	c.services = make(map[string]*service)
+	for i := range c.plugins {
+		p := &c.plugins[i]
+		if p.Interface != InterfaceEnumService {
+			continue
+		}
+
+		s := newService(p, c.config)
+		c.services[p.id] = s
+		s.start()
+	}
+}
+
+// StopServices stops all running service processes.
+func (c *Cache) StopServices() {
+	for _, s := range c.services {
+		s.stop()
+	}
+
+	c.services = nil
+}
+
 func loadPlugins(path string) ([]Config, error) {
 	plugins := make([]Config, 0)
 
@@ -117,7 +159,12 @@ func loadPlugins(path string) ([]Config, error) {
 func (c Cache) ListPlugins() []*Plugin {
 	var ret []*Plugin
 	for _, s := range c.plugins {
-		ret = append(ret, s.toPlugin())
+		p := s.toPlugin()
+		p.Settings = s.getPluginSettings(c.getSettingValues(&s))
+		if svc := c.services[s.id]; svc != nil {
+			p.Service = svc.getStatus()
+		}
+		ret = append(ret, p)
 	}
 
 	return ret
@@ -133,12 +180,13 @@ func (c Cache) ListPluginTasks() []*PluginTask {
 	return ret
 }
 
-func buildPluginInput(plugin *Config, operation *OperationConfig, serverConnection common.StashServerConnection, args []*PluginArgInput) common.PluginInput {
+func (c Cache) buildPluginInput(plugin *Config, operation *OperationConfig, serverConnection common.StashServerConnection, args []*PluginArgInput) common.PluginInput {
 	args = applyDefaultArgs(args, operation.DefaultArgs)
 	serverConnection.PluginDir = plugin.getConfigPath()
 	return common.PluginInput{
 		ServerConnection: serverConnection,
 		Args:             toPluginArgs(args),
+		Settings:         c.getSettingValues(plugin),
 	}
 }
 
@@ -181,14 +229,27 @@ func (c Cache) CreateTask(ctx context.Context, pluginID string, operationName st
 	task := pluginTask{
 		plugin:       plugin,
 		operation:    operation,
-		input:        buildPluginInput(plugin, operation, serverConnection, args),
+		input:        c.buildPluginInput(plugin, operation, serverConnection, args),
 		progress:     progress,
 		gqlHandler:   c.gqlHandler,
 		serverConfig: c.config,
+		service:      c.services[pluginID],
 	}
 	return task.createTask(), nil
 }
 
+// TaskHasResult returns true if the task with the provided name declares a
+// result.
+func (c Cache) TaskHasResult(pluginID string, operationName string) bool {
+	plugin := c.getPlugin(pluginID)
+	if plugin == nil {
+		return false
+	}
+
+	operation := plugin.getTask(operationName)
+	return operation != nil && operation.Result != nil
+}
+
 func (c Cache) ExecutePostHooks(ctx context.Context, id int, hookType HookTriggerEnum, input interface{}, inputFields []string) {
 	if err := c.executePostHooks(ctx, hookType, common.HookContext{
 		ID:          id,
@@ -232,7 +293,7 @@ func (c Cache) executePostHooks(ctx context.Context, hookType HookTriggerEnum, h
 			newCtx := session.AddVisitedPlugin(ctx, p.id)
 			serverConnection := c.makeServerConnection(newCtx)
 
-			pluginInput := buildPluginInput(&p, &h.OperationConfig, serverConnection, nil)
+			pluginInput := c.buildPluginInput(&p, &h.OperationConfig, serverConnection, nil)
 			addHookContext(pluginInput.Args, hookContext)
 
 			pt := pluginTask{
@@ -241,30 +302,14 @@ func (c Cache) executePostHooks(ctx context.Context, hookType HookTriggerEnum, h
 				input:        pluginInput,
 				gqlHandler:   c.gqlHandler,
 				serverConfig: c.config,
+				service:      c.services[p.id],
 			}
 
 			task := pt.createTask()
-			if err := task.Start(); err != nil {
+			if err := runTask(ctx, task); err != nil {
 				return err
 			}
 
-			// handle cancel from context
-			c := make(chan struct{})
-			go func() {
-				task.Wait()
-				close(c)
-			}()
-
-			select {
-			case <-ctx.Done():
-				if err := task.Stop(); err != nil {
-					logger.Warnf("could not stop task: %v", err)
-				}
-				return fmt.Errorf("operation cancelled")
-			case <-c:
-				// task finished normally
-			}
-
 			output := task.GetResult()
 			if output == nil {
 				logger.Debugf("%s [%s]: returned no result", hookType.String(), p.Name)
@@ -281,6 +326,32 @@ func (c Cache) executePostHooks(ctx context.Context, hookType HookTriggerEnum, h
 	return nil
 }
 
+// runTask starts the task and waits for it to complete. The task is stopped
+// if the context is cancelled.
+func runTask(ctx context.Context, task Task) error {
+	if err := task.Start(); err != nil {
+		return err
+	}
+
+	// handle cancel from context
+	c := make(chan struct{})
+	go func() {
+		task.Wait()
+		close(c)
+	}()
+
+	select {
+	case <-ctx.Done():
+		if err := task.Stop(); err != nil {
+			logger.Warnf("could not stop task: %v", err)
+		}
+		return fmt.Errorf("operation cancelled")
+	case <-c:
+		// task finished normally
+		return nil
+	}
+}
+
 func (c Cache) getPlugin(pluginID string) *Config {
 	for _, s := range c.plugins {
 		if s.id == pluginID {
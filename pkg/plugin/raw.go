@@ -42,26 +42,7 @@ func (t *rawPluginTask) Start() error {
 		return fmt.Errorf("empty exec value in operation %s", t.operation.Name)
 	}
 
-	var cmd *exec.Cmd
-	if python.IsPythonCommand(command[0]) {
-		pythonPath := t.serverConfig.GetPythonPath()
-		var p *python.Python
-		if pythonPath != "" {
-			p = python.New(pythonPath)
-		} else {
-			p, _ = python.Resolve()
-		}
-
-		if p != nil {
-			cmd = p.Command(context.TODO(), command[1:])
-		}
-
-		// if could not find python, just use the command args as-is
-	}
-
-	if cmd == nil {
-		cmd = stashExec.Command(command[0], command[1:]...)
-	}
+	cmd := makeCommand(command, t.serverConfig)
 
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
@@ -121,6 +102,28 @@ func (t *rawPluginTask) Start() error {
 	return nil
 }
 
+// makeCommand returns the command to execute for a plugin. Python commands
+// are executed using the configured python path if set.
+func makeCommand(command []string, serverConfig ServerConfig) *exec.Cmd {
+	if python.IsPythonCommand(command[0]) {
+		pythonPath := serverConfig.GetPythonPath()
+		var p *python.Python
+		if pythonPath != "" {
+			p = python.New(pythonPath)
+		} else {
+			p, _ = python.Resolve()
+		}
+
+		if p != nil {
+			return p.Command(context.TODO(), command[1:])
+		}
+
+		// if could not find python, just use the command args as-is
+	}
+
+	return stashExec.Command(command[0], command[1:]...)
+}
+
 func (t *rawPluginTask) getOutput(output string) common.PluginOutput {
 	// try to parse the output as a PluginOutput json. If it fails just
 	// get the raw output
@@ -0,0 +1,85 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/plugin/common"
+	"gopkg.in/yaml.v2"
+)
+
+// GetScraperConfigs returns the scraper configurations provided by the loaded
+// plugins, encoded as YAML and keyed by plugin ID. The plugin name is used as
+// the scraper name if the scraper configuration does not include one.
+func (c Cache) GetScraperConfigs() map[string][]byte {
+	ret := make(map[string][]byte)
+	for _, p := range c.plugins {
+		if p.Scraper == nil {
+			continue
+		}
+
+		conf := make(map[string]interface{})
+		for k, v := range p.Scraper {
+			conf[k] = v
+		}
+
+		if _, found := conf["name"]; !found {
+			conf["name"] = p.getName()
+		}
+
+		out, err := yaml.Marshal(conf)
+		if err != nil {
+			logger.Errorf("[Plugin / %s] error encoding scraper configuration: %v", p.getName(), err)
+			continue
+		}
+
+		ret[p.id] = out
+	}
+
+	return ret
+}
+
+// RunScraper sends a scrape request to the plugin with the provided ID. The
+// scrape context is added to the plugin arguments. Returns the output of the
+// plugin.
+//
+// For service plugins, the request is sent to the running service process.
+func (c Cache) RunScraper(ctx context.Context, pluginID string, scrapeContext common.ScrapeContext) (interface{}, error) {
+	plugin := c.getPlugin(pluginID)
+	if plugin == nil {
+		return nil, fmt.Errorf("no plugin with ID %s", pluginID)
+	}
+
+	serverConnection := c.makeServerConnection(ctx)
+	operation := &OperationConfig{}
+
+	input := c.buildPluginInput(plugin, operation, serverConnection, nil)
+	input.Args[common.ScrapeContextKey] = scrapeContext
+
+	pt := pluginTask{
+		plugin:       plugin,
+		operation:    operation,
+		input:        input,
+		gqlHandler:   c.gqlHandler,
+		serverConfig: c.config,
+		service:      c.services[pluginID],
+	}
+
+	task := pt.createTask()
+	if err := runTask(ctx, task); err != nil {
+		return nil, err
+	}
+
+	output := task.GetResult()
+	if output == nil {
+		return nil, nil
+	}
+
+	if output.Error != nil {
+		return nil, errors.New(*output.Error)
+	}
+
+	return output.Output, nil
+}
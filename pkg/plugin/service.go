@@ -0,0 +1,410 @@
+package plugin
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os/exec"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/plugin/common"
+)
+
+const (
+	serviceMinBackoff = time.Second
+	serviceMaxBackoff = 5 * time.Minute
+
+	// serviceStableDuration is the time after which a running service is
+	// considered stable. The restart backoff is reset if a stable service
+	// exits.
+	serviceStableDuration = time.Minute
+
+	// serviceStopTimeout is the time to wait for a service process to exit
+	// after its connection is closed before it is killed.
+	serviceStopTimeout = 5 * time.Second
+)
+
+var errServiceNotRunning = errors.New("service is not running")
+
+type PluginServiceState string
+
+const (
+	// PluginServiceStateStarting indicates that the service process is
+	// being started.
+	PluginServiceStateStarting PluginServiceState = "STARTING"
+	// PluginServiceStateRunning indicates that the service process is
+	// running and accepting operations.
+	PluginServiceStateRunning PluginServiceState = "RUNNING"
+	// PluginServiceStateRestarting indicates that the service process
+	// exited unexpectedly and is waiting to be restarted.
+	PluginServiceStateRestarting PluginServiceState = "RESTARTING"
+	// PluginServiceStateStopped indicates that the service was stopped by
+	// stash.
+	PluginServiceStateStopped PluginServiceState = "STOPPED"
+)
+
+var AllPluginServiceState = []PluginServiceState{
+	PluginServiceStateStarting,
+	PluginServiceStateRunning,
+	PluginServiceStateRestarting,
+	PluginServiceStateStopped,
+}
+
+func (e PluginServiceState) IsValid() bool {
+	switch e {
+	case PluginServiceStateStarting, PluginServiceStateRunning, PluginServiceStateRestarting, PluginServiceStateStopped:
+		return true
+	}
+	return false
+}
+
+func (e PluginServiceState) String() string {
+	return string(e)
+}
+
+func (e *PluginServiceState) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PluginServiceState(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PluginServiceState", str)
+	}
+	return nil
+}
+
+func (e PluginServiceState) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// PluginServiceStatus describes the health of the process of a service
+// plugin.
+type PluginServiceStatus struct {
+	State PluginServiceState `json:"state"`
+	// Process ID of the running service process
+	Pid *int `json:"pid"`
+	// Time that the current service process was started
+	StartTime *time.Time `json:"startTime"`
+	// Number of times the service process has been restarted
+	RestartCount int `json:"restartCount"`
+	// The reason that the service process last exited
+	LastError *string `json:"lastError"`
+}
+
+// processPipe is the connection to a service process over its stdin and
+// stdout.
+type processPipe struct {
+	io.ReadCloser
+	w io.WriteCloser
+}
+
+func (p processPipe) Write(b []byte) (int, error) {
+	return p.w.Write(b)
+}
+
+func (p processPipe) Close() error {
+	err := p.w.Close()
+	if rErr := p.ReadCloser.Close(); err == nil {
+		err = rErr
+	}
+	return err
+}
+
+// service manages the persistent process of a service plugin. The process is
+// restarted with exponential backoff if it exits unexpectedly.
+type service struct {
+	plugin       *Config
+	serverConfig ServerConfig
+
+	// timings default to the service constants and are only changed in
+	// tests
+	minBackoff     time.Duration
+	maxBackoff     time.Duration
+	stableDuration time.Duration
+	stopTimeout    time.Duration
+	after          func(d time.Duration) <-chan time.Time
+
+	mu     sync.Mutex
+	status PluginServiceStatus
+	client *rpc.Client
+
+	stopChan chan struct{}
+	stopped  chan struct{}
+}
+
+func newService(plugin *Config, serverConfig ServerConfig) *service {
+	return &service{
+		plugin:         plugin,
+		serverConfig:   serverConfig,
+		minBackoff:     serviceMinBackoff,
+		maxBackoff:     serviceMaxBackoff,
+		stableDuration: serviceStableDuration,
+		stopTimeout:    serviceStopTimeout,
+		after:          time.After,
+		status: PluginServiceStatus{
+			State: PluginServiceStateStopped,
+		},
+		stopChan: make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+}
+
+func (s *service) start() {
+	go s.run()
+}
+
+// stop stops the service process and waits for it to exit.
+func (s *service) stop() {
+	close(s.stopChan)
+	<-s.stopped
+}
+
+func (s *service) getStatus() *PluginServiceStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ret := s.status
+	return &ret
+}
+
+func (s *service) getClient() (*rpc.Client, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client == nil {
+		return nil, errServiceNotRunning
+	}
+
+	return s.client, nil
+}
+
+func (s *service) setState(state PluginServiceState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status.State = state
+	if state != PluginServiceStateRunning {
+		s.status.Pid = nil
+		s.status.StartTime = nil
+	}
+}
+
+func (s *service) isStopping() bool {
+	select {
+	case <-s.stopChan:
+		return true
+	default:
+		return false
+	}
+}
+
+func (s *service) run() {
+	defer close(s.stopped)
+	defer s.setState(PluginServiceStateStopped)
+
+	backoff := s.minBackoff
+	for {
+		startTime := time.Now()
+		err := s.runProcess()
+
+		if s.isStopping() {
+			return
+		}
+
+		if err == nil {
+			err = errors.New("process exited")
+		}
+
+		if time.Since(startTime) > s.stableDuration {
+			backoff = s.minBackoff
+		}
+
+		logger.Errorf("[Plugin / %s] service stopped: %v. Restarting in %s", s.plugin.getName(), err, backoff)
+
+		errStr := err.Error()
+		s.mu.Lock()
+		s.status.LastError = &errStr
+		s.mu.Unlock()
+		s.setState(PluginServiceStateRestarting)
+
+		select {
+		case <-s.stopChan:
+			return
+		case <-s.after(backoff):
+		}
+
+		s.mu.Lock()
+		s.status.RestartCount++
+		s.mu.Unlock()
+
+		backoff *= 2
+		if backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+}
+
+// runProcess starts the service process and blocks until it exits or the
+// service is stopped.
+func (s *service) runProcess() error {
+	s.setState(PluginServiceStateStarting)
+
+	command := s.plugin.getExecCommand(&OperationConfig{})
+	if len(command) == 0 {
+		return errors.New("empty exec value")
+	}
+
+	cmd := makeCommand(command, s.serverConfig)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("error getting service stdin: %w", err)
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return fmt.Errorf("error getting service stdout: %w", err)
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("error getting service stderr: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("error starting service: %w", err)
+	}
+
+	// progress is not reported for service operations
+	lt := &pluginTask{plugin: s.plugin}
+	go lt.handlePluginStderr(s.plugin.Name, stderr)
+
+	client := jsonrpc.NewClient(processPipe{ReadCloser: stdout, w: stdin})
+
+	now := time.Now()
+	pid := cmd.Process.Pid
+	s.mu.Lock()
+	s.client = client
+	s.status.State = PluginServiceStateRunning
+	s.status.Pid = &pid
+	s.status.StartTime = &now
+	s.mu.Unlock()
+
+	logger.Infof("[Plugin / %s] service started", s.plugin.getName())
+
+	exited := make(chan error, 1)
+	go func() {
+		exited <- cmd.Wait()
+	}()
+
+	defer func() {
+		s.mu.Lock()
+		s.client = nil
+		s.mu.Unlock()
+	}()
+
+	select {
+	case err := <-exited:
+		client.Close()
+		return err
+	case <-s.stopChan:
+		return s.stopProcess(cmd, client, exited)
+	}
+}
+
+// stopProcess closes the connection to the service process, which should
+// cause it to exit. The process is killed if it does not exit in time.
+func (s *service) stopProcess(cmd *exec.Cmd, client *rpc.Client, exited chan error) error {
+	client.Close()
+
+	select {
+	case err := <-exited:
+		return err
+	case <-time.After(s.stopTimeout):
+		logger.Warnf("[Plugin / %s] service did not stop, killing process", s.plugin.getName())
+		if err := cmd.Process.Kill(); err != nil {
+			return err
+		}
+		return <-exited
+	}
+}
+
+type serviceTaskBuilder struct{}
+
+func (*serviceTaskBuilder) build(task pluginTask) Task {
+	return &servicePluginTask{
+		pluginTask: task,
+	}
+}
+
+// servicePluginTask runs a plugin operation using the persistent connection
+// to a service process.
+type servicePluginTask struct {
+	pluginTask
+
+	started   bool
+	client    *rpc.Client
+	waitGroup sync.WaitGroup
+}
+
+func (t *servicePluginTask) Start() error {
+	if t.started {
+		return errors.New("task already started")
+	}
+
+	if t.service == nil {
+		return errServiceNotRunning
+	}
+
+	client, err := t.service.getClient()
+	if err != nil {
+		return err
+	}
+
+	t.client = client
+	iface := rpcPluginClient{
+		Client: client,
+	}
+
+	done := make(chan *rpc.Call, 1)
+	result := common.PluginOutput{}
+	t.waitGroup.Add(1)
+	iface.RunAsync(t.input, &result, done)
+
+	go func() {
+		defer t.waitGroup.Done()
+		call := <-done
+		if call.Error != nil && result.Error == nil {
+			result.SetError(call.Error)
+		}
+		t.result = &result
+	}()
+
+	t.started = true
+	return nil
+}
+
+func (t *servicePluginTask) Wait() {
+	t.waitGroup.Wait()
+}
+
+// Stop calls the Stop method of the service. Services are expected to stop
+// the running operation, but the service process itself remains running.
+func (t *servicePluginTask) Stop() error {
+	if t.client == nil {
+		return nil
+	}
+
+	iface := rpcPluginClient{
+		Client: t.client,
+	}
+
+	return iface.Stop()
+}
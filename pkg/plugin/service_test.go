@@ -0,0 +1,206 @@
+package plugin
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	serviceHelperModeEnv   = "STASH_TEST_SERVICE_MODE"
+	serviceHelperMarkerEnv = "STASH_TEST_SERVICE_MARKER"
+
+	// exits with an error
+	serviceHelperCrash = "crash"
+	// exits with an error the first time, then serves
+	serviceHelperCrashOnce = "crash-once"
+	// exits when stdin is closed
+	serviceHelperServe = "serve"
+	// does not exit when stdin is closed
+	serviceHelperHang = "hang"
+)
+
+// TestServiceHelperProcess is not a real test. It is the fake service
+// process started by the service tests.
+func TestServiceHelperProcess(t *testing.T) {
+	mode := os.Getenv(serviceHelperModeEnv)
+	if mode == "" {
+		return
+	}
+
+	switch mode {
+	case serviceHelperCrash:
+		os.Exit(1)
+	case serviceHelperCrashOnce:
+		marker := os.Getenv(serviceHelperMarkerEnv)
+		if _, err := os.Stat(marker); err != nil {
+			_ = os.WriteFile(marker, nil, 0644)
+			os.Exit(1)
+		}
+		_, _ = io.Copy(io.Discard, os.Stdin)
+	case serviceHelperServe:
+		_, _ = io.Copy(io.Discard, os.Stdin)
+	case serviceHelperHang:
+		_, _ = io.Copy(io.Discard, os.Stdin)
+		time.Sleep(time.Hour)
+	}
+
+	os.Exit(0)
+}
+
+type serviceBackoffRecorder struct {
+	mu       sync.Mutex
+	backoffs []time.Duration
+}
+
+// after records the backoff and returns immediately.
+func (r *serviceBackoffRecorder) after(d time.Duration) <-chan time.Time {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.backoffs = append(r.backoffs, d)
+	ret := make(chan time.Time, 1)
+	ret <- time.Now()
+	return ret
+}
+
+func (r *serviceBackoffRecorder) get() []time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]time.Duration{}, r.backoffs...)
+}
+
+func newTestService(t *testing.T, mode string) *service {
+	t.Setenv(serviceHelperModeEnv, mode)
+	t.Setenv(serviceHelperMarkerEnv, filepath.Join(t.TempDir(), "marker"))
+
+	s := newService(&Config{
+		Name: "test",
+		Exec: []string{os.Args[0], "-test.run=^TestServiceHelperProcess$"},
+	}, nil)
+	s.minBackoff = time.Millisecond
+	s.maxBackoff = 4 * time.Millisecond
+	return s
+}
+
+func waitForService(t *testing.T, s *service, cond func(status *PluginServiceStatus) bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !cond(s.getStatus()) {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for service, status: %+v", s.getStatus())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestService_restart(t *testing.T) {
+	s := newTestService(t, serviceHelperCrashOnce)
+	recorder := &serviceBackoffRecorder{}
+	s.after = recorder.after
+
+	s.start()
+	defer s.stop()
+
+	waitForService(t, s, func(status *PluginServiceStatus) bool {
+		return status.State == PluginServiceStateRunning && status.RestartCount == 1
+	})
+
+	status := s.getStatus()
+	assert.NotNil(t, status.Pid)
+	assert.NotNil(t, status.StartTime)
+	assert.NotNil(t, status.LastError)
+	assert.Equal(t, []time.Duration{time.Millisecond}, recorder.get())
+
+	if _, err := s.getClient(); err != nil {
+		t.Errorf("service.getClient() error = %v", err)
+	}
+}
+
+func TestService_backoff(t *testing.T) {
+	s := newTestService(t, serviceHelperCrash)
+	recorder := &serviceBackoffRecorder{}
+	s.after = recorder.after
+
+	s.start()
+
+	waitForService(t, s, func(status *PluginServiceStatus) bool {
+		return status.RestartCount >= 5
+	})
+
+	s.stop()
+
+	want := []time.Duration{
+		time.Millisecond,
+		2 * time.Millisecond,
+		4 * time.Millisecond,
+		4 * time.Millisecond,
+		4 * time.Millisecond,
+	}
+	assert.Equal(t, want, recorder.get()[:len(want)])
+	assert.Equal(t, PluginServiceStateStopped, s.getStatus().State)
+}
+
+func TestService_stop(t *testing.T) {
+	tests := []struct {
+		name        string
+		mode        string
+		stopTimeout time.Duration
+		wantElapsed bool
+	}{
+		{
+			"exits",
+			serviceHelperServe,
+			5 * time.Second,
+			false,
+		},
+		{
+			"killed after timeout",
+			serviceHelperHang,
+			200 * time.Millisecond,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(t, tt.mode)
+			s.stopTimeout = tt.stopTimeout
+			s.start()
+
+			waitForService(t, s, func(status *PluginServiceStatus) bool {
+				return status.State == PluginServiceStateRunning
+			})
+
+			start := time.Now()
+			stopped := make(chan struct{})
+			go func() {
+				s.stop()
+				close(stopped)
+			}()
+
+			select {
+			case <-stopped:
+			case <-time.After(10 * time.Second):
+				t.Fatal("timed out waiting for service to stop")
+			}
+
+			assert.Equal(t, tt.wantElapsed, time.Since(start) >= tt.stopTimeout, "stop timeout elapsed")
+
+			status := s.getStatus()
+			assert.Equal(t, PluginServiceStateStopped, status.State)
+			assert.Nil(t, status.Pid)
+			assert.Equal(t, 0, status.RestartCount)
+
+			if _, err := s.getClient(); err != errServiceNotRunning {
+				t.Errorf("service.getClient() error = %v, want %v", err, errServiceNotRunning)
+			}
+		})
+	}
+}
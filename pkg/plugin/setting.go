@@ -0,0 +1,284 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type PluginSettingTypeEnum string
+
+const (
+	PluginSettingTypeEnumString  PluginSettingTypeEnum = "STRING"
+	PluginSettingTypeEnumNumber  PluginSettingTypeEnum = "NUMBER"
+	PluginSettingTypeEnumBoolean PluginSettingTypeEnum = "BOOLEAN"
+	PluginSettingTypeEnumEnum    PluginSettingTypeEnum = "ENUM"
+)
+
+var AllPluginSettingTypeEnum = []PluginSettingTypeEnum{
+	PluginSettingTypeEnumString,
+	PluginSettingTypeEnumNumber,
+	PluginSettingTypeEnumBoolean,
+	PluginSettingTypeEnumEnum,
+}
+
+func (e PluginSettingTypeEnum) IsValid() bool {
+	switch e {
+	case PluginSettingTypeEnumString, PluginSettingTypeEnumNumber, PluginSettingTypeEnumBoolean, PluginSettingTypeEnumEnum:
+		return true
+	}
+	return false
+}
+
+func (e PluginSettingTypeEnum) String() string {
+	return string(e)
+}
+
+func (e *PluginSettingTypeEnum) UnmarshalGQL(v interface{}) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PluginSettingTypeEnum(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PluginSettingTypeEnum", str)
+	}
+	return nil
+}
+
+func (e PluginSettingTypeEnum) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+// UnmarshalYAML allows the setting type to be specified in any case.
+func (e *PluginSettingTypeEnum) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var str string
+	if err := unmarshal(&str); err != nil {
+		return err
+	}
+
+	*e = PluginSettingTypeEnum(strings.ToUpper(str))
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid setting type", str)
+	}
+	return nil
+}
+
+// PluginSetting describes a user-configurable setting of a plugin, and its
+// current value.
+type PluginSetting struct {
+	Name        string                `json:"name"`
+	DisplayName *string               `json:"displayName"`
+	Description *string               `json:"description"`
+	Type        PluginSettingTypeEnum `json:"type"`
+	Options     []string              `json:"options"`
+	Default     interface{}           `json:"default"`
+	Value       interface{}           `json:"value"`
+}
+
+// SettingConfig describes the configuration of a single plugin setting.
+type SettingConfig struct {
+	// The name displayed in the UI. Defaults to the setting name.
+	DisplayName string `yaml:"displayName"`
+
+	// A description of the setting.
+	Description string `yaml:"description"`
+
+	// The type of the setting value. One of string, number, boolean or enum.
+	Type PluginSettingTypeEnum `yaml:"type"`
+
+	// The valid values of an enum setting.
+	Options []string `yaml:"options"`
+
+	// The value used if the setting is not configured.
+	Default interface{} `yaml:"default"`
+}
+
+// coerce converts v into the type of the setting. Returns an error if v is
+// not a valid value for the setting. A nil value is returned as nil.
+func (s SettingConfig) coerce(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	switch s.Type {
+	case PluginSettingTypeEnumString:
+		if str, ok := v.(string); ok {
+			return str, nil
+		}
+	case PluginSettingTypeEnumBoolean:
+		if b, ok := v.(bool); ok {
+			return b, nil
+		}
+	case PluginSettingTypeEnumNumber:
+		switch n := v.(type) {
+		case float64:
+			return n, nil
+		case float32:
+			return float64(n), nil
+		case int:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		case json.Number:
+			return n.Float64()
+		}
+	case PluginSettingTypeEnumEnum:
+		if str, ok := v.(string); ok {
+			for _, o := range s.Options {
+				if o == str {
+					return str, nil
+				}
+			}
+			return nil, fmt.Errorf("%q is not a valid option", str)
+		}
+	}
+
+	return nil, fmt.Errorf("invalid %s value %v", strings.ToLower(s.Type.String()), v)
+}
+
+func (s SettingConfig) validate() error {
+	if !s.Type.IsValid() {
+		return fmt.Errorf("invalid type %q", s.Type)
+	}
+
+	if s.Type == PluginSettingTypeEnumEnum && len(s.Options) == 0 {
+		return fmt.Errorf("enum setting has no options")
+	}
+
+	if _, err := s.coerce(s.Default); err != nil {
+		return fmt.Errorf("invalid default value: %w", err)
+	}
+
+	return nil
+}
+
+func (c Config) validateSettings() error {
+	for name, s := range c.Settings {
+		if err := s.validate(); err != nil {
+			return fmt.Errorf("setting %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// getSettingName returns the configured name of the setting matching name
+// case-insensitively. Returns an empty string if no setting matches.
+func (c Config) getSettingName(name string) string {
+	if _, found := c.Settings[name]; found {
+		return name
+	}
+
+	for k := range c.Settings {
+		if strings.EqualFold(k, name) {
+			return k
+		}
+	}
+
+	return ""
+}
+
+// resolveSettings returns the values of all settings, using the stored
+// values where present and valid, and the default values otherwise.
+func (c Config) resolveSettings(stored map[string]interface{}) map[string]interface{} {
+	ret := make(map[string]interface{})
+	for name, s := range c.Settings {
+		v, _ := s.coerce(s.Default)
+		ret[name] = v
+	}
+
+	for k, v := range stored {
+		name := c.getSettingName(k)
+		if name == "" {
+			continue
+		}
+
+		if cv, err := c.Settings[name].coerce(v); err == nil && cv != nil {
+			ret[name] = cv
+		}
+	}
+
+	return ret
+}
+
+func (c Config) getPluginSettings(values map[string]interface{}) []*PluginSetting {
+	var names []string
+	for name := range c.Settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var ret []*PluginSetting
+	for _, name := range names {
+		s := c.Settings[name]
+		setting := &PluginSetting{
+			Name:    name,
+			Type:    s.Type,
+			Options: s.Options,
+			Value:   values[name],
+		}
+		setting.Default, _ = s.coerce(s.Default)
+
+		if s.DisplayName != "" {
+			displayName := s.DisplayName
+			setting.DisplayName = &displayName
+		}
+		if s.Description != "" {
+			description := s.Description
+			setting.Description = &description
+		}
+
+		ret = append(ret, setting)
+	}
+
+	return ret
+}
+
+// ValidatePluginSettings validates the provided setting values against the
+// settings of the plugin. Returns the values converted to the setting types.
+// Nil values are omitted from the returned map, so that the default value is
+// used.
+func (c Cache) ValidatePluginSettings(pluginID string, input map[string]interface{}) (map[string]interface{}, error) {
+	plugin := c.getPlugin(pluginID)
+	if plugin == nil {
+		return nil, fmt.Errorf("no plugin with ID %s", pluginID)
+	}
+
+	ret := make(map[string]interface{})
+	for k, v := range input {
+		name := plugin.getSettingName(k)
+		if name == "" {
+			return nil, fmt.Errorf("plugin %s has no setting %s", pluginID, k)
+		}
+
+		cv, err := plugin.Settings[name].coerce(v)
+		if err != nil {
+			return nil, fmt.Errorf("setting %s: %w", name, err)
+		}
+
+		if cv != nil {
+			ret[name] = cv
+		}
+	}
+
+	return ret, nil
+}
+
+// GetPluginSettings returns the current values of all settings of the plugin.
+func (c Cache) GetPluginSettings(pluginID string) (map[string]interface{}, error) {
+	plugin := c.getPlugin(pluginID)
+	if plugin == nil {
+		return nil, fmt.Errorf("no plugin with ID %s", pluginID)
+	}
+
+	return c.getSettingValues(plugin), nil
+}
+
+func (c Cache) getSettingValues(plugin *Config) map[string]interface{} {
+	return plugin.resolveSettings(c.config.GetPluginConfiguration(plugin.id))
+}
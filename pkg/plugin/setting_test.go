@@ -0,0 +1,103 @@
+package plugin
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const settingsYAML = `
+name: test
+exec:
+  - test.js
+interface: goja
+settings:
+  tagName:
+    displayName: Tag name
+    description: Name of the tag to add
+    type: string
+    default: hawwwwt
+  maxScenes:
+    type: NUMBER
+    default: 10
+  dryRun:
+    type: boolean
+  mode:
+    type: enum
+    options:
+      - add
+      - remove
+    default: add
+`
+
+func TestLoadPluginSettings(t *testing.T) {
+	c, err := loadPluginFromYAML(strings.NewReader(settingsYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	settings := c.getPluginSettings(c.resolveSettings(nil))
+	assert.Len(t, settings, 4)
+
+	// sorted by name
+	assert.Equal(t, "dryRun", settings[0].Name)
+	assert.Nil(t, settings[0].Value)
+	assert.Equal(t, float64(10), settings[1].Value)
+	assert.Equal(t, "add", settings[2].Value)
+	assert.Equal(t, "hawwwwt", settings[3].Value)
+	assert.Equal(t, "Tag name", *settings[3].DisplayName)
+}
+
+func TestLoadPluginSettingsInvalid(t *testing.T) {
+	invalid := []string{
+		"settings:\n  a:\n    type: date\n",
+		"settings:\n  a:\n    type: enum\n",
+		"settings:\n  a:\n    type: number\n    default: abc\n",
+		"settings:\n  a:\n    type: enum\n    options: [x]\n    default: y\n",
+	}
+
+	for _, s := range invalid {
+		_, err := loadPluginFromYAML(strings.NewReader("name: test\n" + s))
+		assert.Error(t, err, s)
+	}
+}
+
+func TestResolveSettings(t *testing.T) {
+	c, err := loadPluginFromYAML(strings.NewReader(settingsYAML))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	// stored keys are lower case, and may be of the wrong type
+	got := c.resolveSettings(map[string]interface{}{
+		"tagname":   "other",
+		"maxscenes": 5,
+		"dryrun":    "yes",
+		"mode":      "invalid",
+		"unknown":   true,
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"tagName":   "other",
+		"maxScenes": float64(5),
+		"dryRun":    nil,
+		"mode":      "add",
+	}, got)
+}
+
+func TestSettingCoerce(t *testing.T) {
+	number := SettingConfig{Type: PluginSettingTypeEnumNumber}
+	v, err := number.coerce(json.Number("1.5"))
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, v)
+
+	_, err = number.coerce("1.5")
+	assert.Error(t, err)
+
+	boolean := SettingConfig{Type: PluginSettingTypeEnumBoolean}
+	v, err = boolean.coerce(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, v)
+}
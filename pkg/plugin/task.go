@@ -10,6 +10,14 @@ type PluginTask struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
 	Plugin      *Plugin `json:"plugin"`
+
+	// Result describes the result returned by the task. Nil if the task
+	// does not declare a result.
+	Result *PluginTaskResult `json:"result"`
+}
+
+type PluginTaskResult struct {
+	Description *string `json:"description"`
 }
 
 // Task is the interface that handles management of a single plugin task.
@@ -42,6 +50,9 @@ type pluginTask struct {
 	gqlHandler   http.Handler
 	serverConfig ServerConfig
 
+	// service is the running service for service plugins
+	service *service
+
 	progress chan float64
 	result   *common.PluginOutput
 }
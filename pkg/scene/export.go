@@ -40,7 +40,7 @@ func ToBasicJSON(ctx context.Context, reader CoverGetter, scene *models.Scene) (
 	newSceneJSON := jsonschema.Scene{
 		Title:     scene.Title,
 		Code:      scene.Code,
-		URL:       scene.URL,
+		URLs:      scene.URLs.List(),
 		Details:   scene.Details,
 		Director:  scene.Director,
 		CreatedAt: json.JSONTime{Time: scene.CreatedAt},
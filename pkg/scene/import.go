@@ -19,6 +19,7 @@ import (
 
 type FullCreatorUpdater interface {
 	CreatorUpdater
+	models.CustomFieldsWriter
 	Update(ctx context.Context, updatedScene *models.Scene) error
 	Updater
 }
@@ -85,7 +86,7 @@ func (i *Importer) sceneJSONToScene(sceneJSON jsonschema.Scene) models.Scene {
 		Code:         sceneJSON.Code,
 		Details:      sceneJSON.Details,
 		Director:     sceneJSON.Director,
-		URL:          sceneJSON.URL,
+		URLs:         models.NewRelatedStrings(importURLs(sceneJSON.URLs, sceneJSON.URL)),
 		PerformerIDs: models.NewRelatedIDs([]int{}),
 		TagIDs:       models.NewRelatedIDs([]int{}),
 		GalleryIDs:   models.NewRelatedIDs([]int{}),
@@ -360,6 +361,12 @@ func (i *Importer) PostImport(ctx context.Context, id int) error {
 		}
 	}
 
+	if len(i.Input.CustomFields) > 0 {
+		if err := i.ReaderWriter.SetCustomFields(ctx, id, models.CustomFieldsInput{Full: i.Input.CustomFields}); err != nil {
+			return fmt.Errorf("error setting custom fields: %v", err)
+		}
+	}
+
 	return nil
 }
 
@@ -469,3 +476,17 @@ func createTags(ctx context.Context, tagWriter tag.NameFinderCreator, names []st
 
 	return ret, nil
 }
+
+// importURLs returns the urls to import, falling back to the url field of
+// files exported before scenes supported multiple urls.
+func importURLs(urls []string, legacyURL string) []string {
+	if len(urls) > 0 {
+		return urls
+	}
+
+	if legacyURL != "" {
+		return []string{legacyURL}
+	}
+
+	return []string{}
+}
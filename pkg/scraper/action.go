@@ -14,11 +14,12 @@ const (
 	scraperActionStash  scraperAction = "stash"
 	scraperActionXPath  scraperAction = "scrapeXPath"
 	scraperActionJson   scraperAction = "scrapeJson"
+	scraperActionPlugin scraperAction = "plugin"
 )
 
 func (e scraperAction) IsValid() bool {
 	switch e {
-	case scraperActionScript, scraperActionStash, scraperActionXPath, scraperActionJson:
+	case scraperActionScript, scraperActionStash, scraperActionXPath, scraperActionJson, scraperActionPlugin:
 		return true
 	}
 	return false
@@ -31,6 +32,7 @@ type scraperActionImpl interface {
 
 	scrapeSceneByScene(ctx context.Context, scene *models.Scene) (*ScrapedScene, error)
 	scrapeGalleryByGallery(ctx context.Context, gallery *models.Gallery) (*ScrapedGallery, error)
+	scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error)
 }
 
 func (c config) getScraper(scraper scraperTypeConfig, client *http.Client, globalConfig GlobalConfig) scraperActionImpl {
@@ -43,6 +45,8 @@ func (c config) getScraper(scraper scraperTypeConfig, client *http.Client, globa
 		return newXpathScraper(scraper, client, c, globalConfig)
 	case scraperActionJson:
 		return newJsonScraper(scraper, client, c, globalConfig)
+	case scraperActionPlugin:
+		return newPluginScraper(scraper, c)
 	}
 
 	panic("unknown scraper action: " + scraper.Action)
@@ -176,12 +176,56 @@ func (s autotagScraper) viaGallery(ctx context.Context, _client *http.Client, ga
 	return ret, nil
 }
 
+func (s autotagScraper) viaImage(ctx context.Context, _client *http.Client, image *models.Image) (*ScrapedImage, error) {
+	path := image.Path
+	if path == "" {
+		return nil, nil
+	}
+
+	const trimExt = true
+
+	var ret *ScrapedImage
+
+	// populate performers, studio and tags based on image path
+	if err := txn.WithTxn(ctx, s.txnManager, func(ctx context.Context) error {
+		performers, err := autotagMatchPerformers(ctx, path, s.performerReader, trimExt)
+		if err != nil {
+			return fmt.Errorf("autotag scraper viaImage: %w", err)
+		}
+		studio, err := autotagMatchStudio(ctx, path, s.studioReader, trimExt)
+		if err != nil {
+			return fmt.Errorf("autotag scraper viaImage: %w", err)
+		}
+
+		tags, err := autotagMatchTags(ctx, path, s.tagReader, trimExt)
+		if err != nil {
+			return fmt.Errorf("autotag scraper viaImage: %w", err)
+		}
+
+		if len(performers) > 0 || studio != nil || len(tags) > 0 {
+			ret = &ScrapedImage{
+				Performers: performers,
+				Studio:     studio,
+				Tags:       tags,
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (s autotagScraper) supports(ty ScrapeContentType) bool {
 	switch ty {
 	case ScrapeContentTypeScene:
 		return true
 	case ScrapeContentTypeGallery:
 		return true
+	case ScrapeContentTypeImage:
+		return true
 	}
 
 	return false
@@ -205,6 +249,9 @@ func (s autotagScraper) spec() Scraper {
 		Gallery: &ScraperSpec{
 			SupportedScrapes: supportedScrapes,
 		},
+		Image: &ScraperSpec{
+			SupportedScrapes: supportedScrapes,
+		},
 	}
 }
 
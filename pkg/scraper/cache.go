@@ -1,8 +1,10 @@
 package scraper
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"os"
@@ -16,8 +18,10 @@ import (
 	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/scene"
+	"github.com/stashapp/stash/pkg/studio"
 	"github.com/stashapp/stash/pkg/tag"
 	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 const (
@@ -41,6 +45,10 @@ type GlobalConfig interface {
 	GetScraperCDPPath() string
 	GetScraperCertCheck() bool
 	GetPythonPath() string
+	GetCachePath() string
+	ResponseCacheConfig
+	RateLimitConfig
+	SecretsConfig
 }
 
 func isCDPPathHTTP(c GlobalConfig) bool {
@@ -54,29 +62,51 @@ func isCDPPathWS(c GlobalConfig) bool {
 type PerformerFinder interface {
 	match.PerformerAutoTagQueryer
 	match.PerformerFinder
+	Find(ctx context.Context, id int) (*models.Performer, error)
+	models.URLLoader
 }
 
 type StudioFinder interface {
 	match.StudioAutoTagQueryer
 	match.StudioFinder
+	studio.Finder
 }
 
 type TagFinder interface {
 	match.TagAutoTagQueryer
 	tag.Queryer
+	tag.Finder
+}
+
+type SceneFinder interface {
+	scene.IDFinder
+	models.URLLoader
 }
 
 type GalleryFinder interface {
 	Find(ctx context.Context, id int) (*models.Gallery, error)
 	models.FileLoader
+	models.URLLoader
+}
+
+type ImageFinder interface {
+	Find(ctx context.Context, id int) (*models.Image, error)
+	models.ImageFileLoader
+}
+
+type MovieFinder interface {
+	match.MovieNamesFinder
+	Find(ctx context.Context, id int) (*models.Movie, error)
+	models.URLLoader
 }
 
 type Repository struct {
-	SceneFinder     scene.IDFinder
+	SceneFinder     SceneFinder
 	GalleryFinder   GalleryFinder
+	ImageFinder     ImageFinder
 	TagFinder       TagFinder
 	PerformerFinder PerformerFinder
-	MovieFinder     match.MovieNamesFinder
+	MovieFinder     MovieFinder
 	StudioFinder    StudioFinder
 }
 
@@ -86,6 +116,14 @@ type Cache struct {
 	scrapers     map[string]scraper // Scraper ID -> Scraper
 	globalConfig GlobalConfig
 	txnManager   txn.Manager
+	plugins      PluginRunner
+
+	// responseCache is shared by all scrapers
+	responseCache *ResponseCache
+	// rateLimiter is shared by all scrapers
+	rateLimiter *rateLimiter
+	// sessions stores the login sessions of scrapers
+	sessions *sessionStore
 
 	repository Repository
 }
@@ -115,8 +153,10 @@ func newClient(gc GlobalConfig) *http.Client {
 // instance and an error if the scraper directory could not be loaded.
 //
 // Scraper configurations are loaded from yml files in the provided scrapers
-// directory and any subdirectories.
-func NewCache(globalConfig GlobalConfig, txnManager txn.Manager, repo Repository) (*Cache, error) {
+// directory and any subdirectories, and from the scraper configurations
+// provided by plugins. Plugins may be nil, in which case plugin scrapers are
+// not available.
+func NewCache(globalConfig GlobalConfig, txnManager txn.Manager, repo Repository, plugins PluginRunner) (*Cache, error) {
 	// HTTP Client setup
 	client := newClient(globalConfig)
 
@@ -124,7 +164,12 @@ func NewCache(globalConfig GlobalConfig, txnManager txn.Manager, repo Repository
 		client:       client,
 		globalConfig: globalConfig,
 		txnManager:   txnManager,
+		plugins:      plugins,
 		repository:   repo,
+
+		responseCache: NewResponseCache(globalConfig.GetCachePath(), globalConfig),
+		rateLimiter:   newRateLimiter(globalConfig),
+		sessions:      newSessionStore(),
 	}
 
 	var err error
@@ -141,7 +186,7 @@ func (c *Cache) loadScrapers() (map[string]scraper, error) {
 	scrapers := make(map[string]scraper)
 
 	// Add built-in scrapers
-	freeOnes := getFreeonesScraper(c.globalConfig)
+	freeOnes := c.newGroupScraper(getFreeonesConfig())
 	autoTag := getAutoTagScraper(c.txnManager, c.repository, c.globalConfig)
 	scrapers[freeOnes.spec().ID] = freeOnes
 	scrapers[autoTag.spec().ID] = autoTag
@@ -155,7 +200,7 @@ func (c *Cache) loadScrapers() (map[string]scraper, error) {
 			if err != nil {
 				logger.Errorf("Error loading scraper %s: %v", fp, err)
 			} else {
-				scraper := newGroupScraper(*conf, c.globalConfig)
+				scraper := c.newGroupScraper(*conf)
 				scrapers[scraper.spec().ID] = scraper
 			}
 			scraperFiles = append(scraperFiles, fp)
@@ -168,9 +213,57 @@ func (c *Cache) loadScrapers() (map[string]scraper, error) {
 		return nil, err
 	}
 
+	c.loadPluginScrapers(scrapers)
+
 	return scrapers, nil
 }
 
+// loadPluginScrapers adds the scrapers provided by plugins to scrapers.
+// Plugin scrapers do not replace scrapers with the same ID.
+func (c *Cache) loadPluginScrapers(scrapers map[string]scraper) {
+	if c.plugins == nil {
+		return
+	}
+
+	for pluginID, data := range c.plugins.GetScraperConfigs() {
+		conf, err := loadPluginConfigFromYAML(pluginID, bytes.NewReader(data))
+		if err != nil {
+			logger.Errorf("Error loading scraper of plugin %s: %v", pluginID, err)
+			continue
+		}
+
+		if _, found := scrapers[conf.ID]; found {
+			logger.Warnf("Scraper of plugin %s not loaded: a scraper with the same ID already exists", pluginID)
+			continue
+		}
+
+		scrapers[conf.ID] = c.newGroupScraper(*conf)
+	}
+}
+
+// newGroupScraper returns a scraper using the provided configuration. The
+// scraper uses the plugins, response cache, rate limiter and login sessions
+// of the cache.
+func (c *Cache) newGroupScraper(conf config) scraper {
+	conf.plugins = c.plugins
+	conf.responseCache = c.responseCache
+	conf.rateLimiter = c.rateLimiter
+	conf.sessions = c.sessions
+	return newGroupScraper(conf, c.globalConfig)
+}
+
+// ResetSession logs out the scraper with the provided ID, so that it logs in
+// again on its next request. This should be called when the secrets of the
+// scraper are changed.
+func (c *Cache) ResetSession(scraperID string) {
+	c.sessions.reset(scraperID)
+}
+
+// ResponseCache returns the response cache shared by the scrapers.
+func (c *Cache) ResponseCache() *ResponseCache {
+	return c.responseCache
+}
+
 // ReloadScrapers clears the scraper cache and reloads from the scraper path.
 // In the event of an error during loading, the cache will be left empty.
 func (c *Cache) ReloadScrapers() error {
@@ -289,6 +382,24 @@ func (c Cache) ScrapeURL(ctx context.Context, url string, ty ScrapeContentType)
 	return nil, nil
 }
 
+// ScrapeURLs scrapes each of the given urls for the given content, using the
+// first scraper capable of scraping each url. Returns the scraped content of
+// each url, in the same order as the urls. The content is nil for urls which
+// no scraper supports.
+func (c Cache) ScrapeURLs(ctx context.Context, urls []string, ty ScrapeContentType) ([]ScrapedContent, error) {
+	ret := make([]ScrapedContent, len(urls))
+	for i, url := range urls {
+		content, err := c.ScrapeURL(ctx, url, ty)
+		if err != nil {
+			return nil, fmt.Errorf("error scraping url %s: %w", url, err)
+		}
+
+		ret[i] = content
+	}
+
+	return ret, nil
+}
+
 func (c Cache) ScrapeID(ctx context.Context, scraperID string, id int, ty ScrapeContentType) (ScrapedContent, error) {
 	s := c.findScraper(scraperID)
 	if s == nil {
@@ -343,16 +454,182 @@ func (c Cache) ScrapeID(ctx context.Context, scraperID string, id int, ty Scrape
 		if scraped != nil {
 			ret = scraped
 		}
+	case ScrapeContentTypeImage:
+		is, ok := s.(imageScraper)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot use scraper %s as an image scraper", ErrNotSupported, scraperID)
+		}
+
+		image, err := c.getImage(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("scraper %s: unable to load image id %v: %w", scraperID, id, err)
+		}
+
+		// don't assign nil concrete pointer to ret interface, otherwise nil
+		// detection is harder
+		scraped, err := is.viaImage(ctx, c.client, image)
+		if err != nil {
+			return nil, fmt.Errorf("scraper %s: %w", scraperID, err)
+		}
+
+		if scraped != nil {
+			ret = scraped
+		}
+	case ScrapeContentTypePerformer, ScrapeContentTypeMovie, ScrapeContentTypeStudio, ScrapeContentTypeTag:
+		// performers, movies, studios and tags are scraped using a fragment of the stored object
+		fs, ok := s.(fragmentScraper)
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot use scraper %s as a fragment scraper", ErrNotSupported, scraperID)
+		}
+
+		input, err := c.getFragmentInput(ctx, id, ty)
+		if err != nil {
+			return nil, fmt.Errorf("scraper %s: unable to load %v id %v: %w", scraperID, ty, id, err)
+		}
+
+		ret, err = fs.viaFragment(ctx, c.client, *input)
+		if err != nil {
+			return nil, fmt.Errorf("scraper %s: %w", scraperID, err)
+		}
 	}
 
 	return c.postScrape(ctx, ret)
 }
 
+// getFragmentInput returns the fragment input for the stored performer,
+// movie, studio or tag with the provided id.
+func (c Cache) getFragmentInput(ctx context.Context, id int, ty ScrapeContentType) (*Input, error) {
+	var ret *Input
+	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
+		switch ty {
+		case ScrapeContentTypePerformer:
+			p, err := c.repository.PerformerFinder.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if p == nil {
+				return fmt.Errorf("performer with id %d not found", id)
+			}
+
+			urls, err := c.repository.PerformerFinder.GetURLs(ctx, p.ID)
+			if err != nil {
+				return err
+			}
+
+			ret = &Input{Performer: performerToScrapedInput(p, urls)}
+		case ScrapeContentTypeMovie:
+			m, err := c.repository.MovieFinder.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if m == nil {
+				return fmt.Errorf("movie with id %d not found", id)
+			}
+
+			urls, err := c.repository.MovieFinder.GetURLs(ctx, m.ID)
+			if err != nil {
+				return err
+			}
+
+			input := &ScrapedMovieInput{
+				Name:     nullStringPtr(m.Name),
+				Aliases:  nullStringPtr(m.Aliases),
+				Director: nullStringPtr(m.Director),
+				URLs:     urls,
+				Synopsis: nullStringPtr(m.Synopsis),
+			}
+			if len(urls) > 0 {
+				input.URL = &urls[0]
+			}
+			if m.Date.Valid {
+				input.Date = &m.Date.String
+			}
+
+			ret = &Input{Movie: input}
+		case ScrapeContentTypeStudio:
+			s, err := c.repository.StudioFinder.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if s == nil {
+				return fmt.Errorf("studio with id %d not found", id)
+			}
+
+			ret = &Input{Studio: &ScrapedStudioInput{
+				Name:    nullStringPtr(s.Name),
+				URL:     nullStringPtr(s.URL),
+				Details: nullStringPtr(s.Details),
+			}}
+		case ScrapeContentTypeTag:
+			t, err := c.repository.TagFinder.Find(ctx, id)
+			if err != nil {
+				return err
+			}
+			if t == nil {
+				return fmt.Errorf("tag with id %d not found", id)
+			}
+
+			ret = &Input{Tag: &ScrapedTagInput{
+				Name:        &t.Name,
+				Description: nullStringPtr(t.Description),
+			}}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func performerToScrapedInput(p *models.Performer, urls []string) *ScrapedPerformerInput {
+	strPtr := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+
+	ret := &ScrapedPerformerInput{
+		Name:           &p.Name,
+		Disambiguation: strPtr(p.Disambiguation),
+		Gender:         strPtr(p.Gender.String()),
+		URLs:           urls,
+		Twitter:        strPtr(utils.URLForSite(urls, utils.TwitterURL)),
+		Instagram:      strPtr(utils.URLForSite(urls, utils.InstagramURL)),
+		Ethnicity:      strPtr(p.Ethnicity),
+		Country:        strPtr(p.Country),
+		Aliases:        strPtr(p.Aliases),
+	}
+
+	if len(urls) > 0 {
+		ret.URL = &urls[0]
+	}
+	if p.Birthdate != nil {
+		ret.Birthdate = strPtr(p.Birthdate.String())
+	}
+
+	return ret
+}
+
+func nullStringPtr(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+
+	return &s.String
+}
+
 func (c Cache) getScene(ctx context.Context, sceneID int) (*models.Scene, error) {
 	var ret *models.Scene
 	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		var err error
 		ret, err = c.repository.SceneFinder.Find(ctx, sceneID)
+
+		if ret != nil {
+			err = ret.LoadURLs(ctx, c.repository.SceneFinder)
+		}
+
 		return err
 	}); err != nil {
 		return nil, err
@@ -369,6 +646,26 @@ func (c Cache) getGallery(ctx context.Context, galleryID int) (*models.Gallery,
 		if ret != nil {
 			err = ret.LoadFiles(ctx, c.repository.GalleryFinder)
 		}
+		if err == nil && ret != nil {
+			err = ret.LoadURLs(ctx, c.repository.GalleryFinder)
+		}
+
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (c Cache) getImage(ctx context.Context, imageID int) (*models.Image, error) {
+	var ret *models.Image
+	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
+		var err error
+		ret, err = c.repository.ImageFinder.Find(ctx, imageID)
+
+		if ret != nil {
+			err = ret.LoadFiles(ctx, c.repository.ImageFinder)
+		}
 
 		return err
 	}); err != nil {
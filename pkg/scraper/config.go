@@ -15,6 +15,17 @@ type config struct {
 	ID   string
 	path string
 
+	// ID of the plugin that provides this scraper, if any
+	pluginID string
+	// used to run plugin scrapers
+	plugins PluginRunner
+	// used to cache loaded pages
+	responseCache *ResponseCache
+	// used to limit the rate of requests
+	rateLimiter *rateLimiter
+	// used to store the sessions of scrapers which log in
+	sessions *sessionStore
+
 	// The name of the scraper. This is displayed in the UI.
 	Name string `yaml:"name"`
 
@@ -45,9 +56,39 @@ type config struct {
 	// Configuration for querying a gallery by a URL
 	GalleryByURL []*scrapeByURLConfig `yaml:"galleryByURL"`
 
+	// Configuration for querying images by an Image fragment
+	ImageByFragment *scraperTypeConfig `yaml:"imageByFragment"`
+
+	// Configuration for querying an image by a URL
+	ImageByURL []*scrapeByURLConfig `yaml:"imageByURL"`
+
+	// Configuration for querying movies by name
+	MovieByName *scraperTypeConfig `yaml:"movieByName"`
+
+	// Configuration for querying movies by a Movie fragment
+	MovieByFragment *scraperTypeConfig `yaml:"movieByFragment"`
+
 	// Configuration for querying a movie by a URL
 	MovieByURL []*scrapeByURLConfig `yaml:"movieByURL"`
 
+	// Configuration for querying studios by name
+	StudioByName *scraperTypeConfig `yaml:"studioByName"`
+
+	// Configuration for querying studios by a Studio fragment
+	StudioByFragment *scraperTypeConfig `yaml:"studioByFragment"`
+
+	// Configuration for querying a studio by a URL
+	StudioByURL []*scrapeByURLConfig `yaml:"studioByURL"`
+
+	// Configuration for querying tags by name
+	TagByName *scraperTypeConfig `yaml:"tagByName"`
+
+	// Configuration for querying tags by a Tag fragment
+	TagByFragment *scraperTypeConfig `yaml:"tagByFragment"`
+
+	// Configuration for querying a tag by a URL
+	TagByURL []*scrapeByURLConfig `yaml:"tagByURL"`
+
 	// Scraper debugging options
 	DebugOptions *scraperDebugOptions `yaml:"debug"`
 
@@ -105,9 +146,82 @@ func (c config) validate() error {
 		}
 	}
 
+	for _, s := range []*scraperTypeConfig{c.ImageByFragment, c.MovieByName, c.MovieByFragment, c.StudioByName, c.StudioByFragment, c.TagByName, c.TagByFragment} {
+		if s != nil {
+			if err := s.validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, urlConfigs := range [][]*scrapeByURLConfig{c.ImageByURL, c.StudioByURL, c.TagByURL} {
+		for _, s := range urlConfigs {
+			if err := s.validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.DriverOptions != nil && c.DriverOptions.Login != nil {
+		if c.DriverOptions.UseCDP {
+			return errors.New("login is not supported when useCDP is set")
+		}
+
+		if err := c.DriverOptions.Login.validate(); err != nil {
+			return err
+		}
+	}
+
+	// the plugin defaults to the providing plugin, if any
+	for _, s := range c.typeConfigs() {
+		if s.Action == scraperActionPlugin && s.Plugin == "" && c.pluginID == "" {
+			return errors.New("plugin is mandatory for plugin scraper action")
+		}
+	}
+
 	return nil
 }
 
+// typeConfigs returns all of the configured scraper type configurations.
+func (c config) typeConfigs() []*scraperTypeConfig {
+	var ret []*scraperTypeConfig
+	for _, s := range []*scraperTypeConfig{
+		c.PerformerByName,
+		c.PerformerByFragment,
+		c.SceneByFragment,
+		c.GalleryByFragment,
+		c.ImageByFragment,
+		c.SceneByName,
+		c.SceneByQueryFragment,
+		c.MovieByName,
+		c.MovieByFragment,
+		c.StudioByName,
+		c.StudioByFragment,
+		c.TagByName,
+		c.TagByFragment,
+	} {
+		if s != nil {
+			ret = append(ret, s)
+		}
+	}
+
+	for _, urlConfigs := range [][]*scrapeByURLConfig{
+		c.PerformerByURL,
+		c.SceneByURL,
+		c.GalleryByURL,
+		c.ImageByURL,
+		c.MovieByURL,
+		c.StudioByURL,
+		c.TagByURL,
+	} {
+		for _, s := range urlConfigs {
+			ret = append(ret, &s.scraperTypeConfig)
+		}
+	}
+
+	return ret
+}
+
 type stashServer struct {
 	URL string `yaml:"url"`
 }
@@ -117,6 +231,10 @@ type scraperTypeConfig struct {
 	Script  []string      `yaml:"script,flow"`
 	Scraper string        `yaml:"scraper"`
 
+	// for plugin scrapers only. Defaults to the plugin providing the
+	// scraper.
+	Plugin string `yaml:"plugin"`
+
 	// for xpath name scraper only
 	QueryURL             string               `yaml:"queryURL"`
 	QueryURLReplacements queryURLReplacements `yaml:"queryURLReplace"`
@@ -185,15 +303,26 @@ type header struct {
 }
 
 type scraperDriverOptions struct {
-	UseCDP  bool             `yaml:"useCDP"`
-	Sleep   int              `yaml:"sleep"`
-	Clicks  []*clickOptions  `yaml:"clicks"`
-	Cookies []*cookieOptions `yaml:"cookies"`
-	Headers []*header        `yaml:"headers"`
+	UseCDP    bool              `yaml:"useCDP"`
+	Sleep     int               `yaml:"sleep"`
+	Clicks    []*clickOptions   `yaml:"clicks"`
+	Cookies   []*cookieOptions  `yaml:"cookies"`
+	Headers   []*header         `yaml:"headers"`
+	RateLimit *rateLimitOptions `yaml:"rateLimit"`
+	Login     *loginOptions     `yaml:"login"`
 }
 
 func loadConfigFromYAML(id string, reader io.Reader) (*config, error) {
-	ret := &config{}
+	return loadConfig(&config{ID: id}, reader)
+}
+
+// loadPluginConfigFromYAML loads the scraper configuration provided by a
+// plugin. The scraper ID is set to the plugin ID.
+func loadPluginConfigFromYAML(pluginID string, reader io.Reader) (*config, error) {
+	return loadConfig(&config{ID: pluginID, pluginID: pluginID}, reader)
+}
+
+func loadConfig(ret *config, reader io.Reader) (*config, error) {
 
 	parser := yaml.NewDecoder(reader)
 	parser.SetStrict(true)
@@ -202,8 +331,6 @@ func loadConfigFromYAML(id string, reader io.Reader) (*config, error) {
 		return nil, err
 	}
 
-	ret.ID = id
-
 	if err := ret.validate(); err != nil {
 		return nil, err
 	}
@@ -289,19 +416,37 @@ func (c config) spec() Scraper {
 		ret.Gallery = &gallery
 	}
 
-	movie := ScraperSpec{}
-	if len(c.MovieByURL) > 0 {
-		movie.SupportedScrapes = append(movie.SupportedScrapes, ScrapeTypeURL)
-		for _, v := range c.MovieByURL {
-			movie.Urls = append(movie.Urls, v.URL...)
+	ret.Image = typeSpec(nil, c.ImageByFragment, c.ImageByURL)
+
+	ret.Movie = typeSpec(c.MovieByName, c.MovieByFragment, c.MovieByURL)
+	ret.Studio = typeSpec(c.StudioByName, c.StudioByFragment, c.StudioByURL)
+	ret.Tag = typeSpec(c.TagByName, c.TagByFragment, c.TagByURL)
+
+	return ret
+}
+
+// typeSpec returns the specification of a content type supporting the
+// provided scrapes. Returns nil if no scrapes are supported.
+func typeSpec(byName *scraperTypeConfig, byFragment *scraperTypeConfig, byURL []*scrapeByURLConfig) *ScraperSpec {
+	ret := ScraperSpec{}
+	if byName != nil {
+		ret.SupportedScrapes = append(ret.SupportedScrapes, ScrapeTypeName)
+	}
+	if byFragment != nil {
+		ret.SupportedScrapes = append(ret.SupportedScrapes, ScrapeTypeFragment)
+	}
+	if len(byURL) > 0 {
+		ret.SupportedScrapes = append(ret.SupportedScrapes, ScrapeTypeURL)
+		for _, v := range byURL {
+			ret.Urls = append(ret.Urls, v.URL...)
 		}
 	}
 
-	if len(movie.SupportedScrapes) > 0 {
-		ret.Movie = &movie
+	if len(ret.SupportedScrapes) == 0 {
+		return nil
 	}
 
-	return ret
+	return &ret
 }
 
 func (c config) supports(ty ScrapeContentType) bool {
@@ -312,38 +457,23 @@ func (c config) supports(ty ScrapeContentType) bool {
 		return (c.SceneByName != nil && c.SceneByQueryFragment != nil) || c.SceneByFragment != nil || len(c.SceneByURL) > 0
 	case ScrapeContentTypeGallery:
 		return c.GalleryByFragment != nil || len(c.GalleryByURL) > 0
+	case ScrapeContentTypeImage:
+		return c.ImageByFragment != nil || len(c.ImageByURL) > 0
 	case ScrapeContentTypeMovie:
-		return len(c.MovieByURL) > 0
+		return c.MovieByName != nil || c.MovieByFragment != nil || len(c.MovieByURL) > 0
+	case ScrapeContentTypeStudio:
+		return c.StudioByName != nil || c.StudioByFragment != nil || len(c.StudioByURL) > 0
+	case ScrapeContentTypeTag:
+		return c.TagByName != nil || c.TagByFragment != nil || len(c.TagByURL) > 0
 	}
 
 	panic("Unhandled ScrapeContentType")
 }
 
 func (c config) matchesURL(url string, ty ScrapeContentType) bool {
-	switch ty {
-	case ScrapeContentTypePerformer:
-		for _, scraper := range c.PerformerByURL {
-			if scraper.matchesURL(url) {
-				return true
-			}
-		}
-	case ScrapeContentTypeScene:
-		for _, scraper := range c.SceneByURL {
-			if scraper.matchesURL(url) {
-				return true
-			}
-		}
-	case ScrapeContentTypeGallery:
-		for _, scraper := range c.GalleryByURL {
-			if scraper.matchesURL(url) {
-				return true
-			}
-		}
-	case ScrapeContentTypeMovie:
-		for _, scraper := range c.MovieByURL {
-			if scraper.matchesURL(url) {
-				return true
-			}
+	for _, scraper := range loadUrlCandidates(c, ty) {
+		if scraper.matchesURL(url) {
+			return true
 		}
 	}
 
@@ -122,7 +122,7 @@ xPathScrapers:
 # Last updated April 13, 2021
 `
 
-func getFreeonesScraper(globalConfig GlobalConfig) scraper {
+func getFreeonesConfig() config {
 	yml := freeonesScraperConfig
 
 	c, err := loadConfigFromYAML(FreeonesScraperID, strings.NewReader(yml))
@@ -130,5 +130,5 @@ func getFreeonesScraper(globalConfig GlobalConfig) scraper {
 		logger.Fatalf("Error loading builtin freeones scraper: %s", err.Error())
 	}
 
-	return newGroupScraper(*c, globalConfig)
+	return *c
 }
@@ -6,6 +6,7 @@ type ScrapedGallery struct {
 	Title      *string                    `json:"title"`
 	Details    *string                    `json:"details"`
 	URL        *string                    `json:"url"`
+	URLs       []string                   `json:"urls"`
 	Date       *string                    `json:"date"`
 	Studio     *models.ScrapedStudio      `json:"studio"`
 	Tags       []*models.ScrapedTag       `json:"tags"`
@@ -15,8 +16,9 @@ type ScrapedGallery struct {
 func (ScrapedGallery) IsScrapedContent() {}
 
 type ScrapedGalleryInput struct {
-	Title   *string `json:"title"`
-	Details *string `json:"details"`
-	URL     *string `json:"url"`
-	Date    *string `json:"date"`
+	Title   *string  `json:"title"`
+	Details *string  `json:"details"`
+	URL     *string  `json:"url"`
+	URLs    []string `json:"urls"`
+	Date    *string  `json:"date"`
 }
@@ -33,8 +33,16 @@ func (g group) fragmentScraper(input Input) *scraperTypeConfig {
 	case input.Gallery != nil:
 		// TODO - this should be galleryByQueryFragment
 		return g.config.GalleryByFragment
+	case input.Image != nil:
+		return g.config.ImageByFragment
+	case input.Movie != nil:
+		return g.config.MovieByFragment
 	case input.Scene != nil:
 		return g.config.SceneByQueryFragment
+	case input.Studio != nil:
+		return g.config.StudioByFragment
+	case input.Tag != nil:
+		return g.config.TagByFragment
 	}
 
 	return nil
@@ -44,10 +52,12 @@ func (g group) viaFragment(ctx context.Context, client *http.Client, input Input
 	stc := g.fragmentScraper(input)
 	if stc == nil {
 		// If there's no performer fragment scraper in the group, we try to use
-		// the URL scraper. Check if there's an URL in the input, and then shift
-		// to an URL scrape if it's present.
-		if input.Performer != nil && input.Performer.URL != nil && *input.Performer.URL != "" {
-			return g.viaURL(ctx, client, *input.Performer.URL, ScrapeContentTypePerformer)
+		// the URL scraper. Check if there's an URL in the input supported by
+		// the group, and then shift to an URL scrape if it's present.
+		if input.Performer != nil {
+			if url := g.performerInputURL(*input.Performer); url != "" {
+				return g.viaURL(ctx, client, url, ScrapeContentTypePerformer)
+			}
 		}
 
 		return nil, ErrNotSupported
@@ -57,6 +67,24 @@ func (g group) viaFragment(ctx context.Context, client *http.Client, input Input
 	return s.scrapeByFragment(ctx, input)
 }
 
+// performerInputURL returns the first url of the performer input supported
+// by the group, or an empty string if none are supported.
+func (g group) performerInputURL(input ScrapedPerformerInput) string {
+	var urls []string
+	if input.URL != nil {
+		urls = append(urls, *input.URL)
+	}
+	urls = append(urls, input.URLs...)
+
+	for _, url := range urls {
+		if url != "" && g.supportsURL(url, ScrapeContentTypePerformer) {
+			return url
+		}
+	}
+
+	return ""
+}
+
 func (g group) viaScene(ctx context.Context, client *http.Client, scene *models.Scene) (*ScrapedScene, error) {
 	if g.config.SceneByFragment == nil {
 		return nil, ErrNotSupported
@@ -75,6 +103,15 @@ func (g group) viaGallery(ctx context.Context, client *http.Client, gallery *mod
 	return s.scrapeGalleryByGallery(ctx, gallery)
 }
 
+func (g group) viaImage(ctx context.Context, client *http.Client, image *models.Image) (*ScrapedImage, error) {
+	if g.config.ImageByFragment == nil {
+		return nil, ErrNotSupported
+	}
+
+	s := g.config.getScraper(*g.config.ImageByFragment, client, g.globalConf)
+	return s.scrapeImageByImage(ctx, image)
+}
+
 func loadUrlCandidates(c config, ty ScrapeContentType) []*scrapeByURLConfig {
 	switch ty {
 	case ScrapeContentTypePerformer:
@@ -85,6 +122,12 @@ func loadUrlCandidates(c config, ty ScrapeContentType) []*scrapeByURLConfig {
 		return c.MovieByURL
 	case ScrapeContentTypeGallery:
 		return c.GalleryByURL
+	case ScrapeContentTypeImage:
+		return c.ImageByURL
+	case ScrapeContentTypeStudio:
+		return c.StudioByURL
+	case ScrapeContentTypeTag:
+		return c.TagByURL
 	}
 
 	panic("loadUrlCandidates: unreachable")
@@ -125,6 +168,27 @@ func (g group) viaName(ctx context.Context, client *http.Client, name string, ty
 
 		s := g.config.getScraper(*g.config.SceneByName, client, g.globalConf)
 		return s.scrapeByName(ctx, name, ty)
+	case ScrapeContentTypeMovie:
+		if g.config.MovieByName == nil {
+			break
+		}
+
+		s := g.config.getScraper(*g.config.MovieByName, client, g.globalConf)
+		return s.scrapeByName(ctx, name, ty)
+	case ScrapeContentTypeStudio:
+		if g.config.StudioByName == nil {
+			break
+		}
+
+		s := g.config.getScraper(*g.config.StudioByName, client, g.globalConf)
+		return s.scrapeByName(ctx, name, ty)
+	case ScrapeContentTypeTag:
+		if g.config.TagByName == nil {
+			break
+		}
+
+		s := g.config.getScraper(*g.config.TagByName, client, g.globalConf)
+		return s.scrapeByName(ctx, name, ty)
 	}
 
 	return nil, fmt.Errorf("%w: cannot load %v by name", ErrNotSupported, ty)
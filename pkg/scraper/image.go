@@ -46,6 +46,23 @@ func setSceneImage(ctx context.Context, client *http.Client, s *ScrapedScene, gl
 	return nil
 }
 
+func setStudioImage(ctx context.Context, client *http.Client, s *models.ScrapedStudio, globalConfig GlobalConfig) error {
+	// don't try to get the image if it doesn't appear to be a URL
+	if s.Image == nil || !strings.HasPrefix(*s.Image, "http") {
+		// nothing to do
+		return nil
+	}
+
+	img, err := getImage(ctx, *s.Image, client, globalConfig)
+	if err != nil {
+		return err
+	}
+
+	s.Image = img
+
+	return nil
+}
+
 func setMovieFrontImage(ctx context.Context, client *http.Client, m *models.ScrapedMovie, globalConfig GlobalConfig) error {
 	// don't try to get the image if it doesn't appear to be a URL
 	if m.FrontImage == nil || !strings.HasPrefix(*m.FrontImage, "http") {
@@ -80,7 +80,7 @@ func (s *jsonScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeCont
 		return nil, err
 	}
 
-	q := s.getJsonQuery(doc)
+	q := s.getJsonQuery(doc, u)
 	switch ty {
 	case ScrapeContentTypePerformer:
 		return scraper.scrapePerformer(ctx, q)
@@ -88,8 +88,14 @@ func (s *jsonScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeCont
 		return scraper.scrapeScene(ctx, q)
 	case ScrapeContentTypeGallery:
 		return scraper.scrapeGallery(ctx, q)
+	case ScrapeContentTypeImage:
+		return scraper.scrapeImage(ctx, q)
 	case ScrapeContentTypeMovie:
 		return scraper.scrapeMovie(ctx, q)
+	case ScrapeContentTypeStudio:
+		return scraper.scrapeStudio(ctx, q)
+	case ScrapeContentTypeTag:
+		return scraper.scrapeTag(ctx, q)
 	}
 
 	return nil, ErrNotSupported
@@ -116,7 +122,7 @@ func (s *jsonScraper) scrapeByName(ctx context.Context, name string, ty ScrapeCo
 		return nil, err
 	}
 
-	q := s.getJsonQuery(doc)
+	q := s.getJsonQuery(doc, url)
 	q.setType(SearchQuery)
 
 	var content []ScrapedContent
@@ -142,6 +148,39 @@ func (s *jsonScraper) scrapeByName(ctx context.Context, name string, ty ScrapeCo
 			content = append(content, s)
 		}
 
+		return content, nil
+	case ScrapeContentTypeMovie:
+		movies, err := scraper.scrapeMovies(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range movies {
+			content = append(content, m)
+		}
+
+		return content, nil
+	case ScrapeContentTypeStudio:
+		studios, err := scraper.scrapeStudios(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range studios {
+			content = append(content, s)
+		}
+
+		return content, nil
+	case ScrapeContentTypeTag:
+		tags, err := scraper.scrapeTags(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range tags {
+			content = append(content, t)
+		}
+
 		return content, nil
 	}
 
@@ -168,24 +207,32 @@ func (s *jsonScraper) scrapeSceneByScene(ctx context.Context, scene *models.Scen
 		return nil, err
 	}
 
-	q := s.getJsonQuery(doc)
+	q := s.getJsonQuery(doc, url)
 	return scraper.scrapeScene(ctx, q)
 }
 
 func (s *jsonScraper) scrapeByFragment(ctx context.Context, input Input) (ScrapedContent, error) {
+	var queryURL queryURLParameters
 	switch {
 	case input.Gallery != nil:
 		return nil, fmt.Errorf("%w: cannot use a json scraper as a gallery fragment scraper", ErrNotSupported)
 	case input.Performer != nil:
 		return nil, fmt.Errorf("%w: cannot use a json scraper as a performer fragment scraper", ErrNotSupported)
-	case input.Scene == nil:
-		return nil, fmt.Errorf("%w: scene input is nil", ErrNotSupported)
+	case input.Scene != nil:
+		queryURL = queryURLParametersFromScrapedScene(*input.Scene)
+	case input.Image != nil:
+		queryURL = queryURLParametersFromScrapedImage(*input.Image)
+	case input.Movie != nil:
+		queryURL = queryURLParametersFromScrapedMovie(*input.Movie)
+	case input.Studio != nil:
+		queryURL = queryURLParametersFromScrapedStudio(*input.Studio)
+	case input.Tag != nil:
+		queryURL = queryURLParametersFromScrapedTag(*input.Tag)
+	default:
+		return nil, fmt.Errorf("%w: fragment input is nil", ErrNotSupported)
 	}
 
-	scene := *input.Scene
-
 	// construct the URL
-	queryURL := queryURLParametersFromScrapedScene(scene)
 	if s.scraper.QueryURLReplacements != nil {
 		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
 	}
@@ -194,7 +241,7 @@ func (s *jsonScraper) scrapeByFragment(ctx context.Context, input Input) (Scrape
 	scraper := s.getJsonScraper()
 
 	if scraper == nil {
-		return nil, errors.New("xpath scraper with name " + s.scraper.Scraper + " not found in config")
+		return nil, errors.New("json scraper with name " + s.scraper.Scraper + " not found in config")
 	}
 
 	doc, err := s.loadURL(ctx, url)
@@ -203,7 +250,18 @@ func (s *jsonScraper) scrapeByFragment(ctx context.Context, input Input) (Scrape
 		return nil, err
 	}
 
-	q := s.getJsonQuery(doc)
+	q := s.getJsonQuery(doc, url)
+	switch {
+	case input.Image != nil:
+		return scraper.scrapeImage(ctx, q)
+	case input.Movie != nil:
+		return scraper.scrapeMovie(ctx, q)
+	case input.Studio != nil:
+		return scraper.scrapeStudio(ctx, q)
+	case input.Tag != nil:
+		return scraper.scrapeTag(ctx, q)
+	}
+
 	return scraper.scrapeScene(ctx, q)
 }
 
@@ -227,23 +285,53 @@ func (s *jsonScraper) scrapeGalleryByGallery(ctx context.Context, gallery *model
 		return nil, err
 	}
 
-	q := s.getJsonQuery(doc)
+	q := s.getJsonQuery(doc, url)
 	return scraper.scrapeGallery(ctx, q)
 }
 
-func (s *jsonScraper) getJsonQuery(doc string) *jsonQuery {
+func (s *jsonScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromImage(image)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getJsonScraper()
+
+	if scraper == nil {
+		return nil, errors.New("json scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getJsonQuery(doc, url)
+	return scraper.scrapeImage(ctx, q)
+}
+
+func (s *jsonScraper) getJsonQuery(doc string, url string) *jsonQuery {
 	return &jsonQuery{
 		doc:     doc,
+		url:     url,
 		scraper: s,
 	}
 }
 
 type jsonQuery struct {
 	doc       string
+	url       string
 	scraper   *jsonScraper
 	queryType QueryType
 }
 
+func (q *jsonQuery) getURL() string {
+	return q.url
+}
+
 func (q *jsonQuery) getType() QueryType {
 	return q.queryType
 }
@@ -283,5 +371,5 @@ func (q *jsonQuery) subScrape(ctx context.Context, value string) mappedQuery {
 		return nil
 	}
 
-	return q.scraper.getJsonQuery(doc)
+	return q.scraper.getJsonQuery(doc, value)
 }
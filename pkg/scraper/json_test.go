@@ -2,8 +2,10 @@ package scraper
 
 import (
 	"context"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v2"
 )
 
@@ -116,3 +118,192 @@ jsonScrapers:
 		t.Errorf("expected nil scraped performer when not found, got %v", scrapedPerformer)
 	}
 }
+
+func TestJsonStudioScraper(t *testing.T) {
+	const yamlStr = `name: Test
+studioByURL:
+  - action: scrapeJson
+    url:
+      - example.com
+    scraper: studioScraper
+jsonScrapers:
+  studioScraper:
+    studio:
+      Name: data.name
+      URL: data.url
+      Details: data.description
+      Image: data.logo
+      Parent:
+        Name: data.network.name
+        URL: data.network.url
+`
+
+	const json = `
+{
+	"data": {
+		"name": "Studio",
+		"url": "https://example.com/studio",
+		"description": "Studio description",
+		"logo": "https://example.com/logo.png",
+		"network": {
+			"name": "Network",
+			"url": "https://example.com/network"
+		}
+	}
+}
+`
+
+	c, err := loadConfigFromYAML("test", strings.NewReader(yamlStr))
+	if err != nil {
+		t.Fatalf("Error loading yaml: %s", err.Error())
+	}
+
+	spec := c.spec()
+	assert.Nil(t, spec.Scene)
+	if assert.NotNil(t, spec.Studio) {
+		assert.Equal(t, []ScrapeType{ScrapeTypeURL}, spec.Studio.SupportedScrapes)
+	}
+	assert.True(t, c.supports(ScrapeContentTypeStudio))
+	assert.True(t, c.matchesURL("https://example.com/studio", ScrapeContentTypeStudio))
+	assert.False(t, c.matchesURL("https://example.com/studio", ScrapeContentTypeTag))
+
+	q := &jsonQuery{
+		doc: json,
+	}
+
+	scrapedStudio, err := c.JsonScrapers["studioScraper"].scrapeStudio(context.Background(), q)
+	if err != nil {
+		t.Fatalf("Error scraping studio: %s", err.Error())
+	}
+
+	assert.Equal(t, "Studio", scrapedStudio.Name)
+	verifyField(t, "https://example.com/studio", scrapedStudio.URL, "URL")
+	verifyField(t, "Studio description", scrapedStudio.Details, "Details")
+	verifyField(t, "https://example.com/logo.png", scrapedStudio.Image, "Image")
+	if assert.NotNil(t, scrapedStudio.Parent) {
+		assert.Equal(t, "Network", scrapedStudio.Parent.Name)
+		verifyField(t, "https://example.com/network", scrapedStudio.Parent.URL, "Parent.URL")
+	}
+}
+
+func TestJsonTagScraper(t *testing.T) {
+	const yamlStr = `name: Test
+tagByName:
+  action: scrapeJson
+  queryURL: https://example.com/tags?q={}
+  scraper: tagScraper
+jsonScrapers:
+  tagScraper:
+    tag:
+      Name: data.#.name
+      Description: data.#.description
+`
+
+	const json = `
+{
+	"data": [
+		{ "name": "Tag 1", "description": "Description 1" },
+		{ "name": "Tag 2", "description": "Description 2" }
+	]
+}
+`
+
+	c, err := loadConfigFromYAML("test", strings.NewReader(yamlStr))
+	if err != nil {
+		t.Fatalf("Error loading yaml: %s", err.Error())
+	}
+
+	assert.True(t, c.supports(ScrapeContentTypeTag))
+	assert.False(t, c.supports(ScrapeContentTypeStudio))
+
+	q := &jsonQuery{
+		doc: json,
+	}
+
+	tags, err := c.JsonScrapers["tagScraper"].scrapeTags(context.Background(), q)
+	if err != nil {
+		t.Fatalf("Error scraping tags: %s", err.Error())
+	}
+
+	if assert.Len(t, tags, 2) {
+		assert.Equal(t, "Tag 1", tags[0].Name)
+		verifyField(t, "Description 1", tags[0].Description, "Description")
+		assert.Equal(t, "Tag 2", tags[1].Name)
+		verifyField(t, "Description 2", tags[1].Description, "Description")
+	}
+}
+
+func TestJsonImageScraper(t *testing.T) {
+	const yamlStr = `name: Test
+imageByURL:
+  - action: scrapeJson
+    url:
+      - example.com/photo
+    scraper: imageScraper
+jsonScrapers:
+  imageScraper:
+    image:
+      Title: data.title
+      Date: data.date
+      Performers:
+        Name: data.models.#.name
+      Tags:
+        Name: data.tags
+      Studio:
+        Name: data.site
+`
+
+	const json = `
+{
+	"data": {
+		"title": "Photo",
+		"date": "2022-01-02",
+		"models": [
+			{ "name": "Performer 1" },
+			{ "name": "Performer 2" }
+		],
+		"tags": ["Tag 1", "Tag 2"],
+		"site": "Studio"
+	}
+}
+`
+
+	c, err := loadConfigFromYAML("test", strings.NewReader(yamlStr))
+	if err != nil {
+		t.Fatalf("Error loading yaml: %s", err.Error())
+	}
+
+	spec := c.spec()
+	if assert.NotNil(t, spec.Image) {
+		assert.Equal(t, []ScrapeType{ScrapeTypeURL}, spec.Image.SupportedScrapes)
+	}
+	assert.True(t, c.supports(ScrapeContentTypeImage))
+	assert.True(t, c.matchesURL("https://example.com/photo/1", ScrapeContentTypeImage))
+	assert.False(t, c.matchesURL("https://example.com/photo/1", ScrapeContentTypeGallery))
+
+	q := &jsonQuery{
+		doc: json,
+	}
+
+	scrapedImage, err := c.JsonScrapers["imageScraper"].scrapeImage(context.Background(), q)
+	if err != nil {
+		t.Fatalf("Error scraping image: %s", err.Error())
+	}
+
+	verifyField(t, "Photo", scrapedImage.Title, "Title")
+	verifyField(t, "2022-01-02", scrapedImage.Date, "Date")
+
+	if assert.Len(t, scrapedImage.Performers, 2) {
+		verifyField(t, "Performer 1", scrapedImage.Performers[0].Name, "Performers[0].Name")
+		verifyField(t, "Performer 2", scrapedImage.Performers[1].Name, "Performers[1].Name")
+	}
+
+	if assert.Len(t, scrapedImage.Tags, 2) {
+		assert.Equal(t, "Tag 1", scrapedImage.Tags[0].Name)
+		assert.Equal(t, "Tag 2", scrapedImage.Tags[1].Name)
+	}
+
+	if assert.NotNil(t, scrapedImage.Studio) {
+		assert.Equal(t, "Studio", scrapedImage.Studio.Name)
+	}
+}
@@ -0,0 +1,325 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/tidwall/gjson"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	loginTypeForm = "form"
+	loginTypeJSON = "json"
+)
+
+// SecretsConfig contains the secrets used by scraper login flows.
+type SecretsConfig interface {
+	// GetScraperSecrets returns the secrets of the scraper with the provided
+	// ID. Secret names are in lower case.
+	GetScraperSecrets(scraperID string) map[string]string
+}
+
+// loginOptions configures the login request made by a scraper before it
+// loads pages. The URL, field values and header values may contain
+// placeholders of the form {name}, which are replaced with the secrets of the
+// scraper in the stash configuration.
+type loginOptions struct {
+	URL string `yaml:"url"`
+	// Method is the HTTP method of the login request. Defaults to POST.
+	Method string `yaml:"method"`
+	// Type is the encoding of the fields in the request body: form (default)
+	// or json. Fields are sent in the query string of GET requests.
+	Type    string            `yaml:"type"`
+	Fields  map[string]string `yaml:"fields"`
+	Headers []*header         `yaml:"headers"`
+	// Cookie is the name of a cookie that must be set by a successful login.
+	Cookie string `yaml:"cookie"`
+	// TokenPath is the path of a token in the JSON response of the login
+	// request. If set, the token is sent as a bearer token in the
+	// Authorization header of subsequent requests.
+	TokenPath string `yaml:"tokenPath"`
+}
+
+func (o loginOptions) validate() error {
+	if strings.TrimSpace(o.URL) == "" {
+		return errors.New("url is mandatory for login")
+	}
+
+	switch o.Type {
+	case "", loginTypeForm, loginTypeJSON:
+	default:
+		return fmt.Errorf("login type must be %s or %s", loginTypeForm, loginTypeJSON)
+	}
+
+	return nil
+}
+
+func (o loginOptions) method() string {
+	if o.Method == "" {
+		return http.MethodPost
+	}
+	return strings.ToUpper(o.Method)
+}
+
+var secretPlaceholderRE = regexp.MustCompile(`\{(\w+)\}`)
+
+// replaceSecrets replaces the {name} placeholders in s with the provided
+// secrets. Returns an error if a secret is not set.
+func replaceSecrets(s string, secrets map[string]string) (string, error) {
+	var err error
+	ret := secretPlaceholderRE.ReplaceAllStringFunc(s, func(m string) string {
+		name := m[1 : len(m)-1]
+		v, found := secrets[strings.ToLower(name)]
+		if !found {
+			err = fmt.Errorf("secret %q is not set", name)
+			return m
+		}
+		return v
+	})
+
+	return ret, err
+}
+
+// newRequest returns the login request, using the provided secrets.
+func (o loginOptions) newRequest(ctx context.Context, secrets map[string]string) (*http.Request, error) {
+	loginURL, err := replaceSecrets(o.URL, secrets)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+	for k, v := range o.Fields {
+		fields[k], err = replaceSecrets(v, secrets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	method := o.method()
+	var body io.Reader
+	contentType := ""
+	switch {
+	case method == http.MethodGet:
+		u, err := url.Parse(loginURL)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing url %s: %w", loginURL, err)
+		}
+		q := u.Query()
+		for k, v := range fields {
+			q.Set(k, v)
+		}
+		u.RawQuery = q.Encode()
+		loginURL = u.String()
+	case o.Type == loginTypeJSON:
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+		contentType = "application/json"
+	default:
+		values := make(url.Values)
+		for k, v := range fields {
+			values.Set(k, v)
+		}
+		body = strings.NewReader(values.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, loginURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	for _, h := range o.Headers {
+		if h.Key == "" {
+			continue
+		}
+
+		v, err := replaceSecrets(h.Value, secrets)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set(h.Key, v)
+	}
+
+	return req, nil
+}
+
+// loginSession holds the cookies and token of a logged in scraper.
+type loginSession struct {
+	mutex sync.Mutex
+	jar   *cookiejar.Jar
+	token string
+	valid bool
+	// generation is incremented on each login, so that concurrent requests
+	// which are rejected only cause a single login
+	generation int
+}
+
+// login logs in using the login options of the scraper, unless the session
+// is already logged in. Returns the generation of the session.
+func (s *loginSession) login(ctx context.Context, client *http.Client, c config, globalConfig GlobalConfig) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.valid {
+		return s.generation, nil
+	}
+
+	logger.Debugf("[scraper] %s: logging in", c.ID)
+
+	opts := c.DriverOptions.Login
+	req, err := opts.newRequest(ctx, globalConfig.GetScraperSecrets(c.ID))
+	if err != nil {
+		return 0, fmt.Errorf("scraper %s login: %w", c.ID, err)
+	}
+
+	userAgent := globalConfig.GetScraperUserAgent()
+	if userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	// start with the cookies of the scraper configuration
+	jar, err := c.jar()
+	if err != nil {
+		return 0, fmt.Errorf("error creating cookie jar: %w", err)
+	}
+
+	rateLimit := c.DriverOptions.RateLimit
+	limiter := c.rateLimiter.get(c.ID, req.URL.Host, rateLimit)
+	maxRetries := c.rateLimiter.maxRetries(rateLimit)
+	resp, err := doWithRetry(ctx, clientWithJar(client, jar), req, limiter, maxRetries)
+	if err != nil {
+		return 0, fmt.Errorf("scraper %s login: %w", c.ID, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return 0, fmt.Errorf("scraper %s login: http error %d:%s", c.ID, resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	if opts.Cookie != "" && !hasCookie(jar, req.URL, opts.Cookie) {
+		return 0, fmt.Errorf("scraper %s login: cookie %s was not set", c.ID, opts.Cookie)
+	}
+
+	token := ""
+	if opts.TokenPath != "" {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return 0, err
+		}
+
+		token = gjson.GetBytes(body, opts.TokenPath).String()
+		if token == "" {
+			return 0, fmt.Errorf("scraper %s login: token %s not found in response", c.ID, opts.TokenPath)
+		}
+	}
+
+	s.jar = jar
+	s.token = token
+	s.valid = true
+	s.generation++
+
+	return s.generation, nil
+}
+
+// invalidate marks the session as logged out, if it has not been logged in
+// again since the provided generation.
+func (s *loginSession) invalidate(generation int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.generation == generation {
+		s.valid = false
+	}
+}
+
+// authorize sets the token of the session on req, and returns a client which
+// uses the cookies of the session.
+func (s *loginSession) authorize(req *http.Request, client *http.Client) *http.Client {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	return clientWithJar(client, s.jar)
+}
+
+func clientWithJar(client *http.Client, jar http.CookieJar) *http.Client {
+	ret := *client
+	ret.Jar = jar
+	return &ret
+}
+
+func hasCookie(jar http.CookieJar, u *url.URL, name string) bool {
+	for _, c := range jar.Cookies(u) {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sessionStore maintains the login sessions of scrapers.
+type sessionStore struct {
+	mutex    sync.Mutex
+	sessions map[string]*loginSession
+}
+
+func newSessionStore() *sessionStore {
+	return &sessionStore{
+		sessions: make(map[string]*loginSession),
+	}
+}
+
+// get returns the login session of the provided scraper.
+func (s *sessionStore) get(scraperID string) *loginSession {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	ret := s.sessions[scraperID]
+	if ret == nil {
+		ret = &loginSession{}
+		s.sessions[scraperID] = ret
+	}
+
+	return ret
+}
+
+// reset removes the login session of the provided scraper, so that the
+// scraper logs in again on its next request.
+func (s *sessionStore) reset(scraperID string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	delete(s.sessions, scraperID)
+}
+
+// loginSession returns the login session of the scraper, or nil if the
+// scraper does not log in.
+func (c config) loginSession() *loginSession {
+	if c.DriverOptions == nil || c.DriverOptions.Login == nil || c.sessions == nil {
+		return nil
+	}
+
+	return c.sessions.get(c.ID)
+}
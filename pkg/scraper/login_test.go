@@ -0,0 +1,256 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type secretsGlobalConfig struct {
+	mockGlobalConfig
+	secrets map[string]string
+}
+
+func (c secretsGlobalConfig) GetScraperSecrets(scraperID string) map[string]string {
+	return c.secrets
+}
+
+// fakeLoginSite is a site which requires a login before serving pages.
+type fakeLoginSite struct {
+	mutex    sync.Mutex
+	logins   int
+	sessions map[string]bool
+	tokens   map[string]bool
+}
+
+func newFakeLoginSite() *fakeLoginSite {
+	return &fakeLoginSite{
+		sessions: make(map[string]bool),
+		tokens:   make(map[string]bool),
+	}
+}
+
+// expire invalidates all existing sessions and tokens.
+func (s *fakeLoginSite) expire() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.sessions = make(map[string]bool)
+	s.tokens = make(map[string]bool)
+}
+
+func (s *fakeLoginSite) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	switch r.URL.Path {
+	case "/login":
+		var username, password string
+		isJSON := r.Header.Get("Content-Type") == "application/json"
+		if isJSON {
+			var body map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			username, password = body["username"], body["password"]
+		} else {
+			_ = r.ParseForm()
+			username, password = r.PostForm.Get("username"), r.PostForm.Get("password")
+		}
+
+		if username != "user" || password != "secret" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		s.logins++
+		id := strings.Repeat("a", s.logins)
+		// json logins use a token rather than a session cookie
+		if isJSON {
+			s.tokens[id] = true
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"auth": {"token": "`+id+`"}}`)
+			return
+		}
+
+		s.sessions[id] = true
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: id, Path: "/"})
+	case "/page":
+		authorized := false
+		if c, err := r.Cookie("session"); err == nil && s.sessions[c.Value] {
+			authorized = true
+		}
+		if token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "); s.tokens[token] {
+			authorized = true
+		}
+
+		if !authorized {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = io.WriteString(w, "content")
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestFetchURL_Login(t *testing.T) {
+	validSecrets := map[string]string{
+		"username": "user",
+		"password": "secret",
+	}
+
+	tests := []struct {
+		name    string
+		login   loginOptions
+		secrets map[string]string
+		wantErr bool
+	}{
+		{
+			"form",
+			loginOptions{
+				Fields: map[string]string{
+					"username": "{username}",
+					"password": "{password}",
+				},
+				Cookie: "session",
+			},
+			validSecrets,
+			false,
+		},
+		{
+			"json token",
+			loginOptions{
+				Type: loginTypeJSON,
+				Fields: map[string]string{
+					"username": "{username}",
+					"password": "{password}",
+				},
+				TokenPath: "auth.token",
+			},
+			validSecrets,
+			false,
+		},
+		{
+			"missing secret",
+			loginOptions{
+				Fields: map[string]string{
+					"username": "{username}",
+					"password": "{password}",
+				},
+			},
+			map[string]string{
+				"username": "user",
+			},
+			true,
+		},
+		{
+			"invalid credentials",
+			loginOptions{
+				Fields: map[string]string{
+					"username": "{username}",
+					"password": "{password}",
+				},
+			},
+			map[string]string{
+				"username": "user",
+				"password": "wrong",
+			},
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site := newFakeLoginSite()
+			server := httptest.NewServer(site)
+			defer server.Close()
+
+			login := tt.login
+			login.URL = server.URL + "/login"
+
+			c := config{
+				ID:   "test",
+				Name: "Test",
+				DriverOptions: &scraperDriverOptions{
+					Login: &login,
+				},
+				sessions: newSessionStore(),
+			}
+			if err := c.validate(); err != nil {
+				t.Fatalf("validate: %v", err)
+			}
+
+			globalConfig := secretsGlobalConfig{secrets: tt.secrets}
+			ctx := context.Background()
+
+			fetch := func() (string, error) {
+				r, err := fetchURL(ctx, server.URL+"/page", server.Client(), c, globalConfig)
+				if err != nil {
+					return "", err
+				}
+				b, err := io.ReadAll(r)
+				return string(b), err
+			}
+
+			got, err := fetch()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, "content", got)
+
+			// the session is reused
+			_, err = fetch()
+			assert.NoError(t, err)
+			assert.Equal(t, 1, site.logins)
+
+			// the scraper logs in again when the session expires
+			site.expire()
+			got, err = fetch()
+			assert.NoError(t, err)
+			assert.Equal(t, "content", got)
+			assert.Equal(t, 2, site.logins)
+		})
+	}
+}
+
+func TestReplaceSecrets(t *testing.T) {
+	secrets := map[string]string{
+		"username": "user",
+	}
+
+	got, err := replaceSecrets("name={Username}&x={}", secrets)
+	assert.NoError(t, err)
+	assert.Equal(t, "name=user&x={}", got)
+
+	_, err = replaceSecrets("{password}", secrets)
+	assert.Error(t, err)
+}
+
+func TestConfig_validateLogin(t *testing.T) {
+	c := config{
+		Name: "Test",
+		DriverOptions: &scraperDriverOptions{
+			UseCDP: true,
+			Login:  &loginOptions{URL: "https://example.com/login"},
+		},
+	}
+	assert.Error(t, c.validate())
+
+	c.DriverOptions.UseCDP = false
+	assert.NoError(t, c.validate())
+
+	c.DriverOptions.Login.Type = "xml"
+	assert.Error(t, c.validate())
+}
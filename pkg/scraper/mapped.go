@@ -2,18 +2,25 @@ package scraper
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math"
+	"net/url"
 	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"unicode"
+
+	"github.com/dop251/goja"
+	"github.com/tidwall/gjson"
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
+	"github.com/stashapp/stash/pkg/utils"
 	"gopkg.in/yaml.v2"
 )
 
@@ -21,6 +28,9 @@ type mappedQuery interface {
 	runQuery(selector string) ([]string, error)
 	getType() QueryType
 	setType(QueryType)
+	// getURL returns the URL of the page being queried, or an empty string if
+	// it is not known.
+	getURL() string
 	subScrape(ctx context.Context, value string) mappedQuery
 }
 
@@ -226,6 +236,60 @@ func (s *mappedGalleryScraperConfig) UnmarshalYAML(unmarshal func(interface{}) e
 	return nil
 }
 
+type mappedImageScraperConfig struct {
+	mappedConfig
+
+	Tags       mappedConfig `yaml:"Tags"`
+	Performers mappedConfig `yaml:"Performers"`
+	Studio     mappedConfig `yaml:"Studio"`
+}
+type _mappedImageScraperConfig mappedImageScraperConfig
+
+func (s *mappedImageScraperConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// HACK - unmarshal to map first, then remove known image sub-fields, then
+	// remarshal to yaml and pass that down to the base map
+	parentMap := make(map[string]interface{})
+	if err := unmarshal(parentMap); err != nil {
+		return err
+	}
+
+	// move the known sub-fields to a separate map
+	thisMap := make(map[string]interface{})
+
+	thisMap[mappedScraperConfigSceneTags] = parentMap[mappedScraperConfigSceneTags]
+	thisMap[mappedScraperConfigScenePerformers] = parentMap[mappedScraperConfigScenePerformers]
+	thisMap[mappedScraperConfigSceneStudio] = parentMap[mappedScraperConfigSceneStudio]
+
+	delete(parentMap, mappedScraperConfigSceneTags)
+	delete(parentMap, mappedScraperConfigScenePerformers)
+	delete(parentMap, mappedScraperConfigSceneStudio)
+
+	// re-unmarshal the sub-fields
+	yml, err := yaml.Marshal(thisMap)
+	if err != nil {
+		return err
+	}
+
+	// needs to be a different type to prevent infinite recursion
+	c := _mappedImageScraperConfig{}
+	if err := yaml.Unmarshal(yml, &c); err != nil {
+		return err
+	}
+
+	*s = mappedImageScraperConfig(c)
+
+	yml, err = yaml.Marshal(parentMap)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(yml, &s.mappedConfig); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 type mappedPerformerScraperConfig struct {
 	mappedConfig
 
@@ -330,6 +394,58 @@ func (s *mappedMovieScraperConfig) UnmarshalYAML(unmarshal func(interface{}) err
 	return nil
 }
 
+type mappedStudioScraperConfig struct {
+	mappedConfig
+
+	Parent mappedConfig `yaml:"Parent"`
+}
+type _mappedStudioScraperConfig mappedStudioScraperConfig
+
+const (
+	mappedScraperConfigStudioParent = "Parent"
+)
+
+func (s *mappedStudioScraperConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	// HACK - unmarshal to map first, then remove known studio sub-fields, then
+	// remarshal to yaml and pass that down to the base map
+	parentMap := make(map[string]interface{})
+	if err := unmarshal(parentMap); err != nil {
+		return err
+	}
+
+	// move the known sub-fields to a separate map
+	thisMap := make(map[string]interface{})
+
+	thisMap[mappedScraperConfigStudioParent] = parentMap[mappedScraperConfigStudioParent]
+
+	delete(parentMap, mappedScraperConfigStudioParent)
+
+	// re-unmarshal the sub-fields
+	yml, err := yaml.Marshal(thisMap)
+	if err != nil {
+		return err
+	}
+
+	// needs to be a different type to prevent infinite recursion
+	c := _mappedStudioScraperConfig{}
+	if err := yaml.Unmarshal(yml, &c); err != nil {
+		return err
+	}
+
+	*s = mappedStudioScraperConfig(c)
+
+	yml, err = yaml.Marshal(parentMap)
+	if err != nil {
+		return err
+	}
+
+	if err := yaml.Unmarshal(yml, &s.mappedConfig); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 type mappedRegexConfig struct {
 	Regex string `yaml:"regex"`
 	With  string `yaml:"with"`
@@ -523,14 +639,237 @@ func (p *postProcessLbToKg) Apply(ctx context.Context, value string, q mappedQue
 	return value
 }
 
+type postProcessKgToLb bool
+
+func (p *postProcessKgToLb) Apply(ctx context.Context, value string, q mappedQuery) string {
+	const kg_in_lb = 2.20462262
+	return convertUnit(value, kg_in_lb)
+}
+
+type postProcessCmToInch bool
+
+func (p *postProcessCmToInch) Apply(ctx context.Context, value string, q mappedQuery) string {
+	const cm_in_inch = 1 / 2.54
+	return convertUnit(value, cm_in_inch)
+}
+
+type postProcessInchToCm bool
+
+func (p *postProcessInchToCm) Apply(ctx context.Context, value string, q mappedQuery) string {
+	const inch_in_cm = 2.54
+	return convertUnit(value, inch_in_cm)
+}
+
+var numberRE = regexp.MustCompile(`[0-9]+(?:\.[0-9]+)?`)
+
+// convertUnit multiplies the first number in value by factor, returning the
+// rounded integer string. Returns value unchanged if it contains no number.
+func convertUnit(value string, factor float64) string {
+	n := numberRE.FindString(value)
+	if n == "" {
+		return value
+	}
+
+	f, err := strconv.ParseFloat(n, 64)
+	if err != nil {
+		return value
+	}
+
+	return strconv.Itoa(int(math.Round(f * factor)))
+}
+
+type postProcessParseDuration bool
+
+// Apply converts durations such as 1:02:03, PT1H2M3S or 1h 2m 3s into seconds.
+func (p *postProcessParseDuration) Apply(ctx context.Context, value string, q mappedQuery) string {
+	seconds, err := utils.ParseDuration(value)
+	if err != nil {
+		logger.Warnf("Error parsing duration string '%s'", value)
+		return value
+	}
+
+	return strconv.Itoa(seconds)
+}
+
+type postProcessTrim bool
+
+func (p *postProcessTrim) Apply(ctx context.Context, value string, q mappedQuery) string {
+	return strings.TrimSpace(value)
+}
+
+const (
+	caseLower = "lower"
+	caseUpper = "upper"
+	caseTitle = "title"
+)
+
+type postProcessCase string
+
+func (p *postProcessCase) Apply(ctx context.Context, value string, q mappedQuery) string {
+	switch string(*p) {
+	case caseLower:
+		return strings.ToLower(value)
+	case caseUpper:
+		return strings.ToUpper(value)
+	case caseTitle:
+		return titleCase(value)
+	}
+
+	return value
+}
+
+// titleCase upper-cases the first letter of each word in s, and lower-cases
+// the other letters.
+func titleCase(s string) string {
+	ret := []rune(strings.ToLower(s))
+	start := true
+	for i, r := range ret {
+		if start && unicode.IsLetter(r) {
+			ret[i] = unicode.ToUpper(r)
+		}
+		start = unicode.IsSpace(r) || r == '-'
+	}
+
+	return string(ret)
+}
+
+type postProcessJoinURL bool
+
+// Apply resolves value against the URL of the page being scraped.
+func (p *postProcessJoinURL) Apply(ctx context.Context, value string, q mappedQuery) string {
+	base := q.getURL()
+	if base == "" || value == "" {
+		return value
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		logger.Warnf("Error parsing page url '%s': %v", base, err)
+		return value
+	}
+
+	ref, err := url.Parse(value)
+	if err != nil {
+		logger.Warnf("Error parsing url '%s': %v", value, err)
+		return value
+	}
+
+	return baseURL.ResolveReference(ref).String()
+}
+
+type postProcessExtractJSON string
+
+// Apply extracts the value at the gjson path from the JSON in value. If value
+// is not JSON, the first JSON object or array in value is used, so that data
+// embedded in scripts can be extracted. Multiple results are joined with new
+// lines.
+func (p *postProcessExtractJSON) Apply(ctx context.Context, value string, q mappedQuery) string {
+	doc := value
+	if !gjson.Valid(doc) {
+		doc = findJSON(value)
+		if doc == "" {
+			logger.Warnf("No json found in '%s'", value)
+			return ""
+		}
+	}
+
+	result := gjson.Get(doc, string(*p))
+	if result.IsArray() {
+		var values []string
+		for _, r := range result.Array() {
+			values = append(values, r.String())
+		}
+		return strings.Join(values, "\n")
+	}
+
+	return result.String()
+}
+
+// findJSON returns the first valid JSON object or array in s.
+func findJSON(s string) string {
+	for i, r := range s {
+		if r != '{' && r != '[' {
+			continue
+		}
+
+		var raw json.RawMessage
+		if err := json.NewDecoder(strings.NewReader(s[i:])).Decode(&raw); err == nil {
+			return string(raw)
+		}
+	}
+
+	return ""
+}
+
+// javascriptTimeout is the maximum time a javascript post-process action
+// may run for.
+const javascriptTimeout = 5 * time.Second
+
+type postProcessJavascript struct {
+	src     string
+	program *goja.Program
+}
+
+func newPostProcessJavascript(src string) (*postProcessJavascript, error) {
+	program, err := goja.Compile("postProcess", src, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid javascript post-process action: %w", err)
+	}
+
+	return &postProcessJavascript{
+		src:     src,
+		program: program,
+	}, nil
+}
+
+// Apply evaluates the javascript expression with value and url set to the
+// value and the URL of the page being scraped.
+func (p *postProcessJavascript) Apply(ctx context.Context, value string, q mappedQuery) string {
+	vm := goja.New()
+	if err := vm.Set("value", value); err != nil {
+		logger.Warnf("Error setting javascript value: %v", err)
+		return value
+	}
+	if err := vm.Set("url", q.getURL()); err != nil {
+		logger.Warnf("Error setting javascript url: %v", err)
+		return value
+	}
+
+	timer := time.AfterFunc(javascriptTimeout, func() {
+		vm.Interrupt("timeout")
+	})
+	defer timer.Stop()
+
+	result, err := vm.RunProgram(p.program)
+	if err != nil {
+		logger.Warnf("Error running javascript '%s' for value '%s': %v", p.src, value, err)
+		return value
+	}
+
+	if goja.IsUndefined(result) || goja.IsNull(result) {
+		return ""
+	}
+
+	return result.String()
+}
+
 type mappedPostProcessAction struct {
-	ParseDate    string                   `yaml:"parseDate"`
-	SubtractDays bool                     `yaml:"subtractDays"`
-	Replace      mappedRegexConfigs       `yaml:"replace"`
-	SubScraper   *mappedScraperAttrConfig `yaml:"subScraper"`
-	Map          map[string]string        `yaml:"map"`
-	FeetToCm     bool                     `yaml:"feetToCm"`
-	LbToKg       bool                     `yaml:"lbToKg"`
+	ParseDate     string                   `yaml:"parseDate"`
+	SubtractDays  bool                     `yaml:"subtractDays"`
+	Replace       mappedRegexConfigs       `yaml:"replace"`
+	SubScraper    *mappedScraperAttrConfig `yaml:"subScraper"`
+	Map           map[string]string        `yaml:"map"`
+	FeetToCm      bool                     `yaml:"feetToCm"`
+	LbToKg        bool                     `yaml:"lbToKg"`
+	KgToLb        bool                     `yaml:"kgToLb"`
+	CmToInch      bool                     `yaml:"cmToInch"`
+	InchToCm      bool                     `yaml:"inchToCm"`
+	ParseDuration bool                     `yaml:"parseDuration"`
+	Trim          bool                     `yaml:"trim"`
+	Case          string                   `yaml:"case"`
+	JoinURL       bool                     `yaml:"joinURL"`
+	ExtractJSON   string                   `yaml:"extractJSON"`
+	Javascript    string                   `yaml:"javascript"`
 }
 
 func (a mappedPostProcessAction) ToPostProcessAction() (postProcessAction, error) {
@@ -586,10 +925,89 @@ func (a mappedPostProcessAction) ToPostProcessAction() (postProcessAction, error
 		if found != "" {
 			return nil, fmt.Errorf("post-process actions must have a single field, found %s and %s", found, "subtractDays")
 		}
-		// found = "subtractDays"
+		found = "subtractDays"
 		action := postProcessSubtractDays(a.SubtractDays)
 		ret = &action
 	}
+	if a.KgToLb {
+		if found != "" {
+			return nil, fmt.Errorf("post-process actions must have a single field, found %s and %s", found, "kgToLb")
+		}
+		found = "kgToLb"
+		action := postProcessKgToLb(a.KgToLb)
+		ret = &action
+	}
+	if a.CmToInch {
+		if found != "" {
+			return nil, fmt.Errorf("post-process actions must have a single field, found %s and %s", found, "cmToInch")
+		}
+		found = "cmToInch"
+		action := postProcessCmToInch(a.CmToInch)
+		ret = &action
+	}
+	if a.InchToCm {
+		if found != "" {
+			return nil, fmt.Errorf("post-process actions must have a single field, found %s and %s", found, "inchToCm")
+		}
+		found = "inchToCm"
+		action := postProcessInchToCm(a.InchToCm)
+		ret = &action
+	}
+	if a.ParseDuration {
+		if found != "" {
+			return nil, fmt.Errorf("post-process actions must have a single field, found %s and %s", found, "parseDuration")
+		}
+		found = "parseDuration"
+		action := postProcessParseDuration(a.ParseDuration)
+		ret = &action
+	}
+	if a.Trim {
+		if found != "" {
+			return nil, fmt.Errorf("post-process actions must have a single field, found %s and %s", found, "trim")
+		}
+		found = "trim"
+		action := postProcessTrim(a.Trim)
+		ret = &action
+	}
+	if a.Case != "" {
+		if found != "" {
+			return nil, fmt.Errorf("post-process actions must have a single field, found %s and %s", found, "case")
+		}
+		found = "case"
+		switch a.Case {
+		case caseLower, caseUpper, caseTitle:
+		default:
+			return nil, fmt.Errorf("case must be %s, %s or %s", caseLower, caseUpper, caseTitle)
+		}
+		action := postProcessCase(a.Case)
+		ret = &action
+	}
+	if a.JoinURL {
+		if found != "" {
+			return nil, fmt.Errorf("post-process actions must have a single field, found %s and %s", found, "joinURL")
+		}
+		found = "joinURL"
+		action := postProcessJoinURL(a.JoinURL)
+		ret = &action
+	}
+	if a.ExtractJSON != "" {
+		if found != "" {
+			return nil, fmt.Errorf("post-process actions must have a single field, found %s and %s", found, "extractJSON")
+		}
+		found = "extractJSON"
+		action := postProcessExtractJSON(a.ExtractJSON)
+		ret = &action
+	}
+	if a.Javascript != "" {
+		if found != "" {
+			return nil, fmt.Errorf("post-process actions must have a single field, found %s and %s", found, "javascript")
+		}
+		action, err := newPostProcessJavascript(a.Javascript)
+		if err != nil {
+			return nil, err
+		}
+		ret = action
+	}
 
 	if ret == nil {
 		return nil, errors.New("invalid post-process action")
@@ -729,8 +1147,14 @@ type mappedScraper struct {
 	Common    commonMappedConfig            `yaml:"common"`
 	Scene     *mappedSceneScraperConfig     `yaml:"scene"`
 	Gallery   *mappedGalleryScraperConfig   `yaml:"gallery"`
+	Image     *mappedImageScraperConfig     `yaml:"image"`
 	Performer *mappedPerformerScraperConfig `yaml:"performer"`
 	Movie     *mappedMovieScraperConfig     `yaml:"movie"`
+	Studio    *mappedStudioScraperConfig    `yaml:"studio"`
+	Tag       mappedConfig                  `yaml:"tag"`
+
+	// Pagination applies to list results, such as those of byName queries.
+	Pagination *mappedPaginationConfig `yaml:"pagination"`
 }
 
 type mappedResult map[string]string
@@ -752,6 +1176,8 @@ func (r mappedResult) apply(dest interface{}) {
 				// same pointer
 				localValue := value
 				reflectValue = reflect.ValueOf(&localValue)
+			} else if field.Kind() == reflect.Slice {
+				reflectValue = reflect.ValueOf([]string{value})
 			} else {
 				reflectValue = reflect.ValueOf(value)
 			}
@@ -763,6 +1189,22 @@ func (r mappedResult) apply(dest interface{}) {
 	}
 }
 
+// urls returns the URL and URLs values of all results. Each node matched by
+// a selector yields a separate result, so single object scrapes use this to
+// include every url found, rather than only that of the first result.
+func (r mappedResults) urls() []string {
+	var ret []string
+	for _, result := range r {
+		for _, key := range []string{"URL", "URLs"} {
+			if v, found := result[key]; found {
+				ret = append(ret, v)
+			}
+		}
+	}
+
+	return ret
+}
+
 func (r mappedResults) setKey(index int, key string, value string) mappedResults {
 	if index >= len(r) {
 		r = append(r, make(mappedResult))
@@ -787,6 +1229,7 @@ func (s mappedScraper) scrapePerformer(ctx context.Context, q mappedQuery) (*mod
 	if len(results) > 0 {
 		ret = &models.ScrapedPerformer{}
 		results[0].apply(ret)
+		ret.URLs = results.urls()
 
 		// now apply the tags
 		if performerTagsMap != nil {
@@ -812,14 +1255,17 @@ func (s mappedScraper) scrapePerformers(ctx context.Context, q mappedQuery) ([]*
 		return nil, nil
 	}
 
-	results := performerMap.process(ctx, q, s.Common)
-	for _, r := range results {
-		var p models.ScrapedPerformer
-		r.apply(&p)
-		ret = append(ret, &p)
-	}
+	err := s.eachPage(ctx, q, func(q mappedQuery) error {
+		results := performerMap.process(ctx, q, s.Common)
+		for _, r := range results {
+			var p models.ScrapedPerformer
+			r.apply(&p)
+			ret = append(ret, &p)
+		}
+		return nil
+	})
 
-	return ret, nil
+	return ret, err
 }
 
 func (s mappedScraper) processScene(ctx context.Context, q mappedQuery, r mappedResult) *ScrapedScene {
@@ -907,13 +1353,16 @@ func (s mappedScraper) scrapeScenes(ctx context.Context, q mappedQuery) ([]*Scra
 	}
 
 	logger.Debug(`Processing scenes:`)
-	results := sceneMap.process(ctx, q, s.Common)
-	for _, r := range results {
-		logger.Debug(`Processing scene:`)
-		ret = append(ret, s.processScene(ctx, q, r))
-	}
+	err := s.eachPage(ctx, q, func(q mappedQuery) error {
+		results := sceneMap.process(ctx, q, s.Common)
+		for _, r := range results {
+			logger.Debug(`Processing scene:`)
+			ret = append(ret, s.processScene(ctx, q, r))
+		}
+		return nil
+	})
 
-	return ret, nil
+	return ret, err
 }
 
 func (s mappedScraper) scrapeScene(ctx context.Context, q mappedQuery) (*ScrapedScene, error) {
@@ -929,6 +1378,7 @@ func (s mappedScraper) scrapeScene(ctx context.Context, q mappedQuery) (*Scraped
 	results := sceneMap.process(ctx, q, s.Common)
 	if len(results) > 0 {
 		ret = s.processScene(ctx, q, results[0])
+		ret.URLs = results.urls()
 	}
 
 	return ret, nil
@@ -953,6 +1403,7 @@ func (s mappedScraper) scrapeGallery(ctx context.Context, q mappedQuery) (*Scrap
 		ret = &ScrapedGallery{}
 
 		results[0].apply(ret)
+		ret.URLs = results.urls()
 
 		// now apply the performers and tags
 		if galleryPerformersMap != nil {
@@ -992,25 +1443,52 @@ func (s mappedScraper) scrapeGallery(ctx context.Context, q mappedQuery) (*Scrap
 	return ret, nil
 }
 
-func (s mappedScraper) scrapeMovie(ctx context.Context, q mappedQuery) (*models.ScrapedMovie, error) {
-	var ret *models.ScrapedMovie
+func (s mappedScraper) scrapeImage(ctx context.Context, q mappedQuery) (*ScrapedImage, error) {
+	var ret *ScrapedImage
 
-	movieScraperConfig := s.Movie
-	movieMap := movieScraperConfig.mappedConfig
-	if movieMap == nil {
+	imageScraperConfig := s.Image
+	if imageScraperConfig == nil || imageScraperConfig.mappedConfig == nil {
 		return nil, nil
 	}
 
-	movieStudioMap := movieScraperConfig.Studio
+	imageMap := imageScraperConfig.mappedConfig
+	imagePerformersMap := imageScraperConfig.Performers
+	imageTagsMap := imageScraperConfig.Tags
+	imageStudioMap := imageScraperConfig.Studio
 
-	results := movieMap.process(ctx, q, s.Common)
+	logger.Debug(`Processing image:`)
+	results := imageMap.process(ctx, q, s.Common)
 	if len(results) > 0 {
-		ret = &models.ScrapedMovie{}
+		ret = &ScrapedImage{}
+
 		results[0].apply(ret)
 
-		if movieStudioMap != nil {
-			logger.Debug(`Processing movie studio:`)
-			studioResults := movieStudioMap.process(ctx, q, s.Common)
+		// now apply the performers and tags
+		if imagePerformersMap != nil {
+			logger.Debug(`Processing image performers:`)
+			performerResults := imagePerformersMap.process(ctx, q, s.Common)
+
+			for _, p := range performerResults {
+				performer := &models.ScrapedPerformer{}
+				p.apply(performer)
+				ret.Performers = append(ret.Performers, performer)
+			}
+		}
+
+		if imageTagsMap != nil {
+			logger.Debug(`Processing image tags:`)
+			tagResults := imageTagsMap.process(ctx, q, s.Common)
+
+			for _, p := range tagResults {
+				tag := &models.ScrapedTag{}
+				p.apply(tag)
+				ret.Tags = append(ret.Tags, tag)
+			}
+		}
+
+		if imageStudioMap != nil {
+			logger.Debug(`Processing image studio:`)
+			studioResults := imageStudioMap.process(ctx, q, s.Common)
 
 			if len(studioResults) > 0 {
 				studio := &models.ScrapedStudio{}
@@ -1022,3 +1500,152 @@ func (s mappedScraper) scrapeMovie(ctx context.Context, q mappedQuery) (*models.
 
 	return ret, nil
 }
+
+func (s mappedScraper) processMovie(ctx context.Context, q mappedQuery, r mappedResult) *models.ScrapedMovie {
+	ret := &models.ScrapedMovie{}
+	r.apply(ret)
+
+	movieStudioMap := s.Movie.Studio
+	if movieStudioMap != nil {
+		logger.Debug(`Processing movie studio:`)
+		studioResults := movieStudioMap.process(ctx, q, s.Common)
+
+		if len(studioResults) > 0 {
+			studio := &models.ScrapedStudio{}
+			studioResults[0].apply(studio)
+			ret.Studio = studio
+		}
+	}
+
+	return ret
+}
+
+func (s mappedScraper) scrapeMovie(ctx context.Context, q mappedQuery) (*models.ScrapedMovie, error) {
+	if s.Movie == nil || s.Movie.mappedConfig == nil {
+		return nil, nil
+	}
+
+	results := s.Movie.process(ctx, q, s.Common)
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	ret := s.processMovie(ctx, q, results[0])
+	ret.URLs = results.urls()
+
+	return ret, nil
+}
+
+func (s mappedScraper) scrapeMovies(ctx context.Context, q mappedQuery) ([]*models.ScrapedMovie, error) {
+	var ret []*models.ScrapedMovie
+
+	if s.Movie == nil || s.Movie.mappedConfig == nil {
+		return nil, nil
+	}
+
+	logger.Debug(`Processing movies:`)
+	err := s.eachPage(ctx, q, func(q mappedQuery) error {
+		results := s.Movie.process(ctx, q, s.Common)
+		for _, r := range results {
+			logger.Debug(`Processing movie:`)
+			ret = append(ret, s.processMovie(ctx, q, r))
+		}
+		return nil
+	})
+
+	return ret, err
+}
+
+func (s mappedScraper) processStudio(ctx context.Context, q mappedQuery, r mappedResult) *models.ScrapedStudio {
+	ret := &models.ScrapedStudio{}
+	r.apply(ret)
+
+	if s.Studio.Parent != nil {
+		logger.Debug(`Processing studio parent:`)
+		parentResults := s.Studio.Parent.process(ctx, q, s.Common)
+
+		if len(parentResults) > 0 {
+			parent := &models.ScrapedStudio{}
+			parentResults[0].apply(parent)
+			ret.Parent = parent
+		}
+	}
+
+	return ret
+}
+
+func (s mappedScraper) scrapeStudio(ctx context.Context, q mappedQuery) (*models.ScrapedStudio, error) {
+	if s.Studio == nil || s.Studio.mappedConfig == nil {
+		return nil, nil
+	}
+
+	logger.Debug(`Processing studio:`)
+	results := s.Studio.process(ctx, q, s.Common)
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	return s.processStudio(ctx, q, results[0]), nil
+}
+
+func (s mappedScraper) scrapeStudios(ctx context.Context, q mappedQuery) ([]*models.ScrapedStudio, error) {
+	var ret []*models.ScrapedStudio
+
+	if s.Studio == nil || s.Studio.mappedConfig == nil {
+		return nil, nil
+	}
+
+	logger.Debug(`Processing studios:`)
+	err := s.eachPage(ctx, q, func(q mappedQuery) error {
+		results := s.Studio.process(ctx, q, s.Common)
+		for _, r := range results {
+			logger.Debug(`Processing studio:`)
+			ret = append(ret, s.processStudio(ctx, q, r))
+		}
+		return nil
+	})
+
+	return ret, err
+}
+
+func (s mappedScraper) scrapeTag(ctx context.Context, q mappedQuery) (*models.ScrapedTag, error) {
+	tags := s.processTags(ctx, q)
+	if len(tags) == 0 {
+		return nil, nil
+	}
+
+	return tags[0], nil
+}
+
+func (s mappedScraper) scrapeTags(ctx context.Context, q mappedQuery) ([]*models.ScrapedTag, error) {
+	var ret []*models.ScrapedTag
+
+	if s.Tag == nil {
+		return nil, nil
+	}
+
+	err := s.eachPage(ctx, q, func(q mappedQuery) error {
+		ret = append(ret, s.processTags(ctx, q)...)
+		return nil
+	})
+
+	return ret, err
+}
+
+func (s mappedScraper) processTags(ctx context.Context, q mappedQuery) []*models.ScrapedTag {
+	if s.Tag == nil {
+		return nil
+	}
+
+	var ret []*models.ScrapedTag
+
+	logger.Debug(`Processing tags:`)
+	results := s.Tag.process(ctx, q, s.Common)
+	for _, r := range results {
+		tag := &models.ScrapedTag{}
+		r.apply(tag)
+		ret = append(ret, tag)
+	}
+
+	return ret
+}
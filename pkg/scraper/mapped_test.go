@@ -115,3 +115,179 @@ func Test_postProcessParseDate_Apply(t *testing.T) {
 		})
 	}
 }
+
+func TestUnitConversions(t *testing.T) {
+	kgToLb := postProcessKgToLb(true)
+	cmToInch := postProcessCmToInch(true)
+	inchToCm := postProcessInchToCm(true)
+
+	tests := []struct {
+		name   string
+		action postProcessAction
+		in     string
+		out    string
+	}{
+		{"kgToLb", &kgToLb, "57", "126"},
+		{"kgToLb with unit", &kgToLb, "57.5 kg", "127"},
+		{"kgToLb invalid", &kgToLb, "unknown", "unknown"},
+		{"cmToInch", &cmToInch, "167", "66"},
+		{"cmToInch with unit", &cmToInch, "86cm", "34"},
+		{"inchToCm", &inchToCm, "34", "86"},
+		{"inchToCm with unit", &inchToCm, "34\"", "86"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.out, tt.action.Apply(context.Background(), tt.in, nil))
+		})
+	}
+}
+
+func Test_postProcessParseDuration_Apply(t *testing.T) {
+	tests := []struct {
+		in  string
+		out string
+	}{
+		{"90", "90"},
+		{"12:34", "754"},
+		{"1:02:03", "3723"},
+		{"PT1H2M3S", "3723"},
+		{"PT25M", "1500"},
+		{"1h 2m 3s", "3723"},
+		{"1h2m3s", "3723"},
+		{"1h30m", "5400"},
+		{"1 hour 30 minutes", "5400"},
+		{"45 min", "2700"},
+		{"1.5 hrs", "5400"},
+		{"unknown", "unknown"},
+	}
+
+	pp := postProcessParseDuration(true)
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			assert.Equal(t, tt.out, pp.Apply(context.Background(), tt.in, nil))
+		})
+	}
+}
+
+func Test_postProcessCase_Apply(t *testing.T) {
+	tests := []struct {
+		c   string
+		in  string
+		out string
+	}{
+		{caseLower, "Some TITLE", "some title"},
+		{caseUpper, "Some title", "SOME TITLE"},
+		{caseTitle, "sOME title-case words", "Some Title-Case Words"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.c, func(t *testing.T) {
+			pp := postProcessCase(tt.c)
+			assert.Equal(t, tt.out, pp.Apply(context.Background(), tt.in, nil))
+		})
+	}
+}
+
+func Test_postProcessJoinURL_Apply(t *testing.T) {
+	pp := postProcessJoinURL(true)
+	ctx := context.Background()
+
+	q := &xpathQuery{url: "https://example.com/scenes/1?page=2"}
+	assert.Equal(t, "https://example.com/performers/2", pp.Apply(ctx, "/performers/2", q))
+	assert.Equal(t, "https://example.com/scenes/image.jpg", pp.Apply(ctx, "image.jpg", q))
+	assert.Equal(t, "https://other.com/a", pp.Apply(ctx, "https://other.com/a", q))
+
+	// unchanged if the page url is not known
+	assert.Equal(t, "/performers/2", pp.Apply(ctx, "/performers/2", &xpathQuery{}))
+}
+
+func Test_postProcessExtractJSON_Apply(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		value string
+		want  string
+	}{
+		{
+			"json",
+			"name",
+			`{"name": "Scene", "actor": [{"name": "A"}, {"name": "B"}]}`,
+			"Scene",
+		},
+		{
+			"array",
+			"actor.#.name",
+			`{"name": "Scene", "actor": [{"name": "A"}, {"name": "B"}]}`,
+			"A\nB",
+		},
+		{
+			"embedded in script",
+			"video.duration",
+			`window.__DATA__ = {"video": {"duration": 90}}; init();`,
+			"90",
+		},
+		{
+			"no json",
+			"name",
+			"text",
+			"",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pp := postProcessExtractJSON(tt.path)
+			assert.Equal(t, tt.want, pp.Apply(context.Background(), tt.value, nil))
+		})
+	}
+}
+
+func Test_postProcessJavascript_Apply(t *testing.T) {
+	q := &xpathQuery{url: "https://example.com/scenes/1"}
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		src  string
+		in   string
+		want string
+	}{
+		{"expression", `value.split(" ").reverse().join(" ")`, "b a", "a b"},
+		{"url", `url + "/" + value`, "x", "https://example.com/scenes/1/x"},
+		{"number", `parseInt(value) * 2`, "21", "42"},
+		{"undefined", `undefined`, "x", ""},
+		{"runtime error", `value.missing.field`, "x", "x"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pp, err := newPostProcessJavascript(tt.src)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.want, pp.Apply(ctx, tt.in, q))
+		})
+	}
+
+	_, err := newPostProcessJavascript("value +")
+	assert.Error(t, err)
+}
+
+func TestMappedPostProcessAction_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		action mappedPostProcessAction
+	}{
+		{"multiple fields", mappedPostProcessAction{Trim: true, KgToLb: true}},
+		{"invalid case", mappedPostProcessAction{Case: "camel"}},
+		{"invalid javascript", mappedPostProcessAction{Javascript: "value +"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.action.ToPostProcessAction()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// defaultMaxPages is the maximum number of pages loaded by a paginated
+// scraper when maxPages is not set.
+const defaultMaxPages = 10
+
+// mappedPaginationConfig configures how a mapped scraper follows the pages
+// of a list, such as search results or a filmography. Results from all pages
+// are merged.
+type mappedPaginationConfig struct {
+	// NextPage selects the URL of the next page. Relative URLs are resolved
+	// against the URL of the current page.
+	NextPage mappedScraperAttrConfig `yaml:"nextPage"`
+	// MaxPages is the maximum number of pages to load, including the first.
+	// Defaults to defaultMaxPages.
+	MaxPages int `yaml:"maxPages"`
+	// StopWhen is a selector which stops pagination after the current page
+	// when it returns a non-empty value.
+	StopWhen string `yaml:"stopWhen"`
+}
+
+type _mappedPaginationConfig mappedPaginationConfig
+
+func (c *mappedPaginationConfig) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	t := _mappedPaginationConfig{}
+	if err := unmarshal(&t); err != nil {
+		return err
+	}
+
+	if t.NextPage.Selector == "" {
+		return errors.New("pagination: nextPage selector is required")
+	}
+
+	if t.MaxPages < 0 {
+		return errors.New("pagination: maxPages must not be negative")
+	}
+
+	*c = mappedPaginationConfig(t)
+	return nil
+}
+
+func (c mappedPaginationConfig) maxPages() int {
+	if c.MaxPages == 0 {
+		return defaultMaxPages
+	}
+	return c.MaxPages
+}
+
+// nextPageURL returns the URL of the page following q, or an empty string if
+// there is no next page.
+func (c mappedPaginationConfig) nextPageURL(ctx context.Context, q mappedQuery, common commonMappedConfig) string {
+	if c.StopWhen != "" {
+		found, err := q.runQuery(mappedConfig{}.applyCommon(common, c.StopWhen))
+		if err != nil {
+			logger.Warnf("pagination stopWhen: %v", err)
+			return ""
+		}
+		if len(found) > 0 {
+			return ""
+		}
+	}
+
+	found, err := q.runQuery(mappedConfig{}.applyCommon(common, c.NextPage.Selector))
+	if err != nil {
+		logger.Warnf("pagination nextPage: %v", err)
+		return ""
+	}
+	if len(found) == 0 {
+		return ""
+	}
+
+	next := c.NextPage.postProcess(ctx, found[0], q)
+	if next == "" {
+		return ""
+	}
+
+	// resolve relative links against the current page
+	if base := q.getURL(); base != "" {
+		baseURL, err := url.Parse(base)
+		if err == nil {
+			if ref, err := url.Parse(next); err == nil {
+				next = baseURL.ResolveReference(ref).String()
+			}
+		}
+	}
+
+	return next
+}
+
+// eachPage calls fn with q and each of the following pages, until there are
+// no more pages, the maximum number of pages is reached, or fn returns an
+// error. Pagination only applies if the scraper has a pagination
+// configuration.
+func (s mappedScraper) eachPage(ctx context.Context, q mappedQuery, fn func(q mappedQuery) error) error {
+	if err := fn(q); err != nil {
+		return err
+	}
+
+	if s.Pagination == nil {
+		return nil
+	}
+
+	visited := map[string]bool{
+		q.getURL(): true,
+	}
+
+	for page := 2; page <= s.Pagination.maxPages(); page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		next := s.Pagination.nextPageURL(ctx, q, s.Common)
+		if next == "" || visited[next] {
+			break
+		}
+		visited[next] = true
+
+		logger.Debugf("Loading page %d: %s", page, next)
+		nextQ := q.subScrape(ctx, next)
+		if nextQ == nil {
+			break
+		}
+		nextQ.setType(q.getType())
+
+		if err := fn(nextQ); err != nil {
+			return err
+		}
+
+		q = nextQ
+	}
+
+	return nil
+}
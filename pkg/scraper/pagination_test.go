@@ -0,0 +1,140 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+// paginatedSearch serves search results over the provided number of pages.
+// The last page links back to the first page, as loaded by the search.
+func paginatedSearch(pages int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+
+		nextURL := fmt.Sprintf("/search?q=test&page=%d", page+1)
+		if page == pages {
+			nextURL = "/search?q=test"
+		}
+
+		fmt.Fprintf(w, `<html><body>
+<div class="scene"><h2>Scene %d-1</h2></div>
+<div class="scene"><h2>Scene %d-2</h2></div>
+`, page, page)
+		if page == pages {
+			fmt.Fprint(w, `<div class="last-page">Last page</div>`)
+		}
+		fmt.Fprintf(w, `<a rel="next" href="%s">Next</a></body></html>`, nextURL)
+	}
+}
+
+func TestPagination(t *testing.T) {
+	tests := []struct {
+		name       string
+		pagination string
+		want       int
+	}{
+		{
+			"no pagination",
+			"",
+			2,
+		},
+		{
+			"stop when",
+			`
+    pagination:
+      nextPage: //a[@rel="next"]/@href
+      stopWhen: //div[@class="last-page"]`,
+			6,
+		},
+		{
+			"max pages",
+			`
+    pagination:
+      nextPage: //a[@rel="next"]/@href
+      maxPages: 2`,
+			4,
+		},
+		{
+			// stops when the next page has already been loaded
+			"visited",
+			`
+    pagination:
+      nextPage: //a[@rel="next"]/@href`,
+			6,
+		},
+	}
+
+	ts := httptest.NewServer(paginatedSearch(3))
+	defer ts.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			yamlStr := `name: Test
+sceneByName:
+  action: scrapeXPath
+  queryURL: ` + ts.URL + `/search?q={}
+  scraper: sceneSearch
+xPathScrapers:
+  sceneSearch:` + tt.pagination + `
+    scene:
+      Title: //div[@class="scene"]/h2
+`
+
+			c := &config{}
+			if err := yaml.Unmarshal([]byte(yamlStr), &c); err != nil {
+				t.Fatalf("Error loading yaml: %s", err.Error())
+			}
+
+			s := newGroupScraper(*c, mockGlobalConfig{})
+			ns, ok := s.(nameScraper)
+			if !ok {
+				t.Fatal("couldn't convert scraper into name scraper")
+			}
+
+			content, err := ns.viaName(context.Background(), ts.Client(), "test", ScrapeContentTypeScene)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Len(t, content, tt.want)
+			if len(content) > 0 {
+				first := content[0].(*ScrapedScene)
+				assert.Equal(t, "Scene 1-1", *first.Title)
+			}
+		})
+	}
+}
+
+func TestPaginationConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		yamlStr string
+	}{
+		{
+			"missing next page",
+			`maxPages: 2`,
+		},
+		{
+			"negative max pages",
+			`nextPage: //a/@href
+maxPages: -1`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var c mappedPaginationConfig
+			assert.Error(t, yaml.Unmarshal([]byte(tt.yamlStr), &c))
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/common"
+)
+
+// ErrPluginsNotAvailable is returned when a plugin scraper is used, but no
+// PluginRunner was provided to the scraper cache.
+var ErrPluginsNotAvailable = errors.New("plugins are not available")
+
+// PluginRunner provides the scrapers registered by plugins, and runs their
+// scrape operations.
+type PluginRunner interface {
+	// GetScraperConfigs returns the scraper configurations provided by
+	// plugins, encoded as YAML and keyed by plugin ID.
+	GetScraperConfigs() map[string][]byte
+
+	// RunScraper sends a scrape request to the plugin with the provided ID,
+	// and returns the output of the plugin.
+	RunScraper(ctx context.Context, pluginID string, scrapeContext common.ScrapeContext) (interface{}, error)
+}
+
+type pluginScraper struct {
+	scraper scraperTypeConfig
+	config  config
+}
+
+func newPluginScraper(scraper scraperTypeConfig, config config) *pluginScraper {
+	return &pluginScraper{
+		scraper: scraper,
+		config:  config,
+	}
+}
+
+func (s *pluginScraper) pluginID() string {
+	if s.scraper.Plugin != "" {
+		return s.scraper.Plugin
+	}
+
+	return s.config.pluginID
+}
+
+// runPluginScraper sends the input to the plugin and decodes the plugin
+// output into out.
+func (s *pluginScraper) runPluginScraper(ctx context.Context, ty ScrapeContentType, scrapeType ScrapeType, input interface{}, out interface{}) error {
+	if s.config.plugins == nil {
+		return ErrPluginsNotAvailable
+	}
+
+	output, err := s.config.plugins.RunScraper(ctx, s.pluginID(), common.ScrapeContext{
+		Type:       ty.String(),
+		ScrapeType: scrapeType.String(),
+		Input:      input,
+	})
+	if err != nil {
+		return fmt.Errorf("plugin %s: %w", s.pluginID(), err)
+	}
+
+	if output == nil {
+		return nil
+	}
+
+	// the output is decoded from JSON by the plugin task, so re-encode it
+	// to decode it into the scraped type
+	data, err := json.Marshal(output)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("could not unmarshal plugin output: %w", err)
+	}
+
+	return nil
+}
+
+func (s *pluginScraper) scrapeByName(ctx context.Context, name string, ty ScrapeContentType) ([]ScrapedContent, error) {
+	input := map[string]string{"name": name}
+
+	var ret []ScrapedContent
+	var err error
+	switch ty {
+	case ScrapeContentTypePerformer:
+		var performers []models.ScrapedPerformer
+		err = s.runPluginScraper(ctx, ty, ScrapeTypeName, input, &performers)
+		if err == nil {
+			for _, p := range performers {
+				v := p
+				ret = append(ret, &v)
+			}
+		}
+	case ScrapeContentTypeScene:
+		var scenes []ScrapedScene
+		err = s.runPluginScraper(ctx, ty, ScrapeTypeName, input, &scenes)
+		if err == nil {
+			for _, s := range scenes {
+				v := s
+				ret = append(ret, &v)
+			}
+		}
+	case ScrapeContentTypeMovie:
+		var movies []models.ScrapedMovie
+		err = s.runPluginScraper(ctx, ty, ScrapeTypeName, input, &movies)
+		if err == nil {
+			for _, m := range movies {
+				v := m
+				ret = append(ret, &v)
+			}
+		}
+	case ScrapeContentTypeStudio:
+		var studios []models.ScrapedStudio
+		err = s.runPluginScraper(ctx, ty, ScrapeTypeName, input, &studios)
+		if err == nil {
+			for _, s := range studios {
+				v := s
+				ret = append(ret, &v)
+			}
+		}
+	case ScrapeContentTypeTag:
+		var tags []models.ScrapedTag
+		err = s.runPluginScraper(ctx, ty, ScrapeTypeName, input, &tags)
+		if err == nil {
+			for _, t := range tags {
+				v := t
+				ret = append(ret, &v)
+			}
+		}
+	default:
+		return nil, ErrNotSupported
+	}
+
+	return ret, err
+}
+
+func (s *pluginScraper) scrapeByFragment(ctx context.Context, input Input) (ScrapedContent, error) {
+	switch {
+	case input.Performer != nil:
+		return s.scrape(ctx, ScrapeContentTypePerformer, ScrapeTypeFragment, *input.Performer)
+	case input.Gallery != nil:
+		return s.scrape(ctx, ScrapeContentTypeGallery, ScrapeTypeFragment, *input.Gallery)
+	case input.Image != nil:
+		return s.scrape(ctx, ScrapeContentTypeImage, ScrapeTypeFragment, *input.Image)
+	case input.Scene != nil:
+		return s.scrape(ctx, ScrapeContentTypeScene, ScrapeTypeFragment, *input.Scene)
+	case input.Movie != nil:
+		return s.scrape(ctx, ScrapeContentTypeMovie, ScrapeTypeFragment, *input.Movie)
+	case input.Studio != nil:
+		return s.scrape(ctx, ScrapeContentTypeStudio, ScrapeTypeFragment, *input.Studio)
+	case input.Tag != nil:
+		return s.scrape(ctx, ScrapeContentTypeTag, ScrapeTypeFragment, *input.Tag)
+	}
+
+	return nil, ErrNotSupported
+}
+
+func (s *pluginScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeContentType) (ScrapedContent, error) {
+	return s.scrape(ctx, ty, ScrapeTypeURL, map[string]string{"url": url})
+}
+
+func (s *pluginScraper) scrape(ctx context.Context, ty ScrapeContentType, scrapeType ScrapeType, input interface{}) (ScrapedContent, error) {
+	switch ty {
+	case ScrapeContentTypePerformer:
+		var performer *models.ScrapedPerformer
+		err := s.runPluginScraper(ctx, ty, scrapeType, input, &performer)
+		if performer == nil {
+			return nil, err
+		}
+		return performer, err
+	case ScrapeContentTypeGallery:
+		var gallery *ScrapedGallery
+		err := s.runPluginScraper(ctx, ty, scrapeType, input, &gallery)
+		if gallery == nil {
+			return nil, err
+		}
+		return gallery, err
+	case ScrapeContentTypeImage:
+		var image *ScrapedImage
+		err := s.runPluginScraper(ctx, ty, scrapeType, input, &image)
+		if image == nil {
+			return nil, err
+		}
+		return image, err
+	case ScrapeContentTypeScene:
+		var scene *ScrapedScene
+		err := s.runPluginScraper(ctx, ty, scrapeType, input, &scene)
+		if scene == nil {
+			return nil, err
+		}
+		return scene, err
+	case ScrapeContentTypeMovie:
+		var movie *models.ScrapedMovie
+		err := s.runPluginScraper(ctx, ty, scrapeType, input, &movie)
+		if movie == nil {
+			return nil, err
+		}
+		return movie, err
+	case ScrapeContentTypeStudio:
+		var studio *models.ScrapedStudio
+		err := s.runPluginScraper(ctx, ty, scrapeType, input, &studio)
+		if studio == nil {
+			return nil, err
+		}
+		return studio, err
+	case ScrapeContentTypeTag:
+		var tag *models.ScrapedTag
+		err := s.runPluginScraper(ctx, ty, scrapeType, input, &tag)
+		if tag == nil {
+			return nil, err
+		}
+		return tag, err
+	}
+
+	return nil, ErrNotSupported
+}
+
+func (s *pluginScraper) scrapeSceneByScene(ctx context.Context, scene *models.Scene) (*ScrapedScene, error) {
+	var ret *ScrapedScene
+	err := s.runPluginScraper(ctx, ScrapeContentTypeScene, ScrapeTypeFragment, sceneToUpdateInput(scene), &ret)
+	return ret, err
+}
+
+func (s *pluginScraper) scrapeGalleryByGallery(ctx context.Context, gallery *models.Gallery) (*ScrapedGallery, error) {
+	var ret *ScrapedGallery
+	err := s.runPluginScraper(ctx, ScrapeContentTypeGallery, ScrapeTypeFragment, galleryToUpdateInput(gallery), &ret)
+	return ret, err
+}
+
+func (s *pluginScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	var ret *ScrapedImage
+	err := s.runPluginScraper(ctx, ScrapeContentTypeImage, ScrapeTypeFragment, imageToUpdateInput(image), &ret)
+	return ret, err
+}
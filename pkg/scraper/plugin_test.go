@@ -0,0 +1,131 @@
+package scraper
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/plugin/common"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockPluginRunner struct {
+	configs map[string][]byte
+	output  interface{}
+
+	pluginID      string
+	scrapeContext common.ScrapeContext
+}
+
+func (r *mockPluginRunner) GetScraperConfigs() map[string][]byte {
+	return r.configs
+}
+
+func (r *mockPluginRunner) RunScraper(ctx context.Context, pluginID string, scrapeContext common.ScrapeContext) (interface{}, error) {
+	r.pluginID = pluginID
+	r.scrapeContext = scrapeContext
+	return r.output, nil
+}
+
+func TestLoadPluginConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr bool
+	}{
+		{
+			"default plugin",
+			`name: Test
+performerByName:
+  action: plugin
+`,
+			false,
+		},
+		{
+			"invalid field",
+			`name: Test
+performerByName:
+  action: plugin
+  invalid: true
+`,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadPluginConfigFromYAML("test", strings.NewReader(tt.yaml))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("loadPluginConfigFromYAML() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// plugin is mandatory for scrapers that are not provided by plugins
+	_, err := loadConfigFromYAML("test", strings.NewReader(tests[0].yaml))
+	assert.NotNil(t, err)
+}
+
+func TestPluginScraper(t *testing.T) {
+	const yamlStr = `performerByName:
+  action: plugin
+sceneByURL:
+  - action: plugin
+    plugin: other
+    url:
+      - example.com
+`
+
+	name := "Name"
+	runner := &mockPluginRunner{
+		configs: map[string][]byte{
+			"test": []byte("name: Test\n" + yamlStr),
+		},
+		output: []interface{}{
+			map[string]interface{}{"name": name},
+		},
+	}
+
+	c := &Cache{
+		plugins:      runner,
+		globalConfig: mockGlobalConfig{},
+	}
+
+	scrapers := make(map[string]scraper)
+	c.loadPluginScrapers(scrapers)
+
+	s, found := scrapers["test"]
+	if !assert.True(t, found) {
+		return
+	}
+
+	assert.True(t, s.supports(ScrapeContentTypePerformer))
+
+	ctx := context.Background()
+	client := &http.Client{}
+	ns := s.(nameScraper)
+	content, err := ns.viaName(ctx, client, "query", ScrapeContentTypePerformer)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "test", runner.pluginID)
+	assert.Equal(t, common.ScrapeContext{
+		Type:       "PERFORMER",
+		ScrapeType: "NAME",
+		Input:      map[string]string{"name": "query"},
+	}, runner.scrapeContext)
+	assert.Equal(t, []ScrapedContent{&models.ScrapedPerformer{Name: &name}}, content)
+
+	runner.output = map[string]interface{}{"title": name}
+	us := s.(urlScraper)
+	scene, err := us.viaURL(ctx, client, "https://example.com/scene", ScrapeContentTypeScene)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	assert.Equal(t, "other", runner.pluginID)
+	assert.Equal(t, &ScrapedScene{Title: &name}, scene)
+}
@@ -2,12 +2,15 @@ package scraper
 
 import (
 	"context"
+	"strings"
 
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/match"
 	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 	"github.com/stashapp/stash/pkg/tag"
 	"github.com/stashapp/stash/pkg/txn"
+	"github.com/stashapp/stash/pkg/utils"
 )
 
 // postScrape handles post-processing of scraped content. If the content
@@ -34,12 +37,30 @@ func (c Cache) postScrape(ctx context.Context, content ScrapedContent) (ScrapedC
 		}
 	case ScrapedGallery:
 		return c.postScrapeGallery(ctx, v)
+	case *ScrapedImage:
+		if v != nil {
+			return c.postScrapeImage(ctx, *v)
+		}
+	case ScrapedImage:
+		return c.postScrapeImage(ctx, v)
 	case *models.ScrapedMovie:
 		if v != nil {
 			return c.postScrapeMovie(ctx, *v)
 		}
 	case models.ScrapedMovie:
 		return c.postScrapeMovie(ctx, v)
+	case *models.ScrapedStudio:
+		if v != nil {
+			return c.postScrapeStudio(ctx, *v)
+		}
+	case models.ScrapedStudio:
+		return c.postScrapeStudio(ctx, v)
+	case *models.ScrapedTag:
+		if v != nil {
+			return c.postScrapeTag(ctx, *v)
+		}
+	case models.ScrapedTag:
+		return c.postScrapeTag(ctx, v)
 	}
 
 	// If nothing matches, pass the content through
@@ -47,6 +68,8 @@ func (c Cache) postScrape(ctx context.Context, content ScrapedContent) (ScrapedC
 }
 
 func (c Cache) postScrapePerformer(ctx context.Context, p models.ScrapedPerformer) (ScrapedContent, error) {
+	mergePerformerURLs(&p)
+
 	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		tqb := c.repository.TagFinder
 
@@ -72,6 +95,8 @@ func (c Cache) postScrapePerformer(ctx context.Context, p models.ScrapedPerforme
 }
 
 func (c Cache) postScrapeMovie(ctx context.Context, m models.ScrapedMovie) (ScrapedContent, error) {
+	m.URL, m.URLs = mergeURLs(m.URL, m.URLs)
+
 	if m.Studio != nil {
 		if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
 			return match.ScrapedStudio(ctx, c.repository.StudioFinder, m.Studio, nil)
@@ -91,6 +116,41 @@ func (c Cache) postScrapeMovie(ctx context.Context, m models.ScrapedMovie) (Scra
 	return m, nil
 }
 
+func (c Cache) postScrapeStudio(ctx context.Context, s models.ScrapedStudio) (ScrapedContent, error) {
+	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
+		sqb := c.repository.StudioFinder
+
+		if err := match.ScrapedStudio(ctx, sqb, &s, nil); err != nil {
+			return err
+		}
+
+		if s.Parent != nil {
+			return match.ScrapedStudio(ctx, sqb, s.Parent, nil)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	// post-process - set the image if applicable
+	if err := setStudioImage(ctx, c.client, &s, c.globalConfig); err != nil {
+		logger.Warnf("could not set image using URL %s: %v", *s.Image, err)
+	}
+
+	return s, nil
+}
+
+func (c Cache) postScrapeTag(ctx context.Context, t models.ScrapedTag) (ScrapedContent, error) {
+	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
+		return match.ScrapedTag(ctx, c.repository.TagFinder, &t)
+	}); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
 func (c Cache) postScrapeScenePerformer(ctx context.Context, p models.ScrapedPerformer) error {
 	tqb := c.repository.TagFinder
 
@@ -106,6 +166,8 @@ func (c Cache) postScrapeScenePerformer(ctx context.Context, p models.ScrapedPer
 }
 
 func (c Cache) postScrapeScene(ctx context.Context, scene ScrapedScene) (ScrapedContent, error) {
+	scene.URL, scene.URLs = mergeURLs(scene.URL, scene.URLs)
+
 	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		pqb := c.repository.PerformerFinder
 		mqb := c.repository.MovieFinder
@@ -117,6 +179,8 @@ func (c Cache) postScrapeScene(ctx context.Context, scene ScrapedScene) (Scraped
 				continue
 			}
 
+			mergePerformerURLs(p)
+
 			if err := c.postScrapeScenePerformer(ctx, *p); err != nil {
 				return err
 			}
@@ -127,6 +191,8 @@ func (c Cache) postScrapeScene(ctx context.Context, scene ScrapedScene) (Scraped
 		}
 
 		for _, p := range scene.Movies {
+			p.URL, p.URLs = mergeURLs(p.URL, p.URLs)
+
 			err := match.ScrapedMovie(ctx, mqb, p)
 			if err != nil {
 				return err
@@ -160,6 +226,8 @@ func (c Cache) postScrapeScene(ctx context.Context, scene ScrapedScene) (Scraped
 }
 
 func (c Cache) postScrapeGallery(ctx context.Context, g ScrapedGallery) (ScrapedContent, error) {
+	g.URL, g.URLs = mergeURLs(g.URL, g.URLs)
+
 	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
 		pqb := c.repository.PerformerFinder
 		tqb := c.repository.TagFinder
@@ -193,6 +261,40 @@ func (c Cache) postScrapeGallery(ctx context.Context, g ScrapedGallery) (Scraped
 	return g, nil
 }
 
+func (c Cache) postScrapeImage(ctx context.Context, i ScrapedImage) (ScrapedContent, error) {
+	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
+		pqb := c.repository.PerformerFinder
+		tqb := c.repository.TagFinder
+		sqb := c.repository.StudioFinder
+
+		for _, p := range i.Performers {
+			err := match.ScrapedPerformer(ctx, pqb, p, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		tags, err := postProcessTags(ctx, tqb, i.Tags)
+		if err != nil {
+			return err
+		}
+		i.Tags = tags
+
+		if i.Studio != nil {
+			err := match.ScrapedStudio(ctx, sqb, i.Studio, nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
 func postProcessTags(ctx context.Context, tqb tag.Queryer, scrapedTags []*models.ScrapedTag) ([]*models.ScrapedTag, error) {
 	var ret []*models.ScrapedTag
 
@@ -206,3 +308,54 @@ func postProcessTags(ctx context.Context, tqb tag.Queryer, scrapedTags []*models
 
 	return ret, nil
 }
+
+// mergeURLs returns the distinct, non-empty urls of scraped content, starting
+// with the deprecated single url. The single url is set to the first of the
+// returned urls.
+func mergeURLs(url *string, urls []string) (*string, []string) {
+	var all []string
+	if url != nil {
+		all = append(all, *url)
+	}
+	all = append(all, urls...)
+
+	var ret []string
+	for _, u := range all {
+		u = strings.TrimSpace(u)
+		if u != "" {
+			ret = stringslice.StrAppendUnique(ret, u)
+		}
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return &ret[0], ret
+}
+
+// mergePerformerURLs merges the deprecated url, twitter and instagram fields
+// of a scraped performer into its urls, and sets the deprecated fields from
+// the urls where they are not set.
+func mergePerformerURLs(p *models.ScrapedPerformer) {
+	urls := p.URLs
+	if p.Twitter != nil {
+		urls = append(urls, utils.URLFromHandle(*p.Twitter, utils.TwitterURL))
+	}
+	if p.Instagram != nil {
+		urls = append(urls, utils.URLFromHandle(*p.Instagram, utils.InstagramURL))
+	}
+
+	p.URL, p.URLs = mergeURLs(p.URL, urls)
+
+	if p.Twitter == nil {
+		if u := utils.URLForSite(p.URLs, utils.TwitterURL); u != "" {
+			p.Twitter = &u
+		}
+	}
+	if p.Instagram == nil {
+		if u := utils.URLForSite(p.URLs, utils.InstagramURL); u != "" {
+			p.Instagram = &u
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package scraper
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_mergeURLs(t *testing.T) {
+	url := "https://example.com/1"
+	otherURL := "https://example.com/2"
+	blank := " "
+
+	tests := []struct {
+		name     string
+		url      *string
+		urls     []string
+		wantURL  *string
+		wantURLs []string
+	}{
+		{"none", nil, nil, nil, nil},
+		{"blank", &blank, []string{""}, nil, nil},
+		{"url only", &url, nil, &url, []string{url}},
+		{"urls only", nil, []string{otherURL, url}, &otherURL, []string{otherURL, url}},
+		{"url first", &url, []string{otherURL, " " + url}, &url, []string{url, otherURL}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotURL, gotURLs := mergeURLs(tt.url, tt.urls)
+			assert.Equal(t, tt.wantURL, gotURL)
+			assert.Equal(t, tt.wantURLs, gotURLs)
+		})
+	}
+}
+
+func Test_mergePerformerURLs(t *testing.T) {
+	url := "https://example.com/performer"
+	twitterHandle := "@performer"
+	twitterURL := "https://twitter.com/performer"
+	instagramURL := "https://www.instagram.com/performer"
+
+	p := models.ScrapedPerformer{
+		URL:     &url,
+		URLs:    []string{instagramURL},
+		Twitter: &twitterHandle,
+	}
+
+	mergePerformerURLs(&p)
+
+	assert.Equal(t, &url, p.URL)
+	assert.Equal(t, []string{url, instagramURL, twitterURL}, p.URLs)
+	assert.Equal(t, &twitterHandle, p.Twitter)
+	assert.Equal(t, &instagramURL, p.Instagram)
+}
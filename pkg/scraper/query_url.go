@@ -20,8 +20,8 @@ func queryURLParametersFromScene(scene *models.Scene) queryURLParameters {
 	if scene.Title != "" {
 		ret["title"] = scene.Title
 	}
-	if scene.URL != "" {
-		ret["url"] = scene.URL
+	if url := scene.URLs.First(); url != "" {
+		ret["url"] = url
 	}
 	return ret
 }
@@ -37,7 +37,7 @@ func queryURLParametersFromScrapedScene(scene ScrapedSceneInput) queryURLParamet
 
 	setField("title", scene.Title)
 	setField("code", scene.Code)
-	setField("url", scene.URL)
+	setField("url", firstURL(scene.URL, scene.URLs))
 	setField("date", scene.Date)
 	setField("details", scene.Details)
 	setField("director", scene.Director)
@@ -45,6 +45,64 @@ func queryURLParametersFromScrapedScene(scene ScrapedSceneInput) queryURLParamet
 	return ret
 }
 
+func queryURLParametersFromScrapedStudio(studio ScrapedStudioInput) queryURLParameters {
+	ret := make(queryURLParameters)
+
+	setField := func(field string, value *string) {
+		if value != nil {
+			ret[field] = *value
+		}
+	}
+
+	setField("name", studio.Name)
+	setField("url", studio.URL)
+	setField("details", studio.Details)
+	setField("remote_site_id", studio.RemoteSiteID)
+	return ret
+}
+
+func queryURLParametersFromScrapedMovie(movie ScrapedMovieInput) queryURLParameters {
+	ret := make(queryURLParameters)
+
+	setField := func(field string, value *string) {
+		if value != nil {
+			ret[field] = *value
+		}
+	}
+
+	setField("name", movie.Name)
+	setField("aliases", movie.Aliases)
+	setField("url", firstURL(movie.URL, movie.URLs))
+	setField("date", movie.Date)
+	setField("director", movie.Director)
+	setField("synopsis", movie.Synopsis)
+	return ret
+}
+
+func queryURLParametersFromScrapedTag(tag ScrapedTagInput) queryURLParameters {
+	ret := make(queryURLParameters)
+
+	setField := func(field string, value *string) {
+		if value != nil {
+			ret[field] = *value
+		}
+	}
+
+	setField("name", tag.Name)
+	setField("description", tag.Description)
+	return ret
+}
+
+// firstURL returns the deprecated single url of scraped content if set,
+// otherwise the first of its urls.
+func firstURL(url *string, urls []string) *string {
+	if url == nil && len(urls) > 0 {
+		return &urls[0]
+	}
+
+	return url
+}
+
 func queryURLParameterFromURL(url string) queryURLParameters {
 	ret := make(queryURLParameters)
 	ret["url"] = url
@@ -62,10 +120,40 @@ func queryURLParametersFromGallery(gallery *models.Gallery) queryURLParameters {
 		ret["title"] = gallery.Title
 	}
 
-	if gallery.URL != "" {
-		ret["url"] = gallery.URL
+	if url := gallery.URLs.First(); url != "" {
+		ret["url"] = url
+	}
+
+	return ret
+}
+
+func queryURLParametersFromImage(image *models.Image) queryURLParameters {
+	ret := make(queryURLParameters)
+	ret["checksum"] = image.Checksum
+
+	if image.Path != "" {
+		ret["filename"] = filepath.Base(image.Path)
+	}
+	if image.Title != "" {
+		ret["title"] = image.Title
+	}
+
+	return ret
+}
+
+func queryURLParametersFromScrapedImage(image ScrapedImageInput) queryURLParameters {
+	ret := make(queryURLParameters)
+
+	setField := func(field string, value *string) {
+		if value != nil {
+			ret[field] = *value
+		}
 	}
 
+	setField("title", image.Title)
+	setField("url", image.URL)
+	setField("date", image.Date)
+	setField("details", image.Details)
 	return ret
 }
 
@@ -0,0 +1,366 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+const (
+	retryMinBackoff = time.Second
+	retryMaxBackoff = time.Minute
+
+	// retryMaxWait is the maximum time to wait for a Retry-After header
+	retryMaxWait = 5 * time.Minute
+)
+
+// RateLimitConfig contains the global rate limiting options of scrapers.
+type RateLimitConfig interface {
+	// GetScraperRateLimit returns the maximum number of requests per second
+	// made to a single host. Requests are not limited if zero.
+	GetScraperRateLimit() float64
+
+	// GetScraperMaxConcurrency returns the maximum number of concurrent
+	// requests made to a single host. Requests are not limited if zero.
+	GetScraperMaxConcurrency() int
+
+	// GetScraperMaxRetries returns the number of times that requests which
+	// fail with a transient error are retried.
+	GetScraperMaxRetries() int
+}
+
+// rateLimitOptions overrides the global rate limiting options for a
+// scraper. Unset fields use the global values.
+type rateLimitOptions struct {
+	RequestsPerSecond *float64 `yaml:"requestsPerSecond"`
+	Concurrency       *int     `yaml:"concurrency"`
+	MaxRetries        *int     `yaml:"maxRetries"`
+}
+
+// hostLimiter limits the rate and concurrency of requests made to a single
+// host. A nil hostLimiter does not limit requests.
+type hostLimiter struct {
+	interval time.Duration
+	sem      chan struct{}
+
+	mutex sync.Mutex
+	// the earliest time that the next request may start
+	next time.Time
+}
+
+func newHostLimiter(requestsPerSecond float64, concurrency int) *hostLimiter {
+	ret := &hostLimiter{}
+	if requestsPerSecond > 0 {
+		ret.interval = time.Duration(float64(time.Second) / requestsPerSecond)
+	}
+	if concurrency > 0 {
+		ret.sem = make(chan struct{}, concurrency)
+	}
+
+	return ret
+}
+
+// acquire blocks until a request may be made. The returned function must be
+// called when the request is complete.
+func (l *hostLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	if l.sem != nil {
+		select {
+		case l.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	release := func() {
+		if l.sem != nil {
+			<-l.sem
+		}
+	}
+
+	l.mutex.Lock()
+	start := time.Now()
+	if l.next.After(start) {
+		start = l.next
+	}
+	l.next = start.Add(l.interval)
+	l.mutex.Unlock()
+
+	if wait := time.Until(start); wait > 0 {
+		t := time.NewTimer(wait)
+		defer t.Stop()
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			release()
+			return nil, ctx.Err()
+		}
+	}
+
+	return release, nil
+}
+
+// delay prevents requests from starting for the provided duration.
+func (l *hostLimiter) delay(d time.Duration) {
+	if l == nil {
+		return
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if until := time.Now().Add(d); until.After(l.next) {
+		l.next = until
+	}
+}
+
+// limiterKey identifies a hostLimiter. The limiter settings are part of the
+// key, so that a new limiter is used when the settings change.
+type limiterKey struct {
+	// empty if the host is limited using the global options
+	scraperID         string
+	host              string
+	requestsPerSecond float64
+	concurrency       int
+}
+
+// rateLimiter maintains the hostLimiters of all hosts. Hosts are limited using
+// the global options, unless the scraper configures its own options, in which
+// case the scraper has its own limiter for the host.
+type rateLimiter struct {
+	config RateLimitConfig
+
+	mutex sync.Mutex
+	hosts map[limiterKey]*hostLimiter
+}
+
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	return &rateLimiter{
+		config: config,
+		hosts:  make(map[limiterKey]*hostLimiter),
+	}
+}
+
+// get returns the limiter of the host for the provided scraper. Returns nil if
+// r is nil.
+func (r *rateLimiter) get(scraperID string, host string, options *rateLimitOptions) *hostLimiter {
+	if r == nil {
+		return nil
+	}
+
+	key := limiterKey{
+		host:              host,
+		requestsPerSecond: r.config.GetScraperRateLimit(),
+		concurrency:       r.config.GetScraperMaxConcurrency(),
+	}
+	if options != nil && (options.RequestsPerSecond != nil || options.Concurrency != nil) {
+		key.scraperID = scraperID
+		if options.RequestsPerSecond != nil {
+			key.requestsPerSecond = *options.RequestsPerSecond
+		}
+		if options.Concurrency != nil {
+			key.concurrency = *options.Concurrency
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	ret := r.hosts[key]
+	if ret == nil {
+		// remove the limiter with the previous settings
+		for k := range r.hosts {
+			if k.scraperID == key.scraperID && k.host == key.host {
+				delete(r.hosts, k)
+			}
+		}
+
+		ret = newHostLimiter(key.requestsPerSecond, key.concurrency)
+		r.hosts[key] = ret
+	}
+
+	return ret
+}
+
+// maxRetries returns the number of times to retry requests for the provided
+// scraper options.
+func (r *rateLimiter) maxRetries(options *rateLimitOptions) int {
+	if options != nil && options.MaxRetries != nil {
+		return *options.MaxRetries
+	}
+
+	if r == nil {
+		return 0
+	}
+
+	return r.config.GetScraperMaxRetries()
+}
+
+func isTransientStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// isTransientError returns true if the request should be retried. Only
+// timeouts, temporary network errors and connection resets are retried.
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	// DNS and TLS errors are not resolved by retrying
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) || isTLSError(err) {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) {
+		return true
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		if netErr.Timeout() {
+			return true
+		}
+
+		// Temporary is deprecated on net.Error, but is still implemented by
+		// some network errors
+		var tempErr interface{ Temporary() bool }
+		if errors.As(err, &tempErr) && tempErr.Temporary() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isTLSError(err error) bool {
+	var recordErr tls.RecordHeaderError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+
+	return errors.As(err, &recordErr) ||
+		errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr)
+}
+
+// retryAfter returns the duration in the Retry-After header of resp, or zero
+// if the header is not set or invalid.
+func retryAfter(resp *http.Response) time.Duration {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+
+	var ret time.Duration
+	if secs, err := strconv.Atoi(v); err == nil {
+		ret = time.Duration(secs) * time.Second
+	} else if t, err := http.ParseTime(v); err == nil {
+		ret = time.Until(t)
+	}
+
+	if ret < 0 {
+		return 0
+	}
+	if ret > retryMaxWait {
+		return retryMaxWait
+	}
+	return ret
+}
+
+// doWithRetry performs req, waiting for the limiter before each attempt.
+// Requests that fail with a transient error are retried with exponential
+// backoff, respecting the Retry-After header of the response. The body of
+// the returned response has been read into memory, so that the limiter
+// covers the whole transfer.
+func doWithRetry(ctx context.Context, client *http.Client, req *http.Request, limiter *hostLimiter, maxRetries int) (*http.Response, error) {
+	backoff := retryMinBackoff
+	for attempt := 0; ; attempt++ {
+		resp, err := doLimited(ctx, client, req, limiter)
+
+		retry := false
+		wait := backoff
+		switch {
+		case err != nil:
+			retry = isTransientError(err)
+		case isTransientStatus(resp.StatusCode):
+			retry = true
+			if ra := retryAfter(resp); ra > 0 {
+				wait = ra
+				// other requests to the host must also wait
+				limiter.delay(ra)
+			}
+		}
+
+		if !retry || attempt >= maxRetries {
+			return resp, err
+		}
+
+		if err != nil {
+			logger.Debugf("[scraper] request to %s failed: %v. Retrying in %s", req.URL, err, wait)
+		} else {
+			logger.Debugf("[scraper] request to %s returned %d. Retrying in %s", req.URL, resp.StatusCode, wait)
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return nil, ctx.Err()
+		}
+
+		backoff *= 2
+		if backoff > retryMaxBackoff {
+			backoff = retryMaxBackoff
+		}
+	}
+}
+
+func doLimited(ctx context.Context, client *http.Client, req *http.Request, limiter *hostLimiter) (*http.Response, error) {
+	release, err := limiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	r := req.Clone(ctx)
+	if req.GetBody != nil {
+		// the body of req may have been read by a previous attempt
+		r.Body, err = req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := client.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
@@ -0,0 +1,176 @@
+package scraper
+
+import (
+	"context"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoWithRetry(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []int
+		maxRetries int
+		wantStatus int
+		wantCalls  int32
+	}{
+		{"success", []int{http.StatusOK}, 3, http.StatusOK, 1},
+		{"retry after", []int{http.StatusTooManyRequests, http.StatusOK}, 3, http.StatusOK, 2},
+		{"not transient", []int{http.StatusNotFound, http.StatusOK}, 3, http.StatusNotFound, 1},
+		{"no retries", []int{http.StatusServiceUnavailable, http.StatusOK}, 0, http.StatusServiceUnavailable, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				i := atomic.AddInt32(&calls, 1) - 1
+				status := tt.statuses[i]
+				if status == http.StatusTooManyRequests {
+					w.Header().Set("Retry-After", "1")
+				}
+				w.WriteHeader(status)
+				_, _ = w.Write([]byte("body"))
+			}))
+			defer server.Close()
+
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			limiter := newHostLimiter(0, 0)
+
+			resp, err := doWithRetry(context.Background(), server.Client(), req, limiter, tt.maxRetries)
+			if !assert.Nil(t, err) {
+				return
+			}
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, tt.wantCalls, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestHostLimiter(t *testing.T) {
+	const requests = 5
+
+	var current, max int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&current, 1)
+		defer atomic.AddInt32(&current, -1)
+		for {
+			m := atomic.LoadInt32(&max)
+			if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	limiter := newHostLimiter(100, 2)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			_, err := doWithRetry(context.Background(), server.Client(), req, limiter, 0)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, atomic.LoadInt32(&max), int32(2))
+	// requests are started at least 10ms apart
+	assert.GreaterOrEqual(t, time.Since(start), (requests-1)*10*time.Millisecond)
+}
+
+type mockRateLimitConfig struct {
+	rateLimit      float64
+	maxConcurrency int
+}
+
+func (c *mockRateLimitConfig) GetScraperRateLimit() float64 {
+	return c.rateLimit
+}
+
+func (c *mockRateLimitConfig) GetScraperMaxConcurrency() int {
+	return c.maxConcurrency
+}
+
+func (c *mockRateLimitConfig) GetScraperMaxRetries() int {
+	return 0
+}
+
+func TestRateLimiter_get(t *testing.T) {
+	config := &mockRateLimitConfig{}
+	r := newRateLimiter(config)
+	rps := 1.0
+
+	assert.Same(t, r.get("a", "example.com", nil), r.get("b", "example.com", nil))
+	assert.NotSame(t, r.get("a", "example.com", nil), r.get("a", "example.org", nil))
+	assert.NotSame(t, r.get("a", "example.com", nil), r.get("a", "example.com", &rateLimitOptions{RequestsPerSecond: &rps}))
+
+	// limiters are replaced when the settings change
+	before := r.get("a", "example.com", nil)
+	config.rateLimit = 2
+	config.maxConcurrency = 1
+	after := r.get("a", "example.com", nil)
+	assert.NotSame(t, before, after)
+	assert.Equal(t, 500*time.Millisecond, after.interval)
+	assert.Equal(t, 1, cap(after.sem))
+	assert.Same(t, after, r.get("b", "example.com", nil))
+
+	var nilLimiter *rateLimiter
+	assert.Nil(t, nilLimiter.get("a", "example.com", nil))
+}
+
+func Test_isTransientError(t *testing.T) {
+	wrap := func(err error) error {
+		return &url.Error{Op: "Get", URL: "https://example.com", Err: err}
+	}
+
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"timeout", wrap(&net.OpError{Op: "dial", Err: os.ErrDeadlineExceeded}), true},
+		{"connection reset", wrap(&net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}), true},
+		{"connection refused", wrap(&net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}), false},
+		{"dns", wrap(&net.OpError{Op: "dial", Err: &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true}}), false},
+		{"dns timeout", wrap(&net.OpError{Op: "dial", Err: &net.DNSError{Err: "timeout", Name: "example.com", IsTimeout: true}}), false},
+		{"tls certificate", wrap(x509.UnknownAuthorityError{}), false},
+		{"tls hostname", wrap(x509.HostnameError{Certificate: &x509.Certificate{}, Host: "example.com"}), false},
+		{"canceled", wrap(context.Canceled), false},
+		{"max redirects", wrap(ErrMaxRedirects), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isTransientError(tt.err))
+		})
+	}
+}
+
+func Test_isTransientStatus(t *testing.T) {
+	for status, want := range map[int]bool{
+		http.StatusOK:                  false,
+		http.StatusNotFound:            false,
+		http.StatusTooManyRequests:     true,
+		http.StatusInternalServerError: true,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+	} {
+		assert.Equal(t, want, isTransientStatus(status), "status %d", status)
+	}
+}
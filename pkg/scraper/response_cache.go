@@ -0,0 +1,250 @@
+package scraper
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stashapp/stash/pkg/fsutil"
+	"github.com/stashapp/stash/pkg/logger"
+)
+
+// responseCacheDir is the subdirectory of the cache directory that contains
+// cached scraper responses.
+const responseCacheDir = "scraper"
+
+// ResponseCacheConfig contains the options of the scraper response cache.
+type ResponseCacheConfig interface {
+	// GetScraperCacheTTL returns the duration that responses are cached for.
+	// Caching is disabled if zero.
+	GetScraperCacheTTL() time.Duration
+
+	// GetScraperCacheMaxSize returns the maximum total size of the cached
+	// responses in bytes. The size is not limited if zero.
+	GetScraperCacheMaxSize() int64
+}
+
+type responseCacheEntry struct {
+	key     string
+	size    int64
+	created time.Time
+}
+
+// ResponseCache is an on-disk cache of scraper responses. Entries expire
+// after the configured TTL. When the total size of the cache exceeds the
+// configured maximum size, the least recently used entries are evicted.
+//
+// A nil ResponseCache is valid, and does not cache any responses.
+type ResponseCache struct {
+	dir    string
+	config ResponseCacheConfig
+
+	mutex   sync.Mutex
+	loaded  bool
+	entries map[string]*list.Element
+	// most recently used entries are at the front
+	lru  *list.List
+	size int64
+}
+
+// NewResponseCache returns a new ResponseCache that stores responses in
+// the scraper subdirectory of cachePath. Returns nil if cachePath is empty.
+func NewResponseCache(cachePath string, config ResponseCacheConfig) *ResponseCache {
+	if cachePath == "" {
+		return nil
+	}
+
+	return &ResponseCache{
+		dir:     filepath.Join(cachePath, responseCacheDir),
+		config:  config,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// ResponseCacheKey returns the cache key for the response of a scraper action
+// with the provided input.
+func ResponseCacheKey(scraperID string, action string, input string) string {
+	h := sha256.New()
+	for _, v := range []string{scraperID, action, input} {
+		h.Write([]byte(v))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (c *ResponseCache) enabled() bool {
+	return c != nil && c.config.GetScraperCacheTTL() > 0
+}
+
+func (c *ResponseCache) path(key string) string {
+	return filepath.Join(c.dir, key)
+}
+
+// load populates the index from the existing cache files. Files are
+// considered used when they were last written. Assumes the lock is held.
+func (c *ResponseCache) load() {
+	if c.loaded {
+		return
+	}
+	c.loaded = true
+
+	files, err := os.ReadDir(c.dir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warnf("error reading scraper cache directory: %v", err)
+		}
+		return
+	}
+
+	var entries []*responseCacheEntry
+	for _, f := range files {
+		info, err := f.Info()
+		if err != nil || !info.Mode().IsRegular() || filepath.Ext(f.Name()) == ".tmp" {
+			continue
+		}
+
+		entries = append(entries, &responseCacheEntry{
+			key:     f.Name(),
+			size:    info.Size(),
+			created: info.ModTime(),
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].created.After(entries[j].created)
+	})
+
+	for _, e := range entries {
+		c.entries[e.key] = c.lru.PushBack(e)
+		c.size += e.size
+	}
+}
+
+// remove removes the entry from the index and deletes its file. Assumes the
+// lock is held.
+func (c *ResponseCache) remove(elem *list.Element) {
+	e := elem.Value.(*responseCacheEntry)
+	c.lru.Remove(elem)
+	delete(c.entries, e.key)
+	c.size -= e.size
+
+	if err := os.Remove(c.path(e.key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logger.Warnf("error removing scraper cache entry: %v", err)
+	}
+}
+
+// Get returns the cached response for key. Returns false if there is no
+// cached response, or the cached response has expired.
+func (c *ResponseCache) Get(key string) ([]byte, bool) {
+	if !c.enabled() {
+		return nil, false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.load()
+
+	elem, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+
+	e := elem.Value.(*responseCacheEntry)
+	if time.Since(e.created) > c.config.GetScraperCacheTTL() {
+		c.remove(elem)
+		return nil, false
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		logger.Warnf("error reading scraper cache entry: %v", err)
+		c.remove(elem)
+		return nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return data, true
+}
+
+// Set stores the response for key, evicting the least recently used entries
+// if the maximum size is exceeded. Responses larger than the maximum size
+// are not stored.
+func (c *ResponseCache) Set(key string, data []byte) {
+	if !c.enabled() {
+		return
+	}
+
+	maxSize := c.config.GetScraperCacheMaxSize()
+	size := int64(len(data))
+	if maxSize > 0 && size > maxSize {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.load()
+
+	if err := fsutil.EnsureDirAll(c.dir); err != nil {
+		logger.Warnf("error creating scraper cache directory: %v", err)
+		return
+	}
+
+	// write to a temporary file first so that partially written entries are
+	// never read
+	tmpPath := c.path(key) + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		logger.Warnf("error writing scraper cache entry: %v", err)
+		return
+	}
+
+	if err := os.Rename(tmpPath, c.path(key)); err != nil {
+		logger.Warnf("error writing scraper cache entry: %v", err)
+		_ = os.Remove(tmpPath)
+		return
+	}
+
+	if elem, found := c.entries[key]; found {
+		e := elem.Value.(*responseCacheEntry)
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		c.size -= e.size
+	}
+
+	c.entries[key] = c.lru.PushFront(&responseCacheEntry{
+		key:     key,
+		size:    size,
+		created: time.Now(),
+	})
+	c.size += size
+
+	for maxSize > 0 && c.size > maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Clear removes all cached responses.
+func (c *ResponseCache) Clear() error {
+	if c == nil {
+		return nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+	c.size = 0
+	c.loaded = true
+
+	return os.RemoveAll(c.dir)
+}
@@ -0,0 +1,117 @@
+package scraper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockResponseCacheConfig struct {
+	ttl     time.Duration
+	maxSize int64
+}
+
+func (c mockResponseCacheConfig) GetScraperCacheTTL() time.Duration {
+	return c.ttl
+}
+
+func (c mockResponseCacheConfig) GetScraperCacheMaxSize() int64 {
+	return c.maxSize
+}
+
+func TestResponseCache(t *testing.T) {
+	const (
+		key1 = "key1"
+		key2 = "key2"
+		key3 = "key3"
+	)
+
+	data := []byte("data")
+
+	tests := []struct {
+		name    string
+		config  mockResponseCacheConfig
+		set     []string
+		get     []string
+		wantHit []bool
+	}{
+		{
+			"disabled",
+			mockResponseCacheConfig{ttl: 0},
+			[]string{key1},
+			[]string{key1},
+			[]bool{false},
+		},
+		{
+			"hit",
+			mockResponseCacheConfig{ttl: time.Hour},
+			[]string{key1, key2},
+			[]string{key1, key2, key3},
+			[]bool{true, true, false},
+		},
+		{
+			"expired",
+			mockResponseCacheConfig{ttl: time.Nanosecond},
+			[]string{key1},
+			[]string{key1},
+			[]bool{false},
+		},
+		{
+			"evict least recently used",
+			mockResponseCacheConfig{ttl: time.Hour, maxSize: int64(len(data) * 2)},
+			[]string{key1, key2, key3},
+			[]string{key1, key2, key3},
+			[]bool{false, true, true},
+		},
+		{
+			"too large",
+			mockResponseCacheConfig{ttl: time.Hour, maxSize: int64(len(data) - 1)},
+			[]string{key1},
+			[]string{key1},
+			[]bool{false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewResponseCache(t.TempDir(), tt.config)
+			for _, k := range tt.set {
+				c.Set(k, data)
+			}
+
+			// ensure that expired entries have expired
+			time.Sleep(time.Millisecond)
+
+			for i, k := range tt.get {
+				got, hit := c.Get(k)
+				assert.Equal(t, tt.wantHit[i], hit, "key %s", k)
+				if hit {
+					assert.Equal(t, data, got)
+				}
+			}
+		})
+	}
+}
+
+func TestResponseCache_persistence(t *testing.T) {
+	dir := t.TempDir()
+	config := mockResponseCacheConfig{ttl: time.Hour}
+	key := ResponseCacheKey("scraper", "loadURL", "https://example.com")
+	data := []byte("data")
+
+	NewResponseCache(dir, config).Set(key, data)
+
+	// entries are loaded from disk
+	c := NewResponseCache(dir, config)
+	got, hit := c.Get(key)
+	assert.True(t, hit)
+	assert.Equal(t, data, got)
+
+	assert.Nil(t, c.Clear())
+	_, hit = c.Get(key)
+	assert.False(t, hit)
+
+	_, hit = NewResponseCache(dir, config).Get(key)
+	assert.False(t, hit)
+}
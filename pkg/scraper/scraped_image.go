@@ -0,0 +1,43 @@
+package scraper
+
+import (
+	"strconv"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+type ScrapedImage struct {
+	Title      *string                    `json:"title"`
+	Details    *string                    `json:"details"`
+	URL        *string                    `json:"url"`
+	Date       *string                    `json:"date"`
+	Studio     *models.ScrapedStudio      `json:"studio"`
+	Tags       []*models.ScrapedTag       `json:"tags"`
+	Performers []*models.ScrapedPerformer `json:"performers"`
+}
+
+func (ScrapedImage) IsScrapedContent() {}
+
+type ScrapedImageInput struct {
+	Title   *string `json:"title"`
+	Details *string `json:"details"`
+	URL     *string `json:"url"`
+	Date    *string `json:"date"`
+}
+
+// imageUpdateInput is the input passed to script and plugin scrapers when
+// scraping an existing image.
+type imageUpdateInput struct {
+	ID    string  `json:"id"`
+	Title *string `json:"title"`
+}
+
+func imageToUpdateInput(image *models.Image) imageUpdateInput {
+	// fallback to file basename if title is empty
+	title := image.GetTitle()
+
+	return imageUpdateInput{
+		ID:    strconv.Itoa(image.ID),
+		Title: &title,
+	}
+}
@@ -149,6 +149,33 @@ func (s *scriptScraper) scrapeByName(ctx context.Context, name string, ty Scrape
 				ret = append(ret, &v)
 			}
 		}
+	case ScrapeContentTypeMovie:
+		var movies []models.ScrapedMovie
+		err = s.runScraperScript(ctx, input, &movies)
+		if err == nil {
+			for _, m := range movies {
+				v := m
+				ret = append(ret, &v)
+			}
+		}
+	case ScrapeContentTypeStudio:
+		var studios []models.ScrapedStudio
+		err = s.runScraperScript(ctx, input, &studios)
+		if err == nil {
+			for _, s := range studios {
+				v := s
+				ret = append(ret, &v)
+			}
+		}
+	case ScrapeContentTypeTag:
+		var tags []models.ScrapedTag
+		err = s.runScraperScript(ctx, input, &tags)
+		if err == nil {
+			for _, t := range tags {
+				v := t
+				ret = append(ret, &v)
+			}
+		}
 	default:
 		return nil, ErrNotSupported
 	}
@@ -167,9 +194,21 @@ func (s *scriptScraper) scrapeByFragment(ctx context.Context, input Input) (Scra
 	case input.Gallery != nil:
 		inString, err = json.Marshal(*input.Gallery)
 		ty = ScrapeContentTypeGallery
+	case input.Image != nil:
+		inString, err = json.Marshal(*input.Image)
+		ty = ScrapeContentTypeImage
 	case input.Scene != nil:
 		inString, err = json.Marshal(*input.Scene)
 		ty = ScrapeContentTypeScene
+	case input.Movie != nil:
+		inString, err = json.Marshal(*input.Movie)
+		ty = ScrapeContentTypeMovie
+	case input.Studio != nil:
+		inString, err = json.Marshal(*input.Studio)
+		ty = ScrapeContentTypeStudio
+	case input.Tag != nil:
+		inString, err = json.Marshal(*input.Tag)
+		ty = ScrapeContentTypeTag
 	}
 
 	if err != nil {
@@ -193,6 +232,10 @@ func (s *scriptScraper) scrape(ctx context.Context, input string, ty ScrapeConte
 		var gallery *ScrapedGallery
 		err := s.runScraperScript(ctx, input, &gallery)
 		return gallery, err
+	case ScrapeContentTypeImage:
+		var image *ScrapedImage
+		err := s.runScraperScript(ctx, input, &image)
+		return image, err
 	case ScrapeContentTypeScene:
 		var scene *ScrapedScene
 		err := s.runScraperScript(ctx, input, &scene)
@@ -201,6 +244,14 @@ func (s *scriptScraper) scrape(ctx context.Context, input string, ty ScrapeConte
 		var movie *models.ScrapedMovie
 		err := s.runScraperScript(ctx, input, &movie)
 		return movie, err
+	case ScrapeContentTypeStudio:
+		var studio *models.ScrapedStudio
+		err := s.runScraperScript(ctx, input, &studio)
+		return studio, err
+	case ScrapeContentTypeTag:
+		var tag *models.ScrapedTag
+		err := s.runScraperScript(ctx, input, &tag)
+		return tag, err
 	}
 
 	return nil, ErrNotSupported
@@ -234,6 +285,20 @@ func (s *scriptScraper) scrapeGalleryByGallery(ctx context.Context, gallery *mod
 	return ret, err
 }
 
+func (s *scriptScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	inString, err := json.Marshal(imageToUpdateInput(image))
+
+	if err != nil {
+		return nil, err
+	}
+
+	var ret *ScrapedImage
+
+	err = s.runScraperScript(ctx, string(inString), &ret)
+
+	return ret, err
+}
+
 func handleScraperStderr(name string, scraperOutputReader io.ReadCloser) {
 	const scraperPrefix = "[Scrape / %s] "
 
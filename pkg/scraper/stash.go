@@ -81,7 +81,7 @@ type scrapedPerformerStash struct {
 }
 
 func (s *stashScraper) scrapeByFragment(ctx context.Context, input Input) (ScrapedContent, error) {
-	if input.Gallery != nil || input.Scene != nil {
+	if input.Gallery != nil || input.Image != nil || input.Scene != nil || input.Movie != nil {
 		return nil, fmt.Errorf("%w: using stash scraper as a fragment scraper", ErrNotSupported)
 	}
 
@@ -305,6 +305,41 @@ func (s *stashScraper) scrapeGalleryByGallery(ctx context.Context, gallery *mode
 	return &ret, nil
 }
 
+type scrapedImageStash struct {
+	ID         string                   `graphql:"id" json:"id"`
+	Title      *string                  `graphql:"title" json:"title"`
+	Studio     *scrapedStudioStash      `graphql:"studio" json:"studio"`
+	Tags       []*scrapedTagStash       `graphql:"tags" json:"tags"`
+	Performers []*scrapedPerformerStash `graphql:"performers" json:"performers"`
+}
+
+func (s *stashScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	var q struct {
+		FindImage *scrapedImageStash `graphql:"findImage(checksum: $c)"`
+	}
+
+	vars := map[string]interface{}{
+		"c": image.Checksum,
+	}
+
+	client := s.getStashClient()
+	if err := client.Query(ctx, &q, vars); err != nil {
+		return nil, err
+	}
+
+	if q.FindImage == nil {
+		return nil, nil
+	}
+
+	// need to copy back to a scraped image
+	ret := ScrapedImage{}
+	if err := copier.Copy(&ret, q.FindImage); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
 func (s *stashScraper) scrapeByURL(_ context.Context, _ string, _ ScrapeContentType) (ScrapedContent, error) {
 	return nil, ErrNotSupported
 }
@@ -321,12 +356,14 @@ func sceneToUpdateInput(scene *models.Scene) models.SceneUpdateInput {
 
 	// fallback to file basename if title is empty
 	title := scene.GetTitle()
+	url := scene.URLs.First()
 
 	return models.SceneUpdateInput{
 		ID:      strconv.Itoa(scene.ID),
 		Title:   &title,
 		Details: &scene.Details,
-		URL:     &scene.URL,
+		URL:     &url,
+		Urls:    scene.URLs.List(),
 		Date:    dateToStringPtr(scene.Date),
 	}
 }
@@ -343,12 +380,14 @@ func galleryToUpdateInput(gallery *models.Gallery) models.GalleryUpdateInput {
 
 	// fallback to file basename if title is empty
 	title := gallery.GetTitle()
+	url := gallery.URLs.First()
 
 	return models.GalleryUpdateInput{
 		ID:      strconv.Itoa(gallery.ID),
 		Title:   &title,
 		Details: &gallery.Details,
-		URL:     &gallery.URL,
+		URL:     &url,
+		Urls:    gallery.URLs.List(),
 		Date:    dateToStringPtr(gallery.Date),
 	}
 }
@@ -0,0 +1,108 @@
+package stashbox
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/scraper"
+)
+
+// uncachedOperations are the names of query operations that are never cached.
+var uncachedOperations = []string{
+	// used to validate the API key
+	"Me",
+	// used to check whether fingerprints are still linked to a scene
+	"FindSceneFingerprintsByID",
+}
+
+// cachingTransport caches the responses of stash-box graphql queries in the
+// scraper response cache. Mutations and file uploads are never cached.
+type cachingTransport struct {
+	endpoint string
+	cache    *scraper.ResponseCache
+	base     http.RoundTripper
+}
+
+func newCachingTransport(endpoint string, cache *scraper.ResponseCache) *cachingTransport {
+	return &cachingTransport{
+		endpoint: endpoint,
+		cache:    cache,
+		base:     http.DefaultTransport,
+	}
+}
+
+func isCacheableQuery(query string, operationName string) bool {
+	if !strings.HasPrefix(strings.TrimSpace(query), "query") {
+		return false
+	}
+
+	for _, o := range uncachedOperations {
+		if o == operationName {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodPost || req.Body == nil || !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	var gqlReq struct {
+		Query         string `json:"query"`
+		OperationName string `json:"operationName"`
+	}
+	if err := json.Unmarshal(body, &gqlReq); err != nil || !isCacheableQuery(gqlReq.Query, gqlReq.OperationName) {
+		return t.base.RoundTrip(req)
+	}
+
+	// results may depend on the user, so include the API key in the key
+	key := scraper.ResponseCacheKey(t.endpoint, gqlReq.OperationName, req.Header.Get("ApiKey")+"\x00"+string(body))
+	if data, found := t.cache.Get(key); found {
+		return &http.Response{
+			Status:        "200 OK",
+			StatusCode:    http.StatusOK,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header{"Content-Type": []string{"application/json"}},
+			Body:          io.NopCloser(bytes.NewReader(data)),
+			ContentLength: int64(len(data)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+
+	// don't cache responses containing errors
+	var gqlResp struct {
+		Errors json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(data, &gqlResp); err == nil && len(gqlResp.Errors) == 0 {
+		t.cache.Set(key, data)
+	}
+
+	return resp, nil
+}
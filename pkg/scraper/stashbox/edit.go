@@ -0,0 +1,506 @@
+package stashbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/logger"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper/stashbox/graphql"
+)
+
+// ErrNoChanges is returned when submitting an edit of an object that does
+// not differ from the stash-box object.
+var ErrNoChanges = errors.New("no changes to submit")
+
+func getEndpointStashID(stashIDs []models.StashID, endpoint string) *string {
+	for _, v := range stashIDs {
+		if v.Endpoint == endpoint {
+			id := v.StashID
+			return &id
+		}
+	}
+
+	return nil
+}
+
+// SubmitStudioDraft submits the studio to stash-box. stash-box does not
+// support studio drafts, so the studio is submitted as an edit creating a
+// new studio, or modifying the existing studio if the studio has a stash ID
+// for the endpoint. Returns the ID of the edit.
+func (c Client) SubmitStudioDraft(ctx context.Context, studio *models.Studio, endpoint string, comment *string) (*string, error) {
+	qb := c.repository.Studio
+
+	stashIDs, err := qb.GetStashIDs(ctx, studio.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	edit := &graphql.EditInput{
+		Operation: graphql.OperationEnumCreate,
+		Comment:   comment,
+	}
+	if stashID := getEndpointStashID(stashIDs, endpoint); stashID != nil {
+		edit.ID = stashID
+		edit.Operation = graphql.OperationEnumModify
+	}
+
+	details := &graphql.StudioEditDetailsInput{}
+	if studio.Name.Valid && studio.Name.String != "" {
+		details.Name = &studio.Name.String
+	}
+
+	if studio.ParentID.Valid {
+		parentStashIDs, err := qb.GetStashIDs(ctx, int(studio.ParentID.Int64))
+		if err != nil {
+			return nil, err
+		}
+
+		// the parent can only be set if it exists on stash-box
+		details.ParentID = getEndpointStashID(parentStashIDs, endpoint)
+	}
+
+	ret, err := c.client.SubmitStudioEdit(ctx, graphql.StudioEditInput{
+		Edit:    edit,
+		Details: details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret.StudioEdit.ID, nil
+}
+
+// SubmitTagDraft submits the tag to stash-box. stash-box does not support
+// tag drafts, so the tag is submitted as an edit creating a new tag.
+// Returns an error if a tag with the same name already exists on stash-box.
+// Returns the ID of the edit.
+func (c Client) SubmitTagDraft(ctx context.Context, t *models.Tag, comment *string) (*string, error) {
+	existing, err := c.client.FindTag(ctx, nil, &t.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing.FindTag != nil {
+		return nil, fmt.Errorf("tag %q already exists on stash-box", t.Name)
+	}
+
+	details := &graphql.TagEditDetailsInput{
+		Name: &t.Name,
+	}
+	if t.Description.Valid && t.Description.String != "" {
+		details.Description = &t.Description.String
+	}
+
+	aliases, err := c.repository.Tag.GetAliases(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	details.Aliases = aliases
+
+	ret, err := c.client.SubmitTagEdit(ctx, graphql.TagEditInput{
+		Edit: &graphql.EditInput{
+			Operation: graphql.OperationEnumCreate,
+			Comment:   comment,
+		},
+		Details: details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret.TagEdit.ID, nil
+}
+
+// sceneEditValues are the local values of a scene, with related objects
+// translated to their stash-box IDs.
+type sceneEditValues struct {
+	Title    string
+	Details  string
+	Date     string
+	Code     string
+	Director string
+	// StudioID is nil if the scene has no studio, or if the studio does not
+	// have a stash ID for the endpoint.
+	StudioID     *string
+	PerformerIDs []string
+	TagIDs       []string
+	// URLs that are associated with a stash-box site
+	URLs []string
+	// stash-box site IDs of the local and remote URLs, keyed by URL
+	URLSites map[string]string
+	// set if the scene has performers, tags or URLs that could not be found
+	// on stash-box. Remote performers, tags or URLs are not removed in this
+	// case, since they may correspond to the unresolved objects.
+	UnresolvedPerformers bool
+	UnresolvedTags       bool
+	UnresolvedURLs       bool
+}
+
+func diffString(local string, remote *string) *string {
+	r := ""
+	if remote != nil {
+		r = *remote
+	}
+
+	if local == "" || local == r {
+		return nil
+	}
+
+	return &local
+}
+
+// mergeIDs returns the IDs that the remote object should have, or nil if
+// they are the same as the remote IDs. If keepRemote is true, remote IDs not
+// in the local IDs are retained.
+func mergeIDs(local []string, remote []string, keepRemote bool) []string {
+	ret := append([]string{}, local...)
+	if keepRemote {
+		for _, id := range remote {
+			if !stringSliceContains(ret, id) {
+				ret = append(ret, id)
+			}
+		}
+	}
+
+	if len(ret) == len(remote) {
+		same := true
+		for _, id := range remote {
+			if !stringSliceContains(ret, id) {
+				same = false
+				break
+			}
+		}
+		if same {
+			return nil
+		}
+	}
+
+	return ret
+}
+
+func stringSliceContains(s []string, v string) bool {
+	for _, vv := range s {
+		if vv == v {
+			return true
+		}
+	}
+	return false
+}
+
+// sceneEditDetails returns the details of an edit changing the remote scene
+// to match the local values. Fields that are unset locally are not changed.
+// Returns nil if the remote scene does not differ.
+func sceneEditDetails(local sceneEditValues, remote *graphql.SceneFragment) *graphql.SceneEditDetailsInput {
+	ret := &graphql.SceneEditDetailsInput{
+		Title:    diffString(local.Title, remote.Title),
+		Details:  diffString(local.Details, remote.Details),
+		Date:     diffString(local.Date, remote.Date),
+		Code:     diffString(local.Code, remote.Code),
+		Director: diffString(local.Director, remote.Director),
+	}
+	changed := ret.Title != nil || ret.Details != nil || ret.Date != nil || ret.Code != nil || ret.Director != nil
+
+	if local.StudioID != nil && (remote.Studio == nil || remote.Studio.ID != *local.StudioID) {
+		ret.StudioID = local.StudioID
+		changed = true
+	}
+
+	var remotePerformerIDs []string
+	performerAs := make(map[string]*string)
+	for _, p := range remote.Performers {
+		remotePerformerIDs = append(remotePerformerIDs, p.Performer.ID)
+		performerAs[p.Performer.ID] = p.As
+	}
+	if ids := mergeIDs(local.PerformerIDs, remotePerformerIDs, local.UnresolvedPerformers); ids != nil {
+		for _, id := range ids {
+			ret.Performers = append(ret.Performers, &graphql.PerformerAppearanceInput{
+				PerformerID: id,
+				As:          performerAs[id],
+			})
+		}
+		changed = true
+	}
+
+	var remoteTagIDs []string
+	for _, t := range remote.Tags {
+		remoteTagIDs = append(remoteTagIDs, t.ID)
+	}
+	if ids := mergeIDs(local.TagIDs, remoteTagIDs, local.UnresolvedTags); ids != nil {
+		ret.TagIds = ids
+		changed = true
+	}
+
+	var remoteURLs []string
+	for _, u := range remote.Urls {
+		remoteURLs = append(remoteURLs, u.URL)
+	}
+	if len(local.URLs) > 0 || local.UnresolvedURLs {
+		if urls := mergeIDs(local.URLs, remoteURLs, local.UnresolvedURLs); urls != nil {
+			for _, u := range urls {
+				ret.Urls = append(ret.Urls, &graphql.URLInput{
+					URL:    u,
+					SiteID: local.URLSites[u],
+				})
+			}
+			changed = true
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	return ret
+}
+
+func (c Client) getSceneEditValues(ctx context.Context, scene *models.Scene, endpoint string, remote *graphql.SceneFragment) (*sceneEditValues, error) {
+	r := c.repository
+
+	ret := &sceneEditValues{
+		Title:    scene.Title,
+		Details:  scene.Details,
+		Code:     scene.Code,
+		Director: scene.Director,
+	}
+	if scene.Date != nil {
+		ret.Date = scene.Date.String()
+	}
+
+	if scene.StudioID != nil {
+		stashIDs, err := r.Studio.GetStashIDs(ctx, *scene.StudioID)
+		if err != nil {
+			return nil, err
+		}
+		ret.StudioID = getEndpointStashID(stashIDs, endpoint)
+	}
+
+	performers, err := r.Performer.FindBySceneID(ctx, scene.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range performers {
+		stashIDs, err := r.Performer.GetStashIDs(ctx, p.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		if id := getEndpointStashID(stashIDs, endpoint); id != nil {
+			ret.PerformerIDs = append(ret.PerformerIDs, *id)
+		} else {
+			logger.Debugf("performer %q has no stash ID for %s", p.Name, endpoint)
+			ret.UnresolvedPerformers = true
+		}
+	}
+
+	if err := c.setSceneEditURLs(ctx, ret, scene, endpoint, remote.Urls); err != nil {
+		return nil, err
+	}
+
+	tags, err := r.Tag.FindBySceneID(ctx, scene.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tags {
+		id, err := c.findRemoteTagID(ctx, t.Name, remote.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		if id != nil {
+			ret.TagIDs = append(ret.TagIDs, *id)
+		} else {
+			logger.Debugf("tag %q not found on %s", t.Name, endpoint)
+			ret.UnresolvedTags = true
+		}
+	}
+
+	return ret, nil
+}
+
+// setSceneEditURLs sets the URLs of the scene that are associated with a
+// stash-box site, along with the sites of the local and remote URLs. The
+// stash-box sites are only queried if the local URLs differ from the remote
+// URLs.
+func (c Client) setSceneEditURLs(ctx context.Context, ret *sceneEditValues, scene *models.Scene, endpoint string, remote []*graphql.URLFragment) error {
+	if err := scene.LoadURLs(ctx, c.repository.Scene); err != nil {
+		return err
+	}
+
+	local := scene.URLs.List()
+	var remoteURLs []string
+	for _, u := range remote {
+		remoteURLs = append(remoteURLs, u.URL)
+	}
+
+	if len(local) == 0 || mergeIDs(local, remoteURLs, false) == nil {
+		ret.URLs = local
+		return nil
+	}
+
+	res, err := c.client.QuerySites(ctx)
+	if err != nil {
+		return err
+	}
+	sites := res.QuerySites.Sites
+
+	ret.URLSites = make(map[string]string)
+	for _, u := range remote {
+		// the type of a stash-box URL is the name of its site
+		if site := findSiteByName(sites, u.Type); site != nil {
+			ret.URLSites[u.URL] = site.ID
+		} else if site := matchSite(sites, u.URL); site != nil {
+			ret.URLSites[u.URL] = site.ID
+		}
+	}
+
+	for _, u := range local {
+		if _, found := ret.URLSites[u]; !found {
+			if site := matchSite(sites, u); site != nil {
+				ret.URLSites[u] = site.ID
+			}
+		}
+
+		if _, found := ret.URLSites[u]; found {
+			ret.URLs = append(ret.URLs, u)
+		} else {
+			logger.Debugf("url %q does not match a site on %s", u, endpoint)
+			ret.UnresolvedURLs = true
+		}
+	}
+
+	return nil
+}
+
+func findSiteByName(sites []*graphql.SiteFragment, name string) *graphql.SiteFragment {
+	for _, s := range sites {
+		if s.Name == name {
+			return s
+		}
+	}
+
+	return nil
+}
+
+// matchSite returns the scene site that the URL belongs to. URLs are matched
+// using the regex of the site if set, otherwise by the host of the site URL.
+func matchSite(sites []*graphql.SiteFragment, u string) *graphql.SiteFragment {
+	host := urlHost(u)
+
+	for _, s := range sites {
+		if !siteHasType(s, graphql.ValidSiteTypeEnumScene) {
+			continue
+		}
+
+		if s.Regex != nil && *s.Regex != "" {
+			re, err := regexp.Compile(*s.Regex)
+			if err != nil {
+				logger.Debugf("invalid regex for site %q: %v", s.Name, err)
+				continue
+			}
+
+			if re.MatchString(u) {
+				return s
+			}
+			continue
+		}
+
+		if s.URL != nil && host != "" && urlHost(*s.URL) == host {
+			return s
+		}
+	}
+
+	return nil
+}
+
+func siteHasType(s *graphql.SiteFragment, t graphql.ValidSiteTypeEnum) bool {
+	for _, v := range s.ValidTypes {
+		if v == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+// urlHost returns the host of the URL without any www. prefix, or an empty
+// string if the URL cannot be parsed.
+func urlHost(u string) string {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// findRemoteTagID returns the stash-box ID of the tag with the provided
+// name, looking in the tags of the remote scene before querying stash-box.
+func (c Client) findRemoteTagID(ctx context.Context, name string, sceneTags []*graphql.TagFragment) (*string, error) {
+	for _, t := range sceneTags {
+		if strings.EqualFold(t.Name, name) {
+			return &t.ID, nil
+		}
+	}
+
+	res, err := c.client.FindTag(ctx, nil, &name)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.FindTag == nil {
+		return nil, nil
+	}
+
+	return &res.FindTag.ID, nil
+}
+
+// SubmitSceneEdit submits an edit of the stash-box scene linked to the
+// scene by its stash ID for the endpoint. Only the fields that differ from
+// the stash-box scene are included in the edit. Returns ErrNoChanges if the
+// scene does not differ. Returns the ID of the edit.
+func (c Client) SubmitSceneEdit(ctx context.Context, scene *models.Scene, endpoint string, comment *string) (*string, error) {
+	if err := scene.LoadStashIDs(ctx, c.repository.Scene); err != nil {
+		return nil, err
+	}
+
+	remoteID := getEndpointStashID(scene.StashIDs.List(), endpoint)
+	if remoteID == nil {
+		return nil, fmt.Errorf("scene %d has no stash ID for %s", scene.ID, endpoint)
+	}
+
+	res, err := c.client.FindSceneByID(ctx, *remoteID)
+	if err != nil {
+		return nil, err
+	}
+	if res.FindScene == nil {
+		return nil, fmt.Errorf("scene %s not found on %s", *remoteID, endpoint)
+	}
+
+	local, err := c.getSceneEditValues(ctx, scene, endpoint, res.FindScene)
+	if err != nil {
+		return nil, err
+	}
+
+	details := sceneEditDetails(*local, res.FindScene)
+	if details == nil {
+		return nil, ErrNoChanges
+	}
+
+	ret, err := c.client.SubmitSceneEdit(ctx, graphql.SceneEditInput{
+		Edit: &graphql.EditInput{
+			ID:        remoteID,
+			Operation: graphql.OperationEnumModify,
+			Comment:   comment,
+		},
+		Details: details,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ret.SceneEdit.ID, nil
+}
@@ -0,0 +1,254 @@
+package stashbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stashapp/stash/pkg/scraper/stashbox/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_sceneEditDetails(t *testing.T) {
+	var (
+		title       = "title"
+		newTitle    = "new title"
+		date        = "2022-01-01"
+		studioID    = "studio"
+		newStudioID = "new studio"
+		performerA  = "performerA"
+		performerB  = "performerB"
+		as          = "alias"
+		tagA        = "tagA"
+		tagB        = "tagB"
+		urlA        = "https://a.test/scene"
+		urlB        = "https://b.test/scene"
+		siteA       = "siteA"
+		siteB       = "siteB"
+	)
+
+	urlSites := map[string]string{
+		urlA: siteA,
+		urlB: siteB,
+	}
+
+	remote := &graphql.SceneFragment{
+		Title:  &title,
+		Date:   &date,
+		Studio: &graphql.StudioFragment{ID: studioID},
+		Performers: []*graphql.PerformerAppearanceFragment{
+			{Performer: graphql.PerformerFragment{ID: performerA}, As: &as},
+		},
+		Tags: []*graphql.TagFragment{
+			{ID: tagA},
+		},
+		Urls: []*graphql.URLFragment{
+			{URL: urlA, Type: siteA},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		local sceneEditValues
+		want  *graphql.SceneEditDetailsInput
+	}{
+		{
+			"unchanged",
+			sceneEditValues{
+				Title:        title,
+				Date:         date,
+				StudioID:     &studioID,
+				PerformerIDs: []string{performerA},
+				TagIDs:       []string{tagA},
+				URLs:         []string{urlA},
+			},
+			nil,
+		},
+		{
+			"unset local values",
+			sceneEditValues{
+				PerformerIDs: []string{performerA},
+				TagIDs:       []string{tagA},
+			},
+			nil,
+		},
+		{
+			"changed title and studio",
+			sceneEditValues{
+				Title:        newTitle,
+				StudioID:     &newStudioID,
+				PerformerIDs: []string{performerA},
+				TagIDs:       []string{tagA},
+			},
+			&graphql.SceneEditDetailsInput{
+				Title:    &newTitle,
+				StudioID: &newStudioID,
+			},
+		},
+		{
+			"added performer keeps alias",
+			sceneEditValues{
+				PerformerIDs: []string{performerA, performerB},
+				TagIDs:       []string{tagA},
+			},
+			&graphql.SceneEditDetailsInput{
+				Performers: []*graphql.PerformerAppearanceInput{
+					{PerformerID: performerA, As: &as},
+					{PerformerID: performerB},
+				},
+			},
+		},
+		{
+			"replaced tag",
+			sceneEditValues{
+				PerformerIDs: []string{performerA},
+				TagIDs:       []string{tagB},
+			},
+			&graphql.SceneEditDetailsInput{
+				TagIds: []string{tagB},
+			},
+		},
+		{
+			"unresolved tags keep remote tags",
+			sceneEditValues{
+				PerformerIDs:   []string{performerA},
+				TagIDs:         []string{tagB},
+				UnresolvedTags: true,
+			},
+			&graphql.SceneEditDetailsInput{
+				TagIds: []string{tagB, tagA},
+			},
+		},
+		{
+			"changed urls",
+			sceneEditValues{
+				PerformerIDs: []string{performerA},
+				TagIDs:       []string{tagA},
+				URLs:         []string{urlB},
+				URLSites:     urlSites,
+			},
+			&graphql.SceneEditDetailsInput{
+				Urls: []*graphql.URLInput{
+					{URL: urlB, SiteID: siteB},
+				},
+			},
+		},
+		{
+			"unresolved urls keep remote urls",
+			sceneEditValues{
+				PerformerIDs:   []string{performerA},
+				TagIDs:         []string{tagA},
+				URLs:           []string{urlB},
+				URLSites:       urlSites,
+				UnresolvedURLs: true,
+			},
+			&graphql.SceneEditDetailsInput{
+				Urls: []*graphql.URLInput{
+					{URL: urlB, SiteID: siteB},
+					{URL: urlA, SiteID: siteA},
+				},
+			},
+		},
+		{
+			"unresolved performers keep remote performers",
+			sceneEditValues{
+				TagIDs:               []string{tagA},
+				UnresolvedPerformers: true,
+			},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := sceneEditDetails(tt.local, remote)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClient_setSceneEditURLs(t *testing.T) {
+	const (
+		sceneID = 1
+		urlA    = "https://a.test/scene"
+		urlB    = "https://b.test/scene"
+		urlC    = "https://c.test/scene"
+	)
+
+	sites := []map[string]interface{}{
+		{"id": "siteA", "name": "Site A", "regex": `^https://a\.test/`, "valid_types": []string{"SCENE"}},
+		{"id": "siteB", "name": "Site B", "url": "https://www.b.test", "valid_types": []string{"SCENE"}},
+		{"id": "siteC", "name": "Site C", "url": "https://c.test", "valid_types": []string{"PERFORMER"}},
+	}
+
+	remote := []*graphql.URLFragment{
+		{URL: urlA, Type: "Site A"},
+	}
+
+	tests := []struct {
+		name           string
+		local          []string
+		wantURLs       []string
+		wantURLSites   map[string]string
+		wantUnresolved bool
+		wantQueried    bool
+	}{
+		{
+			"unchanged",
+			[]string{urlA},
+			[]string{urlA},
+			nil,
+			false,
+			false,
+		},
+		{
+			"changed",
+			[]string{urlA, urlB, urlC},
+			[]string{urlA, urlB},
+			map[string]string{urlA: "siteA", urlB: "siteB"},
+			true,
+			true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queried := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				queried = true
+				var resp struct {
+					Data struct {
+						QuerySites struct {
+							Sites []map[string]interface{} `json:"sites"`
+						} `json:"querySites"`
+					} `json:"data"`
+				}
+				resp.Data.QuerySites.Sites = sites
+				_ = json.NewEncoder(w).Encode(resp)
+			}))
+			defer server.Close()
+
+			qb := &mocks.SceneReaderWriter{}
+			qb.On("GetURLs", mock.Anything, sceneID).Return(tt.local, nil)
+
+			client := NewClient(models.StashBox{Endpoint: server.URL}, &mocks.TxnManager{}, Repository{
+				Scene: qb,
+			}, nil)
+
+			got := &sceneEditValues{}
+			if err := client.setSceneEditURLs(context.Background(), got, &models.Scene{ID: sceneID}, server.URL, remote); err != nil {
+				t.Errorf("Client.setSceneEditURLs() error = %v", err)
+				return
+			}
+
+			assert.Equal(t, tt.wantURLs, got.URLs)
+			assert.Equal(t, tt.wantURLSites, got.URLSites)
+			assert.Equal(t, tt.wantUnresolved, got.UnresolvedURLs)
+			assert.Equal(t, tt.wantQueried, queried)
+		})
+	}
+}
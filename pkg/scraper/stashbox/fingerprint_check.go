@@ -0,0 +1,194 @@
+package stashbox
+
+import (
+	"context"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/file"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/scraper/stashbox/graphql"
+	"github.com/stashapp/stash/pkg/utils"
+)
+
+// fingerprintDurationTolerance is the maximum difference in seconds between
+// the local and remote duration of a fingerprint for it to be considered
+// linked.
+const fingerprintDurationTolerance = 5
+
+// SceneFingerprintCheck is the result of checking the local fingerprints of a
+// scene against the stash-box scene linked by its stash ID.
+type SceneFingerprintCheck struct {
+	Status models.StashBoxFingerprintStatus
+	// ID of the stash-box scene that the checked scene was merged into
+	MergedInto *string
+	// local fingerprints that are not linked to the stash-box scene
+	Unlinked []*models.StashBoxFingerprint
+	// fingerprints submitted by the current user that no longer match any
+	// local fingerprint
+	Stale []*models.StashBoxFingerprint
+}
+
+// SceneFingerprints returns the oshash and phash fingerprints of the
+// provided files. Files without a duration are ignored.
+func SceneFingerprints(files []*file.VideoFile) []*models.StashBoxFingerprint {
+	var ret []*models.StashBoxFingerprint
+
+	add := func(fp models.StashBoxFingerprint) {
+		for _, v := range ret {
+			if *v == fp {
+				return
+			}
+		}
+		ret = append(ret, &fp)
+	}
+
+	for _, f := range files {
+		duration := int(f.Duration)
+		if duration == 0 {
+			continue
+		}
+
+		if oshash := f.Fingerprints.GetString(file.FingerprintTypeOshash); oshash != "" {
+			add(models.StashBoxFingerprint{
+				Algorithm: graphql.FingerprintAlgorithmOshash.String(),
+				Hash:      oshash,
+				Duration:  duration,
+			})
+		}
+
+		if phash := f.Fingerprints.GetInt64(file.FingerprintTypePhash); phash != 0 {
+			add(models.StashBoxFingerprint{
+				Algorithm: graphql.FingerprintAlgorithmPhash.String(),
+				Hash:      utils.PhashToString(phash),
+				Duration:  duration,
+			})
+		}
+	}
+
+	return ret
+}
+
+type remoteFingerprint struct {
+	models.StashBoxFingerprint
+	UserSubmitted bool
+}
+
+func fingerprintsEqual(a, b models.StashBoxFingerprint) bool {
+	return strings.EqualFold(a.Algorithm, b.Algorithm) && strings.EqualFold(a.Hash, b.Hash)
+}
+
+func durationsMatch(a, b int) bool {
+	diff := a - b
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= fingerprintDurationTolerance
+}
+
+// compareFingerprints returns the local fingerprints that are not linked to
+// the remote fingerprints, and the remote fingerprints submitted by the
+// current user that do not match any local fingerprint.
+func compareFingerprints(local []*models.StashBoxFingerprint, remote []remoteFingerprint) (unlinked []*models.StashBoxFingerprint, stale []*models.StashBoxFingerprint) {
+	for _, l := range local {
+		linked := false
+		for _, r := range remote {
+			if fingerprintsEqual(*l, r.StashBoxFingerprint) && durationsMatch(l.Duration, r.Duration) {
+				linked = true
+				break
+			}
+		}
+
+		if !linked {
+			unlinked = append(unlinked, l)
+		}
+	}
+
+	for _, r := range remote {
+		if !r.UserSubmitted {
+			continue
+		}
+
+		found := false
+		for _, l := range local {
+			if fingerprintsEqual(*l, r.StashBoxFingerprint) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			fp := r.StashBoxFingerprint
+			stale = append(stale, &fp)
+		}
+	}
+
+	return unlinked, stale
+}
+
+// CheckSceneFingerprints checks the provided local fingerprints against the
+// stash-box scene with the provided stash ID.
+func (c Client) CheckSceneFingerprints(ctx context.Context, stashID string, local []*models.StashBoxFingerprint) (*SceneFingerprintCheck, error) {
+	result, err := c.client.FindSceneFingerprintsByID(ctx, stashID)
+	if err != nil {
+		return nil, err
+	}
+
+	s := result.FindScene
+	if s == nil || s.Deleted {
+		return &SceneFingerprintCheck{
+			Status: models.StashBoxFingerprintStatusDeleted,
+		}, nil
+	}
+
+	// stash-box returns the target scene when looking up a merged scene
+	if s.ID != stashID {
+		mergedInto := s.ID
+		return &SceneFingerprintCheck{
+			Status:     models.StashBoxFingerprintStatusMerged,
+			MergedInto: &mergedInto,
+		}, nil
+	}
+
+	remote := make([]remoteFingerprint, len(s.Fingerprints))
+	for i, fp := range s.Fingerprints {
+		remote[i] = remoteFingerprint{
+			StashBoxFingerprint: models.StashBoxFingerprint{
+				Algorithm: fp.Algorithm.String(),
+				Hash:      fp.Hash,
+				Duration:  fp.Duration,
+			},
+			UserSubmitted: fp.UserSubmitted,
+		}
+	}
+
+	ret := &SceneFingerprintCheck{
+		Status: models.StashBoxFingerprintStatusMatched,
+	}
+	ret.Unlinked, ret.Stale = compareFingerprints(local, remote)
+	if len(ret.Unlinked) > 0 {
+		ret.Status = models.StashBoxFingerprintStatusUnlinked
+	}
+
+	return ret, nil
+}
+
+// DownvoteFingerprints removes the current user's submission of the
+// provided fingerprints from the stash-box scene with the provided stash ID.
+func (c Client) DownvoteFingerprints(ctx context.Context, stashID string, fingerprints []*models.StashBoxFingerprint) error {
+	unmatch := true
+	var submissions []graphql.FingerprintSubmission
+	for _, fp := range fingerprints {
+		submissions = append(submissions, graphql.FingerprintSubmission{
+			SceneID: stashID,
+			Fingerprint: &graphql.FingerprintInput{
+				Hash:      fp.Hash,
+				Algorithm: graphql.FingerprintAlgorithm(fp.Algorithm),
+				Duration:  fp.Duration,
+			},
+			Unmatch: &unmatch,
+		})
+	}
+
+	_, err := c.submitStashBoxFingerprints(ctx, submissions)
+	return err
+}
@@ -0,0 +1,84 @@
+package stashbox
+
+import (
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_compareFingerprints(t *testing.T) {
+	oshash := func(hash string, duration int) models.StashBoxFingerprint {
+		return models.StashBoxFingerprint{Algorithm: "OSHASH", Hash: hash, Duration: duration}
+	}
+	phash := func(hash string, duration int) models.StashBoxFingerprint {
+		return models.StashBoxFingerprint{Algorithm: "PHASH", Hash: hash, Duration: duration}
+	}
+	ptr := func(fp models.StashBoxFingerprint) *models.StashBoxFingerprint {
+		return &fp
+	}
+
+	tests := []struct {
+		name         string
+		local        []*models.StashBoxFingerprint
+		remote       []remoteFingerprint
+		wantUnlinked []*models.StashBoxFingerprint
+		wantStale    []*models.StashBoxFingerprint
+	}{
+		{
+			"all linked",
+			[]*models.StashBoxFingerprint{ptr(oshash("a", 100)), ptr(phash("b", 100))},
+			[]remoteFingerprint{
+				{oshash("a", 100), true},
+				{phash("B", 102), false},
+			},
+			nil,
+			nil,
+		},
+		{
+			"missing hash",
+			[]*models.StashBoxFingerprint{ptr(oshash("a", 100)), ptr(phash("b", 100))},
+			[]remoteFingerprint{
+				{oshash("a", 100), false},
+			},
+			[]*models.StashBoxFingerprint{ptr(phash("b", 100))},
+			nil,
+		},
+		{
+			"duration mismatch",
+			[]*models.StashBoxFingerprint{ptr(oshash("a", 100))},
+			[]remoteFingerprint{
+				{oshash("a", 200), false},
+			},
+			[]*models.StashBoxFingerprint{ptr(oshash("a", 100))},
+			nil,
+		},
+		{
+			"same hash different algorithm",
+			[]*models.StashBoxFingerprint{ptr(oshash("a", 100))},
+			[]remoteFingerprint{
+				{phash("a", 100), false},
+			},
+			[]*models.StashBoxFingerprint{ptr(oshash("a", 100))},
+			nil,
+		},
+		{
+			"stale user submitted",
+			[]*models.StashBoxFingerprint{ptr(oshash("a", 100))},
+			[]remoteFingerprint{
+				{oshash("a", 100), true},
+				{oshash("c", 100), true},
+				{oshash("d", 100), false},
+			},
+			nil,
+			[]*models.StashBoxFingerprint{ptr(oshash("c", 100))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotUnlinked, gotStale := compareFingerprints(tt.local, tt.remote)
+			assert.Equal(t, tt.wantUnlinked, gotUnlinked, "unlinked")
+			assert.Equal(t, tt.wantStale, gotStale, "stale")
+		})
+	}
+}
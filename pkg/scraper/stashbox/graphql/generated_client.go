@@ -17,10 +17,17 @@ type StashBoxGraphQLClient interface {
 	SearchPerformer(ctx context.Context, term string, httpRequestOptions ...client.HTTPRequestOption) (*SearchPerformer, error)
 	FindPerformerByID(ctx context.Context, id string, httpRequestOptions ...client.HTTPRequestOption) (*FindPerformerByID, error)
 	FindSceneByID(ctx context.Context, id string, httpRequestOptions ...client.HTTPRequestOption) (*FindSceneByID, error)
+	FindStudio(ctx context.Context, id *string, name *string, httpRequestOptions ...client.HTTPRequestOption) (*FindStudio, error)
 	SubmitFingerprint(ctx context.Context, input FingerprintSubmission, httpRequestOptions ...client.HTTPRequestOption) (*SubmitFingerprint, error)
 	Me(ctx context.Context, httpRequestOptions ...client.HTTPRequestOption) (*Me, error)
 	SubmitSceneDraft(ctx context.Context, input SceneDraftInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitSceneDraft, error)
 	SubmitPerformerDraft(ctx context.Context, input PerformerDraftInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitPerformerDraft, error)
+	FindTag(ctx context.Context, id *string, name *string, httpRequestOptions ...client.HTTPRequestOption) (*FindTag, error)
+	SubmitSceneEdit(ctx context.Context, input SceneEditInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitSceneEdit, error)
+	SubmitStudioEdit(ctx context.Context, input StudioEditInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitStudioEdit, error)
+	SubmitTagEdit(ctx context.Context, input TagEditInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitTagEdit, error)
+	FindSceneFingerprintsByID(ctx context.Context, id string, httpRequestOptions ...client.HTTPRequestOption) (*FindSceneFingerprintsByID, error)
+	QuerySites(ctx context.Context, httpRequestOptions ...client.HTTPRequestOption) (*QuerySites, error)
 }
 
 type Client struct {
@@ -194,6 +201,13 @@ type SceneFragment struct {
 	Performers   []*PerformerAppearanceFragment "json:\"performers\" graphql:\"performers\""
 	Fingerprints []*FingerprintFragment         "json:\"fingerprints\" graphql:\"fingerprints\""
 }
+type SiteFragment struct {
+	ID         string              "json:\"id\" graphql:\"id\""
+	Name       string              "json:\"name\" graphql:\"name\""
+	URL        *string             "json:\"url\" graphql:\"url\""
+	Regex      *string             "json:\"regex\" graphql:\"regex\""
+	ValidTypes []ValidSiteTypeEnum "json:\"valid_types\" graphql:\"valid_types\""
+}
 type FindSceneByFingerprint struct {
 	FindSceneByFingerprint []*SceneFragment "json:\"findSceneByFingerprint\" graphql:\"findSceneByFingerprint\""
 }
@@ -215,6 +229,15 @@ type FindPerformerByID struct {
 type FindSceneByID struct {
 	FindScene *SceneFragment "json:\"findScene\" graphql:\"findScene\""
 }
+type FindStudio struct {
+	FindStudio *struct {
+		Name   string           "json:\"name\" graphql:\"name\""
+		ID     string           "json:\"id\" graphql:\"id\""
+		Urls   []*URLFragment   "json:\"urls\" graphql:\"urls\""
+		Images []*ImageFragment "json:\"images\" graphql:\"images\""
+		Parent *StudioFragment  "json:\"parent\" graphql:\"parent\""
+	} "json:\"findStudio\" graphql:\"findStudio\""
+}
 type SubmitFingerprint struct {
 	SubmitFingerprint bool "json:\"submitFingerprint\" graphql:\"submitFingerprint\""
 }
@@ -233,6 +256,41 @@ type SubmitPerformerDraft struct {
 		ID *string "json:\"id\" graphql:\"id\""
 	} "json:\"submitPerformerDraft\" graphql:\"submitPerformerDraft\""
 }
+type FindTag struct {
+	FindTag *TagFragment "json:\"findTag\" graphql:\"findTag\""
+}
+type SubmitSceneEdit struct {
+	SceneEdit struct {
+		ID string "json:\"id\" graphql:\"id\""
+	} "json:\"sceneEdit\" graphql:\"sceneEdit\""
+}
+type SubmitStudioEdit struct {
+	StudioEdit struct {
+		ID string "json:\"id\" graphql:\"id\""
+	} "json:\"studioEdit\" graphql:\"studioEdit\""
+}
+type SubmitTagEdit struct {
+	TagEdit struct {
+		ID string "json:\"id\" graphql:\"id\""
+	} "json:\"tagEdit\" graphql:\"tagEdit\""
+}
+type FindSceneFingerprintsByID struct {
+	FindScene *struct {
+		ID           string "json:\"id\" graphql:\"id\""
+		Deleted      bool   "json:\"deleted\" graphql:\"deleted\""
+		Fingerprints []*struct {
+			Algorithm     FingerprintAlgorithm "json:\"algorithm\" graphql:\"algorithm\""
+			Hash          string               "json:\"hash\" graphql:\"hash\""
+			Duration      int                  "json:\"duration\" graphql:\"duration\""
+			UserSubmitted bool                 "json:\"user_submitted\" graphql:\"user_submitted\""
+		} "json:\"fingerprints\" graphql:\"fingerprints\""
+	} "json:\"findScene\" graphql:\"findScene\""
+}
+type QuerySites struct {
+	QuerySites struct {
+		Sites []*SiteFragment "json:\"sites\" graphql:\"sites\""
+	} "json:\"querySites\" graphql:\"querySites\""
+}
 
 const FindSceneByFingerprintDocument = `query FindSceneByFingerprint ($fingerprint: FingerprintQueryInput!) {
 	findSceneByFingerprint(fingerprint: $fingerprint) {
@@ -1040,6 +1098,50 @@ func (c *Client) FindSceneByID(ctx context.Context, id string, httpRequestOption
 	return &res, nil
 }
 
+const FindStudioDocument = `query FindStudio ($id: ID, $name: String) {
+	findStudio(id: $id, name: $name) {
+		... StudioFragment
+		parent {
+			... StudioFragment
+		}
+	}
+}
+fragment StudioFragment on Studio {
+	name
+	id
+	urls {
+		... URLFragment
+	}
+	images {
+		... ImageFragment
+	}
+}
+fragment URLFragment on URL {
+	url
+	type
+}
+fragment ImageFragment on Image {
+	id
+	url
+	width
+	height
+}
+`
+
+func (c *Client) FindStudio(ctx context.Context, id *string, name *string, httpRequestOptions ...client.HTTPRequestOption) (*FindStudio, error) {
+	vars := map[string]interface{}{
+		"id":   id,
+		"name": name,
+	}
+
+	var res FindStudio
+	if err := c.Client.Post(ctx, "FindStudio", FindStudioDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 const SubmitFingerprintDocument = `mutation SubmitFingerprint ($input: FingerprintSubmission!) {
 	submitFingerprint(input: $input)
 }
@@ -1115,3 +1217,142 @@ func (c *Client) SubmitPerformerDraft(ctx context.Context, input PerformerDraftI
 
 	return &res, nil
 }
+
+const FindTagDocument = `query FindTag ($id: ID, $name: String) {
+	findTag(id: $id, name: $name) {
+		... TagFragment
+	}
+}
+fragment TagFragment on Tag {
+	name
+	id
+}
+`
+
+func (c *Client) FindTag(ctx context.Context, id *string, name *string, httpRequestOptions ...client.HTTPRequestOption) (*FindTag, error) {
+	vars := map[string]interface{}{
+		"id":   id,
+		"name": name,
+	}
+
+	var res FindTag
+	if err := c.Client.Post(ctx, "FindTag", FindTagDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SubmitSceneEditDocument = `mutation SubmitSceneEdit ($input: SceneEditInput!) {
+	sceneEdit(input: $input) {
+		id
+	}
+}
+`
+
+func (c *Client) SubmitSceneEdit(ctx context.Context, input SceneEditInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitSceneEdit, error) {
+	vars := map[string]interface{}{
+		"input": input,
+	}
+
+	var res SubmitSceneEdit
+	if err := c.Client.Post(ctx, "SubmitSceneEdit", SubmitSceneEditDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SubmitStudioEditDocument = `mutation SubmitStudioEdit ($input: StudioEditInput!) {
+	studioEdit(input: $input) {
+		id
+	}
+}
+`
+
+func (c *Client) SubmitStudioEdit(ctx context.Context, input StudioEditInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitStudioEdit, error) {
+	vars := map[string]interface{}{
+		"input": input,
+	}
+
+	var res SubmitStudioEdit
+	if err := c.Client.Post(ctx, "SubmitStudioEdit", SubmitStudioEditDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SubmitTagEditDocument = `mutation SubmitTagEdit ($input: TagEditInput!) {
+	tagEdit(input: $input) {
+		id
+	}
+}
+`
+
+func (c *Client) SubmitTagEdit(ctx context.Context, input TagEditInput, httpRequestOptions ...client.HTTPRequestOption) (*SubmitTagEdit, error) {
+	vars := map[string]interface{}{
+		"input": input,
+	}
+
+	var res SubmitTagEdit
+	if err := c.Client.Post(ctx, "SubmitTagEdit", SubmitTagEditDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const FindSceneFingerprintsByIDDocument = `query FindSceneFingerprintsByID ($id: ID!) {
+	findScene(id: $id) {
+		id
+		deleted
+		fingerprints {
+			algorithm
+			hash
+			duration
+			user_submitted
+		}
+	}
+}
+`
+
+func (c *Client) FindSceneFingerprintsByID(ctx context.Context, id string, httpRequestOptions ...client.HTTPRequestOption) (*FindSceneFingerprintsByID, error) {
+	vars := map[string]interface{}{
+		"id": id,
+	}
+
+	var res FindSceneFingerprintsByID
+	if err := c.Client.Post(ctx, "FindSceneFingerprintsByID", FindSceneFingerprintsByIDDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const QuerySitesDocument = `query QuerySites {
+	querySites {
+		sites {
+			... SiteFragment
+		}
+	}
+}
+fragment SiteFragment on Site {
+	id
+	name
+	url
+	regex
+	valid_types
+}
+`
+
+func (c *Client) QuerySites(ctx context.Context, httpRequestOptions ...client.HTTPRequestOption) (*QuerySites, error) {
+	vars := map[string]interface{}{}
+
+	var res QuerySites
+	if err := c.Client.Post(ctx, "QuerySites", QuerySitesDocument, &res, vars, httpRequestOptions...); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
@@ -37,6 +37,7 @@ type SceneReader interface {
 	Find(ctx context.Context, id int) (*models.Scene, error)
 	models.StashIDLoader
 	models.VideoFileLoader
+	models.URLLoader
 }
 
 type PerformerReader interface {
@@ -44,6 +45,7 @@ type PerformerReader interface {
 	Find(ctx context.Context, id int) (*models.Performer, error)
 	FindBySceneID(ctx context.Context, sceneID int) ([]*models.Performer, error)
 	models.StashIDLoader
+	models.URLLoader
 	GetImage(ctx context.Context, performerID int) ([]byte, error)
 }
 
@@ -51,10 +53,13 @@ type StudioReader interface {
 	match.StudioFinder
 	studio.Finder
 	models.StashIDLoader
+	GetAliases(ctx context.Context, studioID int) ([]string, error)
 }
 type TagFinder interface {
 	tag.Queryer
+	tag.Finder
 	FindBySceneID(ctx context.Context, sceneID int) ([]*models.Tag, error)
+	GetAliases(ctx context.Context, tagID int) ([]string, error)
 }
 
 type Repository struct {
@@ -72,14 +77,22 @@ type Client struct {
 	box        models.StashBox
 }
 
-// NewClient returns a new instance of a stash-box client.
-func NewClient(box models.StashBox, txnManager txn.Manager, repo Repository) *Client {
+// NewClient returns a new instance of a stash-box client. Query responses
+// are cached in the provided response cache, which may be nil.
+func NewClient(box models.StashBox, txnManager txn.Manager, repo Repository, cache *scraper.ResponseCache) *Client {
 	authHeader := func(req *http.Request) {
 		req.Header.Set("ApiKey", box.APIKey)
 	}
 
+	httpClient := http.DefaultClient
+	if cache != nil {
+		httpClient = &http.Client{
+			Transport: newCachingTransport(box.Endpoint, cache),
+		}
+	}
+
 	client := &graphql.Client{
-		Client: client.NewClient(http.DefaultClient, box.Endpoint, authHeader),
+		Client: client.NewClient(httpClient, box.Endpoint, authHeader),
 	}
 
 	return &Client{
@@ -488,6 +501,16 @@ func findURL(urls []*graphql.URLFragment, urlType string) *string {
 	return nil
 }
 
+// urlsFromFragments returns the urls of all of the provided url fragments.
+func urlsFromFragments(urls []*graphql.URLFragment) []string {
+	var ret []string
+	for _, u := range urls {
+		ret = append(ret, u.URL)
+	}
+
+	return ret
+}
+
 func enumToStringPtr(e fmt.Stringer, titleCase bool) *string {
 	if e != nil {
 		ret := strings.ReplaceAll(e.String(), "_", " ")
@@ -606,15 +629,17 @@ func performerFragmentToScrapedScenePerformer(p graphql.PerformerFragment) *mode
 		images = append(images, image.URL)
 	}
 	sp := &models.ScrapedPerformer{
-		Name:         &p.Name,
-		Country:      p.Country,
-		Measurements: formatMeasurements(p.Measurements),
-		CareerLength: formatCareerLength(p.CareerStartYear, p.CareerEndYear),
-		Tattoos:      formatBodyModifications(p.Tattoos),
-		Piercings:    formatBodyModifications(p.Piercings),
-		Twitter:      findURL(p.Urls, "TWITTER"),
-		RemoteSiteID: &id,
-		Images:       images,
+		Name:           &p.Name,
+		Disambiguation: p.Disambiguation,
+		Country:        p.Country,
+		Measurements:   formatMeasurements(p.Measurements),
+		CareerLength:   formatCareerLength(p.CareerStartYear, p.CareerEndYear),
+		Tattoos:        formatBodyModifications(p.Tattoos),
+		Piercings:      formatBodyModifications(p.Piercings),
+		Twitter:        findURL(p.Urls, "TWITTER"),
+		URLs:           urlsFromFragments(p.Urls),
+		RemoteSiteID:   &id,
+		Images:         images,
 		// TODO - tags not currently supported
 		// graphql schema change to accommodate this. Leave off for now.
 	}
@@ -692,6 +717,7 @@ func (c Client) sceneFragmentToScrapedScene(ctx context.Context, s *graphql.Scen
 		Details:      s.Details,
 		Director:     s.Director,
 		URL:          findURL(s.Urls, "STUDIO"),
+		URLs:         urlsFromFragments(s.Urls),
 		Duration:     s.Duration,
 		RemoteSiteID: &stashID,
 		Fingerprints: getFingerprints(s),
@@ -781,6 +807,183 @@ func (c Client) FindStashBoxPerformerByName(ctx context.Context, name string) (*
 	return ret, nil
 }
 
+// FindStashBoxPerformer queries stash-box for the performer matching the
+// performer with the provided ID. The performer is found using its stash ID
+// for the stash-box endpoint if it has one, otherwise by name. Returns nil if
+// the performer is not found.
+func (c Client) FindStashBoxPerformer(ctx context.Context, performerID int) (*models.ScrapedPerformer, error) {
+	var remoteID, name string
+	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
+		qb := c.repository.Performer
+
+		p, err := qb.Find(ctx, performerID)
+		if err != nil {
+			return err
+		}
+
+		if p == nil {
+			return fmt.Errorf("performer with id %d not found", performerID)
+		}
+
+		stashIDs, err := qb.GetStashIDs(ctx, performerID)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range stashIDs {
+			if id.Endpoint == c.box.Endpoint {
+				remoteID = id.StashID
+				return nil
+			}
+		}
+
+		name = p.Name
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if remoteID != "" {
+		performer, err := c.client.FindPerformerByID(ctx, remoteID)
+		if err != nil {
+			return nil, err
+		}
+
+		if performer.FindPerformer == nil {
+			return nil, nil
+		}
+
+		return performerFragmentToScrapedScenePerformer(*performer.FindPerformer), nil
+	}
+
+	if name == "" {
+		return nil, nil
+	}
+
+	return c.FindStashBoxPerformerByName(ctx, name)
+}
+
+// FindStashBoxStudioByName queries stash-box for the studio with the provided
+// name. Returns nil if the studio is not found.
+func (c Client) FindStashBoxStudioByName(ctx context.Context, name string) (*models.ScrapedStudio, error) {
+	return c.findStashBoxStudio(ctx, nil, &name)
+}
+
+// FindStashBoxStudio queries stash-box for the studio matching the studio
+// with the provided ID. The studio is found using its stash ID for the
+// stash-box endpoint if it has one, otherwise by name, then by each of its
+// aliases. Returns nil if the studio is not found.
+func (c Client) FindStashBoxStudio(ctx context.Context, studioID int) (*models.ScrapedStudio, error) {
+	var remoteID, name *string
+	var aliases []string
+	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
+		qb := c.repository.Studio
+
+		s, err := qb.Find(ctx, studioID)
+		if err != nil {
+			return err
+		}
+
+		if s == nil {
+			return fmt.Errorf("studio with id %d not found", studioID)
+		}
+
+		stashIDs, err := qb.GetStashIDs(ctx, studioID)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range stashIDs {
+			if id.Endpoint == c.box.Endpoint {
+				v := id.StashID
+				remoteID = &v
+				return nil
+			}
+		}
+
+		if s.Name.Valid {
+			name = &s.Name.String
+		}
+
+		aliases, err = qb.GetAliases(ctx, studioID)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if remoteID != nil {
+		return c.findStashBoxStudio(ctx, remoteID, nil)
+	}
+
+	names := aliases
+	if name != nil {
+		names = append([]string{*name}, aliases...)
+	}
+
+	for i := range names {
+		ret, err := c.findStashBoxStudio(ctx, nil, &names[i])
+		if err != nil || ret != nil {
+			return ret, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (c Client) findStashBoxStudio(ctx context.Context, id *string, name *string) (*models.ScrapedStudio, error) {
+	res, err := c.client.FindStudio(ctx, id, name)
+	if err != nil {
+		return nil, err
+	}
+
+	s := res.FindStudio
+	if s == nil {
+		return nil, nil
+	}
+
+	ret := c.studioFragmentToScrapedStudio(ctx, graphql.StudioFragment{
+		Name:   s.Name,
+		ID:     s.ID,
+		Urls:   s.Urls,
+		Images: s.Images,
+	})
+
+	if s.Parent != nil {
+		ret.Parent = c.studioFragmentToScrapedStudio(ctx, *s.Parent)
+	}
+
+	if err := txn.WithTxn(ctx, c.txnManager, func(ctx context.Context) error {
+		if err := match.ScrapedStudio(ctx, c.repository.Studio, ret, &c.box.Endpoint); err != nil {
+			return err
+		}
+
+		if ret.Parent != nil {
+			return match.ScrapedStudio(ctx, c.repository.Studio, ret.Parent, &c.box.Endpoint)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (c Client) studioFragmentToScrapedStudio(ctx context.Context, s graphql.StudioFragment) *models.ScrapedStudio {
+	id := s.ID
+	ret := &models.ScrapedStudio{
+		Name:         s.Name,
+		URL:          findURL(s.Urls, "HOME"),
+		RemoteSiteID: &id,
+	}
+
+	if len(s.Images) > 0 {
+		ret.Image = getFirstImage(ctx, c.getHTTPClient(), s.Images)
+	}
+
+	return ret
+}
+
 func (c Client) GetUser(ctx context.Context) (*graphql.Me, error) {
 	return c.client.Me(ctx)
 }
@@ -808,8 +1011,12 @@ func (c Client) SubmitSceneDraft(ctx context.Context, scene *models.Scene, endpo
 	if scene.Details != "" {
 		draft.Details = &scene.Details
 	}
-	if scene.URL != "" && len(strings.TrimSpace(scene.URL)) > 0 {
-		url := strings.TrimSpace(scene.URL)
+
+	// drafts only support a single url
+	if err := scene.LoadURLs(ctx, r.Scene); err != nil {
+		return nil, err
+	}
+	if url := strings.TrimSpace(scene.URLs.First()); url != "" {
 		draft.URL = &url
 	}
 	if scene.Date != nil {
@@ -1012,15 +1219,16 @@ func (c Client) SubmitPerformerDraft(ctx context.Context, performer *models.Perf
 		draft.Aliases = &performer.Aliases
 	}
 
-	var urls []string
-	if len(strings.TrimSpace(performer.Twitter)) > 0 {
-		urls = append(urls, "https://twitter.com/"+strings.TrimSpace(performer.Twitter))
-	}
-	if len(strings.TrimSpace(performer.Instagram)) > 0 {
-		urls = append(urls, "https://instagram.com/"+strings.TrimSpace(performer.Instagram))
+	performerURLs, err := pqb.GetURLs(ctx, performer.ID)
+	if err != nil {
+		return nil, err
 	}
-	if len(strings.TrimSpace(performer.URL)) > 0 {
-		urls = append(urls, strings.TrimSpace(performer.URL))
+
+	var urls []string
+	for _, url := range performerURLs {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
 	}
 	if len(urls) > 0 {
 		draft.Urls = urls
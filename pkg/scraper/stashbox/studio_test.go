@@ -0,0 +1,177 @@
+package stashbox
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/models/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type remoteStudio struct {
+	ID     string        `json:"id"`
+	Name   string        `json:"name"`
+	Urls   []interface{} `json:"urls"`
+	Images []interface{} `json:"images"`
+	Parent *remoteStudio `json:"parent,omitempty"`
+}
+
+// newStudioServer returns a stash-box server which finds the provided
+// studios by id or name.
+func newStudioServer(t *testing.T, studios []*remoteStudio) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Variables struct {
+				ID   *string `json:"id"`
+				Name *string `json:"name"`
+			} `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("error decoding request: %v", err)
+		}
+
+		var found *remoteStudio
+		for _, s := range studios {
+			v := req.Variables
+			if (v.ID != nil && *v.ID == s.ID) || (v.Name != nil && *v.Name == s.Name) {
+				found = s
+			}
+		}
+
+		var resp struct {
+			Data struct {
+				FindStudio *remoteStudio `json:"findStudio"`
+			} `json:"data"`
+		}
+		resp.Data.FindStudio = found
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestClient_FindStashBoxStudio(t *testing.T) {
+	const (
+		studioID       = 1
+		matchedID      = 2
+		name           = "name"
+		alias          = "alias"
+		remoteID       = "remoteID"
+		remoteParentID = "remoteParentID"
+		remoteName     = "remote name"
+	)
+
+	const (
+		matchStashID = "stash id"
+		matchName    = "name"
+		matchAlias   = "alias"
+	)
+
+	tests := []struct {
+		name    string
+		stashID bool
+		remote  *remoteStudio
+		matchBy string
+	}{
+		{
+			"stash id",
+			true,
+			&remoteStudio{ID: remoteID, Name: remoteName},
+			matchStashID,
+		},
+		{
+			"name",
+			false,
+			&remoteStudio{ID: remoteID, Name: name},
+			matchName,
+		},
+		{
+			"alias",
+			false,
+			&remoteStudio{ID: remoteID, Name: alias},
+			matchAlias,
+		},
+		{
+			"parent",
+			true,
+			&remoteStudio{ID: remoteID, Name: remoteName, Parent: &remoteStudio{ID: remoteParentID, Name: "parent"}},
+			matchStashID,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			server := newStudioServer(t, []*remoteStudio{tt.remote})
+			defer server.Close()
+
+			var stashIDs []models.StashID
+			if tt.stashID {
+				stashIDs = []models.StashID{{Endpoint: server.URL, StashID: remoteID}}
+			}
+
+			qb := &mocks.StudioReaderWriter{}
+			qb.On("Find", mock.Anything, studioID).Return(&models.Studio{
+				ID:   studioID,
+				Name: sql.NullString{String: name, Valid: true},
+			}, nil)
+			qb.On("GetStashIDs", mock.Anything, studioID).Return(stashIDs, nil)
+			qb.On("GetAliases", mock.Anything, studioID).Return([]string{alias}, nil)
+
+			// only the matching query returns the local studio
+			matched := []*models.Studio{{ID: matchedID}}
+			matchedBy := func(by string) ([]*models.Studio, int) {
+				if tt.matchBy == by {
+					return matched, len(matched)
+				}
+				return nil, 0
+			}
+
+			byStashID, _ := matchedBy(matchStashID)
+			qb.On("FindByStashID", mock.Anything, models.StashID{Endpoint: server.URL, StashID: remoteID}).Return(byStashID, nil)
+			qb.On("FindByStashID", mock.Anything, models.StashID{Endpoint: server.URL, StashID: remoteParentID}).Return(nil, nil)
+
+			byName, nameCount := matchedBy(matchName)
+			qb.On("Query", mock.Anything, mock.MatchedBy(func(f *models.StudioFilterType) bool {
+				return f.Name != nil && f.Name.Value == tt.remote.Name
+			}), mock.Anything).Return(byName, nameCount, nil)
+			byAlias, aliasCount := matchedBy(matchAlias)
+			qb.On("Query", mock.Anything, mock.MatchedBy(func(f *models.StudioFilterType) bool {
+				return f.Aliases != nil && f.Aliases.Value == tt.remote.Name
+			}), mock.Anything).Return(byAlias, aliasCount, nil)
+			qb.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(nil, 0, nil)
+
+			client := NewClient(models.StashBox{Endpoint: server.URL}, &mocks.TxnManager{}, Repository{
+				Studio: qb,
+			}, nil)
+
+			got, err := client.FindStashBoxStudio(ctx, studioID)
+			if err != nil {
+				t.Errorf("Client.FindStashBoxStudio() error = %v", err)
+				return
+			}
+
+			if !assert.NotNil(t, got) {
+				return
+			}
+
+			assert.Equal(t, tt.remote.ID, *got.RemoteSiteID)
+			assert.Equal(t, tt.remote.Name, got.Name)
+			if assert.NotNil(t, got.StoredID) {
+				assert.Equal(t, strconv.Itoa(matchedID), *got.StoredID)
+			}
+
+			if tt.remote.Parent == nil {
+				assert.Nil(t, got.Parent)
+			} else if assert.NotNil(t, got.Parent) {
+				assert.Equal(t, remoteParentID, *got.Parent.RemoteSiteID)
+				// the parent does not exist locally
+				assert.Nil(t, got.Parent.StoredID)
+			}
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package scraper
+
+type ScrapedStudioInput struct {
+	Name         *string `json:"name"`
+	URL          *string `json:"url"`
+	Details      *string `json:"details"`
+	RemoteSiteID *string `json:"remote_site_id"`
+}
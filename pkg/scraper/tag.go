@@ -0,0 +1,6 @@
+package scraper
+
+type ScrapedTagInput struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
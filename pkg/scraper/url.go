@@ -7,6 +7,7 @@ import (
 	"io"
 	"net"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
 	"os"
 	"strings"
@@ -23,21 +24,49 @@ import (
 
 const scrapeDefaultSleep = time.Second * 2
 
+// loadURL returns the page at loadURL, decoded as UTF-8. Pages are cached in
+// the response cache of the scraper configuration.
 func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperConfig config, globalConfig GlobalConfig) (io.Reader, error) {
+	action := "loadURL"
 	driverOptions := scraperConfig.DriverOptions
-	if driverOptions != nil && driverOptions.UseCDP {
+	useCDP := driverOptions != nil && driverOptions.UseCDP
+	if useCDP {
+		action = "loadURLCDP"
+	}
+
+	key := ResponseCacheKey(scraperConfig.ID, action, loadURL)
+	if data, found := scraperConfig.responseCache.Get(key); found {
+		logger.Debugf("[scraper] using cached response for %s", loadURL)
+		return bytes.NewReader(data), nil
+	}
+
+	var r io.Reader
+	var err error
+	if useCDP {
 		// get the page using chrome dp
-		return urlFromCDP(ctx, loadURL, *driverOptions, globalConfig)
+		r, err = urlFromCDP(ctx, loadURL, scraperConfig, globalConfig)
+	} else {
+		r, err = fetchURL(ctx, loadURL, client, scraperConfig, globalConfig)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, loadURL, nil)
+	data, err := io.ReadAll(r)
 	if err != nil {
 		return nil, err
 	}
 
-	jar, err := scraperConfig.jar()
+	scraperConfig.responseCache.Set(key, data)
+	return bytes.NewReader(data), nil
+}
+
+func fetchURL(ctx context.Context, loadURL string, client *http.Client, scraperConfig config, globalConfig GlobalConfig) (io.Reader, error) {
+	driverOptions := scraperConfig.DriverOptions
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, loadURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("error creating cookie jar: %w", err)
+		return nil, err
 	}
 
 	u, err := url.Parse(loadURL)
@@ -45,10 +74,19 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 		return nil, fmt.Errorf("error parsing url %s: %w", loadURL, err)
 	}
 
-	// Fetch relevant cookies from the jar for url u and add them to the request
-	cookies := jar.Cookies(u)
-	for _, cookie := range cookies {
-		req.AddCookie(cookie)
+	session := scraperConfig.loginSession()
+	var jar *cookiejar.Jar
+	if session == nil {
+		jar, err = scraperConfig.jar()
+		if err != nil {
+			return nil, fmt.Errorf("error creating cookie jar: %w", err)
+		}
+
+		// Fetch relevant cookies from the jar for url u and add them to the request
+		cookies := jar.Cookies(u)
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
 	}
 
 	userAgent := globalConfig.GetScraperUserAgent()
@@ -65,7 +103,37 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 		}
 	}
 
-	resp, err := client.Do(req)
+	var rateLimit *rateLimitOptions
+	if driverOptions != nil {
+		rateLimit = driverOptions.RateLimit
+	}
+
+	limiter := scraperConfig.rateLimiter.get(scraperConfig.ID, u.Host, rateLimit)
+	maxRetries := scraperConfig.rateLimiter.maxRetries(rateLimit)
+
+	// do performs the request, logging in first if required
+	do := func() (*http.Response, int, error) {
+		if session == nil {
+			resp, err := doWithRetry(ctx, client, req, limiter, maxRetries)
+			return resp, 0, err
+		}
+
+		generation, err := session.login(ctx, client, scraperConfig, globalConfig)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		resp, err := doWithRetry(ctx, session.authorize(req, client), req, limiter, maxRetries)
+		return resp, generation, err
+	}
+
+	resp, generation, err := do()
+	if err == nil && session != nil && resp.StatusCode == http.StatusUnauthorized {
+		// the session has expired - log in again and retry
+		logger.Debugf("[scraper] %s: request to %s was unauthorized. Logging in again", scraperConfig.ID, loadURL)
+		session.invalidate(generation)
+		resp, _, err = do()
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -81,19 +149,35 @@ func loadURL(ctx context.Context, loadURL string, client *http.Client, scraperCo
 	}
 
 	bodyReader := bytes.NewReader(body)
-	printCookies(jar, scraperConfig, "Jar cookies found for scraper urls")
+	if jar != nil {
+		printCookies(jar, scraperConfig, "Jar cookies found for scraper urls")
+	}
 	return charset.NewReader(bodyReader, resp.Header.Get("Content-Type"))
 }
 
 // func urlFromCDP uses chrome cdp and DOM to load and process the url
 // if remote is set as true in the scraperConfig  it will try to use localhost:9222
 // else it will look for google-chrome in path
-func urlFromCDP(ctx context.Context, urlCDP string, driverOptions scraperDriverOptions, globalConfig GlobalConfig) (io.Reader, error) {
+func urlFromCDP(ctx context.Context, urlCDP string, scraperConfig config, globalConfig GlobalConfig) (io.Reader, error) {
+	driverOptions := *scraperConfig.DriverOptions
 
 	if !driverOptions.UseCDP {
 		return nil, fmt.Errorf("url shouldn't be fetched through CDP")
 	}
 
+	u, err := url.Parse(urlCDP)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing url %s: %w", urlCDP, err)
+	}
+
+	// retries are not performed for CDP requests, since the response status
+	// is not available
+	release, err := scraperConfig.rateLimiter.get(scraperConfig.ID, u.Host, driverOptions.RateLimit).acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
 	sleepDuration := scrapeDefaultSleep
 
 	if driverOptions.Sleep > 0 {
@@ -173,7 +257,7 @@ func urlFromCDP(ctx context.Context, urlCDP string, driverOptions scraperDriverO
 	var res string
 	headers := cdpHeaders(driverOptions)
 
-	err := chromedp.Run(ctx,
+	err = chromedp.Run(ctx,
 		network.Enable(),
 		setCDPCookies(driverOptions),
 		printCDPCookies(driverOptions, "Cookies found"),
@@ -0,0 +1,566 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"gopkg.in/yaml.v2"
+)
+
+// ValidationFixture is a saved page used to validate an xpath or json
+// scraper.
+type ValidationFixture struct {
+	// Type is the type of content scraped from the page.
+	Type ScrapeContentType
+	// Scraper is the name of the mapped scraper in the xPathScrapers or
+	// jsonScrapers section. If empty, the mapped scraper used by the scraper
+	// configuration for Type is used.
+	Scraper string
+	// URL selects the matching <type>ByURL configuration when Scraper is
+	// empty.
+	URL string
+	// Document is the HTML or JSON content of the page.
+	Document string
+	// Expected is the expected scraped content as YAML, using the mapped field
+	// names. The result is not compared if Expected is empty.
+	Expected string
+}
+
+// ScraperFieldResult is the result of a single mapped field.
+type ScraperFieldResult struct {
+	// Field is the path of the field, such as Title or Performers.Name
+	Field       string   `json:"field"`
+	Selector    *string  `json:"selector"`
+	Fixed       *string  `json:"fixed"`
+	PostProcess []string `json:"post_process"`
+	// Values are the values of the field after post-processing
+	Values []string `json:"values"`
+}
+
+// ScraperFieldDifference is a difference between the scraped and the
+// expected content. Expected or Actual is nil if the field is missing.
+type ScraperFieldDifference struct {
+	Field    string  `json:"field"`
+	Expected *string `json:"expected"`
+	Actual   *string `json:"actual"`
+}
+
+type ScraperValidationResult struct {
+	// Scraper is the name of the mapped scraper used
+	Scraper     string                    `json:"scraper"`
+	Fields      []*ScraperFieldResult     `json:"fields"`
+	Result      ScrapedContent            `json:"result"`
+	Differences []*ScraperFieldDifference `json:"differences"`
+	Warnings    []string                  `json:"warnings"`
+	// Valid is true if the result matches the expected content
+	Valid bool `json:"valid"`
+}
+
+// ValidateScraperConfig validates the scraper configuration in reader against
+// the provided fixture.
+func ValidateScraperConfig(ctx context.Context, reader io.Reader, fixture ValidationFixture) (*ScraperValidationResult, error) {
+	c, err := loadConfigFromYAML("validate", reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.validateFixture(ctx, fixture)
+}
+
+// ValidateScraper validates the scraper with the provided ID against the
+// provided fixture.
+func (c Cache) ValidateScraper(ctx context.Context, scraperID string, fixture ValidationFixture) (*ScraperValidationResult, error) {
+	s := c.findScraper(scraperID)
+	if s == nil {
+		return nil, fmt.Errorf("%w: id %s", ErrNotFound, scraperID)
+	}
+
+	g, ok := s.(group)
+	if !ok {
+		return nil, fmt.Errorf("%w: scraper %s is not configurable", ErrNotSupported, scraperID)
+	}
+
+	return g.config.validateFixture(ctx, fixture)
+}
+
+// typeConfigsFor returns the scraper type configurations used to scrape the
+// provided content type. If url is not empty, only the matching URL
+// configurations are returned.
+func (c config) typeConfigsFor(ty ScrapeContentType, url string) []*scraperTypeConfig {
+	var ret []*scraperTypeConfig
+	for _, s := range loadUrlCandidates(c, ty) {
+		if url == "" || s.matchesURL(url) {
+			ret = append(ret, &s.scraperTypeConfig)
+		}
+	}
+
+	if url != "" {
+		return ret
+	}
+
+	var others []*scraperTypeConfig
+	switch ty {
+	case ScrapeContentTypePerformer:
+		others = []*scraperTypeConfig{c.PerformerByName, c.PerformerByFragment}
+	case ScrapeContentTypeScene:
+		others = []*scraperTypeConfig{c.SceneByFragment, c.SceneByName, c.SceneByQueryFragment}
+	case ScrapeContentTypeGallery:
+		others = []*scraperTypeConfig{c.GalleryByFragment}
+	case ScrapeContentTypeImage:
+		others = []*scraperTypeConfig{c.ImageByFragment}
+	case ScrapeContentTypeMovie:
+		others = []*scraperTypeConfig{c.MovieByName, c.MovieByFragment}
+	case ScrapeContentTypeStudio:
+		others = []*scraperTypeConfig{c.StudioByName, c.StudioByFragment}
+	case ScrapeContentTypeTag:
+		others = []*scraperTypeConfig{c.TagByName, c.TagByFragment}
+	}
+
+	for _, s := range others {
+		if s != nil {
+			ret = append(ret, s)
+		}
+	}
+
+	return ret
+}
+
+// mappedScraperFor returns the mapped scraper used for the fixture, its name
+// and whether it is an xpath scraper.
+func (c config) mappedScraperFor(fixture ValidationFixture) (*mappedScraper, string, bool, error) {
+	name := fixture.Scraper
+	if name == "" {
+		for _, s := range c.typeConfigsFor(fixture.Type, fixture.URL) {
+			if s.Action == scraperActionXPath || s.Action == scraperActionJson {
+				name = s.Scraper
+				break
+			}
+		}
+
+		if name == "" {
+			return nil, "", false, fmt.Errorf("%w: no xpath or json scraper configured for %v", ErrNotSupported, fixture.Type)
+		}
+	}
+
+	if s := c.XPathScrapers[name]; s != nil {
+		return s, name, true, nil
+	}
+	if s := c.JsonScrapers[name]; s != nil {
+		return s, name, false, nil
+	}
+
+	return nil, "", false, fmt.Errorf("%w: mapped scraper %s", ErrNotFound, name)
+}
+
+// fixtureQuery runs queries against a fixture. Sub-scrapers are not run,
+// since they would load pages from the network.
+type fixtureQuery struct {
+	mappedQuery
+	result *ScraperValidationResult
+}
+
+func (q *fixtureQuery) subScrape(ctx context.Context, value string) mappedQuery {
+	q.result.Warnings = append(q.result.Warnings, fmt.Sprintf("sub-scraper for %s was not run", value))
+	return nil
+}
+
+func (c config) validateFixture(ctx context.Context, fixture ValidationFixture) (*ScraperValidationResult, error) {
+	if !fixture.Type.IsValid() {
+		return nil, fmt.Errorf("invalid scrape content type %q", fixture.Type)
+	}
+
+	s, name, isXPath, err := c.mappedScraperFor(fixture)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := &ScraperValidationResult{
+		Scraper:     name,
+		Fields:      []*ScraperFieldResult{},
+		Differences: []*ScraperFieldDifference{},
+		Warnings:    []string{},
+	}
+
+	q := &fixtureQuery{result: ret}
+	if isXPath {
+		doc, err := html.Parse(strings.NewReader(fixture.Document))
+		if err != nil {
+			return nil, fmt.Errorf("parsing fixture: %w", err)
+		}
+		q.mappedQuery = &xpathQuery{doc: doc, url: fixture.URL}
+	} else {
+		q.mappedQuery = &jsonQuery{doc: fixture.Document, url: fixture.URL}
+	}
+
+	for _, section := range s.sections(fixture.Type) {
+		ret.Fields = append(ret.Fields, section.config.describe(ctx, q, s.Common, section.name)...)
+	}
+
+	content, err := s.scrape(ctx, q, fixture.Type)
+	if err != nil {
+		return nil, err
+	}
+	ret.Result = content
+
+	if fixture.Expected != "" {
+		var expected interface{}
+		if err := yaml.Unmarshal([]byte(fixture.Expected), &expected); err != nil {
+			return nil, fmt.Errorf("parsing expected result: %w", err)
+		}
+
+		ret.Differences = append(ret.Differences, diffResults(flattenResult(expected), flattenResult(contentToResult(content)))...)
+	}
+
+	ret.Valid = len(ret.Differences) == 0
+
+	return ret, nil
+}
+
+// scrape scrapes the provided content type using q.
+func (s mappedScraper) scrape(ctx context.Context, q mappedQuery, ty ScrapeContentType) (ScrapedContent, error) {
+	var ret ScrapedContent
+	var err error
+
+	// don't assign nil concrete pointers to ret
+	switch ty {
+	case ScrapeContentTypePerformer:
+		if v, e := s.scrapePerformer(ctx, q); v != nil {
+			ret, err = v, e
+		} else {
+			err = e
+		}
+	case ScrapeContentTypeScene:
+		if s.Scene == nil {
+			return nil, nil
+		}
+		if v, e := s.scrapeScene(ctx, q); v != nil {
+			ret, err = v, e
+		} else {
+			err = e
+		}
+	case ScrapeContentTypeGallery:
+		if s.Gallery == nil {
+			return nil, nil
+		}
+		if v, e := s.scrapeGallery(ctx, q); v != nil {
+			ret, err = v, e
+		} else {
+			err = e
+		}
+	case ScrapeContentTypeImage:
+		if v, e := s.scrapeImage(ctx, q); v != nil {
+			ret, err = v, e
+		} else {
+			err = e
+		}
+	case ScrapeContentTypeMovie:
+		if s.Movie == nil {
+			return nil, nil
+		}
+		if v, e := s.scrapeMovie(ctx, q); v != nil {
+			ret, err = v, e
+		} else {
+			err = e
+		}
+	case ScrapeContentTypeStudio:
+		if v, e := s.scrapeStudio(ctx, q); v != nil {
+			ret, err = v, e
+		} else {
+			err = e
+		}
+	case ScrapeContentTypeTag:
+		if v, e := s.scrapeTag(ctx, q); v != nil {
+			ret, err = v, e
+		} else {
+			err = e
+		}
+	default:
+		return nil, ErrNotSupported
+	}
+
+	return ret, err
+}
+
+type mappedSection struct {
+	name   string
+	config mappedConfig
+}
+
+// sections returns the mapped configurations of the provided content type,
+// with the path of each.
+func (s mappedScraper) sections(ty ScrapeContentType) []mappedSection {
+	switch ty {
+	case ScrapeContentTypePerformer:
+		if s.Performer != nil {
+			return []mappedSection{
+				{"", s.Performer.mappedConfig},
+				{mappedScraperConfigPerformerTags, s.Performer.Tags},
+			}
+		}
+	case ScrapeContentTypeScene:
+		if s.Scene != nil {
+			return []mappedSection{
+				{"", s.Scene.mappedConfig},
+				{mappedScraperConfigScenePerformers, s.Scene.Performers.mappedConfig},
+				{mappedScraperConfigScenePerformers + "." + mappedScraperConfigPerformerTags, s.Scene.Performers.Tags},
+				{mappedScraperConfigSceneTags, s.Scene.Tags},
+				{mappedScraperConfigSceneStudio, s.Scene.Studio},
+				{mappedScraperConfigSceneMovies, s.Scene.Movies},
+			}
+		}
+	case ScrapeContentTypeGallery:
+		if s.Gallery != nil {
+			return []mappedSection{
+				{"", s.Gallery.mappedConfig},
+				{mappedScraperConfigScenePerformers, s.Gallery.Performers},
+				{mappedScraperConfigSceneTags, s.Gallery.Tags},
+				{mappedScraperConfigSceneStudio, s.Gallery.Studio},
+			}
+		}
+	case ScrapeContentTypeImage:
+		if s.Image != nil {
+			return []mappedSection{
+				{"", s.Image.mappedConfig},
+				{mappedScraperConfigScenePerformers, s.Image.Performers},
+				{mappedScraperConfigSceneTags, s.Image.Tags},
+				{mappedScraperConfigSceneStudio, s.Image.Studio},
+			}
+		}
+	case ScrapeContentTypeMovie:
+		if s.Movie != nil {
+			return []mappedSection{
+				{"", s.Movie.mappedConfig},
+				{mappedScraperConfigMovieStudio, s.Movie.Studio},
+			}
+		}
+	case ScrapeContentTypeStudio:
+		if s.Studio != nil {
+			return []mappedSection{
+				{"", s.Studio.mappedConfig},
+				{mappedScraperConfigStudioParent, s.Studio.Parent},
+			}
+		}
+	case ScrapeContentTypeTag:
+		return []mappedSection{
+			{"", s.Tag},
+		}
+	}
+
+	return nil
+}
+
+// describe runs each field of the configuration separately, returning the
+// selector, post-process chain and values of each. Fields are sorted by name.
+func (s mappedConfig) describe(ctx context.Context, q mappedQuery, common commonMappedConfig, path string) []*ScraperFieldResult {
+	var keys []string
+	for k := range s {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var ret []*ScraperFieldResult
+	for _, k := range keys {
+		attrConfig := s[k]
+
+		field := k
+		if path != "" {
+			field = path + "." + k
+		}
+
+		r := &ScraperFieldResult{
+			Field:       field,
+			PostProcess: []string{},
+			Values:      []string{},
+		}
+
+		if attrConfig.Fixed != "" {
+			fixed := attrConfig.Fixed
+			r.Fixed = &fixed
+			r.Values = []string{fixed}
+			ret = append(ret, r)
+			continue
+		}
+
+		selector := s.applyCommon(common, attrConfig.Selector)
+		r.Selector = &selector
+		if attrConfig.hasConcat() {
+			r.PostProcess = append(r.PostProcess, "concat: "+strconv.Quote(attrConfig.Concat))
+		}
+		for _, a := range attrConfig.postProcessActions {
+			r.PostProcess = append(r.PostProcess, describePostProcessAction(a))
+		}
+		if attrConfig.hasSplit() {
+			r.PostProcess = append(r.PostProcess, "split: "+strconv.Quote(attrConfig.Split))
+		}
+
+		found, err := q.runQuery(selector)
+		if err != nil {
+			r.Values = append(r.Values, "error: "+err.Error())
+		} else if len(found) > 0 {
+			r.Values = append(r.Values, s.postProcess(ctx, q, attrConfig, found)...)
+		}
+
+		ret = append(ret, r)
+	}
+
+	return ret
+}
+
+func describePostProcessAction(a postProcessAction) string {
+	switch v := a.(type) {
+	case *postProcessParseDate:
+		return "parseDate: " + strconv.Quote(string(*v))
+	case *postProcessSubtractDays:
+		return "subtractDays"
+	case *postProcessReplace:
+		var parts []string
+		for _, r := range *v {
+			parts = append(parts, fmt.Sprintf("%q -> %q", r.Regex, r.With))
+		}
+		return "replace: " + strings.Join(parts, ", ")
+	case *postProcessSubScraper:
+		return "subScraper: " + strconv.Quote(v.Selector)
+	case *postProcessMap:
+		return fmt.Sprintf("map: %d values", len(*v))
+	case *postProcessFeetToCm:
+		return "feetToCm"
+	case *postProcessLbToKg:
+		return "lbToKg"
+	case *postProcessKgToLb:
+		return "kgToLb"
+	case *postProcessCmToInch:
+		return "cmToInch"
+	case *postProcessInchToCm:
+		return "inchToCm"
+	case *postProcessParseDuration:
+		return "parseDuration"
+	case *postProcessTrim:
+		return "trim"
+	case *postProcessCase:
+		return "case: " + string(*v)
+	case *postProcessJoinURL:
+		return "joinURL"
+	case *postProcessExtractJSON:
+		return "extractJSON: " + strconv.Quote(string(*v))
+	case *postProcessJavascript:
+		return "javascript: " + strconv.Quote(v.src)
+	}
+
+	return fmt.Sprintf("%T", a)
+}
+
+// contentToResult converts scraped content into maps keyed by the field
+// names used in mapped scraper configurations. Nil fields are omitted.
+func contentToResult(v interface{}) interface{} {
+	return valueToResult(reflect.ValueOf(v))
+}
+
+func valueToResult(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return valueToResult(v.Elem())
+	case reflect.Struct:
+		ret := make(map[string]interface{})
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if !t.Field(i).IsExported() {
+				continue
+			}
+			if fv := valueToResult(v.Field(i)); fv != nil {
+				ret[t.Field(i).Name] = fv
+			}
+		}
+		return ret
+	case reflect.Slice:
+		if v.Len() == 0 {
+			return nil
+		}
+		ret := make([]interface{}, v.Len())
+		for i := range ret {
+			ret[i] = valueToResult(v.Index(i))
+		}
+		return ret
+	case reflect.String:
+		if v.String() == "" {
+			return nil
+		}
+		return v.String()
+	case reflect.Invalid:
+		return nil
+	}
+
+	return fmt.Sprint(v.Interface())
+}
+
+// flattenResult flattens a result into a map of field paths, such as
+// Performers.0.Name, to values.
+func flattenResult(v interface{}) map[string]string {
+	ret := make(map[string]string)
+	flattenInto(ret, "", v)
+	return ret
+}
+
+func flattenInto(dest map[string]string, path string, v interface{}) {
+	join := func(k string) string {
+		if path == "" {
+			return k
+		}
+		return path + "." + k
+	}
+
+	switch vv := v.(type) {
+	case nil:
+	case map[string]interface{}:
+		for k, e := range vv {
+			flattenInto(dest, join(k), e)
+		}
+	case map[interface{}]interface{}:
+		for k, e := range vv {
+			flattenInto(dest, join(fmt.Sprint(k)), e)
+		}
+	case []interface{}:
+		for i, e := range vv {
+			flattenInto(dest, join(strconv.Itoa(i)), e)
+		}
+	default:
+		dest[path] = fmt.Sprint(vv)
+	}
+}
+
+// diffResults returns the differences between the flattened expected and
+// actual results, sorted by field.
+func diffResults(expected map[string]string, actual map[string]string) []*ScraperFieldDifference {
+	var ret []*ScraperFieldDifference
+	for k, e := range expected {
+		e := e
+		a, found := actual[k]
+		switch {
+		case !found:
+			ret = append(ret, &ScraperFieldDifference{Field: k, Expected: &e})
+		case a != e:
+			ret = append(ret, &ScraperFieldDifference{Field: k, Expected: &e, Actual: &a})
+		}
+	}
+
+	for k, a := range actual {
+		a := a
+		if _, found := expected[k]; !found {
+			ret = append(ret, &ScraperFieldDifference{Field: k, Actual: &a})
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Field < ret[j].Field
+	})
+
+	return ret
+}
@@ -0,0 +1,147 @@
+package scraper
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const validateSceneConfig = `name: Test
+sceneByURL:
+  - action: scrapeXPath
+    url:
+      - example.com/scenes/
+    scraper: sceneScraper
+xPathScrapers:
+  sceneScraper:
+    common:
+      $info: //div[@class="info"]
+    scene:
+      Title: //h1
+      Date:
+        selector: $info/span[@class="date"]
+        postProcess:
+          - parseDate: January 2, 2006
+      Code:
+        fixed: ABC
+      Performers:
+        Name: $info/a[@class="performer"]
+        URL:
+          selector: $info/a[@class="performer"]/@href
+          postProcess:
+            - replace:
+                - regex: ^
+                  with: https://example.com
+            - subScraper: //h1
+`
+
+const validateSceneDocument = `<html><body>
+<h1>Scene Title</h1>
+<div class="info">
+	<span class="date">March 4, 2021</span>
+	<a class="performer" href="/p/1">Performer One</a>
+	<a class="performer" href="/p/2">Performer Two</a>
+</div>
+</body></html>`
+
+func TestValidateScraperConfig(t *testing.T) {
+	const expected = `Title: Scene Title
+Date: "2021-03-04"
+Code: ABC
+Performers:
+  - Name: Performer One
+  - Name: Performer Three
+`
+
+	r, err := ValidateScraperConfig(context.Background(), strings.NewReader(validateSceneConfig), ValidationFixture{
+		Type:     ScrapeContentTypeScene,
+		URL:      "https://example.com/scenes/1",
+		Document: validateSceneDocument,
+		Expected: expected,
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, "sceneScraper", r.Scraper)
+	assert.False(t, r.Valid)
+
+	fields := make(map[string]*ScraperFieldResult)
+	for _, f := range r.Fields {
+		fields[f.Field] = f
+	}
+
+	if assert.Contains(t, fields, "Date") {
+		assert.Equal(t, `//div[@class="info"]/span[@class="date"]`, *fields["Date"].Selector)
+		assert.Equal(t, []string{`parseDate: "January 2, 2006"`}, fields["Date"].PostProcess)
+		assert.Equal(t, []string{"2021-03-04"}, fields["Date"].Values)
+	}
+	if assert.Contains(t, fields, "Code") {
+		assert.Nil(t, fields["Code"].Selector)
+		assert.Equal(t, "ABC", *fields["Code"].Fixed)
+	}
+	if assert.Contains(t, fields, "Performers.Name") {
+		assert.Equal(t, []string{"Performer One", "Performer Two"}, fields["Performers.Name"].Values)
+	}
+	if assert.Contains(t, fields, "Performers.URL") {
+		assert.Len(t, fields["Performers.URL"].PostProcess, 2)
+	}
+
+	// sub-scrapers are not run
+	assert.NotEmpty(t, r.Warnings)
+
+	str := func(s string) *string { return &s }
+	assert.Equal(t, []*ScraperFieldDifference{
+		{Field: "Performers.1.Name", Expected: str("Performer Three"), Actual: str("Performer Two")},
+	}, r.Differences)
+}
+
+func TestValidateScraperConfig_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fixture ValidationFixture
+	}{
+		{
+			"invalid type",
+			ValidationFixture{Type: "INVALID"},
+		},
+		{
+			"unmatched url",
+			ValidationFixture{Type: ScrapeContentTypeScene, URL: "https://other.com/scenes/1"},
+		},
+		{
+			"unknown mapped scraper",
+			ValidationFixture{Type: ScrapeContentTypeScene, Scraper: "missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateScraperConfig(context.Background(), strings.NewReader(validateSceneConfig), tt.fixture)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_diffResults(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	expected := flattenResult(map[interface{}]interface{}{
+		"Title": "a",
+		"Tags":  []interface{}{map[interface{}]interface{}{"Name": "x"}},
+		"URL":   "u",
+	})
+	actual := flattenResult(map[string]interface{}{
+		"Title":   "b",
+		"Tags":    []interface{}{map[string]interface{}{"Name": "x"}},
+		"Details": "d",
+	})
+
+	assert.Equal(t, []*ScraperFieldDifference{
+		{Field: "Details", Actual: str("d")},
+		{Field: "Title", Expected: str("a"), Actual: str("b")},
+		{Field: "URL", Expected: str("u")},
+	}, diffResults(expected, actual))
+}
@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/andybalholm/cascadia"
 	"github.com/antchfx/htmlquery"
 
 	"golang.org/x/net/html"
@@ -61,7 +62,7 @@ func (s *xpathScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeCon
 		return nil, err
 	}
 
-	q := s.getXPathQuery(doc)
+	q := s.getXPathQuery(doc, u)
 	switch ty {
 	case ScrapeContentTypePerformer:
 		return scraper.scrapePerformer(ctx, q)
@@ -69,8 +70,14 @@ func (s *xpathScraper) scrapeByURL(ctx context.Context, url string, ty ScrapeCon
 		return scraper.scrapeScene(ctx, q)
 	case ScrapeContentTypeGallery:
 		return scraper.scrapeGallery(ctx, q)
+	case ScrapeContentTypeImage:
+		return scraper.scrapeImage(ctx, q)
 	case ScrapeContentTypeMovie:
 		return scraper.scrapeMovie(ctx, q)
+	case ScrapeContentTypeStudio:
+		return scraper.scrapeStudio(ctx, q)
+	case ScrapeContentTypeTag:
+		return scraper.scrapeTag(ctx, q)
 	}
 
 	return nil, ErrNotSupported
@@ -97,7 +104,7 @@ func (s *xpathScraper) scrapeByName(ctx context.Context, name string, ty ScrapeC
 		return nil, err
 	}
 
-	q := s.getXPathQuery(doc)
+	q := s.getXPathQuery(doc, url)
 	q.setType(SearchQuery)
 
 	var content []ScrapedContent
@@ -121,6 +128,39 @@ func (s *xpathScraper) scrapeByName(ctx context.Context, name string, ty ScrapeC
 			content = append(content, s)
 		}
 
+		return content, nil
+	case ScrapeContentTypeMovie:
+		movies, err := scraper.scrapeMovies(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range movies {
+			content = append(content, m)
+		}
+
+		return content, nil
+	case ScrapeContentTypeStudio:
+		studios, err := scraper.scrapeStudios(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range studios {
+			content = append(content, s)
+		}
+
+		return content, nil
+	case ScrapeContentTypeTag:
+		tags, err := scraper.scrapeTags(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range tags {
+			content = append(content, t)
+		}
+
 		return content, nil
 	}
 
@@ -147,24 +187,32 @@ func (s *xpathScraper) scrapeSceneByScene(ctx context.Context, scene *models.Sce
 		return nil, err
 	}
 
-	q := s.getXPathQuery(doc)
+	q := s.getXPathQuery(doc, url)
 	return scraper.scrapeScene(ctx, q)
 }
 
 func (s *xpathScraper) scrapeByFragment(ctx context.Context, input Input) (ScrapedContent, error) {
+	var queryURL queryURLParameters
 	switch {
 	case input.Gallery != nil:
 		return nil, fmt.Errorf("%w: cannot use an xpath scraper as a gallery fragment scraper", ErrNotSupported)
 	case input.Performer != nil:
 		return nil, fmt.Errorf("%w: cannot use an xpath scraper as a performer fragment scraper", ErrNotSupported)
-	case input.Scene == nil:
-		return nil, fmt.Errorf("%w: scene input is nil", ErrNotSupported)
+	case input.Scene != nil:
+		queryURL = queryURLParametersFromScrapedScene(*input.Scene)
+	case input.Image != nil:
+		queryURL = queryURLParametersFromScrapedImage(*input.Image)
+	case input.Movie != nil:
+		queryURL = queryURLParametersFromScrapedMovie(*input.Movie)
+	case input.Studio != nil:
+		queryURL = queryURLParametersFromScrapedStudio(*input.Studio)
+	case input.Tag != nil:
+		queryURL = queryURLParametersFromScrapedTag(*input.Tag)
+	default:
+		return nil, fmt.Errorf("%w: fragment input is nil", ErrNotSupported)
 	}
 
-	scene := *input.Scene
-
 	// construct the URL
-	queryURL := queryURLParametersFromScrapedScene(scene)
 	if s.scraper.QueryURLReplacements != nil {
 		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
 	}
@@ -182,7 +230,18 @@ func (s *xpathScraper) scrapeByFragment(ctx context.Context, input Input) (Scrap
 		return nil, err
 	}
 
-	q := s.getXPathQuery(doc)
+	q := s.getXPathQuery(doc, url)
+	switch {
+	case input.Image != nil:
+		return scraper.scrapeImage(ctx, q)
+	case input.Movie != nil:
+		return scraper.scrapeMovie(ctx, q)
+	case input.Studio != nil:
+		return scraper.scrapeStudio(ctx, q)
+	case input.Tag != nil:
+		return scraper.scrapeTag(ctx, q)
+	}
+
 	return scraper.scrapeScene(ctx, q)
 }
 
@@ -206,10 +265,34 @@ func (s *xpathScraper) scrapeGalleryByGallery(ctx context.Context, gallery *mode
 		return nil, err
 	}
 
-	q := s.getXPathQuery(doc)
+	q := s.getXPathQuery(doc, url)
 	return scraper.scrapeGallery(ctx, q)
 }
 
+func (s *xpathScraper) scrapeImageByImage(ctx context.Context, image *models.Image) (*ScrapedImage, error) {
+	// construct the URL
+	queryURL := queryURLParametersFromImage(image)
+	if s.scraper.QueryURLReplacements != nil {
+		queryURL.applyReplacements(s.scraper.QueryURLReplacements)
+	}
+	url := queryURL.constructURL(s.scraper.QueryURL)
+
+	scraper := s.getXpathScraper()
+
+	if scraper == nil {
+		return nil, errors.New("xpath scraper with name " + s.scraper.Scraper + " not found in config")
+	}
+
+	doc, err := s.loadURL(ctx, url)
+
+	if err != nil {
+		return nil, err
+	}
+
+	q := s.getXPathQuery(doc, url)
+	return scraper.scrapeImage(ctx, q)
+}
+
 func (s *xpathScraper) loadURL(ctx context.Context, url string) (*html.Node, error) {
 	r, err := loadURL(ctx, url, s.client, s.config, s.globalConfig)
 	if err != nil {
@@ -229,19 +312,25 @@ func (s *xpathScraper) loadURL(ctx context.Context, url string) (*html.Node, err
 	return ret, err
 }
 
-func (s *xpathScraper) getXPathQuery(doc *html.Node) *xpathQuery {
+func (s *xpathScraper) getXPathQuery(doc *html.Node, url string) *xpathQuery {
 	return &xpathQuery{
 		doc:     doc,
+		url:     url,
 		scraper: s,
 	}
 }
 
 type xpathQuery struct {
 	doc       *html.Node
+	url       string
 	scraper   *xpathScraper
 	queryType QueryType
 }
 
+func (q *xpathQuery) getURL() string {
+	return q.url
+}
+
 func (q *xpathQuery) getType() QueryType {
 	return q.queryType
 }
@@ -250,7 +339,19 @@ func (q *xpathQuery) setType(t QueryType) {
 	q.queryType = t
 }
 
+// cssSelectorPrefix marks selectors which are CSS selectors rather than
+// XPath expressions.
+const cssSelectorPrefix = "css:"
+
+// cssAttrRE matches the ::attr(name) suffix used to select attribute values
+// with CSS selectors.
+var cssAttrRE = regexp.MustCompile(`::attr\(\s*([^)\s]+)\s*\)$`)
+
 func (q *xpathQuery) runQuery(selector string) ([]string, error) {
+	if strings.HasPrefix(selector, cssSelectorPrefix) {
+		return q.runCSSQuery(strings.TrimPrefix(selector, cssSelectorPrefix))
+	}
+
 	found, err := htmlquery.QueryAll(q.doc, selector)
 	if err != nil {
 		return nil, fmt.Errorf("selector '%s': parse error: %v", selector, err)
@@ -268,6 +369,39 @@ func (q *xpathQuery) runQuery(selector string) ([]string, error) {
 	return ret, nil
 }
 
+// runCSSQuery returns the text of the elements matching the CSS selector.
+// If the selector ends with ::attr(name), the value of the name attribute is
+// returned instead.
+func (q *xpathQuery) runCSSQuery(selector string) ([]string, error) {
+	attr := ""
+	if m := cssAttrRE.FindStringSubmatch(selector); m != nil {
+		attr = m[1]
+		selector = strings.TrimSuffix(selector, m[0])
+	}
+
+	sel, err := cascadia.Compile(strings.TrimSpace(selector))
+	if err != nil {
+		return nil, fmt.Errorf("css selector '%s': parse error: %v", selector, err)
+	}
+
+	var ret []string
+	for _, n := range sel.MatchAll(q.doc) {
+		var text string
+		if attr != "" {
+			text = strings.TrimSpace(htmlquery.SelectAttr(n, attr))
+		} else {
+			text = q.nodeText(n)
+		}
+
+		// don't add empty strings
+		if text != "" {
+			ret = append(ret, text)
+		}
+	}
+
+	return ret, nil
+}
+
 func (q *xpathQuery) nodeText(n *html.Node) string {
 	var ret string
 	if n != nil && n.Type == html.CommentNode {
@@ -298,5 +432,5 @@ func (q *xpathQuery) subScrape(ctx context.Context, value string) mappedQuery {
 		return nil
 	}
 
-	return q.scraper.getXPathQuery(doc)
+	return q.scraper.getXPathQuery(doc, value)
 }
@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/antchfx/htmlquery"
 	"github.com/stashapp/stash/pkg/models"
@@ -808,6 +809,53 @@ func TestLoadInvalidXPath(t *testing.T) {
 	config.process(context.Background(), q, nil)
 }
 
+func TestCSSSelectors(t *testing.T) {
+	const doc = `<html><body>
+<h1 class="title">  Scene   Title </h1>
+<div id="info">
+	<a class="performer" href="/p/1">Performer One</a>
+	<a class="performer" href="/p/2">Performer Two</a>
+	<a class="tag" href="/t/1"></a>
+</div>
+</body></html>`
+
+	node, err := htmlquery.Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatalf("Error loading document: %s", err.Error())
+	}
+
+	q := &xpathQuery{doc: node}
+
+	tests := []struct {
+		selector string
+		want     []string
+		wantErr  bool
+	}{
+		{"css:h1.title", []string{"Scene Title"}, false},
+		{"css:#info > a.performer", []string{"Performer One", "Performer Two"}, false},
+		{"css:#info a.performer::attr(href)", []string{"/p/1", "/p/2"}, false},
+		{"css:#info a:last-child::attr( href )", []string{"/t/1"}, false},
+		// empty values are skipped
+		{"css:a.tag", nil, false},
+		{"css:a[", nil, true},
+		// xpath still works
+		{`//h1[@class="title"]`, []string{"Scene Title"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			got, err := q.runQuery(tt.selector)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
 type mockGlobalConfig struct{}
 
 func (mockGlobalConfig) GetScraperUserAgent() string {
@@ -834,6 +882,34 @@ func (mockGlobalConfig) GetPythonPath() string {
 	return ""
 }
 
+func (mockGlobalConfig) GetCachePath() string {
+	return ""
+}
+
+func (mockGlobalConfig) GetScraperCacheTTL() time.Duration {
+	return 0
+}
+
+func (mockGlobalConfig) GetScraperCacheMaxSize() int64 {
+	return 0
+}
+
+func (mockGlobalConfig) GetScraperRateLimit() float64 {
+	return 0
+}
+
+func (mockGlobalConfig) GetScraperMaxConcurrency() int {
+	return 0
+}
+
+func (mockGlobalConfig) GetScraperMaxRetries() int {
+	return 0
+}
+
+func (mockGlobalConfig) GetScraperSecrets(scraperID string) map[string]string {
+	return nil
+}
+
 func TestSubScrape(t *testing.T) {
 	retHTML := `
 	<div>
@@ -0,0 +1,500 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/doug-martin/goqu/v9"
+	"github.com/doug-martin/goqu/v9/exp"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	customFieldDefinitionTable = "custom_field_definitions"
+	customFieldIDColumn        = "field_id"
+	customFieldValueColumn     = "value"
+
+	// customFieldSortPrefix is the prefix of sort values that sort by the
+	// value of the named custom field.
+	customFieldSortPrefix = "custom_fields."
+)
+
+type customFieldDefinitionRow struct {
+	ID         int                    `db:"id" goqu:"skipinsert"`
+	EntityType string                 `db:"entity_type"`
+	Name       string                 `db:"name"`
+	Type       string                 `db:"type"`
+	Options    zero.String            `db:"options"`
+	CreatedAt  models.SQLiteTimestamp `db:"created_at"`
+	UpdatedAt  models.SQLiteTimestamp `db:"updated_at"`
+}
+
+func (r *customFieldDefinitionRow) fromCustomFieldDefinition(o models.CustomFieldDefinition) error {
+	r.ID = o.ID
+	r.EntityType = o.EntityType.String()
+	r.Name = o.Name
+	r.Type = o.Type.String()
+	if len(o.Options) > 0 {
+		options, err := json.Marshal(o.Options)
+		if err != nil {
+			return fmt.Errorf("encoding options: %w", err)
+		}
+		r.Options = zero.StringFrom(string(options))
+	}
+	r.CreatedAt = models.SQLiteTimestamp{Timestamp: o.CreatedAt}
+	r.UpdatedAt = models.SQLiteTimestamp{Timestamp: o.UpdatedAt}
+
+	return nil
+}
+
+func (r *customFieldDefinitionRow) resolve() (*models.CustomFieldDefinition, error) {
+	ret := &models.CustomFieldDefinition{
+		ID:         r.ID,
+		EntityType: models.CustomFieldEntityType(r.EntityType),
+		Name:       r.Name,
+		Type:       models.CustomFieldType(r.Type),
+		CreatedAt:  r.CreatedAt.Timestamp,
+		UpdatedAt:  r.UpdatedAt.Timestamp,
+	}
+
+	if r.Options.String != "" {
+		if err := json.Unmarshal([]byte(r.Options.String), &ret.Options); err != nil {
+			return nil, fmt.Errorf("decoding options of custom field %q: %w", r.Name, err)
+		}
+	}
+
+	return ret, nil
+}
+
+type CustomFieldDefinitionStore struct {
+	tableMgr *table
+}
+
+func NewCustomFieldDefinitionStore() *CustomFieldDefinitionStore {
+	return &CustomFieldDefinitionStore{
+		tableMgr: customFieldDefinitionTableMgr,
+	}
+}
+
+func (qb *CustomFieldDefinitionStore) table() exp.IdentifierExpression {
+	return qb.tableMgr.table
+}
+
+func (qb *CustomFieldDefinitionStore) selectDataset() *goqu.SelectDataset {
+	return dialect.From(qb.table()).Select(qb.table().All())
+}
+
+func (qb *CustomFieldDefinitionStore) Create(ctx context.Context, newObject *models.CustomFieldDefinition) error {
+	var r customFieldDefinitionRow
+	if err := r.fromCustomFieldDefinition(*newObject); err != nil {
+		return err
+	}
+
+	id, err := qb.tableMgr.insertID(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	created, err := qb.Find(ctx, id)
+	if err != nil {
+		return fmt.Errorf("finding after create: %w", err)
+	}
+
+	*newObject = *created
+
+	return nil
+}
+
+func (qb *CustomFieldDefinitionStore) Update(ctx context.Context, updatedObject *models.CustomFieldDefinition) error {
+	var r customFieldDefinitionRow
+	if err := r.fromCustomFieldDefinition(*updatedObject); err != nil {
+		return err
+	}
+
+	return qb.tableMgr.updateByID(ctx, updatedObject.ID, r)
+}
+
+// Destroy removes the definition along with all values of the field.
+func (qb *CustomFieldDefinitionStore) Destroy(ctx context.Context, id int) error {
+	return qb.tableMgr.destroyExisting(ctx, []int{id})
+}
+
+func (qb *CustomFieldDefinitionStore) Find(ctx context.Context, id int) (*models.CustomFieldDefinition, error) {
+	q := qb.selectDataset().Where(qb.tableMgr.byID(id))
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("getting custom field definition by id %d: %w", id, err)
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *CustomFieldDefinitionStore) FindByName(ctx context.Context, entityType models.CustomFieldEntityType, name string) (*models.CustomFieldDefinition, error) {
+	q := qb.selectDataset().Where(
+		qb.table().Col("entity_type").Eq(entityType.String()),
+		qb.table().Col("name").Eq(name),
+	)
+
+	ret, err := qb.getMany(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("getting custom field definition by name %q: %w", name, err)
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return ret[0], nil
+}
+
+func (qb *CustomFieldDefinitionStore) FindByEntityType(ctx context.Context, entityType models.CustomFieldEntityType) ([]*models.CustomFieldDefinition, error) {
+	q := qb.selectDataset().Where(
+		qb.table().Col("entity_type").Eq(entityType.String()),
+	).Order(qb.table().Col("name").Asc())
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *CustomFieldDefinitionStore) All(ctx context.Context) ([]*models.CustomFieldDefinition, error) {
+	q := qb.selectDataset().Order(
+		qb.table().Col("entity_type").Asc(),
+		qb.table().Col("name").Asc(),
+	)
+
+	return qb.getMany(ctx, q)
+}
+
+func (qb *CustomFieldDefinitionStore) getMany(ctx context.Context, q *goqu.SelectDataset) ([]*models.CustomFieldDefinition, error) {
+	const single = false
+	var ret []*models.CustomFieldDefinition
+	if err := queryFunc(ctx, q, single, func(r *sqlx.Rows) error {
+		var f customFieldDefinitionRow
+		if err := r.StructScan(&f); err != nil {
+			return err
+		}
+
+		d, err := f.resolve()
+		if err != nil {
+			return err
+		}
+
+		ret = append(ret, d)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// customFieldsTable is the key/value table holding the custom field values of
+// an entity type.
+type customFieldsTable struct {
+	table
+	entityType models.CustomFieldEntityType
+}
+
+func (t *customFieldsTable) fieldIDColumn() exp.IdentifierExpression {
+	return t.table.table.Col(customFieldIDColumn)
+}
+
+func (t *customFieldsTable) valueColumn() exp.IdentifierExpression {
+	return t.table.table.Col(customFieldValueColumn)
+}
+
+func (t *customFieldsTable) definitions(ctx context.Context) ([]*models.CustomFieldDefinition, error) {
+	return NewCustomFieldDefinitionStore().FindByEntityType(ctx, t.entityType)
+}
+
+func (t *customFieldsTable) get(ctx context.Context, id int) (models.CustomFieldMap, error) {
+	defs, err := t.definitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[int]*models.CustomFieldDefinition)
+	for _, d := range defs {
+		byID[d.ID] = d
+	}
+
+	q := dialect.Select(t.fieldIDColumn(), t.valueColumn()).From(t.table.table).Where(t.idColumn.Eq(id))
+
+	const single = false
+	ret := make(models.CustomFieldMap)
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var fieldID int
+		var value interface{}
+		if err := rows.Scan(&fieldID, &value); err != nil {
+			return err
+		}
+
+		d := byID[fieldID]
+		if d == nil {
+			return nil
+		}
+
+		ret[d.Name] = resolveCustomFieldValue(d.Type, value)
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting custom fields from %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return ret, nil
+}
+
+// resolveCustomFieldValue converts a value read from the database into the
+// representation of the field type.
+func resolveCustomFieldValue(fieldType models.CustomFieldType, v interface{}) interface{} {
+	switch vv := v.(type) {
+	case []byte:
+		return string(vv)
+	case int64:
+		switch fieldType {
+		case models.CustomFieldTypeBool:
+			return vv != 0
+		case models.CustomFieldTypeFloat:
+			return float64(vv)
+		}
+	}
+
+	return v
+}
+
+func (t *customFieldsTable) set(ctx context.Context, id int, input models.CustomFieldsInput) error {
+	defs, err := t.definitions(ctx)
+	if err != nil {
+		return err
+	}
+
+	byName := make(map[string]*models.CustomFieldDefinition)
+	for _, d := range defs {
+		byName[d.Name] = d
+	}
+
+	if input.Full != nil {
+		if err := t.destroy(ctx, []int{id}); err != nil {
+			return err
+		}
+	}
+
+	for _, values := range []models.CustomFieldMap{input.Full, input.Partial} {
+		for name, v := range values {
+			d := byName[name]
+			if d == nil {
+				return fmt.Errorf("custom field %q is not defined for %s", name, strings.ToLower(t.entityType.String()))
+			}
+
+			if v == nil {
+				if err := t.destroyValue(ctx, id, d.ID); err != nil {
+					return err
+				}
+				continue
+			}
+
+			converted, err := d.ConvertValue(v)
+			if err != nil {
+				return err
+			}
+
+			if err := t.setValue(ctx, id, d.ID, converted); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *customFieldsTable) setValue(ctx context.Context, id int, fieldID int, v interface{}) error {
+	idCol, _ := t.idColumn.GetCol().(string)
+
+	// prepared so that the value is bound with its type
+	q := dialect.Insert(t.table.table).Prepared(true).Rows(goqu.Record{
+		idCol:                  id,
+		customFieldIDColumn:    fieldID,
+		customFieldValueColumn: v,
+	}).OnConflict(goqu.DoUpdate(
+		idCol+", "+customFieldIDColumn,
+		goqu.Record{customFieldValueColumn: v},
+	))
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting custom field in %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return nil
+}
+
+func (t *customFieldsTable) destroyValue(ctx context.Context, id int, fieldID int) error {
+	q := dialect.Delete(t.table.table).Where(
+		t.idColumn.Eq(id),
+		t.fieldIDColumn().Eq(fieldID),
+	)
+
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying custom field in %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return nil
+}
+
+// criterionHandler returns a handler filtering on the provided criteria,
+// using the provided primary key column of the parent table.
+func (t *customFieldsTable) criterionHandler(criteria []models.CustomFieldCriterionInput, parentIDCol string) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		for _, c := range criteria {
+			clause, err := t.criterionClause(ctx, c, parentIDCol)
+			if err != nil {
+				f.setError(err)
+				return
+			}
+
+			f.addWhere(clause.sql, clause.args...)
+		}
+	}
+}
+
+func (t *customFieldsTable) criterionClause(ctx context.Context, c models.CustomFieldCriterionInput, parentIDCol string) (*sqlClause, error) {
+	d, err := NewCustomFieldDefinitionStore().FindByName(ctx, t.entityType, c.Field)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, fmt.Errorf("custom field %q is not defined for %s", c.Field, strings.ToLower(t.entityType.String()))
+	}
+
+	tableName := t.table.table.GetTable()
+	valueCol := tableName + "." + customFieldValueColumn
+
+	exists := func(condition string, args ...interface{}) sqlClause {
+		return makeClause(fmt.Sprintf("EXISTS (SELECT 1 FROM %[1]s WHERE %[1]s.%[2]s = %[3]s AND %[1]s.%[4]s = %[5]d%[6]s)",
+			tableName, t.idColumn.GetCol(), parentIDCol, customFieldIDColumn, d.ID, condition), args...)
+	}
+
+	requireValues := func(n int) error {
+		if len(c.Value) != n {
+			return fmt.Errorf("custom field criterion with modifier %s requires %d value(s)", c.Modifier, n)
+		}
+		return nil
+	}
+
+	// values compared as strings are not converted to the field type
+	var values []interface{}
+	switch c.Modifier {
+	case models.CriterionModifierMatchesRegex, models.CriterionModifierNotMatchesRegex:
+		if err := requireValues(1); err != nil {
+			return nil, err
+		}
+		s, ok := c.Value[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("regex value must be a string")
+		}
+		if _, err := regexp.Compile(s); err != nil {
+			return nil, err
+		}
+		values = []interface{}{s}
+	case models.CriterionModifierIncludes, models.CriterionModifierExcludes:
+		if d.Type == models.CustomFieldTypeString {
+			for _, v := range c.Value {
+				values = append(values, "%"+fmt.Sprint(v)+"%")
+			}
+			break
+		}
+		fallthrough
+	default:
+		for _, v := range c.Value {
+			converted, err := d.ConvertValue(v)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, converted)
+		}
+	}
+
+	var ret sqlClause
+	switch c.Modifier {
+	case models.CriterionModifierIsNull:
+		ret = exists("").not()
+	case models.CriterionModifierNotNull:
+		ret = exists("")
+	case models.CriterionModifierEquals, models.CriterionModifierNotEquals:
+		if err := requireValues(1); err != nil {
+			return nil, err
+		}
+		ret = exists(" AND "+valueCol+" = ?", values...)
+		if c.Modifier == models.CriterionModifierNotEquals {
+			ret = ret.not()
+		}
+	case models.CriterionModifierGreaterThan:
+		if err := requireValues(1); err != nil {
+			return nil, err
+		}
+		ret = exists(" AND "+valueCol+" > ?", values...)
+	case models.CriterionModifierLessThan:
+		if err := requireValues(1); err != nil {
+			return nil, err
+		}
+		ret = exists(" AND "+valueCol+" < ?", values...)
+	case models.CriterionModifierBetween, models.CriterionModifierNotBetween:
+		if err := requireValues(2); err != nil {
+			return nil, err
+		}
+		ret = exists(" AND "+valueCol+" BETWEEN ? AND ?", values...)
+		if c.Modifier == models.CriterionModifierNotBetween {
+			ret = andClauses(exists(""), ret.not())
+		}
+	case models.CriterionModifierIncludes, models.CriterionModifierExcludes:
+		if len(values) == 0 {
+			return nil, fmt.Errorf("custom field criterion with modifier %s requires at least one value", c.Modifier)
+		}
+		var conditions []string
+		for range values {
+			if d.Type == models.CustomFieldTypeString {
+				conditions = append(conditions, valueCol+" LIKE ?")
+			} else {
+				conditions = append(conditions, valueCol+" = ?")
+			}
+		}
+		ret = exists(" AND ("+strings.Join(conditions, " OR ")+")", values...)
+		if c.Modifier == models.CriterionModifierExcludes {
+			ret = ret.not()
+		}
+	case models.CriterionModifierMatchesRegex:
+		ret = exists(" AND "+valueCol+" regexp ?", values...)
+	case models.CriterionModifierNotMatchesRegex:
+		ret = exists(" AND "+valueCol+" regexp ?", values...).not()
+	default:
+		return nil, fmt.Errorf("unsupported custom field modifier %s", c.Modifier)
+	}
+
+	return &ret, nil
+}
+
+// getSort returns the order by clause sorting by the value of a custom field,
+// if sort refers to a custom field.
+func (t *customFieldsTable) getSort(sort string, primaryTable string, direction string) (string, bool) {
+	if !strings.HasPrefix(sort, customFieldSortPrefix) {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(sort, customFieldSortPrefix)
+	tableName := t.table.table.GetTable()
+
+	// the name is not bound as a parameter, so it must be quoted
+	quotedName := "'" + strings.ReplaceAll(name, "'", "''") + "'"
+
+	return fmt.Sprintf(" ORDER BY (SELECT %[1]s.%[2]s FROM %[1]s INNER JOIN %[3]s ON %[3]s.id = %[1]s.%[4]s WHERE %[1]s.%[5]s = %[6]s.id AND %[3]s.name = %[7]s AND %[3]s.entity_type = '%[8]s') %[9]s, %[6]s.id %[9]s",
+		tableName, customFieldValueColumn, customFieldDefinitionTable, customFieldIDColumn, t.idColumn.GetCol(), primaryTable, quotedName, t.entityType, getSortDirection(direction)), true
+}
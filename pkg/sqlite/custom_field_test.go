@@ -0,0 +1,284 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stashapp/stash/pkg/hash/md5"
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func createCustomFieldDefinition(ctx context.Context, name string, fieldType models.CustomFieldType, options ...string) (*models.CustomFieldDefinition, error) {
+	d := &models.CustomFieldDefinition{
+		EntityType: models.CustomFieldEntityTypePerformer,
+		Name:       name,
+		Type:       fieldType,
+		Options:    options,
+	}
+
+	if err := db.CustomFieldDefinition.Create(ctx, d); err != nil {
+		return nil, fmt.Errorf("creating custom field %s: %w", name, err)
+	}
+
+	return d, nil
+}
+
+func TestCustomFieldDefinitionCreate(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.CustomFieldDefinition
+
+		d, err := createCustomFieldDefinition(ctx, "license", models.CustomFieldTypeEnum, "cc-by", "cc0")
+		if err != nil {
+			return err
+		}
+
+		found, err := qb.FindByName(ctx, models.CustomFieldEntityTypePerformer, "license")
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, d.ID, found.ID)
+		assert.Equal(t, []string{"cc-by", "cc0"}, found.Options)
+
+		// names are unique per entity type
+		_, err = createCustomFieldDefinition(ctx, "license", models.CustomFieldTypeString)
+		assert.NotNil(t, err)
+
+		other := &models.CustomFieldDefinition{
+			EntityType: models.CustomFieldEntityTypeScene,
+			Name:       "license",
+			Type:       models.CustomFieldTypeString,
+		}
+		assert.Nil(t, qb.Create(ctx, other))
+
+		found, err = qb.FindByName(ctx, models.CustomFieldEntityTypeGallery, "license")
+		if err != nil {
+			return err
+		}
+		assert.Nil(t, found)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestPerformerCustomFields(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		if _, err := createCustomFieldDefinition(ctx, "score", models.CustomFieldTypeInt); err != nil {
+			return err
+		}
+		if _, err := createCustomFieldDefinition(ctx, "weight", models.CustomFieldTypeFloat); err != nil {
+			return err
+		}
+		if _, err := createCustomFieldDefinition(ctx, "reviewed", models.CustomFieldTypeBool); err != nil {
+			return err
+		}
+		if _, err := createCustomFieldDefinition(ctx, "reviewed_at", models.CustomFieldTypeDate); err != nil {
+			return err
+		}
+		reviewer, err := createCustomFieldDefinition(ctx, "reviewer", models.CustomFieldTypeString)
+		if err != nil {
+			return err
+		}
+
+		performer := models.Performer{
+			Name:     "TestCustomFields",
+			Checksum: md5.FromString("TestCustomFields"),
+		}
+		if err := qb.Create(ctx, &performer); err != nil {
+			return fmt.Errorf("creating performer: %w", err)
+		}
+
+		if err := qb.SetCustomFields(ctx, performer.ID, models.CustomFieldsInput{
+			Full: models.CustomFieldMap{
+				"score":       float64(3),
+				"weight":      "1.5",
+				"reviewed":    true,
+				"reviewed_at": "2022-01-02",
+				"reviewer":    "reviewer",
+			},
+		}); err != nil {
+			return fmt.Errorf("setting custom fields: %w", err)
+		}
+
+		got, err := qb.GetCustomFields(ctx, performer.ID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, models.CustomFieldMap{
+			"score":       int64(3),
+			"weight":      1.5,
+			"reviewed":    true,
+			"reviewed_at": "2022-01-02",
+			"reviewer":    "reviewer",
+		}, got)
+
+		// partial updates leave other values unchanged, and nil removes
+		if err := qb.SetCustomFields(ctx, performer.ID, models.CustomFieldsInput{
+			Partial: models.CustomFieldMap{
+				"score":    int64(4),
+				"reviewer": nil,
+			},
+		}); err != nil {
+			return fmt.Errorf("setting partial custom fields: %w", err)
+		}
+
+		got, err = qb.GetCustomFields(ctx, performer.ID)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, int64(4), got["score"])
+		assert.NotContains(t, got, "reviewer")
+		assert.Contains(t, got, "reviewed")
+
+		// invalid values and undefined fields are rejected
+		assert.NotNil(t, qb.SetCustomFields(ctx, performer.ID, models.CustomFieldsInput{
+			Partial: models.CustomFieldMap{"score": "not a number"},
+		}))
+		assert.NotNil(t, qb.SetCustomFields(ctx, performer.ID, models.CustomFieldsInput{
+			Partial: models.CustomFieldMap{"undefined": "value"},
+		}))
+
+		// destroying the definition removes its values
+		if err := qb.SetCustomFields(ctx, performer.ID, models.CustomFieldsInput{
+			Partial: models.CustomFieldMap{"reviewer": "reviewer"},
+		}); err != nil {
+			return err
+		}
+		if err := db.CustomFieldDefinition.Destroy(ctx, reviewer.ID); err != nil {
+			return err
+		}
+		got, err = qb.GetCustomFields(ctx, performer.ID)
+		if err != nil {
+			return err
+		}
+		assert.NotContains(t, got, "reviewer")
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestPerformerQueryCustomFields(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		if _, err := createCustomFieldDefinition(ctx, "score", models.CustomFieldTypeInt); err != nil {
+			return err
+		}
+		if _, err := createCustomFieldDefinition(ctx, "license", models.CustomFieldTypeEnum, "cc-by", "cc0"); err != nil {
+			return err
+		}
+
+		var ids []int
+		for i, license := range []string{"cc-by", "cc0", ""} {
+			name := fmt.Sprintf("TestQueryCustomFields%d", i)
+			performer := models.Performer{
+				Name:     name,
+				Checksum: md5.FromString(name),
+			}
+			if err := qb.Create(ctx, &performer); err != nil {
+				return fmt.Errorf("creating performer: %w", err)
+			}
+			ids = append(ids, performer.ID)
+
+			values := models.CustomFieldMap{"score": int64(10 - i)}
+			if license != "" {
+				values["license"] = license
+			}
+			if err := qb.SetCustomFields(ctx, performer.ID, models.CustomFieldsInput{Full: values}); err != nil {
+				return err
+			}
+		}
+
+		query := func(criteria ...models.CustomFieldCriterionInput) []int {
+			t.Helper()
+			performers, _, err := qb.Query(ctx, &models.PerformerFilterType{
+				CustomFields: criteria,
+			}, nil)
+			if err != nil {
+				t.Errorf("querying performers: %v", err)
+				return nil
+			}
+
+			var ret []int
+			for _, p := range performers {
+				ret = append(ret, p.ID)
+			}
+			return ret
+		}
+
+		assert.ElementsMatch(t, []int{ids[0]}, query(models.CustomFieldCriterionInput{
+			Field:    "license",
+			Modifier: models.CriterionModifierEquals,
+			Value:    []interface{}{"cc-by"},
+		}))
+		assert.ElementsMatch(t, []int{ids[2]}, query(models.CustomFieldCriterionInput{
+			Field:    "score",
+			Modifier: models.CriterionModifierNotNull,
+		}, models.CustomFieldCriterionInput{
+			Field:    "license",
+			Modifier: models.CriterionModifierIsNull,
+		}))
+		assert.ElementsMatch(t, []int{ids[0], ids[1]}, query(models.CustomFieldCriterionInput{
+			Field:    "score",
+			Modifier: models.CriterionModifierGreaterThan,
+			Value:    []interface{}{"8"},
+		}))
+		assert.ElementsMatch(t, []int{ids[1]}, query(models.CustomFieldCriterionInput{
+			Field:    "score",
+			Modifier: models.CriterionModifierGreaterThan,
+			Value:    []interface{}{float64(8)},
+		}, models.CustomFieldCriterionInput{
+			Field:    "license",
+			Modifier: models.CriterionModifierIncludes,
+			Value:    []interface{}{"cc0"},
+		}))
+
+		_, _, err := qb.Query(ctx, &models.PerformerFilterType{
+			CustomFields: []models.CustomFieldCriterionInput{
+				{
+					Field:    "undefined",
+					Modifier: models.CriterionModifierNotNull,
+				},
+			},
+		}, nil)
+		assert.NotNil(t, err)
+
+		// sort by custom field value
+		sort := "custom_fields.score"
+		direction := models.SortDirectionEnumAsc
+		performers, _, err := qb.Query(ctx, &models.PerformerFilterType{
+			CustomFields: []models.CustomFieldCriterionInput{
+				{
+					Field:    "score",
+					Modifier: models.CriterionModifierNotNull,
+				},
+			},
+		}, &models.FindFilterType{
+			Sort:      &sort,
+			Direction: &direction,
+		})
+		if err != nil {
+			return err
+		}
+
+		var sorted []int
+		for _, p := range performers {
+			sorted = append(sorted, p.ID)
+		}
+		assert.Equal(t, []int{ids[2], ids[1], ids[0]}, sorted)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
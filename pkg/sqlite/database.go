@@ -22,7 +22,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 )
 
-var appSchemaVersion uint = 40
+var appSchemaVersion uint = 49
 
 //go:embed migrations/*.sql
 var migrationsBox embed.FS
@@ -68,6 +68,8 @@ type Database struct {
 	Scene     *SceneStore
 	Performer *PerformerStore
 
+	CustomFieldDefinition *CustomFieldDefinitionStore
+
 	db     *sqlx.DB
 	dbPath string
 
@@ -87,6 +89,8 @@ func NewDatabase() *Database {
 		Image:     NewImageStore(fileStore),
 		Gallery:   NewGalleryStore(fileStore, folderStore),
 		Performer: NewPerformerStore(),
+
+		CustomFieldDefinition: NewCustomFieldDefinitionStore(),
 	}
 
 	return ret
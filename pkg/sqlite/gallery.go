@@ -21,18 +21,19 @@ import (
 const (
 	galleryTable = "galleries"
 
-	galleriesFilesTable      = "galleries_files"
-	performersGalleriesTable = "performers_galleries"
-	galleriesTagsTable       = "galleries_tags"
-	galleriesImagesTable     = "galleries_images"
-	galleriesScenesTable     = "scenes_galleries"
-	galleryIDColumn          = "gallery_id"
+	galleriesFilesTable        = "galleries_files"
+	performersGalleriesTable   = "performers_galleries"
+	galleriesTagsTable         = "galleries_tags"
+	galleriesImagesTable       = "galleries_images"
+	galleriesScenesTable       = "scenes_galleries"
+	galleriesURLsTable         = "gallery_urls"
+	galleriesCustomFieldsTable = "gallery_custom_fields"
+	galleryIDColumn            = "gallery_id"
 )
 
 type galleryRow struct {
 	ID      int               `db:"id" goqu:"skipinsert"`
 	Title   zero.String       `db:"title"`
-	URL     zero.String       `db:"url"`
 	Date    models.SQLiteDate `db:"date"`
 	Details zero.String       `db:"details"`
 	// expressed as 1-100
@@ -47,7 +48,6 @@ type galleryRow struct {
 func (r *galleryRow) fromGallery(o models.Gallery) {
 	r.ID = o.ID
 	r.Title = zero.StringFrom(o.Title)
-	r.URL = zero.StringFrom(o.URL)
 	if o.Date != nil {
 		_ = r.Date.Scan(o.Date.Time)
 	}
@@ -73,7 +73,6 @@ func (r *galleryQueryRow) resolve() *models.Gallery {
 	ret := &models.Gallery{
 		ID:            r.ID,
 		Title:         r.Title.String,
-		URL:           r.URL.String,
 		Date:          r.Date.DatePtr(),
 		Details:       r.Details.String,
 		Rating:        nullIntPtr(r.Rating),
@@ -100,7 +99,6 @@ type galleryRowRecord struct {
 
 func (r *galleryRowRecord) fromPartial(o models.GalleryPartial) {
 	r.setNullString("title", o.Title)
-	r.setNullString("url", o.URL)
 	r.setSQLiteDate("date", o.Date)
 	r.setNullString("details", o.Details)
 	r.setNullInt("rating", o.Rating)
@@ -151,6 +149,13 @@ func (qb *GalleryStore) Create(ctx context.Context, newObject *models.Gallery, f
 		}
 	}
 
+	if newObject.URLs.Loaded() {
+		const startPos = 0
+		if err := galleriesURLsTableMgr.insertJoins(ctx, id, startPos, newObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if newObject.PerformerIDs.Loaded() {
 		if err := galleriesPerformersTableMgr.insertJoins(ctx, id, newObject.PerformerIDs.List()); err != nil {
 			return err
@@ -185,6 +190,12 @@ func (qb *GalleryStore) Update(ctx context.Context, updatedObject *models.Galler
 		return err
 	}
 
+	if updatedObject.URLs.Loaded() {
+		if err := galleriesURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if updatedObject.PerformerIDs.Loaded() {
 		if err := galleriesPerformersTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.PerformerIDs.List()); err != nil {
 			return err
@@ -230,6 +241,11 @@ func (qb *GalleryStore) UpdatePartial(ctx context.Context, id int, partial model
 		}
 	}
 
+	if partial.URLs != nil {
+		if err := galleriesURLsTableMgr.modifyJoins(ctx, id, partial.URLs.Values, partial.URLs.Mode); err != nil {
+			return nil, err
+		}
+	}
 	if partial.PerformerIDs != nil {
 		if err := galleriesPerformersTableMgr.modifyJoins(ctx, id, partial.PerformerIDs.IDs, partial.PerformerIDs.Mode); err != nil {
 			return nil, err
@@ -655,7 +671,7 @@ func (qb *GalleryStore) makeFilter(ctx context.Context, galleryFilter *models.Ga
 	query.handleCriterion(ctx, intCriterionHandler(galleryFilter.Rating100, "galleries.rating", nil))
 	// legacy rating handler
 	query.handleCriterion(ctx, rating5CriterionHandler(galleryFilter.Rating, "galleries.rating", nil))
-	query.handleCriterion(ctx, stringCriterionHandler(galleryFilter.URL, "galleries.url"))
+	query.handleCriterion(ctx, galleryURLsCriterionHandler(galleryFilter.URL))
 	query.handleCriterion(ctx, boolCriterionHandler(galleryFilter.Organized, "galleries.organized", nil))
 	query.handleCriterion(ctx, galleryIsMissingCriterionHandler(qb, galleryFilter.IsMissing))
 	query.handleCriterion(ctx, galleryTagsCriterionHandler(qb, galleryFilter.Tags))
@@ -671,6 +687,7 @@ func (qb *GalleryStore) makeFilter(ctx context.Context, galleryFilter *models.Ga
 	query.handleCriterion(ctx, dateCriterionHandler(galleryFilter.Date, "galleries.date"))
 	query.handleCriterion(ctx, timestampCriterionHandler(galleryFilter.CreatedAt, "galleries.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(galleryFilter.UpdatedAt, "galleries.updated_at"))
+	query.handleCriterion(ctx, galleriesCustomFieldsTableMgr.criterionHandler(galleryFilter.CustomFields, "galleries.id"))
 
 	return query
 }
@@ -854,6 +871,18 @@ func galleryFileCountCriterionHandler(qb *GalleryStore, fileCount *models.IntCri
 	return h.handler(fileCount)
 }
 
+func galleryURLsCriterionHandler(url *models.StringCriterionInput) criterionHandlerFunc {
+	h := stringListCriterionHandlerBuilder{
+		joinTable:    galleriesURLsTable,
+		stringColumn: urlColumn,
+		addJoinTable: func(f *filterBuilder) {
+			galleriesURLsTableMgr.join(f, "", "galleries.id")
+		},
+	}
+
+	return h.handler(url)
+}
+
 func galleryIsMissingCriterionHandler(qb *GalleryStore, isMissing *string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if isMissing != nil && *isMissing != "" {
@@ -871,6 +900,9 @@ func galleryIsMissingCriterionHandler(qb *GalleryStore, isMissing *string) crite
 			case "tags":
 				qb.tagsRepository().join(f, "tags_join", "galleries.id")
 				f.addWhere("tags_join.gallery_id IS NULL")
+			case "url":
+				galleriesURLsTableMgr.join(f, "", "galleries.id")
+				f.addWhere("gallery_urls.url IS NULL")
 			default:
 				f.addWhere("(galleries." + *isMissing + " IS NULL OR TRIM(galleries." + *isMissing + ") = '')")
 			}
@@ -1111,6 +1143,10 @@ func (qb *GalleryStore) setGallerySort(query *queryBuilder, findFilter *models.F
 		addFolderTable()
 		query.sortAndPagination += " ORDER BY galleries.title COLLATE NATURAL_CS " + direction + ", folders.path " + direction + ", file_folder.path " + direction + ", files.basename COLLATE NATURAL_CS " + direction
 	default:
+		if customFieldSort, ok := galleriesCustomFieldsTableMgr.getSort(sort, galleryTable, direction); ok {
+			query.sortAndPagination += customFieldSort
+			return
+		}
 		query.sortAndPagination += getSort(sort, direction, "galleries")
 	}
 }
@@ -1158,6 +1194,10 @@ func (qb *GalleryStore) tagsRepository() *joinRepository {
 	}
 }
 
+func (qb *GalleryStore) GetURLs(ctx context.Context, galleryID int) ([]string, error) {
+	return galleriesURLsTableMgr.get(ctx, galleryID)
+}
+
 func (qb *GalleryStore) GetTagIDs(ctx context.Context, id int) ([]int, error) {
 	return qb.tagsRepository().getIDs(ctx, id)
 }
@@ -1204,3 +1244,11 @@ func (qb *GalleryStore) scenesRepository() *joinRepository {
 func (qb *GalleryStore) GetSceneIDs(ctx context.Context, id int) ([]int, error) {
 	return qb.scenesRepository().getIDs(ctx, id)
 }
+
+func (qb *GalleryStore) GetCustomFields(ctx context.Context, galleryID int) (models.CustomFieldMap, error) {
+	return galleriesCustomFieldsTableMgr.get(ctx, galleryID)
+}
+
+func (qb *GalleryStore) SetCustomFields(ctx context.Context, galleryID int, input models.CustomFieldsInput) error {
+	return galleriesCustomFieldsTableMgr.set(ctx, galleryID, input)
+}
@@ -18,6 +18,11 @@ import (
 var invalidID = -1
 
 func loadGalleryRelationships(ctx context.Context, expected models.Gallery, actual *models.Gallery) error {
+	if expected.URLs.Loaded() {
+		if err := actual.LoadURLs(ctx, db.Gallery); err != nil {
+			return err
+		}
+	}
 	if expected.SceneIDs.Loaded() {
 		if err := actual.LoadSceneIDs(ctx, db.Gallery); err != nil {
 			return err
@@ -73,7 +78,7 @@ func Test_galleryQueryBuilder_Create(t *testing.T) {
 			"full",
 			models.Gallery{
 				Title:        title,
-				URL:          url,
+				URLs:         models.NewRelatedStrings([]string{url}),
 				Date:         &date,
 				Details:      details,
 				Rating:       &rating,
@@ -91,7 +96,7 @@ func Test_galleryQueryBuilder_Create(t *testing.T) {
 			"with file",
 			models.Gallery{
 				Title:     title,
-				URL:       url,
+				URLs:      models.NewRelatedStrings([]string{url}),
 				Date:      &date,
 				Details:   details,
 				Rating:    &rating,
@@ -223,7 +228,7 @@ func Test_galleryQueryBuilder_Update(t *testing.T) {
 			&models.Gallery{
 				ID:        galleryIDs[galleryIdxWithScene],
 				Title:     title,
-				URL:       url,
+				URLs:      models.NewRelatedStrings([]string{url}),
 				Date:      &date,
 				Details:   details,
 				Rating:    &rating,
@@ -385,7 +390,7 @@ func clearGalleryPartial() models.GalleryPartial {
 	return models.GalleryPartial{
 		Title:        models.OptionalString{Set: true, Null: true},
 		Details:      models.OptionalString{Set: true, Null: true},
-		URL:          models.OptionalString{Set: true, Null: true},
+		URLs:         &models.UpdateStrings{Mode: models.RelationshipUpdateModeSet},
 		Date:         models.OptionalDate{Set: true, Null: true},
 		Rating:       models.OptionalInt{Set: true, Null: true},
 		StudioID:     models.OptionalInt{Set: true, Null: true},
@@ -419,7 +424,7 @@ func Test_galleryQueryBuilder_UpdatePartial(t *testing.T) {
 			models.GalleryPartial{
 				Title:     models.NewOptionalString(title),
 				Details:   models.NewOptionalString(details),
-				URL:       models.NewOptionalString(url),
+				URLs:      &models.UpdateStrings{Values: []string{url}, Mode: models.RelationshipUpdateModeSet},
 				Date:      models.NewOptionalDate(date),
 				Rating:    models.NewOptionalInt(rating),
 				Organized: models.NewOptionalBool(true),
@@ -444,7 +449,7 @@ func Test_galleryQueryBuilder_UpdatePartial(t *testing.T) {
 				ID:        galleryIDs[galleryIdxWithImage],
 				Title:     title,
 				Details:   details,
-				URL:       url,
+				URLs:      models.NewRelatedStrings([]string{url}),
 				Date:      &date,
 				Rating:    &rating,
 				Organized: true,
@@ -1658,7 +1663,7 @@ func TestGalleryQueryURL(t *testing.T) {
 
 	verifyFn := func(g *models.Gallery) {
 		t.Helper()
-		verifyString(t, g.URL, urlCriterion)
+		verifyURLs(t, g.URLs.List(), urlCriterion)
 	}
 
 	verifyGalleryQuery(t, filter, verifyFn)
@@ -1692,6 +1697,10 @@ func verifyGalleryQuery(t *testing.T, filter models.GalleryFilterType, verifyFn
 		assert.Greater(t, len(galleries), 0)
 
 		for _, gallery := range galleries {
+			if err := gallery.LoadURLs(ctx, sqb); err != nil {
+				t.Errorf("Error loading gallery urls: %v", err)
+			}
+
 			verifyFn(gallery)
 		}
 
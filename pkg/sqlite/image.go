@@ -20,10 +20,11 @@ import (
 var imageTable = "images"
 
 const (
-	imageIDColumn         = "image_id"
-	performersImagesTable = "performers_images"
-	imagesTagsTable       = "images_tags"
-	imagesFilesTable      = "images_files"
+	imageIDColumn           = "image_id"
+	performersImagesTable   = "performers_images"
+	imagesTagsTable         = "images_tags"
+	imagesFilesTable        = "images_files"
+	imagesCustomFieldsTable = "image_custom_fields"
 )
 
 type imageRow struct {
@@ -652,6 +653,7 @@ func (qb *ImageStore) makeFilter(ctx context.Context, imageFilter *models.ImageF
 	query.handleCriterion(ctx, imagePerformerFavoriteCriterionHandler(imageFilter.PerformerFavorite))
 	query.handleCriterion(ctx, timestampCriterionHandler(imageFilter.CreatedAt, "images.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(imageFilter.UpdatedAt, "images.updated_at"))
+	query.handleCriterion(ctx, imagesCustomFieldsTableMgr.criterionHandler(imageFilter.CustomFields, "images.id"))
 
 	return query
 }
@@ -1021,6 +1023,10 @@ func (qb *ImageStore) setImageSortAndPagination(q *queryBuilder, findFilter *mod
 			addFolderJoin()
 			sortClause = " ORDER BY images.title COLLATE NATURAL_CS " + direction + ", folders.path " + direction + ", files.basename COLLATE NATURAL_CS " + direction
 		default:
+			if customFieldSort, ok := imagesCustomFieldsTableMgr.getSort(sort, imageTable, direction); ok {
+				sortClause = customFieldSort
+				break
+			}
 			sortClause = getSort(sort, direction, "images")
 		}
 	}
@@ -1104,3 +1110,11 @@ func (qb *ImageStore) UpdateTags(ctx context.Context, imageID int, tagIDs []int)
 	// Delete the existing joins and then create new ones
 	return qb.tagsRepository().replace(ctx, imageID, tagIDs)
 }
+
+func (qb *ImageStore) GetCustomFields(ctx context.Context, imageID int) (models.CustomFieldMap, error) {
+	return imagesCustomFieldsTableMgr.get(ctx, imageID)
+}
+
+func (qb *ImageStore) SetCustomFields(ctx context.Context, imageID int, input models.CustomFieldsInput) error {
+	return imagesCustomFieldsTableMgr.set(ctx, imageID, input)
+}
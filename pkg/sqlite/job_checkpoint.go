@@ -0,0 +1,72 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const jobCheckpointTable = "job_checkpoints"
+
+type jobCheckpointQueryBuilder struct {
+	repository
+}
+
+var JobCheckpointReaderWriter = &jobCheckpointQueryBuilder{
+	repository{
+		tableName: jobCheckpointTable,
+		idColumn:  idColumn,
+	},
+}
+
+func (qb *jobCheckpointQueryBuilder) Create(ctx context.Context, newObject models.JobCheckpoint) (*models.JobCheckpoint, error) {
+	var ret models.JobCheckpoint
+	if err := qb.insertObject(ctx, newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *jobCheckpointQueryBuilder) Update(ctx context.Context, updatedObject models.JobCheckpoint) (*models.JobCheckpoint, error) {
+	const partial = false
+	if err := qb.update(ctx, updatedObject.ID, updatedObject, partial); err != nil {
+		return nil, err
+	}
+
+	var ret models.JobCheckpoint
+	if err := qb.getByID(ctx, updatedObject.ID, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *jobCheckpointQueryBuilder) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+func (qb *jobCheckpointQueryBuilder) Find(ctx context.Context, id int) (*models.JobCheckpoint, error) {
+	var ret models.JobCheckpoint
+	if err := qb.getByID(ctx, id, &ret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (qb *jobCheckpointQueryBuilder) All(ctx context.Context) ([]*models.JobCheckpoint, error) {
+	query := fmt.Sprintf("SELECT * FROM %s ORDER BY add_time ASC, id ASC", jobCheckpointTable)
+
+	var ret models.JobCheckpoints
+	if err := qb.query(ctx, query, nil, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.JobCheckpoint(ret), nil
+}
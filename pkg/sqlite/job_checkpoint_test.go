@@ -0,0 +1,91 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobCheckpointCreateUpdateDestroy(t *testing.T) {
+	now := time.Now()
+	var firstID, secondID int
+
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.JobCheckpointReaderWriter
+
+		for i, jobType := range []string{"generate", "identify"} {
+			addTime := now.Add(time.Duration(i) * time.Minute)
+			c, err := qb.Create(ctx, models.JobCheckpoint{
+				Type:        jobType,
+				Description: "Generating...",
+				Input:       sql.NullString{String: "{}", Valid: true},
+				AddTime:     models.SQLiteTimestamp{Timestamp: addTime},
+				UpdatedAt:   models.SQLiteTimestamp{Timestamp: addTime},
+			})
+			if err != nil {
+				return err
+			}
+
+			if i == 0 {
+				firstID = c.ID
+			} else {
+				secondID = c.ID
+			}
+		}
+
+		return nil
+	})
+
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.JobCheckpointReaderWriter
+
+		c, err := qb.Find(ctx, secondID)
+		if err != nil {
+			return err
+		}
+
+		c.Checkpoint = sql.NullString{String: `{"last_id":10}`, Valid: true}
+		if _, err := qb.Update(ctx, *c); err != nil {
+			return err
+		}
+
+		// ordered by add time
+		checkpoints, err := qb.All(ctx)
+		if err != nil {
+			return err
+		}
+
+		if assert.Len(t, checkpoints, 2) {
+			assert.Equal(t, firstID, checkpoints[0].ID)
+			assert.False(t, checkpoints[0].Checkpoint.Valid)
+			assert.Equal(t, "identify", checkpoints[1].Type)
+			assert.Equal(t, `{"last_id":10}`, checkpoints[1].Checkpoint.String)
+		}
+
+		return nil
+	})
+
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.JobCheckpointReaderWriter
+
+		if err := qb.Destroy(ctx, firstID); err != nil {
+			return err
+		}
+
+		c, err := qb.Find(ctx, firstID)
+		if err != nil {
+			return err
+		}
+		assert.Nil(t, c)
+
+		return qb.Destroy(ctx, secondID)
+	})
+}
@@ -0,0 +1,123 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const (
+	jobRecordTable    = "job_records"
+	jobRecordLogTable = "job_record_logs"
+	jobRecordIDColumn = "job_record_id"
+)
+
+type jobRecordQueryBuilder struct {
+	repository
+}
+
+var JobRecordReaderWriter = &jobRecordQueryBuilder{
+	repository{
+		tableName: jobRecordTable,
+		idColumn:  idColumn,
+	},
+}
+
+func (qb *jobRecordQueryBuilder) Create(ctx context.Context, newObject models.JobRecord) (*models.JobRecord, error) {
+	var ret models.JobRecord
+	if err := qb.insertObject(ctx, newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *jobRecordQueryBuilder) Find(ctx context.Context, id int) (*models.JobRecord, error) {
+	var ret models.JobRecord
+	if err := qb.getByID(ctx, id, &ret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (qb *jobRecordQueryBuilder) Query(ctx context.Context, status *string, findFilter *models.FindFilterType) ([]*models.JobRecord, int, error) {
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+
+	where := ""
+	var args []interface{}
+	if status != nil {
+		where = " WHERE status = ?"
+		args = append(args, *status)
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) as count FROM %s%s", jobRecordTable, where)
+	count, err := qb.runCountQuery(ctx, countQuery, args)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s%s", jobRecordTable, where) + qb.getJobRecordSort(findFilter) + getPagination(findFilter)
+
+	var ret models.JobRecords
+	if err := qb.query(ctx, query, args, &ret); err != nil {
+		return nil, 0, err
+	}
+
+	return []*models.JobRecord(ret), count, nil
+}
+
+func (qb *jobRecordQueryBuilder) getJobRecordSort(findFilter *models.FindFilterType) string {
+	sort := "add_time"
+	direction := "DESC"
+	if findFilter.Sort != nil {
+		switch *findFilter.Sort {
+		case "add_time", "start_time", "end_time", "description", "status":
+			sort = *findFilter.Sort
+			direction = findFilter.GetDirection()
+		}
+	}
+
+	// sort by id as a tie-breaker for jobs added at the same time
+	return getSort(sort, direction, jobRecordTable) + ", " + getColumn(jobRecordTable, "id") + " " + getSortDirection(direction)
+}
+
+func (qb *jobRecordQueryBuilder) GetLogs(ctx context.Context, id int) ([]models.JobRecordLog, error) {
+	query := fmt.Sprintf("SELECT time, level, message FROM %s WHERE %s = ? ORDER BY rowid ASC", jobRecordLogTable, jobRecordIDColumn)
+
+	var ret []models.JobRecordLog
+	if err := qb.tx.Select(ctx, &ret, query, id); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (qb *jobRecordQueryBuilder) UpdateLogs(ctx context.Context, id int, logs []models.JobRecordLog) error {
+	stmt := fmt.Sprintf("DELETE FROM %s WHERE %s = ?", jobRecordLogTable, jobRecordIDColumn)
+	if _, err := qb.tx.Exec(ctx, stmt, id); err != nil {
+		return err
+	}
+
+	stmt = fmt.Sprintf("INSERT INTO %s (%s, time, level, message) VALUES (?, ?, ?, ?)", jobRecordLogTable, jobRecordIDColumn)
+	for _, l := range logs {
+		if _, err := qb.tx.Exec(ctx, stmt, id, l.Time, l.Level, l.Message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (qb *jobRecordQueryBuilder) Prune(ctx context.Context, keep int) error {
+	stmt := fmt.Sprintf("DELETE FROM %[1]s WHERE id NOT IN (SELECT id FROM %[1]s ORDER BY add_time DESC, id DESC LIMIT ?)", jobRecordTable)
+	_, err := qb.tx.Exec(ctx, stmt, keep)
+	return err
+}
@@ -0,0 +1,125 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobRecordQueryLogsPrune(t *testing.T) {
+	const (
+		statusFinished = "FINISHED"
+		statusFailed   = "FAILED"
+	)
+
+	now := time.Now()
+	var failedID int
+
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.JobRecordReaderWriter
+
+		for i, status := range []string{statusFinished, statusFailed, statusFinished} {
+			addTime := now.Add(time.Duration(i) * time.Minute)
+			r, err := qb.Create(ctx, models.JobRecord{
+				Description: "Scanning...",
+				Status:      status,
+				Updated:     i,
+				AddTime:     models.SQLiteTimestamp{Timestamp: addTime},
+				StartTime:   models.NullSQLiteTimestamp{Timestamp: addTime, Valid: true},
+				EndTime:     models.NullSQLiteTimestamp{Timestamp: addTime.Add(time.Second), Valid: true},
+			})
+			if err != nil {
+				return err
+			}
+
+			if status == statusFailed {
+				failedID = r.ID
+			}
+		}
+
+		return qb.UpdateLogs(ctx, failedID, []models.JobRecordLog{
+			{Time: models.SQLiteTimestamp{Timestamp: now}, Level: "info", Message: "first"},
+			{Time: models.SQLiteTimestamp{Timestamp: now}, Level: "error", Message: "second"},
+		})
+	})
+
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.JobRecordReaderWriter
+
+		failed := statusFailed
+		records, count, err := qb.Query(ctx, &failed, nil)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, 1, count)
+		if assert.Len(t, records, 1) {
+			assert.Equal(t, failedID, records[0].ID)
+			assert.Equal(t, sql.NullString{}, records[0].Error)
+		}
+
+		// most recent first by default
+		page := 1
+		perPage := 2
+		records, count, err = qb.Query(ctx, nil, &models.FindFilterType{
+			Page:    &page,
+			PerPage: &perPage,
+		})
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, 3, count)
+		if assert.Len(t, records, 2) {
+			assert.Equal(t, 2, records[0].Updated)
+			assert.Equal(t, failedID, records[1].ID)
+		}
+
+		logs, err := qb.GetLogs(ctx, failedID)
+		if err != nil {
+			return err
+		}
+
+		if assert.Len(t, logs, 2) {
+			assert.Equal(t, "first", logs[0].Message)
+			assert.Equal(t, "error", logs[1].Level)
+		}
+
+		return nil
+	})
+
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.JobRecordReaderWriter
+
+		if err := qb.Prune(ctx, 1); err != nil {
+			return err
+		}
+
+		records, count, err := qb.Query(ctx, nil, nil)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, 1, count)
+		if assert.Len(t, records, 1) {
+			assert.Equal(t, 2, records[0].Updated)
+		}
+
+		// logs of pruned records are removed
+		logs, err := qb.GetLogs(ctx, failedID)
+		if err != nil {
+			return err
+		}
+		assert.Len(t, logs, 0)
+
+		return qb.Prune(ctx, 0)
+	})
+}
@@ -0,0 +1,12 @@
+CREATE TABLE `scene_pending_changes` (
+  `id` integer not null primary key autoincrement,
+  `scene_id` integer not null,
+  `source` varchar(255) not null,
+  `field` varchar(255) not null,
+  `current_value` text,
+  `proposed_value` text not null,
+  `created_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE
+);
+
+CREATE UNIQUE INDEX `index_scene_pending_changes_on_scene_id_field_unique` on `scene_pending_changes` (`scene_id`, `field`);
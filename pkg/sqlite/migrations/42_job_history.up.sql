@@ -0,0 +1,26 @@
+CREATE TABLE `job_records` (
+  `id` integer not null primary key autoincrement,
+  `description` varchar(255) not null,
+  `input` text,
+  `status` varchar(255) not null,
+  `error` text,
+  `created` integer not null default 0,
+  `updated` integer not null default 0,
+  `skipped` integer not null default 0,
+  `add_time` datetime not null,
+  `start_time` datetime,
+  `end_time` datetime
+);
+
+CREATE INDEX `index_job_records_on_add_time` on `job_records` (`add_time`);
+CREATE INDEX `index_job_records_on_status` on `job_records` (`status`);
+
+CREATE TABLE `job_record_logs` (
+  `job_record_id` integer not null,
+  `time` datetime not null,
+  `level` varchar(255) not null,
+  `message` text not null,
+  foreign key(`job_record_id`) references `job_records`(`id`) on delete CASCADE
+);
+
+CREATE INDEX `index_job_record_logs_on_job_record_id` on `job_record_logs` (`job_record_id`);
@@ -0,0 +1,9 @@
+CREATE TABLE `job_checkpoints` (
+  `id` integer not null primary key autoincrement,
+  `type` varchar(255) not null,
+  `description` varchar(255) not null,
+  `input` text,
+  `checkpoint` text,
+  `add_time` datetime not null,
+  `updated_at` datetime not null
+);
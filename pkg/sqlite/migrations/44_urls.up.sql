@@ -0,0 +1,364 @@
+-- move the url columns of scenes, galleries, movies and performers, and the
+-- twitter and instagram columns of performers, into ordered url tables
+PRAGMA foreign_keys=OFF;
+
+CREATE TABLE `scene_urls` (
+  `scene_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `position`, `url`)
+);
+
+CREATE INDEX `scene_urls_url` on `scene_urls` (`url`);
+
+INSERT INTO `scene_urls`
+  (
+    `scene_id`,
+    `position`,
+    `url`
+  )
+  SELECT
+    `id`,
+    '0',
+    `url`
+  FROM `scenes`
+  WHERE `scenes`.`url` IS NOT NULL AND `scenes`.`url` != '';
+
+CREATE TABLE `scenes_new` (
+  `id` integer not null primary key autoincrement,
+  `title` varchar(255),
+  `details` text,
+  `date` date,
+  `rating` tinyint,
+  `studio_id` integer,
+  `o_counter` tinyint not null default 0,
+  `organized` boolean not null default '0',
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  `code` text,
+  `director` text,
+  foreign key(`studio_id`) references `studios`(`id`) on delete SET NULL
+);
+
+INSERT INTO `scenes_new`
+  (
+    `id`,
+    `title`,
+    `details`,
+    `date`,
+    `rating`,
+    `studio_id`,
+    `o_counter`,
+    `organized`,
+    `created_at`,
+    `updated_at`,
+    `code`,
+    `director`
+  )
+  SELECT
+    `id`,
+    `title`,
+    `details`,
+    `date`,
+    `rating`,
+    `studio_id`,
+    `o_counter`,
+    `organized`,
+    `created_at`,
+    `updated_at`,
+    `code`,
+    `director`
+  FROM `scenes`;
+
+DROP INDEX `index_scenes_on_studio_id`;
+DROP TABLE `scenes`;
+ALTER TABLE `scenes_new` rename to `scenes`;
+
+CREATE INDEX `index_scenes_on_studio_id` on `scenes` (`studio_id`);
+
+CREATE TABLE `gallery_urls` (
+  `gallery_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE,
+  PRIMARY KEY(`gallery_id`, `position`, `url`)
+);
+
+CREATE INDEX `gallery_urls_url` on `gallery_urls` (`url`);
+
+INSERT INTO `gallery_urls`
+  (
+    `gallery_id`,
+    `position`,
+    `url`
+  )
+  SELECT
+    `id`,
+    '0',
+    `url`
+  FROM `galleries`
+  WHERE `galleries`.`url` IS NOT NULL AND `galleries`.`url` != '';
+
+CREATE TABLE `galleries_new` (
+  `id` integer not null primary key autoincrement,
+  `folder_id` integer,
+  `title` varchar(255),
+  `date` date,
+  `details` text,
+  `studio_id` integer,
+  `rating` tinyint,
+  `organized` boolean not null default '0',
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  foreign key(`studio_id`) references `studios`(`id`) on delete SET NULL,
+  foreign key(`folder_id`) references `folders`(`id`) on delete SET NULL
+);
+
+INSERT INTO `galleries_new`
+  (
+    `id`,
+    `folder_id`,
+    `title`,
+    `date`,
+    `details`,
+    `studio_id`,
+    `rating`,
+    `organized`,
+    `created_at`,
+    `updated_at`
+  )
+  SELECT
+    `id`,
+    `folder_id`,
+    `title`,
+    `date`,
+    `details`,
+    `studio_id`,
+    `rating`,
+    `organized`,
+    `created_at`,
+    `updated_at`
+  FROM `galleries`;
+
+DROP INDEX `index_galleries_on_studio_id`;
+DROP INDEX `index_galleries_on_folder_id_unique`;
+DROP TABLE `galleries`;
+ALTER TABLE `galleries_new` rename to `galleries`;
+
+CREATE INDEX `index_galleries_on_studio_id` on `galleries` (`studio_id`);
+CREATE UNIQUE INDEX `index_galleries_on_folder_id_unique` on `galleries` (`folder_id`);
+
+CREATE TABLE `movie_urls` (
+  `movie_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  foreign key(`movie_id`) references `movies`(`id`) on delete CASCADE,
+  PRIMARY KEY(`movie_id`, `position`, `url`)
+);
+
+CREATE INDEX `movie_urls_url` on `movie_urls` (`url`);
+
+INSERT INTO `movie_urls`
+  (
+    `movie_id`,
+    `position`,
+    `url`
+  )
+  SELECT
+    `id`,
+    '0',
+    `url`
+  FROM `movies`
+  WHERE `movies`.`url` IS NOT NULL AND `movies`.`url` != '';
+
+CREATE TABLE `movies_new` (
+  `id` integer not null primary key autoincrement,
+  `name` varchar(255) not null,
+  `aliases` varchar(255),
+  `duration` integer,
+  `date` date,
+  `rating` tinyint,
+  `studio_id` integer,
+  `director` varchar(255),
+  `synopsis` text,
+  `checksum` varchar(255) not null,
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  foreign key(`studio_id`) references `studios`(`id`) on delete set null
+);
+
+INSERT INTO `movies_new`
+  (
+    `id`,
+    `name`,
+    `aliases`,
+    `duration`,
+    `date`,
+    `rating`,
+    `studio_id`,
+    `director`,
+    `synopsis`,
+    `checksum`,
+    `created_at`,
+    `updated_at`
+  )
+  SELECT
+    `id`,
+    `name`,
+    `aliases`,
+    `duration`,
+    `date`,
+    `rating`,
+    `studio_id`,
+    `director`,
+    `synopsis`,
+    `checksum`,
+    `created_at`,
+    `updated_at`
+  FROM `movies`;
+
+DROP INDEX `movies_name_unique`;
+DROP INDEX `movies_checksum_unique`;
+DROP INDEX `index_movies_on_studio_id`;
+DROP TABLE `movies`;
+ALTER TABLE `movies_new` rename to `movies`;
+
+CREATE UNIQUE INDEX `movies_name_unique` on `movies` (`name`);
+CREATE UNIQUE INDEX `movies_checksum_unique` on `movies` (`checksum`);
+CREATE INDEX `index_movies_on_studio_id` on `movies` (`studio_id`);
+
+CREATE TABLE `performer_urls` (
+  `performer_id` integer NOT NULL,
+  `position` integer NOT NULL,
+  `url` varchar(255) NOT NULL,
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE,
+  PRIMARY KEY(`performer_id`, `position`, `url`)
+);
+
+CREATE INDEX `performer_urls_url` on `performer_urls` (`url`);
+
+-- twitter and instagram may be stored as handles or full urls
+INSERT INTO `performer_urls`
+  (
+    `performer_id`,
+    `position`,
+    `url`
+  )
+  SELECT `performer_id`, `position`, `url` FROM (
+    SELECT
+      `id` as `performer_id`,
+      0 as `position`,
+      `url`
+    FROM `performers`
+    WHERE `performers`.`url` IS NOT NULL AND `performers`.`url` != ''
+    UNION
+    SELECT
+      `id`,
+      1,
+      CASE
+        WHEN `twitter` LIKE 'http%' THEN `twitter`
+        ELSE 'https://twitter.com/' || `twitter`
+      END
+    FROM `performers`
+    WHERE `performers`.`twitter` IS NOT NULL AND `performers`.`twitter` != ''
+    UNION
+    SELECT
+      `id`,
+      2,
+      CASE
+        WHEN `instagram` LIKE 'http%' THEN `instagram`
+        ELSE 'https://www.instagram.com/' || `instagram`
+      END
+    FROM `performers`
+    WHERE `performers`.`instagram` IS NOT NULL AND `performers`.`instagram` != ''
+  );
+
+CREATE TABLE `performers_new` (
+  `id` integer not null primary key autoincrement,
+  `checksum` varchar(255) not null,
+  `name` varchar(255),
+  `gender` varchar(20),
+  `birthdate` date,
+  `ethnicity` varchar(255),
+  `country` varchar(255),
+  `eye_color` varchar(255),
+  `height` int,
+  `measurements` varchar(255),
+  `fake_tits` varchar(255),
+  `career_length` varchar(255),
+  `tattoos` varchar(255),
+  `piercings` varchar(255),
+  `aliases` varchar(255),
+  `favorite` boolean not null default '0',
+  `created_at` datetime not null,
+  `updated_at` datetime not null,
+  `details` text,
+  `death_date` date,
+  `hair_color` varchar(255),
+  `weight` integer,
+  `rating` tinyint,
+  `ignore_auto_tag` boolean not null default '0'
+);
+
+INSERT INTO `performers_new`
+  (
+    `id`,
+    `checksum`,
+    `name`,
+    `gender`,
+    `birthdate`,
+    `ethnicity`,
+    `country`,
+    `eye_color`,
+    `height`,
+    `measurements`,
+    `fake_tits`,
+    `career_length`,
+    `tattoos`,
+    `piercings`,
+    `aliases`,
+    `favorite`,
+    `created_at`,
+    `updated_at`,
+    `details`,
+    `death_date`,
+    `hair_color`,
+    `weight`,
+    `rating`,
+    `ignore_auto_tag`
+  )
+  SELECT
+    `id`,
+    `checksum`,
+    `name`,
+    `gender`,
+    `birthdate`,
+    `ethnicity`,
+    `country`,
+    `eye_color`,
+    `height`,
+    `measurements`,
+    `fake_tits`,
+    `career_length`,
+    `tattoos`,
+    `piercings`,
+    `aliases`,
+    `favorite`,
+    `created_at`,
+    `updated_at`,
+    `details`,
+    `death_date`,
+    `hair_color`,
+    `weight`,
+    `rating`,
+    `ignore_auto_tag`
+  FROM `performers`;
+
+DROP INDEX `performers_checksum_unique`;
+DROP INDEX `index_performers_on_name`;
+DROP TABLE `performers`;
+ALTER TABLE `performers_new` rename to `performers`;
+
+CREATE UNIQUE INDEX `performers_checksum_unique` on `performers` (`checksum`);
+CREATE INDEX `index_performers_on_name` on `performers` (`name`);
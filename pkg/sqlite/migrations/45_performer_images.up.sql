@@ -0,0 +1,31 @@
+-- allow performers to have multiple images, one of which is the primary image
+PRAGMA foreign_keys=OFF;
+
+CREATE TABLE `performers_image_new` (
+  `id` integer not null primary key autoincrement,
+  `performer_id` integer not null,
+  `position` integer not null,
+  `primary` boolean not null default '0',
+  `image` blob not null,
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE
+);
+
+INSERT INTO `performers_image_new`
+  (
+    `performer_id`,
+    `position`,
+    `primary`,
+    `image`
+  )
+  SELECT
+    `performer_id`,
+    0,
+    1,
+    `image`
+  FROM `performers_image`;
+
+DROP TABLE `performers_image`;
+ALTER TABLE `performers_image_new` rename to `performers_image`;
+
+CREATE INDEX `index_performers_image_on_performer_id` on `performers_image` (`performer_id`, `position`);
+CREATE UNIQUE INDEX `index_performers_image_primary` on `performers_image` (`performer_id`) WHERE `primary` = 1;
@@ -0,0 +1,6 @@
+-- performers are unique by name and disambiguation rather than by name
+ALTER TABLE `performers` ADD COLUMN `disambiguation` varchar(255);
+
+DROP INDEX `performers_checksum_unique`;
+CREATE INDEX `index_performers_on_checksum` on `performers` (`checksum`);
+CREATE UNIQUE INDEX `performers_name_disambiguation_unique` on `performers` (`name`, ifnull(`disambiguation`, ''));
@@ -0,0 +1,91 @@
+-- typed custom fields, defined per entity type, with the values of each
+-- entity type stored in a key/value table. The value column has no declared
+-- type so that values keep their type when compared.
+CREATE TABLE `custom_field_definitions` (
+  `id` integer not null primary key autoincrement,
+  `entity_type` varchar(255) not null,
+  `name` varchar(255) not null,
+  `type` varchar(255) not null,
+  `options` text,
+  `created_at` datetime not null,
+  `updated_at` datetime not null
+);
+
+CREATE UNIQUE INDEX `custom_field_definitions_entity_type_name_unique` on `custom_field_definitions` (`entity_type`, `name`);
+
+CREATE TABLE `scene_custom_fields` (
+  `scene_id` integer NOT NULL,
+  `field_id` integer NOT NULL,
+  `value` NOT NULL,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  foreign key(`field_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `field_id`)
+);
+
+CREATE INDEX `index_scene_custom_fields_on_field_id` on `scene_custom_fields` (`field_id`);
+
+CREATE TABLE `image_custom_fields` (
+  `image_id` integer NOT NULL,
+  `field_id` integer NOT NULL,
+  `value` NOT NULL,
+  foreign key(`image_id`) references `images`(`id`) on delete CASCADE,
+  foreign key(`field_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`image_id`, `field_id`)
+);
+
+CREATE INDEX `index_image_custom_fields_on_field_id` on `image_custom_fields` (`field_id`);
+
+CREATE TABLE `gallery_custom_fields` (
+  `gallery_id` integer NOT NULL,
+  `field_id` integer NOT NULL,
+  `value` NOT NULL,
+  foreign key(`gallery_id`) references `galleries`(`id`) on delete CASCADE,
+  foreign key(`field_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`gallery_id`, `field_id`)
+);
+
+CREATE INDEX `index_gallery_custom_fields_on_field_id` on `gallery_custom_fields` (`field_id`);
+
+CREATE TABLE `performer_custom_fields` (
+  `performer_id` integer NOT NULL,
+  `field_id` integer NOT NULL,
+  `value` NOT NULL,
+  foreign key(`performer_id`) references `performers`(`id`) on delete CASCADE,
+  foreign key(`field_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`performer_id`, `field_id`)
+);
+
+CREATE INDEX `index_performer_custom_fields_on_field_id` on `performer_custom_fields` (`field_id`);
+
+CREATE TABLE `studio_custom_fields` (
+  `studio_id` integer NOT NULL,
+  `field_id` integer NOT NULL,
+  `value` NOT NULL,
+  foreign key(`studio_id`) references `studios`(`id`) on delete CASCADE,
+  foreign key(`field_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`studio_id`, `field_id`)
+);
+
+CREATE INDEX `index_studio_custom_fields_on_field_id` on `studio_custom_fields` (`field_id`);
+
+CREATE TABLE `tag_custom_fields` (
+  `tag_id` integer NOT NULL,
+  `field_id` integer NOT NULL,
+  `value` NOT NULL,
+  foreign key(`tag_id`) references `tags`(`id`) on delete CASCADE,
+  foreign key(`field_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`tag_id`, `field_id`)
+);
+
+CREATE INDEX `index_tag_custom_fields_on_field_id` on `tag_custom_fields` (`field_id`);
+
+CREATE TABLE `movie_custom_fields` (
+  `movie_id` integer NOT NULL,
+  `field_id` integer NOT NULL,
+  `value` NOT NULL,
+  foreign key(`movie_id`) references `movies`(`id`) on delete CASCADE,
+  foreign key(`field_id`) references `custom_field_definitions`(`id`) on delete CASCADE,
+  PRIMARY KEY(`movie_id`, `field_id`)
+);
+
+CREATE INDEX `index_movie_custom_fields_on_field_id` on `movie_custom_fields` (`field_id`);
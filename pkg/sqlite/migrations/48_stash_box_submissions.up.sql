@@ -0,0 +1,10 @@
+CREATE TABLE `stash_box_submissions` (
+  `id` integer not null primary key autoincrement,
+  `endpoint` varchar(255) not null,
+  `entity_type` varchar(255) not null,
+  `entity_id` integer not null,
+  `type` varchar(255) not null,
+  `remote_id` varchar(255) not null,
+  `created_at` datetime not null
+);
+CREATE INDEX `index_stash_box_submissions_on_entity` on `stash_box_submissions` (`entity_type`, `entity_id`);
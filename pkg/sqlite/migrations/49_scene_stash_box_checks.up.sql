@@ -0,0 +1,12 @@
+CREATE TABLE `scene_stash_box_checks` (
+  `scene_id` integer not null,
+  `endpoint` varchar(255) not null,
+  `stash_id` varchar(255) not null,
+  `status` varchar(255) not null,
+  `unlinked_fingerprints` text,
+  `merged_into` varchar(255),
+  `checked_at` datetime not null,
+  foreign key(`scene_id`) references `scenes`(`id`) on delete CASCADE,
+  PRIMARY KEY(`scene_id`, `endpoint`)
+);
+CREATE INDEX `index_scene_stash_box_checks_on_status` on `scene_stash_box_checks` (`status`);
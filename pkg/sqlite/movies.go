@@ -14,6 +14,8 @@ import (
 
 const movieTable = "movies"
 const movieIDColumn = "movie_id"
+const moviesURLsTable = "movie_urls"
+const moviesCustomFieldsTable = "movie_custom_fields"
 
 type movieQueryBuilder struct {
 	repository
@@ -152,12 +154,13 @@ func (qb *movieQueryBuilder) makeFilter(ctx context.Context, movieFilter *models
 	query.handleCriterion(ctx, rating5CriterionHandler(movieFilter.Rating, "movies.rating", nil))
 	query.handleCriterion(ctx, durationCriterionHandler(movieFilter.Duration, "movies.duration", nil))
 	query.handleCriterion(ctx, movieIsMissingCriterionHandler(qb, movieFilter.IsMissing))
-	query.handleCriterion(ctx, stringCriterionHandler(movieFilter.URL, "movies.url"))
+	query.handleCriterion(ctx, movieURLsCriterionHandler(movieFilter.URL))
 	query.handleCriterion(ctx, movieStudioCriterionHandler(qb, movieFilter.Studios))
 	query.handleCriterion(ctx, moviePerformersCriterionHandler(qb, movieFilter.Performers))
 	query.handleCriterion(ctx, dateCriterionHandler(movieFilter.Date, "movies.date"))
 	query.handleCriterion(ctx, timestampCriterionHandler(movieFilter.CreatedAt, "movies.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(movieFilter.UpdatedAt, "movies.updated_at"))
+	query.handleCriterion(ctx, moviesCustomFieldsTableMgr.criterionHandler(movieFilter.CustomFields, "movies.id"))
 
 	return query
 }
@@ -196,6 +199,18 @@ func (qb *movieQueryBuilder) Query(ctx context.Context, movieFilter *models.Movi
 	return movies, countResult, nil
 }
 
+func movieURLsCriterionHandler(url *models.StringCriterionInput) criterionHandlerFunc {
+	h := stringListCriterionHandlerBuilder{
+		joinTable:    moviesURLsTable,
+		stringColumn: urlColumn,
+		addJoinTable: func(f *filterBuilder) {
+			moviesURLsTableMgr.join(f, "", "movies.id")
+		},
+	}
+
+	return h.handler(url)
+}
+
 func movieIsMissingCriterionHandler(qb *movieQueryBuilder, isMissing *string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if isMissing != nil && *isMissing != "" {
@@ -209,6 +224,9 @@ func movieIsMissingCriterionHandler(qb *movieQueryBuilder, isMissing *string) cr
 			case "scenes":
 				f.addLeftJoin("movies_scenes", "", "movies_scenes.movie_id = movies.id")
 				f.addWhere("movies_scenes.scene_id IS NULL")
+			case "url":
+				moviesURLsTableMgr.join(f, "", "movies.id")
+				f.addWhere("movie_urls.url IS NULL")
 			default:
 				f.addWhere("(movies." + *isMissing + " IS NULL OR TRIM(movies." + *isMissing + ") = '')")
 			}
@@ -293,6 +311,9 @@ func (qb *movieQueryBuilder) getMovieSort(findFilter *models.FindFilterType) str
 	case "scenes_count": // generic getSort won't work for this
 		return getCountSort(movieTable, moviesScenesTable, movieIDColumn, direction)
 	default:
+		if customFieldSort, ok := moviesCustomFieldsTableMgr.getSort(sort, movieTable, direction); ok {
+			return customFieldSort
+		}
 		return getSort(sort, direction, "movies")
 	}
 }
@@ -349,6 +370,14 @@ func (qb *movieQueryBuilder) GetBackImage(ctx context.Context, movieID int) ([]b
 	return getImage(ctx, qb.tx, query, movieID)
 }
 
+func (qb *movieQueryBuilder) GetURLs(ctx context.Context, movieID int) ([]string, error) {
+	return moviesURLsTableMgr.get(ctx, movieID)
+}
+
+func (qb *movieQueryBuilder) UpdateURLs(ctx context.Context, movieID int, urls []string) error {
+	return moviesURLsTableMgr.replaceJoins(ctx, movieID, urls)
+}
+
 func (qb *movieQueryBuilder) FindByPerformerID(ctx context.Context, performerID int) ([]*models.Movie, error) {
 	query := `SELECT DISTINCT movies.*
 FROM movies
@@ -387,3 +416,11 @@ WHERE movies.studio_id = ?
 	args := []interface{}{studioID}
 	return qb.runCountQuery(ctx, query, args)
 }
+
+func (qb *movieQueryBuilder) GetCustomFields(ctx context.Context, movieID int) (models.CustomFieldMap, error) {
+	return moviesCustomFieldsTableMgr.get(ctx, movieID)
+}
+
+func (qb *movieQueryBuilder) SetCustomFields(ctx context.Context, movieID int, input models.CustomFieldsInput) error {
+	return moviesCustomFieldsTableMgr.set(ctx, movieID, input)
+}
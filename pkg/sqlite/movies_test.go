@@ -135,29 +135,46 @@ func TestMovieQueryURL(t *testing.T) {
 		URL: &urlCriterion,
 	}
 
-	verifyFn := func(n *models.Movie) {
+	verify := func() {
 		t.Helper()
-		verifyNullString(t, n.URL, urlCriterion)
+		withTxn(func(ctx context.Context) error {
+			sqb := sqlite.MovieReaderWriter
+			movies := queryMovie(ctx, t, sqb, &filter, nil)
+
+			// assume it should find at least one
+			assert.Greater(t, len(movies), 0)
+
+			for _, m := range movies {
+				urls, err := sqb.GetURLs(ctx, m.ID)
+				if err != nil {
+					t.Errorf("Error getting movie urls: %v", err)
+				}
+
+				verifyURLs(t, urls, urlCriterion)
+			}
+
+			return nil
+		})
 	}
 
-	verifyMovieQuery(t, filter, verifyFn)
+	verify()
 
 	urlCriterion.Modifier = models.CriterionModifierNotEquals
-	verifyMovieQuery(t, filter, verifyFn)
+	verify()
 
 	urlCriterion.Modifier = models.CriterionModifierMatchesRegex
 	urlCriterion.Value = "movie_.*1_URL"
-	verifyMovieQuery(t, filter, verifyFn)
+	verify()
 
 	urlCriterion.Modifier = models.CriterionModifierNotMatchesRegex
-	verifyMovieQuery(t, filter, verifyFn)
+	verify()
 
 	urlCriterion.Modifier = models.CriterionModifierIsNull
 	urlCriterion.Value = ""
-	verifyMovieQuery(t, filter, verifyFn)
+	verify()
 
 	urlCriterion.Modifier = models.CriterionModifierNotNull
-	verifyMovieQuery(t, filter, verifyFn)
+	verify()
 }
 
 func verifyMovieQuery(t *testing.T, filter models.MovieFilterType, verifyFn func(s *models.Movie)) {
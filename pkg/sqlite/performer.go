@@ -20,30 +20,30 @@ import (
 const performerTable = "performers"
 const performerIDColumn = "performer_id"
 const performersTagsTable = "performers_tags"
-const performersImageTable = "performers_image" // performer cover image
+const performersImageTable = "performers_image" // performer image gallery
+const performersURLsTable = "performer_urls"
+const performersCustomFieldsTable = "performer_custom_fields"
 
 type performerRow struct {
-	ID           int                    `db:"id" goqu:"skipinsert"`
-	Checksum     string                 `db:"checksum"`
-	Name         zero.String            `db:"name"`
-	Gender       zero.String            `db:"gender"`
-	URL          zero.String            `db:"url"`
-	Twitter      zero.String            `db:"twitter"`
-	Instagram    zero.String            `db:"instagram"`
-	Birthdate    models.SQLiteDate      `db:"birthdate"`
-	Ethnicity    zero.String            `db:"ethnicity"`
-	Country      zero.String            `db:"country"`
-	EyeColor     zero.String            `db:"eye_color"`
-	Height       null.Int               `db:"height"`
-	Measurements zero.String            `db:"measurements"`
-	FakeTits     zero.String            `db:"fake_tits"`
-	CareerLength zero.String            `db:"career_length"`
-	Tattoos      zero.String            `db:"tattoos"`
-	Piercings    zero.String            `db:"piercings"`
-	Aliases      zero.String            `db:"aliases"`
-	Favorite     sql.NullBool           `db:"favorite"`
-	CreatedAt    models.SQLiteTimestamp `db:"created_at"`
-	UpdatedAt    models.SQLiteTimestamp `db:"updated_at"`
+	ID             int                    `db:"id" goqu:"skipinsert"`
+	Checksum       string                 `db:"checksum"`
+	Name           zero.String            `db:"name"`
+	Disambiguation zero.String            `db:"disambiguation"`
+	Gender         zero.String            `db:"gender"`
+	Birthdate      models.SQLiteDate      `db:"birthdate"`
+	Ethnicity      zero.String            `db:"ethnicity"`
+	Country        zero.String            `db:"country"`
+	EyeColor       zero.String            `db:"eye_color"`
+	Height         null.Int               `db:"height"`
+	Measurements   zero.String            `db:"measurements"`
+	FakeTits       zero.String            `db:"fake_tits"`
+	CareerLength   zero.String            `db:"career_length"`
+	Tattoos        zero.String            `db:"tattoos"`
+	Piercings      zero.String            `db:"piercings"`
+	Aliases        zero.String            `db:"aliases"`
+	Favorite       sql.NullBool           `db:"favorite"`
+	CreatedAt      models.SQLiteTimestamp `db:"created_at"`
+	UpdatedAt      models.SQLiteTimestamp `db:"updated_at"`
 	// expressed as 1-100
 	Rating        null.Int          `db:"rating"`
 	Details       zero.String       `db:"details"`
@@ -57,12 +57,10 @@ func (r *performerRow) fromPerformer(o models.Performer) {
 	r.ID = o.ID
 	r.Checksum = o.Checksum
 	r.Name = zero.StringFrom(o.Name)
+	r.Disambiguation = zero.StringFrom(o.Disambiguation)
 	if o.Gender.IsValid() {
 		r.Gender = zero.StringFrom(o.Gender.String())
 	}
-	r.URL = zero.StringFrom(o.URL)
-	r.Twitter = zero.StringFrom(o.Twitter)
-	r.Instagram = zero.StringFrom(o.Instagram)
 	if o.Birthdate != nil {
 		_ = r.Birthdate.Scan(o.Birthdate.Time)
 	}
@@ -91,27 +89,25 @@ func (r *performerRow) fromPerformer(o models.Performer) {
 
 func (r *performerRow) resolve() *models.Performer {
 	ret := &models.Performer{
-		ID:           r.ID,
-		Checksum:     r.Checksum,
-		Name:         r.Name.String,
-		Gender:       models.GenderEnum(r.Gender.String),
-		URL:          r.URL.String,
-		Twitter:      r.Twitter.String,
-		Instagram:    r.Instagram.String,
-		Birthdate:    r.Birthdate.DatePtr(),
-		Ethnicity:    r.Ethnicity.String,
-		Country:      r.Country.String,
-		EyeColor:     r.EyeColor.String,
-		Height:       nullIntPtr(r.Height),
-		Measurements: r.Measurements.String,
-		FakeTits:     r.FakeTits.String,
-		CareerLength: r.CareerLength.String,
-		Tattoos:      r.Tattoos.String,
-		Piercings:    r.Piercings.String,
-		Aliases:      r.Aliases.String,
-		Favorite:     r.Favorite.Bool,
-		CreatedAt:    r.CreatedAt.Timestamp,
-		UpdatedAt:    r.UpdatedAt.Timestamp,
+		ID:             r.ID,
+		Checksum:       r.Checksum,
+		Name:           r.Name.String,
+		Disambiguation: r.Disambiguation.String,
+		Gender:         models.GenderEnum(r.Gender.String),
+		Birthdate:      r.Birthdate.DatePtr(),
+		Ethnicity:      r.Ethnicity.String,
+		Country:        r.Country.String,
+		EyeColor:       r.EyeColor.String,
+		Height:         nullIntPtr(r.Height),
+		Measurements:   r.Measurements.String,
+		FakeTits:       r.FakeTits.String,
+		CareerLength:   r.CareerLength.String,
+		Tattoos:        r.Tattoos.String,
+		Piercings:      r.Piercings.String,
+		Aliases:        r.Aliases.String,
+		Favorite:       r.Favorite.Bool,
+		CreatedAt:      r.CreatedAt.Timestamp,
+		UpdatedAt:      r.UpdatedAt.Timestamp,
 		// expressed as 1-100
 		Rating:        nullIntPtr(r.Rating),
 		Details:       r.Details.String,
@@ -131,10 +127,8 @@ type performerRowRecord struct {
 func (r *performerRowRecord) fromPartial(o models.PerformerPartial) {
 	r.setNullString("checksum", o.Checksum)
 	r.setNullString("name", o.Name)
+	r.setNullString("disambiguation", o.Disambiguation)
 	r.setNullString("gender", o.Gender)
-	r.setNullString("url", o.URL)
-	r.setNullString("twitter", o.Twitter)
-	r.setNullString("instagram", o.Instagram)
 	r.setSQLiteDate("birthdate", o.Birthdate)
 	r.setNullString("ethnicity", o.Ethnicity)
 	r.setNullString("country", o.Country)
@@ -483,6 +477,7 @@ func (qb *PerformerStore) makeFilter(ctx context.Context, filter *models.Perform
 
 	const tableName = performerTable
 	query.handleCriterion(ctx, stringCriterionHandler(filter.Name, tableName+".name"))
+	query.handleCriterion(ctx, stringCriterionHandler(filter.Disambiguation, tableName+".disambiguation"))
 	query.handleCriterion(ctx, stringCriterionHandler(filter.Details, tableName+".details"))
 
 	query.handleCriterion(ctx, boolCriterionHandler(filter.FilterFavorites, tableName+".favorite", nil))
@@ -525,7 +520,7 @@ func (qb *PerformerStore) makeFilter(ctx context.Context, filter *models.Perform
 	// legacy rating handler
 	query.handleCriterion(ctx, rating5CriterionHandler(filter.Rating, tableName+".rating", nil))
 	query.handleCriterion(ctx, stringCriterionHandler(filter.HairColor, tableName+".hair_color"))
-	query.handleCriterion(ctx, stringCriterionHandler(filter.URL, tableName+".url"))
+	query.handleCriterion(ctx, performerURLsCriterionHandler(filter.URL))
 	query.handleCriterion(ctx, intCriterionHandler(filter.Weight, tableName+".weight", nil))
 	query.handleCriterion(ctx, criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 		if filter.StashID != nil {
@@ -549,6 +544,7 @@ func (qb *PerformerStore) makeFilter(ctx context.Context, filter *models.Perform
 	query.handleCriterion(ctx, dateCriterionHandler(filter.DeathDate, tableName+".death_date"))
 	query.handleCriterion(ctx, timestampCriterionHandler(filter.CreatedAt, tableName+".created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(filter.UpdatedAt, tableName+".updated_at"))
+	query.handleCriterion(ctx, performersCustomFieldsTableMgr.criterionHandler(filter.CustomFields, tableName+".id"))
 
 	return query
 }
@@ -565,7 +561,7 @@ func (qb *PerformerStore) Query(ctx context.Context, performerFilter *models.Per
 	distinctIDs(&query, performerTable)
 
 	if q := findFilter.Q; q != nil && *q != "" {
-		searchColumns := []string{"performers.name", "performers.aliases"}
+		searchColumns := []string{"performers.name", "performers.aliases", "performers.disambiguation"}
 		query.parseQueryString(searchColumns, *q)
 	}
 
@@ -590,6 +586,18 @@ func (qb *PerformerStore) Query(ctx context.Context, performerFilter *models.Per
 	return performers, countResult, nil
 }
 
+func performerURLsCriterionHandler(url *models.StringCriterionInput) criterionHandlerFunc {
+	h := stringListCriterionHandlerBuilder{
+		joinTable:    performersURLsTable,
+		stringColumn: urlColumn,
+		addJoinTable: func(f *filterBuilder) {
+			performersURLsTableMgr.join(f, "", "performers.id")
+		},
+	}
+
+	return h.handler(url)
+}
+
 func performerIsMissingCriterionHandler(qb *PerformerStore, isMissing *string) criterionHandlerFunc {
 	return func(ctx context.Context, f *filterBuilder) {
 		if isMissing != nil && *isMissing != "" {
@@ -603,6 +611,12 @@ func performerIsMissingCriterionHandler(qb *PerformerStore, isMissing *string) c
 			case "stash_id":
 				qb.stashIDRepository().join(f, "performer_stash_ids", "performers.id")
 				f.addWhere("performer_stash_ids.performer_id IS NULL")
+			case "url":
+				performersURLsTableMgr.join(f, "", "performers.id")
+				f.addWhere("performer_urls.url IS NULL")
+			case "twitter", "instagram":
+				// twitter and instagram are stored as urls
+				f.addWhere("NOT EXISTS (SELECT 1 FROM performer_urls WHERE performer_urls.performer_id = performers.id AND performer_urls.url LIKE ?)", "%"+*isMissing+".com/%")
 			default:
 				f.addWhere("(performers." + *isMissing + " IS NULL OR TRIM(performers." + *isMissing + ") = '')")
 			}
@@ -788,6 +802,9 @@ func (qb *PerformerStore) getPerformerSort(findFilter *models.FindFilterType) st
 	if sort == "galleries_count" {
 		return getCountSort(performerTable, performersGalleriesTable, performerIDColumn, direction)
 	}
+	if customFieldSort, ok := performersCustomFieldsTableMgr.getSort(sort, performerTable, direction); ok {
+		return customFieldSort
+	}
 
 	return getSort(sort, direction, "performers")
 }
@@ -812,27 +829,283 @@ func (qb *PerformerStore) UpdateTags(ctx context.Context, id int, tagIDs []int)
 	return qb.tagsRepository().replace(ctx, id, tagIDs)
 }
 
-func (qb *PerformerStore) imageRepository() *imageRepository {
-	return &imageRepository{
-		repository: repository{
-			tx:        qb.tx,
-			tableName: "performers_image",
-			idColumn:  performerIDColumn,
-		},
-		imageColumn: "image",
+func (qb *PerformerStore) imagesTable() exp.IdentifierExpression {
+	return performersImageJoinTable
+}
+
+func (qb *PerformerStore) getImageData(ctx context.Context, where ...exp.Expression) ([]byte, error) {
+	table := qb.imagesTable()
+	q := dialect.From(table).Select(table.Col("image")).Where(where...)
+
+	var ret []byte
+	if err := querySimple(ctx, q, &ret); err != nil {
+		return nil, err
 	}
+
+	return ret, nil
 }
 
+// GetImage returns the primary image of the performer.
 func (qb *PerformerStore) GetImage(ctx context.Context, performerID int) ([]byte, error) {
-	return qb.imageRepository().get(ctx, performerID)
+	table := qb.imagesTable()
+	return qb.getImageData(ctx, table.Col(performerIDColumn).Eq(performerID), table.Col("primary").Eq(true))
+}
+
+// GetImageByID returns the data of an image in the performer's image gallery.
+func (qb *PerformerStore) GetImageByID(ctx context.Context, performerID int, imageID int) ([]byte, error) {
+	table := qb.imagesTable()
+	return qb.getImageData(ctx, table.Col(performerIDColumn).Eq(performerID), table.Col(idColumn).Eq(imageID))
+}
+
+// GetImages returns the images in the performer's image gallery, ordered by
+// position.
+func (qb *PerformerStore) GetImages(ctx context.Context, performerID int) ([]*models.PerformerImage, error) {
+	table := qb.imagesTable()
+	q := dialect.From(table).Select(
+		table.Col(idColumn),
+		table.Col(performerIDColumn),
+		table.Col("position"),
+		table.Col("primary"),
+	).Where(table.Col(performerIDColumn).Eq(performerID)).Order(table.Col("position").Asc(), table.Col(idColumn).Asc())
+
+	const single = false
+	var ret []*models.PerformerImage
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var i models.PerformerImage
+		if err := rows.StructScan(&i); err != nil {
+			return err
+		}
+
+		ret = append(ret, &i)
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting images for performer %d: %w", performerID, err)
+	}
+
+	return ret, nil
+}
+
+func (qb *PerformerStore) findImage(images []*models.PerformerImage, imageID int) *models.PerformerImage {
+	for _, i := range images {
+		if i.ID == imageID {
+			return i
+		}
+	}
+
+	return nil
 }
 
+func (qb *PerformerStore) primaryImage(images []*models.PerformerImage) *models.PerformerImage {
+	for _, i := range images {
+		if i.Primary {
+			return i
+		}
+	}
+
+	return nil
+}
+
+func (qb *PerformerStore) setPrimaryImage(ctx context.Context, performerID int, imageID int) error {
+	table := qb.imagesTable()
+
+	// clear the existing primary image first to satisfy the unique index
+	q := dialect.Update(table).Set(goqu.Record{"primary": false}).Where(
+		table.Col(performerIDColumn).Eq(performerID),
+		table.Col(idColumn).Neq(imageID),
+	)
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("clearing primary image of performer %d: %w", performerID, err)
+	}
+
+	q = dialect.Update(table).Set(goqu.Record{"primary": true}).Where(table.Col(idColumn).Eq(imageID))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("setting primary image of performer %d: %w", performerID, err)
+	}
+
+	return nil
+}
+
+// setImagePositions sets the positions of the provided images to match their
+// order.
+func (qb *PerformerStore) setImagePositions(ctx context.Context, imageIDs []int) error {
+	table := qb.imagesTable()
+	for i, id := range imageIDs {
+		q := dialect.Update(table).Set(goqu.Record{"position": i}).Where(table.Col(idColumn).Eq(id))
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("setting position of performer image %d: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// AddImage appends an image to the performer's image gallery and returns its
+// ID. If the performer already has an identical image, then the ID of the
+// existing image is returned instead. The image is made the primary image if
+// primary is true or if the performer has no other images.
+func (qb *PerformerStore) AddImage(ctx context.Context, performerID int, image []byte, primary bool) (int, error) {
+	table := qb.imagesTable()
+
+	var existingID int
+	// image data must be passed as a parameter rather than interpolated
+	q := dialect.From(table).Prepared(true).Select(table.Col(idColumn)).Where(
+		table.Col(performerIDColumn).Eq(performerID),
+		table.Col("image").Eq(image),
+	)
+	if err := querySimple(ctx, q, &existingID); err != nil {
+		return 0, fmt.Errorf("finding existing performer image: %w", err)
+	}
+
+	if existingID != 0 {
+		if primary {
+			if err := qb.setPrimaryImage(ctx, performerID, existingID); err != nil {
+				return 0, err
+			}
+		}
+		return existingID, nil
+	}
+
+	images, err := qb.GetImages(ctx, performerID)
+	if err != nil {
+		return 0, err
+	}
+
+	i := dialect.Insert(table).Prepared(true).Rows(goqu.Record{
+		performerIDColumn: performerID,
+		"position":        len(images),
+		"primary":         false,
+		"image":           image,
+	})
+	result, err := exec(ctx, i)
+	if err != nil {
+		return 0, fmt.Errorf("inserting performer image: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("getting new performer image id: %w", err)
+	}
+
+	if primary || qb.primaryImage(images) == nil {
+		if err := qb.setPrimaryImage(ctx, performerID, int(id)); err != nil {
+			return 0, err
+		}
+	}
+
+	return int(id), nil
+}
+
+// UpdateImage replaces the primary image of the performer. The image is added
+// as the primary image if the performer has no images.
 func (qb *PerformerStore) UpdateImage(ctx context.Context, performerID int, image []byte) error {
-	return qb.imageRepository().replace(ctx, performerID, image)
+	images, err := qb.GetImages(ctx, performerID)
+	if err != nil {
+		return err
+	}
+
+	p := qb.primaryImage(images)
+	if p == nil {
+		_, err := qb.AddImage(ctx, performerID, image, true)
+		return err
+	}
+
+	table := qb.imagesTable()
+	q := dialect.Update(table).Prepared(true).Set(goqu.Record{"image": image}).Where(table.Col(idColumn).Eq(p.ID))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("updating primary image of performer %d: %w", performerID, err)
+	}
+
+	return nil
 }
 
+// DestroyImage removes the primary image of the performer. The first of the
+// remaining images, if any, becomes the primary image.
 func (qb *PerformerStore) DestroyImage(ctx context.Context, performerID int) error {
-	return qb.imageRepository().destroy(ctx, []int{performerID})
+	images, err := qb.GetImages(ctx, performerID)
+	if err != nil {
+		return err
+	}
+
+	p := qb.primaryImage(images)
+	if p == nil {
+		return nil
+	}
+
+	return qb.DestroyImageByID(ctx, performerID, p.ID)
+}
+
+// DestroyImageByID removes an image from the performer's image gallery. If the
+// image was the primary image, then the first of the remaining images becomes
+// the primary image.
+func (qb *PerformerStore) DestroyImageByID(ctx context.Context, performerID int, imageID int) error {
+	images, err := qb.GetImages(ctx, performerID)
+	if err != nil {
+		return err
+	}
+
+	image := qb.findImage(images, imageID)
+	if image == nil {
+		return fmt.Errorf("image %d for performer %d: %w", imageID, performerID, models.ErrNotFound)
+	}
+
+	table := qb.imagesTable()
+	q := dialect.Delete(table).Where(table.Col(idColumn).Eq(imageID))
+	if _, err := exec(ctx, q); err != nil {
+		return fmt.Errorf("destroying performer image %d: %w", imageID, err)
+	}
+
+	var remaining []int
+	for _, i := range images {
+		if i.ID != imageID {
+			remaining = append(remaining, i.ID)
+		}
+	}
+
+	if err := qb.setImagePositions(ctx, remaining); err != nil {
+		return err
+	}
+
+	if image.Primary && len(remaining) > 0 {
+		return qb.setPrimaryImage(ctx, performerID, remaining[0])
+	}
+
+	return nil
+}
+
+// ReorderImages sets the order of the performer's images. imageIDs must
+// contain each of the performer's images exactly once.
+func (qb *PerformerStore) ReorderImages(ctx context.Context, performerID int, imageIDs []int) error {
+	images, err := qb.GetImages(ctx, performerID)
+	if err != nil {
+		return err
+	}
+
+	if len(imageIDs) != len(images) || len(intslice.IntAppendUniques(nil, imageIDs)) != len(imageIDs) {
+		return fmt.Errorf("expected %d unique image ids for performer %d, got %d", len(images), performerID, len(imageIDs))
+	}
+
+	for _, id := range imageIDs {
+		if qb.findImage(images, id) == nil {
+			return fmt.Errorf("image %d for performer %d: %w", id, performerID, models.ErrNotFound)
+		}
+	}
+
+	return qb.setImagePositions(ctx, imageIDs)
+}
+
+// SetPrimaryImage makes an image of the performer's image gallery the primary
+// image.
+func (qb *PerformerStore) SetPrimaryImage(ctx context.Context, performerID int, imageID int) error {
+	images, err := qb.GetImages(ctx, performerID)
+	if err != nil {
+		return err
+	}
+
+	if qb.findImage(images, imageID) == nil {
+		return fmt.Errorf("image %d for performer %d: %w", imageID, performerID, models.ErrNotFound)
+	}
+
+	return qb.setPrimaryImage(ctx, performerID, imageID)
 }
 
 func (qb *PerformerStore) stashIDRepository() *stashIDRepository {
@@ -853,6 +1126,14 @@ func (qb *PerformerStore) UpdateStashIDs(ctx context.Context, performerID int, s
 	return qb.stashIDRepository().replace(ctx, performerID, stashIDs)
 }
 
+func (qb *PerformerStore) GetURLs(ctx context.Context, performerID int) ([]string, error) {
+	return performersURLsTableMgr.get(ctx, performerID)
+}
+
+func (qb *PerformerStore) UpdateURLs(ctx context.Context, performerID int, urls []string) error {
+	return performersURLsTableMgr.replaceJoins(ctx, performerID, urls)
+}
+
 func (qb *PerformerStore) FindByStashID(ctx context.Context, stashID models.StashID) ([]*models.Performer, error) {
 	sq := dialect.From(performersStashIDsJoinTable).Select(performersStashIDsJoinTable.Col(performerIDColumn)).Where(
 		performersStashIDsJoinTable.Col("stash_id").Eq(stashID.StashID),
@@ -893,3 +1174,11 @@ func (qb *PerformerStore) FindByStashIDStatus(ctx context.Context, hasStashID bo
 
 	return ret, nil
 }
+
+func (qb *PerformerStore) GetCustomFields(ctx context.Context, performerID int) (models.CustomFieldMap, error) {
+	return performersCustomFieldsTableMgr.get(ctx, performerID)
+}
+
+func (qb *PerformerStore) SetCustomFields(ctx context.Context, performerID int, input models.CustomFieldsInput) error {
+	return performersCustomFieldsTableMgr.set(ctx, performerID, input)
+}
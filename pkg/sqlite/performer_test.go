@@ -5,6 +5,7 @@ package sqlite_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -23,9 +24,6 @@ func Test_PerformerStore_Update(t *testing.T) {
 		gender        = models.GenderEnumFemale
 		checksum      = "checksum"
 		details       = "details"
-		url           = "url"
-		twitter       = "twitter"
-		instagram     = "instagram"
 		rating        = 3
 		ethnicity     = "ethnicity"
 		country       = "country"
@@ -60,9 +58,6 @@ func Test_PerformerStore_Update(t *testing.T) {
 				Name:          name,
 				Checksum:      checksum,
 				Gender:        gender,
-				URL:           url,
-				Twitter:       twitter,
-				Instagram:     instagram,
 				Birthdate:     &birthdate,
 				Ethnicity:     ethnicity,
 				Country:       country,
@@ -126,9 +121,6 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 		gender        = models.GenderEnumFemale
 		checksum      = "checksum"
 		details       = "details"
-		url           = "url"
-		twitter       = "twitter"
-		instagram     = "instagram"
 		rating        = 3
 		ethnicity     = "ethnicity"
 		country       = "country"
@@ -165,9 +157,6 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 				Name:          models.NewOptionalString(name),
 				Checksum:      models.NewOptionalString(checksum),
 				Gender:        models.NewOptionalString(gender.String()),
-				URL:           models.NewOptionalString(url),
-				Twitter:       models.NewOptionalString(twitter),
-				Instagram:     models.NewOptionalString(instagram),
 				Birthdate:     models.NewOptionalDate(birthdate),
 				Ethnicity:     models.NewOptionalString(ethnicity),
 				Country:       models.NewOptionalString(country),
@@ -194,9 +183,6 @@ func Test_PerformerStore_UpdatePartial(t *testing.T) {
 				Name:          name,
 				Checksum:      checksum,
 				Gender:        gender,
-				URL:           url,
-				Twitter:       twitter,
-				Instagram:     instagram,
 				Birthdate:     &birthdate,
 				Ethnicity:     ethnicity,
 				Country:       country,
@@ -684,6 +670,101 @@ func TestPerformerDestroyPerformerImage(t *testing.T) {
 	}
 }
 
+func TestPerformerImageGallery(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		const name = "TestPerformerImageGallery"
+		performer := models.Performer{
+			Name:     name,
+			Checksum: md5.FromString(name),
+		}
+		if err := qb.Create(ctx, &performer); err != nil {
+			return fmt.Errorf("Error creating performer: %s", err.Error())
+		}
+
+		image1 := []byte("image1")
+		image2 := []byte("image2")
+		image3 := []byte("image3")
+
+		// first image becomes the primary image
+		id1, err := qb.AddImage(ctx, performer.ID, image1, false)
+		if err != nil {
+			return fmt.Errorf("Error adding image: %s", err.Error())
+		}
+		id2, err := qb.AddImage(ctx, performer.ID, image2, false)
+		if err != nil {
+			return fmt.Errorf("Error adding image: %s", err.Error())
+		}
+		id3, err := qb.AddImage(ctx, performer.ID, image3, true)
+		if err != nil {
+			return fmt.Errorf("Error adding image: %s", err.Error())
+		}
+
+		// adding an identical image returns the existing image
+		dupID, err := qb.AddImage(ctx, performer.ID, image2, false)
+		if err != nil {
+			return fmt.Errorf("Error adding image: %s", err.Error())
+		}
+		assert.Equal(t, id2, dupID)
+
+		images, err := qb.GetImages(ctx, performer.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting images: %s", err.Error())
+		}
+		assert.Equal(t, []*models.PerformerImage{
+			{ID: id1, PerformerID: performer.ID, Position: 0},
+			{ID: id2, PerformerID: performer.ID, Position: 1},
+			{ID: id3, PerformerID: performer.ID, Position: 2, Primary: true},
+		}, images)
+
+		storedImage, err := qb.GetImage(ctx, performer.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting image: %s", err.Error())
+		}
+		assert.Equal(t, image3, storedImage)
+
+		storedImage, err = qb.GetImageByID(ctx, performer.ID, id2)
+		if err != nil {
+			return fmt.Errorf("Error getting image: %s", err.Error())
+		}
+		assert.Equal(t, image2, storedImage)
+
+		if err := qb.ReorderImages(ctx, performer.ID, []int{id3, id1}); err == nil {
+			return fmt.Errorf("Expected error reordering with missing image")
+		}
+		if err := qb.ReorderImages(ctx, performer.ID, []int{id3, id1, id2}); err != nil {
+			return fmt.Errorf("Error reordering images: %s", err.Error())
+		}
+
+		if err := qb.SetPrimaryImage(ctx, performer.ID, id2); err != nil {
+			return fmt.Errorf("Error setting primary image: %s", err.Error())
+		}
+
+		// removing the primary image promotes the first remaining image
+		if err := qb.DestroyImageByID(ctx, performer.ID, id2); err != nil {
+			return fmt.Errorf("Error destroying image: %s", err.Error())
+		}
+
+		images, err = qb.GetImages(ctx, performer.ID)
+		if err != nil {
+			return fmt.Errorf("Error getting images: %s", err.Error())
+		}
+		assert.Equal(t, []*models.PerformerImage{
+			{ID: id3, PerformerID: performer.ID, Position: 0, Primary: true},
+			{ID: id1, PerformerID: performer.ID, Position: 1},
+		}, images)
+
+		if err := qb.DestroyImageByID(ctx, performer.ID, id2); !errors.Is(err, models.ErrNotFound) {
+			return fmt.Errorf("Expected not found error destroying removed image, got %v", err)
+		}
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestPerformerQueryAge(t *testing.T) {
 	const age = 19
 	ageCriterion := models.IntCriterionInput{
@@ -789,29 +870,45 @@ func TestPerformerQueryURL(t *testing.T) {
 		URL: &urlCriterion,
 	}
 
-	verifyFn := func(g *models.Performer) {
+	verify := func() {
 		t.Helper()
-		verifyString(t, g.URL, urlCriterion)
+		withTxn(func(ctx context.Context) error {
+			performers := queryPerformers(ctx, t, &filter, nil)
+
+			// assume it should find at least one
+			assert.Greater(t, len(performers), 0)
+
+			for _, p := range performers {
+				urls, err := db.Performer.GetURLs(ctx, p.ID)
+				if err != nil {
+					t.Errorf("Error getting performer urls: %v", err)
+				}
+
+				verifyURLs(t, urls, urlCriterion)
+			}
+
+			return nil
+		})
 	}
 
-	verifyPerformerQuery(t, filter, verifyFn)
+	verify()
 
 	urlCriterion.Modifier = models.CriterionModifierNotEquals
-	verifyPerformerQuery(t, filter, verifyFn)
+	verify()
 
 	urlCriterion.Modifier = models.CriterionModifierMatchesRegex
 	urlCriterion.Value = "performer_.*1_URL"
-	verifyPerformerQuery(t, filter, verifyFn)
+	verify()
 
 	urlCriterion.Modifier = models.CriterionModifierNotMatchesRegex
-	verifyPerformerQuery(t, filter, verifyFn)
+	verify()
 
 	urlCriterion.Modifier = models.CriterionModifierIsNull
 	urlCriterion.Value = ""
-	verifyPerformerQuery(t, filter, verifyFn)
+	verify()
 
 	urlCriterion.Modifier = models.CriterionModifierNotNull
-	verifyPerformerQuery(t, filter, verifyFn)
+	verify()
 }
 
 func verifyPerformerQuery(t *testing.T, filter models.PerformerFilterType, verifyFn func(s *models.Performer)) {
@@ -1155,6 +1252,50 @@ func TestPerformerQueryStudio(t *testing.T) {
 	})
 }
 
+func TestPerformerCreateDisambiguation(t *testing.T) {
+	if err := withRollbackTxn(func(ctx context.Context) error {
+		qb := db.Performer
+
+		const name = "TestDisambiguation"
+		newPerformer := func(disambiguation string) *models.Performer {
+			return &models.Performer{
+				Name:           name,
+				Disambiguation: disambiguation,
+				Checksum:       md5.FromString(name),
+			}
+		}
+
+		if err := qb.Create(ctx, newPerformer("")); err != nil {
+			return fmt.Errorf("Error creating performer: %s", err.Error())
+		}
+
+		// performers may share a name if their disambiguation differs
+		disambiguated := newPerformer("disambiguation")
+		if err := qb.Create(ctx, disambiguated); err != nil {
+			return fmt.Errorf("Error creating disambiguated performer: %s", err.Error())
+		}
+
+		assert.NotNil(t, qb.Create(ctx, newPerformer("")), "duplicate name without disambiguation")
+		assert.NotNil(t, qb.Create(ctx, newPerformer("disambiguation")), "duplicate name and disambiguation")
+
+		found, err := qb.Find(ctx, disambiguated.ID)
+		if err != nil {
+			return fmt.Errorf("Error finding performer: %s", err.Error())
+		}
+		assert.Equal(t, "disambiguation", found.Disambiguation)
+
+		performers, err := qb.FindByNames(ctx, []string{name}, false)
+		if err != nil {
+			return fmt.Errorf("Error finding performers: %s", err.Error())
+		}
+		assert.Len(t, performers, 2)
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestPerformerStashIDs(t *testing.T) {
 	if err := withRollbackTxn(func(ctx context.Context) error {
 		qb := db.Performer
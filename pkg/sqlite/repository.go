@@ -15,6 +15,7 @@ import (
 )
 
 const idColumn = "id"
+const urlColumn = "url"
 
 type objectList interface {
 	Append(o interface{})
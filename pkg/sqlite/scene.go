@@ -22,13 +22,15 @@ import (
 )
 
 const (
-	sceneTable            = "scenes"
-	scenesFilesTable      = "scenes_files"
-	sceneIDColumn         = "scene_id"
-	performersScenesTable = "performers_scenes"
-	scenesTagsTable       = "scenes_tags"
-	scenesGalleriesTable  = "scenes_galleries"
-	moviesScenesTable     = "movies_scenes"
+	sceneTable              = "scenes"
+	scenesFilesTable        = "scenes_files"
+	sceneIDColumn           = "scene_id"
+	performersScenesTable   = "performers_scenes"
+	scenesTagsTable         = "scenes_tags"
+	scenesGalleriesTable    = "scenes_galleries"
+	moviesScenesTable       = "movies_scenes"
+	scenesURLsTable         = "scene_urls"
+	scenesCustomFieldsTable = "scene_custom_fields"
 )
 
 var findExactDuplicateQuery = `
@@ -57,7 +59,6 @@ type sceneRow struct {
 	Code     zero.String       `db:"code"`
 	Details  zero.String       `db:"details"`
 	Director zero.String       `db:"director"`
-	URL      zero.String       `db:"url"`
 	Date     models.SQLiteDate `db:"date"`
 	// expressed as 1-100
 	Rating    null.Int               `db:"rating"`
@@ -74,7 +75,6 @@ func (r *sceneRow) fromScene(o models.Scene) {
 	r.Code = zero.StringFrom(o.Code)
 	r.Details = zero.StringFrom(o.Details)
 	r.Director = zero.StringFrom(o.Director)
-	r.URL = zero.StringFrom(o.URL)
 	if o.Date != nil {
 		_ = r.Date.Scan(o.Date.Time)
 	}
@@ -102,7 +102,6 @@ func (r *sceneQueryRow) resolve() *models.Scene {
 		Code:      r.Code.String,
 		Details:   r.Details.String,
 		Director:  r.Director.String,
-		URL:       r.URL.String,
 		Date:      r.Date.DatePtr(),
 		Rating:    nullIntPtr(r.Rating),
 		Organized: r.Organized,
@@ -133,7 +132,6 @@ func (r *sceneRowRecord) fromPartial(o models.ScenePartial) {
 	r.setNullString("code", o.Code)
 	r.setNullString("details", o.Details)
 	r.setNullString("director", o.Director)
-	r.setNullString("url", o.URL)
 	r.setSQLiteDate("date", o.Date)
 	r.setNullInt("rating", o.Rating)
 	r.setBool("organized", o.Organized)
@@ -185,6 +183,13 @@ func (qb *SceneStore) Create(ctx context.Context, newObject *models.Scene, fileI
 		}
 	}
 
+	if newObject.URLs.Loaded() {
+		const startPos = 0
+		if err := scenesURLsTableMgr.insertJoins(ctx, id, startPos, newObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if newObject.PerformerIDs.Loaded() {
 		if err := scenesPerformersTableMgr.insertJoins(ctx, id, newObject.PerformerIDs.List()); err != nil {
 			return err
@@ -239,6 +244,11 @@ func (qb *SceneStore) UpdatePartial(ctx context.Context, id int, partial models.
 		}
 	}
 
+	if partial.URLs != nil {
+		if err := scenesURLsTableMgr.modifyJoins(ctx, id, partial.URLs.Values, partial.URLs.Mode); err != nil {
+			return nil, err
+		}
+	}
 	if partial.PerformerIDs != nil {
 		if err := scenesPerformersTableMgr.modifyJoins(ctx, id, partial.PerformerIDs.IDs, partial.PerformerIDs.Mode); err != nil {
 			return nil, err
@@ -281,6 +291,12 @@ func (qb *SceneStore) Update(ctx context.Context, updatedObject *models.Scene) e
 		return err
 	}
 
+	if updatedObject.URLs.Loaded() {
+		if err := scenesURLsTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.URLs.List()); err != nil {
+			return err
+		}
+	}
+
 	if updatedObject.PerformerIDs.Loaded() {
 		if err := scenesPerformersTableMgr.replaceJoins(ctx, updatedObject.ID, updatedObject.PerformerIDs.List()); err != nil {
 			return err
@@ -856,7 +872,7 @@ func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneF
 
 	query.handleCriterion(ctx, hasMarkersCriterionHandler(sceneFilter.HasMarkers))
 	query.handleCriterion(ctx, sceneIsMissingCriterionHandler(qb, sceneFilter.IsMissing))
-	query.handleCriterion(ctx, stringCriterionHandler(sceneFilter.URL, "scenes.url"))
+	query.handleCriterion(ctx, sceneURLsCriterionHandler(sceneFilter.URL))
 
 	query.handleCriterion(ctx, criterionHandlerFunc(func(ctx context.Context, f *filterBuilder) {
 		if sceneFilter.StashID != nil {
@@ -865,6 +881,8 @@ func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneF
 		}
 	}))
 
+	query.handleCriterion(ctx, sceneStashBoxFingerprintStatusCriterionHandler(sceneFilter.StashBoxFingerprintStatus))
+
 	query.handleCriterion(ctx, boolCriterionHandler(sceneFilter.Interactive, "video_files.interactive", qb.addVideoFilesTable))
 	query.handleCriterion(ctx, intCriterionHandler(sceneFilter.InteractiveSpeed, "video_files.interactive_speed", qb.addVideoFilesTable))
 
@@ -883,6 +901,7 @@ func (qb *SceneStore) makeFilter(ctx context.Context, sceneFilter *models.SceneF
 	query.handleCriterion(ctx, dateCriterionHandler(sceneFilter.Date, "scenes.date"))
 	query.handleCriterion(ctx, timestampCriterionHandler(sceneFilter.CreatedAt, "scenes.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(sceneFilter.UpdatedAt, "scenes.updated_at"))
+	query.handleCriterion(ctx, scenesCustomFieldsTableMgr.criterionHandler(sceneFilter.CustomFields, "scenes.id"))
 
 	return query
 }
@@ -1135,6 +1154,9 @@ func sceneIsMissingCriterionHandler(qb *SceneStore, isMissing *string) criterion
 			case "tags":
 				qb.tagsRepository().join(f, "tags_join", "scenes.id")
 				f.addWhere("tags_join.scene_id IS NULL")
+			case "url":
+				scenesURLsTableMgr.join(f, "", "scenes.id")
+				f.addWhere("scene_urls.url IS NULL")
 			case "stash_id":
 				qb.stashIDRepository().join(f, "scene_stash_ids", "scenes.id")
 				f.addWhere("scene_stash_ids.scene_id IS NULL")
@@ -1160,6 +1182,52 @@ func (qb *SceneStore) getMultiCriterionHandlerBuilder(foreignTable, joinTable, f
 	}
 }
 
+func sceneURLsCriterionHandler(url *models.StringCriterionInput) criterionHandlerFunc {
+	h := stringListCriterionHandlerBuilder{
+		joinTable:    scenesURLsTable,
+		stringColumn: urlColumn,
+		addJoinTable: func(f *filterBuilder) {
+			scenesURLsTableMgr.join(f, "", "scenes.id")
+		},
+	}
+
+	return h.handler(url)
+}
+
+func sceneStashBoxFingerprintStatusCriterionHandler(status *models.StashBoxFingerprintStatusCriterionInput) criterionHandlerFunc {
+	return func(ctx context.Context, f *filterBuilder) {
+		if status == nil || len(status.Value) == 0 {
+			return
+		}
+
+		var not string
+		switch status.Modifier {
+		case models.CriterionModifierIncludes:
+		case models.CriterionModifierExcludes:
+			not = "NOT "
+		default:
+			f.setError(fmt.Errorf("invalid modifier %s for stash-box fingerprint status", status.Modifier))
+			return
+		}
+
+		var args []interface{}
+		for _, v := range status.Value {
+			args = append(args, v.String())
+		}
+
+		// only consider checks against the current stash ids
+		where := fmt.Sprintf(`SELECT 1 FROM %[1]s
+JOIN scene_stash_ids ON scene_stash_ids.scene_id = %[1]s.scene_id AND scene_stash_ids.endpoint = %[1]s.endpoint AND scene_stash_ids.stash_id = %[1]s.stash_id
+WHERE %[1]s.scene_id = scenes.id AND %[1]s.status IN %[2]s`, sceneStashBoxCheckTable, getInBinding(len(args)))
+		if status.Endpoint != nil {
+			where += fmt.Sprintf(" AND %s.endpoint = ?", sceneStashBoxCheckTable)
+			args = append(args, *status.Endpoint)
+		}
+
+		f.addWhere(fmt.Sprintf("%sEXISTS (%s)", not, where), args...)
+	}
+}
+
 func sceneCaptionCriterionHandler(qb *SceneStore, captions *models.StringCriterionInput) criterionHandlerFunc {
 	h := stringListCriterionHandlerBuilder{
 		joinTable:    videoCaptionsTable,
@@ -1412,6 +1480,10 @@ func (qb *SceneStore) setSceneSort(query *queryBuilder, findFilter *models.FindF
 		addFolderTable()
 		query.sortAndPagination += " ORDER BY scenes.title COLLATE NATURAL_CS " + direction + ", folders.path " + direction + ", files.basename COLLATE NATURAL_CS " + direction
 	default:
+		if customFieldSort, ok := scenesCustomFieldsTableMgr.getSort(sort, sceneTable, direction); ok {
+			query.sortAndPagination += customFieldSort
+			return
+		}
 		query.sortAndPagination += getSort(sort, direction, "scenes")
 	}
 }
@@ -1511,6 +1583,10 @@ func (qb *SceneStore) GetPerformerIDs(ctx context.Context, id int) ([]int, error
 	return qb.performersRepository().getIDs(ctx, id)
 }
 
+func (qb *SceneStore) GetURLs(ctx context.Context, sceneID int) ([]string, error) {
+	return scenesURLsTableMgr.get(ctx, sceneID)
+}
+
 func (qb *SceneStore) tagsRepository() *joinRepository {
 	return &joinRepository{
 		repository: repository{
@@ -1611,3 +1687,11 @@ func (qb *SceneStore) FindDuplicates(ctx context.Context, distance int) ([][]*mo
 
 	return duplicates, nil
 }
+
+func (qb *SceneStore) GetCustomFields(ctx context.Context, sceneID int) (models.CustomFieldMap, error) {
+	return scenesCustomFieldsTableMgr.get(ctx, sceneID)
+}
+
+func (qb *SceneStore) SetCustomFields(ctx context.Context, sceneID int, input models.CustomFieldsInput) error {
+	return scenesCustomFieldsTableMgr.set(ctx, sceneID, input)
+}
@@ -0,0 +1,75 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const scenePendingChangeTable = "scene_pending_changes"
+
+type scenePendingChangeQueryBuilder struct {
+	repository
+}
+
+var ScenePendingChangeReaderWriter = &scenePendingChangeQueryBuilder{
+	repository{
+		tableName: scenePendingChangeTable,
+		idColumn:  idColumn,
+	},
+}
+
+func (qb *scenePendingChangeQueryBuilder) Create(ctx context.Context, newObject models.ScenePendingChange) (*models.ScenePendingChange, error) {
+	var ret models.ScenePendingChange
+	if err := qb.insertObject(ctx, newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *scenePendingChangeQueryBuilder) Destroy(ctx context.Context, id int) error {
+	return qb.destroyExisting(ctx, []int{id})
+}
+
+func (qb *scenePendingChangeQueryBuilder) DestroyBySceneID(ctx context.Context, sceneID int) error {
+	stmt := fmt.Sprintf("DELETE FROM %s WHERE scene_id = ?", scenePendingChangeTable)
+	_, err := qb.tx.Exec(ctx, stmt, sceneID)
+	return err
+}
+
+func (qb *scenePendingChangeQueryBuilder) Find(ctx context.Context, id int) (*models.ScenePendingChange, error) {
+	var ret models.ScenePendingChange
+	if err := qb.getByID(ctx, id, &ret); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func (qb *scenePendingChangeQueryBuilder) FindBySceneID(ctx context.Context, sceneID int) ([]*models.ScenePendingChange, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s WHERE scene_id = ? ORDER BY id ASC`, scenePendingChangeTable)
+
+	var ret models.ScenePendingChanges
+	if err := qb.query(ctx, query, []interface{}{sceneID}, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.ScenePendingChange(ret), nil
+}
+
+func (qb *scenePendingChangeQueryBuilder) All(ctx context.Context) ([]*models.ScenePendingChange, error) {
+	query := fmt.Sprintf(`SELECT * FROM %s ORDER BY scene_id ASC, id ASC`, scenePendingChangeTable)
+
+	var ret models.ScenePendingChanges
+	if err := qb.query(ctx, query, nil, &ret); err != nil {
+		return nil, err
+	}
+
+	return []*models.ScenePendingChange(ret), nil
+}
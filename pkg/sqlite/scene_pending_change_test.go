@@ -0,0 +1,104 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScenePendingChangeCreateFindDestroy(t *testing.T) {
+	sceneID := sceneIDs[sceneIdxWithGallery]
+	now := models.SQLiteTimestamp{Timestamp: time.Now()}
+
+	var titleID int
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.ScenePendingChangeReaderWriter
+
+		title, err := qb.Create(ctx, models.ScenePendingChange{
+			SceneID:       sceneID,
+			Source:        "source",
+			Field:         "title",
+			CurrentValue:  sql.NullString{String: `"current"`, Valid: true},
+			ProposedValue: `"proposed"`,
+			CreatedAt:     now,
+		})
+		if err != nil {
+			return err
+		}
+		titleID = title.ID
+
+		_, err = qb.Create(ctx, models.ScenePendingChange{
+			SceneID:       sceneID,
+			Source:        "source",
+			Field:         "studio_id",
+			ProposedValue: "1",
+			CreatedAt:     now,
+		})
+		return err
+	})
+
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.ScenePendingChangeReaderWriter
+
+		found, err := qb.Find(ctx, titleID)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, "title", found.Field)
+		assert.Equal(t, `"current"`, found.CurrentValue.String)
+		assert.Equal(t, `"proposed"`, found.ProposedValue)
+
+		changes, err := qb.FindBySceneID(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		assert.Len(t, changes, 2)
+
+		all, err := qb.All(ctx)
+		if err != nil {
+			return err
+		}
+
+		assert.Len(t, all, 2)
+
+		return nil
+	})
+
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.ScenePendingChangeReaderWriter
+
+		if err := qb.Destroy(ctx, titleID); err != nil {
+			return err
+		}
+
+		changes, err := qb.FindBySceneID(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		assert.Len(t, changes, 1)
+
+		if err := qb.DestroyBySceneID(ctx, sceneID); err != nil {
+			return err
+		}
+
+		changes, err = qb.FindBySceneID(ctx, sceneID)
+		if err != nil {
+			return err
+		}
+
+		assert.Len(t, changes, 0)
+
+		return nil
+	})
+}
@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gopkg.in/guregu/null.v4/zero"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const sceneStashBoxCheckTable = "scene_stash_box_checks"
+
+type sceneStashBoxCheckRow struct {
+	SceneID              int                    `db:"scene_id"`
+	Endpoint             string                 `db:"endpoint"`
+	StashID              string                 `db:"stash_id"`
+	Status               string                 `db:"status"`
+	UnlinkedFingerprints zero.String            `db:"unlinked_fingerprints"`
+	MergedInto           zero.String            `db:"merged_into"`
+	CheckedAt            models.SQLiteTimestamp `db:"checked_at"`
+}
+
+func (r *sceneStashBoxCheckRow) fromSceneStashBoxCheck(o models.SceneStashBoxCheck) error {
+	r.SceneID = o.SceneID
+	r.Endpoint = o.Endpoint
+	r.StashID = o.StashID
+	r.Status = o.Status.String()
+	if len(o.UnlinkedFingerprints) > 0 {
+		v, err := json.Marshal(o.UnlinkedFingerprints)
+		if err != nil {
+			return fmt.Errorf("encoding unlinked fingerprints: %w", err)
+		}
+		r.UnlinkedFingerprints = zero.StringFrom(string(v))
+	}
+	r.MergedInto = zero.StringFromPtr(o.MergedInto)
+	r.CheckedAt = models.SQLiteTimestamp{Timestamp: o.CheckedAt}
+
+	return nil
+}
+
+func (r *sceneStashBoxCheckRow) resolve() (*models.SceneStashBoxCheck, error) {
+	ret := &models.SceneStashBoxCheck{
+		SceneID:    r.SceneID,
+		Endpoint:   r.Endpoint,
+		StashID:    r.StashID,
+		Status:     models.StashBoxFingerprintStatus(r.Status),
+		MergedInto: r.MergedInto.Ptr(),
+		CheckedAt:  r.CheckedAt.Timestamp,
+	}
+
+	if r.UnlinkedFingerprints.String != "" {
+		if err := json.Unmarshal([]byte(r.UnlinkedFingerprints.String), &ret.UnlinkedFingerprints); err != nil {
+			return nil, fmt.Errorf("decoding unlinked fingerprints: %w", err)
+		}
+	}
+
+	return ret, nil
+}
+
+type sceneStashBoxCheckQueryBuilder struct {
+	repository
+}
+
+var SceneStashBoxCheckReaderWriter = &sceneStashBoxCheckQueryBuilder{
+	repository{
+		tableName: sceneStashBoxCheckTable,
+		idColumn:  sceneIDColumn,
+	},
+}
+
+func (qb *sceneStashBoxCheckQueryBuilder) Set(ctx context.Context, check models.SceneStashBoxCheck) error {
+	var r sceneStashBoxCheckRow
+	if err := r.fromSceneStashBoxCheck(check); err != nil {
+		return err
+	}
+
+	stmt := fmt.Sprintf(`INSERT OR REPLACE INTO %s
+		(scene_id, endpoint, stash_id, status, unlinked_fingerprints, merged_into, checked_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`, sceneStashBoxCheckTable)
+	_, err := qb.tx.Exec(ctx, stmt, r.SceneID, r.Endpoint, r.StashID, r.Status, r.UnlinkedFingerprints, r.MergedInto, r.CheckedAt)
+	return err
+}
+
+func (qb *sceneStashBoxCheckQueryBuilder) Destroy(ctx context.Context, sceneID int, endpoint string) error {
+	stmt := fmt.Sprintf("DELETE FROM %s WHERE scene_id = ? AND endpoint = ?", sceneStashBoxCheckTable)
+	_, err := qb.tx.Exec(ctx, stmt, sceneID, endpoint)
+	return err
+}
+
+func (qb *sceneStashBoxCheckQueryBuilder) FindBySceneID(ctx context.Context, sceneID int) ([]*models.SceneStashBoxCheck, error) {
+	// only return checks of the current stash ids
+	query := fmt.Sprintf(`SELECT c.* FROM %s c
+		INNER JOIN scene_stash_ids s ON s.scene_id = c.scene_id AND s.endpoint = c.endpoint AND s.stash_id = c.stash_id
+		WHERE c.scene_id = ?
+		ORDER BY c.endpoint`, sceneStashBoxCheckTable)
+
+	var rows []sceneStashBoxCheckRow
+	if err := qb.tx.Select(ctx, &rows, query, sceneID); err != nil {
+		return nil, err
+	}
+
+	ret := make([]*models.SceneStashBoxCheck, len(rows))
+	for i := range rows {
+		c, err := rows[i].resolve()
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = c
+	}
+
+	return ret, nil
+}
+
+func (qb *sceneStashBoxCheckQueryBuilder) LastCheckedAt(ctx context.Context, endpoint string) (*time.Time, error) {
+	query := fmt.Sprintf("SELECT checked_at FROM %s WHERE endpoint = ? ORDER BY checked_at DESC LIMIT 1", sceneStashBoxCheckTable)
+
+	var ret []models.SQLiteTimestamp
+	if err := qb.tx.Select(ctx, &ret, query, endpoint); err != nil {
+		return nil, err
+	}
+
+	if len(ret) == 0 {
+		return nil, nil
+	}
+
+	return &ret[0].Timestamp, nil
+}
@@ -0,0 +1,152 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneStashBoxCheck(t *testing.T) {
+	const otherEndpoint = "https://other.test/graphql"
+
+	matchedIdx := sceneIdxWithMovie
+	unlinkedIdx := sceneIdxWithGallery
+	// check against a stash id that the scene no longer has
+	outdatedIdx := sceneIdxWithPerformer
+	otherEndpointIdx := sceneIdx1WithPerformer
+
+	now := time.Now().Truncate(time.Second)
+	unlinked := []*models.StashBoxFingerprint{
+		{Algorithm: "OSHASH", Hash: "abc", Duration: 100},
+	}
+	merged := "merged"
+
+	checks := []models.SceneStashBoxCheck{
+		{
+			SceneID:  sceneIDs[matchedIdx],
+			Endpoint: sceneStashID(matchedIdx).Endpoint,
+			StashID:  sceneStashID(matchedIdx).StashID,
+			Status:   models.StashBoxFingerprintStatusMatched,
+		},
+		{
+			SceneID:              sceneIDs[unlinkedIdx],
+			Endpoint:             sceneStashID(unlinkedIdx).Endpoint,
+			StashID:              sceneStashID(unlinkedIdx).StashID,
+			Status:               models.StashBoxFingerprintStatusUnlinked,
+			UnlinkedFingerprints: unlinked,
+		},
+		{
+			SceneID:  sceneIDs[outdatedIdx],
+			Endpoint: sceneStashID(outdatedIdx).Endpoint,
+			StashID:  "outdated",
+			Status:   models.StashBoxFingerprintStatusUnlinked,
+		},
+		{
+			SceneID:    sceneIDs[otherEndpointIdx],
+			Endpoint:   sceneStashID(otherEndpointIdx).Endpoint,
+			StashID:    sceneStashID(otherEndpointIdx).StashID,
+			Status:     models.StashBoxFingerprintStatusMerged,
+			MergedInto: &merged,
+		},
+	}
+
+	withRollbackTxn(func(ctx context.Context) error {
+		qb := sqlite.SceneStashBoxCheckReaderWriter
+
+		for i, c := range checks {
+			c.CheckedAt = now.Add(time.Duration(i) * time.Minute)
+			if err := qb.Set(ctx, c); err != nil {
+				t.Errorf("SceneStashBoxCheckReaderWriter.Set() error = %v", err)
+				return nil
+			}
+		}
+
+		got, err := qb.FindBySceneID(ctx, sceneIDs[unlinkedIdx])
+		if err != nil {
+			t.Errorf("SceneStashBoxCheckReaderWriter.FindBySceneID() error = %v", err)
+			return nil
+		}
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, models.StashBoxFingerprintStatusUnlinked, got[0].Status)
+			assert.Equal(t, unlinked, got[0].UnlinkedFingerprints)
+			assert.Nil(t, got[0].MergedInto)
+			assert.Equal(t, now.Add(time.Minute), got[0].CheckedAt.Local())
+		}
+
+		got, err = qb.FindBySceneID(ctx, sceneIDs[outdatedIdx])
+		if err != nil {
+			t.Errorf("SceneStashBoxCheckReaderWriter.FindBySceneID() error = %v", err)
+			return nil
+		}
+		assert.Len(t, got, 0)
+
+		lastChecked, err := qb.LastCheckedAt(ctx, sceneStashID(unlinkedIdx).Endpoint)
+		if err != nil {
+			t.Errorf("SceneStashBoxCheckReaderWriter.LastCheckedAt() error = %v", err)
+			return nil
+		}
+		if assert.NotNil(t, lastChecked) {
+			assert.Equal(t, now.Add(time.Minute), lastChecked.Local())
+		}
+
+		lastChecked, err = qb.LastCheckedAt(ctx, otherEndpoint)
+		if err != nil {
+			t.Errorf("SceneStashBoxCheckReaderWriter.LastCheckedAt() error = %v", err)
+			return nil
+		}
+		assert.Nil(t, lastChecked)
+
+		sqb := db.Scene
+
+		// outdated checks are not included
+		scenes := queryScene(ctx, t, sqb, &models.SceneFilterType{
+			StashBoxFingerprintStatus: &models.StashBoxFingerprintStatusCriterionInput{
+				Value:    []models.StashBoxFingerprintStatus{models.StashBoxFingerprintStatusUnlinked, models.StashBoxFingerprintStatusMerged},
+				Modifier: models.CriterionModifierIncludes,
+			},
+		}, nil)
+		assert.ElementsMatch(t, []int{sceneIDs[unlinkedIdx], sceneIDs[otherEndpointIdx]}, scenesToIDs(scenes))
+
+		endpoint := sceneStashID(unlinkedIdx).Endpoint
+		scenes = queryScene(ctx, t, sqb, &models.SceneFilterType{
+			StashBoxFingerprintStatus: &models.StashBoxFingerprintStatusCriterionInput{
+				Endpoint: &endpoint,
+				Value:    []models.StashBoxFingerprintStatus{models.StashBoxFingerprintStatusUnlinked, models.StashBoxFingerprintStatusMerged},
+				Modifier: models.CriterionModifierIncludes,
+			},
+		}, nil)
+		assert.ElementsMatch(t, []int{sceneIDs[unlinkedIdx]}, scenesToIDs(scenes))
+
+		scenes = queryScene(ctx, t, sqb, &models.SceneFilterType{
+			StashBoxFingerprintStatus: &models.StashBoxFingerprintStatusCriterionInput{
+				Value:    []models.StashBoxFingerprintStatus{models.StashBoxFingerprintStatusUnlinked},
+				Modifier: models.CriterionModifierExcludes,
+			},
+		}, nil)
+		ids := scenesToIDs(scenes)
+		assert.NotContains(t, ids, sceneIDs[unlinkedIdx])
+		assert.Contains(t, ids, sceneIDs[matchedIdx])
+		assert.Contains(t, ids, sceneIDs[outdatedIdx])
+
+		if err := qb.Destroy(ctx, sceneIDs[unlinkedIdx], sceneStashID(unlinkedIdx).Endpoint); err != nil {
+			t.Errorf("SceneStashBoxCheckReaderWriter.Destroy() error = %v", err)
+			return nil
+		}
+
+		got, err = qb.FindBySceneID(ctx, sceneIDs[unlinkedIdx])
+		if err != nil {
+			t.Errorf("SceneStashBoxCheckReaderWriter.FindBySceneID() error = %v", err)
+			return nil
+		}
+		assert.Len(t, got, 0)
+
+		return nil
+	})
+}
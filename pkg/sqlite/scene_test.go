@@ -22,6 +22,11 @@ import (
 )
 
 func loadSceneRelationships(ctx context.Context, expected models.Scene, actual *models.Scene) error {
+	if expected.URLs.Loaded() {
+		if err := actual.LoadURLs(ctx, db.Scene); err != nil {
+			return err
+		}
+	}
 	if expected.GalleryIDs.Loaded() {
 		if err := actual.LoadGalleryIDs(ctx, db.Scene); err != nil {
 			return err
@@ -105,7 +110,7 @@ func Test_sceneQueryBuilder_Create(t *testing.T) {
 				Code:         code,
 				Details:      details,
 				Director:     director,
-				URL:          url,
+				URLs:         models.NewRelatedStrings([]string{url}),
 				Date:         &date,
 				Rating:       &rating,
 				Organized:    true,
@@ -146,7 +151,7 @@ func Test_sceneQueryBuilder_Create(t *testing.T) {
 				Code:      code,
 				Details:   details,
 				Director:  director,
-				URL:       url,
+				URLs:      models.NewRelatedStrings([]string{url}),
 				Date:      &date,
 				Rating:    &rating,
 				Organized: true,
@@ -331,7 +336,7 @@ func Test_sceneQueryBuilder_Update(t *testing.T) {
 				Code:         code,
 				Details:      details,
 				Director:     director,
-				URL:          url,
+				URLs:         models.NewRelatedStrings([]string{url}),
 				Date:         &date,
 				Rating:       &rating,
 				Organized:    true,
@@ -494,7 +499,7 @@ func clearScenePartial() models.ScenePartial {
 		Code:         models.OptionalString{Set: true, Null: true},
 		Details:      models.OptionalString{Set: true, Null: true},
 		Director:     models.OptionalString{Set: true, Null: true},
-		URL:          models.OptionalString{Set: true, Null: true},
+		URLs:         &models.UpdateStrings{Mode: models.RelationshipUpdateModeSet},
 		Date:         models.OptionalDate{Set: true, Null: true},
 		Rating:       models.OptionalInt{Set: true, Null: true},
 		StudioID:     models.OptionalInt{Set: true, Null: true},
@@ -541,7 +546,7 @@ func Test_sceneQueryBuilder_UpdatePartial(t *testing.T) {
 				Code:      models.NewOptionalString(code),
 				Details:   models.NewOptionalString(details),
 				Director:  models.NewOptionalString(director),
-				URL:       models.NewOptionalString(url),
+				URLs:      &models.UpdateStrings{Values: []string{url}, Mode: models.RelationshipUpdateModeSet},
 				Date:      models.NewOptionalDate(date),
 				Rating:    models.NewOptionalInt(rating),
 				Organized: models.NewOptionalBool(true),
@@ -597,7 +602,7 @@ func Test_sceneQueryBuilder_UpdatePartial(t *testing.T) {
 				Code:         code,
 				Details:      details,
 				Director:     director,
-				URL:          url,
+				URLs:         models.NewRelatedStrings([]string{url}),
 				Date:         &date,
 				Rating:       &rating,
 				Organized:    true,
@@ -709,6 +714,10 @@ func Test_sceneQueryBuilder_UpdatePartialRelationships(t *testing.T) {
 		endpoint2   = "endpoint2"
 		stashID1    = "stashid1"
 		stashID2    = "stashid2"
+		url1        = "url1"
+		url2        = "url2"
+
+		existingURLs = getURLs(getSceneEmptyString(sceneIdxWithGallery, urlField))
 
 		movieScenes = []models.MoviesScenes{
 			{
@@ -740,6 +749,48 @@ func Test_sceneQueryBuilder_UpdatePartialRelationships(t *testing.T) {
 		want    models.Scene
 		wantErr bool
 	}{
+		{
+			"add urls",
+			sceneIDs[sceneIdxWithGallery],
+			models.ScenePartial{
+				URLs: &models.UpdateStrings{
+					Values: []string{url1, url2, url1},
+					Mode:   models.RelationshipUpdateModeAdd,
+				},
+			},
+			models.Scene{
+				URLs: models.NewRelatedStrings(append(existingURLs, url1, url2)),
+			},
+			false,
+		},
+		{
+			"set urls",
+			sceneIDs[sceneIdxWithGallery],
+			models.ScenePartial{
+				URLs: &models.UpdateStrings{
+					Values: []string{url2, url1},
+					Mode:   models.RelationshipUpdateModeSet,
+				},
+			},
+			models.Scene{
+				URLs: models.NewRelatedStrings([]string{url2, url1}),
+			},
+			false,
+		},
+		{
+			"remove urls",
+			sceneIDs[sceneIdxWithGallery],
+			models.ScenePartial{
+				URLs: &models.UpdateStrings{
+					Values: existingURLs,
+					Mode:   models.RelationshipUpdateModeRemove,
+				},
+			},
+			models.Scene{
+				URLs: models.NewRelatedStrings([]string{}),
+			},
+			false,
+		},
 		{
 			"add galleries",
 			sceneIDs[sceneIdxWithGallery],
@@ -1228,6 +1279,10 @@ func Test_sceneQueryBuilder_UpdatePartialRelationships(t *testing.T) {
 			}
 
 			// only compare fields that were in the partial
+			if tt.partial.URLs != nil {
+				assert.Equal(tt.want.URLs.List(), got.URLs.List())
+				assert.Equal(tt.want.URLs.List(), s.URLs.List())
+			}
 			if tt.partial.PerformerIDs != nil {
 				assert.ElementsMatch(tt.want.PerformerIDs.List(), got.PerformerIDs.List())
 				assert.ElementsMatch(tt.want.PerformerIDs.List(), s.PerformerIDs.List())
@@ -2226,7 +2281,7 @@ func TestSceneQueryURL(t *testing.T) {
 
 	verifyFn := func(s *models.Scene) {
 		t.Helper()
-		verifyString(t, s.URL, urlCriterion)
+		verifyURLs(t, s.URLs.List(), urlCriterion)
 	}
 
 	verifySceneQuery(t, filter, verifyFn)
@@ -2406,6 +2461,10 @@ func verifySceneQuery(t *testing.T, filter models.SceneFilterType, verifyFn func
 		assert.Greater(t, len(scenes), 0)
 
 		for _, scene := range scenes {
+			if err := scene.LoadURLs(ctx, sqb); err != nil {
+				t.Errorf("Error loading scene urls: %v", err)
+			}
+
 			verifyFn(scene)
 		}
 
@@ -2496,6 +2555,17 @@ func verifyStringPtr(t *testing.T, value *string, criterion models.StringCriteri
 	}
 }
 
+// verifyURLs verifies the urls of a test object, which have at most one value.
+func verifyURLs(t *testing.T, urls []string, criterion models.StringCriterionInput) {
+	t.Helper()
+	var value string
+	if len(urls) > 0 {
+		value = urls[0]
+	}
+
+	verifyString(t, value, criterion)
+}
+
 func verifyString(t *testing.T, value string, criterion models.StringCriterionInput) {
 	t.Helper()
 	assert := assert.New(t)
@@ -803,6 +803,15 @@ func getSceneNullStringPtr(index int, field string) *string {
 	return getStringPtrFromNullString(getPrefixedNullStringValue("scene", index, field))
 }
 
+// getURLs returns the urls of a test object with the provided url value.
+func getURLs(url string) []string {
+	if url == "" {
+		return []string{}
+	}
+
+	return []string{url}
+}
+
 func getSceneEmptyString(index int, field string) string {
 	v := getSceneNullStringPtr(index, field)
 	if v == nil {
@@ -972,7 +981,7 @@ func makeScene(i int) *models.Scene {
 	return &models.Scene{
 		Title:        title,
 		Details:      details,
-		URL:          getSceneEmptyString(i, urlField),
+		URLs:         models.NewRelatedStrings(getURLs(getSceneEmptyString(i, urlField))),
 		Rating:       getIntPtr(rating),
 		OCounter:     getOCounter(i),
 		Date:         getObjectDateObject(i),
@@ -1133,7 +1142,7 @@ func makeGallery(i int, includeScenes bool) *models.Gallery {
 
 	ret := &models.Gallery{
 		Title:        getGalleryStringValue(i, titleField),
-		URL:          getGalleryNullStringValue(i, urlField).String,
+		URLs:         models.NewRelatedStrings(getURLs(getGalleryNullStringValue(i, urlField).String)),
 		Rating:       getIntPtr(getRating(i)),
 		Date:         getObjectDateObject(i),
 		StudioID:     studioID,
@@ -1207,7 +1216,6 @@ func createMovies(ctx context.Context, mqb models.MovieReaderWriter, n int, o in
 		name = getMovieStringValue(index, name)
 		movie := models.Movie{
 			Name:     sql.NullString{String: name, Valid: true},
-			URL:      getMovieNullStringValue(index, urlField),
 			Checksum: md5.FromString(name),
 		}
 
@@ -1217,6 +1225,10 @@ func createMovies(ctx context.Context, mqb models.MovieReaderWriter, n int, o in
 			return fmt.Errorf("Error creating movie [%d] %v+: %s", i, movie, err.Error())
 		}
 
+		if err := mqb.UpdateURLs(ctx, created.ID, getURLs(getMovieNullStringValue(index, urlField).String)); err != nil {
+			return fmt.Errorf("setting movie urls: %w", err)
+		}
+
 		movieIDs = append(movieIDs, created.ID)
 		movieNames = append(movieNames, created.Name.String)
 	}
@@ -1305,7 +1317,6 @@ func createPerformers(ctx context.Context, n int, o int) error {
 		performer := models.Performer{
 			Name:          getPerformerStringValue(index, name),
 			Checksum:      getPerformerStringValue(i, checksumField),
-			URL:           getPerformerNullStringValue(i, urlField),
 			Favorite:      getPerformerBoolValue(i),
 			Birthdate:     getPerformerBirthdate(i),
 			DeathDate:     getPerformerDeathDate(i),
@@ -1326,6 +1337,10 @@ func createPerformers(ctx context.Context, n int, o int) error {
 			return fmt.Errorf("Error creating performer %v+: %s", performer, err.Error())
 		}
 
+		if err := pqb.UpdateURLs(ctx, performer.ID, getURLs(getPerformerNullStringValue(i, urlField))); err != nil {
+			return fmt.Errorf("setting performer urls: %w", err)
+		}
+
 		if (index+1)%5 != 0 {
 			if err := pqb.UpdateStashIDs(ctx, performer.ID, []models.StashID{
 				performerStashID(i),
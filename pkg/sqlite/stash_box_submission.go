@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/stashapp/stash/pkg/models"
+)
+
+const stashBoxSubmissionTable = "stash_box_submissions"
+
+type stashBoxSubmissionQueryBuilder struct {
+	repository
+}
+
+var StashBoxSubmissionReaderWriter = &stashBoxSubmissionQueryBuilder{
+	repository{
+		tableName: stashBoxSubmissionTable,
+		idColumn:  idColumn,
+	},
+}
+
+func (qb *stashBoxSubmissionQueryBuilder) Create(ctx context.Context, newObject models.StashBoxSubmission) (*models.StashBoxSubmission, error) {
+	var ret models.StashBoxSubmission
+	if err := qb.insertObject(ctx, newObject, &ret); err != nil {
+		return nil, err
+	}
+
+	return &ret, nil
+}
+
+func (qb *stashBoxSubmissionQueryBuilder) Query(ctx context.Context, filter models.StashBoxSubmissionFilter, findFilter *models.FindFilterType) ([]*models.StashBoxSubmission, int, error) {
+	if findFilter == nil {
+		findFilter = &models.FindFilterType{}
+	}
+
+	var where []string
+	var args []interface{}
+	if filter.Endpoint != nil {
+		where = append(where, "endpoint = ?")
+		args = append(args, *filter.Endpoint)
+	}
+	if filter.EntityType != nil {
+		where = append(where, "entity_type = ?")
+		args = append(args, *filter.EntityType)
+	}
+	if filter.EntityID != nil {
+		where = append(where, "entity_id = ?")
+		args = append(args, *filter.EntityID)
+	}
+
+	whereClause := ""
+	if len(where) > 0 {
+		whereClause = " WHERE " + strings.Join(where, " AND ")
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) as count FROM %s%s", stashBoxSubmissionTable, whereClause)
+	count, err := qb.runCountQuery(ctx, countQuery, args)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	query := fmt.Sprintf("SELECT * FROM %s%s", stashBoxSubmissionTable, whereClause) + qb.getStashBoxSubmissionSort(findFilter) + getPagination(findFilter)
+
+	var ret models.StashBoxSubmissions
+	if err := qb.query(ctx, query, args, &ret); err != nil {
+		return nil, 0, err
+	}
+
+	return []*models.StashBoxSubmission(ret), count, nil
+}
+
+func (qb *stashBoxSubmissionQueryBuilder) getStashBoxSubmissionSort(findFilter *models.FindFilterType) string {
+	sort := "created_at"
+	direction := "DESC"
+	if findFilter.Sort != nil {
+		switch *findFilter.Sort {
+		case "created_at", "endpoint", "entity_type", "type":
+			sort = *findFilter.Sort
+			direction = findFilter.GetDirection()
+		}
+	}
+
+	// sort by id as a tie-breaker for submissions made at the same time
+	return getSort(sort, direction, stashBoxSubmissionTable) + ", " + getColumn(stashBoxSubmissionTable, "id") + " " + getSortDirection(direction)
+}
@@ -0,0 +1,74 @@
+//go:build integration
+// +build integration
+
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stashapp/stash/pkg/models"
+	"github.com/stashapp/stash/pkg/sqlite"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStashBoxSubmissionQuery(t *testing.T) {
+	const (
+		endpoint      = "https://stashbox.test/graphql"
+		otherEndpoint = "https://other.test/graphql"
+	)
+
+	now := time.Now()
+
+	withTxn(func(ctx context.Context) error {
+		qb := sqlite.StashBoxSubmissionReaderWriter
+
+		submissions := []models.StashBoxSubmission{
+			{Endpoint: endpoint, EntityType: models.StashBoxSubmissionEntityScene, EntityID: 1, Type: models.StashBoxSubmissionTypeDraft, RemoteID: "draft"},
+			{Endpoint: endpoint, EntityType: models.StashBoxSubmissionEntityScene, EntityID: 1, Type: models.StashBoxSubmissionTypeEdit, RemoteID: "edit"},
+			{Endpoint: otherEndpoint, EntityType: models.StashBoxSubmissionEntityTag, EntityID: 1, Type: models.StashBoxSubmissionTypeEdit, RemoteID: "tag"},
+		}
+
+		for i, s := range submissions {
+			s.CreatedAt = models.SQLiteTimestamp{Timestamp: now.Add(time.Duration(i) * time.Minute)}
+			if _, err := qb.Create(ctx, s); err != nil {
+				return err
+			}
+		}
+
+		entityType := models.StashBoxSubmissionEntityScene
+		entityID := 1
+		got, count, err := qb.Query(ctx, models.StashBoxSubmissionFilter{
+			EntityType: &entityType,
+			EntityID:   &entityID,
+		}, nil)
+		if err != nil {
+			t.Errorf("StashBoxSubmissionReaderWriter.Query() error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, 2, count)
+		if assert.Len(t, got, 2) {
+			// most recent first
+			assert.Equal(t, "edit", got[0].RemoteID)
+			assert.Equal(t, "draft", got[1].RemoteID)
+		}
+
+		e := otherEndpoint
+		got, count, err = qb.Query(ctx, models.StashBoxSubmissionFilter{
+			Endpoint: &e,
+		}, nil)
+		if err != nil {
+			t.Errorf("StashBoxSubmissionReaderWriter.Query() error = %v", err)
+			return nil
+		}
+
+		assert.Equal(t, 1, count)
+		if assert.Len(t, got, 1) {
+			assert.Equal(t, models.StashBoxSubmissionEntityTag, got[0].EntityType)
+		}
+
+		return nil
+	})
+}
@@ -17,6 +17,7 @@ const studioTable = "studios"
 const studioIDColumn = "studio_id"
 const studioAliasesTable = "studio_aliases"
 const studioAliasColumn = "alias"
+const studiosCustomFieldsTable = "studio_custom_fields"
 
 type studioQueryBuilder struct {
 	repository
@@ -152,6 +153,21 @@ func (qb *studioQueryBuilder) FindByStashID(ctx context.Context, stashID models.
 	return qb.queryStudios(ctx, query, args)
 }
 
+func (qb *studioQueryBuilder) FindByStashIDStatus(ctx context.Context, hasStashID bool, stashboxEndpoint string) ([]*models.Studio, error) {
+	in := "IN"
+	if !hasStashID {
+		in = "NOT IN"
+	}
+
+	query := selectAll("studios") + `
+		WHERE studios.id ` + in + ` (
+			SELECT studio_id FROM studio_stash_ids WHERE endpoint = ?
+		)
+	` + qb.getStudioSort(nil)
+	args := []interface{}{stashboxEndpoint}
+	return qb.queryStudios(ctx, query, args)
+}
+
 func (qb *studioQueryBuilder) Count(ctx context.Context) (int, error) {
 	return qb.runCountQuery(ctx, qb.buildCountQuery("SELECT studios.id FROM studios"), nil)
 }
@@ -254,6 +270,7 @@ func (qb *studioQueryBuilder) makeFilter(ctx context.Context, studioFilter *mode
 	query.handleCriterion(ctx, studioAliasCriterionHandler(qb, studioFilter.Aliases))
 	query.handleCriterion(ctx, timestampCriterionHandler(studioFilter.CreatedAt, "studios.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(studioFilter.UpdatedAt, "studios.updated_at"))
+	query.handleCriterion(ctx, studiosCustomFieldsTableMgr.criterionHandler(studioFilter.CustomFields, "studios.id"))
 
 	return query
 }
@@ -393,6 +410,9 @@ func (qb *studioQueryBuilder) getStudioSort(findFilter *models.FindFilterType) s
 	case "galleries_count":
 		return getCountSort(studioTable, galleryTable, studioIDColumn, direction)
 	default:
+		if customFieldSort, ok := studiosCustomFieldsTableMgr.getSort(sort, studioTable, direction); ok {
+			return customFieldSort
+		}
 		return getSort(sort, direction, "studios")
 	}
 }
@@ -477,3 +497,11 @@ func (qb *studioQueryBuilder) GetAliases(ctx context.Context, studioID int) ([]s
 func (qb *studioQueryBuilder) UpdateAliases(ctx context.Context, studioID int, aliases []string) error {
 	return qb.aliasRepository().replace(ctx, studioID, aliases)
 }
+
+func (qb *studioQueryBuilder) GetCustomFields(ctx context.Context, studioID int) (models.CustomFieldMap, error) {
+	return studiosCustomFieldsTableMgr.get(ctx, studioID)
+}
+
+func (qb *studioQueryBuilder) SetCustomFields(ctx context.Context, studioID int, input models.CustomFieldsInput) error {
+	return studiosCustomFieldsTableMgr.set(ctx, studioID, input)
+}
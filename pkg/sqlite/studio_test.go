@@ -622,6 +622,56 @@ func TestStudioStashIDs(t *testing.T) {
 	}
 }
 
+func TestStudioFindByStashIDStatus(t *testing.T) {
+	const endpoint = "https://stashbox.test/studio-status"
+
+	if err := withTxn(func(ctx context.Context) error {
+		qb := sqlite.StudioReaderWriter
+
+		tagged, err := createStudio(ctx, qb, "TestStudioFindByStashIDStatus tagged", nil)
+		if err != nil {
+			return fmt.Errorf("Error creating studio: %s", err.Error())
+		}
+		if err := qb.UpdateStashIDs(ctx, tagged.ID, []models.StashID{
+			{Endpoint: endpoint, StashID: "stash-id"},
+		}); err != nil {
+			return err
+		}
+
+		untagged, err := createStudio(ctx, qb, "TestStudioFindByStashIDStatus untagged", nil)
+		if err != nil {
+			return fmt.Errorf("Error creating studio: %s", err.Error())
+		}
+
+		containsID := func(studios []*models.Studio, id int) bool {
+			for _, s := range studios {
+				if s.ID == id {
+					return true
+				}
+			}
+			return false
+		}
+
+		studios, err := qb.FindByStashIDStatus(ctx, true, endpoint)
+		if err != nil {
+			return err
+		}
+		assert.Len(t, studios, 1)
+		assert.True(t, containsID(studios, tagged.ID))
+
+		studios, err = qb.FindByStashIDStatus(ctx, false, endpoint)
+		if err != nil {
+			return err
+		}
+		assert.False(t, containsID(studios, tagged.ID))
+		assert.True(t, containsID(studios, untagged.ID))
+
+		return nil
+	}); err != nil {
+		t.Error(err.Error())
+	}
+}
+
 func TestStudioQueryURL(t *testing.T) {
 	const sceneIdx = 1
 	studioURL := getStudioStringValue(sceneIdx, urlField)
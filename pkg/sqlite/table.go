@@ -15,6 +15,7 @@ import (
 	"github.com/stashapp/stash/pkg/logger"
 	"github.com/stashapp/stash/pkg/models"
 	"github.com/stashapp/stash/pkg/sliceutil/intslice"
+	"github.com/stashapp/stash/pkg/sliceutil/stringslice"
 )
 
 type table struct {
@@ -366,6 +367,124 @@ func (t *stashIDTable) modifyJoins(ctx context.Context, id int, v []models.Stash
 	return nil
 }
 
+// orderedValueTable is a table of string values, such as urls, that are
+// ordered by a position column.
+type orderedValueTable struct {
+	table
+	valueColumn exp.IdentifierExpression
+}
+
+// join adds a left join of the table to the filter, using the provided
+// primary key column of the parent table.
+func (t *orderedValueTable) join(f *filterBuilder, as string, parentIDCol string) {
+	tableName := t.table.table.GetTable()
+	joinName := tableName
+	if as != "" {
+		joinName = as
+	}
+
+	f.addLeftJoin(tableName, as, fmt.Sprintf("%s.%s = %s", joinName, t.idColumn.GetCol(), parentIDCol))
+}
+
+func (t *orderedValueTable) positionColumn() exp.IdentifierExpression {
+	return t.table.table.Col("position")
+}
+
+func (t *orderedValueTable) get(ctx context.Context, id int) ([]string, error) {
+	q := dialect.Select(t.valueColumn).From(t.table.table).Where(t.idColumn.Eq(id)).Order(t.positionColumn().Asc())
+
+	const single = false
+	var ret []string
+	if err := queryFunc(ctx, q, single, func(rows *sqlx.Rows) error {
+		var v string
+		if err := rows.Scan(&v); err != nil {
+			return err
+		}
+
+		ret = append(ret, v)
+
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("getting values from %s: %w", t.table.table.GetTable(), err)
+	}
+
+	return ret, nil
+}
+
+// insertJoins inserts the provided values, starting at the provided position.
+func (t *orderedValueTable) insertJoins(ctx context.Context, id int, startPos int, v []string) error {
+	// eliminate duplicates
+	v = stringslice.StrAppendUniques(nil, v)
+
+	for i, vv := range v {
+		q := dialect.Insert(t.table.table).Cols(t.idColumn.GetCol(), t.positionColumn().GetCol(), t.valueColumn.GetCol()).Vals(
+			goqu.Vals{id, startPos + i, vv},
+		)
+
+		if _, err := exec(ctx, q); err != nil {
+			return fmt.Errorf("inserting into %s: %w", t.table.table.GetTable(), err)
+		}
+	}
+
+	return nil
+}
+
+func (t *orderedValueTable) replaceJoins(ctx context.Context, id int, v []string) error {
+	if err := t.destroy(ctx, []int{id}); err != nil {
+		return err
+	}
+
+	const startPos = 0
+	return t.insertJoins(ctx, id, startPos, v)
+}
+
+func (t *orderedValueTable) addJoins(ctx context.Context, id int, v []string) error {
+	existing, err := t.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// only append values that are not already present
+	var toAdd []string
+	for _, vv := range v {
+		if !stringslice.StrInclude(existing, vv) {
+			toAdd = append(toAdd, vv)
+		}
+	}
+
+	return t.insertJoins(ctx, id, len(existing), toAdd)
+}
+
+func (t *orderedValueTable) destroyJoins(ctx context.Context, id int, v []string) error {
+	existing, err := t.get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	var remaining []string
+	for _, vv := range existing {
+		if !stringslice.StrInclude(v, vv) {
+			remaining = append(remaining, vv)
+		}
+	}
+
+	// rewrite the remaining values so that the positions remain contiguous
+	return t.replaceJoins(ctx, id, remaining)
+}
+
+func (t *orderedValueTable) modifyJoins(ctx context.Context, id int, v []string, mode models.RelationshipUpdateMode) error {
+	switch mode {
+	case models.RelationshipUpdateModeSet:
+		return t.replaceJoins(ctx, id, v)
+	case models.RelationshipUpdateModeAdd:
+		return t.addJoins(ctx, id, v)
+	case models.RelationshipUpdateModeRemove:
+		return t.destroyJoins(ctx, id, v)
+	}
+
+	return nil
+}
+
 type scenesMoviesTable struct {
 	table
 }
@@ -3,6 +3,8 @@ package sqlite
 import (
 	"github.com/doug-martin/goqu/v9"
 
+	"github.com/stashapp/stash/pkg/models"
+
 	_ "github.com/doug-martin/goqu/v9/dialect/sqlite3"
 )
 
@@ -18,15 +20,29 @@ var (
 	galleriesTagsJoinTable       = goqu.T(galleriesTagsTable)
 	performersGalleriesJoinTable = goqu.T(performersGalleriesTable)
 	galleriesScenesJoinTable     = goqu.T(galleriesScenesTable)
+	galleriesURLsJoinTable       = goqu.T(galleriesURLsTable)
 
 	scenesFilesJoinTable      = goqu.T(scenesFilesTable)
 	scenesTagsJoinTable       = goqu.T(scenesTagsTable)
 	scenesPerformersJoinTable = goqu.T(performersScenesTable)
 	scenesStashIDsJoinTable   = goqu.T("scene_stash_ids")
 	scenesMoviesJoinTable     = goqu.T(moviesScenesTable)
+	scenesURLsJoinTable       = goqu.T(scenesURLsTable)
 
 	performersTagsJoinTable     = goqu.T(performersTagsTable)
 	performersStashIDsJoinTable = goqu.T("performer_stash_ids")
+	performersURLsJoinTable     = goqu.T(performersURLsTable)
+	performersImageJoinTable    = goqu.T(performersImageTable)
+
+	moviesURLsJoinTable = goqu.T(moviesURLsTable)
+
+	scenesCustomFieldsJoinTable     = goqu.T(scenesCustomFieldsTable)
+	imagesCustomFieldsJoinTable     = goqu.T(imagesCustomFieldsTable)
+	galleriesCustomFieldsJoinTable  = goqu.T(galleriesCustomFieldsTable)
+	performersCustomFieldsJoinTable = goqu.T(performersCustomFieldsTable)
+	studiosCustomFieldsJoinTable    = goqu.T(studiosCustomFieldsTable)
+	tagsCustomFieldsJoinTable       = goqu.T(tagsCustomFieldsTable)
+	moviesCustomFieldsJoinTable     = goqu.T(moviesCustomFieldsTable)
 )
 
 var (
@@ -65,6 +81,14 @@ var (
 		},
 		fkColumn: performersImagesJoinTable.Col(performerIDColumn),
 	}
+
+	imagesCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    imagesCustomFieldsJoinTable,
+			idColumn: imagesCustomFieldsJoinTable.Col(imageIDColumn),
+		},
+		entityType: models.CustomFieldEntityTypeImage,
+	}
 )
 
 var (
@@ -103,6 +127,22 @@ var (
 		},
 		fkColumn: galleriesScenesJoinTable.Col(sceneIDColumn),
 	}
+
+	galleriesURLsTableMgr = &orderedValueTable{
+		table: table{
+			table:    galleriesURLsJoinTable,
+			idColumn: galleriesURLsJoinTable.Col(galleryIDColumn),
+		},
+		valueColumn: galleriesURLsJoinTable.Col(urlColumn),
+	}
+
+	galleriesCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    galleriesCustomFieldsJoinTable,
+			idColumn: galleriesCustomFieldsJoinTable.Col(galleryIDColumn),
+		},
+		entityType: models.CustomFieldEntityTypeGallery,
+	}
 )
 
 var (
@@ -149,6 +189,22 @@ var (
 			idColumn: scenesMoviesJoinTable.Col(sceneIDColumn),
 		},
 	}
+
+	scenesURLsTableMgr = &orderedValueTable{
+		table: table{
+			table:    scenesURLsJoinTable,
+			idColumn: scenesURLsJoinTable.Col(sceneIDColumn),
+		},
+		valueColumn: scenesURLsJoinTable.Col(urlColumn),
+	}
+
+	scenesCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    scenesCustomFieldsJoinTable,
+			idColumn: scenesCustomFieldsJoinTable.Col(sceneIDColumn),
+		},
+		entityType: models.CustomFieldEntityTypeScene,
+	}
 )
 
 var (
@@ -183,6 +239,22 @@ var (
 		table:    goqu.T(performerTable),
 		idColumn: goqu.T(performerTable).Col(idColumn),
 	}
+
+	performersURLsTableMgr = &orderedValueTable{
+		table: table{
+			table:    performersURLsJoinTable,
+			idColumn: performersURLsJoinTable.Col(performerIDColumn),
+		},
+		valueColumn: performersURLsJoinTable.Col(urlColumn),
+	}
+
+	performersCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    performersCustomFieldsJoinTable,
+			idColumn: performersCustomFieldsJoinTable.Col(performerIDColumn),
+		},
+		entityType: models.CustomFieldEntityTypePerformer,
+	}
 )
 
 var (
@@ -190,6 +262,14 @@ var (
 		table:    goqu.T(studioTable),
 		idColumn: goqu.T(studioTable).Col(idColumn),
 	}
+
+	studiosCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    studiosCustomFieldsJoinTable,
+			idColumn: studiosCustomFieldsJoinTable.Col(studioIDColumn),
+		},
+		entityType: models.CustomFieldEntityTypeStudio,
+	}
 )
 
 var (
@@ -197,6 +277,14 @@ var (
 		table:    goqu.T(tagTable),
 		idColumn: goqu.T(tagTable).Col(idColumn),
 	}
+
+	tagsCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    tagsCustomFieldsJoinTable,
+			idColumn: tagsCustomFieldsJoinTable.Col(tagIDColumn),
+		},
+		entityType: models.CustomFieldEntityTypeTag,
+	}
 )
 
 var (
@@ -204,4 +292,27 @@ var (
 		table:    goqu.T(movieTable),
 		idColumn: goqu.T(movieTable).Col(idColumn),
 	}
+
+	moviesURLsTableMgr = &orderedValueTable{
+		table: table{
+			table:    moviesURLsJoinTable,
+			idColumn: moviesURLsJoinTable.Col(movieIDColumn),
+		},
+		valueColumn: moviesURLsJoinTable.Col(urlColumn),
+	}
+
+	moviesCustomFieldsTableMgr = &customFieldsTable{
+		table: table{
+			table:    moviesCustomFieldsJoinTable,
+			idColumn: moviesCustomFieldsJoinTable.Col(movieIDColumn),
+		},
+		entityType: models.CustomFieldEntityTypeMovie,
+	}
+)
+
+var (
+	customFieldDefinitionTableMgr = &table{
+		table:    goqu.T(customFieldDefinitionTable),
+		idColumn: goqu.T(customFieldDefinitionTable).Col(idColumn),
+	}
 )
@@ -15,6 +15,7 @@ import (
 
 const tagTable = "tags"
 const tagIDColumn = "tag_id"
+const tagsCustomFieldsTable = "tag_custom_fields"
 const tagAliasesTable = "tag_aliases"
 const tagAliasColumn = "alias"
 
@@ -340,6 +341,7 @@ func (qb *tagQueryBuilder) makeFilter(ctx context.Context, tagFilter *models.Tag
 	query.handleCriterion(ctx, tagChildCountCriterionHandler(qb, tagFilter.ChildCount))
 	query.handleCriterion(ctx, timestampCriterionHandler(tagFilter.CreatedAt, "tags.created_at"))
 	query.handleCriterion(ctx, timestampCriterionHandler(tagFilter.UpdatedAt, "tags.updated_at"))
+	query.handleCriterion(ctx, tagsCustomFieldsTableMgr.criterionHandler(tagFilter.CustomFields, "tags.id"))
 
 	return query
 }
@@ -614,6 +616,10 @@ func (qb *tagQueryBuilder) getTagSort(query *queryBuilder, findFilter *models.Fi
 		}
 	}
 
+	if customFieldSort, ok := tagsCustomFieldsTableMgr.getSort(sort, tagTable, direction); ok {
+		return customFieldSort
+	}
+
 	return getSort(sort, direction, "tags")
 }
 
@@ -850,3 +856,11 @@ SELECT t.*, c.path FROM tags t INNER JOIN children c ON t.id = c.child_id
 
 	return ret, nil
 }
+
+func (qb *tagQueryBuilder) GetCustomFields(ctx context.Context, tagID int) (models.CustomFieldMap, error) {
+	return tagsCustomFieldsTableMgr.get(ctx, tagID)
+}
+
+func (qb *tagQueryBuilder) SetCustomFields(ctx context.Context, tagID int, input models.CustomFieldsInput) error {
+	return tagsCustomFieldsTableMgr.set(ctx, tagID, input)
+}
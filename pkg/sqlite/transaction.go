@@ -115,5 +115,13 @@ func (db *Database) TxnRepository() models.Repository {
 		Studio:      StudioReaderWriter,
 		Tag:         TagReaderWriter,
 		SavedFilter: SavedFilterReaderWriter,
+
+		ScenePendingChange: ScenePendingChangeReaderWriter,
+		JobRecord:          JobRecordReaderWriter,
+		JobCheckpoint:      JobCheckpointReaderWriter,
+		StashBoxSubmission: StashBoxSubmissionReaderWriter,
+		SceneStashBoxCheck: SceneStashBoxCheckReaderWriter,
+
+		CustomFieldDefinition: db.CustomFieldDefinition,
 	}
 }
@@ -109,6 +109,38 @@ func TestImporterPostImport(t *testing.T) {
 	readerWriter.AssertExpectations(t)
 }
 
+func TestImporterPostImportCustomFields(t *testing.T) {
+	readerWriter := &mocks.TagReaderWriter{}
+
+	i := Importer{
+		ReaderWriter: readerWriter,
+		Input: jsonschema.Tag{
+			CustomFields: map[string]interface{}{
+				"field": "value",
+			},
+		},
+	}
+
+	setCustomFieldsErr := errors.New("SetCustomFields error")
+
+	customFields := models.CustomFieldsInput{Full: i.Input.CustomFields}
+
+	var noAliases []string
+	var noParents []int
+	readerWriter.On("UpdateAliases", testCtx, mock.Anything, noAliases).Return(nil)
+	readerWriter.On("UpdateParentTags", testCtx, mock.Anything, noParents).Return(nil)
+	readerWriter.On("SetCustomFields", testCtx, tagID, customFields).Return(nil).Once()
+	readerWriter.On("SetCustomFields", testCtx, errImageID, customFields).Return(setCustomFieldsErr).Once()
+
+	err := i.PostImport(testCtx, tagID)
+	assert.Nil(t, err)
+
+	err = i.PostImport(testCtx, errImageID)
+	assert.NotNil(t, err)
+
+	readerWriter.AssertExpectations(t)
+}
+
 func TestImporterPostImportParentMissing(t *testing.T) {
 	readerWriter := &mocks.TagReaderWriter{}
 
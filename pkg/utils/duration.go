@@ -0,0 +1,68 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+var (
+	durationClockRE = regexp.MustCompile(`^(?:(\d+):)?(\d+):(\d+)$`)
+	durationISORE   = regexp.MustCompile(`(?i)^P(?:(\d+)D)?T?(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?$`)
+	durationUnitRE  = regexp.MustCompile(`(?i)(\d+(?:\.\d+)?)\s*(hours?|hrs?|h|minutes?|mins?|m|seconds?|secs?|s)`)
+)
+
+// ParseDuration parses durations such as 90, 1:02:03, PT1H2M3S or 1h 2m 3s,
+// returning the duration in seconds.
+func ParseDuration(s string) (int, error) {
+	v := strings.TrimSpace(s)
+
+	if ret, err := strconv.Atoi(v); err == nil {
+		// already in seconds
+		return ret, nil
+	}
+
+	atof := func(s string) float64 {
+		f, _ := strconv.ParseFloat(s, 64)
+		return f
+	}
+
+	var seconds float64
+	switch {
+	case durationClockRE.MatchString(v):
+		m := durationClockRE.FindStringSubmatch(v)
+		seconds = atof(m[1])*3600 + atof(m[2])*60 + atof(m[3])
+	case len(v) > 1 && durationISORE.MatchString(v):
+		m := durationISORE.FindStringSubmatch(v)
+		seconds = atof(m[1])*86400 + atof(m[2])*3600 + atof(m[3])*60 + atof(m[4])
+	default:
+		found := false
+		for _, m := range durationUnitRE.FindAllStringSubmatchIndex(v, -1) {
+			// units may be directly followed by the next part, as in 1h2m3s,
+			// but not by other letters, as in 5 months
+			if m[1] < len(v) && unicode.IsLetter(rune(v[m[1]])) {
+				continue
+			}
+
+			found = true
+			n := atof(v[m[2]:m[3]])
+			switch unit := strings.ToLower(v[m[4]:m[5]]); {
+			case strings.HasPrefix(unit, "h"):
+				seconds += n * 3600
+			case strings.HasPrefix(unit, "m"):
+				seconds += n * 60
+			default:
+				seconds += n
+			}
+		}
+
+		if !found {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+	}
+
+	return int(math.Round(seconds)), nil
+}
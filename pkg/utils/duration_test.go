@@ -0,0 +1,40 @@
+package utils
+
+import "testing"
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    int
+		wantErr bool
+	}{
+		{"90", 90, false},
+		{" 5400 ", 5400, false},
+		{"12:34", 754, false},
+		{"1:02:03", 3723, false},
+		{"PT1H2M3S", 3723, false},
+		{"PT25M", 1500, false},
+		{"1h 2m 3s", 3723, false},
+		{"1h2m3s", 3723, false},
+		{"1h30m", 5400, false},
+		{"1hr30min", 5400, false},
+		{"5 months", 0, true},
+		{"1 hour 30 minutes", 5400, false},
+		{"45 min", 2700, false},
+		{"1.5 hrs", 5400, false},
+		{"unknown", 0, true},
+		{"P", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseDuration(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ParseDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	TwitterURL   = "https://twitter.com"
+	InstagramURL = "https://www.instagram.com"
+)
+
+var httpRE = regexp.MustCompile(`(?i)^https?://`)
+
+// URLFromHandle returns the url of the provided social media handle on the
+// site with the provided base url. The handle is returned unchanged if it is
+// already a url. Leading @ characters are removed from the handle.
+func URLFromHandle(handle string, siteURL string) string {
+	handle = strings.TrimSpace(handle)
+	if handle == "" || httpRE.MatchString(handle) {
+		return handle
+	}
+
+	return siteURL + "/" + strings.TrimPrefix(handle, "@")
+}
+
+// URLForSite returns the first of the provided urls that is hosted on the
+// site with the provided base url. Returns an empty string if none match.
+func URLForSite(urls []string, siteURL string) string {
+	host := strings.ToLower(httpRE.ReplaceAllString(siteURL, ""))
+	host = strings.TrimPrefix(host, "www.")
+
+	for _, u := range urls {
+		h := strings.ToLower(httpRE.ReplaceAllString(u, ""))
+		h = strings.TrimPrefix(h, "www.")
+		if strings.HasPrefix(h, host+"/") || h == host {
+			return u
+		}
+	}
+
+	return ""
+}
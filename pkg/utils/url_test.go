@@ -0,0 +1,49 @@
+package utils
+
+import "testing"
+
+func TestURLFromHandle(t *testing.T) {
+	tests := []struct {
+		name   string
+		handle string
+		want   string
+	}{
+		{"empty", "", ""},
+		{"handle", "foo", "https://twitter.com/foo"},
+		{"at handle", "@foo", "https://twitter.com/foo"},
+		{"url", "https://twitter.com/foo", "https://twitter.com/foo"},
+		{"http url", "HTTP://x.com/foo", "HTTP://x.com/foo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := URLFromHandle(tt.handle, TwitterURL); got != tt.want {
+				t.Errorf("URLFromHandle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestURLForSite(t *testing.T) {
+	urls := []string{
+		"https://example.com/foo",
+		"http://instagram.com/bar",
+		"https://twitter.com/baz",
+	}
+
+	tests := []struct {
+		name    string
+		siteURL string
+		want    string
+	}{
+		{"twitter", TwitterURL, "https://twitter.com/baz"},
+		{"instagram without www", InstagramURL, "http://instagram.com/bar"},
+		{"missing", "https://www.onlyfans.com", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := URLForSite(urls, tt.siteURL); got != tt.want {
+				t.Errorf("URLForSite() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,7 +12,7 @@ export const sceneFields = [
   "title",
   "date",
   "details",
-  "url",
+  "urls",
   "code",
   "director",
   "studio",
@@ -23,6 +23,7 @@ export const sceneFields = [
 export type SceneField = typeof sceneFields[number];
 
 export const multiValueSceneFields: SceneField[] = [
+  "urls",
   "studio",
   "performers",
   "tags",
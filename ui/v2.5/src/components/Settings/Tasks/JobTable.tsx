@@ -13,6 +13,7 @@ import {
   faCheck,
   faCircle,
   faCog,
+  faExclamationTriangle,
   faHourglassStart,
   faTimes,
 } from "@fortawesome/free-solid-svg-icons";
@@ -37,7 +38,8 @@ const Task: React.FC<IJob> = ({ job }) => {
   useEffect(() => {
     if (
       job.status === GQL.JobStatus.Cancelled ||
-      job.status === GQL.JobStatus.Finished
+      job.status === GQL.JobStatus.Finished ||
+      job.status === GQL.JobStatus.Failed
     ) {
       // fade out around 10 seconds
       setTimeout(() => {
@@ -71,6 +73,8 @@ const Task: React.FC<IJob> = ({ job }) => {
         return "finished";
       case GQL.JobStatus.Cancelled:
         return "cancelled";
+      case GQL.JobStatus.Failed:
+        return "failed";
     }
   }
 
@@ -95,6 +99,9 @@ const Task: React.FC<IJob> = ({ job }) => {
       case GQL.JobStatus.Cancelled:
         icon = faBan;
         break;
+      case GQL.JobStatus.Failed:
+        icon = faExclamationTriangle;
+        break;
     }
 
     return <Icon icon={icon} className={`fa-fw ${iconClass}`} />;
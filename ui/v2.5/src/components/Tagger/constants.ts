@@ -67,9 +67,7 @@ export const PERFORMER_FIELDS = [
   "career_length",
   "tattoos",
   "piercings",
-  "url",
-  "twitter",
-  "instagram",
+  "urls",
   "details",
   "death_date",
   "weight",